@@ -22,6 +22,7 @@ type Services struct {
 	BitbucketServerWebhook    http.Handler
 	NewCodeIntelUploadHandler NewCodeIntelUploadHandler
 	NewExecutorProxyHandler   NewExecutorProxyHandler
+	InsightsDataHandler       http.Handler
 	AuthzResolver             graphqlbackend.AuthzResolver
 	BatchChangesResolver      graphqlbackend.BatchChangesResolver
 	CodeIntelResolver         graphqlbackend.CodeIntelResolver
@@ -48,6 +49,7 @@ func DefaultServices() Services {
 		BitbucketServerWebhook:    makeNotFoundHandler("bitbucket server webhook"),
 		NewCodeIntelUploadHandler: func(_ bool) http.Handler { return makeNotFoundHandler("code intel upload") },
 		NewExecutorProxyHandler:   func() http.Handler { return makeNotFoundHandler("executor proxy") },
+		InsightsDataHandler:       makeNotFoundHandler("code insights data export"),
 	}
 }
 
@@ -136,6 +136,21 @@ type MoveBatchChangeArgs struct {
 	NewNamespace *graphql.ID
 }
 
+type TransferBatchChangeOwnershipArgs struct {
+	BatchChange graphql.ID
+	NewOwner    graphql.ID
+}
+
+type AddBatchChangeAdminArgs struct {
+	BatchChange graphql.ID
+	Admin       graphql.ID
+}
+
+type RemoveBatchChangeAdminArgs struct {
+	BatchChange graphql.ID
+	Admin       graphql.ID
+}
+
 type DeleteBatchChangeArgs struct {
 	BatchChange graphql.ID
 }
@@ -195,6 +210,18 @@ type DeleteBatchChangesCredentialArgs struct {
 	BatchChangesCredential graphql.ID
 }
 
+type CreateBatchChangesCommitSigningKeyArgs struct {
+	ExternalServiceKind string
+	ExternalServiceURL  string
+	Format              string
+	PrivateKey          string
+	Passphrase          *string
+}
+
+type DeleteBatchChangesCommitSigningKeyArgs struct {
+	CommitSigningKey graphql.ID
+}
+
 type ListBatchChangesCodeHostsArgs struct {
 	First  int32
 	After  *string
@@ -241,6 +268,10 @@ type CreateBatchSpecExecutionArgs struct {
 	Namespace *graphql.ID
 }
 
+type RetryBatchSpecWorkspaceArgs struct {
+	Workspace graphql.ID
+}
+
 type CloseChangesetsArgs struct {
 	BulkOperationBaseArgs
 }
@@ -250,6 +281,46 @@ type PublishChangesetsArgs struct {
 	Draft bool
 }
 
+type RerunChangesetChecksArgs struct {
+	BulkOperationBaseArgs
+}
+
+type UpdateChangesetBranchesArgs struct {
+	BulkOperationBaseArgs
+}
+
+type CreateBatchSpecTemplateArgs struct {
+	Namespace   *graphql.ID
+	Name        string
+	Description string
+	Template    string
+	Parameters  []BatchSpecTemplateParameterInput
+}
+
+type BatchSpecTemplateParameterInput struct {
+	Name         string
+	Type         string
+	Description  string
+	Required     bool
+	DefaultValue *JSONValue
+}
+
+type DeleteBatchSpecTemplateArgs struct {
+	BatchSpecTemplate graphql.ID
+}
+
+type InstantiateBatchSpecTemplateArgs struct {
+	BatchSpecTemplate graphql.ID
+	Namespace         *graphql.ID
+	Inputs            *JSONValue
+}
+
+type ListBatchSpecTemplatesArgs struct {
+	Namespace *graphql.ID
+	First     int32
+	After     *string
+}
+
 type BatchChangesResolver interface {
 	//
 	// MUTATIONS
@@ -270,8 +341,13 @@ type BatchChangesResolver interface {
 	CloseBatchChange(ctx context.Context, args *CloseBatchChangeArgs) (BatchChangeResolver, error)
 	MoveBatchChange(ctx context.Context, args *MoveBatchChangeArgs) (BatchChangeResolver, error)
 	DeleteBatchChange(ctx context.Context, args *DeleteBatchChangeArgs) (*EmptyResponse, error)
+	TransferBatchChangeOwnership(ctx context.Context, args *TransferBatchChangeOwnershipArgs) (BatchChangeResolver, error)
+	AddBatchChangeAdmin(ctx context.Context, args *AddBatchChangeAdminArgs) (BatchChangeResolver, error)
+	RemoveBatchChangeAdmin(ctx context.Context, args *RemoveBatchChangeAdminArgs) (BatchChangeResolver, error)
 	CreateBatchChangesCredential(ctx context.Context, args *CreateBatchChangesCredentialArgs) (BatchChangesCredentialResolver, error)
 	DeleteBatchChangesCredential(ctx context.Context, args *DeleteBatchChangesCredentialArgs) (*EmptyResponse, error)
+	CreateBatchChangesCommitSigningKey(ctx context.Context, args *CreateBatchChangesCommitSigningKeyArgs) (BatchChangesCommitSigningKeyResolver, error)
+	DeleteBatchChangesCommitSigningKey(ctx context.Context, args *DeleteBatchChangesCommitSigningKeyArgs) (*EmptyResponse, error)
 
 	CreateChangesetSpec(ctx context.Context, args *CreateChangesetSpecArgs) (ChangesetSpecResolver, error)
 	SyncChangeset(ctx context.Context, args *SyncChangesetArgs) (*EmptyResponse, error)
@@ -281,8 +357,14 @@ type BatchChangesResolver interface {
 	ReenqueueChangesets(ctx context.Context, args *ReenqueueChangesetsArgs) (BulkOperationResolver, error)
 	MergeChangesets(ctx context.Context, args *MergeChangesetsArgs) (BulkOperationResolver, error)
 	CreateBatchSpecExecution(ctx context.Context, args *CreateBatchSpecExecutionArgs) (BatchSpecExecutionResolver, error)
+	RetryBatchSpecWorkspace(ctx context.Context, args *RetryBatchSpecWorkspaceArgs) (BatchSpecWorkspaceResolver, error)
 	CloseChangesets(ctx context.Context, args *CloseChangesetsArgs) (BulkOperationResolver, error)
 	PublishChangesets(ctx context.Context, args *PublishChangesetsArgs) (BulkOperationResolver, error)
+	RerunChangesetChecks(ctx context.Context, args *RerunChangesetChecksArgs) (BulkOperationResolver, error)
+	UpdateChangesetBranches(ctx context.Context, args *UpdateChangesetBranchesArgs) (BulkOperationResolver, error)
+	CreateBatchSpecTemplate(ctx context.Context, args *CreateBatchSpecTemplateArgs) (BatchSpecTemplateResolver, error)
+	DeleteBatchSpecTemplate(ctx context.Context, args *DeleteBatchSpecTemplateArgs) (*EmptyResponse, error)
+	InstantiateBatchSpecTemplate(ctx context.Context, args *InstantiateBatchSpecTemplateArgs) (BatchSpecResolver, error)
 
 	// Queries
 
@@ -297,6 +379,8 @@ type BatchChangesResolver interface {
 	BatchChangesCodeHosts(ctx context.Context, args *ListBatchChangesCodeHostsArgs) (BatchChangesCodeHostConnectionResolver, error)
 	RepoChangesetsStats(ctx context.Context, repo *graphql.ID) (RepoChangesetsStatsResolver, error)
 	RepoDiffStat(ctx context.Context, repo *graphql.ID) (*DiffStat, error)
+	BatchChangesReport(ctx context.Context, args *BatchChangesReportArgs) (BatchChangesReportResolver, error)
+	BatchSpecTemplates(ctx context.Context, args *ListBatchSpecTemplatesArgs) (BatchSpecTemplateConnectionResolver, error)
 
 	NodeResolvers() map[string]NodeByIDFunc
 }
@@ -532,6 +616,44 @@ type BatchChangesCodeHostResolver interface {
 	ExternalServiceURL() string
 	RequiresSSH() bool
 	Credential() BatchChangesCredentialResolver
+	CommitSigningKey(ctx context.Context) (BatchChangesCommitSigningKeyResolver, error)
+}
+
+type BatchChangesCommitSigningKeyResolver interface {
+	ID() graphql.ID
+	ExternalServiceKind() string
+	ExternalServiceURL() string
+	// Format returns a value of type signing.Format, in upper case.
+	Format() string
+	Fingerprint() string
+	CreatedAt() DateTime
+}
+
+type BatchSpecTemplateConnectionResolver interface {
+	Nodes(ctx context.Context) ([]BatchSpecTemplateResolver, error)
+	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
+}
+
+type BatchSpecTemplateResolver interface {
+	ID() graphql.ID
+	Name() string
+	Description() string
+	Namespace(ctx context.Context) (*OrgResolver, error)
+	Template() string
+	Parameters() []BatchSpecTemplateParameterResolver
+	Creator(ctx context.Context) (*UserResolver, error)
+	CreatedAt() DateTime
+	ViewerCanAdminister(ctx context.Context) (bool, error)
+}
+
+type BatchSpecTemplateParameterResolver interface {
+	Name() string
+	// Type returns a value of type btypes.BatchSpecTemplateParameterType, in
+	// upper case.
+	Type() string
+	Description() string
+	Required() bool
+	DefaultValue() *JSONValue
 }
 
 type BatchChangesCredentialResolver interface {
@@ -543,6 +665,13 @@ type BatchChangesCredentialResolver interface {
 	IsSiteCredential() bool
 }
 
+type BatchChangesReportArgs struct {
+	Namespace      *graphql.ID
+	From           *DateTime
+	To             *DateTime
+	StuckAfterDays int32
+}
+
 type ChangesetCountsArgs struct {
 	From            *DateTime
 	To              *DateTime
@@ -581,6 +710,8 @@ type BatchChangeResolver interface {
 	InitialApplier(ctx context.Context) (*UserResolver, error)
 	LastApplier(ctx context.Context) (*UserResolver, error)
 	LastAppliedAt() DateTime
+	Owner(ctx context.Context) (*UserResolver, error)
+	Admins(ctx context.Context) ([]NamespaceResolver, error)
 	SpecCreator(ctx context.Context) (*UserResolver, error)
 	ViewerCanAdminister(ctx context.Context) (bool, error)
 	URL(ctx context.Context) (string, error)
@@ -691,6 +822,8 @@ type ExternalChangesetResolver interface {
 	ReviewState(context.Context) *string
 	// CheckState returns a value of type *btypes.ChangesetCheckState.
 	CheckState() *string
+	Checks() []ChangesetCheckResolver
+	CommitSignature() ChangesetCommitSignatureResolver
 	Repository(ctx context.Context) *RepositoryResolver
 
 	Events(ctx context.Context, args *ChangesetEventsConnectionArgs) (ChangesetEventsConnectionResolver, error)
@@ -705,6 +838,21 @@ type ExternalChangesetResolver interface {
 	CurrentSpec(ctx context.Context) (VisibleChangesetSpecResolver, error)
 }
 
+type ChangesetCheckResolver interface {
+	Name() string
+	// State returns a value of type btypes.ChangesetCheckState.
+	State() string
+	URL() *string
+	FinishedAt() *DateTime
+	Rerunnable() bool
+}
+
+type ChangesetCommitSignatureResolver interface {
+	// Format returns a value of type signing.Format, in upper case.
+	Format() string
+	Fingerprint() string
+}
+
 type ChangesetEventsConnectionResolver interface {
 	Nodes(ctx context.Context) ([]ChangesetEventResolver, error)
 	TotalCount(ctx context.Context) (int32, error)
@@ -729,15 +877,54 @@ type ChangesetCountsResolver interface {
 	OpenPending() int32
 }
 
+type BatchChangesReportResolver interface {
+	TotalCount() int32
+	TimeToMerge() ChangesetTimeToMergeDistributionResolver
+	ByCodeHost() []ChangesetReportGroupResolver
+	ByNamespace() []ChangesetReportGroupResolver
+	StuckChangesets() []ChangesetReportEntryResolver
+	CSV() (string, error)
+}
+
+type ChangesetTimeToMergeDistributionResolver interface {
+	MergedCount() int32
+	MeanSeconds() *int32
+	MedianSeconds() *int32
+	P90Seconds() *int32
+	Buckets() []ChangesetTimeToMergeBucketResolver
+}
+
+type ChangesetTimeToMergeBucketResolver interface {
+	UpperBoundSeconds() *int32
+	Count() int32
+}
+
+type ChangesetReportGroupResolver interface {
+	Name() string
+	Total() int32
+	Open() int32
+	Draft() int32
+	Merged() int32
+	Closed() int32
+}
+
+type ChangesetReportEntryResolver interface {
+	BatchChange() BatchChangeResolver
+	Changeset() ChangesetResolver
+	OpenedAt() DateTime
+	OpenForSeconds() int32
+}
+
 type BatchSpecExecutionResolver interface {
 	ID() graphql.ID
 	InputSpec() string
-	State() string
+	State(ctx context.Context) (string, error)
 	CreatedAt() DateTime
 	StartedAt() *DateTime
 	FinishedAt() *DateTime
 	Failure() *string
 	Steps() BatchSpecExecutionStepsResolver
+	Workspaces(ctx context.Context) ([]BatchSpecWorkspaceResolver, error)
 	PlaceInQueue() *int32
 	BatchSpec(ctx context.Context) (BatchSpecResolver, error)
 	Initiator(ctx context.Context) (*UserResolver, error)
@@ -749,3 +936,16 @@ type BatchSpecExecutionStepsResolver interface {
 	SrcPreview() ExecutionLogEntryResolver
 	Teardown() []ExecutionLogEntryResolver
 }
+
+type BatchSpecWorkspaceResolver interface {
+	ID() graphql.ID
+	Repository(ctx context.Context) (*RepositoryResolver, error)
+	Branch() string
+	Commit() string
+	State() string
+	StartedAt() *DateTime
+	FinishedAt() *DateTime
+	Failure() *string
+	ExecutionLogs() []ExecutionLogEntryResolver
+	ChangesetSpecs(ctx context.Context) ([]ChangesetSpecResolver, error)
+}
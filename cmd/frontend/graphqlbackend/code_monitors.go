@@ -20,6 +20,7 @@ type CodeMonitorsResolver interface {
 	UpdateCodeMonitor(ctx context.Context, args *UpdateCodeMonitorArgs) (MonitorResolver, error)
 	ResetTriggerQueryTimestamps(ctx context.Context, args *ResetTriggerQueryTimestampsArgs) (*EmptyResponse, error)
 	TriggerTestEmailAction(ctx context.Context, args *TriggerTestEmailActionArgs) (*EmptyResponse, error)
+	TestCodeMonitor(ctx context.Context, args *TestCodeMonitorArgs) (MonitorTestRunResolver, error)
 
 	NodeResolvers() map[string]NodeByIDFunc
 }
@@ -80,6 +81,7 @@ type MonitorEmailResolver interface {
 	Enabled() bool
 	Priority() string
 	Header() string
+	Digest() string
 	Recipients(ctx context.Context, args *ListRecipientsArgs) (MonitorActionEmailRecipientsConnectionResolver, error)
 	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
 }
@@ -146,6 +148,7 @@ type CreateActionEmailArgs struct {
 	Priority   string
 	Recipients []graphql.ID
 	Header     string
+	Digest     *string
 }
 
 type ToggleCodeMonitorArgs struct {
@@ -161,6 +164,19 @@ type ResetTriggerQueryTimestampsArgs struct {
 	Id graphql.ID
 }
 
+type TestCodeMonitorArgs struct {
+	Monitor           *CreateMonitorArgs
+	Trigger           *CreateTriggerArgs
+	Actions           []*CreateActionArgs
+	Days              int32
+	SendNotifications bool
+}
+
+type MonitorTestRunResolver interface {
+	Query() string
+	Results() []*CommitSearchResultResolver
+}
+
 type TriggerTestEmailActionArgs struct {
 	Namespace   graphql.ID
 	Description string
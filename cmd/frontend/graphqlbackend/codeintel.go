@@ -21,9 +21,11 @@ type CodeIntelResolver interface {
 	DeleteLSIFIndex(ctx context.Context, args *struct{ ID graphql.ID }) (*EmptyResponse, error)
 	IndexConfiguration(ctx context.Context, id graphql.ID) (IndexConfigurationResolver, error) // TODO - rename ...ForRepo
 	UpdateRepositoryIndexConfiguration(ctx context.Context, args *UpdateRepositoryIndexConfigurationArgs) (*EmptyResponse, error)
+	UpdateRepositoryIndexOnPush(ctx context.Context, args *UpdateRepositoryIndexOnPushArgs) (*EmptyResponse, error)
 	CommitGraph(ctx context.Context, id graphql.ID) (CodeIntelligenceCommitGraphResolver, error)
 	QueueAutoIndexJobForRepo(ctx context.Context, args *QueueAutoIndexJobForRepoArgs) (*EmptyResponse, error)
 	GitBlobLSIFData(ctx context.Context, args *GitBlobLSIFDataArgs) (GitBlobLSIFDataResolver, error)
+	GitBlobSearchBasedCodeIntel(ctx context.Context, args *GitBlobLSIFDataArgs) (SearchBasedCodeIntelResolver, error)
 
 	NodeResolvers() map[string]NodeByIDFunc
 }
@@ -120,6 +122,37 @@ type LSIFIndexConnectionResolver interface {
 
 type IndexConfigurationResolver interface {
 	Configuration() *string
+	IndexOnPush() bool
+	InferredConfiguration(ctx context.Context, args *InferredIndexConfigurationArgs) (InferredIndexConfigurationResolver, error)
+	IndexJobsPreview(ctx context.Context, args *IndexJobsPreviewArgs) (IndexJobsPreviewResolver, error)
+}
+
+type InferredIndexConfigurationArgs struct {
+	Rev    *string
+	Format string
+}
+
+type InferredIndexConfigurationResolver interface {
+	Commit() string
+	Format() string
+	Configuration() *string
+}
+
+type IndexJobsPreviewArgs struct {
+	Rev *string
+}
+
+type IndexJobsPreviewResolver interface {
+	Commit() string
+	ConfigurationSource() *string
+	Jobs() []IndexJobPreviewResolver
+}
+
+type IndexJobPreviewResolver interface {
+	Root() string
+	Indexer() string
+	Steps() IndexStepsResolver
+	Deduplicated() bool
 }
 
 type UpdateRepositoryIndexConfigurationArgs struct {
@@ -127,6 +160,11 @@ type UpdateRepositoryIndexConfigurationArgs struct {
 	Configuration string
 }
 
+type UpdateRepositoryIndexOnPushArgs struct {
+	Repository graphql.ID
+	Enabled    bool
+}
+
 type QueueAutoIndexJobForRepoArgs struct {
 	Repository graphql.ID
 	Rev        *string
@@ -159,6 +197,12 @@ type GitBlobLSIFDataResolver interface {
 	References(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
 	Hover(ctx context.Context, args *LSIFQueryPositionArgs) (HoverResolver, error)
 	Documentation(ctx context.Context, args *LSIFQueryPositionArgs) (DocumentationResolver, error)
+	Confidence() string
+}
+
+type SearchBasedCodeIntelResolver interface {
+	Definitions(ctx context.Context, args *LSIFQueryPositionArgs) (LocationConnectionResolver, error)
+	References(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
 }
 
 type GitBlobLSIFDataArgs struct {
@@ -214,6 +258,7 @@ type CodeIntelligenceRangeResolver interface {
 type LocationConnectionResolver interface {
 	Nodes(ctx context.Context) ([]LocationResolver, error)
 	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
+	Precise() bool
 }
 
 type HoverResolver interface {
@@ -239,6 +239,20 @@ func (r *GitTreeEntryResolver) LSIF(ctx context.Context, args *struct{ ToolName
 	})
 }
 
+func (r *GitTreeEntryResolver) SearchBasedCodeIntel(ctx context.Context) (SearchBasedCodeIntelResolver, error) {
+	repo, err := r.commit.repoResolver.repo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return EnterpriseResolvers.codeIntelResolver.GitBlobSearchBasedCodeIntel(ctx, &GitBlobLSIFDataArgs{
+		Repo:      repo,
+		Commit:    api.CommitID(r.Commit().OID()),
+		Path:      r.Path(),
+		ExactPath: !r.stat.IsDir(),
+	})
+}
+
 type fileInfo struct {
 	path  string
 	size  int64
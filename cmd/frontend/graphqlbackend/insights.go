@@ -15,6 +15,13 @@ import (
 // InsightsResolver is the root resolver.
 type InsightsResolver interface {
 	Insights(ctx context.Context, args *InsightsArgs) (InsightConnectionResolver, error)
+	InsightSeriesAlerts(ctx context.Context, args *InsightSeriesAlertsArgs) ([]InsightSeriesAlertResolver, error)
+
+	CreateInsightSeriesAlert(ctx context.Context, args *CreateInsightSeriesAlertArgs) (InsightSeriesAlertResolver, error)
+	DeleteInsightSeriesAlert(ctx context.Context, args *DeleteInsightSeriesAlertArgs) (*EmptyResponse, error)
+	PauseInsightSeriesBackfill(ctx context.Context, args *InsightSeriesBackfillArgs) (InsightSeriesBackfillResolver, error)
+	ResumeInsightSeriesBackfill(ctx context.Context, args *InsightSeriesBackfillArgs) (InsightSeriesBackfillResolver, error)
+	CancelInsightSeriesBackfill(ctx context.Context, args *InsightSeriesBackfillArgs) (InsightSeriesBackfillResolver, error)
 }
 
 type InsightsArgs struct {
@@ -33,6 +40,21 @@ type InsightStatusResolver interface {
 	FailedJobs() int32
 }
 
+type InsightSeriesBackfillArgs struct {
+	SeriesID string
+}
+
+type InsightSeriesBackfillResolver interface {
+	SeriesID() string
+	State() string
+	QueuedJobs() int32
+	ProcessingJobs() int32
+	CompletedJobs() int32
+	FailedJobs() int32
+	PausedJobs() int32
+	EstimatedCompletion() *DateTime
+}
+
 type InsightsPointsArgs struct {
 	From             *DateTime
 	To               *DateTime
@@ -40,16 +62,80 @@ type InsightsPointsArgs struct {
 	ExcludeRepoRegex *string
 }
 
+type InsightsRepositoryBreakdownArgs struct {
+	DateTime         DateTime
+	First            int32
+	IncludeRepoRegex *string
+	ExcludeRepoRegex *string
+}
+
+type InsightRepositoryDataPointResolver interface {
+	RepositoryName() string
+	DateTime() DateTime
+	Value() float64
+}
+
 type InsightSeriesResolver interface {
+	SeriesID() string
 	Label() string
 	Points(ctx context.Context, args *InsightsPointsArgs) ([]InsightsDataPointResolver, error)
+	RepositoryBreakdown(ctx context.Context, args *InsightsRepositoryBreakdownArgs) ([]InsightRepositoryDataPointResolver, error)
 	Status(ctx context.Context) (InsightStatusResolver, error)
+	Backfill(ctx context.Context) (InsightSeriesBackfillResolver, error)
+}
+
+type InsightSeriesAlertsArgs struct {
+	SeriesID *string
+}
+
+type CreateInsightSeriesAlertArgs struct {
+	Input CreateInsightSeriesAlertInput
+}
+
+type CreateInsightSeriesAlertInput struct {
+	SeriesID   string
+	Capture    *string
+	Condition  string
+	Threshold  float64
+	WindowDays *int32
+	Email      *bool
+	WebhookURL *string
+}
+
+type DeleteInsightSeriesAlertArgs struct {
+	ID graphql.ID
+}
+
+type InsightSeriesAlertResolver interface {
+	ID() graphql.ID
+	SeriesID() string
+	Capture() *string
+	Condition() string
+	Threshold() float64
+	WindowDays() int32
+	Email() bool
+	WebhookURL() *string
+	Triggered() bool
+	LastEvaluatedAt() *DateTime
+	History(ctx context.Context, args *InsightSeriesAlertHistoryArgs) ([]InsightSeriesAlertEventResolver, error)
+}
+
+type InsightSeriesAlertHistoryArgs struct {
+	First int32
+}
+
+type InsightSeriesAlertEventResolver interface {
+	TriggeredAt() DateTime
+	Value() float64
+	PreviousValue() *float64
+	EmailError() *string
+	WebhookError() *string
 }
 
 type InsightResolver interface {
 	Title() string
 	Description() string
-	Series() []InsightSeriesResolver
+	Series(ctx context.Context) ([]InsightSeriesResolver, error)
 	ID() string
 }
 
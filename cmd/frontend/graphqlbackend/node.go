@@ -253,3 +253,8 @@ func (r *NodeResolver) ToBatchSpecExecution() (BatchSpecExecutionResolver, bool)
 	n, ok := r.Node.(BatchSpecExecutionResolver)
 	return n, ok
 }
+
+func (r *NodeResolver) ToBatchSpecTemplate() (BatchSpecTemplateResolver, bool) {
+	n, ok := r.Node.(BatchSpecTemplateResolver)
+	return n, ok
+}
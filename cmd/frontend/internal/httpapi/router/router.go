@@ -11,6 +11,9 @@ const (
 	LSIFUpload = "lsif.upload"
 	GraphQL    = "graphql"
 
+	InsightsExport = "insights.export"
+	InsightsImport = "insights.import"
+
 	SearchStream = "search.stream"
 
 	SrcCliVersion  = "src-cli.version"
@@ -72,6 +75,8 @@ func New(base *mux.Router) *mux.Router {
 	base.Path("/gitlab-webhooks").Methods("POST").Name(GitLabWebhooks)
 	base.Path("/bitbucket-server-webhooks").Methods("POST").Name(BitbucketServerWebhooks)
 	base.Path("/lsif/upload").Methods("POST").Name(LSIFUpload)
+	base.Path("/insights/export").Methods("GET").Name(InsightsExport)
+	base.Path("/insights/import").Methods("POST").Name(InsightsImport)
 	base.Path("/search/stream").Methods("GET").Name(SearchStream)
 	base.Path("/src-cli/version").Methods("GET").Name(SrcCliVersion)
 	base.Path("/src-cli/{rest:.*}").Methods("GET").Name(SrcCliDownload)
@@ -0,0 +1,40 @@
+package webhookhandlers
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	gh "github.com/google/go-github/v28/github"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// handleGitHubPushEvent converts a github push event into a code host agnostic push event
+// and dispatches it to the registered push handlers.
+func handleGitHubPushEvent(ctx context.Context, extSvc *types.ExternalService, payload interface{}) error {
+	e, ok := payload.(*gh.PushEvent)
+	if !ok {
+		return errors.Errorf("incorrect event type sent to github push handler: %T", payload)
+	}
+	if e.GetDeleted() || e.GetRepo() == nil {
+		return nil
+	}
+
+	serviceID, err := webhooks.ExtractExternalServiceID(extSvc)
+	if err != nil {
+		return err
+	}
+
+	return webhooks.DispatchPush(ctx, webhooks.PushEvent{
+		Repo: api.ExternalRepoSpec{
+			ID:          e.GetRepo().GetNodeID(),
+			ServiceType: extsvc.TypeGitHub,
+			ServiceID:   serviceID,
+		},
+		Ref:    e.GetRef(),
+		Commit: e.GetAfter(),
+	})
+}
@@ -14,4 +14,6 @@ func Init(db dbutil.DB, w *webhooks.GitHubWebhook) {
 	w.Register(handleGitHubUserAuthzEvent(db), "organisation")
 	w.Register(handleGitHubUserAuthzEvent(db), "member") // member has both users and repos
 	w.Register(handleGitHubUserAuthzEvent(db), "membership")
+
+	w.Register(handleGitHubPushEvent, "push")
 }
@@ -0,0 +1,41 @@
+package webhooks
+
+import (
+	"net/url"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// ExtractExternalServiceID returns the normalized base URL of the given GitHub, GitLab, or
+// Bitbucket Server external service, which is used as the service ID of the repositories it
+// syncs.
+func ExtractExternalServiceID(extSvc *types.ExternalService) (string, error) {
+	c, err := extSvc.Configuration()
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to get external service config")
+	}
+
+	var serviceID string
+	switch c := c.(type) {
+	case *schema.GitHubConnection:
+		serviceID = c.Url
+	case *schema.BitbucketServerConnection:
+		serviceID = c.Url
+	case *schema.GitLabConnection:
+		serviceID = c.Url
+	}
+	if serviceID == "" {
+		return "", errors.New("could not determine service id")
+	}
+
+	u, err := url.Parse(serviceID)
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to parse service ID")
+	}
+
+	return extsvc.NormalizeBaseURL(u).String(), nil
+}
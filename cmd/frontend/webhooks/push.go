@@ -0,0 +1,55 @@
+package webhooks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// PushEvent is a code host agnostic description of a push to a single branch of a
+// repository, received through a code host webhook.
+type PushEvent struct {
+	// Repo identifies the repository on the code host.
+	Repo api.ExternalRepoSpec
+
+	// Ref is the fully qualified name of the updated branch, e.g. refs/heads/main.
+	Ref string
+
+	// Commit is the new tip of the updated branch.
+	Commit string
+}
+
+// PushHandler handles a push event received from any code host.
+type PushHandler func(ctx context.Context, event PushEvent) error
+
+var (
+	pushHandlersMu sync.RWMutex
+	pushHandlers   []PushHandler
+)
+
+// RegisterPushHandler registers a handler that is invoked for each push to a branch
+// received by the GitHub, GitLab, and Bitbucket Server webhook handlers.
+func RegisterPushHandler(handler PushHandler) {
+	pushHandlersMu.Lock()
+	defer pushHandlersMu.Unlock()
+	pushHandlers = append(pushHandlers, handler)
+}
+
+// DispatchPush invokes every registered push handler with the given event. Deleted
+// branches should not be dispatched.
+func DispatchPush(ctx context.Context, event PushEvent) error {
+	pushHandlersMu.RLock()
+	defer pushHandlersMu.RUnlock()
+
+	var errs *multierror.Error
+	for _, handler := range pushHandlers {
+		if err := handler(ctx, event); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
@@ -0,0 +1,41 @@
+package webhooks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+func TestDispatchPush(t *testing.T) {
+	var received []PushEvent
+	RegisterPushHandler(func(ctx context.Context, event PushEvent) error {
+		received = append(received, event)
+		return nil
+	})
+	RegisterPushHandler(func(ctx context.Context, event PushEvent) error {
+		return errors.New("oops")
+	})
+
+	event := PushEvent{
+		Repo: api.ExternalRepoSpec{
+			ID:          "MDEwOlJlcG9zaXRvcnk0MTI4ODcwOA==",
+			ServiceType: extsvc.TypeGitHub,
+			ServiceID:   "https://github.com/",
+		},
+		Ref:    "refs/heads/main",
+		Commit: "deadbeef01deadbeef02deadbeef03deadbeef04",
+	}
+
+	if err := DispatchPush(context.Background(), event); err == nil {
+		t.Fatalf("expected an error")
+	}
+
+	if diff := cmp.Diff([]PushEvent{event}, received); diff != "" {
+		t.Errorf("unexpected events (-want +got):\n%s", diff)
+	}
+}
@@ -158,6 +158,29 @@ func main() {
 				}
 
 				return &server.JVMPackagesSyncer{Config: &c}, nil
+			case extsvc.TypeGoModules:
+				var c schema.GoModulesConnection
+				for _, info := range r.Sources {
+					es, err := externalServiceStore.GetByID(ctx, info.ExternalServiceID())
+					if err != nil {
+						return nil, errors.Wrap(err, "get external service")
+					}
+
+					normalized, err := jsonc.Parse(es.Config)
+					if err != nil {
+						return nil, errors.Wrap(err, "normalize JSON")
+					}
+
+					if err = jsoniter.Unmarshal(normalized, &c); err != nil {
+						return nil, errors.Wrap(err, "unmarshal JSON")
+					}
+					break
+				}
+
+				return &server.GoModulesSyncer{
+					Config:    &c,
+					DepsStore: repos.NewDependencyReposStore(db),
+				}, nil
 			}
 			return &server.GitRepoSyncer{}, nil
 		},
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -18,6 +19,7 @@ import (
 	"github.com/inconshreveable/log15"
 
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/signing"
 	"github.com/sourcegraph/sourcegraph/internal/vcs"
 )
 
@@ -222,6 +224,17 @@ func (s *Server) createCommitFromPatch(ctx context.Context, req protocol.CreateC
 	}
 	cmtHash := strings.TrimSpace(string(out))
 
+	if req.Signing != nil {
+		signedHash, err := signCommit(ctx, cmtHash, req.Signing, tmpRepoDir, tmpGitPathEnv, altObjectsEnv)
+		if err != nil {
+			// The error never contains the key, so it's safe to log and return.
+			log15.Error("Failed to sign commit.", "ref", ref, "commit", cmtHash, "err", err)
+			resp.SetError(repo, "", "", errors.Wrap(err, "gitserver: signing commit"))
+			return http.StatusInternalServerError, resp
+		}
+		cmtHash = signedHash
+	}
+
 	// Move objects from tmpObjectsDir to repoObjectsDir.
 	err = filepath.Walk(tmpObjectsDir, func(path string, info fs.FileInfo, err error) error {
 		if err != nil {
@@ -298,6 +311,39 @@ func (s *Server) createCommitFromPatch(ctx context.Context, req protocol.CreateC
 	return http.StatusOK, resp
 }
 
+// signCommit signs the commit with the given key and writes the signed commit
+// object to the repository. It returns the ID of the signed commit, which
+// replaces the unsigned commit.
+func signCommit(ctx context.Context, commitID string, conf *protocol.CommitSigningConfig, dir string, env ...string) (string, error) {
+	signer, err := signing.NewSigner(signing.Format(conf.Format), conf.PrivateKey, conf.Passphrase)
+	if err != nil {
+		return "", err
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "cat-file", "commit", commitID)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	commit, err := cmd.Output()
+	if err != nil {
+		return "", errors.Wrap(err, "reading commit")
+	}
+
+	signed, err := signing.SignCommit(commit, signer)
+	if err != nil {
+		return "", err
+	}
+
+	cmd = exec.CommandContext(ctx, "git", "hash-object", "-t", "commit", "-w", "--stdin")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = bytes.NewReader(signed)
+	out, err := cmd.Output()
+	if err != nil {
+		return "", errors.Wrap(err, "writing signed commit")
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
 func cleanUpTmpRepo(path string) {
 	err := os.RemoveAll(path)
 	if err != nil {
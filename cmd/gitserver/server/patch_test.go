@@ -0,0 +1,57 @@
+package server
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+)
+
+func TestSignCommit(t *testing.T) {
+	dir := t.TempDir()
+	cmd := func(name string, arg ...string) string {
+		t.Helper()
+		return runCmd(t, dir, name, arg...)
+	}
+	commitID := strings.TrimSpace(makeSingleCommitRepo(cmd))
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf := &protocol.CommitSigningConfig{
+		Format:     "ssh",
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+	}
+
+	signedID, err := signCommit(context.Background(), commitID, conf, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signedID == commitID {
+		t.Fatal("signed commit has the same ID as the unsigned commit")
+	}
+
+	signed := cmd("git", "cat-file", "commit", signedID)
+	if !strings.Contains(signed, "\ngpgsig -----BEGIN SSH SIGNATURE-----\n") {
+		t.Fatalf("commit not signed:\n%s", signed)
+	}
+	if have, want := cmd("git", "rev-parse", signedID+"^{tree}"), cmd("git", "rev-parse", commitID+"^{tree}"); have != want {
+		t.Fatalf("wrong tree. want=%s, have=%s", want, have)
+	}
+
+	t.Run("invalid key", func(t *testing.T) {
+		if _, err := signCommit(context.Background(), commitID, &protocol.CommitSigningConfig{Format: "ssh", PrivateKey: "nope"}, dir); err == nil {
+			t.Fatal("no error returned for invalid key")
+		}
+	})
+}
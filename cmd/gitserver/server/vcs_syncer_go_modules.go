@@ -0,0 +1,352 @@
+package server
+
+import (
+	"archive/zip"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gomodproxy"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// goModulesScheme is the moniker scheme of Go packages recorded in the
+// lsif_dependency_repos table.
+const goModulesScheme = "gomod"
+
+// GoModulesDependencyReposStore lists the Go module versions referenced by precise
+// code intelligence indexes.
+type GoModulesDependencyReposStore interface {
+	GetDependencyRepos(ctx context.Context, opts dbstore.GetDependencyReposOptions) ([]dbstore.DependencyRepo, error)
+}
+
+// GoModulesSyncer creates git repositories from the source archives of Go modules
+// served by a Go module proxy. Each module version becomes a git tag.
+type GoModulesSyncer struct {
+	Config *schema.GoModulesConnection
+
+	// DepsStore, if set, supplies the versions referenced by precise code
+	// intelligence indexes in addition to those listed in Config.
+	DepsStore GoModulesDependencyReposStore
+
+	// Client defaults to a client for the proxies listed in Config.
+	Client *gomodproxy.Client
+}
+
+var _ VCSSyncer = &GoModulesSyncer{}
+
+func (s *GoModulesSyncer) Type() string {
+	return "go_modules"
+}
+
+// IsCloneable checks to see if the VCS remote URL is cloneable. Any non-nil
+// error indicates there is a problem.
+func (s *GoModulesSyncer) IsCloneable(ctx context.Context, remoteURL *vcs.URL) error {
+	dependencies, err := s.moduleDependencies(ctx, remoteURL.Path)
+	if err != nil {
+		return err
+	}
+
+	for _, dependency := range dependencies {
+		if _, err := s.client().GoMod(ctx, dependency); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CloneCommand returns the command to be executed for cloning from remote.
+// Like for JVM packages, the actual cloning happens inside this method and the
+// returned command is a no-op.
+func (s *GoModulesSyncer) CloneCommand(ctx context.Context, remoteURL *vcs.URL, bareGitDirectory string) (*exec.Cmd, error) {
+	err := os.MkdirAll(bareGitDirectory, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "--bare", "init")
+	if _, err := runCommandInDirectory(ctx, cmd, bareGitDirectory); err != nil {
+		return nil, err
+	}
+
+	// The Fetch method is responsible for cleaning up temporary directories.
+	if err := s.Fetch(ctx, remoteURL, GitDir(bareGitDirectory)); err != nil {
+		return nil, err
+	}
+
+	// no-op command to satisfy VCSSyncer interface, see docstring for more details.
+	return exec.CommandContext(ctx, "git", "--version"), nil
+}
+
+// Fetch adds git tags for newly added module versions and removes git tags
+// for deleted versions.
+func (s *GoModulesSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, dir GitDir) error {
+	dependencies, err := s.moduleDependencies(ctx, remoteURL.Path)
+	if err != nil {
+		return err
+	}
+
+	tags := map[string]bool{}
+
+	out, err := runCommandInDirectory(ctx, exec.CommandContext(ctx, "git", "tag"), string(dir))
+	if err != nil {
+		return err
+	}
+
+	for _, line := range strings.Split(out, "\n") {
+		if len(line) == 0 {
+			continue
+		}
+		tags[line] = true
+	}
+
+	for i, dependency := range dependencies {
+		if tags[dependency.GitTagFromVersion()] {
+			continue
+		}
+		// the gitPushDependencyTag method is reponsible for cleaning up temporary directories.
+		if err := s.gitPushDependencyTag(ctx, string(dir), dependency, i == 0); err != nil {
+			return errors.Wrapf(err, "error pushing dependency %q", dependency.String())
+		}
+	}
+
+	dependencyTags := make(map[string]struct{}, len(dependencies))
+	for _, dependency := range dependencies {
+		dependencyTags[dependency.GitTagFromVersion()] = struct{}{}
+	}
+
+	for tag := range tags {
+		if _, isDependencyTag := dependencyTags[tag]; !isDependencyTag {
+			cmd := exec.CommandContext(ctx, "git", "tag", "-d", tag)
+			if _, err := runCommandInDirectory(ctx, cmd, string(dir)); err != nil {
+				log15.Error("Failed to delete git tag", "error", err, "tag", tag)
+				continue
+			}
+		}
+	}
+
+	return nil
+}
+
+// RemoteShowCommand returns the command to be executed for showing remote.
+func (s *GoModulesSyncer) RemoteShowCommand(ctx context.Context, remoteURL *vcs.URL) (cmd *exec.Cmd, err error) {
+	return exec.CommandContext(ctx, "git", "remote", "show", "./"), nil
+}
+
+func (s *GoModulesSyncer) client() *gomodproxy.Client {
+	if s.Client == nil {
+		s.Client = gomodproxy.NewClient(s.Config, nil)
+	}
+	return s.Client
+}
+
+// moduleDependencies returns the versions of the Go module that belongs to the given URL path,
+// gathered from the connection configuration and from precise code intelligence indexes. The
+// returned dependencies are sorted by semantic versioning, latest first.
+func (s *GoModulesSyncer) moduleDependencies(ctx context.Context, repoURLPath string) ([]reposource.GoDependency, error) {
+	mod, err := reposource.ParseGoModule(repoURLPath)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := map[string]struct{}{}
+	for _, dep := range s.Config.Dependencies {
+		dependency, err := reposource.ParseGoDependency(dep)
+		if err != nil {
+			return nil, err
+		}
+		if dependency.GoModule == mod {
+			versions[dependency.Version] = struct{}{}
+		}
+	}
+
+	if s.DepsStore != nil {
+		dependencyRepos, err := s.DepsStore.GetDependencyRepos(ctx, dbstore.GetDependencyReposOptions{
+			Scheme: goModulesScheme,
+			Name:   mod.Path,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, dependencyRepo := range dependencyRepos {
+			versions[dependencyRepo.Version] = struct{}{}
+		}
+	}
+
+	if len(versions) == 0 {
+		return nil, errors.Errorf("no Go module versions for URL path %s", repoURLPath)
+	}
+
+	dependencies := make([]reposource.GoDependency, 0, len(versions))
+	for version := range versions {
+		dependencies = append(dependencies, reposource.NewGoDependency(mod.Path, version))
+	}
+
+	reposource.SortGoDependencies(dependencies)
+	return dependencies, nil
+}
+
+// gitPushDependencyTag pushes a git tag to the given bareGitDirectory path. The
+// tag points to a commit that adds all sources of given module version. When
+// isLatestVersion is true, the main branch of the bare git directory will also be
+// updated to point to the same commit as the git tag.
+func (s *GoModulesSyncer) gitPushDependencyTag(ctx context.Context, bareGitDirectory string, dependency reposource.GoDependency, isLatestVersion bool) error {
+	tmpDirectory, err := ioutil.TempDir("", "gomod")
+	if err != nil {
+		return err
+	}
+	// Always clean up created temporary directories.
+	defer os.RemoveAll(tmpDirectory)
+
+	cmd := exec.CommandContext(ctx, "git", "init")
+	if _, err := runCommandInDirectory(ctx, cmd, tmpDirectory); err != nil {
+		return err
+	}
+
+	if err := s.commitModuleZip(ctx, dependency, tmpDirectory); err != nil {
+		return err
+	}
+
+	cmd = exec.CommandContext(ctx, "git", "remote", "add", "origin", bareGitDirectory)
+	if _, err := runCommandInDirectory(ctx, cmd, tmpDirectory); err != nil {
+		return err
+	}
+
+	// Use --no-verify for security reasons. See https://github.com/sourcegraph/sourcegraph/pull/23399
+	cmd = exec.CommandContext(ctx, "git", "push", "--no-verify", "--force", "origin", "--tags")
+	if _, err := runCommandInDirectory(ctx, cmd, tmpDirectory); err != nil {
+		return err
+	}
+
+	if isLatestVersion {
+		defaultBranch, err := runCommandInDirectory(ctx, exec.CommandContext(ctx, "git", "rev-parse", "--abbrev-ref", "HEAD"), tmpDirectory)
+		if err != nil {
+			return err
+		}
+		// Use --no-verify for security reasons. See https://github.com/sourcegraph/sourcegraph/pull/23399
+		cmd = exec.CommandContext(ctx, "git", "push", "--no-verify", "--force", "origin", strings.TrimSpace(defaultBranch)+":latest", dependency.GitTagFromVersion())
+		if _, err := runCommandInDirectory(ctx, cmd, tmpDirectory); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// commitModuleZip creates a git commit in the given working directory that adds all the
+// files of the module zip served by the proxy. The go.mod file served by the proxy is
+// added when the archive doesn't have one, which is the case for pre-modules versions.
+func (s *GoModulesSyncer) commitModuleZip(ctx context.Context, dependency reposource.GoDependency, workingDirectory string) error {
+	zipReader, err := s.client().Zip(ctx, dependency)
+	if err != nil {
+		return err
+	}
+
+	if err := unzipModule(zipReader, dependency.String()+"/", workingDirectory); err != nil {
+		return errors.Wrapf(err, "failed to unzip module %s", dependency.String())
+	}
+
+	goModPath := filepath.Join(workingDirectory, "go.mod")
+	if _, err := os.Stat(goModPath); os.IsNotExist(err) {
+		goMod, err := s.client().GoMod(ctx, dependency)
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(goModPath, goMod, 0600); err != nil {
+			return err
+		}
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "add", ".")
+	if _, err := runCommandInDirectory(ctx, cmd, workingDirectory); err != nil {
+		return err
+	}
+
+	// Use --no-verify for security reasons. See https://github.com/sourcegraph/sourcegraph/pull/23399
+	cmd = exec.CommandContext(ctx, "git", "commit", "--no-verify", "-m", dependency.String(), "--date", stableGitCommitDate)
+	if _, err := runCommandInDirectory(ctx, cmd, workingDirectory); err != nil {
+		return err
+	}
+
+	cmd = exec.CommandContext(ctx, "git", "tag", "-m", dependency.String(), dependency.GitTagFromVersion())
+	if _, err := runCommandInDirectory(ctx, cmd, workingDirectory); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// unzipModule extracts the files of a module zip to the given destination. Every file
+// in a module zip is prefixed with "<module>@<version>/"; the prefix is stripped and
+// files outside of it are ignored.
+func unzipModule(reader *zip.Reader, prefix, destination string) error {
+	destinationDirectory := strings.TrimSuffix(destination, string(os.PathSeparator)) + string(os.PathSeparator)
+	for _, file := range reader.File {
+		if !strings.HasPrefix(file.Name, prefix) {
+			continue
+		}
+		name := strings.TrimPrefix(file.Name, prefix)
+		if name == ".git" || strings.HasPrefix(name, ".git/") {
+			// For security reasons, don't unzip files under the `.git/`
+			// directory. See https://github.com/sourcegraph/security-issues/issues/163
+			continue
+		}
+		if name == "" || strings.HasSuffix(name, "/") {
+			// Skip directory entries.
+			continue
+		}
+		outputPath := path.Join(destination, name)
+		if !strings.HasPrefix(outputPath, destinationDirectory) {
+			// For security reasons, skip file if it's not a child
+			// of the target directory. See "Zip Slip Vulnerability".
+			continue
+		}
+
+		if err := copyModuleZipEntry(file, outputPath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func copyModuleZipEntry(entry *zip.File, outputPath string) (err error) {
+	inputFile, err := entry.Open()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err1 := inputFile.Close()
+		if err == nil {
+			err = err1
+		}
+	}()
+
+	if err = os.MkdirAll(path.Dir(outputPath), 0700); err != nil {
+		return err
+	}
+	outputFile, err := os.OpenFile(outputPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err1 := outputFile.Close()
+		if err == nil {
+			err = err1
+		}
+	}()
+
+	_, err = io.Copy(outputFile, inputFile)
+	return err
+}
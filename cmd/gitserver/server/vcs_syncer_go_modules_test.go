@@ -0,0 +1,92 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const exampleGoModule = "example.com/mod"
+
+func goModuleZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+	for name, contents := range files {
+		w, err := zipWriter.Create(name)
+		assert.Nil(t, err)
+		_, err = w.Write([]byte(contents))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, zipWriter.Close())
+	return buf.Bytes()
+}
+
+type fakeGoModulesDependencyReposStore []dbstore.DependencyRepo
+
+func (s fakeGoModulesDependencyReposStore) GetDependencyRepos(ctx context.Context, opts dbstore.GetDependencyReposOptions) ([]dbstore.DependencyRepo, error) {
+	return s, nil
+}
+
+func TestGoModulesCloneCommand(t *testing.T) {
+	dir, err := os.MkdirTemp("", "")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	zips := map[string][]byte{
+		"/example.com/mod/@v/v1.0.0.zip": goModuleZip(t, map[string]string{
+			exampleGoModule + "@v1.0.0/go.mod":       "module example.com/mod\n",
+			exampleGoModule + "@v1.0.0/mod.go":       "package mod\n",
+			exampleGoModule + "@v1.0.0/.git/config":  "[core]\n",
+			exampleGoModule + "@v1.0.0/../escape.go": "package escape\n",
+			"example.com/other@v1.0.0/other.go":      "package other\n",
+		}),
+		"/example.com/mod/@v/v1.1.0.zip": goModuleZip(t, map[string]string{
+			exampleGoModule + "@v1.1.0/mod.go": "package mod // v1.1.0\n",
+		}),
+	}
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/example.com/mod/@v/v1.1.0.mod" {
+			_, _ = w.Write([]byte("module example.com/mod\n"))
+			return
+		}
+		if contents, ok := zips[r.URL.Path]; ok {
+			_, _ = w.Write(contents)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer proxy.Close()
+
+	s := GoModulesSyncer{
+		Config:    &schema.GoModulesConnection{Urls: []string{proxy.URL}, Dependencies: []string{exampleGoModule + "@v1.0.0"}},
+		DepsStore: fakeGoModulesDependencyReposStore{{Scheme: "gomod", Name: exampleGoModule, Version: "v1.1.0"}},
+	}
+	bareGitDirectory := path.Join(dir, "git")
+
+	cmd, err := s.CloneCommand(context.Background(), &vcs.URL{URL: url.URL{Path: "go/" + exampleGoModule}}, bareGitDirectory)
+	if err != nil {
+		t.Fatalf("unexpected error cloning: %s", err)
+	}
+	assert.Nil(t, cmd.Run())
+
+	assertCommandOutput(t, exec.Command("git", "tag", "--list"), bareGitDirectory, "v1.0.0\nv1.1.0\n")
+	assertCommandOutput(t, exec.Command("git", "ls-tree", "-r", "--name-only", "v1.0.0"), bareGitDirectory, "go.mod\nmod.go\n")
+	assertCommandOutput(t, exec.Command("git", "show", "v1.1.0:mod.go"), bareGitDirectory, "package mod // v1.1.0\n")
+	// go.mod is synthesized from the proxy when missing from the archive
+	assertCommandOutput(t, exec.Command("git", "show", "v1.1.0:go.mod"), bareGitDirectory, "module example.com/mod\n")
+	assertCommandOutput(t, exec.Command("git", "show", "latest:mod.go"), bareGitDirectory, "package mod // v1.1.0\n")
+}
@@ -42,6 +42,9 @@ type Server struct {
 	JVMPackagesSource interface {
 		GetRepo(ctx context.Context, artifactName string) (*types.Repo, error)
 	}
+	GoModulesSource interface {
+		GetRepo(ctx context.Context, name string) (*types.Repo, error)
+	}
 	Scheduler interface {
 		UpdateOnce(id api.RepoID, name api.RepoName)
 		ScheduleInfo(id api.RepoID) *protocol.RepoUpdateSchedulerInfoResult
@@ -206,7 +209,7 @@ func (s *Server) handleExternalServiceSync(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	src, err := repos.NewSource(&types.ExternalService{
+	src, err := repos.NewSource(nil, &types.ExternalService{
 		ID:          req.ExternalService.ID,
 		Kind:        req.ExternalService.Kind,
 		DisplayName: req.ExternalService.DisplayName,
@@ -454,6 +457,25 @@ func (s *Server) remoteRepoSync(ctx context.Context, codehost *extsvc.CodeHost,
 				ErrorNotFound: true,
 			}, nil
 		}
+	case extsvc.GoModules:
+		if s.GoModulesSource != nil {
+			repo, err = s.GoModulesSource.GetRepo(ctx, remoteName)
+			if err != nil {
+				if errcode.IsNotFound(err) {
+					return &protocol.RepoLookupResult{
+						ErrorNotFound: true,
+					}, nil
+				}
+				return nil, err
+			}
+		} else {
+			log15.Error(
+				"GoModulesSource is nil: doing nothing. To fix this problem, make sure that cloud_default is true for the Go modules external service type.",
+				"remoteName", remoteName)
+			return &protocol.RepoLookupResult{
+				ErrorNotFound: true,
+			}, nil
+		}
 	}
 
 	if repo.Private {
@@ -151,7 +151,7 @@ func Main(enterpriseInit EnterpriseInit) {
 		m := repos.NewSourceMetrics()
 		m.MustRegister(prometheus.DefaultRegisterer)
 
-		src = repos.NewSourcer(cf, repos.ObservedSource(log15.Root(), m))
+		src = repos.NewSourcer(db, cf, repos.ObservedSource(log15.Root(), m))
 	}
 
 	scheduler := repos.NewUpdateScheduler()
@@ -188,6 +188,7 @@ func Main(enterpriseInit EnterpriseInit) {
 				extsvc.KindGitHub,
 				extsvc.KindGitLab,
 				extsvc.KindJVMPackages,
+				extsvc.KindGoModules,
 			},
 		})
 		if err != nil {
@@ -211,6 +212,8 @@ func Main(enterpriseInit EnterpriseInit) {
 				}
 			case *schema.JVMPackagesConnection:
 				server.JVMPackagesSource, err = repos.NewJVMPackagesSource(e)
+			case *schema.GoModulesConnection:
+				server.GoModulesSource, err = repos.NewGoModulesSource(e, cf, db)
 			}
 
 			if err != nil {
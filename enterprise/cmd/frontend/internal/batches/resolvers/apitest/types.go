@@ -331,6 +331,34 @@ type BatchChangesCredential struct {
 	CreatedAt           string
 }
 
+type BatchChangesCommitSigningKey struct {
+	ID                  string
+	ExternalServiceKind string
+	ExternalServiceURL  string
+	Format              string
+	Fingerprint         string
+	CreatedAt           string
+}
+
+type BatchSpecTemplate struct {
+	ID                  string
+	Name                string
+	Description         string
+	Template            string
+	Parameters          []BatchSpecTemplateParameter
+	Creator             *User
+	CreatedAt           string
+	ViewerCanAdminister bool
+}
+
+type BatchSpecTemplateParameter struct {
+	Name         string
+	Type         string
+	Description  string
+	Required     bool
+	DefaultValue interface{}
+}
+
 type EmptyResponse struct {
 	AlwaysNil string
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/graph-gophers/graphql-go/relay"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/service"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/state"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
@@ -84,6 +85,39 @@ func (r *batchChangeResolver) LastAppliedAt() graphqlbackend.DateTime {
 	return graphqlbackend.DateTime{Time: r.batchChange.LastAppliedAt}
 }
 
+func (r *batchChangeResolver) Owner(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.store.DB(), r.batchChange.OwnerID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *batchChangeResolver) Admins(ctx context.Context) ([]graphqlbackend.NamespaceResolver, error) {
+	admins, err := r.store.ListBatchChangeAdmins(ctx, store.ListBatchChangeAdminsOpts{BatchChangeID: r.batchChange.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.NamespaceResolver, 0, len(admins))
+	for _, a := range admins {
+		var n graphqlbackend.NamespaceResolver
+		if a.UserID != 0 {
+			n.Namespace, err = graphqlbackend.UserByIDInt32(ctx, r.store.DB(), a.UserID)
+		} else {
+			n.Namespace, err = graphqlbackend.OrgByIDInt32(ctx, r.store.DB(), a.OrgID)
+		}
+		if err != nil {
+			if errcode.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		resolvers = append(resolvers, n)
+	}
+	return resolvers, nil
+}
+
 func (r *batchChangeResolver) SpecCreator(ctx context.Context) (*graphqlbackend.UserResolver, error) {
 	spec, err := r.store.GetBatchSpec(ctx, store.GetBatchSpecOpts{
 		ID: r.batchChange.BatchSpecID,
@@ -99,7 +133,7 @@ func (r *batchChangeResolver) SpecCreator(ctx context.Context) (*graphqlbackend.
 }
 
 func (r *batchChangeResolver) ViewerCanAdminister(ctx context.Context) (bool, error) {
-	return checkSiteAdminOrSameUser(ctx, r.store.DB(), r.batchChange.InitialApplierID)
+	return checkBatchChangeAdmin(ctx, service.New(r.store), r.batchChange)
 }
 
 func (r *batchChangeResolver) URL(ctx context.Context) (string, error) {
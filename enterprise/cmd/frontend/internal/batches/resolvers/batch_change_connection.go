@@ -38,11 +38,12 @@ func (r *batchChangesConnectionResolver) Nodes(ctx context.Context) ([]graphqlba
 
 func (r *batchChangesConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
 	opts := store.CountBatchChangesOpts{
-		ChangesetID:      r.opts.ChangesetID,
-		State:            r.opts.State,
-		InitialApplierID: r.opts.InitialApplierID,
-		NamespaceUserID:  r.opts.NamespaceUserID,
-		NamespaceOrgID:   r.opts.NamespaceOrgID,
+		ChangesetID:          r.opts.ChangesetID,
+		State:                r.opts.State,
+		InitialApplierID:     r.opts.InitialApplierID,
+		AdministeredByUserID: r.opts.AdministeredByUserID,
+		NamespaceUserID:      r.opts.NamespaceUserID,
+		NamespaceOrgID:       r.opts.NamespaceOrgID,
 	}
 	count, err := r.store.CountBatchChanges(ctx, opts)
 	return int32(count), err
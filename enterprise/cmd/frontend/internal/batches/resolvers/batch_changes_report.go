@@ -0,0 +1,285 @@
+package resolvers
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/state"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+// loadBatchChangesReport computes the state.Report for the published
+// changesets of the batch changes matching args.
+func loadBatchChangesReport(ctx context.Context, s *store.Store, args *graphqlbackend.BatchChangesReportArgs) (*state.Report, error) {
+	var opts store.ListBatchChangesOpts
+	if args.Namespace != nil {
+		if err := graphqlbackend.UnmarshalNamespaceID(*args.Namespace, &opts.NamespaceUserID, &opts.NamespaceOrgID); err != nil {
+			return nil, err
+		}
+	}
+	batchChanges, _, err := s.ListBatchChanges(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	type entry struct {
+		batchChange *btypes.BatchChange
+		changeset   *btypes.Changeset
+	}
+	var (
+		entries      []entry
+		changesetIDs []int64
+		repoIDs      []api.RepoID
+		seen         = make(map[int64]struct{})
+	)
+	// List the changesets of all batch changes at once and group them by batch
+	// change in memory.
+	changesetsByBatchChange := make(map[int64][]*btypes.Changeset)
+	if len(batchChanges) > 0 {
+		batchChangeIDs := make([]int64, 0, len(batchChanges))
+		for _, bc := range batchChanges {
+			batchChangeIDs = append(batchChangeIDs, bc.ID)
+		}
+		published := btypes.ChangesetPublicationStatePublished
+		cs, _, err := s.ListChangesets(ctx, store.ListChangesetsOpts{
+			BatchChangeIDs:   batchChangeIDs,
+			PublicationState: &published,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range cs {
+			for _, assoc := range c.BatchChanges {
+				changesetsByBatchChange[assoc.BatchChangeID] = append(changesetsByBatchChange[assoc.BatchChangeID], c)
+			}
+		}
+	}
+
+	for _, bc := range batchChanges {
+		for _, c := range changesetsByBatchChange[bc.ID] {
+			// Changesets that haven't been synced yet have no history.
+			if c.ExternalCreatedAt().IsZero() {
+				continue
+			}
+			entries = append(entries, entry{batchChange: bc, changeset: c})
+			if _, ok := seen[c.ID]; !ok {
+				seen[c.ID] = struct{}{}
+				changesetIDs = append(changesetIDs, c.ID)
+				repoIDs = append(repoIDs, c.RepoID)
+			}
+		}
+	}
+
+	eventsByChangeset := make(map[int64]state.ChangesetEvents)
+	if len(changesetIDs) > 0 {
+		es, _, err := s.ListChangesetEvents(ctx, store.ListChangesetEventsOpts{
+			ChangesetIDs: changesetIDs,
+			Kinds:        state.RequiredEventTypesForHistory,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range es {
+			eventsByChangeset[e.ChangesetID] = append(eventsByChangeset[e.ChangesetID], e)
+		}
+		// ComputeLifecycle depends on the events being sorted.
+		for _, events := range eventsByChangeset {
+			sort.Sort(events)
+		}
+	}
+
+	repos, err := s.Repos().GetReposSetByIDs(ctx, repoIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := make(map[int64]string)
+	rows := make([]*state.ReportRow, 0, len(entries))
+	for _, e := range entries {
+		repo, ok := repos[e.changeset.RepoID]
+		if !ok {
+			// The repository has been deleted since.
+			continue
+		}
+
+		lifecycle, err := state.ComputeLifecycle(e.changeset, eventsByChangeset[e.changeset.ID])
+		if err != nil {
+			return nil, err
+		}
+		if args.From != nil && lifecycle.OpenedAt.Before(args.From.Time) {
+			continue
+		}
+		if args.To != nil && !lifecycle.OpenedAt.Before(args.To.Time) {
+			continue
+		}
+
+		namespace, ok := namespaces[e.batchChange.ID]
+		if !ok {
+			ns, err := database.NamespacesWith(s).GetByID(ctx, e.batchChange.NamespaceOrgID, e.batchChange.NamespaceUserID)
+			if err != nil {
+				return nil, err
+			}
+			namespace = ns.Name
+			namespaces[e.batchChange.ID] = namespace
+		}
+
+		rows = append(rows, &state.ReportRow{
+			BatchChange: e.batchChange,
+			Namespace:   namespace,
+			Changeset:   e.changeset,
+			Repo:        repo,
+			Lifecycle:   lifecycle,
+		})
+	}
+
+	stuckAfter := time.Duration(args.StuckAfterDays) * 24 * time.Hour
+	return state.NewReport(s.Clock()(), stuckAfter, rows), nil
+}
+
+type batchChangesReportResolver struct {
+	store  *store.Store
+	report *state.Report
+}
+
+var _ graphqlbackend.BatchChangesReportResolver = &batchChangesReportResolver{}
+
+func (r *batchChangesReportResolver) TotalCount() int32 {
+	var total int32
+	for _, g := range r.report.ByCodeHost() {
+		total += g.Total
+	}
+	return total
+}
+
+func (r *batchChangesReportResolver) TimeToMerge() graphqlbackend.ChangesetTimeToMergeDistributionResolver {
+	return &changesetTimeToMergeDistributionResolver{dist: r.report.TimeToMerge()}
+}
+
+func (r *batchChangesReportResolver) ByCodeHost() []graphqlbackend.ChangesetReportGroupResolver {
+	return newChangesetReportGroupResolvers(r.report.ByCodeHost())
+}
+
+func (r *batchChangesReportResolver) ByNamespace() []graphqlbackend.ChangesetReportGroupResolver {
+	return newChangesetReportGroupResolvers(r.report.ByNamespace())
+}
+
+func (r *batchChangesReportResolver) StuckChangesets() []graphqlbackend.ChangesetReportEntryResolver {
+	now := r.store.Clock()()
+	stuck := r.report.Stuck()
+	resolvers := make([]graphqlbackend.ChangesetReportEntryResolver, 0, len(stuck))
+	for _, row := range stuck {
+		resolvers = append(resolvers, &changesetReportEntryResolver{store: r.store, row: row, now: now})
+	}
+	return resolvers
+}
+
+func (r *batchChangesReportResolver) CSV() (string, error) {
+	var b strings.Builder
+	if err := r.report.WriteCSV(&b); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+type changesetTimeToMergeDistributionResolver struct {
+	dist state.TimeToMergeDistribution
+}
+
+var _ graphqlbackend.ChangesetTimeToMergeDistributionResolver = &changesetTimeToMergeDistributionResolver{}
+
+func (r *changesetTimeToMergeDistributionResolver) MergedCount() int32 {
+	return r.dist.Merged
+}
+
+func (r *changesetTimeToMergeDistributionResolver) MeanSeconds() *int32 {
+	return r.seconds(r.dist.Mean)
+}
+
+func (r *changesetTimeToMergeDistributionResolver) MedianSeconds() *int32 {
+	return r.seconds(r.dist.Median)
+}
+
+func (r *changesetTimeToMergeDistributionResolver) P90Seconds() *int32 {
+	return r.seconds(r.dist.P90)
+}
+
+func (r *changesetTimeToMergeDistributionResolver) seconds(d time.Duration) *int32 {
+	if r.dist.Merged == 0 {
+		return nil
+	}
+	s := int32(d / time.Second)
+	return &s
+}
+
+func (r *changesetTimeToMergeDistributionResolver) Buckets() []graphqlbackend.ChangesetTimeToMergeBucketResolver {
+	resolvers := make([]graphqlbackend.ChangesetTimeToMergeBucketResolver, 0, len(r.dist.Buckets))
+	for _, b := range r.dist.Buckets {
+		resolvers = append(resolvers, &changesetTimeToMergeBucketResolver{bucket: b})
+	}
+	return resolvers
+}
+
+type changesetTimeToMergeBucketResolver struct {
+	bucket state.TimeToMergeBucket
+}
+
+func (r *changesetTimeToMergeBucketResolver) UpperBoundSeconds() *int32 {
+	if r.bucket.UpperBound == 0 {
+		return nil
+	}
+	s := int32(r.bucket.UpperBound / time.Second)
+	return &s
+}
+
+func (r *changesetTimeToMergeBucketResolver) Count() int32 {
+	return r.bucket.Count
+}
+
+type changesetReportGroupResolver struct {
+	group *state.ReportGroup
+}
+
+func newChangesetReportGroupResolvers(groups []*state.ReportGroup) []graphqlbackend.ChangesetReportGroupResolver {
+	resolvers := make([]graphqlbackend.ChangesetReportGroupResolver, 0, len(groups))
+	for _, g := range groups {
+		resolvers = append(resolvers, &changesetReportGroupResolver{group: g})
+	}
+	return resolvers
+}
+
+func (r *changesetReportGroupResolver) Name() string  { return r.group.Name }
+func (r *changesetReportGroupResolver) Total() int32  { return r.group.Total }
+func (r *changesetReportGroupResolver) Open() int32   { return r.group.Open }
+func (r *changesetReportGroupResolver) Draft() int32  { return r.group.Draft }
+func (r *changesetReportGroupResolver) Merged() int32 { return r.group.Merged }
+func (r *changesetReportGroupResolver) Closed() int32 { return r.group.Closed }
+
+type changesetReportEntryResolver struct {
+	store *store.Store
+	row   *state.ReportRow
+	now   time.Time
+}
+
+var _ graphqlbackend.ChangesetReportEntryResolver = &changesetReportEntryResolver{}
+
+func (r *changesetReportEntryResolver) BatchChange() graphqlbackend.BatchChangeResolver {
+	return &batchChangeResolver{store: r.store, batchChange: r.row.BatchChange}
+}
+
+func (r *changesetReportEntryResolver) Changeset() graphqlbackend.ChangesetResolver {
+	return NewChangesetResolver(r.store, r.row.Changeset, r.row.Repo)
+}
+
+func (r *changesetReportEntryResolver) OpenedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.row.Lifecycle.OpenedAt}
+}
+
+func (r *changesetReportEntryResolver) OpenForSeconds() int32 {
+	return int32(r.row.Lifecycle.OpenFor(r.now) / time.Second)
+}
@@ -0,0 +1,75 @@
+package resolvers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/state"
+)
+
+func TestChangesetTimeToMergeDistributionResolver(t *testing.T) {
+	t.Run("no merged changesets", func(t *testing.T) {
+		resolver := changesetTimeToMergeDistributionResolver{dist: state.TimeToMergeDistribution{}}
+
+		if have := resolver.MergedCount(); have != 0 {
+			t.Errorf("wrong merged count. want=%d, have=%d", 0, have)
+		}
+		for name, have := range map[string]*int32{
+			"MeanSeconds":   resolver.MeanSeconds(),
+			"MedianSeconds": resolver.MedianSeconds(),
+			"P90Seconds":    resolver.P90Seconds(),
+		} {
+			if have != nil {
+				t.Errorf("resolver.%s wrong. want=nil, have=%d", name, *have)
+			}
+		}
+	})
+
+	t.Run("merged changesets", func(t *testing.T) {
+		resolver := changesetTimeToMergeDistributionResolver{dist: state.TimeToMergeDistribution{
+			Merged: 3,
+			Mean:   2 * time.Hour,
+			Median: time.Hour,
+			P90:    4 * time.Hour,
+			Buckets: []state.TimeToMergeBucket{
+				{UpperBound: 24 * time.Hour, Count: 3},
+				{UpperBound: 0},
+			},
+		}}
+
+		if have := resolver.MergedCount(); have != 3 {
+			t.Errorf("wrong merged count. want=%d, have=%d", 3, have)
+		}
+		for name, tc := range map[string]struct {
+			have *int32
+			want int32
+		}{
+			"MeanSeconds":   {have: resolver.MeanSeconds(), want: 7200},
+			"MedianSeconds": {have: resolver.MedianSeconds(), want: 3600},
+			"P90Seconds":    {have: resolver.P90Seconds(), want: 14400},
+		} {
+			if tc.have == nil || *tc.have != tc.want {
+				t.Errorf("resolver.%s wrong. want=%d, have=%v", name, tc.want, tc.have)
+			}
+		}
+
+		type bucket struct {
+			UpperBoundSeconds *int32
+			Count             int32
+		}
+		var have []bucket
+		for _, b := range resolver.Buckets() {
+			have = append(have, bucket{UpperBoundSeconds: b.UpperBoundSeconds(), Count: b.Count()})
+		}
+		day := int32(86400)
+		want := []bucket{
+			{UpperBoundSeconds: &day, Count: 3},
+			{UpperBoundSeconds: nil, Count: 0},
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatalf("wrong buckets (-want +got):\n%s", diff)
+		}
+	})
+}
@@ -3,6 +3,7 @@ package resolvers
 import (
 	"context"
 	"strings"
+	"sync"
 
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
@@ -27,6 +28,10 @@ func unmarshalBatchSpecExecutionRandID(id graphql.ID) (batchSpecExecutionID stri
 type batchSpecExecutionResolver struct {
 	store *store.Store
 	exec  *btypes.BatchSpecExecution
+
+	jobsOnce sync.Once
+	jobs     []*btypes.BatchSpecWorkspaceJob
+	jobsErr  error
 }
 
 // Type guard.
@@ -40,8 +45,21 @@ func (r *batchSpecExecutionResolver) InputSpec() string {
 	return r.exec.BatchSpec
 }
 
-func (r *batchSpecExecutionResolver) State() string {
-	return strings.ToUpper(string(r.exec.State))
+func (r *batchSpecExecutionResolver) State(ctx context.Context) (string, error) {
+	// Once the workspaces have been resolved, the execution is still
+	// processing until all of its workspaces are finished.
+	if r.exec.State == btypes.BatchSpecExecutionStateCompleted {
+		jobs, err := r.workspaceJobs(ctx)
+		if err != nil {
+			return "", err
+		}
+		for _, j := range jobs {
+			if !j.Finished() {
+				return strings.ToUpper(string(btypes.BatchSpecExecutionStateProcessing)), nil
+			}
+		}
+	}
+	return strings.ToUpper(string(r.exec.State)), nil
 }
 
 func (r *batchSpecExecutionResolver) CreatedAt() graphqlbackend.DateTime {
@@ -73,6 +91,28 @@ func (r *batchSpecExecutionResolver) Steps() graphqlbackend.BatchSpecExecutionSt
 	}
 }
 
+func (r *batchSpecExecutionResolver) Workspaces(ctx context.Context) ([]graphqlbackend.BatchSpecWorkspaceResolver, error) {
+	jobs, err := r.workspaceJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.BatchSpecWorkspaceResolver, 0, len(jobs))
+	for _, j := range jobs {
+		resolvers = append(resolvers, &batchSpecWorkspaceResolver{store: r.store, job: j})
+	}
+	return resolvers, nil
+}
+
+func (r *batchSpecExecutionResolver) workspaceJobs(ctx context.Context) ([]*btypes.BatchSpecWorkspaceJob, error) {
+	r.jobsOnce.Do(func() {
+		r.jobs, r.jobsErr = r.store.ListBatchSpecWorkspaceJobs(ctx, store.ListBatchSpecWorkspaceJobsOpts{
+			BatchSpecExecutionID: r.exec.ID,
+		})
+	})
+	return r.jobs, r.jobsErr
+}
+
 func (r *batchSpecExecutionResolver) PlaceInQueue() *int32 {
 	// TODO(eseliger): Implement this.
 	return nil
@@ -0,0 +1,160 @@
+package resolvers
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/service"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+const batchSpecTemplateIDKind = "BatchSpecTemplate"
+
+func marshalBatchSpecTemplateID(id int64) graphql.ID {
+	return relay.MarshalID(batchSpecTemplateIDKind, id)
+}
+
+func unmarshalBatchSpecTemplateID(id graphql.ID) (templateID int64, err error) {
+	err = relay.UnmarshalSpec(id, &templateID)
+	return
+}
+
+type batchSpecTemplateResolver struct {
+	store    *store.Store
+	template *btypes.BatchSpecTemplate
+}
+
+var _ graphqlbackend.BatchSpecTemplateResolver = &batchSpecTemplateResolver{}
+
+func (r *batchSpecTemplateResolver) ID() graphql.ID {
+	return marshalBatchSpecTemplateID(r.template.ID)
+}
+
+func (r *batchSpecTemplateResolver) Name() string {
+	return r.template.Name
+}
+
+func (r *batchSpecTemplateResolver) Description() string {
+	return r.template.Description
+}
+
+func (r *batchSpecTemplateResolver) Namespace(ctx context.Context) (*graphqlbackend.OrgResolver, error) {
+	if r.template.NamespaceOrgID == 0 {
+		return nil, nil
+	}
+	return graphqlbackend.OrgByIDInt32(ctx, r.store.DB(), r.template.NamespaceOrgID)
+}
+
+func (r *batchSpecTemplateResolver) Template() string {
+	return r.template.Template
+}
+
+func (r *batchSpecTemplateResolver) Parameters() []graphqlbackend.BatchSpecTemplateParameterResolver {
+	resolvers := make([]graphqlbackend.BatchSpecTemplateParameterResolver, 0, len(r.template.Parameters))
+	for _, p := range r.template.Parameters {
+		resolvers = append(resolvers, &batchSpecTemplateParameterResolver{parameter: p})
+	}
+	return resolvers
+}
+
+func (r *batchSpecTemplateResolver) Creator(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.store.DB(), r.template.CreatorUserID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *batchSpecTemplateResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.template.CreatedAt}
+}
+
+func (r *batchSpecTemplateResolver) ViewerCanAdminister(ctx context.Context) (bool, error) {
+	return checkBatchSpecTemplateAdmin(ctx, service.New(r.store), r.template.NamespaceOrgID)
+}
+
+type batchSpecTemplateParameterResolver struct {
+	parameter btypes.BatchSpecTemplateParameter
+}
+
+var _ graphqlbackend.BatchSpecTemplateParameterResolver = &batchSpecTemplateParameterResolver{}
+
+func (r *batchSpecTemplateParameterResolver) Name() string {
+	return r.parameter.Name
+}
+
+func (r *batchSpecTemplateParameterResolver) Type() string {
+	return strings.ToUpper(string(r.parameter.Type))
+}
+
+func (r *batchSpecTemplateParameterResolver) Description() string {
+	return r.parameter.Description
+}
+
+func (r *batchSpecTemplateParameterResolver) Required() bool {
+	return r.parameter.Required
+}
+
+func (r *batchSpecTemplateParameterResolver) DefaultValue() *graphqlbackend.JSONValue {
+	if r.parameter.Default == nil {
+		return nil
+	}
+	return &graphqlbackend.JSONValue{Value: r.parameter.Default}
+}
+
+type batchSpecTemplateConnectionResolver struct {
+	store *store.Store
+	opts  store.ListBatchSpecTemplatesOpts
+
+	// Cache results because they are used by multiple fields
+	once      sync.Once
+	templates []*btypes.BatchSpecTemplate
+	next      int64
+	err       error
+}
+
+var _ graphqlbackend.BatchSpecTemplateConnectionResolver = &batchSpecTemplateConnectionResolver{}
+
+func (r *batchSpecTemplateConnectionResolver) Nodes(ctx context.Context) ([]graphqlbackend.BatchSpecTemplateResolver, error) {
+	templates, _, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.BatchSpecTemplateResolver, 0, len(templates))
+	for _, t := range templates {
+		resolvers = append(resolvers, &batchSpecTemplateResolver{store: r.store, template: t})
+	}
+
+	return resolvers, nil
+}
+
+func (r *batchSpecTemplateConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	_, next, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if next != 0 {
+		return graphqlutil.NextPageCursor(strconv.Itoa(int(next))), nil
+	}
+
+	return graphqlutil.HasNextPage(false), nil
+}
+
+func (r *batchSpecTemplateConnectionResolver) compute(ctx context.Context) ([]*btypes.BatchSpecTemplate, int64, error) {
+	r.once.Do(func() {
+		r.templates, r.next, r.err = r.store.ListBatchSpecTemplates(ctx, r.opts)
+	})
+
+	return r.templates, r.next, r.err
+}
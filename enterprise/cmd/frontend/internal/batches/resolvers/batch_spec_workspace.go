@@ -0,0 +1,105 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+const batchSpecWorkspaceIDKind = "BatchSpecWorkspace"
+
+func marshalBatchSpecWorkspaceID(id int64) graphql.ID {
+	return relay.MarshalID(batchSpecWorkspaceIDKind, id)
+}
+
+func unmarshalBatchSpecWorkspaceID(id graphql.ID) (workspaceID int64, err error) {
+	err = relay.UnmarshalSpec(id, &workspaceID)
+	return
+}
+
+type batchSpecWorkspaceResolver struct {
+	store *store.Store
+	job   *btypes.BatchSpecWorkspaceJob
+}
+
+// Type guard.
+var _ graphqlbackend.BatchSpecWorkspaceResolver = &batchSpecWorkspaceResolver{}
+
+func (r *batchSpecWorkspaceResolver) ID() graphql.ID {
+	return marshalBatchSpecWorkspaceID(r.job.ID)
+}
+
+func (r *batchSpecWorkspaceResolver) Repository(ctx context.Context) (*graphqlbackend.RepositoryResolver, error) {
+	// 🚨 SECURITY: database.Repos.Get uses the authzFilter under the hood and
+	// returns an error if the user doesn't have access to the repository.
+	repo, err := r.store.Repos().Get(ctx, r.job.RepoID)
+	if err != nil {
+		return nil, err
+	}
+	return graphqlbackend.NewRepositoryResolver(r.store.DB(), repo), nil
+}
+
+func (r *batchSpecWorkspaceResolver) Branch() string {
+	return r.job.Branch
+}
+
+func (r *batchSpecWorkspaceResolver) Commit() string {
+	return r.job.Commit
+}
+
+func (r *batchSpecWorkspaceResolver) State() string {
+	return strings.ToUpper(string(r.job.State))
+}
+
+func (r *batchSpecWorkspaceResolver) StartedAt() *graphqlbackend.DateTime {
+	if r.job.StartedAt == nil {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: *r.job.StartedAt}
+}
+
+func (r *batchSpecWorkspaceResolver) FinishedAt() *graphqlbackend.DateTime {
+	if r.job.FinishedAt == nil {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: *r.job.FinishedAt}
+}
+
+func (r *batchSpecWorkspaceResolver) Failure() *string {
+	return r.job.FailureMessage
+}
+
+func (r *batchSpecWorkspaceResolver) ExecutionLogs() []graphqlbackend.ExecutionLogEntryResolver {
+	resolvers := make([]graphqlbackend.ExecutionLogEntryResolver, 0, len(r.job.ExecutionLogs))
+	for _, entry := range r.job.ExecutionLogs {
+		resolvers = append(resolvers, graphqlbackend.NewExecutionLogEntryResolver(r.store.DB(), entry))
+	}
+	return resolvers
+}
+
+func (r *batchSpecWorkspaceResolver) ChangesetSpecs(ctx context.Context) ([]graphqlbackend.ChangesetSpecResolver, error) {
+	if len(r.job.ChangesetSpecIDs) == 0 {
+		return []graphqlbackend.ChangesetSpecResolver{}, nil
+	}
+
+	specs, _, err := r.store.ListChangesetSpecs(ctx, store.ListChangesetSpecsOpts{IDs: r.job.ChangesetSpecIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.ChangesetSpecResolver, 0, len(specs))
+	for _, spec := range specs {
+		resolver, err := NewChangesetSpecResolver(ctx, r.store, spec)
+		if err != nil {
+			return nil, err
+		}
+		resolvers = append(resolvers, resolver)
+	}
+	return resolvers, nil
+}
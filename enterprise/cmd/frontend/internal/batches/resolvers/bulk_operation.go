@@ -121,6 +121,10 @@ func changesetJobTypeToBulkOperationType(t btypes.ChangesetJobType) (string, err
 		return "CLOSE", nil
 	case btypes.ChangesetJobTypePublish:
 		return "PUBLISH", nil
+	case btypes.ChangesetJobTypeRerunChecks:
+		return "RERUN_CHECKS", nil
+	case btypes.ChangesetJobTypeUpdateBranch:
+		return "UPDATE_BRANCH", nil
 	default:
 		return "", errors.Errorf("invalid job type %q", t)
 	}
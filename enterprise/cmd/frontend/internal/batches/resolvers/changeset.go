@@ -178,7 +178,7 @@ func (r *changesetResolver) BatchChanges(ctx context.Context, args *graphqlbacke
 	if !isSiteAdmin {
 		if args.ViewerCanAdminister != nil && *args.ViewerCanAdminister {
 			actor := actor.FromContext(ctx)
-			opts.InitialApplierID = actor.UID
+			opts.AdministeredByUserID = actor.UID
 		}
 	}
 
@@ -383,6 +383,25 @@ func (r *changesetResolver) CheckState() *string {
 	return &state
 }
 
+func (r *changesetResolver) Checks() []graphqlbackend.ChangesetCheckResolver {
+	if !r.changeset.Published() {
+		return []graphqlbackend.ChangesetCheckResolver{}
+	}
+
+	resolvers := make([]graphqlbackend.ChangesetCheckResolver, 0, len(r.changeset.ExternalChecks))
+	for _, c := range r.changeset.ExternalChecks {
+		resolvers = append(resolvers, &changesetCheckResolver{check: c})
+	}
+	return resolvers
+}
+
+func (r *changesetResolver) CommitSignature() graphqlbackend.ChangesetCommitSignatureResolver {
+	if r.changeset.CommitSignature == nil {
+		return nil
+	}
+	return &changesetCommitSignatureResolver{signature: r.changeset.CommitSignature}
+}
+
 func (r *changesetResolver) Error() *string { return r.changeset.FailureMessage }
 
 func (r *changesetResolver) SyncerError() *string { return r.changeset.SyncErrorMessage }
@@ -554,3 +573,33 @@ func (r *changesetLabelResolver) Description() *string {
 	}
 	return &r.label.Description
 }
+
+type changesetCheckResolver struct {
+	check btypes.ChangesetCheck
+}
+
+func (r *changesetCheckResolver) Name() string {
+	return r.check.Name
+}
+
+func (r *changesetCheckResolver) State() string {
+	return string(r.check.State)
+}
+
+func (r *changesetCheckResolver) URL() *string {
+	if r.check.URL == "" {
+		return nil
+	}
+	return &r.check.URL
+}
+
+func (r *changesetCheckResolver) FinishedAt() *graphqlbackend.DateTime {
+	if r.check.FinishedAt.IsZero() {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: r.check.FinishedAt}
+}
+
+func (r *changesetCheckResolver) Rerunnable() bool {
+	return r.check.Rerunnable()
+}
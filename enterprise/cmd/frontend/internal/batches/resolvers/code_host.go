@@ -1,12 +1,16 @@
 package resolvers
 
 import (
+	"context"
+
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 )
 
 type batchChangesCodeHostResolver struct {
+	store      *store.Store
 	codeHost   *btypes.CodeHost
 	credential graphqlbackend.BatchChangesCredentialResolver
 }
@@ -28,3 +32,17 @@ func (c *batchChangesCodeHostResolver) Credential() graphqlbackend.BatchChangesC
 func (c *batchChangesCodeHostResolver) RequiresSSH() bool {
 	return c.codeHost.RequiresSSH
 }
+
+func (c *batchChangesCodeHostResolver) CommitSigningKey(ctx context.Context) (graphqlbackend.BatchChangesCommitSigningKeyResolver, error) {
+	key, err := c.store.GetCommitSigningKey(ctx, store.GetCommitSigningKeyOpts{
+		ExternalServiceType: c.codeHost.ExternalServiceType,
+		ExternalServiceID:   c.codeHost.ExternalServiceID,
+	})
+	if err == store.ErrNoResults {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &batchChangesCommitSigningKeyResolver{key: key}, nil
+}
@@ -0,0 +1,67 @@
+package resolvers
+
+import (
+	"strings"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+const batchChangesCommitSigningKeyIDKind = "BatchChangesCommitSigningKey"
+
+func marshalBatchChangesCommitSigningKeyID(id int64) graphql.ID {
+	return relay.MarshalID(batchChangesCommitSigningKeyIDKind, id)
+}
+
+func unmarshalBatchChangesCommitSigningKeyID(id graphql.ID) (keyID int64, err error) {
+	err = relay.UnmarshalSpec(id, &keyID)
+	return
+}
+
+type batchChangesCommitSigningKeyResolver struct {
+	key *btypes.CommitSigningKey
+}
+
+var _ graphqlbackend.BatchChangesCommitSigningKeyResolver = &batchChangesCommitSigningKeyResolver{}
+
+func (r *batchChangesCommitSigningKeyResolver) ID() graphql.ID {
+	return marshalBatchChangesCommitSigningKeyID(r.key.ID)
+}
+
+func (r *batchChangesCommitSigningKeyResolver) ExternalServiceKind() string {
+	return extsvc.TypeToKind(r.key.ExternalServiceType)
+}
+
+func (r *batchChangesCommitSigningKeyResolver) ExternalServiceURL() string {
+	return r.key.ExternalServiceID
+}
+
+func (r *batchChangesCommitSigningKeyResolver) Format() string {
+	return strings.ToUpper(string(r.key.Format))
+}
+
+func (r *batchChangesCommitSigningKeyResolver) Fingerprint() string {
+	return r.key.Fingerprint
+}
+
+func (r *batchChangesCommitSigningKeyResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.key.CreatedAt}
+}
+
+type changesetCommitSignatureResolver struct {
+	signature *btypes.ChangesetCommitSignature
+}
+
+var _ graphqlbackend.ChangesetCommitSignatureResolver = &changesetCommitSignatureResolver{}
+
+func (r *changesetCommitSignatureResolver) Format() string {
+	return strings.ToUpper(string(r.signature.Format))
+}
+
+func (r *changesetCommitSignatureResolver) Fingerprint() string {
+	return r.signature.Fingerprint
+}
@@ -90,6 +90,50 @@ func (e ErrDuplicateCredential) Extensions() map[string]interface{} {
 	return map[string]interface{}{"code": "ErrDuplicateCredential"}
 }
 
+type ErrDuplicateCommitSigningKey struct{}
+
+func (e ErrDuplicateCommitSigningKey) Error() string {
+	return "a commit signing key for this code host already exists"
+}
+
+func (e ErrDuplicateCommitSigningKey) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": "ErrDuplicateCommitSigningKey"}
+}
+
+type ErrInvalidCommitSigningKey struct {
+	SourceErr error
+}
+
+func (e ErrInvalidCommitSigningKey) Error() string {
+	return fmt.Sprintf("invalid commit signing key: %s", e.SourceErr)
+}
+
+func (e ErrInvalidCommitSigningKey) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": "ErrInvalidCommitSigningKey"}
+}
+
+type ErrDuplicateBatchSpecTemplate struct{}
+
+func (e ErrDuplicateBatchSpecTemplate) Error() string {
+	return "a batch spec template with this name already exists in the namespace"
+}
+
+func (e ErrDuplicateBatchSpecTemplate) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": "ErrDuplicateBatchSpecTemplate"}
+}
+
+type ErrInvalidBatchSpecTemplate struct {
+	SourceErr error
+}
+
+func (e ErrInvalidBatchSpecTemplate) Error() string {
+	return e.SourceErr.Error()
+}
+
+func (e ErrInvalidBatchSpecTemplate) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": "ErrInvalidBatchSpecTemplate"}
+}
+
 type ErrVerifyCredentialFailed struct {
 	SourceErr error
 }
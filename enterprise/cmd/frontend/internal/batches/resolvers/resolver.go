@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 	"github.com/graph-gophers/graphql-go"
@@ -27,6 +28,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/signing"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/usagestats"
 )
@@ -130,6 +132,9 @@ func (r *Resolver) NodeResolvers() map[string]graphqlbackend.NodeByIDFunc {
 		batchSpecExecutionIDKind: func(ctx context.Context, id graphql.ID) (graphqlbackend.Node, error) {
 			return r.batchSpecExecutionByID(ctx, id)
 		},
+		batchSpecTemplateIDKind: func(ctx context.Context, id graphql.ID) (graphqlbackend.Node, error) {
+			return r.batchSpecTemplateByID(ctx, id)
+		},
 	}
 }
 
@@ -371,6 +376,39 @@ func (r *Resolver) batchSpecExecutionByID(ctx context.Context, id graphql.ID) (g
 	return &batchSpecExecutionResolver{store: r.store, exec: spec}, nil
 }
 
+func (r *Resolver) batchSpecTemplateByID(ctx context.Context, id graphql.ID) (graphqlbackend.BatchSpecTemplateResolver, error) {
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	templateID, err := unmarshalBatchSpecTemplateID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if templateID == 0 {
+		return nil, nil
+	}
+
+	template, err := r.store.GetBatchSpecTemplate(ctx, templateID)
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Org templates are only visible to members of the org.
+	if err := service.New(r.store).CheckBatchSpecTemplateAccess(ctx, template); err != nil {
+		if err == backend.ErrNotAnOrgMember {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &batchSpecTemplateResolver{store: r.store, template: template}, nil
+}
+
 func (r *Resolver) CreateBatchChange(ctx context.Context, args *graphqlbackend.CreateBatchChangeArgs) (graphqlbackend.BatchChangeResolver, error) {
 	var err error
 	tr, _ := trace.New(ctx, "Resolver.CreateBatchChange", fmt.Sprintf("BatchSpec %s", args.BatchSpec))
@@ -651,6 +689,108 @@ func (r *Resolver) DeleteBatchChange(ctx context.Context, args *graphqlbackend.D
 	return &graphqlbackend.EmptyResponse{}, err
 }
 
+func (r *Resolver) TransferBatchChangeOwnership(ctx context.Context, args *graphqlbackend.TransferBatchChangeOwnershipArgs) (_ graphqlbackend.BatchChangeResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.TransferBatchChangeOwnership", fmt.Sprintf("BatchChange: %q, NewOwner: %q", args.BatchChange, args.NewOwner))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	batchChangeID, err := unmarshalBatchChangeID(args.BatchChange)
+	if err != nil {
+		return nil, err
+	}
+
+	if batchChangeID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	newOwnerID, err := graphqlbackend.UnmarshalUserID(args.NewOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	svc := service.New(r.store)
+	// 🚨 SECURITY: TransferBatchChangeOwnership checks whether the current user is authorized.
+	batchChange, err := svc.TransferBatchChangeOwnership(ctx, batchChangeID, newOwnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchChangeResolver{store: r.store, batchChange: batchChange}, nil
+}
+
+func (r *Resolver) AddBatchChangeAdmin(ctx context.Context, args *graphqlbackend.AddBatchChangeAdminArgs) (_ graphqlbackend.BatchChangeResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.AddBatchChangeAdmin", fmt.Sprintf("BatchChange: %q, Admin: %q", args.BatchChange, args.Admin))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	batchChangeID, err := unmarshalBatchChangeID(args.BatchChange)
+	if err != nil {
+		return nil, err
+	}
+
+	if batchChangeID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	var userID, orgID int32
+	if err := graphqlbackend.UnmarshalNamespaceID(args.Admin, &userID, &orgID); err != nil {
+		return nil, err
+	}
+
+	svc := service.New(r.store)
+	// 🚨 SECURITY: AddBatchChangeAdmin checks whether the current user is authorized.
+	batchChange, err := svc.AddBatchChangeAdmin(ctx, batchChangeID, userID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchChangeResolver{store: r.store, batchChange: batchChange}, nil
+}
+
+func (r *Resolver) RemoveBatchChangeAdmin(ctx context.Context, args *graphqlbackend.RemoveBatchChangeAdminArgs) (_ graphqlbackend.BatchChangeResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.RemoveBatchChangeAdmin", fmt.Sprintf("BatchChange: %q, Admin: %q", args.BatchChange, args.Admin))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	batchChangeID, err := unmarshalBatchChangeID(args.BatchChange)
+	if err != nil {
+		return nil, err
+	}
+
+	if batchChangeID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	var userID, orgID int32
+	if err := graphqlbackend.UnmarshalNamespaceID(args.Admin, &userID, &orgID); err != nil {
+		return nil, err
+	}
+
+	svc := service.New(r.store)
+	// 🚨 SECURITY: RemoveBatchChangeAdmin checks whether the current user is authorized.
+	batchChange, err := svc.RemoveBatchChangeAdmin(ctx, batchChangeID, userID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchChangeResolver{store: r.store, batchChange: batchChange}, nil
+}
+
 func (r *Resolver) BatchChanges(ctx context.Context, args *graphqlbackend.ListBatchChangesArgs) (graphqlbackend.BatchChangesConnectionResolver, error) {
 	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DB()); err != nil {
 		return nil, err
@@ -683,7 +823,7 @@ func (r *Resolver) BatchChanges(ctx context.Context, args *graphqlbackend.ListBa
 	if !isSiteAdmin {
 		if args.ViewerCanAdminister != nil && *args.ViewerCanAdminister {
 			actor := actor.FromContext(ctx)
-			opts.InitialApplierID = actor.UID
+			opts.AdministeredByUserID = actor.UID
 		}
 	}
 
@@ -734,6 +874,71 @@ func (r *Resolver) RepoDiffStat(ctx context.Context, repo *graphql.ID) (*graphql
 	return graphqlbackend.NewDiffStat(*diffStat), nil
 }
 
+func (r *Resolver) BatchChangesReport(ctx context.Context, args *graphqlbackend.BatchChangesReportArgs) (_ graphqlbackend.BatchChangesReportResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.BatchChangesReport", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: The report spans all batch changes, so only site admins
+	// can request it.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	if args.StuckAfterDays < 0 {
+		return nil, errors.New("stuckAfterDays must not be negative")
+	}
+
+	report, err := loadBatchChangesReport(ctx, r.store, args)
+	if err != nil {
+		return nil, err
+	}
+	return &batchChangesReportResolver{store: r.store, report: report}, nil
+}
+
+func (r *Resolver) BatchSpecTemplates(ctx context.Context, args *graphqlbackend.ListBatchSpecTemplatesArgs) (graphqlbackend.BatchSpecTemplateConnectionResolver, error) {
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	if err := validateFirstParamDefaults(args.First); err != nil {
+		return nil, err
+	}
+
+	opts := store.ListBatchSpecTemplatesOpts{LimitOpts: store.LimitOpts{Limit: int(args.First)}}
+	if args.After != nil {
+		cursor, err := strconv.ParseInt(*args.After, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		opts.Cursor = cursor
+	}
+
+	template := &btypes.BatchSpecTemplate{}
+	if args.Namespace != nil {
+		var userID int32
+		if err := graphqlbackend.UnmarshalNamespaceID(*args.Namespace, &userID, &opts.NamespaceOrgID); err != nil {
+			return nil, err
+		}
+		if opts.NamespaceOrgID == 0 {
+			return nil, errors.New("batch spec templates can only belong to orgs")
+		}
+		template.NamespaceOrgID = opts.NamespaceOrgID
+	}
+
+	// 🚨 SECURITY: Org templates are only visible to members of the org.
+	if err := service.New(r.store).CheckBatchSpecTemplateAccess(ctx, template); err != nil {
+		return nil, err
+	}
+
+	return &batchSpecTemplateConnectionResolver{store: r.store, opts: opts}, nil
+}
+
 func (r *Resolver) BatchChangesCodeHosts(ctx context.Context, args *graphqlbackend.ListBatchChangesCodeHostsArgs) (graphqlbackend.BatchChangesCodeHostConnectionResolver, error) {
 	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DB()); err != nil {
 		return nil, err
@@ -1104,7 +1309,20 @@ func (r *Resolver) generateAuthenticatorForCredential(ctx context.Context, exter
 	if err != nil {
 		return nil, err
 	}
-	if externalServiceType == extsvc.TypeBitbucketServer {
+	if externalServiceType == extsvc.TypeAWSCodeCommit {
+		// AWS CodeCommit pull requests are always created with the access key
+		// of the code host connection, so we only need Git credentials to push.
+		parts := strings.SplitN(credential, ":", 2)
+		if len(parts) != 2 {
+			return nil, errors.New("AWS CodeCommit credentials must be in the form username:password")
+		}
+		a = &auth.BasicAuthWithSSH{
+			BasicAuth:  auth.BasicAuth{Username: parts[0], Password: parts[1]},
+			PrivateKey: keypair.PrivateKey,
+			PublicKey:  keypair.PublicKey,
+			Passphrase: keypair.Passphrase,
+		}
+	} else if externalServiceType == extsvc.TypeBitbucketServer {
 		// We need to fetch the username for the token, as just an OAuth token isn't enough for some reason..
 		username, err := svc.FetchUsernameForBitbucketServerToken(ctx, externalServiceURL, externalServiceType, credential)
 		if err != nil {
@@ -1195,6 +1413,103 @@ func (r *Resolver) deleteBatchChangesSiteCredential(ctx context.Context, credent
 	return &graphqlbackend.EmptyResponse{}, nil
 }
 
+func (r *Resolver) CreateBatchChangesCommitSigningKey(ctx context.Context, args *graphqlbackend.CreateBatchChangesCommitSigningKeyArgs) (_ graphqlbackend.BatchChangesCommitSigningKeyResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.CreateBatchChangesCommitSigningKey", fmt.Sprintf("%q (%q), format: %q", args.ExternalServiceKind, args.ExternalServiceURL, args.Format))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Check that a commit signing key can only be created by a
+	// site-admin.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	// Need to validate externalServiceKind, otherwise this'll panic.
+	kind, valid := extsvc.ParseServiceKind(args.ExternalServiceKind)
+	if !valid {
+		return nil, errors.New("invalid external service kind")
+	}
+	externalServiceType := extsvc.KindToType(kind)
+
+	format := signing.Format(strings.ToLower(args.Format))
+	if !format.Valid() {
+		return nil, errors.Errorf("invalid commit signing key format %q", args.Format)
+	}
+
+	var passphrase string
+	if args.Passphrase != nil {
+		passphrase = *args.Passphrase
+	}
+
+	// Make sure the key can be used before storing it, so that pushes don't
+	// start failing.
+	signer, err := signing.NewSigner(format, args.PrivateKey, passphrase)
+	if err != nil {
+		return nil, ErrInvalidCommitSigningKey{SourceErr: err}
+	}
+
+	// Throw error documented in schema.graphql.
+	existing, err := r.store.GetCommitSigningKey(ctx, store.GetCommitSigningKeyOpts{
+		ExternalServiceType: externalServiceType,
+		ExternalServiceID:   args.ExternalServiceURL,
+	})
+	if err != nil && err != store.ErrNoResults {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrDuplicateCommitSigningKey{}
+	}
+
+	key := &btypes.CommitSigningKey{
+		ExternalServiceType: externalServiceType,
+		ExternalServiceID:   args.ExternalServiceURL,
+		Format:              format,
+		Fingerprint:         signer.Fingerprint(),
+	}
+	if err := r.store.CreateCommitSigningKey(ctx, key, args.PrivateKey, passphrase); err != nil {
+		return nil, err
+	}
+
+	return &batchChangesCommitSigningKeyResolver{key: key}, nil
+}
+
+func (r *Resolver) DeleteBatchChangesCommitSigningKey(ctx context.Context, args *graphqlbackend.DeleteBatchChangesCommitSigningKeyArgs) (_ *graphqlbackend.EmptyResponse, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.DeleteBatchChangesCommitSigningKey", fmt.Sprintf("Key: %q", args.CommitSigningKey))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	keyID, err := unmarshalBatchChangesCommitSigningKeyID(args.CommitSigningKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if keyID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	// 🚨 SECURITY: Check that the requesting user may delete the key.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	// This also fails if the key was not found.
+	if err := r.store.DeleteCommitSigningKey(ctx, keyID); err != nil {
+		return nil, err
+	}
+
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
 func (r *Resolver) DetachChangesets(ctx context.Context, args *graphqlbackend.DetachChangesetsArgs) (_ graphqlbackend.BulkOperationResolver, err error) {
 	tr, ctx := trace.New(ctx, "Resolver.DetachChangesets", fmt.Sprintf("BatchChange: %q, len(Changesets): %d", args.BatchChange, len(args.Changesets)))
 	defer func() {
@@ -1417,6 +1732,82 @@ func (r *Resolver) PublishChangesets(ctx context.Context, args *graphqlbackend.P
 
 }
 
+func (r *Resolver) RerunChangesetChecks(ctx context.Context, args *graphqlbackend.RerunChangesetChecksArgs) (_ graphqlbackend.BulkOperationResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.RerunChangesetChecks", fmt.Sprintf("BatchChange: %q, len(Changesets): %d", args.BatchChange, len(args.Changesets)))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	batchChangeID, changesetIDs, err := unmarshalBulkOperationBaseArgs(args.BulkOperationBaseArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: CreateChangesetJobs checks whether current user is authorized.
+	svc := service.New(r.store)
+	published := btypes.ChangesetPublicationStatePublished
+	failed := btypes.ChangesetCheckStateFailed
+	bulkGroupID, err := svc.CreateChangesetJobs(
+		ctx,
+		batchChangeID,
+		changesetIDs,
+		btypes.ChangesetJobTypeRerunChecks,
+		&btypes.ChangesetJobRerunChecksPayload{},
+		store.ListChangesetsOpts{
+			PublicationState:   &published,
+			ReconcilerStates:   []btypes.ReconcilerState{btypes.ReconcilerStateCompleted},
+			ExternalStates:     []btypes.ChangesetExternalState{btypes.ChangesetExternalStateOpen, btypes.ChangesetExternalStateDraft},
+			ExternalCheckState: &failed,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.bulkOperationByIDString(ctx, bulkGroupID)
+}
+
+func (r *Resolver) UpdateChangesetBranches(ctx context.Context, args *graphqlbackend.UpdateChangesetBranchesArgs) (_ graphqlbackend.BulkOperationResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.UpdateChangesetBranches", fmt.Sprintf("BatchChange: %q, len(Changesets): %d", args.BatchChange, len(args.Changesets)))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	batchChangeID, changesetIDs, err := unmarshalBulkOperationBaseArgs(args.BulkOperationBaseArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: CreateChangesetJobs checks whether current user is authorized.
+	svc := service.New(r.store)
+	published := btypes.ChangesetPublicationStatePublished
+	bulkGroupID, err := svc.CreateChangesetJobs(
+		ctx,
+		batchChangeID,
+		changesetIDs,
+		btypes.ChangesetJobTypeUpdateBranch,
+		&btypes.ChangesetJobUpdateBranchPayload{},
+		store.ListChangesetsOpts{
+			PublicationState: &published,
+			ReconcilerStates: []btypes.ReconcilerState{btypes.ReconcilerStateCompleted},
+			ExternalStates:   []btypes.ChangesetExternalState{btypes.ChangesetExternalStateOpen, btypes.ChangesetExternalStateDraft},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.bulkOperationByIDString(ctx, bulkGroupID)
+}
+
 func (r *Resolver) CreateBatchSpecExecution(ctx context.Context, args *graphqlbackend.CreateBatchSpecExecutionArgs) (_ graphqlbackend.BatchSpecExecutionResolver, err error) {
 	tr, ctx := trace.New(ctx, "Resolver.CreateBatchSpecExecution", "")
 	defer func() {
@@ -1433,6 +1824,14 @@ func (r *Resolver) CreateBatchSpecExecution(ctx context.Context, args *graphqlba
 		return nil, err
 	}
 
+	spec, err := btypes.NewBatchSpecFromRaw(args.Spec)
+	if err != nil {
+		return nil, err
+	}
+	if err := spec.Spec.ValidateServerSideExecution(); err != nil {
+		return nil, err
+	}
+
 	actor := actor.FromContext(ctx)
 
 	exec := &btypes.BatchSpecExecution{
@@ -1462,6 +1861,175 @@ func (r *Resolver) CreateBatchSpecExecution(ctx context.Context, args *graphqlba
 	return r.batchSpecExecutionByID(ctx, marshalBatchSpecExecutionRandID(exec.RandID))
 }
 
+func (r *Resolver) RetryBatchSpecWorkspace(ctx context.Context, args *graphqlbackend.RetryBatchSpecWorkspaceArgs) (_ graphqlbackend.BatchSpecWorkspaceResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.RetryBatchSpecWorkspace", fmt.Sprintf("Workspace: %q", args.Workspace))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Check that the requesting user is admin.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	id, err := unmarshalBatchSpecWorkspaceID(args.Workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	if id == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	job, err := r.store.RetryBatchSpecWorkspaceJob(ctx, id)
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, errors.New("workspace not found or not in a failed state")
+		}
+		return nil, err
+	}
+
+	return &batchSpecWorkspaceResolver{store: r.store, job: job}, nil
+}
+
+func (r *Resolver) CreateBatchSpecTemplate(ctx context.Context, args *graphqlbackend.CreateBatchSpecTemplateArgs) (_ graphqlbackend.BatchSpecTemplateResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.CreateBatchSpecTemplate", fmt.Sprintf("Name: %q", args.Name))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	template := &btypes.BatchSpecTemplate{
+		Name:        args.Name,
+		Description: args.Description,
+		Template:    args.Template,
+		Parameters:  make([]btypes.BatchSpecTemplateParameter, 0, len(args.Parameters)),
+	}
+
+	if args.Namespace != nil {
+		var userID int32
+		if err := graphqlbackend.UnmarshalNamespaceID(*args.Namespace, &userID, &template.NamespaceOrgID); err != nil {
+			return nil, err
+		}
+		if template.NamespaceOrgID == 0 {
+			return nil, errors.New("batch spec templates can only belong to orgs")
+		}
+	}
+
+	for _, p := range args.Parameters {
+		parameter := btypes.BatchSpecTemplateParameter{
+			Name:        p.Name,
+			Type:        btypes.BatchSpecTemplateParameterType(strings.ToLower(p.Type)),
+			Description: p.Description,
+			Required:    p.Required,
+		}
+		if p.DefaultValue != nil {
+			parameter.Default = p.DefaultValue.Value
+		}
+		template.Parameters = append(template.Parameters, parameter)
+	}
+
+	// 🚨 SECURITY: CreateBatchSpecTemplate checks whether the current user may
+	// create templates in the namespace.
+	if err := service.New(r.store).CreateBatchSpecTemplate(ctx, template); err != nil {
+		if errors.HasType(err, service.ErrInvalidBatchSpecTemplate{}) {
+			return nil, ErrInvalidBatchSpecTemplate{SourceErr: err}
+		}
+		if err == store.ErrBatchSpecTemplateNameExists {
+			return nil, ErrDuplicateBatchSpecTemplate{}
+		}
+		return nil, err
+	}
+
+	return &batchSpecTemplateResolver{store: r.store, template: template}, nil
+}
+
+func (r *Resolver) DeleteBatchSpecTemplate(ctx context.Context, args *graphqlbackend.DeleteBatchSpecTemplateArgs) (_ *graphqlbackend.EmptyResponse, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.DeleteBatchSpecTemplate", fmt.Sprintf("Template: %q", args.BatchSpecTemplate))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	templateID, err := unmarshalBatchSpecTemplateID(args.BatchSpecTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	if templateID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	// 🚨 SECURITY: DeleteBatchSpecTemplate checks whether the current user may
+	// delete the template.
+	if err := service.New(r.store).DeleteBatchSpecTemplate(ctx, templateID); err != nil {
+		return nil, err
+	}
+
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+func (r *Resolver) InstantiateBatchSpecTemplate(ctx context.Context, args *graphqlbackend.InstantiateBatchSpecTemplateArgs) (_ graphqlbackend.BatchSpecResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.InstantiateBatchSpecTemplate", fmt.Sprintf("Template: %q", args.BatchSpecTemplate))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if err := batchChangesCreateAccess(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	templateID, err := unmarshalBatchSpecTemplateID(args.BatchSpecTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	if templateID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	opts := service.InstantiateBatchSpecTemplateOpts{TemplateID: templateID}
+
+	if args.Inputs != nil && args.Inputs.Value != nil {
+		inputs, ok := args.Inputs.Value.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("inputs must be a JSON object")
+		}
+		opts.Inputs = inputs
+	}
+
+	if args.Namespace != nil {
+		if err := graphqlbackend.UnmarshalNamespaceID(*args.Namespace, &opts.NamespaceUserID, &opts.NamespaceOrgID); err != nil {
+			return nil, err
+		}
+	} else {
+		opts.NamespaceUserID = actor.FromContext(ctx).UID
+	}
+
+	// 🚨 SECURITY: InstantiateBatchSpecTemplate checks whether the current
+	// user may use the template and has access to the namespace.
+	spec, err := service.New(r.store).InstantiateBatchSpecTemplate(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchSpecResolver{store: r.store, batchSpec: spec}, nil
+}
+
 func parseBatchChangeState(s *string) (btypes.BatchChangeState, error) {
 	if s == nil {
 		return btypes.BatchChangeStateAny, nil
@@ -1489,6 +2057,32 @@ func checkSiteAdminOrSameUser(ctx context.Context, db dbutil.DB, userID int32) (
 	return true, nil
 }
 
+func checkBatchChangeAdmin(ctx context.Context, svc *service.Service, batchChange *btypes.BatchChange) (bool, error) {
+	// 🚨 SECURITY: Only site admins, the owner and the admins of a batch
+	// change have batch change admin rights.
+	if err := svc.CheckBatchChangeAdmin(ctx, batchChange); err != nil {
+		if errors.HasType(err, &backend.InsufficientAuthorizationError{}) {
+			return false, nil
+		}
+
+		return false, err
+	}
+	return true, nil
+}
+
+func checkBatchSpecTemplateAdmin(ctx context.Context, svc *service.Service, namespaceOrgID int32) (bool, error) {
+	// 🚨 SECURITY: Only site admins can manage site-wide templates, and only
+	// org members and site admins can manage org templates.
+	if err := svc.CheckBatchSpecTemplateAdmin(ctx, namespaceOrgID); err != nil {
+		if err == backend.ErrMustBeSiteAdmin || err == backend.ErrNotAnOrgMember || err == backend.ErrNotAuthenticated {
+			return false, nil
+		}
+
+		return false, err
+	}
+	return true, nil
+}
+
 func validateFirstParam(first int32, max int) error {
 	if first < 0 || first > int32(max) {
 		return ErrInvalidFirstParameter{Min: 0, Max: max, First: int(first)}
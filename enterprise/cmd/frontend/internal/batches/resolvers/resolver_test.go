@@ -2,9 +2,14 @@ package resolvers
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -54,6 +59,7 @@ func TestNullIDResilience(t *testing.T) {
 		marshalBatchChangesCredentialID(0, false),
 		marshalBatchChangesCredentialID(0, true),
 		marshalBulkOperationID(""),
+		marshalBatchSpecTemplateID(0),
 	}
 
 	for _, id := range ids {
@@ -77,9 +83,13 @@ func TestNullIDResilience(t *testing.T) {
 		fmt.Sprintf(`mutation { applyBatchChange(batchSpec: %q) { id } }`, marshalBatchSpecRandID("")),
 		fmt.Sprintf(`mutation { createBatchChange(batchSpec: %q) { id } }`, marshalBatchSpecRandID("")),
 		fmt.Sprintf(`mutation { moveBatchChange(batchChange: %q, newName: "foobar") { id } }`, marshalBatchChangeID(0)),
+		fmt.Sprintf(`mutation { transferBatchChangeOwnership(batchChange: %q, newOwner: %q) { id } }`, marshalBatchChangeID(0), graphqlbackend.MarshalUserID(1)),
+		fmt.Sprintf(`mutation { addBatchChangeAdmin(batchChange: %q, admin: %q) { id } }`, marshalBatchChangeID(0), graphqlbackend.MarshalUserID(1)),
+		fmt.Sprintf(`mutation { removeBatchChangeAdmin(batchChange: %q, admin: %q) { id } }`, marshalBatchChangeID(0), graphqlbackend.MarshalUserID(1)),
 		fmt.Sprintf(`mutation { createBatchChangesCredential(externalServiceKind: GITHUB, externalServiceURL: "http://test", credential: "123123", user: %q) { id } }`, graphqlbackend.MarshalUserID(0)),
 		fmt.Sprintf(`mutation { deleteBatchChangesCredential(batchChangesCredential: %q) { alwaysNil } }`, marshalBatchChangesCredentialID(0, false)),
 		fmt.Sprintf(`mutation { deleteBatchChangesCredential(batchChangesCredential: %q) { alwaysNil } }`, marshalBatchChangesCredentialID(0, true)),
+		fmt.Sprintf(`mutation { deleteBatchChangesCommitSigningKey(commitSigningKey: %q) { alwaysNil } }`, marshalBatchChangesCommitSigningKeyID(0)),
 		fmt.Sprintf(`mutation { createChangesetComments(batchChange: %q, changesets: [], body: "test") { id } }`, marshalBatchChangeID(0)),
 		fmt.Sprintf(`mutation { createChangesetComments(batchChange: %q, changesets: [%q], body: "test") { id } }`, marshalBatchChangeID(1), marshalChangesetID(0)),
 		fmt.Sprintf(`mutation { reenqueueChangesets(batchChange: %q, changesets: []) { id } }`, marshalBatchChangeID(0)),
@@ -90,6 +100,12 @@ func TestNullIDResilience(t *testing.T) {
 		fmt.Sprintf(`mutation { closeChangesets(batchChange: %q, changesets: [%q]) { id } }`, marshalBatchChangeID(1), marshalChangesetID(0)),
 		fmt.Sprintf(`mutation { publishChangesets(batchChange: %q, changesets: []) { id } }`, marshalBatchChangeID(0)),
 		fmt.Sprintf(`mutation { publishChangesets(batchChange: %q, changesets: [%q]) { id } }`, marshalBatchChangeID(1), marshalChangesetID(0)),
+		fmt.Sprintf(`mutation { rerunChangesetChecks(batchChange: %q, changesets: []) { id } }`, marshalBatchChangeID(0)),
+		fmt.Sprintf(`mutation { rerunChangesetChecks(batchChange: %q, changesets: [%q]) { id } }`, marshalBatchChangeID(1), marshalChangesetID(0)),
+		fmt.Sprintf(`mutation { updateChangesetBranches(batchChange: %q, changesets: []) { id } }`, marshalBatchChangeID(0)),
+		fmt.Sprintf(`mutation { updateChangesetBranches(batchChange: %q, changesets: [%q]) { id } }`, marshalBatchChangeID(1), marshalChangesetID(0)),
+		fmt.Sprintf(`mutation { deleteBatchSpecTemplate(batchSpecTemplate: %q) { alwaysNil } }`, marshalBatchSpecTemplateID(0)),
+		fmt.Sprintf(`mutation { instantiateBatchSpecTemplate(batchSpecTemplate: %q) { id } }`, marshalBatchSpecTemplateID(0)),
 	}
 
 	for _, m := range mutations {
@@ -1271,6 +1287,134 @@ mutation($batchChangesCredential: ID!) {
 }
 `
 
+func TestBatchChangesCommitSigningKeys(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	db := dbtest.NewDB(t, "")
+
+	adminID := ct.CreateTestUser(t, db, true).ID
+	userID := ct.CreateTestUser(t, db, false).ID
+
+	cstore := store.New(db, &observation.TestContext, nil)
+
+	r := &Resolver{store: cstore}
+	s, err := graphqlbackend.NewSchema(db, r, nil, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	input := map[string]interface{}{
+		"externalServiceKind": extsvc.KindGitHub,
+		"externalServiceURL":  "https://github.com/",
+		"format":              "SSH",
+		"privateKey":          string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+	}
+
+	t.Run("non-admin", func(t *testing.T) {
+		var response struct {
+			CreateBatchChangesCommitSigningKey apitest.BatchChangesCommitSigningKey
+		}
+		errs := apitest.Exec(actor.WithActor(ctx, actor.FromUser(userID)), t, s, input, &response, mutationCreateCommitSigningKey)
+		if len(errs) != 1 {
+			t.Fatalf("expected single error, got %d", len(errs))
+		}
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		invalid := map[string]interface{}{}
+		for k, v := range input {
+			invalid[k] = v
+		}
+		invalid["privateKey"] = "not a key"
+
+		var response struct {
+			CreateBatchChangesCommitSigningKey apitest.BatchChangesCommitSigningKey
+		}
+		errs := apitest.Exec(actor.WithActor(ctx, actor.FromUser(adminID)), t, s, invalid, &response, mutationCreateCommitSigningKey)
+		if len(errs) != 1 {
+			t.Fatalf("expected single error, got %d", len(errs))
+		}
+		if have, want := errs[0].Extensions["code"], "ErrInvalidCommitSigningKey"; have != want {
+			t.Fatalf("wrong error code. want=%q, have=%q", want, have)
+		}
+	})
+
+	var keyID string
+	t.Run("create", func(t *testing.T) {
+		actorCtx := actor.WithActor(ctx, actor.FromUser(adminID))
+
+		var response struct {
+			CreateBatchChangesCommitSigningKey apitest.BatchChangesCommitSigningKey
+		}
+		apitest.MustExec(actorCtx, t, s, input, &response, mutationCreateCommitSigningKey)
+
+		key := response.CreateBatchChangesCommitSigningKey
+		if key.Format != "SSH" {
+			t.Fatalf("wrong format: %q", key.Format)
+		}
+		if !strings.HasPrefix(key.Fingerprint, "SHA256:") {
+			t.Fatalf("wrong fingerprint: %q", key.Fingerprint)
+		}
+		keyID = key.ID
+
+		errs := apitest.Exec(actorCtx, t, s, input, &response, mutationCreateCommitSigningKey)
+		if len(errs) != 1 {
+			t.Fatalf("expected single error, got %d", len(errs))
+		}
+		if have, want := errs[0].Extensions["code"], "ErrDuplicateCommitSigningKey"; have != want {
+			t.Fatalf("wrong error code. want=%q, have=%q", want, have)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		deleteInput := map[string]interface{}{"commitSigningKey": keyID}
+		var response struct{ DeleteBatchChangesCommitSigningKey apitest.EmptyResponse }
+
+		errs := apitest.Exec(actor.WithActor(ctx, actor.FromUser(userID)), t, s, deleteInput, &response, mutationDeleteCommitSigningKey)
+		if len(errs) != 1 {
+			t.Fatalf("expected single error, got %d", len(errs))
+		}
+
+		actorCtx := actor.WithActor(ctx, actor.FromUser(adminID))
+		apitest.MustExec(actorCtx, t, s, deleteInput, &response, mutationDeleteCommitSigningKey)
+
+		errs = apitest.Exec(actorCtx, t, s, deleteInput, &response, mutationDeleteCommitSigningKey)
+		if len(errs) != 1 {
+			t.Fatalf("expected single error, got %d", len(errs))
+		}
+	})
+}
+
+const mutationCreateCommitSigningKey = `
+mutation($externalServiceKind: ExternalServiceKind!, $externalServiceURL: String!, $format: CommitSigningKeyFormat!, $privateKey: String!) {
+  createBatchChangesCommitSigningKey(externalServiceKind: $externalServiceKind, externalServiceURL: $externalServiceURL, format: $format, privateKey: $privateKey) {
+    id
+    externalServiceKind
+    externalServiceURL
+    format
+    fingerprint
+  }
+}
+`
+
+const mutationDeleteCommitSigningKey = `
+mutation($commitSigningKey: ID!) {
+  deleteBatchChangesCommitSigningKey(commitSigningKey: $commitSigningKey) { alwaysNil }
+}
+`
+
 func TestCreateChangesetComments(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -1610,7 +1754,18 @@ func TestResolver_CreateBatchSpecExecution(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	testSpec := `testSpec: yeah`
+	testSpec := `name: testing
+on:
+  - repository: github.com/sourcegraph/sourcegraph
+steps:
+  - run: echo "Hello World" > README.md
+    container: alpine:3
+changesetTemplate:
+  title: Hello World
+  branch: hello-world
+  commit:
+    message: Hello World
+`
 	mutateAndAssert := func(namespaceID, expectNamespaceID string) {
 		input := map[string]interface{}{
 			"spec": testSpec,
@@ -1895,3 +2050,190 @@ mutation($batchChange: ID!, $changesets: [ID!]!, $draft: Boolean!) {
 `
 
 func stringPtr(s string) *string { return &s }
+
+func TestBatchSpecTemplates(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	db := dbtest.NewDB(t, "")
+
+	adminID := ct.CreateTestUser(t, db, true).ID
+	userID := ct.CreateTestUser(t, db, false).ID
+
+	cstore := store.New(db, &observation.TestContext, nil)
+
+	r := &Resolver{store: cstore}
+	s, err := graphqlbackend.NewSchema(db, r, nil, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	input := map[string]interface{}{
+		"name": "bump",
+		"template": `name: bump-${{ inputs.package }}
+on:
+  - repositoriesMatchingQuery: ${{ inputs.package }} file:package.json
+steps:
+  - run: npm install ${{ inputs.package }}@latest
+    container: node:16
+changesetTemplate:
+  title: Bump ${{ inputs.package }}
+  body: Bump ${{ inputs.package }}
+  branch: bump-${{ inputs.package }}
+  commit:
+    message: Bump ${{ inputs.package }}
+  published: false
+`,
+		"parameters": []map[string]interface{}{
+			{"name": "package", "type": "STRING", "required": true},
+		},
+	}
+
+	t.Run("non-admin", func(t *testing.T) {
+		var response struct{ CreateBatchSpecTemplate apitest.BatchSpecTemplate }
+		errs := apitest.Exec(actor.WithActor(ctx, actor.FromUser(userID)), t, s, input, &response, mutationCreateBatchSpecTemplate)
+		if len(errs) != 1 {
+			t.Fatalf("expected single error, got %d", len(errs))
+		}
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		invalid := map[string]interface{}{}
+		for k, v := range input {
+			invalid[k] = v
+		}
+		invalid["parameters"] = []map[string]interface{}{}
+
+		var response struct{ CreateBatchSpecTemplate apitest.BatchSpecTemplate }
+		errs := apitest.Exec(actor.WithActor(ctx, actor.FromUser(adminID)), t, s, invalid, &response, mutationCreateBatchSpecTemplate)
+		if len(errs) != 1 {
+			t.Fatalf("expected single error, got %d", len(errs))
+		}
+		if have, want := errs[0].Extensions["code"], "ErrInvalidBatchSpecTemplate"; have != want {
+			t.Fatalf("wrong error code. want=%q, have=%q", want, have)
+		}
+	})
+
+	var templateID string
+	t.Run("create", func(t *testing.T) {
+		actorCtx := actor.WithActor(ctx, actor.FromUser(adminID))
+
+		var response struct{ CreateBatchSpecTemplate apitest.BatchSpecTemplate }
+		apitest.MustExec(actorCtx, t, s, input, &response, mutationCreateBatchSpecTemplate)
+
+		template := response.CreateBatchSpecTemplate
+		if template.Name != "bump" {
+			t.Fatalf("wrong name: %q", template.Name)
+		}
+		if len(template.Parameters) != 1 || template.Parameters[0].Type != "STRING" || !template.Parameters[0].Required {
+			t.Fatalf("wrong parameters: %+v", template.Parameters)
+		}
+		if template.Creator.DatabaseID != adminID {
+			t.Fatalf("wrong creator: %d", template.Creator.DatabaseID)
+		}
+		templateID = template.ID
+
+		errs := apitest.Exec(actorCtx, t, s, input, &response, mutationCreateBatchSpecTemplate)
+		if len(errs) != 1 {
+			t.Fatalf("expected single error, got %d", len(errs))
+		}
+		if have, want := errs[0].Extensions["code"], "ErrDuplicateBatchSpecTemplate"; have != want {
+			t.Fatalf("wrong error code. want=%q, have=%q", want, have)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		var response struct {
+			BatchSpecTemplates struct{ Nodes []apitest.BatchSpecTemplate }
+		}
+		apitest.MustExec(actor.WithActor(ctx, actor.FromUser(userID)), t, s, nil, &response, queryBatchSpecTemplates)
+
+		nodes := response.BatchSpecTemplates.Nodes
+		if len(nodes) != 1 || nodes[0].ID != templateID {
+			t.Fatalf("wrong templates: %+v", nodes)
+		}
+		if nodes[0].ViewerCanAdminister {
+			t.Fatal("non-admin can administer site template")
+		}
+	})
+
+	t.Run("instantiate", func(t *testing.T) {
+		actorCtx := actor.WithActor(ctx, actor.FromUser(userID))
+		instantiateInput := map[string]interface{}{
+			"batchSpecTemplate": templateID,
+			"inputs":            map[string]interface{}{"package": "lodash"},
+		}
+
+		var response struct{ InstantiateBatchSpecTemplate apitest.BatchSpec }
+		apitest.MustExec(actorCtx, t, s, instantiateInput, &response, mutationInstantiateBatchSpecTemplate)
+
+		spec := response.InstantiateBatchSpecTemplate
+		if !strings.Contains(spec.OriginalInput, "name: bump-lodash") {
+			t.Fatalf("template not rendered:\n%s", spec.OriginalInput)
+		}
+		if have, want := spec.Namespace.DatabaseID, userID; have != want {
+			t.Fatalf("wrong namespace. want=%d, have=%d", want, have)
+		}
+
+		instantiateInput["inputs"] = map[string]interface{}{}
+		errs := apitest.Exec(actorCtx, t, s, instantiateInput, &response, mutationInstantiateBatchSpecTemplate)
+		if len(errs) != 1 {
+			t.Fatalf("expected single error, got %d", len(errs))
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		deleteInput := map[string]interface{}{"batchSpecTemplate": templateID}
+		var response struct{ DeleteBatchSpecTemplate apitest.EmptyResponse }
+
+		errs := apitest.Exec(actor.WithActor(ctx, actor.FromUser(userID)), t, s, deleteInput, &response, mutationDeleteBatchSpecTemplate)
+		if len(errs) != 1 {
+			t.Fatalf("expected single error, got %d", len(errs))
+		}
+
+		apitest.MustExec(actor.WithActor(ctx, actor.FromUser(adminID)), t, s, deleteInput, &response, mutationDeleteBatchSpecTemplate)
+	})
+}
+
+const fragmentBatchSpecTemplate = `
+fragment t on BatchSpecTemplate {
+  id
+  name
+  description
+  template
+  parameters { name type description required defaultValue }
+  creator { databaseID }
+  createdAt
+  viewerCanAdminister
+}
+`
+
+const mutationCreateBatchSpecTemplate = `
+mutation($name: String!, $template: String!, $parameters: [BatchSpecTemplateParameterInput!]!) {
+  createBatchSpecTemplate(name: $name, template: $template, parameters: $parameters) { ...t }
+}
+` + fragmentBatchSpecTemplate
+
+const queryBatchSpecTemplates = `
+query {
+  batchSpecTemplates { nodes { ...t } }
+}
+` + fragmentBatchSpecTemplate
+
+const mutationInstantiateBatchSpecTemplate = `
+mutation($batchSpecTemplate: ID!, $inputs: JSONValue) {
+  instantiateBatchSpecTemplate(batchSpecTemplate: $batchSpecTemplate, inputs: $inputs) {
+    id
+    originalInput
+    namespace { ... on User { databaseID } }
+  }
+}
+`
+
+const mutationDeleteBatchSpecTemplate = `
+mutation($batchSpecTemplate: ID!) {
+  deleteBatchSpecTemplate(batchSpecTemplate: $batchSpecTemplate) { alwaysNil }
+}
+`
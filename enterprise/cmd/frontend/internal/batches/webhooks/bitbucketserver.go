@@ -1,6 +1,7 @@
 package webhooks
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -11,7 +12,9 @@ import (
 	"github.com/hashicorp/go-multierror"
 	"github.com/inconshreveable/log15"
 
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/webhooks"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
@@ -36,12 +39,19 @@ func (h *BitbucketServerWebhook) ServeHTTP(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	externalServiceID, err := extractExternalServiceID(extSvc)
+	externalServiceID, err := webhooks.ExtractExternalServiceID(extSvc)
 	if err != nil {
 		respond(w, http.StatusInternalServerError, err)
 		return
 	}
 
+	if e, ok := e.(*bitbucketserver.RepoRefsChangedEvent); ok {
+		if err := h.dispatchPushes(r.Context(), externalServiceID, e); err != nil {
+			respond(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
 	prs, ev := h.convertEvent(e)
 
 	m := new(multierror.Error)
@@ -119,6 +129,32 @@ func (h *BitbucketServerWebhook) parseEvent(r *http.Request) (interface{}, *type
 	return e, extSvc, nil
 }
 
+// dispatchPushes dispatches a push event for each branch created or updated by the
+// given refs changed event.
+func (h *BitbucketServerWebhook) dispatchPushes(ctx context.Context, externalServiceID string, e *bitbucketserver.RepoRefsChangedEvent) error {
+	m := new(multierror.Error)
+	for _, change := range e.Changes {
+		if change.Ref.Type != "BRANCH" || change.Type == bitbucketserver.RefChangeTypeDelete {
+			continue
+		}
+
+		err := webhooks.DispatchPush(ctx, webhooks.PushEvent{
+			Repo: api.ExternalRepoSpec{
+				ID:          strconv.Itoa(e.Repository.ID),
+				ServiceType: extsvc.TypeBitbucketServer,
+				ServiceID:   externalServiceID,
+			},
+			Ref:    change.Ref.ID,
+			Commit: change.ToHash,
+		})
+		if err != nil {
+			m = multierror.Append(m, err)
+		}
+	}
+
+	return m.ErrorOrNil()
+}
+
 func (h *BitbucketServerWebhook) convertEvent(theirs interface{}) (prs []PR, ours keyer) {
 	log15.Debug("Bitbucket Server webhook received", "type", fmt.Sprintf("%T", theirs))
 
@@ -55,7 +55,7 @@ func (h *GitHubWebhook) Register(router *webhooks.GitHubWebhook) {
 // it's registered to handle in GitHubWebhook.Register
 func (h *GitHubWebhook) handleGitHubWebhook(ctx context.Context, extSvc *types.ExternalService, payload interface{}) error {
 	m := new(multierror.Error)
-	externalServiceID, err := extractExternalServiceID(extSvc)
+	externalServiceID, err := webhooks.ExtractExternalServiceID(extSvc)
 	if err != nil {
 		return err
 	}
@@ -573,6 +573,7 @@ func (h *GitHubWebhook) commitStatusEvent(e *gh.StatusEvent) *github.CommitStatu
 		SHA:        e.GetSHA(),
 		State:      e.GetState(),
 		Context:    e.GetContext(),
+		TargetURL:  e.GetTargetURL(),
 		ReceivedAt: h.Store.Clock()(),
 	}
 }
@@ -588,9 +589,13 @@ func (h *GitHubWebhook) checkSuiteEvent(cs *gh.CheckSuite) *github.CheckSuite {
 
 func (h *GitHubWebhook) checkRunEvent(cr *gh.CheckRun) *github.CheckRun {
 	return &github.CheckRun{
-		ID:         cr.GetNodeID(),
-		Status:     cr.GetStatus(),
-		Conclusion: cr.GetConclusion(),
-		ReceivedAt: h.Store.Clock()(),
+		ID:           cr.GetNodeID(),
+		Name:         cr.GetName(),
+		Status:       cr.GetStatus(),
+		Conclusion:   cr.GetConclusion(),
+		DetailsURL:   cr.GetDetailsURL(),
+		CompletedAt:  cr.GetCompletedAt().Time,
+		CheckSuiteID: cr.GetCheckSuite().GetNodeID(),
+		ReceivedAt:   h.Store.Clock()(),
 	}
 }
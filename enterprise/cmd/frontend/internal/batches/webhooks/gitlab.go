@@ -10,8 +10,10 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/inconshreveable/log15"
 
+	fewebhooks "github.com/sourcegraph/sourcegraph/cmd/frontend/webhooks"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -131,7 +133,7 @@ func (h *GitLabWebhook) getExternalServiceFromRawID(ctx context.Context, raw str
 func (h *GitLabWebhook) handleEvent(ctx context.Context, extSvc *types.ExternalService, event interface{}) *httpError {
 	log15.Debug("GitLab webhook received", "type", fmt.Sprintf("%T", event))
 
-	esID, err := extractExternalServiceID(extSvc)
+	esID, err := fewebhooks.ExtractExternalServiceID(extSvc)
 	if err != nil {
 		return &httpError{
 			code: http.StatusInternalServerError,
@@ -199,6 +201,15 @@ func (h *GitLabWebhook) handleEvent(ctx context.Context, extSvc *types.ExternalS
 			}
 		}
 		return nil
+
+	case *webhooks.PushEvent:
+		if err := h.handlePushEvent(ctx, esID, e); err != nil {
+			return &httpError{
+				code: http.StatusInternalServerError,
+				err:  err,
+			}
+		}
+		return nil
 	}
 
 	// We don't want to return a non-2XX status code and have GitLab retry the
@@ -248,6 +259,24 @@ func (h *GitLabWebhook) handlePipelineEvent(ctx context.Context, esID string, ev
 	return nil
 }
 
+// handlePushEvent dispatches pushes to branches of the project to the push
+// handlers registered outside of batch changes, such as auto-indexing.
+func (h *GitLabWebhook) handlePushEvent(ctx context.Context, esID string, event *webhooks.PushEvent) error {
+	if event.Deleted() {
+		return nil
+	}
+
+	return fewebhooks.DispatchPush(ctx, fewebhooks.PushEvent{
+		Repo: api.ExternalRepoSpec{
+			ID:          strconv.Itoa(event.Project.ID),
+			ServiceType: extsvc.TypeGitLab,
+			ServiceID:   esID,
+		},
+		Ref:    event.Ref,
+		Commit: event.After,
+	})
+}
+
 func (h *GitLabWebhook) getChangesetForPR(ctx context.Context, tx *store.Store, pr *PR, repo *types.Repo) (*btypes.Changeset, error) {
 	return tx.GetChangeset(ctx, store.GetChangesetOpts{
 		RepoID:              repo.ID,
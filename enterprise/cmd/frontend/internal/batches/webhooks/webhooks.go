@@ -5,20 +5,19 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"net/url"
 	"strconv"
 
 	"github.com/cockroachdb/errors"
 	"github.com/inconshreveable/log15"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/global"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/reconciler"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/state"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
-	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/types"
-	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 type Webhook struct {
@@ -60,33 +59,6 @@ func (h Webhook) getRepoForPR(
 	return rs[0], nil
 }
 
-func extractExternalServiceID(extSvc *types.ExternalService) (string, error) {
-	c, err := extSvc.Configuration()
-	if err != nil {
-		return "", errors.Wrap(err, "Failed to get external service config")
-	}
-
-	var serviceID string
-	switch c := c.(type) {
-	case *schema.GitHubConnection:
-		serviceID = c.Url
-	case *schema.BitbucketServerConnection:
-		serviceID = c.Url
-	case *schema.GitLabConnection:
-		serviceID = c.Url
-	}
-	if serviceID == "" {
-		return "", errors.New("could not determine service id")
-	}
-
-	u, err := url.Parse(serviceID)
-	if err != nil {
-		return "", errors.Wrap(err, "Failed to parse service ID")
-	}
-
-	return extsvc.NormalizeBaseURL(u).String(), nil
-}
-
 type keyer interface {
 	Key() string
 }
@@ -169,12 +141,27 @@ func (h Webhook) upsertChangesetEvent(
 	events, _, err := tx.ListChangesetEvents(ctx, store.ListChangesetEventsOpts{
 		ChangesetIDs: []int64{cs.ID},
 	})
+	if err != nil {
+		return err
+	}
+	prevCheckState, prevReviewState := cs.ExternalCheckState, cs.ExternalReviewState
 	state.SetDerivedState(ctx, tx.Repos(), cs, events)
+	stateChanged := cs.ExternalCheckState != prevCheckState || cs.ExternalReviewState != prevReviewState
 	if err := tx.UpdateChangesetCodeHostState(ctx, cs); err != nil {
 		return err
 	}
 
-	return nil
+	// Like the syncer, hand the changeset to the reconciler if it can now be
+	// merged automatically, so that a check or review reported by a webhook
+	// doesn't have to wait for the next sync.
+	if cs.ReconcilerState != btypes.ReconcilerStateCompleted {
+		return nil
+	}
+	due, err := reconciler.AutoMergeDue(ctx, tx, cs, stateChanged)
+	if err != nil || !due {
+		return err
+	}
+	return tx.EnqueueChangeset(ctx, cs, global.DefaultReconcilerEnqueueState(), btypes.ReconcilerStateCompleted)
 }
 
 type httpError struct {
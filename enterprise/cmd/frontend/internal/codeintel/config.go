@@ -14,6 +14,7 @@ type Config struct {
 	UploadStoreConfig                         *uploadstore.Config
 	AutoIndexEnqueuerConfig                   *enqueuer.Config
 	HunkCacheSize                             int
+	AutoIndexPushDebounceInterval             time.Duration
 	DiagnosticsCountMigrationBatchSize        int
 	DiagnosticsCountMigrationBatchInterval    time.Duration
 	DefinitionsCountMigrationBatchSize        int
@@ -38,6 +39,7 @@ func init() {
 	config.AutoIndexEnqueuerConfig = enqueuerConfig
 
 	config.HunkCacheSize = config.GetInt("PRECISE_CODE_INTEL_HUNK_CACHE_SIZE", "1000", "The capacity of the git diff hunk cache.")
+	config.AutoIndexPushDebounceInterval = config.GetInterval("PRECISE_CODE_INTEL_AUTO_INDEX_PUSH_DEBOUNCE_INTERVAL", "1m", "The time to wait after a push to a repository's default branch before processing the index jobs queued for the latest pushed commit.")
 	config.DiagnosticsCountMigrationBatchSize = config.GetInt("PRECISE_CODE_INTEL_DIAGNOSTICS_COUNT_MIGRATION_BATCH_SIZE", "1000", "The maximum number of document records to migrate at a time.")
 	config.DiagnosticsCountMigrationBatchInterval = config.GetInterval("PRECISE_CODE_INTEL_DIAGNOSTICS_COUNT_MIGRATION_BATCH_INTERVAL", "1s", "The timeout between processing migration batches.")
 	config.DefinitionsCountMigrationBatchSize = config.GetInt("PRECISE_CODE_INTEL_DEFINITIONS_COUNT_MIGRATION_BATCH_SIZE", "1000", "The maximum number of definition records to migrate at once.")
@@ -5,17 +5,25 @@ import (
 	"net/http"
 
 	"github.com/cockroachdb/errors"
+	"github.com/google/zoekt"
+	zoektquery "github.com/google/zoekt/query"
 	"github.com/inconshreveable/log15"
 	"github.com/opentracing/opentracing-go"
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/webhooks"
 	codeintelresolvers "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers"
 	codeintelgqlresolvers "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers/graphql"
+	codeintelwebhooks "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/oobmigration"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/backend"
+	"github.com/sourcegraph/sourcegraph/internal/symbols"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 )
 
@@ -44,6 +52,8 @@ func Init(ctx context.Context, db dbutil.DB, outOfBandMigrationRunner *oobmigrat
 		return err
 	}
 
+	registerPushHandler(db)
+
 	enterpriseServices.CodeIntelResolver = resolver
 	enterpriseServices.NewCodeIntelUploadHandler = uploadHandler
 	return nil
@@ -61,6 +71,8 @@ func newResolver(ctx context.Context, db dbutil.DB, observationContext *observat
 		services.gitserverClient,
 		services.indexEnqueuer,
 		hunkCache,
+		symbols.DefaultClient,
+		indexedSearcher{search.Indexed()},
 		observationContext,
 	)
 	resolver := codeintelgqlresolvers.NewResolver(db, innerResolver)
@@ -68,6 +80,28 @@ func newResolver(ctx context.Context, db dbutil.DB, observationContext *observat
 	return resolver, err
 }
 
+// indexedSearcher adapts the indexed search backend shared by the frontend to the interface
+// used by search-based code navigation.
+type indexedSearcher struct {
+	*backend.Zoekt
+}
+
+func (s indexedSearcher) Search(ctx context.Context, q zoektquery.Q, opts *zoekt.SearchOptions) (*zoekt.SearchResult, error) {
+	return s.Client.Search(ctx, q, opts)
+}
+
+func registerPushHandler(db dbutil.DB) {
+	pushHandler := codeintelwebhooks.NewPushHandler(
+		database.Repos(db),
+		services.dbStore,
+		services.gitserverClient,
+		services.indexEnqueuer,
+		config.AutoIndexPushDebounceInterval,
+	)
+
+	webhooks.RegisterPushHandler(pushHandler.Handle)
+}
+
 func newUploadHandler(ctx context.Context, db dbutil.DB) (func(internal bool) http.Handler, error) {
 	internalHandler, err := NewCodeIntelUploadHandler(ctx, db, true)
 	if err != nil {
@@ -0,0 +1,38 @@
+package resolvers
+
+import (
+	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+)
+
+// Confidence describes how closely the uploads used to answer code intelligence queries
+// correspond to the requested commit. Results from uploads with a lower confidence have
+// had their positions adjusted across a larger diff and are more likely to be imprecise.
+type Confidence string
+
+const (
+	// ConfidenceExact indicates that every upload was indexed at the requested commit.
+	ConfidenceExact Confidence = "EXACT"
+
+	// ConfidenceNearestUpload indicates that the uploads are visible from the requested
+	// commit in the commit graph, and positions were adjusted across the diff between
+	// the requested commit and the indexed commit.
+	ConfidenceNearestUpload Confidence = "NEAREST_UPLOAD"
+
+	// ConfidenceRelatedBranch indicates that no upload is visible from the requested commit,
+	// and the uploads were instead found on a related branch by way of a merge base. The
+	// positions were adjusted across the diff between the requested commit and the indexed
+	// commit, which may include unrelated changes made on both branches.
+	ConfidenceRelatedBranch Confidence = "RELATED_BRANCH"
+)
+
+// visibleUploadConfidence returns the confidence of results produced by the given set of
+// uploads visible from the given commit.
+func visibleUploadConfidence(commit string, uploads []store.Dump) Confidence {
+	for i := range uploads {
+		if uploads[i].Commit != commit {
+			return ConfidenceNearestUpload
+		}
+	}
+
+	return ConfidenceExact
+}
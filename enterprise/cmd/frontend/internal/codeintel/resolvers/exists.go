@@ -2,6 +2,7 @@ package resolvers
 
 import (
 	"context"
+	"sort"
 	"strings"
 
 	"github.com/cockroachdb/errors"
@@ -25,7 +26,7 @@ const numAncestors = 100
 // exact document path are returned. Otherwise, dumps containing any document for which the given
 // path is a prefix are returned. These dump IDs should be subsequently passed to invocations of
 // Definitions, References, and Hover.
-func (r *resolver) findClosestDumps(ctx context.Context, cachedCommitChecker *cachedCommitChecker, repositoryID int, commit, path string, exactPath bool, indexer string) (_ []store.Dump, err error) {
+func (r *resolver) findClosestDumps(ctx context.Context, cachedCommitChecker *cachedCommitChecker, cachedRepositoryChecker *cachedRepositoryChecker, repositoryID int, commit, path string, exactPath bool, indexer string) (_ []store.Dump, err error) {
 	ctx, traceLog, endObservation := r.operations.findClosestDumps.WithAndLogger(ctx, &err, observation.Args{
 		LogFields: []log.Field{
 			log.Int("repositoryID", repositoryID),
@@ -37,7 +38,7 @@ func (r *resolver) findClosestDumps(ctx context.Context, cachedCommitChecker *ca
 	})
 	defer endObservation(1, observation.Args{})
 
-	candidates, err := r.inferClosestUploads(ctx, repositoryID, commit, path, exactPath, indexer)
+	candidates, err := r.inferClosestUploads(ctx, cachedRepositoryChecker, repositoryID, commit, path, exactPath, indexer)
 	if err != nil {
 		return nil, err
 	}
@@ -46,6 +47,144 @@ func (r *resolver) findClosestDumps(ctx context.Context, cachedCommitChecker *ca
 		log.String("candidates", uploadIDsToString(candidates)),
 	)
 
+	filtered, err := r.filterClosestDumps(ctx, cachedCommitChecker, candidates, path, exactPath, traceLog)
+	if err != nil {
+		return nil, err
+	}
+
+	return filtered, nil
+}
+
+// findClosestDumpsOnRelatedBranches returns the set of dumps that can answer code intelligence
+// queries for the given path when no dump is visible from the given commit. This occurs when the
+// commit is on a branch that forked from its parent before the parent was indexed, or when the
+// commit was rebased and its new ancestors have not been indexed.
+//
+// We compute the merge base of the given commit with the tip of the default branch and a small
+// number of recently updated branches, and return the dumps visible from the first such merge base
+// for which there is data. The merge bases are cached per commit, as they are the same for all of
+// the paths requested while browsing it. These dumps may be indexed at a commit that is neither an
+// ancestor nor a descendant of the given commit, so results from these dumps must have their
+// positions adjusted across the (possibly large) diff between the two commits.
+func (r *resolver) findClosestDumpsOnRelatedBranches(ctx context.Context, cachedCommitChecker *cachedCommitChecker, cachedRepositoryChecker *cachedRepositoryChecker, repositoryID int, commit, path string, exactPath bool, indexer string) (_ []store.Dump, err error) {
+	ctx, traceLog, endObservation := r.operations.findClosestDumpsOnRelatedBranches.WithAndLogger(ctx, &err, observation.Args{
+		LogFields: []log.Field{
+			log.Int("repositoryID", repositoryID),
+			log.String("commit", commit),
+			log.String("path", path),
+			log.Bool("exactPath", exactPath),
+			log.String("indexer", indexer),
+		},
+	})
+	defer endObservation(1, observation.Args{})
+
+	// Repository has no LSIF data at all, so there is nothing to find on other branches either
+	if repositoryExists, err := cachedRepositoryChecker.exists(ctx, repositoryID); err != nil {
+		return nil, err
+	} else if !repositoryExists {
+		return nil, nil
+	}
+
+	mergeBases, err := r.relatedBranchMergeBases(ctx, repositoryID, commit)
+	if err != nil {
+		return nil, err
+	}
+	traceLog(log.Int("numMergeBases", len(mergeBases)))
+
+	for _, mergeBase := range mergeBases {
+		candidates, err := r.dbStore.FindClosestDumps(ctx, repositoryID, mergeBase, path, exactPath, indexer)
+		if err != nil {
+			return nil, errors.Wrap(err, "dbstore.FindClosestDumps")
+		}
+		traceLog(
+			log.String("mergeBase", mergeBase),
+			log.Int("numCandidates", len(candidates)),
+			log.String("candidates", uploadIDsToString(candidates)),
+		)
+
+		filtered, err := r.filterClosestDumps(ctx, cachedCommitChecker, candidates, path, exactPath, traceLog)
+		if err != nil {
+			return nil, err
+		}
+		if len(filtered) != 0 {
+			return filtered, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// relatedBranchMergeBases returns the distinct merge bases of the given commit with the heads of the
+// related branches of its repository, in the order of the branches, excluding the commit itself. The
+// merge bases are the same for every path of the commit, so they are cached for a short time.
+func (r *resolver) relatedBranchMergeBases(ctx context.Context, repositoryID int, commit string) ([]string, error) {
+	if mergeBases, ok := r.mergeBaseCache.get(repositoryID, commit); ok {
+		return mergeBases, nil
+	}
+
+	refDescriptions, err := r.gitserverClient.RefDescriptions(ctx, repositoryID)
+	if err != nil {
+		return nil, errors.Wrap(err, "gitserverClient.RefDescriptions")
+	}
+
+	seen := map[string]struct{}{commit: {}}
+	mergeBases := make([]string, 0, maxRelatedBranches)
+	for _, branchHead := range relatedBranchHeads(refDescriptions, maxRelatedBranches) {
+		mergeBase, ok, err := r.gitserverClient.MergeBase(ctx, repositoryID, commit, branchHead)
+		if err != nil {
+			return nil, errors.Wrap(err, "gitserverClient.MergeBase")
+		}
+		if !ok {
+			continue
+		}
+		if _, ok := seen[mergeBase]; ok {
+			continue
+		}
+		seen[mergeBase] = struct{}{}
+		mergeBases = append(mergeBases, mergeBase)
+	}
+
+	r.mergeBaseCache.set(repositoryID, commit, mergeBases)
+	return mergeBases, nil
+}
+
+// maxRelatedBranches is the maximum number of branches with which we will compute a merge base when
+// trying to find dumps on a related branch. Each branch costs a gitserver and a database round-trip
+// in a latency-sensitive path.
+const maxRelatedBranches = 5
+
+// relatedBranchHeads returns the commits at the tips of at most limit branches. The default branch
+// is always first, followed by the most recently created branches.
+func relatedBranchHeads(refDescriptions map[string]gitserver.RefDescription, limit int) []string {
+	commits := make([]string, 0, len(refDescriptions))
+	for commit, refDescription := range refDescriptions {
+		if refDescription.Type == gitserver.RefTypeBranch {
+			commits = append(commits, commit)
+		}
+	}
+
+	sort.Slice(commits, func(i, j int) bool {
+		di, dj := refDescriptions[commits[i]], refDescriptions[commits[j]]
+		if di.IsDefaultBranch != dj.IsDefaultBranch {
+			return di.IsDefaultBranch
+		}
+		if !di.CreatedDate.Equal(dj.CreatedDate) {
+			return di.CreatedDate.After(dj.CreatedDate)
+		}
+
+		return commits[i] < commits[j]
+	})
+
+	if len(commits) > limit {
+		commits = commits[:limit]
+	}
+
+	return commits
+}
+
+// filterClosestDumps removes the candidate dumps whose commit no longer exists in gitserver. If exactPath
+// is true, then dumps that do not contain the exact document path are also removed.
+func (r *resolver) filterClosestDumps(ctx context.Context, cachedCommitChecker *cachedCommitChecker, candidates []store.Dump, path string, exactPath bool, traceLog observation.TraceLogger) ([]store.Dump, error) {
 	candidatesWithCommits, err := filterUploadsWithCommits(ctx, cachedCommitChecker, candidates)
 	if err != nil {
 		return nil, err
@@ -61,7 +200,7 @@ func (r *resolver) findClosestDumps(ctx context.Context, cachedCommitChecker *ca
 	for i := range candidatesWithCommits {
 		if exactPath {
 			// TODO - this breaks if the file was renamed in git diff
-			pathExists, err := r.lsifStore.Exists(ctx, candidates[i].ID, strings.TrimPrefix(path, candidates[i].Root))
+			pathExists, err := r.lsifStore.Exists(ctx, candidatesWithCommits[i].ID, strings.TrimPrefix(path, candidatesWithCommits[i].Root))
 			if err != nil {
 				return nil, errors.Wrap(err, "lsifStore.Exists")
 			}
@@ -72,7 +211,7 @@ func (r *resolver) findClosestDumps(ctx context.Context, cachedCommitChecker *ca
 			// TODO(efritz) - ensure there's a valid document path for this condition as well
 		}
 
-		filtered = append(filtered, candidates[i])
+		filtered = append(filtered, candidatesWithCommits[i])
 	}
 	traceLog(
 		log.Int("numFiltered", len(filtered)),
@@ -95,7 +234,7 @@ func (r *resolver) findClosestDumps(ctx context.Context, cachedCommitChecker *ca
 // this commit will.
 //
 // TODO(efritz) - show an indication in the GraphQL response and the UI that this repo is refreshing.
-func (r *resolver) inferClosestUploads(ctx context.Context, repositoryID int, commit, path string, exactPath bool, indexer string) ([]store.Dump, error) {
+func (r *resolver) inferClosestUploads(ctx context.Context, cachedRepositoryChecker *cachedRepositoryChecker, repositoryID int, commit, path string, exactPath bool, indexer string) ([]store.Dump, error) {
 	// The parameters exactPath and rootMustEnclosePath align here: if we're looking for dumps
 	// that can answer queries for a directory (e.g. diagnostics), we want any dump that happens
 	// to intersect the target directory. If we're looking for dumps that can answer queries for
@@ -107,8 +246,8 @@ func (r *resolver) inferClosestUploads(ctx context.Context, repositoryID int, co
 	}
 
 	// Repository has no LSIF data at all
-	if repositoryExists, err := r.dbStore.HasRepository(ctx, repositoryID); err != nil {
-		return nil, errors.Wrap(err, "dbstore.HasRepository")
+	if repositoryExists, err := cachedRepositoryChecker.exists(ctx, repositoryID); err != nil {
+		return nil, err
 	} else if !repositoryExists {
 		return nil, nil
 	}
@@ -3,6 +3,7 @@ package resolvers
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 
@@ -37,8 +38,8 @@ func TestFindClosestDumps(t *testing.T) {
 		return commit != "c4", nil
 	})
 
-	resolver := newResolver(mockDBStore, mockLSIFStore, mockGitserverClient, nil, nil, &observation.TestContext)
-	dumps, err := resolver.findClosestDumps(context.Background(), commitChecker, 42, "deadbeef", "s1/main.go", true, "idx")
+	resolver := newResolver(mockDBStore, mockLSIFStore, mockGitserverClient, nil, nil, nil, nil, &observation.TestContext)
+	dumps, err := resolver.findClosestDumps(context.Background(), commitChecker, newCachedRepositoryChecker(mockDBStore), 42, "deadbeef", "s1/main.go", true, "idx")
 	if err != nil {
 		t.Fatalf("unexpected error finding closest dumps: %s", err)
 	}
@@ -92,8 +93,8 @@ func TestFindClosestDumpsInfersClosestUploads(t *testing.T) {
 		return false, nil
 	})
 
-	resolver := newResolver(mockDBStore, mockLSIFStore, mockGitserverClient, nil, nil, &observation.TestContext)
-	dumps, err := resolver.findClosestDumps(context.Background(), commitChecker, 42, "deadbeef", "s1/main.go", true, "idx")
+	resolver := newResolver(mockDBStore, mockLSIFStore, mockGitserverClient, nil, nil, nil, nil, &observation.TestContext)
+	dumps, err := resolver.findClosestDumps(context.Background(), commitChecker, newCachedRepositoryChecker(mockDBStore), 42, "deadbeef", "s1/main.go", true, "idx")
 	if err != nil {
 		t.Fatalf("unexpected error finding closest dumps: %s", err)
 	}
@@ -131,8 +132,8 @@ func TestFindClosestDumpsDoesNotInferClosestUploadForUnknownRepository(t *testin
 	mockGitserverClient := NewMockGitserverClient()
 	commitChecker := newCachedCommitChecker(mockGitserverClient)
 
-	resolver := newResolver(mockDBStore, mockLSIFStore, mockGitserverClient, nil, nil, &observation.TestContext)
-	dumps, err := resolver.findClosestDumps(context.Background(), commitChecker, 42, "deadbeef", "s1/main.go", true, "idx")
+	resolver := newResolver(mockDBStore, mockLSIFStore, mockGitserverClient, nil, nil, nil, nil, &observation.TestContext)
+	dumps, err := resolver.findClosestDumps(context.Background(), commitChecker, newCachedRepositoryChecker(mockDBStore), 42, "deadbeef", "s1/main.go", true, "idx")
 	if err != nil {
 		t.Fatalf("unexpected error finding closest dumps: %s", err)
 	}
@@ -144,3 +145,122 @@ func TestFindClosestDumpsDoesNotInferClosestUploadForUnknownRepository(t *testin
 		t.Errorf("expected number of calls to store.MarkRepositoryAsDirty. want=%d have=%d", 0, value)
 	}
 }
+
+func TestFindClosestDumpsOnRelatedBranches(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockGitserverClient := NewMockGitserverClient()
+	commitChecker := newCachedCommitChecker(mockGitserverClient)
+
+	now := time.Unix(1587396557, 0).UTC()
+
+	mockGitserverClient.RefDescriptionsFunc.SetDefaultReturn(map[string]gitserver.RefDescription{
+		"h1": {Name: "main", Type: gitserver.RefTypeBranch, IsDefaultBranch: true, CreatedDate: now.Add(-time.Hour)},
+		"h2": {Name: "feature-a", Type: gitserver.RefTypeBranch, CreatedDate: now},
+		"h3": {Name: "feature-b", Type: gitserver.RefTypeBranch, CreatedDate: now.Add(-time.Minute)},
+		"h4": {Name: "v1.0.0", Type: gitserver.RefTypeTag, CreatedDate: now},
+	}, nil)
+	mockGitserverClient.MergeBaseFunc.SetDefaultHook(func(ctx context.Context, repositoryID int, a, b string) (string, bool, error) {
+		switch b {
+		case "h1":
+			return "mb1", true, nil
+		case "h2":
+			return "deadbeef", true, nil // requested commit is on this branch
+		case "h3":
+			return "mb3", true, nil
+		}
+		return "", false, nil
+	})
+	mockGitserverClient.CommitExistsFunc.SetDefaultReturn(true, nil)
+	mockDBStore.HasRepositoryFunc.SetDefaultReturn(true, nil)
+	mockDBStore.FindClosestDumpsFunc.SetDefaultHook(func(ctx context.Context, repositoryID int, commit, path string, rootMustEnclosePath bool, indexer string) ([]store.Dump, error) {
+		switch commit {
+		case "mb1":
+			return []store.Dump{{ID: 50, Commit: "c1", Root: "s2/"}}, nil // no file in root
+		case "mb3":
+			return []store.Dump{{ID: 51, Commit: "c3", Root: "s1/"}}, nil
+		}
+		return nil, nil
+	})
+	mockLSIFStore.ExistsFunc.SetDefaultHook(func(ctx context.Context, bundleID int, path string) (bool, error) {
+		return path == "main.go", nil
+	})
+
+	resolver := newResolver(mockDBStore, mockLSIFStore, mockGitserverClient, nil, nil, nil, nil, &observation.TestContext)
+	dumps, err := resolver.findClosestDumpsOnRelatedBranches(context.Background(), commitChecker, newCachedRepositoryChecker(mockDBStore), 42, "deadbeef", "s1/main.go", true, "idx")
+	if err != nil {
+		t.Fatalf("unexpected error finding closest dumps: %s", err)
+	}
+
+	expected := []store.Dump{
+		{ID: 51, Commit: "c3", Root: "s1/"},
+	}
+	if diff := cmp.Diff(expected, dumps); diff != "" {
+		t.Errorf("unexpected dumps (-want +got):\n%s", diff)
+	}
+
+	var mergeBaseHeads []string
+	for _, call := range mockGitserverClient.MergeBaseFunc.History() {
+		mergeBaseHeads = append(mergeBaseHeads, call.Arg3)
+	}
+	if diff := cmp.Diff([]string{"h1", "h2", "h3"}, mergeBaseHeads); diff != "" {
+		t.Errorf("unexpected merge base heads (-want +got):\n%s", diff)
+	}
+
+	var queriedCommits []string
+	for _, call := range mockDBStore.FindClosestDumpsFunc.History() {
+		queriedCommits = append(queriedCommits, call.Arg2)
+	}
+	if diff := cmp.Diff([]string{"mb1", "mb3"}, queriedCommits); diff != "" {
+		t.Errorf("unexpected queried commits (-want +got):\n%s", diff)
+	}
+
+	// The merge bases are cached for other paths of the same commit
+	if _, err := resolver.findClosestDumpsOnRelatedBranches(context.Background(), commitChecker, newCachedRepositoryChecker(mockDBStore), 42, "deadbeef", "s1/other.go", true, "idx"); err != nil {
+		t.Fatalf("unexpected error finding closest dumps: %s", err)
+	}
+	if value := len(mockGitserverClient.RefDescriptionsFunc.History()); value != 1 {
+		t.Errorf("unexpected number of calls to gitserverClient.RefDescriptions. want=%d have=%d", 1, value)
+	}
+	if value := len(mockGitserverClient.MergeBaseFunc.History()); value != 3 {
+		t.Errorf("unexpected number of calls to gitserverClient.MergeBase. want=%d have=%d", 3, value)
+	}
+}
+
+func TestMergeBaseCacheExpires(t *testing.T) {
+	now := time.Unix(1587396557, 0).UTC()
+
+	cache := newMergeBaseCache()
+	cache.now = func() time.Time { return now }
+	cache.set(42, "deadbeef", []string{"mb1"})
+
+	if mergeBases, ok := cache.get(42, "deadbeef"); !ok {
+		t.Errorf("expected merge bases to be cached")
+	} else if diff := cmp.Diff([]string{"mb1"}, mergeBases); diff != "" {
+		t.Errorf("unexpected merge bases (-want +got):\n%s", diff)
+	}
+	if _, ok := cache.get(43, "deadbeef"); ok {
+		t.Errorf("unexpected merge bases for another repository")
+	}
+
+	now = now.Add(mergeBaseCacheTTL)
+	if _, ok := cache.get(42, "deadbeef"); ok {
+		t.Errorf("expected cached merge bases to expire")
+	}
+}
+
+func TestRelatedBranchHeads(t *testing.T) {
+	now := time.Unix(1587396557, 0).UTC()
+
+	refDescriptions := map[string]gitserver.RefDescription{
+		"h1": {Name: "feature-a", Type: gitserver.RefTypeBranch, CreatedDate: now.Add(-time.Minute * 2)},
+		"h2": {Name: "feature-b", Type: gitserver.RefTypeBranch, CreatedDate: now},
+		"h3": {Name: "main", Type: gitserver.RefTypeBranch, IsDefaultBranch: true, CreatedDate: now.Add(-time.Hour)},
+		"h4": {Name: "v1.0.0", Type: gitserver.RefTypeTag, CreatedDate: now.Add(time.Hour)},
+		"h5": {Name: "feature-c", Type: gitserver.RefTypeBranch, CreatedDate: now.Add(-time.Minute)},
+	}
+
+	if diff := cmp.Diff([]string{"h3", "h2", "h5"}, relatedBranchHeads(refDescriptions, 3)); diff != "" {
+		t.Errorf("unexpected branch heads (-want +got):\n%s", diff)
+	}
+}
@@ -1,19 +1,144 @@
 package graphql
 
 import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindex/enqueuer"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbconn"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
 )
 
 type IndexConfigurationResolver struct {
+	resolver      resolvers.Resolver
+	repositoryID  int
 	configuration []byte
+	indexOnPush   bool
 }
 
-func NewIndexConfigurationResolver(configuration []byte) gql.IndexConfigurationResolver {
+func NewIndexConfigurationResolver(resolver resolvers.Resolver, repositoryID int, configuration []byte, indexOnPush bool) gql.IndexConfigurationResolver {
 	return &IndexConfigurationResolver{
+		resolver:      resolver,
+		repositoryID:  repositoryID,
 		configuration: configuration,
+		indexOnPush:   indexOnPush,
 	}
 }
 
 func (r *IndexConfigurationResolver) Configuration() *string {
 	return strPtr(string(r.configuration))
 }
+
+func (r *IndexConfigurationResolver) IndexOnPush() bool {
+	return r.indexOnPush
+}
+
+func (r *IndexConfigurationResolver) InferredConfiguration(ctx context.Context, args *gql.InferredIndexConfigurationArgs) (gql.InferredIndexConfigurationResolver, error) {
+	// 🚨 SECURITY: Only site admins may run inference over repository contents for now
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, dbconn.Global); err != nil {
+		return nil, err
+	}
+
+	marshal, err := indexConfigurationMarshaller(args.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	indexConfiguration, commit, err := r.resolver.InferredIndexConfiguration(ctx, r.repositoryID, args.Rev)
+	if err != nil {
+		return nil, err
+	}
+
+	var configuration *string
+	if indexConfiguration != nil {
+		marshaled, err := marshal(*indexConfiguration)
+		if err != nil {
+			return nil, err
+		}
+
+		configuration = strPtr(string(marshaled))
+	}
+
+	return &inferredIndexConfigurationResolver{
+		commit:        commit,
+		format:        args.Format,
+		configuration: configuration,
+	}, nil
+}
+
+func (r *IndexConfigurationResolver) IndexJobsPreview(ctx context.Context, args *gql.IndexJobsPreviewArgs) (gql.IndexJobsPreviewResolver, error) {
+	// 🚨 SECURITY: Only site admins may run inference over repository contents for now
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, dbconn.Global); err != nil {
+		return nil, err
+	}
+
+	plan, err := r.resolver.PreviewIndexJobs(ctx, r.repositoryID, args.Rev)
+	if err != nil {
+		return nil, err
+	}
+
+	return &indexJobsPreviewResolver{plan: plan}, nil
+}
+
+// indexConfigurationMarshaller returns the function that encodes an index configuration in the given
+// GraphQL IndexConfigurationFormat.
+func indexConfigurationMarshaller(format string) (func(config.IndexConfiguration) ([]byte, error), error) {
+	switch format {
+	case "JSON":
+		return config.MarshalJSON, nil
+	case "YAML":
+		return config.MarshalYAML, nil
+	}
+
+	return nil, errors.Errorf("unknown index configuration format %q", format)
+}
+
+type inferredIndexConfigurationResolver struct {
+	commit        string
+	format        string
+	configuration *string
+}
+
+func (r *inferredIndexConfigurationResolver) Commit() string         { return r.commit }
+func (r *inferredIndexConfigurationResolver) Format() string         { return r.format }
+func (r *inferredIndexConfigurationResolver) Configuration() *string { return r.configuration }
+
+type indexJobsPreviewResolver struct {
+	plan enqueuer.IndexPlan
+}
+
+func (r *indexJobsPreviewResolver) Commit() string { return r.plan.Commit }
+
+func (r *indexJobsPreviewResolver) ConfigurationSource() *string {
+	if r.plan.Source == "" {
+		return nil
+	}
+
+	return strPtr(strings.ToUpper(string(r.plan.Source)))
+}
+
+func (r *indexJobsPreviewResolver) Jobs() []gql.IndexJobPreviewResolver {
+	jobs := make([]gql.IndexJobPreviewResolver, 0, len(r.plan.Indexes))
+	for _, plannedIndex := range r.plan.Indexes {
+		jobs = append(jobs, &indexJobPreviewResolver{plannedIndex: plannedIndex})
+	}
+
+	return jobs
+}
+
+type indexJobPreviewResolver struct {
+	plannedIndex enqueuer.PlannedIndex
+}
+
+func (r *indexJobPreviewResolver) Root() string       { return r.plannedIndex.Index.Root }
+func (r *indexJobPreviewResolver) Indexer() string    { return r.plannedIndex.Index.Indexer }
+func (r *indexJobPreviewResolver) Deduplicated() bool { return r.plannedIndex.Deduplicated }
+
+func (r *indexJobPreviewResolver) Steps() gql.IndexStepsResolver {
+	return &indexStepsResolver{index: r.plannedIndex.Index}
+}
@@ -0,0 +1,133 @@
+package graphql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	resolvermocks "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers/mocks"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindex/enqueuer"
+	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestInferredConfiguration(t *testing.T) {
+	t.Cleanup(func() {
+		database.Mocks.Users.GetByCurrentAuthUser = nil
+	})
+	database.Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) {
+		return &types.User{SiteAdmin: true}, nil
+	}
+
+	mockResolver := resolvermocks.NewMockResolver()
+	mockResolver.InferredIndexConfigurationFunc.SetDefaultReturn(&config.IndexConfiguration{
+		IndexJobs: []config.IndexJob{
+			{Root: "lib", Indexer: "sourcegraph/lsif-go:latest", Outfile: "dump.lsif"},
+		},
+	}, "deadbeef", nil)
+
+	resolver := NewIndexConfigurationResolver(mockResolver, 42, nil, false)
+
+	inferred, err := resolver.InferredConfiguration(context.Background(), &gql.InferredIndexConfigurationArgs{Format: "YAML"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if history := mockResolver.InferredIndexConfigurationFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(history))
+	} else if history[0].Arg1 != 42 || history[0].Arg2 != nil {
+		t.Errorf("unexpected arguments. want=(%d, nil) have=(%d, %v)", 42, history[0].Arg1, history[0].Arg2)
+	}
+
+	if inferred.Commit() != "deadbeef" {
+		t.Errorf("unexpected commit. want=%q have=%q", "deadbeef", inferred.Commit())
+	}
+	if inferred.Format() != "YAML" {
+		t.Errorf("unexpected format. want=%q have=%q", "YAML", inferred.Format())
+	}
+	if inferred.Configuration() == nil {
+		t.Fatalf("expected configuration")
+	}
+
+	configuration, err := config.UnmarshalYAML([]byte(*inferred.Configuration()))
+	if err != nil {
+		t.Fatalf("unexpected error unmarshalling configuration: %s", err)
+	}
+	if len(configuration.IndexJobs) != 1 || configuration.IndexJobs[0].Root != "lib" {
+		t.Errorf("unexpected configuration: %+v", configuration)
+	}
+}
+
+func TestInferredConfigurationUnauthenticated(t *testing.T) {
+	mockResolver := resolvermocks.NewMockResolver()
+	resolver := NewIndexConfigurationResolver(mockResolver, 42, nil, false)
+
+	if _, err := resolver.InferredConfiguration(context.Background(), &gql.InferredIndexConfigurationArgs{Format: "JSON"}); err != backend.ErrNotAuthenticated {
+		t.Errorf("unexpected error. want=%q have=%q", backend.ErrNotAuthenticated, err)
+	}
+	if len(mockResolver.InferredIndexConfigurationFunc.History()) != 0 {
+		t.Errorf("unexpected call to InferredIndexConfiguration")
+	}
+}
+
+func TestIndexJobsPreview(t *testing.T) {
+	t.Cleanup(func() {
+		database.Mocks.Users.GetByCurrentAuthUser = nil
+	})
+	database.Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) {
+		return &types.User{SiteAdmin: true}, nil
+	}
+
+	mockResolver := resolvermocks.NewMockResolver()
+	mockResolver.PreviewIndexJobsFunc.SetDefaultReturn(enqueuer.IndexPlan{
+		Commit: "deadbeef",
+		Source: enqueuer.ConfigurationSourceRepository,
+		Indexes: []enqueuer.PlannedIndex{
+			{Index: store.Index{Root: "a", Indexer: "lsif-go"}, Deduplicated: true},
+			{Index: store.Index{Root: "b", Indexer: "lsif-tsc", DockerSteps: []store.DockerStep{{Image: "node:12"}}}, Deduplicated: true},
+		},
+	}, nil)
+
+	rev := "main"
+	preview, err := NewIndexConfigurationResolver(mockResolver, 42, nil, false).IndexJobsPreview(context.Background(), &gql.IndexJobsPreviewArgs{Rev: &rev})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if history := mockResolver.PreviewIndexJobsFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(history))
+	} else if history[0].Arg2 == nil || *history[0].Arg2 != "main" {
+		t.Errorf("unexpected revision. want=%q have=%v", "main", history[0].Arg2)
+	}
+
+	if preview.Commit() != "deadbeef" {
+		t.Errorf("unexpected commit. want=%q have=%q", "deadbeef", preview.Commit())
+	}
+	if source := preview.ConfigurationSource(); source == nil || *source != "REPOSITORY" {
+		t.Errorf("unexpected configuration source. want=%q have=%v", "REPOSITORY", source)
+	}
+
+	type job struct {
+		Root         string
+		Indexer      string
+		PreIndex     int
+		Deduplicated bool
+	}
+	var jobs []job
+	for _, j := range preview.Jobs() {
+		jobs = append(jobs, job{j.Root(), j.Indexer(), len(j.Steps().PreIndex()), j.Deduplicated()})
+	}
+
+	expectedJobs := []job{
+		{"a", "lsif-go", 0, true},
+		{"b", "lsif-tsc", 1, true},
+	}
+	if diff := cmp.Diff(expectedJobs, jobs); diff != "" {
+		t.Errorf("unexpected jobs (-want +got):\n%s", diff)
+	}
+}
@@ -12,6 +12,7 @@ type LocationConnectionResolver struct {
 	locations        []resolvers.AdjustedLocation
 	cursor           *string
 	locationResolver *CachedLocationResolver
+	precise          bool
 }
 
 func NewLocationConnectionResolver(locations []resolvers.AdjustedLocation, cursor *string, locationResolver *CachedLocationResolver) gql.LocationConnectionResolver {
@@ -19,6 +20,18 @@ func NewLocationConnectionResolver(locations []resolvers.AdjustedLocation, curso
 		locations:        locations,
 		cursor:           cursor,
 		locationResolver: locationResolver,
+		precise:          true,
+	}
+}
+
+// NewSearchBasedLocationConnectionResolver creates a location connection resolver for locations
+// found by search-based heuristics rather than precise code intelligence data.
+func NewSearchBasedLocationConnectionResolver(locations []resolvers.AdjustedLocation, cursor *string, locationResolver *CachedLocationResolver) gql.LocationConnectionResolver {
+	return &LocationConnectionResolver{
+		locations:        locations,
+		cursor:           cursor,
+		locationResolver: locationResolver,
+		precise:          false,
 	}
 }
 
@@ -29,3 +42,7 @@ func (r *LocationConnectionResolver) Nodes(ctx context.Context) ([]gql.LocationR
 func (r *LocationConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
 	return encodeCursor(r.cursor), nil
 }
+
+func (r *LocationConnectionResolver) Precise() bool {
+	return r.precise
+}
@@ -212,7 +212,12 @@ func (r *Resolver) IndexConfiguration(ctx context.Context, id graphql.ID) (gql.I
 		return nil, err
 	}
 
-	return NewIndexConfigurationResolver(configuration), nil
+	indexOnPush, err := r.resolver.IsIndexOnPushEnabled(ctx, int(repositoryID))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewIndexConfigurationResolver(r.resolver, int(repositoryID), configuration, indexOnPush), nil
 }
 
 func (r *Resolver) UpdateRepositoryIndexConfiguration(ctx context.Context, args *gql.UpdateRepositoryIndexConfigurationArgs) (*gql.EmptyResponse, error) {
@@ -237,6 +242,28 @@ func (r *Resolver) UpdateRepositoryIndexConfiguration(ctx context.Context, args
 	return &gql.EmptyResponse{}, nil
 }
 
+func (r *Resolver) UpdateRepositoryIndexOnPush(ctx context.Context, args *gql.UpdateRepositoryIndexOnPushArgs) (*gql.EmptyResponse, error) {
+	if !autoIndexingEnabled() {
+		return nil, errAutoIndexingNotEnabled
+	}
+
+	// 🚨 SECURITY: Only site admins may configure indexing jobs for now
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, dbconn.Global); err != nil {
+		return nil, err
+	}
+
+	repositoryID, err := gql.UnmarshalRepositoryID(args.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.resolver.UpdateIndexOnPushByRepositoryID(ctx, int(repositoryID), args.Enabled); err != nil {
+		return nil, err
+	}
+
+	return &gql.EmptyResponse{}, nil
+}
+
 func (r *Resolver) CommitGraph(ctx context.Context, id graphql.ID) (gql.CodeIntelligenceCommitGraphResolver, error) {
 	repositoryID, err := gql.UnmarshalRepositoryID(id)
 	if err != nil {
@@ -268,6 +295,10 @@ func (r *Resolver) GitBlobLSIFData(ctx context.Context, args *gql.GitBlobLSIFDat
 	return NewQueryResolver(resolver, r.locationResolver), nil
 }
 
+func (r *Resolver) GitBlobSearchBasedCodeIntel(ctx context.Context, args *gql.GitBlobLSIFDataArgs) (gql.SearchBasedCodeIntelResolver, error) {
+	return NewSearchBasedCodeIntelResolver(r.resolver.SearchBasedQueryResolver(args), r.locationResolver), nil
+}
+
 // makeGetUploadsOptions translates the given GraphQL arguments into options defined by the
 // store.GetUploads operations.
 func makeGetUploadsOptions(ctx context.Context, args *gql.LSIFRepositoryUploadsQueryArgs) (store.GetUploadsOptions, error) {
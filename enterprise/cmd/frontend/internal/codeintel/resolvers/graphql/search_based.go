@@ -0,0 +1,51 @@
+package graphql
+
+import (
+	"context"
+
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers"
+)
+
+// SearchBasedCodeIntelResolver is the GraphQL wrapper around a search-based query resolver. All
+// location connections returned by this resolver are marked as imprecise.
+type SearchBasedCodeIntelResolver struct {
+	resolver         resolvers.SearchBasedQueryResolver
+	locationResolver *CachedLocationResolver
+}
+
+// NewSearchBasedCodeIntelResolver creates a new SearchBasedCodeIntelResolver with the given resolver that
+// defines all search-based code navigation behavior.
+func NewSearchBasedCodeIntelResolver(resolver resolvers.SearchBasedQueryResolver, locationResolver *CachedLocationResolver) gql.SearchBasedCodeIntelResolver {
+	return &SearchBasedCodeIntelResolver{
+		resolver:         resolver,
+		locationResolver: locationResolver,
+	}
+}
+
+func (r *SearchBasedCodeIntelResolver) Definitions(ctx context.Context, args *gql.LSIFQueryPositionArgs) (gql.LocationConnectionResolver, error) {
+	locations, err := r.resolver.Definitions(ctx, int(args.Line), int(args.Character))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSearchBasedLocationConnectionResolver(locations, nil, r.locationResolver), nil
+}
+
+func (r *SearchBasedCodeIntelResolver) References(ctx context.Context, args *gql.LSIFPagedQueryPositionArgs) (gql.LocationConnectionResolver, error) {
+	limit := derefInt32(args.First, DefaultReferencesPageSize)
+	if limit <= 0 {
+		return nil, ErrIllegalLimit
+	}
+	cursor, err := decodeCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	locations, cursor, err := r.resolver.References(ctx, int(args.Line), int(args.Character), limit, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSearchBasedLocationConnectionResolver(locations, strPtr(cursor), r.locationResolver), nil
+}
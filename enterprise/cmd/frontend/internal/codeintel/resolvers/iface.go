@@ -4,11 +4,16 @@ import (
 	"context"
 	"time"
 
+	"github.com/google/zoekt"
+	zoektquery "github.com/google/zoekt/query"
+
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindex/enqueuer"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
 	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
 )
@@ -16,6 +21,9 @@ import (
 type GitserverClient interface {
 	CommitExists(ctx context.Context, repositoryID int, commit string) (bool, error)
 	CommitGraph(ctx context.Context, repositoryID int, options gitserver.CommitGraphOptions) (*gitserver.CommitGraph, error)
+	RefDescriptions(ctx context.Context, repositoryID int) (map[string]gitserver.RefDescription, error)
+	MergeBase(ctx context.Context, repositoryID int, a, b string) (string, bool, error)
+	RawContents(ctx context.Context, repositoryID int, commit, file string) ([]byte, error)
 }
 
 type DBStore interface {
@@ -40,6 +48,8 @@ type DBStore interface {
 	DeleteIndexByID(ctx context.Context, id int) (bool, error)
 	GetIndexConfigurationByRepositoryID(ctx context.Context, repositoryID int) (store.IndexConfiguration, bool, error)
 	UpdateIndexConfigurationByRepositoryID(ctx context.Context, repositoryID int, data []byte) error
+	IsIndexOnPushEnabled(ctx context.Context, repositoryID int) (bool, error)
+	UpdateIndexOnPushByRepositoryID(ctx context.Context, repositoryID int, enabled bool) error
 }
 
 type LSIFStore interface {
@@ -61,6 +71,17 @@ type LSIFStore interface {
 type IndexEnqueuer interface {
 	ForceQueueIndexesForRepository(ctx context.Context, repositoryID int, commit string) error
 	InferIndexConfiguration(ctx context.Context, repositoryID int) (*config.IndexConfiguration, error)
+	InferIndexConfigurationForRevision(ctx context.Context, repositoryID int, rev string) (*config.IndexConfiguration, string, error)
+	PlanIndexesForRevision(ctx context.Context, repositoryID int, rev string) (enqueuer.IndexPlan, error)
+}
+
+type SymbolsClient interface {
+	Search(ctx context.Context, args search.SymbolsParameters) (*[]result.Symbol, error)
+}
+
+type IndexedSearcher interface {
+	Enabled() bool
+	Search(ctx context.Context, q zoektquery.Q, opts *zoekt.SearchOptions) (*zoekt.SearchResult, error)
 }
 
 type RepoUpdaterClient = enqueuer.RepoUpdaterClient
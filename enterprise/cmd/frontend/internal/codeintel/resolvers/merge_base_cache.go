@@ -0,0 +1,66 @@
+package resolvers
+
+import (
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// mergeBaseCacheSize is the maximum number of commits whose related branch merge bases are cached.
+const mergeBaseCacheSize = 1000
+
+// mergeBaseCacheTTL is the time for which the related branch merge bases of a commit are cached.
+// Branches move, so the merge bases must be computed again eventually; this only needs to cover
+// the burst of requests made for the files of a commit while a user browses it.
+const mergeBaseCacheTTL = time.Minute
+
+// mergeBaseCache caches the merge bases of commits with the heads of the related branches of their
+// repository. The merge bases are the same for every path of a commit, but computing them costs a
+// gitserver round-trip per branch in a latency-sensitive path.
+type mergeBaseCache struct {
+	cache *lru.Cache
+	now   func() time.Time
+}
+
+type mergeBaseCacheKey struct {
+	repositoryID int
+	commit       string
+}
+
+type mergeBaseCacheEntry struct {
+	mergeBases []string
+	expiresAt  time.Time
+}
+
+func newMergeBaseCache() *mergeBaseCache {
+	cache, err := lru.New(mergeBaseCacheSize)
+	if err != nil {
+		// Only returned for a non-positive size
+		panic(err)
+	}
+
+	return &mergeBaseCache{cache: cache, now: time.Now}
+}
+
+// get returns the cached merge bases of the given commit, if they have not expired.
+func (c *mergeBaseCache) get(repositoryID int, commit string) ([]string, bool) {
+	value, ok := c.cache.Get(mergeBaseCacheKey{repositoryID, commit})
+	if !ok {
+		return nil, false
+	}
+
+	entry := value.(mergeBaseCacheEntry)
+	if !c.now().Before(entry.expiresAt) {
+		return nil, false
+	}
+
+	return entry.mergeBases, true
+}
+
+// set caches the merge bases of the given commit.
+func (c *mergeBaseCache) set(repositoryID int, commit string, mergeBases []string) {
+	c.cache.Add(mergeBaseCacheKey{repositoryID, commit}, mergeBaseCacheEntry{
+		mergeBases: mergeBases,
+		expiresAt:  c.now().Add(mergeBaseCacheTTL),
+	})
+}
@@ -8,6 +8,8 @@ import (
 	"sync"
 	"time"
 
+	zoekt "github.com/google/zoekt"
+	query "github.com/google/zoekt/query"
 	enqueuer "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindex/enqueuer"
 	gitserver "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
 	dbstore "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
@@ -15,6 +17,8 @@ import (
 	api "github.com/sourcegraph/sourcegraph/internal/api"
 	basestore "github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	protocol "github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+	search "github.com/sourcegraph/sourcegraph/internal/search"
+	result "github.com/sourcegraph/sourcegraph/internal/search/result"
 	config "github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
 	precise "github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
 )
@@ -74,6 +78,9 @@ type MockDBStore struct {
 	// HasRepositoryFunc is an instance of a mock function object
 	// controlling the behavior of the method HasRepository.
 	HasRepositoryFunc *DBStoreHasRepositoryFunc
+	// IsIndexOnPushEnabledFunc is an instance of a mock function object
+	// controlling the behavior of the method IsIndexOnPushEnabled.
+	IsIndexOnPushEnabledFunc *DBStoreIsIndexOnPushEnabledFunc
 	// MarkRepositoryAsDirtyFunc is an instance of a mock function object
 	// controlling the behavior of the method MarkRepositoryAsDirty.
 	MarkRepositoryAsDirtyFunc *DBStoreMarkRepositoryAsDirtyFunc
@@ -87,6 +94,10 @@ type MockDBStore struct {
 	// function object controlling the behavior of the method
 	// UpdateIndexConfigurationByRepositoryID.
 	UpdateIndexConfigurationByRepositoryIDFunc *DBStoreUpdateIndexConfigurationByRepositoryIDFunc
+	// UpdateIndexOnPushByRepositoryIDFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// UpdateIndexOnPushByRepositoryID.
+	UpdateIndexOnPushByRepositoryIDFunc *DBStoreUpdateIndexOnPushByRepositoryIDFunc
 }
 
 // NewMockDBStore creates a new mock of the DBStore interface. All methods
@@ -173,6 +184,11 @@ func NewMockDBStore() *MockDBStore {
 				return false, nil
 			},
 		},
+		IsIndexOnPushEnabledFunc: &DBStoreIsIndexOnPushEnabledFunc{
+			defaultHook: func(context.Context, int) (bool, error) {
+				return false, nil
+			},
+		},
 		MarkRepositoryAsDirtyFunc: &DBStoreMarkRepositoryAsDirtyFunc{
 			defaultHook: func(context.Context, int) error {
 				return nil
@@ -193,6 +209,11 @@ func NewMockDBStore() *MockDBStore {
 				return nil
 			},
 		},
+		UpdateIndexOnPushByRepositoryIDFunc: &DBStoreUpdateIndexOnPushByRepositoryIDFunc{
+			defaultHook: func(context.Context, int, bool) error {
+				return nil
+			},
+		},
 	}
 }
 
@@ -248,6 +269,9 @@ func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 		HasRepositoryFunc: &DBStoreHasRepositoryFunc{
 			defaultHook: i.HasRepository,
 		},
+		IsIndexOnPushEnabledFunc: &DBStoreIsIndexOnPushEnabledFunc{
+			defaultHook: i.IsIndexOnPushEnabled,
+		},
 		MarkRepositoryAsDirtyFunc: &DBStoreMarkRepositoryAsDirtyFunc{
 			defaultHook: i.MarkRepositoryAsDirty,
 		},
@@ -260,6 +284,9 @@ func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 		UpdateIndexConfigurationByRepositoryIDFunc: &DBStoreUpdateIndexConfigurationByRepositoryIDFunc{
 			defaultHook: i.UpdateIndexConfigurationByRepositoryID,
 		},
+		UpdateIndexOnPushByRepositoryIDFunc: &DBStoreUpdateIndexOnPushByRepositoryIDFunc{
+			defaultHook: i.UpdateIndexOnPushByRepositoryID,
+		},
 	}
 }
 
@@ -2075,6 +2102,116 @@ func (c DBStoreHasRepositoryFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// DBStoreIsIndexOnPushEnabledFunc describes the behavior when the
+// IsIndexOnPushEnabled method of the parent MockDBStore instance is
+// invoked.
+type DBStoreIsIndexOnPushEnabledFunc struct {
+	defaultHook func(context.Context, int) (bool, error)
+	hooks       []func(context.Context, int) (bool, error)
+	history     []DBStoreIsIndexOnPushEnabledFuncCall
+	mutex       sync.Mutex
+}
+
+// IsIndexOnPushEnabled delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockDBStore) IsIndexOnPushEnabled(v0 context.Context, v1 int) (bool, error) {
+	r0, r1 := m.IsIndexOnPushEnabledFunc.nextHook()(v0, v1)
+	m.IsIndexOnPushEnabledFunc.appendCall(DBStoreIsIndexOnPushEnabledFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the IsIndexOnPushEnabled
+// method of the parent MockDBStore instance is invoked and the hook queue
+// is empty.
+func (f *DBStoreIsIndexOnPushEnabledFunc) SetDefaultHook(hook func(context.Context, int) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// IsIndexOnPushEnabled method of the parent MockDBStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *DBStoreIsIndexOnPushEnabledFunc) PushHook(hook func(context.Context, int) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreIsIndexOnPushEnabledFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreIsIndexOnPushEnabledFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, int) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *DBStoreIsIndexOnPushEnabledFunc) nextHook() func(context.Context, int) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreIsIndexOnPushEnabledFunc) appendCall(r0 DBStoreIsIndexOnPushEnabledFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreIsIndexOnPushEnabledFuncCall objects
+// describing the invocations of this function.
+func (f *DBStoreIsIndexOnPushEnabledFunc) History() []DBStoreIsIndexOnPushEnabledFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreIsIndexOnPushEnabledFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreIsIndexOnPushEnabledFuncCall is an object that describes an
+// invocation of method IsIndexOnPushEnabled on an instance of MockDBStore.
+type DBStoreIsIndexOnPushEnabledFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreIsIndexOnPushEnabledFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreIsIndexOnPushEnabledFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // DBStoreMarkRepositoryAsDirtyFunc describes the behavior when the
 // MarkRepositoryAsDirty method of the parent MockDBStore instance is
 // invoked.
@@ -2530,11 +2667,127 @@ func (c DBStoreUpdateIndexConfigurationByRepositoryIDFuncCall) Results() []inter
 	return []interface{}{c.Result0}
 }
 
+// DBStoreUpdateIndexOnPushByRepositoryIDFunc describes the behavior when
+// the UpdateIndexOnPushByRepositoryID method of the parent MockDBStore
+// instance is invoked.
+type DBStoreUpdateIndexOnPushByRepositoryIDFunc struct {
+	defaultHook func(context.Context, int, bool) error
+	hooks       []func(context.Context, int, bool) error
+	history     []DBStoreUpdateIndexOnPushByRepositoryIDFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateIndexOnPushByRepositoryID delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockDBStore) UpdateIndexOnPushByRepositoryID(v0 context.Context, v1 int, v2 bool) error {
+	r0 := m.UpdateIndexOnPushByRepositoryIDFunc.nextHook()(v0, v1, v2)
+	m.UpdateIndexOnPushByRepositoryIDFunc.appendCall(DBStoreUpdateIndexOnPushByRepositoryIDFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateIndexOnPushByRepositoryID method of the parent MockDBStore instance
+// is invoked and the hook queue is empty.
+func (f *DBStoreUpdateIndexOnPushByRepositoryIDFunc) SetDefaultHook(hook func(context.Context, int, bool) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateIndexOnPushByRepositoryID method of the parent MockDBStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *DBStoreUpdateIndexOnPushByRepositoryIDFunc) PushHook(hook func(context.Context, int, bool) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreUpdateIndexOnPushByRepositoryIDFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, bool) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreUpdateIndexOnPushByRepositoryIDFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, bool) error {
+		return r0
+	})
+}
+
+func (f *DBStoreUpdateIndexOnPushByRepositoryIDFunc) nextHook() func(context.Context, int, bool) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreUpdateIndexOnPushByRepositoryIDFunc) appendCall(r0 DBStoreUpdateIndexOnPushByRepositoryIDFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// DBStoreUpdateIndexOnPushByRepositoryIDFuncCall objects describing the
+// invocations of this function.
+func (f *DBStoreUpdateIndexOnPushByRepositoryIDFunc) History() []DBStoreUpdateIndexOnPushByRepositoryIDFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreUpdateIndexOnPushByRepositoryIDFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreUpdateIndexOnPushByRepositoryIDFuncCall is an object that
+// describes an invocation of method UpdateIndexOnPushByRepositoryID on an
+// instance of MockDBStore.
+type DBStoreUpdateIndexOnPushByRepositoryIDFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreUpdateIndexOnPushByRepositoryIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreUpdateIndexOnPushByRepositoryIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // MockEnqueuerDBStore is a mock implementation of the EnqueuerDBStore
 // interface (from the package
 // github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers)
 // used for unit testing.
 type MockEnqueuerDBStore struct {
+	// DeleteDelayedIndexesFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteDelayedIndexes.
+	DeleteDelayedIndexesFunc *EnqueuerDBStoreDeleteDelayedIndexesFunc
 	// DirtyRepositoriesFunc is an instance of a mock function object
 	// controlling the behavior of the method DirtyRepositories.
 	DirtyRepositoriesFunc *EnqueuerDBStoreDirtyRepositoriesFunc
@@ -2552,6 +2805,9 @@ type MockEnqueuerDBStore struct {
 	// HandleFunc is an instance of a mock function object controlling the
 	// behavior of the method Handle.
 	HandleFunc *EnqueuerDBStoreHandleFunc
+	// InsertDependencyRepoFunc is an instance of a mock function object
+	// controlling the behavior of the method InsertDependencyRepo.
+	InsertDependencyRepoFunc *EnqueuerDBStoreInsertDependencyRepoFunc
 	// InsertIndexFunc is an instance of a mock function object controlling
 	// the behavior of the method InsertIndex.
 	InsertIndexFunc *EnqueuerDBStoreInsertIndexFunc
@@ -2568,6 +2824,11 @@ type MockEnqueuerDBStore struct {
 // overwritten.
 func NewMockEnqueuerDBStore() *MockEnqueuerDBStore {
 	return &MockEnqueuerDBStore{
+		DeleteDelayedIndexesFunc: &EnqueuerDBStoreDeleteDelayedIndexesFunc{
+			defaultHook: func(context.Context, int) (time.Time, bool, error) {
+				return time.Time{}, false, nil
+			},
+		},
 		DirtyRepositoriesFunc: &EnqueuerDBStoreDirtyRepositoriesFunc{
 			defaultHook: func(context.Context) (map[int]int, error) {
 				return nil, nil
@@ -2593,6 +2854,11 @@ func NewMockEnqueuerDBStore() *MockEnqueuerDBStore {
 				return nil
 			},
 		},
+		InsertDependencyRepoFunc: &EnqueuerDBStoreInsertDependencyRepoFunc{
+			defaultHook: func(context.Context, string, string, string) (bool, error) {
+				return false, nil
+			},
+		},
 		InsertIndexFunc: &EnqueuerDBStoreInsertIndexFunc{
 			defaultHook: func(context.Context, dbstore.Index) (int, error) {
 				return 0, nil
@@ -2616,6 +2882,9 @@ func NewMockEnqueuerDBStore() *MockEnqueuerDBStore {
 // overwritten.
 func NewMockEnqueuerDBStoreFrom(i EnqueuerDBStore) *MockEnqueuerDBStore {
 	return &MockEnqueuerDBStore{
+		DeleteDelayedIndexesFunc: &EnqueuerDBStoreDeleteDelayedIndexesFunc{
+			defaultHook: i.DeleteDelayedIndexes,
+		},
 		DirtyRepositoriesFunc: &EnqueuerDBStoreDirtyRepositoriesFunc{
 			defaultHook: i.DirtyRepositories,
 		},
@@ -2631,6 +2900,9 @@ func NewMockEnqueuerDBStoreFrom(i EnqueuerDBStore) *MockEnqueuerDBStore {
 		HandleFunc: &EnqueuerDBStoreHandleFunc{
 			defaultHook: i.Handle,
 		},
+		InsertDependencyRepoFunc: &EnqueuerDBStoreInsertDependencyRepoFunc{
+			defaultHook: i.InsertDependencyRepo,
+		},
 		InsertIndexFunc: &EnqueuerDBStoreInsertIndexFunc{
 			defaultHook: i.InsertIndex,
 		},
@@ -2643,37 +2915,37 @@ func NewMockEnqueuerDBStoreFrom(i EnqueuerDBStore) *MockEnqueuerDBStore {
 	}
 }
 
-// EnqueuerDBStoreDirtyRepositoriesFunc describes the behavior when the
-// DirtyRepositories method of the parent MockEnqueuerDBStore instance is
+// EnqueuerDBStoreDeleteDelayedIndexesFunc describes the behavior when the
+// DeleteDelayedIndexes method of the parent MockEnqueuerDBStore instance is
 // invoked.
-type EnqueuerDBStoreDirtyRepositoriesFunc struct {
-	defaultHook func(context.Context) (map[int]int, error)
-	hooks       []func(context.Context) (map[int]int, error)
-	history     []EnqueuerDBStoreDirtyRepositoriesFuncCall
+type EnqueuerDBStoreDeleteDelayedIndexesFunc struct {
+	defaultHook func(context.Context, int) (time.Time, bool, error)
+	hooks       []func(context.Context, int) (time.Time, bool, error)
+	history     []EnqueuerDBStoreDeleteDelayedIndexesFuncCall
 	mutex       sync.Mutex
 }
 
-// DirtyRepositories delegates to the next hook function in the queue and
+// DeleteDelayedIndexes delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockEnqueuerDBStore) DirtyRepositories(v0 context.Context) (map[int]int, error) {
-	r0, r1 := m.DirtyRepositoriesFunc.nextHook()(v0)
-	m.DirtyRepositoriesFunc.appendCall(EnqueuerDBStoreDirtyRepositoriesFuncCall{v0, r0, r1})
-	return r0, r1
+func (m *MockEnqueuerDBStore) DeleteDelayedIndexes(v0 context.Context, v1 int) (time.Time, bool, error) {
+	r0, r1, r2 := m.DeleteDelayedIndexesFunc.nextHook()(v0, v1)
+	m.DeleteDelayedIndexesFunc.appendCall(EnqueuerDBStoreDeleteDelayedIndexesFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
 }
 
-// SetDefaultHook sets function that is called when the DirtyRepositories
+// SetDefaultHook sets function that is called when the DeleteDelayedIndexes
 // method of the parent MockEnqueuerDBStore instance is invoked and the hook
 // queue is empty.
-func (f *EnqueuerDBStoreDirtyRepositoriesFunc) SetDefaultHook(hook func(context.Context) (map[int]int, error)) {
+func (f *EnqueuerDBStoreDeleteDelayedIndexesFunc) SetDefaultHook(hook func(context.Context, int) (time.Time, bool, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// DirtyRepositories method of the parent MockEnqueuerDBStore instance
+// DeleteDelayedIndexes method of the parent MockEnqueuerDBStore instance
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *EnqueuerDBStoreDirtyRepositoriesFunc) PushHook(hook func(context.Context) (map[int]int, error)) {
+func (f *EnqueuerDBStoreDeleteDelayedIndexesFunc) PushHook(hook func(context.Context, int) (time.Time, bool, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2681,21 +2953,21 @@ func (f *EnqueuerDBStoreDirtyRepositoriesFunc) PushHook(hook func(context.Contex
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *EnqueuerDBStoreDirtyRepositoriesFunc) SetDefaultReturn(r0 map[int]int, r1 error) {
-	f.SetDefaultHook(func(context.Context) (map[int]int, error) {
-		return r0, r1
+func (f *EnqueuerDBStoreDeleteDelayedIndexesFunc) SetDefaultReturn(r0 time.Time, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int) (time.Time, bool, error) {
+		return r0, r1, r2
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *EnqueuerDBStoreDirtyRepositoriesFunc) PushReturn(r0 map[int]int, r1 error) {
-	f.PushHook(func(context.Context) (map[int]int, error) {
-		return r0, r1
+func (f *EnqueuerDBStoreDeleteDelayedIndexesFunc) PushReturn(r0 time.Time, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int) (time.Time, bool, error) {
+		return r0, r1, r2
 	})
 }
 
-func (f *EnqueuerDBStoreDirtyRepositoriesFunc) nextHook() func(context.Context) (map[int]int, error) {
+func (f *EnqueuerDBStoreDeleteDelayedIndexesFunc) nextHook() func(context.Context, int) (time.Time, bool, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2708,68 +2980,183 @@ func (f *EnqueuerDBStoreDirtyRepositoriesFunc) nextHook() func(context.Context)
 	return hook
 }
 
-func (f *EnqueuerDBStoreDirtyRepositoriesFunc) appendCall(r0 EnqueuerDBStoreDirtyRepositoriesFuncCall) {
+func (f *EnqueuerDBStoreDeleteDelayedIndexesFunc) appendCall(r0 EnqueuerDBStoreDeleteDelayedIndexesFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of EnqueuerDBStoreDirtyRepositoriesFuncCall
+// History returns a sequence of EnqueuerDBStoreDeleteDelayedIndexesFuncCall
 // objects describing the invocations of this function.
-func (f *EnqueuerDBStoreDirtyRepositoriesFunc) History() []EnqueuerDBStoreDirtyRepositoriesFuncCall {
+func (f *EnqueuerDBStoreDeleteDelayedIndexesFunc) History() []EnqueuerDBStoreDeleteDelayedIndexesFuncCall {
 	f.mutex.Lock()
-	history := make([]EnqueuerDBStoreDirtyRepositoriesFuncCall, len(f.history))
+	history := make([]EnqueuerDBStoreDeleteDelayedIndexesFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// EnqueuerDBStoreDirtyRepositoriesFuncCall is an object that describes an
-// invocation of method DirtyRepositories on an instance of
+// EnqueuerDBStoreDeleteDelayedIndexesFuncCall is an object that describes
+// an invocation of method DeleteDelayedIndexes on an instance of
 // MockEnqueuerDBStore.
-type EnqueuerDBStoreDirtyRepositoriesFuncCall struct {
+type EnqueuerDBStoreDeleteDelayedIndexesFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 map[int]int
+	Result0 time.Time
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 error
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c EnqueuerDBStoreDirtyRepositoriesFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c EnqueuerDBStoreDeleteDelayedIndexesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c EnqueuerDBStoreDirtyRepositoriesFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c EnqueuerDBStoreDeleteDelayedIndexesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
-// EnqueuerDBStoreDoneFunc describes the behavior when the Done method of
-// the parent MockEnqueuerDBStore instance is invoked.
-type EnqueuerDBStoreDoneFunc struct {
-	defaultHook func(error) error
-	hooks       []func(error) error
-	history     []EnqueuerDBStoreDoneFuncCall
+// EnqueuerDBStoreDirtyRepositoriesFunc describes the behavior when the
+// DirtyRepositories method of the parent MockEnqueuerDBStore instance is
+// invoked.
+type EnqueuerDBStoreDirtyRepositoriesFunc struct {
+	defaultHook func(context.Context) (map[int]int, error)
+	hooks       []func(context.Context) (map[int]int, error)
+	history     []EnqueuerDBStoreDirtyRepositoriesFuncCall
 	mutex       sync.Mutex
 }
 
-// Done delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockEnqueuerDBStore) Done(v0 error) error {
-	r0 := m.DoneFunc.nextHook()(v0)
-	m.DoneFunc.appendCall(EnqueuerDBStoreDoneFuncCall{v0, r0})
-	return r0
-}
-
-// SetDefaultHook sets function that is called when the Done method of the
+// DirtyRepositories delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockEnqueuerDBStore) DirtyRepositories(v0 context.Context) (map[int]int, error) {
+	r0, r1 := m.DirtyRepositoriesFunc.nextHook()(v0)
+	m.DirtyRepositoriesFunc.appendCall(EnqueuerDBStoreDirtyRepositoriesFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the DirtyRepositories
+// method of the parent MockEnqueuerDBStore instance is invoked and the hook
+// queue is empty.
+func (f *EnqueuerDBStoreDirtyRepositoriesFunc) SetDefaultHook(hook func(context.Context) (map[int]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DirtyRepositories method of the parent MockEnqueuerDBStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *EnqueuerDBStoreDirtyRepositoriesFunc) PushHook(hook func(context.Context) (map[int]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *EnqueuerDBStoreDirtyRepositoriesFunc) SetDefaultReturn(r0 map[int]int, r1 error) {
+	f.SetDefaultHook(func(context.Context) (map[int]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *EnqueuerDBStoreDirtyRepositoriesFunc) PushReturn(r0 map[int]int, r1 error) {
+	f.PushHook(func(context.Context) (map[int]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *EnqueuerDBStoreDirtyRepositoriesFunc) nextHook() func(context.Context) (map[int]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EnqueuerDBStoreDirtyRepositoriesFunc) appendCall(r0 EnqueuerDBStoreDirtyRepositoriesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EnqueuerDBStoreDirtyRepositoriesFuncCall
+// objects describing the invocations of this function.
+func (f *EnqueuerDBStoreDirtyRepositoriesFunc) History() []EnqueuerDBStoreDirtyRepositoriesFuncCall {
+	f.mutex.Lock()
+	history := make([]EnqueuerDBStoreDirtyRepositoriesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EnqueuerDBStoreDirtyRepositoriesFuncCall is an object that describes an
+// invocation of method DirtyRepositories on an instance of
+// MockEnqueuerDBStore.
+type EnqueuerDBStoreDirtyRepositoriesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[int]int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnqueuerDBStoreDirtyRepositoriesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnqueuerDBStoreDirtyRepositoriesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// EnqueuerDBStoreDoneFunc describes the behavior when the Done method of
+// the parent MockEnqueuerDBStore instance is invoked.
+type EnqueuerDBStoreDoneFunc struct {
+	defaultHook func(error) error
+	hooks       []func(error) error
+	history     []EnqueuerDBStoreDoneFuncCall
+	mutex       sync.Mutex
+}
+
+// Done delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockEnqueuerDBStore) Done(v0 error) error {
+	r0 := m.DoneFunc.nextHook()(v0)
+	m.DoneFunc.appendCall(EnqueuerDBStoreDoneFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Done method of the
 // parent MockEnqueuerDBStore instance is invoked and the hook queue is
 // empty.
 func (f *EnqueuerDBStoreDoneFunc) SetDefaultHook(hook func(error) error) {
@@ -3185,6 +3572,124 @@ func (c EnqueuerDBStoreHandleFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// EnqueuerDBStoreInsertDependencyRepoFunc describes the behavior when the
+// InsertDependencyRepo method of the parent MockEnqueuerDBStore instance is
+// invoked.
+type EnqueuerDBStoreInsertDependencyRepoFunc struct {
+	defaultHook func(context.Context, string, string, string) (bool, error)
+	hooks       []func(context.Context, string, string, string) (bool, error)
+	history     []EnqueuerDBStoreInsertDependencyRepoFuncCall
+	mutex       sync.Mutex
+}
+
+// InsertDependencyRepo delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockEnqueuerDBStore) InsertDependencyRepo(v0 context.Context, v1 string, v2 string, v3 string) (bool, error) {
+	r0, r1 := m.InsertDependencyRepoFunc.nextHook()(v0, v1, v2, v3)
+	m.InsertDependencyRepoFunc.appendCall(EnqueuerDBStoreInsertDependencyRepoFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the InsertDependencyRepo
+// method of the parent MockEnqueuerDBStore instance is invoked and the hook
+// queue is empty.
+func (f *EnqueuerDBStoreInsertDependencyRepoFunc) SetDefaultHook(hook func(context.Context, string, string, string) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InsertDependencyRepo method of the parent MockEnqueuerDBStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *EnqueuerDBStoreInsertDependencyRepoFunc) PushHook(hook func(context.Context, string, string, string) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *EnqueuerDBStoreInsertDependencyRepoFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string, string) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *EnqueuerDBStoreInsertDependencyRepoFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, string, string, string) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *EnqueuerDBStoreInsertDependencyRepoFunc) nextHook() func(context.Context, string, string, string) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EnqueuerDBStoreInsertDependencyRepoFunc) appendCall(r0 EnqueuerDBStoreInsertDependencyRepoFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EnqueuerDBStoreInsertDependencyRepoFuncCall
+// objects describing the invocations of this function.
+func (f *EnqueuerDBStoreInsertDependencyRepoFunc) History() []EnqueuerDBStoreInsertDependencyRepoFuncCall {
+	f.mutex.Lock()
+	history := make([]EnqueuerDBStoreInsertDependencyRepoFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EnqueuerDBStoreInsertDependencyRepoFuncCall is an object that describes
+// an invocation of method InsertDependencyRepo on an instance of
+// MockEnqueuerDBStore.
+type EnqueuerDBStoreInsertDependencyRepoFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnqueuerDBStoreInsertDependencyRepoFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnqueuerDBStoreInsertDependencyRepoFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // EnqueuerDBStoreInsertIndexFunc describes the behavior when the
 // InsertIndex method of the parent MockEnqueuerDBStore instance is invoked.
 type EnqueuerDBStoreInsertIndexFunc struct {
@@ -4183,6 +4688,15 @@ type MockGitserverClient struct {
 	// CommitGraphFunc is an instance of a mock function object controlling
 	// the behavior of the method CommitGraph.
 	CommitGraphFunc *GitserverClientCommitGraphFunc
+	// MergeBaseFunc is an instance of a mock function object controlling
+	// the behavior of the method MergeBase.
+	MergeBaseFunc *GitserverClientMergeBaseFunc
+	// RawContentsFunc is an instance of a mock function object controlling
+	// the behavior of the method RawContents.
+	RawContentsFunc *GitserverClientRawContentsFunc
+	// RefDescriptionsFunc is an instance of a mock function object
+	// controlling the behavior of the method RefDescriptions.
+	RefDescriptionsFunc *GitserverClientRefDescriptionsFunc
 }
 
 // NewMockGitserverClient creates a new mock of the GitserverClient
@@ -4200,6 +4714,21 @@ func NewMockGitserverClient() *MockGitserverClient {
 				return nil, nil
 			},
 		},
+		MergeBaseFunc: &GitserverClientMergeBaseFunc{
+			defaultHook: func(context.Context, int, string, string) (string, bool, error) {
+				return "", false, nil
+			},
+		},
+		RawContentsFunc: &GitserverClientRawContentsFunc{
+			defaultHook: func(context.Context, int, string, string) ([]byte, error) {
+				return nil, nil
+			},
+		},
+		RefDescriptionsFunc: &GitserverClientRefDescriptionsFunc{
+			defaultHook: func(context.Context, int) (map[string]gitserver.RefDescription, error) {
+				return nil, nil
+			},
+		},
 	}
 }
 
@@ -4214,6 +4743,15 @@ func NewMockGitserverClientFrom(i GitserverClient) *MockGitserverClient {
 		CommitGraphFunc: &GitserverClientCommitGraphFunc{
 			defaultHook: i.CommitGraph,
 		},
+		MergeBaseFunc: &GitserverClientMergeBaseFunc{
+			defaultHook: i.MergeBase,
+		},
+		RawContentsFunc: &GitserverClientRawContentsFunc{
+			defaultHook: i.RawContents,
+		},
+		RefDescriptionsFunc: &GitserverClientRefDescriptionsFunc{
+			defaultHook: i.RefDescriptions,
+		},
 	}
 }
 
@@ -4355,10 +4893,881 @@ func (f *GitserverClientCommitGraphFunc) SetDefaultHook(hook func(context.Contex
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// CommitGraph method of the parent MockGitserverClient instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *GitserverClientCommitGraphFunc) PushHook(hook func(context.Context, int, gitserver.CommitGraphOptions) (*gitserver.CommitGraph, error)) {
+// CommitGraph method of the parent MockGitserverClient instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *GitserverClientCommitGraphFunc) PushHook(hook func(context.Context, int, gitserver.CommitGraphOptions) (*gitserver.CommitGraph, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *GitserverClientCommitGraphFunc) SetDefaultReturn(r0 *gitserver.CommitGraph, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, gitserver.CommitGraphOptions) (*gitserver.CommitGraph, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *GitserverClientCommitGraphFunc) PushReturn(r0 *gitserver.CommitGraph, r1 error) {
+	f.PushHook(func(context.Context, int, gitserver.CommitGraphOptions) (*gitserver.CommitGraph, error) {
+		return r0, r1
+	})
+}
+
+func (f *GitserverClientCommitGraphFunc) nextHook() func(context.Context, int, gitserver.CommitGraphOptions) (*gitserver.CommitGraph, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverClientCommitGraphFunc) appendCall(r0 GitserverClientCommitGraphFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitserverClientCommitGraphFuncCall objects
+// describing the invocations of this function.
+func (f *GitserverClientCommitGraphFunc) History() []GitserverClientCommitGraphFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverClientCommitGraphFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverClientCommitGraphFuncCall is an object that describes an
+// invocation of method CommitGraph on an instance of MockGitserverClient.
+type GitserverClientCommitGraphFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 gitserver.CommitGraphOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *gitserver.CommitGraph
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverClientCommitGraphFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverClientCommitGraphFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// GitserverClientMergeBaseFunc describes the behavior when the MergeBase
+// method of the parent MockGitserverClient instance is invoked.
+type GitserverClientMergeBaseFunc struct {
+	defaultHook func(context.Context, int, string, string) (string, bool, error)
+	hooks       []func(context.Context, int, string, string) (string, bool, error)
+	history     []GitserverClientMergeBaseFuncCall
+	mutex       sync.Mutex
+}
+
+// MergeBase delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockGitserverClient) MergeBase(v0 context.Context, v1 int, v2 string, v3 string) (string, bool, error) {
+	r0, r1, r2 := m.MergeBaseFunc.nextHook()(v0, v1, v2, v3)
+	m.MergeBaseFunc.appendCall(GitserverClientMergeBaseFuncCall{v0, v1, v2, v3, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the MergeBase method of
+// the parent MockGitserverClient instance is invoked and the hook queue is
+// empty.
+func (f *GitserverClientMergeBaseFunc) SetDefaultHook(hook func(context.Context, int, string, string) (string, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// MergeBase method of the parent MockGitserverClient instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *GitserverClientMergeBaseFunc) PushHook(hook func(context.Context, int, string, string) (string, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *GitserverClientMergeBaseFunc) SetDefaultReturn(r0 string, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, string, string) (string, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *GitserverClientMergeBaseFunc) PushReturn(r0 string, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int, string, string) (string, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *GitserverClientMergeBaseFunc) nextHook() func(context.Context, int, string, string) (string, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverClientMergeBaseFunc) appendCall(r0 GitserverClientMergeBaseFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitserverClientMergeBaseFuncCall objects
+// describing the invocations of this function.
+func (f *GitserverClientMergeBaseFunc) History() []GitserverClientMergeBaseFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverClientMergeBaseFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverClientMergeBaseFuncCall is an object that describes an
+// invocation of method MergeBase on an instance of MockGitserverClient.
+type GitserverClientMergeBaseFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverClientMergeBaseFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverClientMergeBaseFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// GitserverClientRawContentsFunc describes the behavior when the
+// RawContents method of the parent MockGitserverClient instance is invoked.
+type GitserverClientRawContentsFunc struct {
+	defaultHook func(context.Context, int, string, string) ([]byte, error)
+	hooks       []func(context.Context, int, string, string) ([]byte, error)
+	history     []GitserverClientRawContentsFuncCall
+	mutex       sync.Mutex
+}
+
+// RawContents delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockGitserverClient) RawContents(v0 context.Context, v1 int, v2 string, v3 string) ([]byte, error) {
+	r0, r1 := m.RawContentsFunc.nextHook()(v0, v1, v2, v3)
+	m.RawContentsFunc.appendCall(GitserverClientRawContentsFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the RawContents method
+// of the parent MockGitserverClient instance is invoked and the hook queue
+// is empty.
+func (f *GitserverClientRawContentsFunc) SetDefaultHook(hook func(context.Context, int, string, string) ([]byte, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RawContents method of the parent MockGitserverClient instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *GitserverClientRawContentsFunc) PushHook(hook func(context.Context, int, string, string) ([]byte, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *GitserverClientRawContentsFunc) SetDefaultReturn(r0 []byte, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string, string) ([]byte, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *GitserverClientRawContentsFunc) PushReturn(r0 []byte, r1 error) {
+	f.PushHook(func(context.Context, int, string, string) ([]byte, error) {
+		return r0, r1
+	})
+}
+
+func (f *GitserverClientRawContentsFunc) nextHook() func(context.Context, int, string, string) ([]byte, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverClientRawContentsFunc) appendCall(r0 GitserverClientRawContentsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitserverClientRawContentsFuncCall objects
+// describing the invocations of this function.
+func (f *GitserverClientRawContentsFunc) History() []GitserverClientRawContentsFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverClientRawContentsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverClientRawContentsFuncCall is an object that describes an
+// invocation of method RawContents on an instance of MockGitserverClient.
+type GitserverClientRawContentsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []byte
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverClientRawContentsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverClientRawContentsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// GitserverClientRefDescriptionsFunc describes the behavior when the
+// RefDescriptions method of the parent MockGitserverClient instance is
+// invoked.
+type GitserverClientRefDescriptionsFunc struct {
+	defaultHook func(context.Context, int) (map[string]gitserver.RefDescription, error)
+	hooks       []func(context.Context, int) (map[string]gitserver.RefDescription, error)
+	history     []GitserverClientRefDescriptionsFuncCall
+	mutex       sync.Mutex
+}
+
+// RefDescriptions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockGitserverClient) RefDescriptions(v0 context.Context, v1 int) (map[string]gitserver.RefDescription, error) {
+	r0, r1 := m.RefDescriptionsFunc.nextHook()(v0, v1)
+	m.RefDescriptionsFunc.appendCall(GitserverClientRefDescriptionsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the RefDescriptions
+// method of the parent MockGitserverClient instance is invoked and the hook
+// queue is empty.
+func (f *GitserverClientRefDescriptionsFunc) SetDefaultHook(hook func(context.Context, int) (map[string]gitserver.RefDescription, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RefDescriptions method of the parent MockGitserverClient instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *GitserverClientRefDescriptionsFunc) PushHook(hook func(context.Context, int) (map[string]gitserver.RefDescription, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *GitserverClientRefDescriptionsFunc) SetDefaultReturn(r0 map[string]gitserver.RefDescription, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) (map[string]gitserver.RefDescription, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *GitserverClientRefDescriptionsFunc) PushReturn(r0 map[string]gitserver.RefDescription, r1 error) {
+	f.PushHook(func(context.Context, int) (map[string]gitserver.RefDescription, error) {
+		return r0, r1
+	})
+}
+
+func (f *GitserverClientRefDescriptionsFunc) nextHook() func(context.Context, int) (map[string]gitserver.RefDescription, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverClientRefDescriptionsFunc) appendCall(r0 GitserverClientRefDescriptionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitserverClientRefDescriptionsFuncCall
+// objects describing the invocations of this function.
+func (f *GitserverClientRefDescriptionsFunc) History() []GitserverClientRefDescriptionsFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverClientRefDescriptionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverClientRefDescriptionsFuncCall is an object that describes an
+// invocation of method RefDescriptions on an instance of
+// MockGitserverClient.
+type GitserverClientRefDescriptionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[string]gitserver.RefDescription
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverClientRefDescriptionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverClientRefDescriptionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// MockIndexEnqueuer is a mock implementation of the IndexEnqueuer interface
+// (from the package
+// github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers)
+// used for unit testing.
+type MockIndexEnqueuer struct {
+	// ForceQueueIndexesForRepositoryFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// ForceQueueIndexesForRepository.
+	ForceQueueIndexesForRepositoryFunc *IndexEnqueuerForceQueueIndexesForRepositoryFunc
+	// InferIndexConfigurationFunc is an instance of a mock function object
+	// controlling the behavior of the method InferIndexConfiguration.
+	InferIndexConfigurationFunc *IndexEnqueuerInferIndexConfigurationFunc
+	// InferIndexConfigurationForRevisionFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// InferIndexConfigurationForRevision.
+	InferIndexConfigurationForRevisionFunc *IndexEnqueuerInferIndexConfigurationForRevisionFunc
+	// PlanIndexesForRevisionFunc is an instance of a mock function object
+	// controlling the behavior of the method PlanIndexesForRevision.
+	PlanIndexesForRevisionFunc *IndexEnqueuerPlanIndexesForRevisionFunc
+}
+
+// NewMockIndexEnqueuer creates a new mock of the IndexEnqueuer interface.
+// All methods return zero values for all results, unless overwritten.
+func NewMockIndexEnqueuer() *MockIndexEnqueuer {
+	return &MockIndexEnqueuer{
+		ForceQueueIndexesForRepositoryFunc: &IndexEnqueuerForceQueueIndexesForRepositoryFunc{
+			defaultHook: func(context.Context, int, string) error {
+				return nil
+			},
+		},
+		InferIndexConfigurationFunc: &IndexEnqueuerInferIndexConfigurationFunc{
+			defaultHook: func(context.Context, int) (*config.IndexConfiguration, error) {
+				return nil, nil
+			},
+		},
+		InferIndexConfigurationForRevisionFunc: &IndexEnqueuerInferIndexConfigurationForRevisionFunc{
+			defaultHook: func(context.Context, int, string) (*config.IndexConfiguration, string, error) {
+				return nil, "", nil
+			},
+		},
+		PlanIndexesForRevisionFunc: &IndexEnqueuerPlanIndexesForRevisionFunc{
+			defaultHook: func(context.Context, int, string) (enqueuer.IndexPlan, error) {
+				return enqueuer.IndexPlan{}, nil
+			},
+		},
+	}
+}
+
+// NewMockIndexEnqueuerFrom creates a new mock of the MockIndexEnqueuer
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockIndexEnqueuerFrom(i IndexEnqueuer) *MockIndexEnqueuer {
+	return &MockIndexEnqueuer{
+		ForceQueueIndexesForRepositoryFunc: &IndexEnqueuerForceQueueIndexesForRepositoryFunc{
+			defaultHook: i.ForceQueueIndexesForRepository,
+		},
+		InferIndexConfigurationFunc: &IndexEnqueuerInferIndexConfigurationFunc{
+			defaultHook: i.InferIndexConfiguration,
+		},
+		InferIndexConfigurationForRevisionFunc: &IndexEnqueuerInferIndexConfigurationForRevisionFunc{
+			defaultHook: i.InferIndexConfigurationForRevision,
+		},
+		PlanIndexesForRevisionFunc: &IndexEnqueuerPlanIndexesForRevisionFunc{
+			defaultHook: i.PlanIndexesForRevision,
+		},
+	}
+}
+
+// IndexEnqueuerForceQueueIndexesForRepositoryFunc describes the behavior
+// when the ForceQueueIndexesForRepository method of the parent
+// MockIndexEnqueuer instance is invoked.
+type IndexEnqueuerForceQueueIndexesForRepositoryFunc struct {
+	defaultHook func(context.Context, int, string) error
+	hooks       []func(context.Context, int, string) error
+	history     []IndexEnqueuerForceQueueIndexesForRepositoryFuncCall
+	mutex       sync.Mutex
+}
+
+// ForceQueueIndexesForRepository delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockIndexEnqueuer) ForceQueueIndexesForRepository(v0 context.Context, v1 int, v2 string) error {
+	r0 := m.ForceQueueIndexesForRepositoryFunc.nextHook()(v0, v1, v2)
+	m.ForceQueueIndexesForRepositoryFunc.appendCall(IndexEnqueuerForceQueueIndexesForRepositoryFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// ForceQueueIndexesForRepository method of the parent MockIndexEnqueuer
+// instance is invoked and the hook queue is empty.
+func (f *IndexEnqueuerForceQueueIndexesForRepositoryFunc) SetDefaultHook(hook func(context.Context, int, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ForceQueueIndexesForRepository method of the parent MockIndexEnqueuer
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *IndexEnqueuerForceQueueIndexesForRepositoryFunc) PushHook(hook func(context.Context, int, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *IndexEnqueuerForceQueueIndexesForRepositoryFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *IndexEnqueuerForceQueueIndexesForRepositoryFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, string) error {
+		return r0
+	})
+}
+
+func (f *IndexEnqueuerForceQueueIndexesForRepositoryFunc) nextHook() func(context.Context, int, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *IndexEnqueuerForceQueueIndexesForRepositoryFunc) appendCall(r0 IndexEnqueuerForceQueueIndexesForRepositoryFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// IndexEnqueuerForceQueueIndexesForRepositoryFuncCall objects describing
+// the invocations of this function.
+func (f *IndexEnqueuerForceQueueIndexesForRepositoryFunc) History() []IndexEnqueuerForceQueueIndexesForRepositoryFuncCall {
+	f.mutex.Lock()
+	history := make([]IndexEnqueuerForceQueueIndexesForRepositoryFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// IndexEnqueuerForceQueueIndexesForRepositoryFuncCall is an object that
+// describes an invocation of method ForceQueueIndexesForRepository on an
+// instance of MockIndexEnqueuer.
+type IndexEnqueuerForceQueueIndexesForRepositoryFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c IndexEnqueuerForceQueueIndexesForRepositoryFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c IndexEnqueuerForceQueueIndexesForRepositoryFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// IndexEnqueuerInferIndexConfigurationFunc describes the behavior when the
+// InferIndexConfiguration method of the parent MockIndexEnqueuer instance
+// is invoked.
+type IndexEnqueuerInferIndexConfigurationFunc struct {
+	defaultHook func(context.Context, int) (*config.IndexConfiguration, error)
+	hooks       []func(context.Context, int) (*config.IndexConfiguration, error)
+	history     []IndexEnqueuerInferIndexConfigurationFuncCall
+	mutex       sync.Mutex
+}
+
+// InferIndexConfiguration delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockIndexEnqueuer) InferIndexConfiguration(v0 context.Context, v1 int) (*config.IndexConfiguration, error) {
+	r0, r1 := m.InferIndexConfigurationFunc.nextHook()(v0, v1)
+	m.InferIndexConfigurationFunc.appendCall(IndexEnqueuerInferIndexConfigurationFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// InferIndexConfiguration method of the parent MockIndexEnqueuer instance
+// is invoked and the hook queue is empty.
+func (f *IndexEnqueuerInferIndexConfigurationFunc) SetDefaultHook(hook func(context.Context, int) (*config.IndexConfiguration, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InferIndexConfiguration method of the parent MockIndexEnqueuer instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *IndexEnqueuerInferIndexConfigurationFunc) PushHook(hook func(context.Context, int) (*config.IndexConfiguration, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *IndexEnqueuerInferIndexConfigurationFunc) SetDefaultReturn(r0 *config.IndexConfiguration, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) (*config.IndexConfiguration, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *IndexEnqueuerInferIndexConfigurationFunc) PushReturn(r0 *config.IndexConfiguration, r1 error) {
+	f.PushHook(func(context.Context, int) (*config.IndexConfiguration, error) {
+		return r0, r1
+	})
+}
+
+func (f *IndexEnqueuerInferIndexConfigurationFunc) nextHook() func(context.Context, int) (*config.IndexConfiguration, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *IndexEnqueuerInferIndexConfigurationFunc) appendCall(r0 IndexEnqueuerInferIndexConfigurationFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// IndexEnqueuerInferIndexConfigurationFuncCall objects describing the
+// invocations of this function.
+func (f *IndexEnqueuerInferIndexConfigurationFunc) History() []IndexEnqueuerInferIndexConfigurationFuncCall {
+	f.mutex.Lock()
+	history := make([]IndexEnqueuerInferIndexConfigurationFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// IndexEnqueuerInferIndexConfigurationFuncCall is an object that describes
+// an invocation of method InferIndexConfiguration on an instance of
+// MockIndexEnqueuer.
+type IndexEnqueuerInferIndexConfigurationFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *config.IndexConfiguration
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c IndexEnqueuerInferIndexConfigurationFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c IndexEnqueuerInferIndexConfigurationFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// IndexEnqueuerInferIndexConfigurationForRevisionFunc describes the
+// behavior when the InferIndexConfigurationForRevision method of the parent
+// MockIndexEnqueuer instance is invoked.
+type IndexEnqueuerInferIndexConfigurationForRevisionFunc struct {
+	defaultHook func(context.Context, int, string) (*config.IndexConfiguration, string, error)
+	hooks       []func(context.Context, int, string) (*config.IndexConfiguration, string, error)
+	history     []IndexEnqueuerInferIndexConfigurationForRevisionFuncCall
+	mutex       sync.Mutex
+}
+
+// InferIndexConfigurationForRevision delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockIndexEnqueuer) InferIndexConfigurationForRevision(v0 context.Context, v1 int, v2 string) (*config.IndexConfiguration, string, error) {
+	r0, r1, r2 := m.InferIndexConfigurationForRevisionFunc.nextHook()(v0, v1, v2)
+	m.InferIndexConfigurationForRevisionFunc.appendCall(IndexEnqueuerInferIndexConfigurationForRevisionFuncCall{v0, v1, v2, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// InferIndexConfigurationForRevision method of the parent MockIndexEnqueuer
+// instance is invoked and the hook queue is empty.
+func (f *IndexEnqueuerInferIndexConfigurationForRevisionFunc) SetDefaultHook(hook func(context.Context, int, string) (*config.IndexConfiguration, string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InferIndexConfigurationForRevision method of the parent MockIndexEnqueuer
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *IndexEnqueuerInferIndexConfigurationForRevisionFunc) PushHook(hook func(context.Context, int, string) (*config.IndexConfiguration, string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *IndexEnqueuerInferIndexConfigurationForRevisionFunc) SetDefaultReturn(r0 *config.IndexConfiguration, r1 string, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, string) (*config.IndexConfiguration, string, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *IndexEnqueuerInferIndexConfigurationForRevisionFunc) PushReturn(r0 *config.IndexConfiguration, r1 string, r2 error) {
+	f.PushHook(func(context.Context, int, string) (*config.IndexConfiguration, string, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *IndexEnqueuerInferIndexConfigurationForRevisionFunc) nextHook() func(context.Context, int, string) (*config.IndexConfiguration, string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *IndexEnqueuerInferIndexConfigurationForRevisionFunc) appendCall(r0 IndexEnqueuerInferIndexConfigurationForRevisionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// IndexEnqueuerInferIndexConfigurationForRevisionFuncCall objects
+// describing the invocations of this function.
+func (f *IndexEnqueuerInferIndexConfigurationForRevisionFunc) History() []IndexEnqueuerInferIndexConfigurationForRevisionFuncCall {
+	f.mutex.Lock()
+	history := make([]IndexEnqueuerInferIndexConfigurationForRevisionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// IndexEnqueuerInferIndexConfigurationForRevisionFuncCall is an object that
+// describes an invocation of method InferIndexConfigurationForRevision on
+// an instance of MockIndexEnqueuer.
+type IndexEnqueuerInferIndexConfigurationForRevisionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *config.IndexConfiguration
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 string
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c IndexEnqueuerInferIndexConfigurationForRevisionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c IndexEnqueuerInferIndexConfigurationForRevisionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// IndexEnqueuerPlanIndexesForRevisionFunc describes the behavior when the
+// PlanIndexesForRevision method of the parent MockIndexEnqueuer instance is
+// invoked.
+type IndexEnqueuerPlanIndexesForRevisionFunc struct {
+	defaultHook func(context.Context, int, string) (enqueuer.IndexPlan, error)
+	hooks       []func(context.Context, int, string) (enqueuer.IndexPlan, error)
+	history     []IndexEnqueuerPlanIndexesForRevisionFuncCall
+	mutex       sync.Mutex
+}
+
+// PlanIndexesForRevision delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockIndexEnqueuer) PlanIndexesForRevision(v0 context.Context, v1 int, v2 string) (enqueuer.IndexPlan, error) {
+	r0, r1 := m.PlanIndexesForRevisionFunc.nextHook()(v0, v1, v2)
+	m.PlanIndexesForRevisionFunc.appendCall(IndexEnqueuerPlanIndexesForRevisionFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// PlanIndexesForRevision method of the parent MockIndexEnqueuer instance is
+// invoked and the hook queue is empty.
+func (f *IndexEnqueuerPlanIndexesForRevisionFunc) SetDefaultHook(hook func(context.Context, int, string) (enqueuer.IndexPlan, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// PlanIndexesForRevision method of the parent MockIndexEnqueuer instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *IndexEnqueuerPlanIndexesForRevisionFunc) PushHook(hook func(context.Context, int, string) (enqueuer.IndexPlan, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -4366,21 +5775,21 @@ func (f *GitserverClientCommitGraphFunc) PushHook(hook func(context.Context, int
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *GitserverClientCommitGraphFunc) SetDefaultReturn(r0 *gitserver.CommitGraph, r1 error) {
-	f.SetDefaultHook(func(context.Context, int, gitserver.CommitGraphOptions) (*gitserver.CommitGraph, error) {
+func (f *IndexEnqueuerPlanIndexesForRevisionFunc) SetDefaultReturn(r0 enqueuer.IndexPlan, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string) (enqueuer.IndexPlan, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *GitserverClientCommitGraphFunc) PushReturn(r0 *gitserver.CommitGraph, r1 error) {
-	f.PushHook(func(context.Context, int, gitserver.CommitGraphOptions) (*gitserver.CommitGraph, error) {
+func (f *IndexEnqueuerPlanIndexesForRevisionFunc) PushReturn(r0 enqueuer.IndexPlan, r1 error) {
+	f.PushHook(func(context.Context, int, string) (enqueuer.IndexPlan, error) {
 		return r0, r1
 	})
 }
 
-func (f *GitserverClientCommitGraphFunc) nextHook() func(context.Context, int, gitserver.CommitGraphOptions) (*gitserver.CommitGraph, error) {
+func (f *IndexEnqueuerPlanIndexesForRevisionFunc) nextHook() func(context.Context, int, string) (enqueuer.IndexPlan, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -4393,26 +5802,27 @@ func (f *GitserverClientCommitGraphFunc) nextHook() func(context.Context, int, g
 	return hook
 }
 
-func (f *GitserverClientCommitGraphFunc) appendCall(r0 GitserverClientCommitGraphFuncCall) {
+func (f *IndexEnqueuerPlanIndexesForRevisionFunc) appendCall(r0 IndexEnqueuerPlanIndexesForRevisionFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of GitserverClientCommitGraphFuncCall objects
-// describing the invocations of this function.
-func (f *GitserverClientCommitGraphFunc) History() []GitserverClientCommitGraphFuncCall {
+// History returns a sequence of IndexEnqueuerPlanIndexesForRevisionFuncCall
+// objects describing the invocations of this function.
+func (f *IndexEnqueuerPlanIndexesForRevisionFunc) History() []IndexEnqueuerPlanIndexesForRevisionFuncCall {
 	f.mutex.Lock()
-	history := make([]GitserverClientCommitGraphFuncCall, len(f.history))
+	history := make([]IndexEnqueuerPlanIndexesForRevisionFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// GitserverClientCommitGraphFuncCall is an object that describes an
-// invocation of method CommitGraph on an instance of MockGitserverClient.
-type GitserverClientCommitGraphFuncCall struct {
+// IndexEnqueuerPlanIndexesForRevisionFuncCall is an object that describes
+// an invocation of method PlanIndexesForRevision on an instance of
+// MockIndexEnqueuer.
+type IndexEnqueuerPlanIndexesForRevisionFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -4421,10 +5831,10 @@ type GitserverClientCommitGraphFuncCall struct {
 	Arg1 int
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 gitserver.CommitGraphOptions
+	Arg2 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *gitserver.CommitGraph
+	Result0 enqueuer.IndexPlan
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -4432,92 +5842,90 @@ type GitserverClientCommitGraphFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c GitserverClientCommitGraphFuncCall) Args() []interface{} {
+func (c IndexEnqueuerPlanIndexesForRevisionFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c GitserverClientCommitGraphFuncCall) Results() []interface{} {
+func (c IndexEnqueuerPlanIndexesForRevisionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// MockIndexEnqueuer is a mock implementation of the IndexEnqueuer interface
-// (from the package
+// MockIndexedSearcher is a mock implementation of the IndexedSearcher
+// interface (from the package
 // github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers)
 // used for unit testing.
-type MockIndexEnqueuer struct {
-	// ForceQueueIndexesForRepositoryFunc is an instance of a mock function
-	// object controlling the behavior of the method
-	// ForceQueueIndexesForRepository.
-	ForceQueueIndexesForRepositoryFunc *IndexEnqueuerForceQueueIndexesForRepositoryFunc
-	// InferIndexConfigurationFunc is an instance of a mock function object
-	// controlling the behavior of the method InferIndexConfiguration.
-	InferIndexConfigurationFunc *IndexEnqueuerInferIndexConfigurationFunc
+type MockIndexedSearcher struct {
+	// EnabledFunc is an instance of a mock function object controlling the
+	// behavior of the method Enabled.
+	EnabledFunc *IndexedSearcherEnabledFunc
+	// SearchFunc is an instance of a mock function object controlling the
+	// behavior of the method Search.
+	SearchFunc *IndexedSearcherSearchFunc
 }
 
-// NewMockIndexEnqueuer creates a new mock of the IndexEnqueuer interface.
-// All methods return zero values for all results, unless overwritten.
-func NewMockIndexEnqueuer() *MockIndexEnqueuer {
-	return &MockIndexEnqueuer{
-		ForceQueueIndexesForRepositoryFunc: &IndexEnqueuerForceQueueIndexesForRepositoryFunc{
-			defaultHook: func(context.Context, int, string) error {
-				return nil
+// NewMockIndexedSearcher creates a new mock of the IndexedSearcher
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockIndexedSearcher() *MockIndexedSearcher {
+	return &MockIndexedSearcher{
+		EnabledFunc: &IndexedSearcherEnabledFunc{
+			defaultHook: func() bool {
+				return false
 			},
 		},
-		InferIndexConfigurationFunc: &IndexEnqueuerInferIndexConfigurationFunc{
-			defaultHook: func(context.Context, int) (*config.IndexConfiguration, error) {
+		SearchFunc: &IndexedSearcherSearchFunc{
+			defaultHook: func(context.Context, query.Q, *zoekt.SearchOptions) (*zoekt.SearchResult, error) {
 				return nil, nil
 			},
 		},
 	}
 }
 
-// NewMockIndexEnqueuerFrom creates a new mock of the MockIndexEnqueuer
+// NewMockIndexedSearcherFrom creates a new mock of the MockIndexedSearcher
 // interface. All methods delegate to the given implementation, unless
 // overwritten.
-func NewMockIndexEnqueuerFrom(i IndexEnqueuer) *MockIndexEnqueuer {
-	return &MockIndexEnqueuer{
-		ForceQueueIndexesForRepositoryFunc: &IndexEnqueuerForceQueueIndexesForRepositoryFunc{
-			defaultHook: i.ForceQueueIndexesForRepository,
+func NewMockIndexedSearcherFrom(i IndexedSearcher) *MockIndexedSearcher {
+	return &MockIndexedSearcher{
+		EnabledFunc: &IndexedSearcherEnabledFunc{
+			defaultHook: i.Enabled,
 		},
-		InferIndexConfigurationFunc: &IndexEnqueuerInferIndexConfigurationFunc{
-			defaultHook: i.InferIndexConfiguration,
+		SearchFunc: &IndexedSearcherSearchFunc{
+			defaultHook: i.Search,
 		},
 	}
 }
 
-// IndexEnqueuerForceQueueIndexesForRepositoryFunc describes the behavior
-// when the ForceQueueIndexesForRepository method of the parent
-// MockIndexEnqueuer instance is invoked.
-type IndexEnqueuerForceQueueIndexesForRepositoryFunc struct {
-	defaultHook func(context.Context, int, string) error
-	hooks       []func(context.Context, int, string) error
-	history     []IndexEnqueuerForceQueueIndexesForRepositoryFuncCall
+// IndexedSearcherEnabledFunc describes the behavior when the Enabled method
+// of the parent MockIndexedSearcher instance is invoked.
+type IndexedSearcherEnabledFunc struct {
+	defaultHook func() bool
+	hooks       []func() bool
+	history     []IndexedSearcherEnabledFuncCall
 	mutex       sync.Mutex
 }
 
-// ForceQueueIndexesForRepository delegates to the next hook function in the
-// queue and stores the parameter and result values of this invocation.
-func (m *MockIndexEnqueuer) ForceQueueIndexesForRepository(v0 context.Context, v1 int, v2 string) error {
-	r0 := m.ForceQueueIndexesForRepositoryFunc.nextHook()(v0, v1, v2)
-	m.ForceQueueIndexesForRepositoryFunc.appendCall(IndexEnqueuerForceQueueIndexesForRepositoryFuncCall{v0, v1, v2, r0})
+// Enabled delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockIndexedSearcher) Enabled() bool {
+	r0 := m.EnabledFunc.nextHook()()
+	m.EnabledFunc.appendCall(IndexedSearcherEnabledFuncCall{r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the
-// ForceQueueIndexesForRepository method of the parent MockIndexEnqueuer
-// instance is invoked and the hook queue is empty.
-func (f *IndexEnqueuerForceQueueIndexesForRepositoryFunc) SetDefaultHook(hook func(context.Context, int, string) error) {
+// SetDefaultHook sets function that is called when the Enabled method of
+// the parent MockIndexedSearcher instance is invoked and the hook queue is
+// empty.
+func (f *IndexedSearcherEnabledFunc) SetDefaultHook(hook func() bool) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ForceQueueIndexesForRepository method of the parent MockIndexEnqueuer
-// instance invokes the hook at the front of the queue and discards it.
-// After the queue is empty, the default hook function is invoked for any
-// future action.
-func (f *IndexEnqueuerForceQueueIndexesForRepositoryFunc) PushHook(hook func(context.Context, int, string) error) {
+// Enabled method of the parent MockIndexedSearcher instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *IndexedSearcherEnabledFunc) PushHook(hook func() bool) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -4525,21 +5933,21 @@ func (f *IndexEnqueuerForceQueueIndexesForRepositoryFunc) PushHook(hook func(con
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *IndexEnqueuerForceQueueIndexesForRepositoryFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int, string) error {
+func (f *IndexedSearcherEnabledFunc) SetDefaultReturn(r0 bool) {
+	f.SetDefaultHook(func() bool {
 		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *IndexEnqueuerForceQueueIndexesForRepositoryFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int, string) error {
+func (f *IndexedSearcherEnabledFunc) PushReturn(r0 bool) {
+	f.PushHook(func() bool {
 		return r0
 	})
 }
 
-func (f *IndexEnqueuerForceQueueIndexesForRepositoryFunc) nextHook() func(context.Context, int, string) error {
+func (f *IndexedSearcherEnabledFunc) nextHook() func() bool {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -4552,85 +5960,72 @@ func (f *IndexEnqueuerForceQueueIndexesForRepositoryFunc) nextHook() func(contex
 	return hook
 }
 
-func (f *IndexEnqueuerForceQueueIndexesForRepositoryFunc) appendCall(r0 IndexEnqueuerForceQueueIndexesForRepositoryFuncCall) {
+func (f *IndexedSearcherEnabledFunc) appendCall(r0 IndexedSearcherEnabledFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// IndexEnqueuerForceQueueIndexesForRepositoryFuncCall objects describing
-// the invocations of this function.
-func (f *IndexEnqueuerForceQueueIndexesForRepositoryFunc) History() []IndexEnqueuerForceQueueIndexesForRepositoryFuncCall {
+// History returns a sequence of IndexedSearcherEnabledFuncCall objects
+// describing the invocations of this function.
+func (f *IndexedSearcherEnabledFunc) History() []IndexedSearcherEnabledFuncCall {
 	f.mutex.Lock()
-	history := make([]IndexEnqueuerForceQueueIndexesForRepositoryFuncCall, len(f.history))
+	history := make([]IndexedSearcherEnabledFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// IndexEnqueuerForceQueueIndexesForRepositoryFuncCall is an object that
-// describes an invocation of method ForceQueueIndexesForRepository on an
-// instance of MockIndexEnqueuer.
-type IndexEnqueuerForceQueueIndexesForRepositoryFuncCall struct {
-	// Arg0 is the value of the 1st argument passed to this method
-	// invocation.
-	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 int
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 string
+// IndexedSearcherEnabledFuncCall is an object that describes an invocation
+// of method Enabled on an instance of MockIndexedSearcher.
+type IndexedSearcherEnabledFuncCall struct {
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 bool
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c IndexEnqueuerForceQueueIndexesForRepositoryFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c IndexedSearcherEnabledFuncCall) Args() []interface{} {
+	return []interface{}{}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c IndexEnqueuerForceQueueIndexesForRepositoryFuncCall) Results() []interface{} {
+func (c IndexedSearcherEnabledFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// IndexEnqueuerInferIndexConfigurationFunc describes the behavior when the
-// InferIndexConfiguration method of the parent MockIndexEnqueuer instance
-// is invoked.
-type IndexEnqueuerInferIndexConfigurationFunc struct {
-	defaultHook func(context.Context, int) (*config.IndexConfiguration, error)
-	hooks       []func(context.Context, int) (*config.IndexConfiguration, error)
-	history     []IndexEnqueuerInferIndexConfigurationFuncCall
+// IndexedSearcherSearchFunc describes the behavior when the Search method
+// of the parent MockIndexedSearcher instance is invoked.
+type IndexedSearcherSearchFunc struct {
+	defaultHook func(context.Context, query.Q, *zoekt.SearchOptions) (*zoekt.SearchResult, error)
+	hooks       []func(context.Context, query.Q, *zoekt.SearchOptions) (*zoekt.SearchResult, error)
+	history     []IndexedSearcherSearchFuncCall
 	mutex       sync.Mutex
 }
 
-// InferIndexConfiguration delegates to the next hook function in the queue
-// and stores the parameter and result values of this invocation.
-func (m *MockIndexEnqueuer) InferIndexConfiguration(v0 context.Context, v1 int) (*config.IndexConfiguration, error) {
-	r0, r1 := m.InferIndexConfigurationFunc.nextHook()(v0, v1)
-	m.InferIndexConfigurationFunc.appendCall(IndexEnqueuerInferIndexConfigurationFuncCall{v0, v1, r0, r1})
+// Search delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockIndexedSearcher) Search(v0 context.Context, v1 query.Q, v2 *zoekt.SearchOptions) (*zoekt.SearchResult, error) {
+	r0, r1 := m.SearchFunc.nextHook()(v0, v1, v2)
+	m.SearchFunc.appendCall(IndexedSearcherSearchFuncCall{v0, v1, v2, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the
-// InferIndexConfiguration method of the parent MockIndexEnqueuer instance
-// is invoked and the hook queue is empty.
-func (f *IndexEnqueuerInferIndexConfigurationFunc) SetDefaultHook(hook func(context.Context, int) (*config.IndexConfiguration, error)) {
+// SetDefaultHook sets function that is called when the Search method of the
+// parent MockIndexedSearcher instance is invoked and the hook queue is
+// empty.
+func (f *IndexedSearcherSearchFunc) SetDefaultHook(hook func(context.Context, query.Q, *zoekt.SearchOptions) (*zoekt.SearchResult, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// InferIndexConfiguration method of the parent MockIndexEnqueuer instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *IndexEnqueuerInferIndexConfigurationFunc) PushHook(hook func(context.Context, int) (*config.IndexConfiguration, error)) {
+// Search method of the parent MockIndexedSearcher instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *IndexedSearcherSearchFunc) PushHook(hook func(context.Context, query.Q, *zoekt.SearchOptions) (*zoekt.SearchResult, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -4638,21 +6033,21 @@ func (f *IndexEnqueuerInferIndexConfigurationFunc) PushHook(hook func(context.Co
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *IndexEnqueuerInferIndexConfigurationFunc) SetDefaultReturn(r0 *config.IndexConfiguration, r1 error) {
-	f.SetDefaultHook(func(context.Context, int) (*config.IndexConfiguration, error) {
+func (f *IndexedSearcherSearchFunc) SetDefaultReturn(r0 *zoekt.SearchResult, r1 error) {
+	f.SetDefaultHook(func(context.Context, query.Q, *zoekt.SearchOptions) (*zoekt.SearchResult, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *IndexEnqueuerInferIndexConfigurationFunc) PushReturn(r0 *config.IndexConfiguration, r1 error) {
-	f.PushHook(func(context.Context, int) (*config.IndexConfiguration, error) {
+func (f *IndexedSearcherSearchFunc) PushReturn(r0 *zoekt.SearchResult, r1 error) {
+	f.PushHook(func(context.Context, query.Q, *zoekt.SearchOptions) (*zoekt.SearchResult, error) {
 		return r0, r1
 	})
 }
 
-func (f *IndexEnqueuerInferIndexConfigurationFunc) nextHook() func(context.Context, int) (*config.IndexConfiguration, error) {
+func (f *IndexedSearcherSearchFunc) nextHook() func(context.Context, query.Q, *zoekt.SearchOptions) (*zoekt.SearchResult, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -4665,37 +6060,38 @@ func (f *IndexEnqueuerInferIndexConfigurationFunc) nextHook() func(context.Conte
 	return hook
 }
 
-func (f *IndexEnqueuerInferIndexConfigurationFunc) appendCall(r0 IndexEnqueuerInferIndexConfigurationFuncCall) {
+func (f *IndexedSearcherSearchFunc) appendCall(r0 IndexedSearcherSearchFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// IndexEnqueuerInferIndexConfigurationFuncCall objects describing the
-// invocations of this function.
-func (f *IndexEnqueuerInferIndexConfigurationFunc) History() []IndexEnqueuerInferIndexConfigurationFuncCall {
+// History returns a sequence of IndexedSearcherSearchFuncCall objects
+// describing the invocations of this function.
+func (f *IndexedSearcherSearchFunc) History() []IndexedSearcherSearchFuncCall {
 	f.mutex.Lock()
-	history := make([]IndexEnqueuerInferIndexConfigurationFuncCall, len(f.history))
+	history := make([]IndexedSearcherSearchFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// IndexEnqueuerInferIndexConfigurationFuncCall is an object that describes
-// an invocation of method InferIndexConfiguration on an instance of
-// MockIndexEnqueuer.
-type IndexEnqueuerInferIndexConfigurationFuncCall struct {
+// IndexedSearcherSearchFuncCall is an object that describes an invocation
+// of method Search on an instance of MockIndexedSearcher.
+type IndexedSearcherSearchFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int
+	Arg1 query.Q
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 *zoekt.SearchOptions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *config.IndexConfiguration
+	Result0 *zoekt.SearchResult
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -4703,13 +6099,13 @@ type IndexEnqueuerInferIndexConfigurationFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c IndexEnqueuerInferIndexConfigurationFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c IndexedSearcherSearchFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c IndexEnqueuerInferIndexConfigurationFuncCall) Results() []interface{} {
+func (c IndexedSearcherSearchFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
@@ -6581,3 +7977,144 @@ func (c RepoUpdaterClientEnqueueRepoUpdateFuncCall) Args() []interface{} {
 func (c RepoUpdaterClientEnqueueRepoUpdateFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
+
+// MockSymbolsClient is a mock implementation of the SymbolsClient interface
+// (from the package
+// github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers)
+// used for unit testing.
+type MockSymbolsClient struct {
+	// SearchFunc is an instance of a mock function object controlling the
+	// behavior of the method Search.
+	SearchFunc *SymbolsClientSearchFunc
+}
+
+// NewMockSymbolsClient creates a new mock of the SymbolsClient interface.
+// All methods return zero values for all results, unless overwritten.
+func NewMockSymbolsClient() *MockSymbolsClient {
+	return &MockSymbolsClient{
+		SearchFunc: &SymbolsClientSearchFunc{
+			defaultHook: func(context.Context, search.SymbolsParameters) (*[]result.Symbol, error) {
+				return nil, nil
+			},
+		},
+	}
+}
+
+// NewMockSymbolsClientFrom creates a new mock of the MockSymbolsClient
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockSymbolsClientFrom(i SymbolsClient) *MockSymbolsClient {
+	return &MockSymbolsClient{
+		SearchFunc: &SymbolsClientSearchFunc{
+			defaultHook: i.Search,
+		},
+	}
+}
+
+// SymbolsClientSearchFunc describes the behavior when the Search method of
+// the parent MockSymbolsClient instance is invoked.
+type SymbolsClientSearchFunc struct {
+	defaultHook func(context.Context, search.SymbolsParameters) (*[]result.Symbol, error)
+	hooks       []func(context.Context, search.SymbolsParameters) (*[]result.Symbol, error)
+	history     []SymbolsClientSearchFuncCall
+	mutex       sync.Mutex
+}
+
+// Search delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSymbolsClient) Search(v0 context.Context, v1 search.SymbolsParameters) (*[]result.Symbol, error) {
+	r0, r1 := m.SearchFunc.nextHook()(v0, v1)
+	m.SearchFunc.appendCall(SymbolsClientSearchFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Search method of the
+// parent MockSymbolsClient instance is invoked and the hook queue is empty.
+func (f *SymbolsClientSearchFunc) SetDefaultHook(hook func(context.Context, search.SymbolsParameters) (*[]result.Symbol, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Search method of the parent MockSymbolsClient instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SymbolsClientSearchFunc) PushHook(hook func(context.Context, search.SymbolsParameters) (*[]result.Symbol, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *SymbolsClientSearchFunc) SetDefaultReturn(r0 *[]result.Symbol, r1 error) {
+	f.SetDefaultHook(func(context.Context, search.SymbolsParameters) (*[]result.Symbol, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *SymbolsClientSearchFunc) PushReturn(r0 *[]result.Symbol, r1 error) {
+	f.PushHook(func(context.Context, search.SymbolsParameters) (*[]result.Symbol, error) {
+		return r0, r1
+	})
+}
+
+func (f *SymbolsClientSearchFunc) nextHook() func(context.Context, search.SymbolsParameters) (*[]result.Symbol, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SymbolsClientSearchFunc) appendCall(r0 SymbolsClientSearchFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SymbolsClientSearchFuncCall objects
+// describing the invocations of this function.
+func (f *SymbolsClientSearchFunc) History() []SymbolsClientSearchFuncCall {
+	f.mutex.Lock()
+	history := make([]SymbolsClientSearchFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SymbolsClientSearchFuncCall is an object that describes an invocation of
+// method Search on an instance of MockSymbolsClient.
+type SymbolsClientSearchFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 search.SymbolsParameters
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *[]result.Symbol
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SymbolsClientSearchFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SymbolsClientSearchFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
@@ -2,3 +2,4 @@ package mocks
 
 //go:generate ../../../../../../../dev/mockgen.sh github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers -i Resolver -o mock_resolver.go
 //go:generate ../../../../../../../dev/mockgen.sh github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers -i QueryResolver -o mock_query.go
+//go:generate ../../../../../../../dev/mockgen.sh github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers -i SearchBasedQueryResolver -o mock_search_based_query.go
@@ -16,6 +16,9 @@ import (
 // github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers)
 // used for unit testing.
 type MockQueryResolver struct {
+	// ConfidenceFunc is an instance of a mock function object controlling
+	// the behavior of the method Confidence.
+	ConfidenceFunc *QueryResolverConfidenceFunc
 	// DefinitionsFunc is an instance of a mock function object controlling
 	// the behavior of the method Definitions.
 	DefinitionsFunc *QueryResolverDefinitionsFunc
@@ -52,6 +55,11 @@ type MockQueryResolver struct {
 // All methods return zero values for all results, unless overwritten.
 func NewMockQueryResolver() *MockQueryResolver {
 	return &MockQueryResolver{
+		ConfidenceFunc: &QueryResolverConfidenceFunc{
+			defaultHook: func() resolvers.Confidence {
+				return ""
+			},
+		},
 		DefinitionsFunc: &QueryResolverDefinitionsFunc{
 			defaultHook: func(context.Context, int, int) ([]resolvers.AdjustedLocation, error) {
 				return nil, nil
@@ -110,6 +118,9 @@ func NewMockQueryResolver() *MockQueryResolver {
 // overwritten.
 func NewMockQueryResolverFrom(i resolvers.QueryResolver) *MockQueryResolver {
 	return &MockQueryResolver{
+		ConfidenceFunc: &QueryResolverConfidenceFunc{
+			defaultHook: i.Confidence,
+		},
 		DefinitionsFunc: &QueryResolverDefinitionsFunc{
 			defaultHook: i.Definitions,
 		},
@@ -143,6 +154,106 @@ func NewMockQueryResolverFrom(i resolvers.QueryResolver) *MockQueryResolver {
 	}
 }
 
+// QueryResolverConfidenceFunc describes the behavior when the Confidence
+// method of the parent MockQueryResolver instance is invoked.
+type QueryResolverConfidenceFunc struct {
+	defaultHook func() resolvers.Confidence
+	hooks       []func() resolvers.Confidence
+	history     []QueryResolverConfidenceFuncCall
+	mutex       sync.Mutex
+}
+
+// Confidence delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockQueryResolver) Confidence() resolvers.Confidence {
+	r0 := m.ConfidenceFunc.nextHook()()
+	m.ConfidenceFunc.appendCall(QueryResolverConfidenceFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Confidence method of
+// the parent MockQueryResolver instance is invoked and the hook queue is
+// empty.
+func (f *QueryResolverConfidenceFunc) SetDefaultHook(hook func() resolvers.Confidence) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Confidence method of the parent MockQueryResolver instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *QueryResolverConfidenceFunc) PushHook(hook func() resolvers.Confidence) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *QueryResolverConfidenceFunc) SetDefaultReturn(r0 resolvers.Confidence) {
+	f.SetDefaultHook(func() resolvers.Confidence {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *QueryResolverConfidenceFunc) PushReturn(r0 resolvers.Confidence) {
+	f.PushHook(func() resolvers.Confidence {
+		return r0
+	})
+}
+
+func (f *QueryResolverConfidenceFunc) nextHook() func() resolvers.Confidence {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *QueryResolverConfidenceFunc) appendCall(r0 QueryResolverConfidenceFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of QueryResolverConfidenceFuncCall objects
+// describing the invocations of this function.
+func (f *QueryResolverConfidenceFunc) History() []QueryResolverConfidenceFuncCall {
+	f.mutex.Lock()
+	history := make([]QueryResolverConfidenceFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// QueryResolverConfidenceFuncCall is an object that describes an invocation
+// of method Confidence on an instance of MockQueryResolver.
+type QueryResolverConfidenceFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 resolvers.Confidence
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c QueryResolverConfidenceFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c QueryResolverConfidenceFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // QueryResolverDefinitionsFunc describes the behavior when the Definitions
 // method of the parent MockQueryResolver instance is invoked.
 type QueryResolverDefinitionsFunc struct {
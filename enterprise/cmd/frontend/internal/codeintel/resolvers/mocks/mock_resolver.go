@@ -8,7 +8,9 @@ import (
 
 	graphqlbackend "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	resolvers "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers"
+	enqueuer "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindex/enqueuer"
 	dbstore "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	config "github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
 )
 
 // MockResolver is a mock implementation of the Resolver interface (from the
@@ -43,16 +45,33 @@ type MockResolver struct {
 	// IndexConnectionResolverFunc is an instance of a mock function object
 	// controlling the behavior of the method IndexConnectionResolver.
 	IndexConnectionResolverFunc *ResolverIndexConnectionResolverFunc
+	// InferredIndexConfigurationFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// InferredIndexConfiguration.
+	InferredIndexConfigurationFunc *ResolverInferredIndexConfigurationFunc
+	// IsIndexOnPushEnabledFunc is an instance of a mock function object
+	// controlling the behavior of the method IsIndexOnPushEnabled.
+	IsIndexOnPushEnabledFunc *ResolverIsIndexOnPushEnabledFunc
+	// PreviewIndexJobsFunc is an instance of a mock function object
+	// controlling the behavior of the method PreviewIndexJobs.
+	PreviewIndexJobsFunc *ResolverPreviewIndexJobsFunc
 	// QueryResolverFunc is an instance of a mock function object
 	// controlling the behavior of the method QueryResolver.
 	QueryResolverFunc *ResolverQueryResolverFunc
 	// QueueAutoIndexJobForRepoFunc is an instance of a mock function object
 	// controlling the behavior of the method QueueAutoIndexJobForRepo.
 	QueueAutoIndexJobForRepoFunc *ResolverQueueAutoIndexJobForRepoFunc
+	// SearchBasedQueryResolverFunc is an instance of a mock function object
+	// controlling the behavior of the method SearchBasedQueryResolver.
+	SearchBasedQueryResolverFunc *ResolverSearchBasedQueryResolverFunc
 	// UpdateIndexConfigurationByRepositoryIDFunc is an instance of a mock
 	// function object controlling the behavior of the method
 	// UpdateIndexConfigurationByRepositoryID.
 	UpdateIndexConfigurationByRepositoryIDFunc *ResolverUpdateIndexConfigurationByRepositoryIDFunc
+	// UpdateIndexOnPushByRepositoryIDFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// UpdateIndexOnPushByRepositoryID.
+	UpdateIndexOnPushByRepositoryIDFunc *ResolverUpdateIndexOnPushByRepositoryIDFunc
 	// UploadConnectionResolverFunc is an instance of a mock function object
 	// controlling the behavior of the method UploadConnectionResolver.
 	UploadConnectionResolverFunc *ResolverUploadConnectionResolverFunc
@@ -107,6 +126,21 @@ func NewMockResolver() *MockResolver {
 				return nil
 			},
 		},
+		InferredIndexConfigurationFunc: &ResolverInferredIndexConfigurationFunc{
+			defaultHook: func(context.Context, int, *string) (*config.IndexConfiguration, string, error) {
+				return nil, "", nil
+			},
+		},
+		IsIndexOnPushEnabledFunc: &ResolverIsIndexOnPushEnabledFunc{
+			defaultHook: func(context.Context, int) (bool, error) {
+				return false, nil
+			},
+		},
+		PreviewIndexJobsFunc: &ResolverPreviewIndexJobsFunc{
+			defaultHook: func(context.Context, int, *string) (enqueuer.IndexPlan, error) {
+				return enqueuer.IndexPlan{}, nil
+			},
+		},
 		QueryResolverFunc: &ResolverQueryResolverFunc{
 			defaultHook: func(context.Context, *graphqlbackend.GitBlobLSIFDataArgs) (resolvers.QueryResolver, error) {
 				return nil, nil
@@ -117,11 +151,21 @@ func NewMockResolver() *MockResolver {
 				return nil
 			},
 		},
+		SearchBasedQueryResolverFunc: &ResolverSearchBasedQueryResolverFunc{
+			defaultHook: func(*graphqlbackend.GitBlobLSIFDataArgs) resolvers.SearchBasedQueryResolver {
+				return nil
+			},
+		},
 		UpdateIndexConfigurationByRepositoryIDFunc: &ResolverUpdateIndexConfigurationByRepositoryIDFunc{
 			defaultHook: func(context.Context, int, string) error {
 				return nil
 			},
 		},
+		UpdateIndexOnPushByRepositoryIDFunc: &ResolverUpdateIndexOnPushByRepositoryIDFunc{
+			defaultHook: func(context.Context, int, bool) error {
+				return nil
+			},
+		},
 		UploadConnectionResolverFunc: &ResolverUploadConnectionResolverFunc{
 			defaultHook: func(dbstore.GetUploadsOptions) *resolvers.UploadsResolver {
 				return nil
@@ -161,15 +205,30 @@ func NewMockResolverFrom(i resolvers.Resolver) *MockResolver {
 		IndexConnectionResolverFunc: &ResolverIndexConnectionResolverFunc{
 			defaultHook: i.IndexConnectionResolver,
 		},
+		InferredIndexConfigurationFunc: &ResolverInferredIndexConfigurationFunc{
+			defaultHook: i.InferredIndexConfiguration,
+		},
+		IsIndexOnPushEnabledFunc: &ResolverIsIndexOnPushEnabledFunc{
+			defaultHook: i.IsIndexOnPushEnabled,
+		},
+		PreviewIndexJobsFunc: &ResolverPreviewIndexJobsFunc{
+			defaultHook: i.PreviewIndexJobs,
+		},
 		QueryResolverFunc: &ResolverQueryResolverFunc{
 			defaultHook: i.QueryResolver,
 		},
 		QueueAutoIndexJobForRepoFunc: &ResolverQueueAutoIndexJobForRepoFunc{
 			defaultHook: i.QueueAutoIndexJobForRepo,
 		},
+		SearchBasedQueryResolverFunc: &ResolverSearchBasedQueryResolverFunc{
+			defaultHook: i.SearchBasedQueryResolver,
+		},
 		UpdateIndexConfigurationByRepositoryIDFunc: &ResolverUpdateIndexConfigurationByRepositoryIDFunc{
 			defaultHook: i.UpdateIndexConfigurationByRepositoryID,
 		},
+		UpdateIndexOnPushByRepositoryIDFunc: &ResolverUpdateIndexOnPushByRepositoryIDFunc{
+			defaultHook: i.UpdateIndexOnPushByRepositoryID,
+		},
 		UploadConnectionResolverFunc: &ResolverUploadConnectionResolverFunc{
 			defaultHook: i.UploadConnectionResolver,
 		},
@@ -1168,6 +1227,346 @@ func (c ResolverIndexConnectionResolverFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// ResolverInferredIndexConfigurationFunc describes the behavior when the
+// InferredIndexConfiguration method of the parent MockResolver instance is
+// invoked.
+type ResolverInferredIndexConfigurationFunc struct {
+	defaultHook func(context.Context, int, *string) (*config.IndexConfiguration, string, error)
+	hooks       []func(context.Context, int, *string) (*config.IndexConfiguration, string, error)
+	history     []ResolverInferredIndexConfigurationFuncCall
+	mutex       sync.Mutex
+}
+
+// InferredIndexConfiguration delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockResolver) InferredIndexConfiguration(v0 context.Context, v1 int, v2 *string) (*config.IndexConfiguration, string, error) {
+	r0, r1, r2 := m.InferredIndexConfigurationFunc.nextHook()(v0, v1, v2)
+	m.InferredIndexConfigurationFunc.appendCall(ResolverInferredIndexConfigurationFuncCall{v0, v1, v2, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// InferredIndexConfiguration method of the parent MockResolver instance is
+// invoked and the hook queue is empty.
+func (f *ResolverInferredIndexConfigurationFunc) SetDefaultHook(hook func(context.Context, int, *string) (*config.IndexConfiguration, string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InferredIndexConfiguration method of the parent MockResolver instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ResolverInferredIndexConfigurationFunc) PushHook(hook func(context.Context, int, *string) (*config.IndexConfiguration, string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverInferredIndexConfigurationFunc) SetDefaultReturn(r0 *config.IndexConfiguration, r1 string, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, *string) (*config.IndexConfiguration, string, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverInferredIndexConfigurationFunc) PushReturn(r0 *config.IndexConfiguration, r1 string, r2 error) {
+	f.PushHook(func(context.Context, int, *string) (*config.IndexConfiguration, string, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *ResolverInferredIndexConfigurationFunc) nextHook() func(context.Context, int, *string) (*config.IndexConfiguration, string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverInferredIndexConfigurationFunc) appendCall(r0 ResolverInferredIndexConfigurationFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverInferredIndexConfigurationFuncCall
+// objects describing the invocations of this function.
+func (f *ResolverInferredIndexConfigurationFunc) History() []ResolverInferredIndexConfigurationFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverInferredIndexConfigurationFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverInferredIndexConfigurationFuncCall is an object that describes an
+// invocation of method InferredIndexConfiguration on an instance of
+// MockResolver.
+type ResolverInferredIndexConfigurationFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 *string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *config.IndexConfiguration
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 string
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverInferredIndexConfigurationFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverInferredIndexConfigurationFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// ResolverIsIndexOnPushEnabledFunc describes the behavior when the
+// IsIndexOnPushEnabled method of the parent MockResolver instance is
+// invoked.
+type ResolverIsIndexOnPushEnabledFunc struct {
+	defaultHook func(context.Context, int) (bool, error)
+	hooks       []func(context.Context, int) (bool, error)
+	history     []ResolverIsIndexOnPushEnabledFuncCall
+	mutex       sync.Mutex
+}
+
+// IsIndexOnPushEnabled delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockResolver) IsIndexOnPushEnabled(v0 context.Context, v1 int) (bool, error) {
+	r0, r1 := m.IsIndexOnPushEnabledFunc.nextHook()(v0, v1)
+	m.IsIndexOnPushEnabledFunc.appendCall(ResolverIsIndexOnPushEnabledFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the IsIndexOnPushEnabled
+// method of the parent MockResolver instance is invoked and the hook queue
+// is empty.
+func (f *ResolverIsIndexOnPushEnabledFunc) SetDefaultHook(hook func(context.Context, int) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// IsIndexOnPushEnabled method of the parent MockResolver instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ResolverIsIndexOnPushEnabledFunc) PushHook(hook func(context.Context, int) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverIsIndexOnPushEnabledFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverIsIndexOnPushEnabledFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, int) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *ResolverIsIndexOnPushEnabledFunc) nextHook() func(context.Context, int) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverIsIndexOnPushEnabledFunc) appendCall(r0 ResolverIsIndexOnPushEnabledFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverIsIndexOnPushEnabledFuncCall
+// objects describing the invocations of this function.
+func (f *ResolverIsIndexOnPushEnabledFunc) History() []ResolverIsIndexOnPushEnabledFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverIsIndexOnPushEnabledFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverIsIndexOnPushEnabledFuncCall is an object that describes an
+// invocation of method IsIndexOnPushEnabled on an instance of MockResolver.
+type ResolverIsIndexOnPushEnabledFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverIsIndexOnPushEnabledFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverIsIndexOnPushEnabledFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ResolverPreviewIndexJobsFunc describes the behavior when the
+// PreviewIndexJobs method of the parent MockResolver instance is invoked.
+type ResolverPreviewIndexJobsFunc struct {
+	defaultHook func(context.Context, int, *string) (enqueuer.IndexPlan, error)
+	hooks       []func(context.Context, int, *string) (enqueuer.IndexPlan, error)
+	history     []ResolverPreviewIndexJobsFuncCall
+	mutex       sync.Mutex
+}
+
+// PreviewIndexJobs delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockResolver) PreviewIndexJobs(v0 context.Context, v1 int, v2 *string) (enqueuer.IndexPlan, error) {
+	r0, r1 := m.PreviewIndexJobsFunc.nextHook()(v0, v1, v2)
+	m.PreviewIndexJobsFunc.appendCall(ResolverPreviewIndexJobsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the PreviewIndexJobs
+// method of the parent MockResolver instance is invoked and the hook queue
+// is empty.
+func (f *ResolverPreviewIndexJobsFunc) SetDefaultHook(hook func(context.Context, int, *string) (enqueuer.IndexPlan, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// PreviewIndexJobs method of the parent MockResolver instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ResolverPreviewIndexJobsFunc) PushHook(hook func(context.Context, int, *string) (enqueuer.IndexPlan, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverPreviewIndexJobsFunc) SetDefaultReturn(r0 enqueuer.IndexPlan, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, *string) (enqueuer.IndexPlan, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverPreviewIndexJobsFunc) PushReturn(r0 enqueuer.IndexPlan, r1 error) {
+	f.PushHook(func(context.Context, int, *string) (enqueuer.IndexPlan, error) {
+		return r0, r1
+	})
+}
+
+func (f *ResolverPreviewIndexJobsFunc) nextHook() func(context.Context, int, *string) (enqueuer.IndexPlan, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverPreviewIndexJobsFunc) appendCall(r0 ResolverPreviewIndexJobsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverPreviewIndexJobsFuncCall objects
+// describing the invocations of this function.
+func (f *ResolverPreviewIndexJobsFunc) History() []ResolverPreviewIndexJobsFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverPreviewIndexJobsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverPreviewIndexJobsFuncCall is an object that describes an
+// invocation of method PreviewIndexJobs on an instance of MockResolver.
+type ResolverPreviewIndexJobsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 *string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 enqueuer.IndexPlan
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverPreviewIndexJobsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverPreviewIndexJobsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // ResolverQueryResolverFunc describes the behavior when the QueryResolver
 // method of the parent MockResolver instance is invoked.
 type ResolverQueryResolverFunc struct {
@@ -1389,6 +1788,112 @@ func (c ResolverQueueAutoIndexJobForRepoFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// ResolverSearchBasedQueryResolverFunc describes the behavior when the
+// SearchBasedQueryResolver method of the parent MockResolver instance is
+// invoked.
+type ResolverSearchBasedQueryResolverFunc struct {
+	defaultHook func(*graphqlbackend.GitBlobLSIFDataArgs) resolvers.SearchBasedQueryResolver
+	hooks       []func(*graphqlbackend.GitBlobLSIFDataArgs) resolvers.SearchBasedQueryResolver
+	history     []ResolverSearchBasedQueryResolverFuncCall
+	mutex       sync.Mutex
+}
+
+// SearchBasedQueryResolver delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockResolver) SearchBasedQueryResolver(v0 *graphqlbackend.GitBlobLSIFDataArgs) resolvers.SearchBasedQueryResolver {
+	r0 := m.SearchBasedQueryResolverFunc.nextHook()(v0)
+	m.SearchBasedQueryResolverFunc.appendCall(ResolverSearchBasedQueryResolverFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// SearchBasedQueryResolver method of the parent MockResolver instance is
+// invoked and the hook queue is empty.
+func (f *ResolverSearchBasedQueryResolverFunc) SetDefaultHook(hook func(*graphqlbackend.GitBlobLSIFDataArgs) resolvers.SearchBasedQueryResolver) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SearchBasedQueryResolver method of the parent MockResolver instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ResolverSearchBasedQueryResolverFunc) PushHook(hook func(*graphqlbackend.GitBlobLSIFDataArgs) resolvers.SearchBasedQueryResolver) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverSearchBasedQueryResolverFunc) SetDefaultReturn(r0 resolvers.SearchBasedQueryResolver) {
+	f.SetDefaultHook(func(*graphqlbackend.GitBlobLSIFDataArgs) resolvers.SearchBasedQueryResolver {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverSearchBasedQueryResolverFunc) PushReturn(r0 resolvers.SearchBasedQueryResolver) {
+	f.PushHook(func(*graphqlbackend.GitBlobLSIFDataArgs) resolvers.SearchBasedQueryResolver {
+		return r0
+	})
+}
+
+func (f *ResolverSearchBasedQueryResolverFunc) nextHook() func(*graphqlbackend.GitBlobLSIFDataArgs) resolvers.SearchBasedQueryResolver {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverSearchBasedQueryResolverFunc) appendCall(r0 ResolverSearchBasedQueryResolverFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverSearchBasedQueryResolverFuncCall
+// objects describing the invocations of this function.
+func (f *ResolverSearchBasedQueryResolverFunc) History() []ResolverSearchBasedQueryResolverFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverSearchBasedQueryResolverFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverSearchBasedQueryResolverFuncCall is an object that describes an
+// invocation of method SearchBasedQueryResolver on an instance of
+// MockResolver.
+type ResolverSearchBasedQueryResolverFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 *graphqlbackend.GitBlobLSIFDataArgs
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 resolvers.SearchBasedQueryResolver
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverSearchBasedQueryResolverFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverSearchBasedQueryResolverFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // ResolverUpdateIndexConfigurationByRepositoryIDFunc describes the behavior
 // when the UpdateIndexConfigurationByRepositoryID method of the parent
 // MockResolver instance is invoked.
@@ -1503,6 +2008,119 @@ func (c ResolverUpdateIndexConfigurationByRepositoryIDFuncCall) Results() []inte
 	return []interface{}{c.Result0}
 }
 
+// ResolverUpdateIndexOnPushByRepositoryIDFunc describes the behavior when
+// the UpdateIndexOnPushByRepositoryID method of the parent MockResolver
+// instance is invoked.
+type ResolverUpdateIndexOnPushByRepositoryIDFunc struct {
+	defaultHook func(context.Context, int, bool) error
+	hooks       []func(context.Context, int, bool) error
+	history     []ResolverUpdateIndexOnPushByRepositoryIDFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateIndexOnPushByRepositoryID delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockResolver) UpdateIndexOnPushByRepositoryID(v0 context.Context, v1 int, v2 bool) error {
+	r0 := m.UpdateIndexOnPushByRepositoryIDFunc.nextHook()(v0, v1, v2)
+	m.UpdateIndexOnPushByRepositoryIDFunc.appendCall(ResolverUpdateIndexOnPushByRepositoryIDFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateIndexOnPushByRepositoryID method of the parent MockResolver
+// instance is invoked and the hook queue is empty.
+func (f *ResolverUpdateIndexOnPushByRepositoryIDFunc) SetDefaultHook(hook func(context.Context, int, bool) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateIndexOnPushByRepositoryID method of the parent MockResolver
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *ResolverUpdateIndexOnPushByRepositoryIDFunc) PushHook(hook func(context.Context, int, bool) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverUpdateIndexOnPushByRepositoryIDFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, bool) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverUpdateIndexOnPushByRepositoryIDFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, bool) error {
+		return r0
+	})
+}
+
+func (f *ResolverUpdateIndexOnPushByRepositoryIDFunc) nextHook() func(context.Context, int, bool) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverUpdateIndexOnPushByRepositoryIDFunc) appendCall(r0 ResolverUpdateIndexOnPushByRepositoryIDFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// ResolverUpdateIndexOnPushByRepositoryIDFuncCall objects describing the
+// invocations of this function.
+func (f *ResolverUpdateIndexOnPushByRepositoryIDFunc) History() []ResolverUpdateIndexOnPushByRepositoryIDFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverUpdateIndexOnPushByRepositoryIDFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverUpdateIndexOnPushByRepositoryIDFuncCall is an object that
+// describes an invocation of method UpdateIndexOnPushByRepositoryID on an
+// instance of MockResolver.
+type ResolverUpdateIndexOnPushByRepositoryIDFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverUpdateIndexOnPushByRepositoryIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverUpdateIndexOnPushByRepositoryIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // ResolverUploadConnectionResolverFunc describes the behavior when the
 // UploadConnectionResolver method of the parent MockResolver instance is
 // invoked.
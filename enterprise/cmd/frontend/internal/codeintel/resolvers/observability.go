@@ -26,8 +26,11 @@ type operations struct {
 	documentationIDsToPathIDs *observation.Operation
 	documentationReferences   *observation.Operation
 	documentation             *observation.Operation
+	searchBasedDefinitions    *observation.Operation
+	searchBasedReferences     *observation.Operation
 
-	findClosestDumps *observation.Operation
+	findClosestDumps                  *observation.Operation
+	findClosestDumpsOnRelatedBranches *observation.Operation
 }
 
 func newOperations(observationContext *observation.Context) *operations {
@@ -67,8 +70,11 @@ func newOperations(observationContext *observation.Context) *operations {
 		documentationIDsToPathIDs: op("DocumentationIDsToPathIDs"),
 		documentationReferences:   op("DocumentationReferences"),
 		documentation:             op("Documentation"),
+		searchBasedDefinitions:    op("SearchBasedDefinitions"),
+		searchBasedReferences:     op("SearchBasedReferences"),
 
-		findClosestDumps: subOp("findClosestDumps"),
+		findClosestDumps:                  subOp("findClosestDumps"),
+		findClosestDumpsOnRelatedBranches: subOp("findClosestDumpsOnRelatedBranches"),
 	}
 }
 
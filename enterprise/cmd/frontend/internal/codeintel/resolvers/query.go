@@ -59,6 +59,7 @@ type QueryResolver interface {
 	Documentation(ctx context.Context, line int, character int) ([]*Documentation, error)
 	DocumentationDefinitions(ctx context.Context, pathID string) ([]AdjustedLocation, error)
 	DocumentationReferences(ctx context.Context, pathID string, limit int, rawCursor string) ([]AdjustedLocation, string, error)
+	Confidence() Confidence
 }
 
 type Documentation struct {
@@ -74,12 +75,14 @@ type queryResolver struct {
 	commit              string
 	path                string
 	uploads             []store.Dump
+	confidence          Confidence
 	operations          *operations
 }
 
 // NewQueryResolver create a new query resolver with the given services. The methods of this
 // struct return queries for the given repository, commit, and path, and will query only the
-// bundles associated with the given dump objects.
+// bundles associated with the given dump objects. The given confidence describes how those dump objects
+// were chosen relative to the requested commit.
 func NewQueryResolver(
 	dbStore DBStore,
 	lsifStore LSIFStore,
@@ -89,9 +92,10 @@ func NewQueryResolver(
 	commit string,
 	path string,
 	uploads []store.Dump,
+	confidence Confidence,
 	operations *operations,
 ) QueryResolver {
-	return newQueryResolver(dbStore, lsifStore, cachedCommitChecker, positionAdjuster, repositoryID, commit, path, uploads, operations)
+	return newQueryResolver(dbStore, lsifStore, cachedCommitChecker, positionAdjuster, repositoryID, commit, path, uploads, confidence, operations)
 }
 
 func newQueryResolver(
@@ -103,6 +107,7 @@ func newQueryResolver(
 	commit string,
 	path string,
 	uploads []store.Dump,
+	confidence Confidence,
 	operations *operations,
 ) *queryResolver {
 	return &queryResolver{
@@ -115,5 +120,11 @@ func newQueryResolver(
 		commit:              commit,
 		path:                path,
 		uploads:             uploads,
+		confidence:          confidence,
 	}
 }
+
+// Confidence returns how closely the uploads used to answer queries correspond to the requested commit.
+func (r *queryResolver) Confidence() Confidence {
+	return r.confidence
+}
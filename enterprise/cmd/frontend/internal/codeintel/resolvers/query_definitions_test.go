@@ -43,6 +43,7 @@ func TestDefinitions(t *testing.T) {
 		"deadbeef",
 		"s1/main.go",
 		uploads,
+		ConfidenceExact,
 		newOperations(&observation.TestContext),
 	)
 	adjustedLocations, err := resolver.Definitions(context.Background(), 10, 20)
@@ -121,6 +122,7 @@ func TestDefinitionsRemote(t *testing.T) {
 		"deadbeef",
 		"s1/main.go",
 		uploads,
+		ConfidenceExact,
 		newOperations(&observation.TestContext),
 	)
 	adjustedLocations, err := resolver.Definitions(context.Background(), 10, 20)
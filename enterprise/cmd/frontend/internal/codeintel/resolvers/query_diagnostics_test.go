@@ -45,6 +45,7 @@ func TestDiagnostics(t *testing.T) {
 		"deadbeef",
 		"s1/main.go",
 		uploads,
+		ConfidenceExact,
 		newOperations(&observation.TestContext),
 	)
 	adjustedDiagnostics, totalCount, err := resolver.Diagnostics(context.Background(), 5)
@@ -40,6 +40,7 @@ func TestHover(t *testing.T) {
 		"deadbeef",
 		"s1/main.go",
 		uploads,
+		ConfidenceExact,
 		newOperations(&observation.TestContext),
 	)
 	text, rn, exists, err := resolver.Hover(context.Background(), 10, 20)
@@ -122,6 +123,7 @@ func TestHoverRemote(t *testing.T) {
 		"deadbeef",
 		"s1/main.go",
 		uploads,
+		ConfidenceExact,
 		newOperations(&observation.TestContext),
 	)
 	text, rn, exists, err := resolver.Hover(context.Background(), 10, 20)
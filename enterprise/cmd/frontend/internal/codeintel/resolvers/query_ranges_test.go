@@ -53,6 +53,7 @@ func TestRanges(t *testing.T) {
 		"deadbeef",
 		"s1/main.go",
 		uploads,
+		ConfidenceExact,
 		newOperations(&observation.TestContext),
 	)
 	adjustedRanges, err := resolver.Ranges(context.Background(), 10, 20)
@@ -48,6 +48,7 @@ func TestReferences(t *testing.T) {
 		"deadbeef",
 		"s1/main.go",
 		uploads,
+		ConfidenceExact,
 		newOperations(&observation.TestContext),
 	)
 	adjustedLocations, _, err := resolver.References(context.Background(), 10, 20, 50, "")
@@ -169,6 +170,7 @@ func TestReferencesRemote(t *testing.T) {
 		"deadbeef",
 		"s1/main.go",
 		uploads,
+		ConfidenceExact,
 		newOperations(&observation.TestContext),
 	)
 	adjustedLocations, _, err := resolver.References(context.Background(), 10, 20, 50, "")
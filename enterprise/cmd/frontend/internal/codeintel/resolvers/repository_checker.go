@@ -0,0 +1,36 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+)
+
+type cachedRepositoryChecker struct {
+	dbStore DBStore
+	cache   map[int]bool
+}
+
+func newCachedRepositoryChecker(dbStore DBStore) *cachedRepositoryChecker {
+	return &cachedRepositoryChecker{
+		dbStore: dbStore,
+		cache:   map[int]bool{},
+	}
+}
+
+// exists determines if the given repository has any LSIF data. If we do not know the
+// answer from a previous call to exists, we ask the database and store the result for
+// a subsequent call.
+func (c *cachedRepositoryChecker) exists(ctx context.Context, repositoryID int) (bool, error) {
+	if exists, ok := c.cache[repositoryID]; ok {
+		return exists, nil
+	}
+
+	exists, err := c.dbStore.HasRepository(ctx, repositoryID)
+	if err != nil {
+		return false, errors.Wrap(err, "dbstore.HasRepository")
+	}
+
+	c.cache[repositoryID] = exists
+	return exists, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/opentracing/opentracing-go/log"
 
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindex/enqueuer"
 	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
@@ -30,9 +31,14 @@ type Resolver interface {
 	DeleteIndexByID(ctx context.Context, id int) error
 	IndexConfiguration(ctx context.Context, repositoryID int) ([]byte, error)
 	UpdateIndexConfigurationByRepositoryID(ctx context.Context, repositoryID int, configuration string) error
+	IsIndexOnPushEnabled(ctx context.Context, repositoryID int) (bool, error)
+	UpdateIndexOnPushByRepositoryID(ctx context.Context, repositoryID int, enabled bool) error
 	CommitGraph(ctx context.Context, repositoryID int) (gql.CodeIntelligenceCommitGraphResolver, error)
 	QueueAutoIndexJobForRepo(ctx context.Context, repositoryID int, rev *string) error
+	InferredIndexConfiguration(ctx context.Context, repositoryID int, rev *string) (*config.IndexConfiguration, string, error)
+	PreviewIndexJobs(ctx context.Context, repositoryID int, rev *string) (enqueuer.IndexPlan, error)
 	QueryResolver(ctx context.Context, args *gql.GitBlobLSIFDataArgs) (QueryResolver, error)
+	SearchBasedQueryResolver(args *gql.GitBlobLSIFDataArgs) SearchBasedQueryResolver
 }
 
 type resolver struct {
@@ -41,6 +47,9 @@ type resolver struct {
 	gitserverClient GitserverClient
 	indexEnqueuer   IndexEnqueuer
 	hunkCache       HunkCache
+	symbolsClient   SymbolsClient
+	indexedSearcher IndexedSearcher
+	mergeBaseCache  *mergeBaseCache
 	operations      *operations
 }
 
@@ -51,9 +60,11 @@ func NewResolver(
 	gitserverClient GitserverClient,
 	indexEnqueuer IndexEnqueuer,
 	hunkCache HunkCache,
+	symbolsClient SymbolsClient,
+	indexedSearcher IndexedSearcher,
 	observationContext *observation.Context,
 ) Resolver {
-	return newResolver(dbStore, lsifStore, gitserverClient, indexEnqueuer, hunkCache, observationContext)
+	return newResolver(dbStore, lsifStore, gitserverClient, indexEnqueuer, hunkCache, symbolsClient, indexedSearcher, observationContext)
 }
 
 func newResolver(
@@ -62,6 +73,8 @@ func newResolver(
 	gitserverClient GitserverClient,
 	indexEnqueuer IndexEnqueuer,
 	hunkCache HunkCache,
+	symbolsClient SymbolsClient,
+	indexedSearcher IndexedSearcher,
 	observationContext *observation.Context,
 ) *resolver {
 	return &resolver{
@@ -70,6 +83,9 @@ func newResolver(
 		gitserverClient: gitserverClient,
 		indexEnqueuer:   indexEnqueuer,
 		hunkCache:       hunkCache,
+		symbolsClient:   symbolsClient,
+		indexedSearcher: indexedSearcher,
+		mergeBaseCache:  newMergeBaseCache(),
 		operations:      newOperations(observationContext),
 	}
 }
@@ -114,7 +130,7 @@ func (r *resolver) IndexConfiguration(ctx context.Context, repositoryID int) ([]
 		return nil, err
 	}
 
-	if exists {
+	if exists && len(configuration.Data) != 0 {
 		return configuration.Data, nil
 	}
 
@@ -142,6 +158,14 @@ func (r *resolver) UpdateIndexConfigurationByRepositoryID(ctx context.Context, r
 	return r.dbStore.UpdateIndexConfigurationByRepositoryID(ctx, repositoryID, []byte(configuration))
 }
 
+func (r *resolver) IsIndexOnPushEnabled(ctx context.Context, repositoryID int) (bool, error) {
+	return r.dbStore.IsIndexOnPushEnabled(ctx, repositoryID)
+}
+
+func (r *resolver) UpdateIndexOnPushByRepositoryID(ctx context.Context, repositoryID int, enabled bool) error {
+	return r.dbStore.UpdateIndexOnPushByRepositoryID(ctx, repositoryID, enabled)
+}
+
 func (r *resolver) CommitGraph(ctx context.Context, repositoryID int) (gql.CodeIntelligenceCommitGraphResolver, error) {
 	stale, updatedAt, err := r.dbStore.CommitGraphMetadata(ctx, repositoryID)
 	if err != nil {
@@ -152,12 +176,24 @@ func (r *resolver) CommitGraph(ctx context.Context, repositoryID int) (gql.CodeI
 }
 
 func (r *resolver) QueueAutoIndexJobForRepo(ctx context.Context, repositoryID int, rev *string) error {
-	revOrDefault := "HEAD"
+	return r.indexEnqueuer.ForceQueueIndexesForRepository(ctx, repositoryID, revOrHead(rev))
+}
+
+func (r *resolver) InferredIndexConfiguration(ctx context.Context, repositoryID int, rev *string) (*config.IndexConfiguration, string, error) {
+	return r.indexEnqueuer.InferIndexConfigurationForRevision(ctx, repositoryID, revOrHead(rev))
+}
+
+func (r *resolver) PreviewIndexJobs(ctx context.Context, repositoryID int, rev *string) (enqueuer.IndexPlan, error) {
+	return r.indexEnqueuer.PlanIndexesForRevision(ctx, repositoryID, revOrHead(rev))
+}
+
+// revOrHead returns the given revision or HEAD if no revision is supplied.
+func revOrHead(rev *string) string {
 	if rev != nil {
-		revOrDefault = *rev
+		return *rev
 	}
 
-	return r.indexEnqueuer.ForceQueueIndexesForRepository(ctx, repositoryID, revOrDefault)
+	return "HEAD"
 }
 
 const slowQueryResolverRequestThreshold = time.Second
@@ -179,20 +215,44 @@ func (r *resolver) QueryResolver(ctx context.Context, args *gql.GitBlobLSIFDataA
 
 	cachedCommitChecker := newCachedCommitChecker(r.gitserverClient)
 	cachedCommitChecker.set(int(args.Repo.ID), string(args.Commit))
+	cachedRepositoryChecker := newCachedRepositoryChecker(r.dbStore)
 
 	dumps, err := r.findClosestDumps(
 		ctx,
 		cachedCommitChecker,
+		cachedRepositoryChecker,
 		int(args.Repo.ID),
 		string(args.Commit),
 		args.Path,
 		args.ExactPath,
 		args.ToolName,
 	)
-	if err != nil || len(dumps) == 0 {
+	if err != nil {
 		return nil, err
 	}
 
+	confidence := visibleUploadConfidence(string(args.Commit), dumps)
+
+	if len(dumps) == 0 {
+		// No upload is visible from the requested commit. Fall back to the uploads visible
+		// from the point where this commit's history diverged from another branch.
+		dumps, err = r.findClosestDumpsOnRelatedBranches(
+			ctx,
+			cachedCommitChecker,
+			cachedRepositoryChecker,
+			int(args.Repo.ID),
+			string(args.Commit),
+			args.Path,
+			args.ExactPath,
+			args.ToolName,
+		)
+		if err != nil || len(dumps) == 0 {
+			return nil, err
+		}
+
+		confidence = ConfidenceRelatedBranch
+	}
+
 	return NewQueryResolver(
 		r.dbStore,
 		r.lsifStore,
@@ -202,6 +262,22 @@ func (r *resolver) QueryResolver(ctx context.Context, args *gql.GitBlobLSIFDataA
 		string(args.Commit),
 		args.Path,
 		dumps,
+		confidence,
 		r.operations,
 	), nil
 }
+
+// SearchBasedQueryResolver constructs a new query resolver instance which answers code navigation
+// queries for the given repository, commit, and path using the symbols service and indexed search.
+func (r *resolver) SearchBasedQueryResolver(args *gql.GitBlobLSIFDataArgs) SearchBasedQueryResolver {
+	return NewSearchBasedQueryResolver(
+		r.gitserverClient,
+		r.symbolsClient,
+		r.indexedSearcher,
+		int(args.Repo.ID),
+		args.Repo.Name,
+		string(args.Commit),
+		args.Path,
+		r.operations,
+	)
+}
@@ -20,7 +20,7 @@ func TestQueryResolver(t *testing.T) {
 	mockLSIFStore := NewMockLSIFStore()
 	mockGitserverClient := NewMockGitserverClient()
 
-	resolver := NewResolver(mockDBStore, mockLSIFStore, mockGitserverClient, nil, nil, &observation.TestContext)
+	resolver := NewResolver(mockDBStore, mockLSIFStore, mockGitserverClient, nil, nil, nil, nil, &observation.TestContext)
 	queryResolver, err := resolver.QueryResolver(context.Background(), &gql.GitBlobLSIFDataArgs{
 		Repo:      &types.Repo{ID: 50},
 		Commit:    api.CommitID("deadbeef"),
@@ -35,6 +35,17 @@ func TestQueryResolver(t *testing.T) {
 	if queryResolver != nil {
 		t.Errorf("expected nil-valued resolver")
 	}
+
+	// The repository is unknown, so no related branches are searched for uploads.
+	if value := len(mockDBStore.HasRepositoryFunc.History()); value != 1 {
+		t.Errorf("unexpected number of calls to store.HasRepository. want=%d have=%d", 1, value)
+	}
+	if value := len(mockGitserverClient.RefDescriptionsFunc.History()); value != 0 {
+		t.Errorf("unexpected number of calls to gitserverClient.RefDescriptions. want=%d have=%d", 0, value)
+	}
+	if value := len(mockGitserverClient.MergeBaseFunc.History()); value != 0 {
+		t.Errorf("unexpected number of calls to gitserverClient.MergeBase. want=%d have=%d", 0, value)
+	}
 }
 
 const expectedFallbackIndexConfiguration = `{
@@ -80,7 +91,7 @@ func TestFallbackIndexConfiguration(t *testing.T) {
 	gitServerClient.HeadFunc.SetDefaultReturn("deadbeef", true, nil)
 	gitServerClient.ListFilesFunc.SetDefaultReturn([]string{"go.mod"}, nil)
 
-	resolver := NewResolver(mockDBStore, mockLSIFStore, mockGitserverClient, indexEnqueuer, nil, &observation.TestContext)
+	resolver := NewResolver(mockDBStore, mockLSIFStore, mockGitserverClient, indexEnqueuer, nil, nil, nil, &observation.TestContext)
 	json, err := resolver.IndexConfiguration(context.Background(), 0)
 	if err != nil {
 		t.Fatalf("unexpected error: %s", err)
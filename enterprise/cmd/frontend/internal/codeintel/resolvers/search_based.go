@@ -0,0 +1,308 @@
+package resolvers
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"regexp/syntax"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/zoekt"
+	zoektquery "github.com/google/zoekt/query"
+	"github.com/opentracing/opentracing-go/log"
+
+	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/inventory"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+)
+
+// SearchBasedQueryResolver answers code navigation queries for a single file without the use of
+// precise code intelligence data. Definitions are found via the symbols service and references
+// are found via indexed text search. Results are heuristic and are ranked so that the candidates
+// most likely to be correct appear first.
+type SearchBasedQueryResolver interface {
+	Definitions(ctx context.Context, line, character int) ([]AdjustedLocation, error)
+	References(ctx context.Context, line, character, limit int, rawCursor string) ([]AdjustedLocation, string, error)
+}
+
+type searchBasedQueryResolver struct {
+	gitserverClient GitserverClient
+	symbolsClient   SymbolsClient
+	indexedSearcher IndexedSearcher
+	repositoryID    int
+	repositoryName  api.RepoName
+	commit          string
+	path            string
+	operations      *operations
+}
+
+// NewSearchBasedQueryResolver creates a new search-based query resolver for the given repository,
+// commit, and path.
+func NewSearchBasedQueryResolver(
+	gitserverClient GitserverClient,
+	symbolsClient SymbolsClient,
+	indexedSearcher IndexedSearcher,
+	repositoryID int,
+	repositoryName api.RepoName,
+	commit string,
+	path string,
+	operations *operations,
+) SearchBasedQueryResolver {
+	return newSearchBasedQueryResolver(gitserverClient, symbolsClient, indexedSearcher, repositoryID, repositoryName, commit, path, operations)
+}
+
+func newSearchBasedQueryResolver(
+	gitserverClient GitserverClient,
+	symbolsClient SymbolsClient,
+	indexedSearcher IndexedSearcher,
+	repositoryID int,
+	repositoryName api.RepoName,
+	commit string,
+	path string,
+	operations *operations,
+) *searchBasedQueryResolver {
+	return &searchBasedQueryResolver{
+		gitserverClient: gitserverClient,
+		symbolsClient:   symbolsClient,
+		indexedSearcher: indexedSearcher,
+		repositoryID:    repositoryID,
+		repositoryName:  repositoryName,
+		commit:          commit,
+		path:            path,
+		operations:      operations,
+	}
+}
+
+const slowSearchBasedRequestThreshold = 2 * time.Second
+
+// SearchBasedDefinitionsLimit is the maximum number of locations returned from search-based Definitions.
+const SearchBasedDefinitionsLimit = 25
+
+// searchBasedReferencesSearchLimit is the maximum number of matches requested from indexed search
+// for a single search-based References query. Matches beyond this limit are not ranked or paged.
+const searchBasedReferencesSearchLimit = 500
+
+// Definitions returns the symbols whose name matches the identifier at the given position, ranked
+// by their likelihood of being the definition of that identifier.
+func (r *searchBasedQueryResolver) Definitions(ctx context.Context, line, character int) (_ []AdjustedLocation, err error) {
+	ctx, traceLog, endObservation := observeResolver(ctx, &err, "SearchBasedDefinitions", r.operations.searchBasedDefinitions, slowSearchBasedRequestThreshold, observation.Args{
+		LogFields: []log.Field{
+			log.Int("repositoryID", r.repositoryID),
+			log.String("commit", r.commit),
+			log.String("path", r.path),
+			log.Int("line", line),
+			log.Int("character", character),
+		},
+	})
+	defer endObservation()
+
+	contents, err := r.gitserverClient.RawContents(ctx, r.repositoryID, r.commit, r.path)
+	if err != nil {
+		return nil, errors.Wrap(err, "gitserverClient.RawContents")
+	}
+
+	name, ok := identifierAtPosition(contents, line, character)
+	if !ok {
+		return nil, nil
+	}
+	traceLog(log.String("name", name))
+
+	symbols, err := r.symbolsClient.Search(ctx, search.SymbolsParameters{
+		Repo:            r.repositoryName,
+		CommitID:        api.CommitID(r.commit),
+		Query:           "^" + regexp.QuoteMeta(name) + "$",
+		IsRegExp:        true,
+		IsCaseSensitive: true,
+		// Fetch more candidates than are returned, so that the best ranked ones are not cut off
+		// by the arbitrary order of the symbols service.
+		First: DefinitionsLimit,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "symbolsClient.Search")
+	}
+	if symbols == nil {
+		return nil, nil
+	}
+	traceLog(log.Int("numSymbols", len(*symbols)))
+
+	candidates := make([]searchBasedCandidate, 0, len(*symbols))
+	for _, symbol := range *symbols {
+		symbolRange := symbol.Range()
+
+		candidates = append(candidates, searchBasedCandidate{
+			location: r.adjustedLocation(r.commit, symbol.Path, lsifstore.Range{
+				Start: lsifstore.Position{Line: symbolRange.Start.Line, Character: symbolRange.Start.Character},
+				End:   lsifstore.Position{Line: symbolRange.End.Line, Character: symbolRange.End.Character},
+			}),
+			language: symbol.Language,
+		})
+	}
+
+	locations := newSearchBasedRanker(r.path, contents).rank(candidates)
+	if len(locations) > SearchBasedDefinitionsLimit {
+		locations = locations[:SearchBasedDefinitionsLimit]
+	}
+
+	return locations, nil
+}
+
+// References returns the whole-word, case-sensitive occurrences of the identifier at the given
+// position in the indexed default branch of the repository, ranked by their likelihood of referring
+// to the same symbol. The cursor is the offset of the next page into the ranked results.
+func (r *searchBasedQueryResolver) References(ctx context.Context, line, character, limit int, rawCursor string) (_ []AdjustedLocation, _ string, err error) {
+	ctx, traceLog, endObservation := observeResolver(ctx, &err, "SearchBasedReferences", r.operations.searchBasedReferences, slowSearchBasedRequestThreshold, observation.Args{
+		LogFields: []log.Field{
+			log.Int("repositoryID", r.repositoryID),
+			log.String("commit", r.commit),
+			log.String("path", r.path),
+			log.Int("line", line),
+			log.Int("character", character),
+			log.Int("limit", limit),
+			log.String("rawCursor", rawCursor),
+		},
+	})
+	defer endObservation()
+
+	offset := 0
+	if rawCursor != "" {
+		if offset, err = strconv.Atoi(rawCursor); err != nil || offset < 0 {
+			return nil, "", errors.Errorf("malformed search-based references cursor %q", rawCursor)
+		}
+	}
+
+	if r.indexedSearcher == nil || !r.indexedSearcher.Enabled() {
+		return nil, "", nil
+	}
+
+	contents, err := r.gitserverClient.RawContents(ctx, r.repositoryID, r.commit, r.path)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "gitserverClient.RawContents")
+	}
+
+	name, ok := identifierAtPosition(contents, line, character)
+	if !ok {
+		return nil, "", nil
+	}
+	traceLog(log.String("name", name))
+
+	pattern, err := syntax.Parse(`\b`+regexp.QuoteMeta(name)+`\b`, syntax.Perl)
+	if err != nil {
+		return nil, "", err
+	}
+
+	result, err := r.indexedSearcher.Search(ctx, zoektquery.NewAnd(
+		&zoektquery.RepoBranches{Set: map[string][]string{string(r.repositoryName): {"HEAD"}}},
+		&zoektquery.Regexp{Regexp: pattern, Content: true, CaseSensitive: true},
+	), &zoekt.SearchOptions{
+		ShardMaxMatchCount: searchBasedReferencesSearchLimit,
+		TotalMaxMatchCount: searchBasedReferencesSearchLimit,
+		MaxDocDisplayCount: searchBasedReferencesSearchLimit,
+	})
+	if err != nil {
+		return nil, "", errors.Wrap(err, "indexedSearcher.Search")
+	}
+	traceLog(log.Int("numFiles", len(result.Files)))
+
+	var candidates []searchBasedCandidate
+	for _, file := range result.Files {
+		// Indexed search returns results for the indexed commit of the default branch, which may
+		// differ from the requested commit. Link to the commit the match was actually found in.
+		commit := file.Version
+		if commit == "" {
+			commit = r.commit
+		}
+
+		for _, lineMatch := range file.LineMatches {
+			if lineMatch.FileName {
+				continue
+			}
+
+			for _, fragment := range lineMatch.LineFragments {
+				start := utf8.RuneCount(lineMatch.Line[:fragment.LineOffset])
+				end := start + utf8.RuneCount(lineMatch.Line[fragment.LineOffset:fragment.LineOffset+fragment.MatchLength])
+
+				candidates = append(candidates, searchBasedCandidate{
+					location: r.adjustedLocation(commit, file.FileName, lsifstore.Range{
+						Start: lsifstore.Position{Line: lineMatch.LineNumber - 1, Character: start},
+						End:   lsifstore.Position{Line: lineMatch.LineNumber - 1, Character: end},
+					}),
+					language: file.Language,
+				})
+			}
+		}
+	}
+
+	locations := newSearchBasedRanker(r.path, contents).rank(candidates)
+	if offset >= len(locations) {
+		return nil, "", nil
+	}
+
+	locations = locations[offset:]
+	if len(locations) <= limit {
+		return locations, "", nil
+	}
+
+	return locations[:limit], strconv.Itoa(offset + limit), nil
+}
+
+// adjustedLocation creates a location within the target repository at the given commit.
+func (r *searchBasedQueryResolver) adjustedLocation(commit, path string, rn lsifstore.Range) AdjustedLocation {
+	return AdjustedLocation{
+		Dump:           store.Dump{RepositoryID: r.repositoryID, RepositoryName: string(r.repositoryName), Commit: commit},
+		Path:           path,
+		AdjustedCommit: commit,
+		AdjustedRange:  rn,
+	}
+}
+
+// identifierAtPosition returns the identifier in the given file contents that encloses the given
+// zero-based line and character (rune) offset. A false-valued flag is returned if the position does
+// not fall on an identifier.
+func identifierAtPosition(contents []byte, line, character int) (string, bool) {
+	lines := bytes.Split(contents, []byte("\n"))
+	if line < 0 || line >= len(lines) || character < 0 {
+		return "", false
+	}
+	runes := []rune(string(lines[line]))
+	if character >= len(runes) || !isIdentifierRune(runes[character]) {
+		return "", false
+	}
+
+	start := character
+	for start > 0 && isIdentifierRune(runes[start-1]) {
+		start--
+	}
+	end := character + 1
+	for end < len(runes) && isIdentifierRune(runes[end]) {
+		end++
+	}
+
+	return string(runes[start:end]), true
+}
+
+func isIdentifierRune(r rune) bool {
+	return r == '_' || r == '$' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') || r >= utf8.RuneSelf
+}
+
+// searchBasedCandidate is an unranked search-based result.
+type searchBasedCandidate struct {
+	location AdjustedLocation
+	language string
+}
+
+// languageOf returns the language of the candidate, falling back to the language implied by
+// the candidate's file extension when the search backend did not report one.
+func (c searchBasedCandidate) languageOf() string {
+	if c.language != "" {
+		return c.language
+	}
+
+	language, _ := inventory.GetLanguageByFilename(c.location.Path)
+	return language
+}
@@ -0,0 +1,206 @@
+package resolvers
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/inventory"
+)
+
+// searchBasedRanker orders search-based candidates by their likelihood of referring to the same
+// symbol as the identifier in the source file. Candidates are ranked, in order of precedence, by:
+//
+//  1. whether the candidate is in the source file itself,
+//  2. whether the candidate is in a file or directory imported by the source file,
+//  3. whether the candidate is written in the same language as the source file, and
+//  4. the number of leading directories the candidate shares with the source file.
+//
+// Ties are broken by path and position so that the ordering (and therefore pagination) is stable.
+type searchBasedRanker struct {
+	path     string
+	language string
+	imports  []string
+}
+
+func newSearchBasedRanker(sourcePath string, contents []byte) *searchBasedRanker {
+	language, _ := inventory.GetLanguageByFilename(sourcePath)
+
+	return &searchBasedRanker{
+		path:     sourcePath,
+		language: language,
+		imports:  extractImportPaths(sourcePath, contents),
+	}
+}
+
+type rankedCandidate struct {
+	location     AdjustedLocation
+	sameFile     bool
+	imported     bool
+	sameLanguage bool
+	proximity    int
+}
+
+// rank returns the locations of the given candidates in ranked order with duplicates removed.
+func (r *searchBasedRanker) rank(candidates []searchBasedCandidate) []AdjustedLocation {
+	ranked := make([]rankedCandidate, 0, len(candidates))
+	seen := make(map[AdjustedLocation]struct{}, len(candidates))
+
+	for _, candidate := range candidates {
+		if _, ok := seen[candidate.location]; ok {
+			continue
+		}
+		seen[candidate.location] = struct{}{}
+
+		ranked = append(ranked, rankedCandidate{
+			location:     candidate.location,
+			sameFile:     candidate.location.Path == r.path,
+			imported:     r.isImported(candidate.location.Path),
+			sameLanguage: r.language != "" && strings.EqualFold(candidate.languageOf(), r.language),
+			proximity:    sharedDirectoryDepth(path.Dir(r.path), path.Dir(candidate.location.Path)),
+		})
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+
+		if a.sameFile != b.sameFile {
+			return a.sameFile
+		}
+		if a.imported != b.imported {
+			return a.imported
+		}
+		if a.sameLanguage != b.sameLanguage {
+			return a.sameLanguage
+		}
+		if a.proximity != b.proximity {
+			return a.proximity > b.proximity
+		}
+		if a.location.Path != b.location.Path {
+			return a.location.Path < b.location.Path
+		}
+		if a.location.AdjustedRange.Start.Line != b.location.AdjustedRange.Start.Line {
+			return a.location.AdjustedRange.Start.Line < b.location.AdjustedRange.Start.Line
+		}
+		return a.location.AdjustedRange.Start.Character < b.location.AdjustedRange.Start.Character
+	})
+
+	locations := make([]AdjustedLocation, 0, len(ranked))
+	for _, candidate := range ranked {
+		locations = append(locations, candidate.location)
+	}
+
+	return locations
+}
+
+// isImported determines if the given path is a file or within a directory referred to by one of the
+// import statements of the source file. Import paths are often qualified differently than paths in
+// the repository (e.g. Go module paths or Java source roots), so a match on the trailing segments of
+// either path is sufficient.
+func (r *searchBasedRanker) isImported(candidatePath string) bool {
+	dir := path.Dir(candidatePath)
+	withoutExtension := strings.TrimSuffix(candidatePath, path.Ext(candidatePath))
+
+	for _, importPath := range r.imports {
+		if (dir != "." && pathsOverlap(dir, importPath)) || pathsOverlap(withoutExtension, importPath) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pathsOverlap returns true if the trailing path segments of a are equal to b or vice versa.
+func pathsOverlap(a, b string) bool {
+	return hasPathSuffix(a, b) || hasPathSuffix(b, a)
+}
+
+// hasPathSuffix returns true if the trailing path segments of p are equal to suffix.
+func hasPathSuffix(p, suffix string) bool {
+	return p == suffix || strings.HasSuffix(p, "/"+suffix)
+}
+
+// sharedDirectoryDepth returns the number of leading directory segments shared by a and b.
+func sharedDirectoryDepth(a, b string) int {
+	if a == "." || b == "." {
+		return 0
+	}
+
+	as, bs := strings.Split(a, "/"), strings.Split(b, "/")
+
+	n := 0
+	for n < len(as) && n < len(bs) && as[n] == bs[n] {
+		n++
+	}
+
+	return n
+}
+
+var (
+	// goImportBlockPattern matches a parenthesized Go import block.
+	goImportBlockPattern = regexp.MustCompile(`(?ms)^import\s*\((.*?)\)`)
+
+	// quotedImportPatterns match single imports with a quoted path, e.g. Go single-line imports,
+	// JavaScript and TypeScript import statements and require calls, and C-style includes.
+	quotedImportPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?m)^\s*import\s+(?:[\w.]+\s+)?"([^"]+)"`),
+		regexp.MustCompile(`(?m)\bfrom\s+['"]([^'"]+)['"]`),
+		regexp.MustCompile(`(?m)^\s*import\s+['"]([^'"]+)['"]`),
+		regexp.MustCompile(`\brequire\(\s*['"]([^'"]+)['"]\s*\)`),
+		regexp.MustCompile(`(?m)^\s*#\s*include\s+"([^"]+)"`),
+	}
+
+	// dottedImportPatterns match imports of dot-separated module names, e.g. Python, Java, Kotlin,
+	// and Scala imports.
+	dottedImportPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?m)^\s*from\s+([\w.]+)\s+import\b`),
+		regexp.MustCompile(`(?m)^\s*import\s+(?:static\s+)?([\w]+(?:\.[\w]+)+)(?:\.\*)?\s*;?\s*$`),
+	}
+
+	quotedPathPattern = regexp.MustCompile(`"([^"]+)"`)
+)
+
+// extractImportPaths returns the slash-separated paths referenced by the import statements in the
+// given file contents. Relative imports are resolved against the directory of the source path. This
+// is a language-agnostic heuristic and may both miss imports and return paths that do not exist.
+func extractImportPaths(sourcePath string, contents []byte) []string {
+	var importPaths []string
+	seen := map[string]struct{}{}
+	add := func(importPath string) {
+		if importPath = strings.Trim(importPath, "/"); importPath == "" || importPath == "." {
+			return
+		}
+		if _, ok := seen[importPath]; ok {
+			return
+		}
+
+		seen[importPath] = struct{}{}
+		importPaths = append(importPaths, importPath)
+	}
+
+	for _, match := range goImportBlockPattern.FindAllSubmatch(contents, -1) {
+		for _, quoted := range quotedPathPattern.FindAllSubmatch(match[1], -1) {
+			add(string(quoted[1]))
+		}
+	}
+
+	for _, pattern := range quotedImportPatterns {
+		for _, match := range pattern.FindAllSubmatch(contents, -1) {
+			importPath := string(match[1])
+			if strings.HasPrefix(importPath, "./") || strings.HasPrefix(importPath, "../") {
+				importPath = path.Join(path.Dir(sourcePath), importPath)
+			}
+
+			add(importPath)
+		}
+	}
+
+	for _, pattern := range dottedImportPatterns {
+		for _, match := range pattern.FindAllSubmatch(contents, -1) {
+			add(strings.ReplaceAll(string(match[1]), ".", "/"))
+		}
+	}
+
+	return importPaths
+}
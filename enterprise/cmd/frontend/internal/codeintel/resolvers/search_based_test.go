@@ -0,0 +1,300 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/zoekt"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+const testSearchBasedSource = `package server
+
+import (
+	"context"
+
+	"github.com/example/project/internal/store"
+)
+
+func handle(ctx context.Context) error {
+	return store.Save(ctx)
+}
+`
+
+func TestSearchBasedDefinitions(t *testing.T) {
+	mockGitserverClient := NewMockGitserverClient()
+	mockSymbolsClient := NewMockSymbolsClient()
+
+	mockGitserverClient.RawContentsFunc.SetDefaultReturn([]byte(testSearchBasedSource), nil)
+	mockSymbolsClient.SearchFunc.SetDefaultReturn(&[]result.Symbol{
+		{Name: "Save", Path: "web/save.ts", Line: 3, Language: "TypeScript", Pattern: "/^export function Save() {$/"},
+		{Name: "Save", Path: "cmd/tool/save.go", Line: 10, Language: "Go", Pattern: "/^func Save() {$/"},
+		{Name: "Save", Path: "internal/store/store.go", Line: 20, Language: "Go", Pattern: "/^func Save(ctx context.Context) error {$/"},
+		{Name: "Save", Path: "internal/server/save.go", Line: 5, Language: "Go", Pattern: "/^func Save() {$/"},
+	}, nil)
+
+	resolver := newSearchBasedQueryResolver(
+		mockGitserverClient,
+		mockSymbolsClient,
+		nil,
+		42,
+		"github.com/example/project",
+		"deadbeef",
+		"internal/server/handler.go",
+		newOperations(&observation.TestContext),
+	)
+
+	// position of `Save` in `store.Save(ctx)`
+	locations, err := resolver.Definitions(context.Background(), 9, 15)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if history := mockSymbolsClient.SearchFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected number of symbol searches. want=%d have=%d", 1, len(history))
+	} else if history[0].Arg1.Query != "^Save$" || string(history[0].Arg1.CommitID) != "deadbeef" || history[0].Arg1.First != DefinitionsLimit {
+		t.Errorf("unexpected symbol search parameters: %+v", history[0].Arg1)
+	}
+
+	expectedLocations := []AdjustedLocation{
+		testSearchBasedLocation("internal/store/store.go", 19, 5, 9),
+		testSearchBasedLocation("internal/server/save.go", 4, 5, 9),
+		testSearchBasedLocation("cmd/tool/save.go", 9, 5, 9),
+		testSearchBasedLocation("web/save.ts", 2, 16, 20),
+	}
+	if diff := cmp.Diff(expectedLocations, locations); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+}
+
+func TestSearchBasedDefinitionsRankedBeforeLimit(t *testing.T) {
+	mockGitserverClient := NewMockGitserverClient()
+	mockSymbolsClient := NewMockSymbolsClient()
+	mockGitserverClient.RawContentsFunc.SetDefaultReturn([]byte(testSearchBasedSource), nil)
+
+	// The definition imported by the source file is returned last by the symbols service.
+	symbols := make([]result.Symbol, 0, SearchBasedDefinitionsLimit+1)
+	for i := 0; i < SearchBasedDefinitionsLimit; i++ {
+		symbols = append(symbols, result.Symbol{Name: "Save", Path: fmt.Sprintf("web/save%d.ts", i), Line: 3, Language: "TypeScript", Pattern: "/^export function Save() {$/"})
+	}
+	symbols = append(symbols, result.Symbol{Name: "Save", Path: "internal/store/store.go", Line: 20, Language: "Go", Pattern: "/^func Save(ctx context.Context) error {$/"})
+	mockSymbolsClient.SearchFunc.SetDefaultReturn(&symbols, nil)
+
+	resolver := newSearchBasedQueryResolver(mockGitserverClient, mockSymbolsClient, nil, 42, "github.com/example/project", "deadbeef", "internal/server/handler.go", newOperations(&observation.TestContext))
+	locations, err := resolver.Definitions(context.Background(), 9, 15)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(locations) != SearchBasedDefinitionsLimit {
+		t.Fatalf("unexpected number of locations. want=%d have=%d", SearchBasedDefinitionsLimit, len(locations))
+	}
+	if diff := cmp.Diff(testSearchBasedLocation("internal/store/store.go", 19, 5, 9), locations[0]); diff != "" {
+		t.Errorf("unexpected first location (-want +got):\n%s", diff)
+	}
+}
+
+func TestSearchBasedDefinitionsNoIdentifier(t *testing.T) {
+	mockGitserverClient := NewMockGitserverClient()
+	mockSymbolsClient := NewMockSymbolsClient()
+	mockGitserverClient.RawContentsFunc.SetDefaultReturn([]byte(testSearchBasedSource), nil)
+
+	resolver := newSearchBasedQueryResolver(mockGitserverClient, mockSymbolsClient, nil, 42, "github.com/example/project", "deadbeef", "internal/server/handler.go", newOperations(&observation.TestContext))
+
+	// position of `(` in `store.Save(ctx)`
+	locations, err := resolver.Definitions(context.Background(), 9, 18)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(locations) != 0 {
+		t.Errorf("unexpected locations: %v", locations)
+	}
+	if len(mockSymbolsClient.SearchFunc.History()) != 0 {
+		t.Errorf("unexpected symbol search")
+	}
+}
+
+func TestSearchBasedReferences(t *testing.T) {
+	mockGitserverClient := NewMockGitserverClient()
+	mockIndexedSearcher := NewMockIndexedSearcher()
+
+	mockGitserverClient.RawContentsFunc.SetDefaultReturn([]byte(testSearchBasedSource), nil)
+	mockIndexedSearcher.EnabledFunc.SetDefaultReturn(true)
+	mockIndexedSearcher.SearchFunc.SetDefaultReturn(&zoekt.SearchResult{
+		Files: []zoekt.FileMatch{
+			{
+				FileName: "web/client.ts",
+				Language: "TypeScript",
+				Version:  "cafebabe",
+				LineMatches: []zoekt.LineMatch{
+					{Line: []byte("api.Save()"), LineNumber: 7, LineFragments: []zoekt.LineFragmentMatch{{LineOffset: 4, MatchLength: 4}}},
+				},
+			},
+			{
+				FileName: "internal/server/handler.go",
+				Language: "Go",
+				Version:  "cafebabe",
+				LineMatches: []zoekt.LineMatch{
+					{Line: []byte("\treturn store.Save(ctx)"), LineNumber: 10, LineFragments: []zoekt.LineFragmentMatch{{LineOffset: 14, MatchLength: 4}}},
+				},
+			},
+			{
+				FileName: "internal/store/store.go",
+				Language: "Go",
+				Version:  "cafebabe",
+				LineMatches: []zoekt.LineMatch{
+					{Line: []byte("func Save(ctx context.Context) error {"), LineNumber: 20, LineFragments: []zoekt.LineFragmentMatch{{LineOffset: 5, MatchLength: 4}}},
+				},
+			},
+		},
+	}, nil)
+
+	resolver := newSearchBasedQueryResolver(
+		mockGitserverClient,
+		nil,
+		mockIndexedSearcher,
+		42,
+		"github.com/example/project",
+		"deadbeef",
+		"internal/server/handler.go",
+		newOperations(&observation.TestContext),
+	)
+
+	locations, cursor, err := resolver.References(context.Background(), 9, 15, 2, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if cursor != "2" {
+		t.Errorf("unexpected cursor. want=%q have=%q", "2", cursor)
+	}
+
+	expectedLocations := []AdjustedLocation{
+		testSearchBasedReferenceLocation("internal/server/handler.go", 9, 14, 18),
+		testSearchBasedReferenceLocation("internal/store/store.go", 19, 5, 9),
+	}
+	if diff := cmp.Diff(expectedLocations, locations); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+
+	locations, cursor, err = resolver.References(context.Background(), 9, 15, 2, cursor)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if cursor != "" {
+		t.Errorf("unexpected cursor. want=%q have=%q", "", cursor)
+	}
+
+	expectedLocations = []AdjustedLocation{
+		testSearchBasedReferenceLocation("web/client.ts", 6, 4, 8),
+	}
+	if diff := cmp.Diff(expectedLocations, locations); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+}
+
+func TestSearchBasedReferencesIndexedSearchDisabled(t *testing.T) {
+	mockGitserverClient := NewMockGitserverClient()
+	mockIndexedSearcher := NewMockIndexedSearcher()
+	mockIndexedSearcher.EnabledFunc.SetDefaultReturn(false)
+
+	resolver := newSearchBasedQueryResolver(mockGitserverClient, nil, mockIndexedSearcher, 42, "github.com/example/project", "deadbeef", "internal/server/handler.go", newOperations(&observation.TestContext))
+
+	locations, cursor, err := resolver.References(context.Background(), 9, 15, 10, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(locations) != 0 || cursor != "" {
+		t.Errorf("unexpected results: %v %q", locations, cursor)
+	}
+	if len(mockIndexedSearcher.SearchFunc.History()) != 0 {
+		t.Errorf("unexpected indexed search")
+	}
+}
+
+func TestIdentifierAtPosition(t *testing.T) {
+	contents := []byte("const π = 3\n\tfoo.barBaz_1(x)\n")
+
+	testCases := []struct {
+		line      int
+		character int
+		expected  string
+	}{
+		{0, 0, "const"},
+		{0, 6, "π"},
+		{1, 1, "foo"},
+		{1, 3, "foo"},
+		{1, 4, ""},
+		{1, 7, "barBaz_1"},
+		{1, 12, "barBaz_1"},
+		{1, 16, ""},
+		{5, 0, ""},
+	}
+
+	for _, testCase := range testCases {
+		name, ok := identifierAtPosition(contents, testCase.line, testCase.character)
+		if name != testCase.expected || ok != (testCase.expected != "") {
+			t.Errorf("unexpected identifier at %d:%d. want=%q have=%q", testCase.line, testCase.character, testCase.expected, name)
+		}
+	}
+}
+
+func TestExtractImportPaths(t *testing.T) {
+	testCases := []struct {
+		path     string
+		contents string
+		expected []string
+	}{
+		{
+			path:     "cmd/server/main.go",
+			contents: "package main\n\nimport (\n\t\"fmt\"\n\tlog \"github.com/example/log\"\n)\n\nimport \"os\"\n",
+			expected: []string{"fmt", "github.com/example/log", "os"},
+		},
+		{
+			path:     "web/src/app.ts",
+			contents: "import { a } from './util/a'\nimport b from '../lib/b'\nconst c = require('lodash')\n",
+			expected: []string{"web/src/util/a", "web/lib/b", "lodash"},
+		},
+		{
+			path:     "app/views.py",
+			contents: "import os\nfrom app.models import User\n",
+			expected: []string{"app/models"},
+		},
+		{
+			path:     "src/main/java/com/example/App.java",
+			contents: "package com.example;\n\nimport com.example.util.Strings;\nimport static org.junit.Assert.*;\n",
+			expected: []string{"com/example/util/Strings", "org/junit/Assert"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		if diff := cmp.Diff(testCase.expected, extractImportPaths(testCase.path, []byte(testCase.contents))); diff != "" {
+			t.Errorf("unexpected import paths for %s (-want +got):\n%s", testCase.path, diff)
+		}
+	}
+}
+
+func testSearchBasedLocation(path string, line, startCharacter, endCharacter int) AdjustedLocation {
+	return testSearchBasedLocationAtCommit("deadbeef", path, line, startCharacter, endCharacter)
+}
+
+func testSearchBasedReferenceLocation(path string, line, startCharacter, endCharacter int) AdjustedLocation {
+	return testSearchBasedLocationAtCommit("cafebabe", path, line, startCharacter, endCharacter)
+}
+
+func testSearchBasedLocationAtCommit(commit, path string, line, startCharacter, endCharacter int) AdjustedLocation {
+	return AdjustedLocation{
+		Dump:           dbstore.Dump{RepositoryID: 42, RepositoryName: "github.com/example/project", Commit: commit},
+		Path:           path,
+		AdjustedCommit: commit,
+		AdjustedRange: lsifstore.Range{
+			Start: lsifstore.Position{Line: line, Character: startCharacter},
+			End:   lsifstore.Position{Line: line, Character: endCharacter},
+		},
+	}
+}
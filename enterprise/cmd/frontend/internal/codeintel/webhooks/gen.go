@@ -0,0 +1,3 @@
+package webhooks
+
+//go:generate ../../../../../../dev/mockgen.sh github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/webhooks -i RepoStore -i DBStore -i GitserverClient -i IndexEnqueuer -o mock_iface_test.go
@@ -0,0 +1,25 @@
+package webhooks
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+type RepoStore interface {
+	List(ctx context.Context, opt database.ReposListOptions) ([]*types.Repo, error)
+}
+
+type DBStore interface {
+	IsIndexOnPushEnabled(ctx context.Context, repositoryID int) (bool, error)
+}
+
+type GitserverClient interface {
+	DefaultBranch(ctx context.Context, repositoryID int) (string, bool, error)
+}
+
+type IndexEnqueuer interface {
+	QueueIndexesForPush(ctx context.Context, repositoryID int, rev string, delay time.Duration) error
+}
@@ -0,0 +1,589 @@
+// Code generated by go-mockgen 1.1.2; DO NOT EDIT.
+
+package webhooks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	database "github.com/sourcegraph/sourcegraph/internal/database"
+	types "github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// MockDBStore is a mock implementation of the DBStore interface (from the
+// package
+// github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/webhooks)
+// used for unit testing.
+type MockDBStore struct {
+	// IsIndexOnPushEnabledFunc is an instance of a mock function object
+	// controlling the behavior of the method IsIndexOnPushEnabled.
+	IsIndexOnPushEnabledFunc *DBStoreIsIndexOnPushEnabledFunc
+}
+
+// NewMockDBStore creates a new mock of the DBStore interface. All methods
+// return zero values for all results, unless overwritten.
+func NewMockDBStore() *MockDBStore {
+	return &MockDBStore{
+		IsIndexOnPushEnabledFunc: &DBStoreIsIndexOnPushEnabledFunc{
+			defaultHook: func(context.Context, int) (bool, error) {
+				return false, nil
+			},
+		},
+	}
+}
+
+// NewMockDBStoreFrom creates a new mock of the MockDBStore interface. All
+// methods delegate to the given implementation, unless overwritten.
+func NewMockDBStoreFrom(i DBStore) *MockDBStore {
+	return &MockDBStore{
+		IsIndexOnPushEnabledFunc: &DBStoreIsIndexOnPushEnabledFunc{
+			defaultHook: i.IsIndexOnPushEnabled,
+		},
+	}
+}
+
+// DBStoreIsIndexOnPushEnabledFunc describes the behavior when the
+// IsIndexOnPushEnabled method of the parent MockDBStore instance is
+// invoked.
+type DBStoreIsIndexOnPushEnabledFunc struct {
+	defaultHook func(context.Context, int) (bool, error)
+	hooks       []func(context.Context, int) (bool, error)
+	history     []DBStoreIsIndexOnPushEnabledFuncCall
+	mutex       sync.Mutex
+}
+
+// IsIndexOnPushEnabled delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockDBStore) IsIndexOnPushEnabled(v0 context.Context, v1 int) (bool, error) {
+	r0, r1 := m.IsIndexOnPushEnabledFunc.nextHook()(v0, v1)
+	m.IsIndexOnPushEnabledFunc.appendCall(DBStoreIsIndexOnPushEnabledFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the IsIndexOnPushEnabled
+// method of the parent MockDBStore instance is invoked and the hook queue
+// is empty.
+func (f *DBStoreIsIndexOnPushEnabledFunc) SetDefaultHook(hook func(context.Context, int) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// IsIndexOnPushEnabled method of the parent MockDBStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *DBStoreIsIndexOnPushEnabledFunc) PushHook(hook func(context.Context, int) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreIsIndexOnPushEnabledFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreIsIndexOnPushEnabledFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, int) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *DBStoreIsIndexOnPushEnabledFunc) nextHook() func(context.Context, int) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreIsIndexOnPushEnabledFunc) appendCall(r0 DBStoreIsIndexOnPushEnabledFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreIsIndexOnPushEnabledFuncCall objects
+// describing the invocations of this function.
+func (f *DBStoreIsIndexOnPushEnabledFunc) History() []DBStoreIsIndexOnPushEnabledFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreIsIndexOnPushEnabledFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreIsIndexOnPushEnabledFuncCall is an object that describes an
+// invocation of method IsIndexOnPushEnabled on an instance of MockDBStore.
+type DBStoreIsIndexOnPushEnabledFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreIsIndexOnPushEnabledFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreIsIndexOnPushEnabledFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// MockGitserverClient is a mock implementation of the GitserverClient
+// interface (from the package
+// github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/webhooks)
+// used for unit testing.
+type MockGitserverClient struct {
+	// DefaultBranchFunc is an instance of a mock function object
+	// controlling the behavior of the method DefaultBranch.
+	DefaultBranchFunc *GitserverClientDefaultBranchFunc
+}
+
+// NewMockGitserverClient creates a new mock of the GitserverClient
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockGitserverClient() *MockGitserverClient {
+	return &MockGitserverClient{
+		DefaultBranchFunc: &GitserverClientDefaultBranchFunc{
+			defaultHook: func(context.Context, int) (string, bool, error) {
+				return "", false, nil
+			},
+		},
+	}
+}
+
+// NewMockGitserverClientFrom creates a new mock of the MockGitserverClient
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockGitserverClientFrom(i GitserverClient) *MockGitserverClient {
+	return &MockGitserverClient{
+		DefaultBranchFunc: &GitserverClientDefaultBranchFunc{
+			defaultHook: i.DefaultBranch,
+		},
+	}
+}
+
+// GitserverClientDefaultBranchFunc describes the behavior when the
+// DefaultBranch method of the parent MockGitserverClient instance is
+// invoked.
+type GitserverClientDefaultBranchFunc struct {
+	defaultHook func(context.Context, int) (string, bool, error)
+	hooks       []func(context.Context, int) (string, bool, error)
+	history     []GitserverClientDefaultBranchFuncCall
+	mutex       sync.Mutex
+}
+
+// DefaultBranch delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockGitserverClient) DefaultBranch(v0 context.Context, v1 int) (string, bool, error) {
+	r0, r1, r2 := m.DefaultBranchFunc.nextHook()(v0, v1)
+	m.DefaultBranchFunc.appendCall(GitserverClientDefaultBranchFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the DefaultBranch method
+// of the parent MockGitserverClient instance is invoked and the hook queue
+// is empty.
+func (f *GitserverClientDefaultBranchFunc) SetDefaultHook(hook func(context.Context, int) (string, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DefaultBranch method of the parent MockGitserverClient instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *GitserverClientDefaultBranchFunc) PushHook(hook func(context.Context, int) (string, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *GitserverClientDefaultBranchFunc) SetDefaultReturn(r0 string, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int) (string, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *GitserverClientDefaultBranchFunc) PushReturn(r0 string, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int) (string, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *GitserverClientDefaultBranchFunc) nextHook() func(context.Context, int) (string, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverClientDefaultBranchFunc) appendCall(r0 GitserverClientDefaultBranchFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitserverClientDefaultBranchFuncCall
+// objects describing the invocations of this function.
+func (f *GitserverClientDefaultBranchFunc) History() []GitserverClientDefaultBranchFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverClientDefaultBranchFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverClientDefaultBranchFuncCall is an object that describes an
+// invocation of method DefaultBranch on an instance of MockGitserverClient.
+type GitserverClientDefaultBranchFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverClientDefaultBranchFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverClientDefaultBranchFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// MockIndexEnqueuer is a mock implementation of the IndexEnqueuer interface
+// (from the package
+// github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/webhooks)
+// used for unit testing.
+type MockIndexEnqueuer struct {
+	// QueueIndexesForPushFunc is an instance of a mock function object
+	// controlling the behavior of the method QueueIndexesForPush.
+	QueueIndexesForPushFunc *IndexEnqueuerQueueIndexesForPushFunc
+}
+
+// NewMockIndexEnqueuer creates a new mock of the IndexEnqueuer interface.
+// All methods return zero values for all results, unless overwritten.
+func NewMockIndexEnqueuer() *MockIndexEnqueuer {
+	return &MockIndexEnqueuer{
+		QueueIndexesForPushFunc: &IndexEnqueuerQueueIndexesForPushFunc{
+			defaultHook: func(context.Context, int, string, time.Duration) error {
+				return nil
+			},
+		},
+	}
+}
+
+// NewMockIndexEnqueuerFrom creates a new mock of the MockIndexEnqueuer
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockIndexEnqueuerFrom(i IndexEnqueuer) *MockIndexEnqueuer {
+	return &MockIndexEnqueuer{
+		QueueIndexesForPushFunc: &IndexEnqueuerQueueIndexesForPushFunc{
+			defaultHook: i.QueueIndexesForPush,
+		},
+	}
+}
+
+// IndexEnqueuerQueueIndexesForPushFunc describes the behavior when the
+// QueueIndexesForPush method of the parent MockIndexEnqueuer instance is
+// invoked.
+type IndexEnqueuerQueueIndexesForPushFunc struct {
+	defaultHook func(context.Context, int, string, time.Duration) error
+	hooks       []func(context.Context, int, string, time.Duration) error
+	history     []IndexEnqueuerQueueIndexesForPushFuncCall
+	mutex       sync.Mutex
+}
+
+// QueueIndexesForPush delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockIndexEnqueuer) QueueIndexesForPush(v0 context.Context, v1 int, v2 string, v3 time.Duration) error {
+	r0 := m.QueueIndexesForPushFunc.nextHook()(v0, v1, v2, v3)
+	m.QueueIndexesForPushFunc.appendCall(IndexEnqueuerQueueIndexesForPushFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the QueueIndexesForPush
+// method of the parent MockIndexEnqueuer instance is invoked and the hook
+// queue is empty.
+func (f *IndexEnqueuerQueueIndexesForPushFunc) SetDefaultHook(hook func(context.Context, int, string, time.Duration) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// QueueIndexesForPush method of the parent MockIndexEnqueuer instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *IndexEnqueuerQueueIndexesForPushFunc) PushHook(hook func(context.Context, int, string, time.Duration) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *IndexEnqueuerQueueIndexesForPushFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, string, time.Duration) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *IndexEnqueuerQueueIndexesForPushFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, string, time.Duration) error {
+		return r0
+	})
+}
+
+func (f *IndexEnqueuerQueueIndexesForPushFunc) nextHook() func(context.Context, int, string, time.Duration) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *IndexEnqueuerQueueIndexesForPushFunc) appendCall(r0 IndexEnqueuerQueueIndexesForPushFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of IndexEnqueuerQueueIndexesForPushFuncCall
+// objects describing the invocations of this function.
+func (f *IndexEnqueuerQueueIndexesForPushFunc) History() []IndexEnqueuerQueueIndexesForPushFuncCall {
+	f.mutex.Lock()
+	history := make([]IndexEnqueuerQueueIndexesForPushFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// IndexEnqueuerQueueIndexesForPushFuncCall is an object that describes an
+// invocation of method QueueIndexesForPush on an instance of
+// MockIndexEnqueuer.
+type IndexEnqueuerQueueIndexesForPushFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 time.Duration
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c IndexEnqueuerQueueIndexesForPushFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c IndexEnqueuerQueueIndexesForPushFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// MockRepoStore is a mock implementation of the RepoStore interface (from
+// the package
+// github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/webhooks)
+// used for unit testing.
+type MockRepoStore struct {
+	// ListFunc is an instance of a mock function object controlling the
+	// behavior of the method List.
+	ListFunc *RepoStoreListFunc
+}
+
+// NewMockRepoStore creates a new mock of the RepoStore interface. All
+// methods return zero values for all results, unless overwritten.
+func NewMockRepoStore() *MockRepoStore {
+	return &MockRepoStore{
+		ListFunc: &RepoStoreListFunc{
+			defaultHook: func(context.Context, database.ReposListOptions) ([]*types.Repo, error) {
+				return nil, nil
+			},
+		},
+	}
+}
+
+// NewMockRepoStoreFrom creates a new mock of the MockRepoStore interface.
+// All methods delegate to the given implementation, unless overwritten.
+func NewMockRepoStoreFrom(i RepoStore) *MockRepoStore {
+	return &MockRepoStore{
+		ListFunc: &RepoStoreListFunc{
+			defaultHook: i.List,
+		},
+	}
+}
+
+// RepoStoreListFunc describes the behavior when the List method of the
+// parent MockRepoStore instance is invoked.
+type RepoStoreListFunc struct {
+	defaultHook func(context.Context, database.ReposListOptions) ([]*types.Repo, error)
+	hooks       []func(context.Context, database.ReposListOptions) ([]*types.Repo, error)
+	history     []RepoStoreListFuncCall
+	mutex       sync.Mutex
+}
+
+// List delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockRepoStore) List(v0 context.Context, v1 database.ReposListOptions) ([]*types.Repo, error) {
+	r0, r1 := m.ListFunc.nextHook()(v0, v1)
+	m.ListFunc.appendCall(RepoStoreListFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the List method of the
+// parent MockRepoStore instance is invoked and the hook queue is empty.
+func (f *RepoStoreListFunc) SetDefaultHook(hook func(context.Context, database.ReposListOptions) ([]*types.Repo, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// List method of the parent MockRepoStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *RepoStoreListFunc) PushHook(hook func(context.Context, database.ReposListOptions) ([]*types.Repo, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *RepoStoreListFunc) SetDefaultReturn(r0 []*types.Repo, r1 error) {
+	f.SetDefaultHook(func(context.Context, database.ReposListOptions) ([]*types.Repo, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *RepoStoreListFunc) PushReturn(r0 []*types.Repo, r1 error) {
+	f.PushHook(func(context.Context, database.ReposListOptions) ([]*types.Repo, error) {
+		return r0, r1
+	})
+}
+
+func (f *RepoStoreListFunc) nextHook() func(context.Context, database.ReposListOptions) ([]*types.Repo, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoStoreListFunc) appendCall(r0 RepoStoreListFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoStoreListFuncCall objects describing
+// the invocations of this function.
+func (f *RepoStoreListFunc) History() []RepoStoreListFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoStoreListFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoStoreListFuncCall is an object that describes an invocation of method
+// List on an instance of MockRepoStore.
+type RepoStoreListFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 database.ReposListOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*types.Repo
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoStoreListFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoStoreListFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
@@ -0,0 +1,74 @@
+package webhooks
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+// PushHandler queues auto-indexing jobs for pushes to the default branch of repositories
+// that have opted into indexing on push. The jobs are delayed by the debounce interval, and
+// a push replaces the jobs still delayed for a previous push to the same repository, so that
+// a burst of pushes results in a single set of index jobs for the most recent commit.
+type PushHandler struct {
+	repoStore        RepoStore
+	dbStore          DBStore
+	gitserverClient  GitserverClient
+	indexEnqueuer    IndexEnqueuer
+	debounceInterval time.Duration
+}
+
+// NewPushHandler creates a new push handler. Index jobs queued for a push are processed once
+// the debounce interval has elapsed.
+func NewPushHandler(
+	repoStore RepoStore,
+	dbStore DBStore,
+	gitserverClient GitserverClient,
+	indexEnqueuer IndexEnqueuer,
+	debounceInterval time.Duration,
+) *PushHandler {
+	return &PushHandler{
+		repoStore:        repoStore,
+		dbStore:          dbStore,
+		gitserverClient:  gitserverClient,
+		indexEnqueuer:    indexEnqueuer,
+		debounceInterval: debounceInterval,
+	}
+}
+
+// Handle queues index jobs for the pushed commit if the push updated the default branch
+// of a repository with index on push enabled. This method is a webhooks.PushHandler.
+func (h *PushHandler) Handle(ctx context.Context, event webhooks.PushEvent) error {
+	if !conf.CodeIntelAutoIndexingEnabled() {
+		return nil
+	}
+
+	// 🚨 SECURITY: We need to be able to find private repositories here, so we use the internal actor.
+	// The event has already been authenticated by the webhook handler of the code host.
+	ctx = actor.WithInternalActor(ctx)
+
+	repos, err := h.repoStore.List(ctx, database.ReposListOptions{
+		ExternalRepos: []api.ExternalRepoSpec{event.Repo},
+	})
+	if err != nil || len(repos) == 0 {
+		return err
+	}
+	repositoryID := int(repos[0].ID)
+
+	enabled, err := h.dbStore.IsIndexOnPushEnabled(ctx, repositoryID)
+	if err != nil || !enabled {
+		return err
+	}
+
+	defaultBranch, ok, err := h.gitserverClient.DefaultBranch(ctx, repositoryID)
+	if err != nil || !ok || defaultBranch != event.Ref {
+		return err
+	}
+
+	return h.indexEnqueuer.QueueIndexesForPush(ctx, repositoryID, event.Commit, h.debounceInterval)
+}
@@ -0,0 +1,93 @@
+package webhooks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestPushHandler(t *testing.T) {
+	enabled := true
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{CodeIntelAutoIndexingEnabled: &enabled}})
+	defer conf.Mock(nil)
+
+	mockRepoStore := NewMockRepoStore()
+	mockDBStore := NewMockDBStore()
+	mockGitserverClient := NewMockGitserverClient()
+	mockIndexEnqueuer := NewMockIndexEnqueuer()
+
+	mockRepoStore.ListFunc.SetDefaultReturn([]*types.Repo{{ID: 42}}, nil)
+	mockDBStore.IsIndexOnPushEnabledFunc.SetDefaultReturn(true, nil)
+	mockGitserverClient.DefaultBranchFunc.SetDefaultReturn("refs/heads/main", true, nil)
+
+	handler := NewPushHandler(mockRepoStore, mockDBStore, mockGitserverClient, mockIndexEnqueuer, time.Minute)
+
+	push := func(ref, commit string) {
+		if err := handler.Handle(context.Background(), webhooks.PushEvent{
+			Repo:   api.ExternalRepoSpec{ID: "42", ServiceType: extsvc.TypeGitLab, ServiceID: "https://gitlab.com/"},
+			Ref:    ref,
+			Commit: commit,
+		}); err != nil {
+			t.Fatalf("unexpected error handling push: %s", err)
+		}
+	}
+
+	push("refs/heads/main", "c1")
+	push("refs/heads/feature", "c2")
+	push("refs/heads/main", "c3")
+
+	var commits []string
+	for _, call := range mockIndexEnqueuer.QueueIndexesForPushFunc.History() {
+		if call.Arg1 != 42 {
+			t.Errorf("unexpected repository identifier. want=%d have=%d", 42, call.Arg1)
+		}
+		if call.Arg3 != time.Minute {
+			t.Errorf("unexpected delay. want=%s have=%s", time.Minute, call.Arg3)
+		}
+		commits = append(commits, call.Arg2)
+	}
+	if diff := cmp.Diff([]string{"c1", "c3"}, commits); diff != "" {
+		t.Errorf("unexpected commits (-want +got):\n%s", diff)
+	}
+}
+
+func TestPushHandlerIndexOnPushDisabled(t *testing.T) {
+	enabled := true
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{CodeIntelAutoIndexingEnabled: &enabled}})
+	defer conf.Mock(nil)
+
+	mockRepoStore := NewMockRepoStore()
+	mockDBStore := NewMockDBStore()
+	mockGitserverClient := NewMockGitserverClient()
+	mockIndexEnqueuer := NewMockIndexEnqueuer()
+
+	mockRepoStore.ListFunc.SetDefaultReturn([]*types.Repo{{ID: 42}}, nil)
+	mockDBStore.IsIndexOnPushEnabledFunc.SetDefaultReturn(false, nil)
+	mockGitserverClient.DefaultBranchFunc.SetDefaultReturn("refs/heads/main", true, nil)
+
+	handler := NewPushHandler(mockRepoStore, mockDBStore, mockGitserverClient, mockIndexEnqueuer, time.Minute)
+
+	if err := handler.Handle(context.Background(), webhooks.PushEvent{
+		Repo:   api.ExternalRepoSpec{ID: "42", ServiceType: extsvc.TypeGitLab, ServiceID: "https://gitlab.com/"},
+		Ref:    "refs/heads/main",
+		Commit: "c1",
+	}); err != nil {
+		t.Fatalf("unexpected error handling push: %s", err)
+	}
+
+	if len(mockGitserverClient.DefaultBranchFunc.History()) != 0 {
+		t.Errorf("unexpected call to DefaultBranch")
+	}
+	if len(mockIndexEnqueuer.QueueIndexesForPushFunc.History()) != 0 {
+		t.Errorf("unexpected call to QueueIndexesForPush")
+	}
+}
@@ -59,6 +59,7 @@ type ActionEmail struct {
 	Priority   string
 	Recipients RecipientsConnection
 	Header     string
+	Digest     string
 	Events     ActionEventConnection
 }
 
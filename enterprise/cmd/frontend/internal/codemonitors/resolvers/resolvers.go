@@ -226,8 +226,9 @@ func (r *Resolver) TriggerTestEmailAction(ctx context.Context, args *graphqlback
 		return nil, err
 	}
 
+	data := email.NewTestTemplateDataForNewSearchResults(ctx, args.Description)
 	for _, recipient := range args.Email.Recipients {
-		if err := sendTestEmail(ctx, recipient, args.Description); err != nil {
+		if err := sendTestEmail(ctx, recipient, data); err != nil {
 			return nil, err
 		}
 	}
@@ -235,7 +236,7 @@ func (r *Resolver) TriggerTestEmailAction(ctx context.Context, args *graphqlback
 	return &graphqlbackend.EmptyResponse{}, nil
 }
 
-func sendTestEmail(ctx context.Context, recipient graphql.ID, description string) error {
+func sendTestEmail(ctx context.Context, recipient graphql.ID, data *email.TemplateDataNewSearchResults) error {
 	var (
 		userID int32
 		orgID  int32
@@ -248,7 +249,6 @@ func sendTestEmail(ctx context.Context, recipient graphql.ID, description string
 	if orgID != 0 {
 		return nil
 	}
-	data := email.NewTestTemplateDataForNewSearchResults(ctx, description)
 	return email.SendEmailForNewSearchResult(ctx, userID, data)
 }
 
@@ -813,6 +813,10 @@ func (m *monitorEmail) Header() string {
 	return m.MonitorEmail.Header
 }
 
+func (m *monitorEmail) Digest() string {
+	return m.MonitorEmail.Digest
+}
+
 func (m *monitorEmail) ID() graphql.ID {
 	return relay.MarshalID(monitorActionEmailKind, m.Id)
 }
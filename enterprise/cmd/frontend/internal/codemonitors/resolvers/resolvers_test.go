@@ -20,6 +20,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 )
 
 func init() {
@@ -665,6 +666,7 @@ func TestEditCodeMonitor(t *testing.T) {
 							},
 						},
 						Header: "updated header action 1",
+						Digest: "DAILY",
 					}}, {
 					ActionEmail: apitest.ActionEmail{
 						Id:       string(relay.MarshalID(monitorActionEmailKind, 3)),
@@ -681,6 +683,7 @@ func TestEditCodeMonitor(t *testing.T) {
 							},
 						},
 						Header: "header action 3",
+						Digest: "IMMEDIATE",
 					}},
 				},
 			},
@@ -707,7 +710,7 @@ mutation ($monitorID: ID!, $triggerID: ID!, $actionID: ID!, $user1ID: ID!, $user
     monitor: {id: $monitorID, update: {description: "updated test monitor", enabled: false, namespace: $user1ID}},
 	trigger: {id: $triggerID, update: {query: "repo:bar"}},
 	actions: [
-	  {email: {id: $actionID, update: {enabled: false, priority: CRITICAL, recipients: [$user2ID], header: "updated header action 1"}}}
+	  {email: {id: $actionID, update: {enabled: false, priority: CRITICAL, recipients: [$user2ID], header: "updated header action 1", digest: DAILY}}}
 	  {email: {update: {enabled: true, priority: NORMAL, recipients: [$user1ID, $user2ID], header: "header action 3"}}}
     ]
   )
@@ -740,6 +743,7 @@ mutation ($monitorID: ID!, $triggerID: ID!, $actionID: ID!, $user1ID: ID!, $user
 		  enabled
 		  priority
 		  header
+		  digest
 		  recipients {
 			nodes {
 			  ... on User {
@@ -1186,6 +1190,97 @@ func TestTriggerTestEmailAction(t *testing.T) {
 	}
 }
 
+func TestTestCodeMonitor(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	var gotQuery string
+	defaultSearchCommits := searchCommits
+	searchCommits = func(ctx context.Context, db dbutil.DB, query string) ([]*graphqlbackend.CommitSearchResultResolver, error) {
+		gotQuery = query
+		return nil, nil
+	}
+	t.Cleanup(func() { searchCommits = defaultSearchCommits })
+
+	var got []email.TemplateDataNewSearchResults
+	email.MockSendEmailForNewSearchResult = func(ctx context.Context, userID int32, data *email.TemplateDataNewSearchResults) error {
+		got = append(got, *data)
+		return nil
+	}
+	t.Cleanup(func() { email.MockSendEmailForNewSearchResult = nil })
+
+	ctx := actor.WithInternalActor(context.Background())
+	r := newTestResolver(t, nil)
+
+	namespaceID := relay.MarshalID("User", actor.FromContext(ctx).UID)
+	daily := cm.DigestDaily
+	args := &graphqlbackend.TestCodeMonitorArgs{
+		Monitor: &graphqlbackend.CreateMonitorArgs{
+			Namespace:   namespaceID,
+			Description: "A code monitor name",
+			Enabled:     true,
+		},
+		Trigger: &graphqlbackend.CreateTriggerArgs{
+			Query: "type:diff TODO",
+		},
+		Actions: []*graphqlbackend.CreateActionArgs{
+			{Email: &graphqlbackend.CreateActionEmailArgs{
+				Enabled:    true,
+				Priority:   "NORMAL",
+				Recipients: []graphql.ID{namespaceID},
+			}},
+			{Email: &graphqlbackend.CreateActionEmailArgs{
+				Enabled:    true,
+				Priority:   "CRITICAL",
+				Recipients: []graphql.ID{namespaceID},
+				Digest:     &daily,
+			}},
+		},
+		Days:              3,
+		SendNotifications: true,
+	}
+	run, err := r.TestCodeMonitor(ctx, args)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantQuery := testRunQuery("type:diff TODO", r.Now(), 3)
+	if run.Query() != wantQuery || gotQuery != wantQuery {
+		t.Fatalf("unexpected query. want=%q have=%q searched=%q", wantQuery, run.Query(), gotQuery)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d sample emails, want 2", len(got))
+	}
+	if !got[0].IsTest || got[0].Digest != "" || !got[1].IsTest || got[1].Digest != "daily" {
+		t.Fatalf("unexpected sample emails %+v", got)
+	}
+
+	args.Days = 31
+	if _, err := r.TestCodeMonitor(ctx, args); err == nil {
+		t.Fatal("expected an error for a test run of more than 30 days")
+	}
+
+	// Sample emails can't be sent to other users.
+	got = nil
+	args.Days = 3
+	args.Actions[1].Email.Recipients = []graphql.ID{namespaceID, relay.MarshalID("User", 42)}
+	if _, err := r.TestCodeMonitor(ctx, args); err == nil {
+		t.Fatal("expected an error for a recipient other than the current user")
+	}
+	if len(got) != 0 {
+		t.Fatalf("got %d sample emails, want none", len(got))
+	}
+}
+
+func TestTestRunQuery(t *testing.T) {
+	now := time.Date(2021, 6, 8, 12, 0, 0, 0, time.UTC)
+	want := `type:diff TODO after:"2021-06-01T12:00:01Z"`
+	if got := testRunQuery("type:diff TODO", now, 7); got != want {
+		t.Fatalf("unexpected query. want=%q have=%q", want, got)
+	}
+}
+
 func TestMonitorKindEqualsResolvers(t *testing.T) {
 	got := email.MonitorKind
 	want := MonitorKind
@@ -0,0 +1,148 @@
+package resolvers
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	cm "github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors/email"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// maxTestRunDays is the maximum number of days of commits searched by a code monitor test run.
+const maxTestRunDays = 30
+
+func (r *Resolver) TestCodeMonitor(ctx context.Context, args *graphqlbackend.TestCodeMonitorArgs) (graphqlbackend.MonitorTestRunResolver, error) {
+	err := r.isAllowedToCreate(ctx, args.Monitor.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if args.Days < 1 || args.Days > maxTestRunDays {
+		return nil, errors.Errorf("days must be between 1 and %d, got %d", maxTestRunDays, args.Days)
+	}
+	if args.SendNotifications {
+		if err := checkTestRunRecipients(ctx, args.Actions); err != nil {
+			return nil, err
+		}
+	}
+
+	query := testRunQuery(args.Trigger.Query, r.Now(), args.Days)
+	results, err := searchCommits(ctx, r.store.Handle().DB(), query)
+	if err != nil {
+		return nil, err
+	}
+
+	if args.SendNotifications {
+		if err := sendTestRunEmails(ctx, args, results); err != nil {
+			return nil, err
+		}
+	}
+	return &monitorTestRun{query: query, results: results}, nil
+}
+
+// testRunQuery restricts the given trigger query to the commits of the given number of days
+// before now, rewriting it the way trigger jobs restrict it to the commits since their last run.
+func testRunQuery(queryString string, now time.Time, days int32) string {
+	return cm.QueryWithAfterFilter(queryString, now.AddDate(0, 0, -int(days)))
+}
+
+// searchCommits runs the given search query as the current user and returns its commit results.
+// Tests can replace it to avoid running searches.
+var searchCommits = func(ctx context.Context, db dbutil.DB, query string) ([]*graphqlbackend.CommitSearchResultResolver, error) {
+	// Trigger jobs search with the default version of the search syntax too.
+	search, err := graphqlbackend.NewSearchImplementer(ctx, db, &graphqlbackend.SearchArgs{Version: "V1", Query: query})
+	if err != nil {
+		return nil, err
+	}
+	results, err := search.Results(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	commits := []*graphqlbackend.CommitSearchResultResolver{}
+	for _, result := range results.Results() {
+		if commit, ok := result.ToCommitSearchResult(); ok {
+			commits = append(commits, commit)
+		}
+	}
+	return commits, nil
+}
+
+// checkTestRunRecipients returns an error if any recipient of the given actions is not the current
+// user.
+//
+// 🚨 SECURITY: The results of a test run are searched with the permissions of the current user,
+// so sample emails listing them must not be sent to anyone else.
+func checkTestRunRecipients(ctx context.Context, actions []*graphqlbackend.CreateActionArgs) error {
+	uid := actor.FromContext(ctx).UID
+	for _, action := range actions {
+		if action.Email == nil {
+			continue
+		}
+		for _, recipient := range action.Email.Recipients {
+			var userID, orgID int32
+			if err := graphqlbackend.UnmarshalNamespaceID(recipient, &userID, &orgID); err != nil {
+				return err
+			}
+			if orgID != 0 || userID != uid {
+				return errors.Errorf("sample emails of a test run can only be sent to yourself, got recipient %s", recipient)
+			}
+		}
+	}
+	return nil
+}
+
+// sendTestRunEmails sends a sample email with the results of a test run to the recipients of each
+// email action.
+func sendTestRunEmails(ctx context.Context, args *graphqlbackend.TestCodeMonitorArgs, results []*graphqlbackend.CommitSearchResultResolver) error {
+	commits := make([]cm.MatchedCommit, 0, len(results))
+	for _, result := range results {
+		commit := result.Commit()
+		commits = append(commits, cm.MatchedCommit{
+			Repository:     commit.Repository().Name(),
+			OID:            string(commit.OID()),
+			AbbreviatedOID: commit.AbbreviatedOID(),
+			URL:            commit.URL(),
+		})
+	}
+
+	for _, action := range args.Actions {
+		if action.Email == nil {
+			continue
+		}
+		e := &cm.MonitorEmail{
+			Priority: action.Email.Priority,
+			Digest:   cm.DigestImmediate,
+		}
+		if action.Email.Digest != nil {
+			e.Digest = *action.Email.Digest
+		}
+		data, err := email.NewTestTemplateDataForSearchResults(ctx, args.Monitor.Description, e, commits)
+		if err != nil {
+			return err
+		}
+		for _, recipient := range action.Email.Recipients {
+			if err := sendTestEmail(ctx, recipient, data); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type monitorTestRun struct {
+	query   string
+	results []*graphqlbackend.CommitSearchResultResolver
+}
+
+func (m *monitorTestRun) Query() string {
+	return m.query
+}
+
+func (m *monitorTestRun) Results() []*graphqlbackend.CommitSearchResultResolver {
+	return m.results
+}
@@ -9,6 +9,10 @@ type Config struct {
 	env.BaseConfig
 
 	Shared *config.SharedConfig
+
+	DiffImage string
 }
 
-func (c *Config) Load() {}
+func (c *Config) Load() {
+	c.DiffImage = c.Get("EXECUTOR_BATCHES_DIFF_IMAGE", "alpine/git:v2.32.0", "The docker image used to compute the diff of a batch spec workspace after its steps ran.")
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/executorqueue/handler"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/background"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	apiclient "github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
@@ -15,8 +16,10 @@ import (
 )
 
 func QueueOptions(db dbutil.DB, config *Config, observationContext *observation.Context) handler.QueueOptions {
+	s := store.New(db, observationContext, nil)
+
 	recordTransformer := func(ctx context.Context, record workerutil.Record) (apiclient.Job, error) {
-		return transformRecord(ctx, db, record.(*btypes.BatchSpecExecution), config)
+		return transformRecord(ctx, db, s, record.(*btypes.BatchSpecWorkspaceJob), config)
 	}
 
 	return handler.QueueOptions{
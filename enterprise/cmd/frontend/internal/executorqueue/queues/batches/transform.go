@@ -3,130 +3,91 @@ package batches
 import (
 	"context"
 	"fmt"
-	"net/url"
-	"os"
+	"sort"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	apiclient "github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
-	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 )
 
-// transformRecord transforms a *btypes.BatchSpecExecution into an apiclient.Job.
-func transformRecord(ctx context.Context, db dbutil.DB, exec *btypes.BatchSpecExecution, config *Config) (apiclient.Job, error) {
-	// TODO: createAccessToken is a bit of technical debt until we figure out a
-	// better solution. The problem is that src-cli needs to make requests to
-	// the Sourcegraph instance *on behalf of the user*.
-	//
-	// Ideally we'd have something like one-time tokens that
-	// * we could hand to src-cli
-	// * are not visible to the user in the Sourcegraph web UI
-	// * valid only for the duration of the batch spec execution
-	// * and cleaned up after batch spec is executed
-	//
-	// Until then we create a fresh access token every time.
-	//
-	// GetOrCreate doesn't work because once an access token has been created
-	// in the database Sourcegraph can't access the plain-text token anymore.
-	// Only a hash for verification is kept in the database.
-	token, err := createAccessToken(ctx, db, exec.UserID)
-	if err != nil {
-		return apiclient.Job{}, err
-	}
+// executionGetter is the subset of *store.Store used by transformRecord.
+type executionGetter interface {
+	GetBatchSpecExecution(ctx context.Context, opts store.GetBatchSpecExecutionOpts) (*btypes.BatchSpecExecution, error)
+}
 
-	frontendURL := conf.Get().ExternalURL
+// executorScriptsPath is the directory in the workspace to which the executor
+// writes the scripts of the job's steps. It must be kept in sync with
+// ScriptsPath in enterprise/cmd/executor/internal/command.
+const executorScriptsPath = ".sourcegraph-executor"
+
+// diffCommands are run in the workspace after all steps of the batch spec ran.
+// They print the changes made by the steps, which are turned into changeset
+// specs once the job completes. The scripts written by the executor are not
+// part of the changes.
+var diffCommands = []string{
+	fmt.Sprintf("git add --all -- . ':!%s'", executorScriptsPath),
+	"git diff --cached --no-color --no-ext-diff",
+}
 
-	srcEndpoint, err := makeURL(frontendURL, config.Shared.FrontendUsername, config.Shared.FrontendPassword)
+// transformRecord transforms a *btypes.BatchSpecWorkspaceJob into an
+// apiclient.Job that runs the steps of the batch spec in the repository of the
+// workspace and prints the resulting diff.
+func transformRecord(ctx context.Context, db dbutil.DB, s executionGetter, job *btypes.BatchSpecWorkspaceJob, config *Config) (apiclient.Job, error) {
+	exec, err := s.GetBatchSpecExecution(ctx, store.GetBatchSpecExecutionOpts{ID: job.BatchSpecExecutionID})
 	if err != nil {
 		return apiclient.Job{}, err
 	}
 
-	redactedSrcEndpoint, err := makeURL(frontendURL, "USERNAME_REMOVED", "PASSWORD_REMOVED")
+	spec, err := btypes.NewBatchSpecFromRaw(exec.BatchSpec)
 	if err != nil {
 		return apiclient.Job{}, err
 	}
 
-	cliEnv := []string{
-		fmt.Sprintf("SRC_ENDPOINT=%s", srcEndpoint),
-		fmt.Sprintf("SRC_ACCESS_TOKEN=%s", token),
-
-		// TODO: This is wrong here, it should be set on the executor machine
-		fmt.Sprintf("HOME=%s", os.Getenv("HOME")),
-		fmt.Sprintf("PATH=%s", os.Getenv("PATH")),
+	// The repository permissions of the user have been checked when the
+	// workspaces were resolved, so we can load the repository as an internal
+	// actor here.
+	repo, err := database.Repos(db).Get(actor.WithInternalActor(ctx), job.RepoID)
+	if err != nil {
+		return apiclient.Job{}, err
 	}
 
-	var namespaceName string
-	if exec.NamespaceUserID != 0 {
-		user, err := database.Users(db).GetByID(ctx, exec.NamespaceUserID)
-		if err != nil {
-			return apiclient.Job{}, err
-		}
-		namespaceName = user.Username
-	} else {
-		org, err := database.Orgs(db).GetByID(ctx, exec.NamespaceOrgID)
+	dockerSteps := make([]apiclient.DockerStep, 0, len(spec.Spec.Steps)+1)
+	for _, step := range spec.Spec.Steps {
+		env, err := step.Env.Resolve(nil)
 		if err != nil {
 			return apiclient.Job{}, err
 		}
-		namespaceName = org.Name
+
+		dockerSteps = append(dockerSteps, apiclient.DockerStep{
+			Image:    step.Container,
+			Commands: []string{step.Run},
+			Dir:      ".",
+			Env:      formatEnv(env),
+		})
 	}
+	dockerSteps = append(dockerSteps, apiclient.DockerStep{
+		Image:    config.DiffImage,
+		Commands: diffCommands,
+		Dir:      ".",
+	})
 
 	return apiclient.Job{
-		ID:                  int(exec.ID),
-		VirtualMachineFiles: map[string]string{"spec.yml": exec.BatchSpec},
-		CliSteps: []apiclient.CliStep{
-			{
-				Commands: []string{
-					"batch",
-					"preview",
-					"-f", "spec.yml",
-					"-text-only",
-					"-skip-errors",
-					"-n", namespaceName,
-				},
-				Dir: ".",
-				Env: cliEnv,
-			},
-		},
-		RedactedValues: map[string]string{
-			// 🚨 SECURITY: Catch leak of upload endpoint. This is necessary in addition
-			// to the below in case the username or password contains illegal URL characters,
-			// which are then urlencoded and are not replaceable via byte comparison.
-			srcEndpoint: redactedSrcEndpoint,
-
-			// 🚨 SECURITY: Catch uses of fragments pulled from URL to construct another target
-			// (in src-cli). We only pass the constructed URL to src-cli, which we trust not to
-			// ship the values to a third party, but not to trust to ensure the values are absent
-			// from the command's stdout or stderr streams.
-			config.Shared.FrontendUsername: "USERNAME_REMOVED",
-			config.Shared.FrontendPassword: "PASSWORD_REMOVED",
-
-			// 🚨 SECURITY: Redact the access token used for src-cli to talk to
-			// Sourcegraph instance.
-			token: "SRC_ACCESS_TOKEN_REMOVED",
-		},
+		ID:             int(job.ID),
+		RepositoryName: string(repo.Name),
+		Commit:         job.Commit,
+		DockerSteps:    dockerSteps,
 	}, nil
 }
 
-const (
-	accessTokenNote  = "batch-spec-execution"
-	accessTokenScope = "user:all"
-)
-
-func createAccessToken(ctx context.Context, db dbutil.DB, userID int32) (string, error) {
-	_, token, err := database.AccessTokens(db).Create(ctx, userID, []string{accessTokenScope}, accessTokenNote, userID)
-	if err != nil {
-		return "", err
+// formatEnv returns the given environment as sorted KEY=VALUE pairs.
+func formatEnv(env map[string]string) []string {
+	formatted := make([]string, 0, len(env))
+	for k, v := range env {
+		formatted = append(formatted, fmt.Sprintf("%s=%s", k, v))
 	}
-	return token, err
-}
-
-func makeURL(base, username, password string) (string, error) {
-	u, err := url.Parse(base)
-	if err != nil {
-		return "", err
-	}
-
-	u.User = url.UserPassword(username, password)
-	return u.String(), nil
+	sort.Strings(formatted)
+	return formatted
 }
@@ -18,7 +18,8 @@ func Routines(ctx context.Context, batchesStore *store.Store, cf *httpcli.Factor
 
 	reconcilerWorkerStore := NewReconcilerDBWorkerStore(batchesStore.Handle(), observationContext)
 	bulkProcessorWorkerStore := NewBulkOperationDBWorkerStore(batchesStore.Handle(), observationContext)
-	specExecutionWorkerStore := NewExecutorStore(batchesStore.Handle(), observationContext)
+	specExecutionWorkerStore := NewBatchSpecExecutionDBWorkerStore(batchesStore.Handle(), observationContext)
+	workspaceJobWorkerStore := NewExecutorStore(batchesStore.Handle(), observationContext)
 
 	routines := []goroutine.BackgroundRoutine{
 		newReconcilerWorker(ctx, batchesStore, reconcilerWorkerStore, gitserver.DefaultClient, sourcer, metrics),
@@ -28,10 +29,12 @@ func Routines(ctx context.Context, batchesStore *store.Store, cf *httpcli.Factor
 
 		scheduler.NewScheduler(ctx, batchesStore),
 
-		newBulkOperationWorker(ctx, batchesStore, bulkProcessorWorkerStore, sourcer, metrics),
+		newBulkOperationWorker(ctx, batchesStore, bulkProcessorWorkerStore, gitserver.DefaultClient, sourcer, metrics),
 		newBulkOperationWorkerResetter(bulkProcessorWorkerStore, metrics),
 
+		newWorkspaceResolverWorker(ctx, batchesStore, specExecutionWorkerStore, metrics),
 		newBatchSpecExecutionResetter(specExecutionWorkerStore, metrics),
+		newWorkspaceJobResetter(workspaceJobWorkerStore, metrics),
 	}
 	return routines
 }
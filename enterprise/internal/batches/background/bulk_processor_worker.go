@@ -9,6 +9,7 @@ import (
 	"github.com/keegancsmith/sqlf"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/processor"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/reconciler"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
@@ -33,10 +34,11 @@ func newBulkOperationWorker(
 	ctx context.Context,
 	s *store.Store,
 	workerStore dbworkerstore.Store,
+	gitClient reconciler.GitserverClient,
 	sourcer sources.Sourcer,
 	metrics batchChangesMetrics,
 ) *workerutil.Worker {
-	r := &bulkProcessorWorker{sourcer: sourcer, store: s}
+	r := &bulkProcessorWorker{sourcer: sourcer, store: s, gitClient: gitClient}
 
 	options := workerutil.WorkerOptions{
 		Name:              "batches_bulk_processor",
@@ -91,8 +93,9 @@ func scanFirstChangesetJobRecord(rows *sql.Rows, err error) (workerutil.Record,
 // bulkProcessorWorker is a wrapper for the workerutil handlerfunc to create a
 // bulkProcessor with a source and store.
 type bulkProcessorWorker struct {
-	store   *store.Store
-	sourcer sources.Sourcer
+	store     *store.Store
+	sourcer   sources.Sourcer
+	gitClient reconciler.GitserverClient
 }
 
 func (b *bulkProcessorWorker) HandlerFunc() workerutil.HandlerFunc {
@@ -113,7 +116,7 @@ func (b *bulkProcessorWorker) HandlerFunc() workerutil.HandlerFunc {
 			}
 		}()
 
-		p := processor.New(tx, b.sourcer)
+		p := processor.New(tx, b.sourcer, b.gitClient)
 
 		return p.Process(ctx, job)
 	}
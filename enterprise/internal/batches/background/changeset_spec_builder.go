@@ -0,0 +1,142 @@
+package background
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/batch-change-utils/overridable"
+	"github.com/sourcegraph/go-diff/diff"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	"github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+// changesetSpecRepository is the repository in which the changes that are
+// turned into changeset specs were produced.
+type changesetSpecRepository struct {
+	ID      graphql.ID
+	Name    string
+	BaseRef string
+	BaseRev string
+}
+
+// defaultGitCommitAuthor is the author of the commits in changesets whose
+// changeset template doesn't specify one.
+var defaultGitCommitAuthor = btypes.GitCommitAuthor{
+	Name:  "Sourcegraph",
+	Email: "batch-changes@sourcegraph.com",
+}
+
+// buildChangesetSpecs turns the diff produced in the given repository into
+// the descriptions of the changeset specs to create for it.
+//
+// The changeset template is first adjusted with the overrides matching the
+// repository. The file diffs are then split up by the transformChanges groups
+// that apply to the repository, with each group resulting in an additional
+// changeset on its own branch. Groups without changes don't produce a
+// changeset, and neither does the default branch if all changes were grouped.
+func buildChangesetSpecs(s *btypes.BatchSpecFields, repo changesetSpecRepository, rawDiff string) ([]*btypes.ChangesetSpecDescription, error) {
+	tmpl, err := s.ChangesetTemplate.ForRepository(repo.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	diffsByBranch, err := groupFileDiffs(rawDiff, tmpl.Branch, s.TransformChanges.GroupsForRepository(repo.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	author := defaultGitCommitAuthor
+	if tmpl.Commit.Author != nil {
+		author = *tmpl.Commit.Author
+	}
+
+	branches := make([]string, 0, len(diffsByBranch))
+	for branch := range diffsByBranch {
+		branches = append(branches, branch)
+	}
+	sort.Strings(branches)
+
+	specs := make([]*btypes.ChangesetSpecDescription, 0, len(branches))
+	for _, branch := range branches {
+		specs = append(specs, &btypes.ChangesetSpecDescription{
+			BaseRepository: repo.ID,
+			BaseRef:        repo.BaseRef,
+			BaseRev:        repo.BaseRev,
+			HeadRepository: repo.ID,
+			HeadRef:        git.EnsureRefPrefix(branch),
+			Title:          tmpl.Title,
+			Body:           tmpl.Body,
+			Commits: []btypes.GitCommitDescription{{
+				Message:     tmpl.Commit.Message,
+				Diff:        diffsByBranch[branch],
+				AuthorName:  author.Name,
+				AuthorEmail: author.Email,
+			}},
+			Published: publishedValue(tmpl.Published, repo.Name, branch),
+		})
+	}
+
+	return specs, nil
+}
+
+// groupFileDiffs splits the given diff into one diff per branch. Each file
+// diff is assigned to the branch of the last group whose directory contains
+// the file, or to the default branch if no group matches.
+func groupFileDiffs(rawDiff, defaultBranch string, groups []btypes.Group) (map[string]string, error) {
+	fileDiffs, err := diff.ParseMultiFileDiff([]byte(rawDiff))
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing diff")
+	}
+
+	byBranch := make(map[string][]*diff.FileDiff)
+	for _, fd := range fileDiffs {
+		branch := defaultBranch
+		name := fileDiffPath(fd)
+		for i := len(groups) - 1; i >= 0; i-- {
+			if dir := path.Clean(strings.Trim(groups[i].Directory, "/")); name == dir || strings.HasPrefix(name, dir+"/") {
+				branch = groups[i].BranchFor(defaultBranch)
+				break
+			}
+		}
+		byBranch[branch] = append(byBranch[branch], fd)
+	}
+
+	diffs := make(map[string]string, len(byBranch))
+	for branch, fds := range byBranch {
+		printed, err := diff.PrintMultiFileDiff(fds)
+		if err != nil {
+			return nil, errors.Wrap(err, "printing diff")
+		}
+		diffs[branch] = string(printed)
+	}
+	return diffs, nil
+}
+
+// fileDiffPath returns the path of the file changed by the file diff,
+// relative to the repository root.
+func fileDiffPath(fd *diff.FileDiff) string {
+	name := fd.NewName
+	if name == "/dev/null" {
+		name = fd.OrigName
+	}
+	for _, prefix := range []string{"a/", "b/"} {
+		if strings.HasPrefix(name, prefix) {
+			return name[len(prefix):]
+		}
+	}
+	return name
+}
+
+// publishedValue returns the published value of the changeset on the given
+// branch, which is nil if the changeset template doesn't set one.
+func publishedValue(published overridable.BoolOrString, repoName, branch string) batches.PublishedValue {
+	if published.Equal(overridable.BoolOrString{}) {
+		return batches.PublishedValue{}
+	}
+	return batches.PublishedValue{Val: published.ValueWithSuffix(repoName, branch)}
+}
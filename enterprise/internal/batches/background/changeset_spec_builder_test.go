@@ -0,0 +1,224 @@
+package background
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/batch-change-utils/overridable"
+	"github.com/sourcegraph/go-diff/diff"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+const builderTestDiff = `diff --git a/README.md b/README.md
+index 1234567..89abcde 100644
+--- a/README.md
++++ b/README.md
+@@ -1 +1 @@
+-Hello
++Hello World
+diff --git a/client/web/index.ts b/client/web/index.ts
+index 1234567..89abcde 100644
+--- a/client/web/index.ts
++++ b/client/web/index.ts
+@@ -1 +1 @@
+-hello()
++helloWorld()
+diff --git a/client/shared/old.ts b/client/shared/old.ts
+deleted file mode 100644
+index 1234567..0000000
+--- a/client/shared/old.ts
++++ /dev/null
+@@ -1 +0,0 @@
+-hello()
+diff --git a/enterprise/main.go b/enterprise/main.go
+index 1234567..89abcde 100644
+--- a/enterprise/main.go
++++ b/enterprise/main.go
+@@ -1 +1 @@
+-hello()
++helloWorld()
+`
+
+func TestBuildChangesetSpecs(t *testing.T) {
+	repo := changesetSpecRepository{
+		ID:      "UmVwb3NpdG9yeTox",
+		Name:    "github.com/sourcegraph/sourcegraph",
+		BaseRef: "refs/heads/main",
+		BaseRev: "d34db33f",
+	}
+
+	tmpl := btypes.ChangesetTemplate{
+		Title:  "Hello World",
+		Body:   "My first batch change!",
+		Branch: "hello-world",
+		Commit: btypes.CommitTemplate{Message: "Append Hello World"},
+	}
+
+	type wantSpec struct {
+		headRef   string
+		title     string
+		message   string
+		files     []string
+		published interface{}
+	}
+
+	tests := map[string]struct {
+		fields btypes.BatchSpecFields
+		want   []wantSpec
+	}{
+		"no transformChanges": {
+			fields: btypes.BatchSpecFields{ChangesetTemplate: tmpl},
+			want: []wantSpec{
+				{
+					headRef: "refs/heads/hello-world",
+					title:   "Hello World",
+					message: "Append Hello World",
+					files:   []string{"README.md", "client/web/index.ts", "client/shared/old.ts", "enterprise/main.go"},
+				},
+			},
+		},
+		"groups": {
+			fields: btypes.BatchSpecFields{
+				ChangesetTemplate: tmpl,
+				TransformChanges: &btypes.TransformChanges{Group: []btypes.Group{
+					{Directory: "client", BranchSuffix: "-client"},
+					{Directory: "client/web", Branch: "hello-web"},
+					{Directory: "enterprise", Branch: "hello-enterprise", Repository: "github.com/sourcegraph/other"},
+				}},
+			},
+			want: []wantSpec{
+				{
+					headRef: "refs/heads/hello-web",
+					title:   "Hello World",
+					message: "Append Hello World",
+					files:   []string{"client/web/index.ts"},
+				},
+				{
+					headRef: "refs/heads/hello-world",
+					title:   "Hello World",
+					message: "Append Hello World",
+					files:   []string{"README.md", "enterprise/main.go"},
+				},
+				{
+					headRef: "refs/heads/hello-world-client",
+					title:   "Hello World",
+					message: "Append Hello World",
+					files:   []string{"client/shared/old.ts"},
+				},
+			},
+		},
+		"overrides": {
+			fields: btypes.BatchSpecFields{
+				ChangesetTemplate: func() btypes.ChangesetTemplate {
+					tmpl := tmpl
+					tmpl.Overrides = []btypes.ChangesetTemplateOverride{
+						{Repository: "github.com/sourcegraph/*", Title: "Hello Sourcegraph", Branch: "hello-sourcegraph"},
+						{Repository: "github.com/other/*", Title: "Hello Other"},
+						{Repository: "github.com/*/sourcegraph", Commit: &btypes.CommitTemplate{Message: "Say hello"}},
+					}
+					return tmpl
+				}(),
+				TransformChanges: &btypes.TransformChanges{Group: []btypes.Group{
+					{Directory: "enterprise", BranchSuffix: "-enterprise"},
+				}},
+			},
+			want: []wantSpec{
+				{
+					headRef: "refs/heads/hello-sourcegraph",
+					title:   "Hello Sourcegraph",
+					message: "Say hello",
+					files:   []string{"README.md", "client/web/index.ts", "client/shared/old.ts"},
+				},
+				{
+					headRef: "refs/heads/hello-sourcegraph-enterprise",
+					title:   "Hello Sourcegraph",
+					message: "Say hello",
+					files:   []string{"enterprise/main.go"},
+				},
+			},
+		},
+		"published per branch": {
+			fields: btypes.BatchSpecFields{
+				ChangesetTemplate: func() btypes.ChangesetTemplate {
+					tmpl := tmpl
+					tmpl.Published = overridable.FromBoolOrString(false)
+					if err := tmpl.Published.UnmarshalJSON([]byte(`[{"github.com/sourcegraph/sourcegraph@hello-world-client": "draft"}]`)); err != nil {
+						t.Fatal(err)
+					}
+					return tmpl
+				}(),
+				TransformChanges: &btypes.TransformChanges{Group: []btypes.Group{
+					{Directory: "client", BranchSuffix: "-client"},
+				}},
+			},
+			want: []wantSpec{
+				{
+					headRef:   "refs/heads/hello-world",
+					title:     "Hello World",
+					message:   "Append Hello World",
+					files:     []string{"README.md", "enterprise/main.go"},
+					published: false,
+				},
+				{
+					headRef:   "refs/heads/hello-world-client",
+					title:     "Hello World",
+					message:   "Append Hello World",
+					files:     []string{"client/web/index.ts", "client/shared/old.ts"},
+					published: "draft",
+				},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			specs, err := buildChangesetSpecs(&tc.fields, repo, builderTestDiff)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			have := make([]wantSpec, 0, len(specs))
+			for _, s := range specs {
+				if s.BaseRepository != repo.ID || s.HeadRepository != repo.ID {
+					t.Errorf("wrong repository: base=%s head=%s", s.BaseRepository, s.HeadRepository)
+				}
+				if s.BaseRef != repo.BaseRef || s.BaseRev != repo.BaseRev {
+					t.Errorf("wrong base: ref=%s rev=%s", s.BaseRef, s.BaseRev)
+				}
+				if len(s.Commits) != 1 {
+					t.Fatalf("wrong number of commits: %d", len(s.Commits))
+				}
+
+				files, err := diffFiles(s.Commits[0].Diff)
+				if err != nil {
+					t.Fatal(err)
+				}
+				have = append(have, wantSpec{
+					headRef:   s.HeadRef,
+					title:     s.Title,
+					message:   s.Commits[0].Message,
+					files:     files,
+					published: s.Published.Val,
+				})
+			}
+
+			if diff := cmp.Diff(tc.want, have, cmp.AllowUnexported(wantSpec{})); diff != "" {
+				t.Errorf("unexpected changeset specs (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
+
+func diffFiles(rawDiff string) ([]string, error) {
+	fileDiffs, err := diff.ParseMultiFileDiff([]byte(rawDiff))
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0, len(fileDiffs))
+	for _, fd := range fileDiffs {
+		files = append(files, fileDiffPath(fd))
+	}
+	return files, nil
+}
@@ -19,3 +19,15 @@ func newBatchSpecExecutionResetter(workerStore dbworkerstore.Store, metrics batc
 	resetter := dbworker.NewResetter(workerStore, options)
 	return resetter
 }
+
+// newWorkspaceJobResetter creates a dbworker.Resetter that re-enqueues lost
+// batch_spec_workspace_jobs for processing.
+func newWorkspaceJobResetter(workerStore dbworkerstore.Store, metrics batchChangesMetrics) *dbworker.Resetter {
+	options := dbworker.ResetterOptions{
+		Name:     "batch_spec_workspace_executor_resetter",
+		Interval: 1 * time.Minute,
+		Metrics:  metrics.workspaceJobResetterMetrics,
+	}
+
+	return dbworker.NewResetter(workerStore, options)
+}
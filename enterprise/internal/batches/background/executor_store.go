@@ -5,15 +5,19 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/cockroachdb/errors"
-	"github.com/graph-gophers/graphql-go"
-	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/hashicorp/go-multierror"
 	"github.com/keegancsmith/sqlf"
 
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
@@ -32,19 +36,20 @@ const executorStalledJobMaximumAge = time.Second * 25
 const executorMaximumNumResets = 3
 
 var executorWorkerStoreOptions = dbworkerstore.Options{
-	Name:              "batch_spec_executor_worker_store",
-	TableName:         "batch_spec_executions",
-	ColumnExpressions: store.BatchSpecExecutionColumns,
-	Scan:              scanFirstExecutionRecord,
-	OrderByExpression: sqlf.Sprintf("batch_spec_executions.created_at, batch_spec_executions.id"),
+	Name:              "batch_spec_workspace_executor_worker_store",
+	TableName:         "batch_spec_workspace_jobs",
+	ColumnExpressions: store.BatchSpecWorkspaceJobColumns,
+	Scan:              scanFirstWorkspaceJobRecord,
+	OrderByExpression: sqlf.Sprintf("batch_spec_workspace_jobs.created_at, batch_spec_workspace_jobs.id"),
 	StalledMaxAge:     executorStalledJobMaximumAge,
 	MaxNumResets:      executorMaximumNumResets,
-	// Explicitly disable retries.
+	// Explicitly disable retries. Failed workspaces are retried by the user.
 	MaxNumRetries: 0,
 }
 
-// NewExecutorStore creates a dbworker store that wraps the batch_spec_executions
-// table.
+// NewExecutorStore creates a dbworker store that wraps the
+// batch_spec_workspace_jobs table, from which executors dequeue the jobs that
+// run the steps of a batch spec in a single workspace.
 func NewExecutorStore(handle *basestore.TransactableHandle, observationContext *observation.Context) dbworkerstore.Store {
 	return &executorStore{
 		Store:              dbworkerstore.NewWithMetrics(handle, executorWorkerStoreOptions, observationContext),
@@ -54,119 +59,315 @@ func NewExecutorStore(handle *basestore.TransactableHandle, observationContext *
 
 var _ dbworkerstore.Store = &executorStore{}
 
-// executorStore is a thin wrapper around dbworkerstore.Store that allows us to
-// extract information out of the ExecutionLogEntry field and persisting it to
-// separate columns when marking a job as complete.
+// executorStore is a thin wrapper around dbworkerstore.Store that turns the
+// diff a workspace job produced into changeset specs when the job is marked
+// as complete, and assembles the batch spec of the execution once all of its
+// workspace jobs are finished.
 type executorStore struct {
 	dbworkerstore.Store
 
 	observationContext *observation.Context
 }
 
-// markCompleteQuery is taken from internal/workerutil/dbworker/store/store.go
-//
-// If that one changes we need to update this one here too.
-const markCompleteQuery = `
-UPDATE batch_spec_executions
-SET state = 'completed', finished_at = clock_timestamp(), batch_spec_id = (SELECT id FROM batch_specs WHERE rand_id = %s)
-WHERE id = %s AND state = 'processing' AND worker_hostname = %s
-RETURNING id
-`
-
 func (s *executorStore) MarkComplete(ctx context.Context, id int, options dbworkerstore.MarkFinalOptions) (_ bool, err error) {
-	batchesStore := store.New(s.Store.Handle().DB(), s.observationContext, nil)
+	tx, err := s.transact(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer func() { err = tx.Done(err) }()
 
-	batchSpecRandID, err := loadAndExtractBatchSpecRandID(ctx, batchesStore, int64(id))
+	job, err := tx.GetBatchSpecWorkspaceJob(ctx, store.GetBatchSpecWorkspaceJobOpts{ID: int64(id)})
 	if err != nil {
-		// If we couldn't extract the batch spec rand id, we mark the job as failed
-		return s.Store.MarkFailed(ctx, id, fmt.Sprintf("failed to extract batch spec ID: %s", err), options)
+		return false, err
+	}
+
+	workerStore := dbworkerstore.New(tx.Handle(), executorWorkerStoreOptions)
+
+	specIDs, err := createChangesetSpecsForWorkspaceJob(ctx, tx, job)
+	if err != nil {
+		// If we couldn't turn the diff into changeset specs, we mark the job
+		// as failed so that it can be retried.
+		ok, markErr := workerStore.MarkFailed(ctx, id, fmt.Sprintf("failed to create changeset specs: %s", err), options)
+		if markErr != nil || !ok {
+			return ok, markErr
+		}
+		return ok, assembleBatchSpecIfFinished(ctx, tx, job.BatchSpecExecutionID)
+	}
+
+	if err := tx.SetBatchSpecWorkspaceJobChangesetSpecs(ctx, job.ID, specIDs); err != nil {
+		return false, err
+	}
+
+	ok, err := workerStore.MarkComplete(ctx, id, options)
+	if err != nil || !ok {
+		return ok, err
 	}
 
-	_, ok, err := basestore.ScanFirstInt(batchesStore.Query(ctx, sqlf.Sprintf(markCompleteQuery, batchSpecRandID, id, options.WorkerHostname)))
-	return ok, err
+	return ok, assembleBatchSpecIfFinished(ctx, tx, job.BatchSpecExecutionID)
 }
 
-func loadAndExtractBatchSpecRandID(ctx context.Context, s *store.Store, id int64) (string, error) {
-	exec, err := s.GetBatchSpecExecution(ctx, store.GetBatchSpecExecutionOpts{ID: id})
+func (s *executorStore) MarkErrored(ctx context.Context, id int, failureMessage string, options dbworkerstore.MarkFinalOptions) (bool, error) {
+	return s.markFinal(ctx, id, func(workerStore dbworkerstore.Store) (bool, error) {
+		return workerStore.MarkErrored(ctx, id, failureMessage, options)
+	})
+}
+
+func (s *executorStore) MarkFailed(ctx context.Context, id int, failureMessage string, options dbworkerstore.MarkFinalOptions) (bool, error) {
+	return s.markFinal(ctx, id, func(workerStore dbworkerstore.Store) (bool, error) {
+		return workerStore.MarkFailed(ctx, id, failureMessage, options)
+	})
+}
+
+// markFinal marks the job with the given ID as finished with the given func
+// and assembles the batch spec of its execution if it was the last job.
+func (s *executorStore) markFinal(ctx context.Context, id int, mark func(dbworkerstore.Store) (bool, error)) (_ bool, err error) {
+	tx, err := s.transact(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	ok, err := mark(dbworkerstore.New(tx.Handle(), executorWorkerStoreOptions))
+	if err != nil || !ok {
+		return ok, err
+	}
+
+	job, err := tx.GetBatchSpecWorkspaceJob(ctx, store.GetBatchSpecWorkspaceJobOpts{ID: int64(id)})
 	if err != nil {
-		return "", err
+		return false, err
 	}
 
-	if len(exec.ExecutionLogs) < 1 {
-		return "", errors.New("no execution logs")
+	// Jobs that errored can be requeued by the underlying store.
+	if !job.Finished() {
+		return ok, nil
 	}
 
-	return extractBatchSpecRandID(exec.ExecutionLogs)
+	return ok, assembleBatchSpecIfFinished(ctx, tx, job.BatchSpecExecutionID)
 }
 
-var ErrNoBatchSpecRandID = errors.New("no batch spec rand id found in execution logs")
+// ResetStalled resets the stalled jobs and assembles the batch specs of the
+// executions whose last jobs were marked as failed because they have been
+// reset too often.
+func (s *executorStore) ResetStalled(ctx context.Context) (resetLastHeartbeatsByIDs, failedLastHeartbeatsByIDs map[int]time.Duration, err error) {
+	tx, err := s.transact(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { err = tx.Done(err) }()
 
-func extractBatchSpecRandID(logs []workerutil.ExecutionLogEntry) (string, error) {
-	var (
-		entry workerutil.ExecutionLogEntry
-		found bool
-	)
+	resetLastHeartbeatsByIDs, failedLastHeartbeatsByIDs, err = dbworkerstore.New(tx.Handle(), executorWorkerStoreOptions).ResetStalled(ctx)
+	if err != nil {
+		return resetLastHeartbeatsByIDs, failedLastHeartbeatsByIDs, err
+	}
 
-	for _, e := range logs {
-		if e.Key == "step.src.0" {
-			entry = e
-			found = true
-			break
+	seen := make(map[int64]struct{})
+	var execIDs []int64
+	for id := range failedLastHeartbeatsByIDs {
+		job, err := tx.GetBatchSpecWorkspaceJob(ctx, store.GetBatchSpecWorkspaceJobOpts{ID: int64(id)})
+		if err != nil {
+			return resetLastHeartbeatsByIDs, failedLastHeartbeatsByIDs, err
+		}
+		if _, ok := seen[job.BatchSpecExecutionID]; !ok {
+			seen[job.BatchSpecExecutionID] = struct{}{}
+			execIDs = append(execIDs, job.BatchSpecExecutionID)
 		}
 	}
-	if !found {
-		return "", ErrNoBatchSpecRandID
+	// Lock the executions in a consistent order.
+	sort.Slice(execIDs, func(i, j int) bool { return execIDs[i] < execIDs[j] })
+	for _, execID := range execIDs {
+		if err := assembleBatchSpecIfFinished(ctx, tx, execID); err != nil {
+			return resetLastHeartbeatsByIDs, failedLastHeartbeatsByIDs, err
+		}
 	}
 
-	var batchSpecRandID string
-	for _, l := range strings.Split(entry.Out, "\n") {
-		const outputLinePrefix = "stdout: "
+	return resetLastHeartbeatsByIDs, failedLastHeartbeatsByIDs, nil
+}
 
-		if !strings.HasPrefix(l, outputLinePrefix) {
-			continue
+func (s *executorStore) transact(ctx context.Context) (*store.Store, error) {
+	return store.New(s.Store.Handle().DB(), s.observationContext, nil).Transact(ctx)
+}
+
+// createChangesetSpecsForWorkspaceJob creates the changeset specs for the diff
+// produced by the given job and returns their IDs.
+func createChangesetSpecsForWorkspaceJob(ctx context.Context, s *store.Store, job *btypes.BatchSpecWorkspaceJob) (_ []int64, err error) {
+	// Use a savepoint so that no changeset specs are left behind if creating
+	// one of them fails.
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	exec, err := tx.GetBatchSpecExecution(ctx, store.GetBatchSpecExecutionOpts{ID: job.BatchSpecExecutionID})
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := btypes.NewBatchSpecFromRaw(exec.BatchSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	// The repository permissions of the user have been checked when the
+	// workspaces were resolved, so we can load the repository as an internal
+	// actor here.
+	repo, err := tx.Repos().Get(actor.WithInternalActor(ctx), job.RepoID)
+	if err != nil {
+		return nil, err
+	}
+
+	rawDiff, err := extractWorkspaceDiff(job.ExecutionLogs)
+	if err != nil {
+		return nil, err
+	}
+
+	descriptions, err := buildChangesetSpecs(&spec.Spec, changesetSpecRepository{
+		ID:      graphqlbackend.MarshalRepositoryID(repo.ID),
+		Name:    string(repo.Name),
+		BaseRef: job.Branch,
+		BaseRev: job.Commit,
+	}, rawDiff)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(descriptions))
+	for _, d := range descriptions {
+		raw, err := json.Marshal(d)
+		if err != nil {
+			return nil, err
 		}
 
-		jsonPart := l[len(outputLinePrefix):]
+		changesetSpec, err := btypes.NewChangesetSpecFromRaw(string(raw))
+		if err != nil {
+			return nil, err
+		}
+		changesetSpec.UserID = exec.UserID
+		changesetSpec.RepoID = repo.ID
 
-		var e srcCLILogLine
-		if err := json.Unmarshal([]byte(jsonPart), &e); err != nil {
-			// If we can't unmarshal the line as JSON we skip it
-			continue
+		if err := tx.CreateChangesetSpec(ctx, changesetSpec); err != nil {
+			return nil, err
 		}
+		ids = append(ids, changesetSpec.ID)
+	}
 
-		if e.Operation == operationCreatingBatchSpec && e.Status == "SUCCESS" {
-			parts := strings.Split(e.Message, "/")
-			if len(parts) == 0 {
-				return "", ErrNoBatchSpecRandID
-			}
+	return ids, nil
+}
 
-			batchSpecGraphQLID := graphql.ID(parts[len(parts)-1])
-			if err := relay.UnmarshalSpec(batchSpecGraphQLID, &batchSpecRandID); err != nil {
-				// If we can't extract the ID we simply return our main error
-				return "", ErrNoBatchSpecRandID
-			}
+// assembleBatchSpecIfFinished creates the batch spec of the given execution
+// and attaches the changeset specs of its completed workspace jobs, if none of
+// its workspace jobs is queued or processing anymore. If the batch spec
+// already exists, because a failed job has been retried, the new changeset
+// specs are attached to it.
+//
+// The execution is locked before its jobs are listed, so that of two
+// transactions finishing the last jobs at the same time, the second one sees
+// the job finished by the first one.
+func assembleBatchSpecIfFinished(ctx context.Context, tx *store.Store, execID int64) error {
+	exec, err := tx.GetBatchSpecExecution(ctx, store.GetBatchSpecExecutionOpts{ID: execID, ForUpdate: true})
+	if err != nil {
+		return err
+	}
 
-			return batchSpecRandID, nil
+	jobs, err := tx.ListBatchSpecWorkspaceJobs(ctx, store.ListBatchSpecWorkspaceJobsOpts{BatchSpecExecutionID: execID})
+	if err != nil {
+		return err
+	}
+
+	var changesetSpecIDs []int64
+	for _, j := range jobs {
+		if !j.Finished() {
+			return nil
 		}
+		changesetSpecIDs = append(changesetSpecIDs, j.ChangesetSpecIDs...)
 	}
 
-	return batchSpecRandID, ErrNoBatchSpecRandID
-}
+	batchSpecID := exec.BatchSpecID
+	if batchSpecID == 0 {
+		batchSpec, err := btypes.NewBatchSpecFromRaw(exec.BatchSpec)
+		if err != nil {
+			return err
+		}
+		batchSpec.UserID = exec.UserID
+		batchSpec.NamespaceUserID = exec.NamespaceUserID
+		batchSpec.NamespaceOrgID = exec.NamespaceOrgID
+
+		if err := tx.CreateBatchSpec(ctx, batchSpec); err != nil {
+			return err
+		}
+		if err := tx.SetBatchSpecExecutionBatchSpec(ctx, exec.ID, batchSpec.ID); err != nil {
+			return err
+		}
+		batchSpecID = batchSpec.ID
+	}
 
-// srcCLILogLine matches the definition of log entries that are printed by
-// src-cli when used with the `-text-only` flag.
-type srcCLILogLine struct {
-	Operation string `json:"operation"` // "PREPARING_DOCKER_IMAGES"
+	if len(changesetSpecIDs) == 0 {
+		return nil
+	}
 
-	Timestamp time.Time `json:"timestamp"`
+	changesetSpecs, _, err := tx.ListChangesetSpecs(ctx, store.ListChangesetSpecsOpts{IDs: changesetSpecIDs})
+	if err != nil {
+		return err
+	}
 
-	Status  string `json:"status"`            // "STARTED", "PROGRESS", "SUCCESS", "FAILURE"
-	Message string `json:"message,omitempty"` // "70% done"
+	var errs *multierror.Error
+	for _, cs := range changesetSpecs {
+		if cs.BatchSpecID == batchSpecID {
+			continue
+		}
+		cs.BatchSpecID = batchSpecID
+		if err := tx.UpdateChangesetSpec(ctx, cs); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs.ErrorOrNil()
 }
 
-const operationCreatingBatchSpec = "CREATING_BATCH_SPEC"
+// ErrNoWorkspaceDiff is returned by extractWorkspaceDiff if the execution logs
+// don't contain the output of the step that prints the diff.
+var ErrNoWorkspaceDiff = errors.New("no diff found in execution logs")
+
+// extractWorkspaceDiff returns the diff printed by the last docker step of a
+// workspace job. The executor stops at the first failing step, so the last
+// step of a completed job is always the one that prints the diff.
+func extractWorkspaceDiff(logs []workerutil.ExecutionLogEntry) (string, error) {
+	const keyPrefix = "step.docker."
+
+	var (
+		entry workerutil.ExecutionLogEntry
+		last  = -1
+	)
+	for _, e := range logs {
+		if !strings.HasPrefix(e.Key, keyPrefix) {
+			continue
+		}
+		i, err := strconv.Atoi(e.Key[len(keyPrefix):])
+		if err != nil || i <= last {
+			continue
+		}
+		entry, last = e, i
+	}
+	if last == -1 {
+		return "", ErrNoWorkspaceDiff
+	}
+
+	var b strings.Builder
+	for _, l := range strings.Split(entry.Out, "\n") {
+		const outputLinePrefix = "stdout:"
+
+		if !strings.HasPrefix(l, outputLinePrefix) {
+			continue
+		}
+		// Empty lines may have lost the space following the prefix.
+		b.WriteString(strings.TrimPrefix(l[len(outputLinePrefix):], " "))
+		b.WriteByte('\n')
+	}
+
+	return b.String(), nil
+}
 
-// scanFirstExecutionRecord scans a slice of batch change executions and returns the first.
-func scanFirstExecutionRecord(rows *sql.Rows, err error) (workerutil.Record, bool, error) {
-	return store.ScanFirstBatchSpecExecution(rows, err)
+// scanFirstWorkspaceJobRecord scans a slice of batch spec workspace jobs and
+// returns the first.
+func scanFirstWorkspaceJobRecord(rows *sql.Rows, err error) (workerutil.Record, bool, error) {
+	return store.ScanFirstBatchSpecWorkspaceJob(rows, err)
 }
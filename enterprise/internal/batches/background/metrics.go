@@ -16,6 +16,8 @@ type batchChangesMetrics struct {
 	reconcilerWorkerResetterMetrics    dbworker.ResetterMetrics
 	bulkProcessorWorkerResetterMetrics dbworker.ResetterMetrics
 	executionResetterMetrics           dbworker.ResetterMetrics
+	workspaceResolverWorkerMetrics     workerutil.WorkerMetrics
+	workspaceJobResetterMetrics        dbworker.ResetterMetrics
 }
 
 func newMetrics(observationContext *observation.Context) batchChangesMetrics {
@@ -25,6 +27,8 @@ func newMetrics(observationContext *observation.Context) batchChangesMetrics {
 		reconcilerWorkerResetterMetrics:    makeResetterMetrics(observationContext, "batch_changes_reconciler"),
 		bulkProcessorWorkerResetterMetrics: makeResetterMetrics(observationContext, "batch_changes_bulk_processor"),
 		executionResetterMetrics:           makeResetterMetrics(observationContext, "batch_spec_executor"),
+		workspaceResolverWorkerMetrics:     workerutil.NewMetrics(observationContext, "batch_spec_workspace_resolver", nil),
+		workspaceJobResetterMetrics:        makeResetterMetrics(observationContext, "batch_spec_workspace_executor"),
 	}
 }
 
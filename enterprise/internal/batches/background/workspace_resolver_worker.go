@@ -0,0 +1,110 @@
+package background
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/service"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+)
+
+// newWorkspaceResolverWorker creates a dbworker.Worker that fetches queued
+// batch_spec_executions from the database, resolves the workspaces their
+// steps are executed in, and queues one executor job per workspace.
+func newWorkspaceResolverWorker(
+	ctx context.Context,
+	s *store.Store,
+	workerStore dbworkerstore.Store,
+	metrics batchChangesMetrics,
+) *workerutil.Worker {
+	r := &workspaceResolverWorker{store: s, newResolver: service.NewWorkspaceResolver}
+
+	options := workerutil.WorkerOptions{
+		Name:              "batch_spec_workspace_resolver",
+		NumHandlers:       1,
+		HeartbeatInterval: 15 * time.Second,
+		Interval:          5 * time.Second,
+		Metrics:           metrics.workspaceResolverWorkerMetrics,
+	}
+
+	return dbworker.NewWorker(ctx, workerStore, r.HandlerFunc(), options)
+}
+
+// NewBatchSpecExecutionDBWorkerStore creates a dbworker store that wraps the
+// batch_spec_executions table.
+func NewBatchSpecExecutionDBWorkerStore(handle *basestore.TransactableHandle, observationContext *observation.Context) dbworkerstore.Store {
+	options := dbworkerstore.Options{
+		Name:              "batch_spec_execution_worker_store",
+		TableName:         "batch_spec_executions",
+		ColumnExpressions: store.BatchSpecExecutionColumns,
+		Scan:              scanFirstExecutionRecord,
+		OrderByExpression: sqlf.Sprintf("batch_spec_executions.created_at, batch_spec_executions.id"),
+		StalledMaxAge:     60 * time.Second,
+		MaxNumResets:      executorMaximumNumResets,
+		// Explicitly disable retries.
+		MaxNumRetries: 0,
+	}
+
+	return dbworkerstore.NewWithMetrics(handle, options, observationContext)
+}
+
+// scanFirstExecutionRecord scans a slice of batch change executions and returns the first.
+func scanFirstExecutionRecord(rows *sql.Rows, err error) (workerutil.Record, bool, error) {
+	return store.ScanFirstBatchSpecExecution(rows, err)
+}
+
+type workspaceResolverWorker struct {
+	store       *store.Store
+	newResolver func(*store.Store) service.WorkspaceResolver
+}
+
+func (w *workspaceResolverWorker) HandlerFunc() workerutil.HandlerFunc {
+	return func(ctx context.Context, record workerutil.Record) (err error) {
+		exec := record.(*btypes.BatchSpecExecution)
+
+		spec, err := btypes.NewBatchSpecFromRaw(exec.BatchSpec)
+		if err != nil {
+			return err
+		}
+
+		// 🚨 SECURITY: The workspaces are resolved as the user that created
+		// the execution, so that only repositories they have access to are
+		// included.
+		userCtx := actor.WithActor(ctx, actor.FromUser(exec.UserID))
+
+		workspaces, err := w.newResolver(w.store).ResolveWorkspacesForBatchSpec(userCtx, &spec.Spec)
+		if err != nil {
+			return err
+		}
+
+		tx, err := w.store.Transact(ctx)
+		if err != nil {
+			return err
+		}
+		defer func() { err = tx.Done(err) }()
+
+		for _, ws := range workspaces {
+			job := &btypes.BatchSpecWorkspaceJob{
+				BatchSpecExecutionID: exec.ID,
+				RepoID:               ws.Repo.ID,
+				Branch:               ws.Branch,
+				Commit:               string(ws.Commit),
+			}
+			if err := tx.CreateBatchSpecWorkspaceJob(ctx, job); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
@@ -3,11 +3,13 @@ package processor
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 	"github.com/inconshreveable/log15"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/global"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/reconciler"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/service"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/state"
@@ -15,7 +17,9 @@ import (
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 )
 
 // unknownJobTypeErr is returned when a ChangesetJob record is of an unknown type
@@ -34,10 +38,11 @@ func (e unknownJobTypeErr) NonRetryable() bool {
 
 var changesetIsProcessingErr = errors.New("cannot update a changeset that is currently being processed; will retry")
 
-func New(tx *store.Store, sourcer sources.Sourcer) BulkProcessor {
+func New(tx *store.Store, sourcer sources.Sourcer, gitClient reconciler.GitserverClient) BulkProcessor {
 	return &bulkProcessor{
-		tx:      tx,
-		sourcer: sourcer,
+		tx:              tx,
+		sourcer:         sourcer,
+		gitserverClient: gitClient,
 	}
 }
 
@@ -46,8 +51,9 @@ type BulkProcessor interface {
 }
 
 type bulkProcessor struct {
-	tx      *store.Store
-	sourcer sources.Sourcer
+	tx              *store.Store
+	sourcer         sources.Sourcer
+	gitserverClient reconciler.GitserverClient
 
 	css  sources.ChangesetSource
 	repo *types.Repo
@@ -101,6 +107,10 @@ func (b *bulkProcessor) Process(ctx context.Context, job *btypes.ChangesetJob) (
 		return b.closeChangeset(ctx, job)
 	case btypes.ChangesetJobTypePublish:
 		return b.publishChangeset(ctx, job)
+	case btypes.ChangesetJobTypeRerunChecks:
+		return b.rerunChecks(ctx, job)
+	case btypes.ChangesetJobTypeUpdateBranch:
+		return b.updateBranch(ctx, job)
 
 	default:
 		return &unknownJobTypeErr{jobType: string(job.JobType)}
@@ -266,3 +276,110 @@ func (b *bulkProcessor) publishChangeset(ctx context.Context, job *btypes.Change
 
 	return nil
 }
+
+func (b *bulkProcessor) rerunChecks(ctx context.Context, job *btypes.ChangesetJob) error {
+	checks := b.ch.FailedRerunnableChecks()
+	if len(checks) == 0 {
+		return errcode.MakeNonRetryable(errors.New("changeset has no failed checks that can be re-run"))
+	}
+
+	css, err := sources.ToChecksRerunningChangesetSource(b.css)
+	if err != nil {
+		return errcode.MakeNonRetryable(err)
+	}
+
+	cs := &sources.Changeset{
+		Changeset: b.ch,
+		Repo:      b.repo,
+	}
+	// The new check states are picked up by the next sync or webhook.
+	return css.RerunChecks(ctx, cs, checks)
+}
+
+func (b *bulkProcessor) updateBranch(ctx context.Context, job *btypes.ChangesetJob) error {
+	cs := &sources.Changeset{
+		Changeset: b.ch,
+		Repo:      b.repo,
+	}
+
+	// If the code host can update the branch itself, let it do the work.
+	if css, err := sources.ToBranchUpdatingChangesetSource(b.css); err == nil {
+		if err := css.UpdateBranch(ctx, cs); err != nil {
+			return err
+		}
+
+		events, err := cs.Changeset.Events()
+		if err != nil {
+			log15.Error("Events", "err", err)
+			return errcode.MakeNonRetryable(err)
+		}
+		state.SetDerivedState(ctx, b.tx.Repos(), cs.Changeset, events)
+
+		if err := b.tx.UpsertChangesetEvents(ctx, events...); err != nil {
+			log15.Error("UpsertChangesetEvents", "err", err)
+			return errcode.MakeNonRetryable(err)
+		}
+
+		if err := b.tx.UpdateChangesetCodeHostState(ctx, cs.Changeset); err != nil {
+			log15.Error("UpdateChangeset", "err", err)
+			return errcode.MakeNonRetryable(err)
+		}
+
+		return nil
+	}
+
+	// Otherwise we apply the diff of the current changeset spec on top of the
+	// latest commit of the base branch and push that to the head branch.
+	if b.ch.CurrentSpecID == 0 {
+		return errcode.MakeNonRetryable(errors.New("cannot update the branch of an imported changeset on this code host"))
+	}
+
+	spec, err := b.tx.GetChangesetSpecByID(ctx, b.ch.CurrentSpecID)
+	if err != nil {
+		log15.Error("GetChangesetSpecByID", "err", err)
+		return errcode.MakeNonRetryable(errors.Wrapf(err, "getting changeset spec for changeset %d", b.ch.ID))
+	}
+
+	baseCommit, err := git.ResolveRevision(ctx, b.repo.Name, spec.Spec.BaseRef, git.ResolveRevisionOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "resolving base branch %q", spec.Spec.BaseRef)
+	}
+
+	pushConf, err := b.css.GitserverPushConfig(ctx, b.tx.ExternalServices(), b.repo)
+	if err != nil {
+		return err
+	}
+	opts, err := reconciler.BuildCommitOpts(b.repo, spec, pushConf)
+	if err != nil {
+		return errcode.MakeNonRetryable(err)
+	}
+	opts.BaseCommit = baseCommit
+	signature, err := reconciler.SignCommitOpts(ctx, b.tx, b.repo, &opts)
+	if err != nil {
+		return err
+	}
+
+	if _, err := b.gitserverClient.CreateCommitFromPatch(ctx, opts); err != nil {
+		// The diff doesn't apply to the new base, which won't change by
+		// retrying. Report the conflict right away.
+		var e *protocol.CreateCommitFromPatchError
+		if errors.As(err, &e) {
+			return errcode.MakeNonRetryable(errors.Errorf(
+				"applying changeset diff on top of %s (%s) in repository %q: %s\n"+
+					"```\n"+
+					"$ %s\n"+
+					"%s\n"+
+					"```",
+				spec.Spec.BaseRef, baseCommit, e.RepositoryName, e.InternalError, e.Command, strings.TrimSpace(e.CombinedOutput)))
+		}
+		return err
+	}
+
+	b.ch.CommitSignature = signature
+	if err := b.tx.UpdateChangesetCommitSignature(ctx, b.ch); err != nil {
+		log15.Error("UpdateChangesetCommitSignature", "err", err)
+		return errcode.MakeNonRetryable(err)
+	}
+
+	return nil
+}
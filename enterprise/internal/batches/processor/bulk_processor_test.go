@@ -10,11 +10,15 @@ import (
 	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 )
 
 func TestBulkProcessor(t *testing.T) {
@@ -191,6 +195,142 @@ func TestBulkProcessor(t *testing.T) {
 		}
 	})
 
+	t.Run("Update branch job", func(t *testing.T) {
+		fake := &sources.FakeChangesetSource{FakeMetadata: &github.PullRequest{}}
+		bp := &bulkProcessor{
+			tx:      bstore,
+			sourcer: sources.NewFakeSourcer(nil, fake),
+		}
+		job := &types.ChangesetJob{
+			JobType:     types.ChangesetJobTypeUpdateBranch,
+			ChangesetID: changeset.ID,
+			UserID:      user.ID,
+			Payload:     &btypes.ChangesetJobUpdateBranchPayload{},
+		}
+		err := bp.Process(ctx, job)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !fake.UpdateBranchCalled {
+			t.Fatal("expected UpdateBranch to be called but wasn't")
+		}
+	})
+
+	t.Run("Update branch job without code host support", func(t *testing.T) {
+		git.Mocks.ResolveRevision = func(spec string, opt git.ResolveRevisionOptions) (api.CommitID, error) {
+			if spec != "refs/heads/main" {
+				t.Fatalf("unexpected revision resolved: %q", spec)
+			}
+			return "c0ffee", nil
+		}
+		t.Cleanup(git.ResetMocks)
+
+		changesetSpec := ct.CreateChangesetSpec(t, ctx, bstore, ct.TestSpecOpts{
+			User:              user.ID,
+			Repo:              repo.ID,
+			BatchSpec:         batchSpec.ID,
+			HeadRef:           "refs/heads/update-branch",
+			BaseRef:           "refs/heads/main",
+			BaseRev:           "d34db33f",
+			CommitMessage:     "Update the branch",
+			CommitDiff:        "diff --git a/README.md b/README.md",
+			CommitAuthorName:  "Mary McButtons",
+			CommitAuthorEmail: "mary@example.com",
+		})
+		changeset := ct.CreateChangeset(t, ctx, bstore, ct.TestChangesetOpts{
+			Repo:                repo.ID,
+			BatchChanges:        []types.BatchChangeAssoc{{BatchChangeID: batchChange.ID}},
+			Metadata:            &github.PullRequest{},
+			ExternalServiceType: extsvc.TypeGitHub,
+			CurrentSpec:         changesetSpec.ID,
+		})
+		job := &types.ChangesetJob{
+			JobType:     types.ChangesetJobTypeUpdateBranch,
+			ChangesetID: changeset.ID,
+			UserID:      user.ID,
+			Payload:     &btypes.ChangesetJobUpdateBranchPayload{},
+		}
+
+		t.Run("success", func(t *testing.T) {
+			fake := &sources.FakeChangesetSource{FakeMetadata: &github.PullRequest{}}
+			gitClient := &ct.FakeGitserverClient{Response: "refs/heads/update-branch"}
+			bp := &bulkProcessor{
+				tx:              bstore,
+				sourcer:         sources.NewFakeSourcer(nil, nonBranchUpdatingSource{fake}),
+				gitserverClient: gitClient,
+			}
+			if err := bp.Process(ctx, job); err != nil {
+				t.Fatal(err)
+			}
+			if fake.UpdateBranchCalled {
+				t.Fatal("expected UpdateBranch not to be called but was")
+			}
+			if !gitClient.CreateCommitFromPatchCalled {
+				t.Fatal("expected CreateCommitFromPatch to be called but wasn't")
+			}
+			// The diff is applied on top of the latest commit of the base branch, not on top of
+			// the base revision of the spec.
+			req := gitClient.CreateCommitFromPatchReq
+			if req.BaseCommit != "c0ffee" {
+				t.Fatalf("unexpected base commit: %q", req.BaseCommit)
+			}
+			if req.TargetRef != "refs/heads/update-branch" || req.Patch != "diff --git a/README.md b/README.md\n" || req.Push == nil {
+				t.Fatalf("unexpected request: %+v", req)
+			}
+		})
+
+		t.Run("conflict", func(t *testing.T) {
+			fake := &sources.FakeChangesetSource{FakeMetadata: &github.PullRequest{}}
+			gitClient := &ct.FakeGitserverClient{ResponseErr: &protocol.CreateCommitFromPatchError{
+				RepositoryName: string(repo.Name),
+				InternalError:  "applying patch",
+				Command:        "git apply --cached",
+				CombinedOutput: "error: patch failed: README.md:1",
+			}}
+			bp := &bulkProcessor{
+				tx:              bstore,
+				sourcer:         sources.NewFakeSourcer(nil, nonBranchUpdatingSource{fake}),
+				gitserverClient: gitClient,
+			}
+			err := bp.Process(ctx, job)
+			if err == nil {
+				t.Fatal("unexpected nil error")
+			}
+			if !errcode.IsNonRetryable(err) {
+				t.Fatalf("error is retryable: %v", err)
+			}
+		})
+
+		t.Run("imported changeset", func(t *testing.T) {
+			imported := ct.CreateChangeset(t, ctx, bstore, ct.TestChangesetOpts{
+				Repo:                repo.ID,
+				BatchChanges:        []types.BatchChangeAssoc{{BatchChangeID: batchChange.ID}},
+				Metadata:            &github.PullRequest{},
+				ExternalServiceType: extsvc.TypeGitHub,
+				ExternalID:          "123",
+			})
+			fake := &sources.FakeChangesetSource{FakeMetadata: &github.PullRequest{}}
+			gitClient := &ct.FakeGitserverClient{}
+			bp := &bulkProcessor{
+				tx:              bstore,
+				sourcer:         sources.NewFakeSourcer(nil, nonBranchUpdatingSource{fake}),
+				gitserverClient: gitClient,
+			}
+			err := bp.Process(ctx, &types.ChangesetJob{
+				JobType:     types.ChangesetJobTypeUpdateBranch,
+				ChangesetID: imported.ID,
+				UserID:      user.ID,
+				Payload:     &btypes.ChangesetJobUpdateBranchPayload{},
+			})
+			if err == nil || !errcode.IsNonRetryable(err) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if gitClient.CreateCommitFromPatchCalled {
+				t.Fatal("expected CreateCommitFromPatch not to be called but was")
+			}
+		})
+	})
+
 	t.Run("Close job", func(t *testing.T) {
 		fake := &sources.FakeChangesetSource{FakeMetadata: &github.PullRequest{}}
 		bp := &bulkProcessor{
@@ -359,3 +499,18 @@ func TestBulkProcessor(t *testing.T) {
 		})
 	})
 }
+
+// nonBranchUpdatingSource wraps a changeset source without exposing its
+// UpdateBranch method, like the sources of code hosts that can't update
+// branches.
+type nonBranchUpdatingSource struct {
+	sources.ChangesetSource
+}
+
+func (s nonBranchUpdatingSource) WithAuthenticator(a auth.Authenticator) (sources.ChangesetSource, error) {
+	css, err := s.ChangesetSource.WithAuthenticator(a)
+	if err != nil {
+		return nil, err
+	}
+	return nonBranchUpdatingSource{css}, nil
+}
@@ -0,0 +1,95 @@
+package reconciler
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+var autoMergeEventKinds = []btypes.ChangesetEventKind{
+	btypes.ChangesetEventKindAutoMergeScheduled,
+	btypes.ChangesetEventKindAutoMerged,
+	btypes.ChangesetEventKindAutoMergeFailed,
+}
+
+// LoadAutoMerge returns the auto-merge configuration of the changeset template
+// in the batch spec applied to the batch change owning the given changeset. It
+// returns nil if the changeset isn't owned by a batch change or auto-merge
+// isn't configured.
+func LoadAutoMerge(ctx context.Context, tx *store.Store, ch *btypes.Changeset) (*btypes.ChangesetAutoMerge, error) {
+	if ch.OwnedByBatchChangeID == 0 {
+		return nil, nil
+	}
+
+	batchChange, err := tx.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: ch.OwnedByBatchChangeID})
+	if err != nil {
+		return nil, err
+	}
+
+	batchSpec, err := tx.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: batchChange.BatchSpecID})
+	if err != nil {
+		return nil, err
+	}
+
+	return batchSpec.Spec.ChangesetTemplate.AutoMerge, nil
+}
+
+// AutoMergeDue returns whether the given changeset should be enqueued so that
+// the reconciler can merge it automatically. That is the case if it is an
+// auto-merge candidate, auto-merge is enabled for it, and either its computed
+// state just changed or the merge window it was scheduled for has opened.
+func AutoMergeDue(ctx context.Context, tx *store.Store, ch *btypes.Changeset, stateChanged bool) (bool, error) {
+	if !ch.AutoMergeCandidate() {
+		return false, nil
+	}
+
+	cfg, err := LoadAutoMerge(ctx, tx, ch)
+	if err != nil || cfg == nil || !cfg.Enabled {
+		return false, err
+	}
+
+	if stateChanged {
+		return true, nil
+	}
+
+	events, _, err := tx.ListChangesetEvents(ctx, store.ListChangesetEventsOpts{
+		ChangesetIDs: []int64{ch.ID},
+		Kinds:        autoMergeEventKinds,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	var latest *btypes.ChangesetEvent
+	for _, e := range events {
+		if latest == nil || e.Timestamp().After(latest.Timestamp()) {
+			latest = e
+		}
+	}
+	if latest == nil || latest.Kind != btypes.ChangesetEventKindAutoMergeScheduled {
+		return false, nil
+	}
+
+	meta, ok := latest.Metadata.(*btypes.AutoMergeEvent)
+	if !ok {
+		return false, nil
+	}
+	return !meta.ScheduledFor.After(tx.Clock()()), nil
+}
+
+// scheduleAutoMerge records in the changeset's history that it will be merged
+// automatically once the merge window opens at the given time.
+func scheduleAutoMerge(ctx context.Context, tx *store.Store, ch *btypes.Changeset, cfg *btypes.ChangesetAutoMerge, scheduledFor time.Time) error {
+	return tx.UpsertChangesetEvents(ctx, &btypes.ChangesetEvent{
+		ChangesetID: ch.ID,
+		Kind:        btypes.ChangesetEventKindAutoMergeScheduled,
+		Key:         scheduledFor.UTC().Format(time.RFC3339),
+		Metadata: &btypes.AutoMergeEvent{
+			CreatedAt:    tx.Clock()(),
+			Squash:       cfg.Squash,
+			ScheduledFor: scheduledFor,
+		},
+	})
+}
@@ -102,6 +102,9 @@ func (e *executor) Run(ctx context.Context, plan *Plan) (err error) {
 		case btypes.ReconcilerOperationArchive:
 			e.archiveChangeset()
 
+		case btypes.ReconcilerOperationMerge:
+			err = e.mergeChangeset(ctx, plan.AutoMerge)
+
 		default:
 			err = errors.Errorf("executor operation %q not implemented", op)
 		}
@@ -150,11 +153,20 @@ func (e *executor) pushChangesetPatch(ctx context.Context) (err error) {
 	if err != nil {
 		return err
 	}
-	opts, err := buildCommitOpts(e.repo, e.spec, pushConf)
+	opts, err := BuildCommitOpts(e.repo, e.spec, pushConf)
+	if err != nil {
+		return err
+	}
+	signature, err := SignCommitOpts(ctx, e.tx, e.repo, &opts)
 	if err != nil {
 		return err
 	}
-	return e.pushCommit(ctx, opts)
+	if err := e.pushCommit(ctx, opts); err != nil {
+		return err
+	}
+
+	e.ch.CommitSignature = signature
+	return nil
 }
 
 // publishChangeset creates the given changeset on its code host.
@@ -336,6 +348,93 @@ func (e *executor) undraftChangeset(ctx context.Context) (err error) {
 	return nil
 }
 
+// mergeChangeset merges the given changeset on its code host according to the
+// auto-merge configuration and records the outcome in the changeset's history.
+func (e *executor) mergeChangeset(ctx context.Context, cfg *btypes.ChangesetAutoMerge) (err error) {
+	if cfg == nil {
+		return errors.New("no auto-merge configuration")
+	}
+
+	now := e.tx.Clock()()
+	event := &btypes.ChangesetEvent{
+		ChangesetID: e.ch.ID,
+		Kind:        btypes.ChangesetEventKindAutoMerged,
+		Key:         "auto_merged",
+	}
+	meta := &btypes.AutoMergeEvent{CreatedAt: now, Squash: cfg.Squash}
+
+	cs := &sources.Changeset{Changeset: e.ch, Repo: e.repo}
+	if err := e.css.MergeChangeset(ctx, cs, cfg.Squash); err != nil {
+		// If the code host refuses the merge, retrying won't help until the
+		// state of the changeset changes again, so we record the failure
+		// instead of failing the reconciler run.
+		if !errors.HasType(err, sources.ChangesetNotMergeableError{}) {
+			return errors.Wrap(err, "merging changeset")
+		}
+		event.Kind = btypes.ChangesetEventKindAutoMergeFailed
+		event.Key = now.UTC().Format(time.RFC3339Nano)
+		meta.Error = err.Error()
+	}
+	event.Metadata = meta
+
+	return e.tx.UpsertChangesetEvents(ctx, event)
+}
+
+// credentialUserIDs returns the IDs of the users whose credentials can be
+// used to reconcile the changesets owned by the batch change, in order of
+// preference: the user that last applied it, its owner and its user admins.
+// Deleted users are left out, so that the credentials of users that left are
+// no longer used.
+func credentialUserIDs(ctx context.Context, s *store.Store, batchChange *btypes.BatchChange) ([]int32, error) {
+	admins, err := s.ListBatchChangeAdmins(ctx, store.ListBatchChangeAdminsOpts{BatchChangeID: batchChange.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := credentialCandidates(batchChange, admins)
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	users, err := database.UsersWith(s).List(ctx, &database.UsersListOptions{UserIDs: candidates})
+	if err != nil {
+		return nil, err
+	}
+	active := make(map[int32]struct{}, len(users))
+	for _, u := range users {
+		active[u.ID] = struct{}{}
+	}
+
+	ids := make([]int32, 0, len(candidates))
+	for _, id := range candidates {
+		if _, ok := active[id]; ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// credentialCandidates returns the IDs of the last applier, the owner and the
+// user admins of the batch change, in that order and without duplicates.
+func credentialCandidates(batchChange *btypes.BatchChange, admins []*btypes.BatchChangeAdmin) []int32 {
+	ids := make([]int32, 0, len(admins)+2)
+	seen := make(map[int32]struct{}, len(admins)+2)
+	add := func(id int32) {
+		if _, ok := seen[id]; ok || id == 0 {
+			return
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	add(batchChange.LastApplierID)
+	add(batchChange.OwnerID)
+	for _, a := range admins {
+		add(a.UserID)
+	}
+	return ids
+}
+
 // sleep sleeps for 3 seconds.
 func (e *executor) sleep() {
 	if !e.noSleepBeforeSync {
@@ -358,7 +457,14 @@ func loadChangesetSource(ctx context.Context, s *store.Store, sourcer sources.So
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to load owning batch change")
 		}
-		css, err = sources.WithAuthenticatorForUser(ctx, s, css, batchChange.LastApplierID, repo)
+		userIDs, err := credentialUserIDs(ctx, s, batchChange)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load users with credentials")
+		}
+		// The last applier might have left or not have credentials for the
+		// code host, so we fall back to the owner and the admins of the batch
+		// change before using the site credential.
+		css, err = sources.WithAuthenticatorForUsers(ctx, s, css, userIDs, repo)
 		if err != nil {
 			switch err {
 			case sources.ErrMissingCredentials:
@@ -407,7 +513,35 @@ func (e *executor) pushCommit(ctx context.Context, opts protocol.CreateCommitFro
 	return nil
 }
 
-func buildCommitOpts(repo *types.Repo, spec *btypes.ChangesetSpec, pushOpts *protocol.PushConfig) (opts protocol.CreateCommitFromPatchRequest, err error) {
+// SignCommitOpts configures opts to sign the commit with the commit signing
+// key registered for the code host of the repo, if there is one. It returns
+// the signature the commit will have, or nil if it won't be signed.
+func SignCommitOpts(ctx context.Context, s *store.Store, repo *types.Repo, opts *protocol.CreateCommitFromPatchRequest) (*btypes.ChangesetCommitSignature, error) {
+	key, err := s.GetCommitSigningKey(ctx, store.GetCommitSigningKeyOpts{
+		ExternalServiceType: repo.ExternalRepo.ServiceType,
+		ExternalServiceID:   repo.ExternalRepo.ServiceID,
+	})
+	if err == store.ErrNoResults {
+		opts.Signing = nil
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "loading commit signing key")
+	}
+
+	opts.Signing, err = key.SigningConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &btypes.ChangesetCommitSignature{
+		Format:      key.Format,
+		Fingerprint: key.Fingerprint,
+	}, nil
+}
+
+// BuildCommitOpts returns the options to create the commit described by the
+// given changeset spec on top of the spec's base revision.
+func BuildCommitOpts(repo *types.Repo, spec *btypes.ChangesetSpec, pushOpts *protocol.PushConfig) (opts protocol.CreateCommitFromPatchRequest, err error) {
 	desc := spec.Spec
 
 	diff, err := desc.Diff()
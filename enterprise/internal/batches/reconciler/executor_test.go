@@ -23,6 +23,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	gitprotocol "github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/signing"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
@@ -85,8 +86,10 @@ func TestExecutor_ExecutePlan(t *testing.T) {
 		wantCloseOnCodeHost       bool
 		wantLoadFromCodeHost      bool
 		wantReopenOnCodeHost      bool
+		wantMergeOnCodeHost       bool
 
 		wantGitserverCommit bool
+		wantEventKinds      []btypes.ChangesetEventKind
 
 		wantChangeset       ct.ChangesetAssertions
 		wantNonRetryableErr bool
@@ -313,6 +316,53 @@ func TestExecutor_ExecutePlan(t *testing.T) {
 				ExternalState:  btypes.ChangesetExternalStateClosed,
 			},
 		},
+		"auto-merge": {
+			hasCurrentSpec: true,
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalID:       githubPR.ID,
+				ExternalBranch:   githubHeadRef,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+			},
+			plan: &Plan{
+				Ops:       Operations{btypes.ReconcilerOperationMerge},
+				AutoMerge: &btypes.ChangesetAutoMerge{Enabled: true, Squash: true},
+			},
+
+			wantMergeOnCodeHost: true,
+			wantEventKinds:      []btypes.ChangesetEventKind{btypes.ChangesetEventKindAutoMerged},
+
+			wantChangeset: ct.ChangesetAssertions{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalID:       githubPR.ID,
+				ExternalBranch:   githubHeadRef,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+			},
+		},
+		"auto-merge not mergeable": {
+			hasCurrentSpec: true,
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalID:       githubPR.ID,
+				ExternalBranch:   githubHeadRef,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+			},
+			plan: &Plan{
+				Ops:       Operations{btypes.ReconcilerOperationMerge},
+				AutoMerge: &btypes.ChangesetAutoMerge{Enabled: true},
+			},
+			sourcerErr: sources.ChangesetNotMergeableError{ErrorMsg: "merge conflict"},
+
+			wantMergeOnCodeHost: true,
+			wantEventKinds:      []btypes.ChangesetEventKind{btypes.ChangesetEventKindAutoMergeFailed},
+
+			wantChangeset: ct.ChangesetAssertions{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalID:       githubPR.ID,
+				ExternalBranch:   githubHeadRef,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+			},
+		},
 		"reopening closed changeset without updates": {
 			hasCurrentSpec: true,
 			changeset: ct.TestChangesetOpts{
@@ -552,10 +602,27 @@ func TestExecutor_ExecutePlan(t *testing.T) {
 				t.Fatalf("wrong CloseChangeset call. wantCalled=%t, wasCalled=%t", want, have)
 			}
 
+			if have, want := fakeSource.MergeChangesetCalled, tc.wantMergeOnCodeHost; have != want {
+				t.Fatalf("wrong MergeChangeset call. wantCalled=%t, wasCalled=%t", want, have)
+			}
+
 			if tc.wantNonRetryableErr {
 				return
 			}
 
+			if len(tc.wantEventKinds) > 0 {
+				events, _, err := cstore.ListChangesetEvents(ctx, store.ListChangesetEventsOpts{
+					ChangesetIDs: []int64{changeset.ID},
+					Kinds:        tc.wantEventKinds,
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if have, want := len(events), len(tc.wantEventKinds); have != want {
+					t.Fatalf("wrong number of changeset events. want=%d, have=%d", want, have)
+				}
+			}
+
 			// Assert that the changeset in the database looks like we want
 			assertions := tc.wantChangeset
 			assertions.Repo = repo.ID
@@ -771,6 +838,60 @@ func TestLoadChangesetSource(t *testing.T) {
 		}
 	})
 
+	t.Run("credentials of deleted last applier are not used", func(t *testing.T) {
+		departed := ct.CreateTestUser(t, db, false)
+		owner := ct.CreateTestUser(t, db, false)
+
+		batchChange := ct.CreateBatchChange(t, ctx, cstore, "reconciler-test-departed", departed.ID, batchSpec.ID)
+		// Ownership has been transferred, but the departed user is still the
+		// last applier.
+		batchChange.OwnerID = owner.ID
+		batchChange.NamespaceUserID = owner.ID
+		if err := cstore.UpdateBatchChange(ctx, batchChange); err != nil {
+			t.Fatal(err)
+		}
+
+		departedToken := &auth.OAuthBearerToken{Token: "departed"}
+		ownerToken := &auth.OAuthBearerToken{Token: "owner"}
+		for userID, token := range map[int32]auth.Authenticator{departed.ID: departedToken, owner.ID: ownerToken} {
+			if _, err := cstore.UserCredentials().Create(ctx, database.UserCredentialScope{
+				Domain:              database.UserCredentialDomainBatches,
+				UserID:              userID,
+				ExternalServiceType: repo.ExternalRepo.ServiceType,
+				ExternalServiceID:   repo.ExternalRepo.ServiceID,
+			}, token); err != nil {
+				t.Fatal(err)
+			}
+		}
+		t.Cleanup(func() {
+			ct.TruncateTables(t, db, "user_credentials")
+		})
+
+		load := func(t *testing.T) auth.Authenticator {
+			t.Helper()
+
+			fakeSource := &sources.FakeChangesetSource{}
+			sourcer := sources.NewFakeSourcer(nil, fakeSource)
+			if _, err := loadChangesetSource(ctx, cstore, sourcer, &btypes.Changeset{
+				OwnedByBatchChangeID: batchChange.ID,
+			}, repo); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			return fakeSource.CurrentAuthenticator
+		}
+
+		if diff := cmp.Diff(departedToken, load(t)); diff != "" {
+			t.Errorf("unexpected authenticator:\n%s", diff)
+		}
+
+		if err := database.Users(db).Delete(ctx, departed.ID); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(ownerToken, load(t)); diff != "" {
+			t.Errorf("unexpected authenticator:\n%s", diff)
+		}
+	})
+
 	t.Run("owned by user without credential falls back to site-credential", func(t *testing.T) {
 		if err := cstore.CreateSiteCredential(ctx, &btypes.SiteCredential{
 			ExternalServiceType: repo.ExternalRepo.ServiceType,
@@ -828,6 +949,8 @@ func TestExecutor_UserCredentialsForGitserver(t *testing.T) {
 		credentials    auth.Authenticator
 		wantErr        bool
 		wantPushConfig *gitprotocol.PushConfig
+		signingKey     *btypes.CommitSigningKey
+		wantSigning    *gitprotocol.CommitSigningConfig
 	}{
 		{
 			name:        "github OAuthBearerToken",
@@ -839,6 +962,27 @@ func TestExecutor_UserCredentialsForGitserver(t *testing.T) {
 				RemoteURL: "https://my-secret-github-token@github.com/sourcegraph/" + string(gitHubRepo.Name),
 			},
 		},
+		{
+			name:        "github OAuthBearerToken and commit signing key",
+			user:        user,
+			extSvc:      gitHubExtSvc,
+			repo:        gitHubRepo,
+			credentials: &auth.OAuthBearerToken{Token: "my-secret-github-token"},
+			wantPushConfig: &gitprotocol.PushConfig{
+				RemoteURL: "https://my-secret-github-token@github.com/sourcegraph/" + string(gitHubRepo.Name),
+			},
+			signingKey: &btypes.CommitSigningKey{
+				ExternalServiceType: gitHubRepo.ExternalRepo.ServiceType,
+				ExternalServiceID:   gitHubRepo.ExternalRepo.ServiceID,
+				Format:              signing.FormatSSH,
+				Fingerprint:         "SHA256:abc",
+			},
+			wantSigning: &gitprotocol.CommitSigningConfig{
+				Format:     string(signing.FormatSSH),
+				PrivateKey: "signing key",
+				Passphrase: "signing passphrase",
+			},
+		},
 		{
 			name:    "github no credentials",
 			user:    user,
@@ -957,6 +1101,13 @@ func TestExecutor_UserCredentialsForGitserver(t *testing.T) {
 				defer func() { cstore.UserCredentials().Delete(ctx, cred.ID) }()
 			}
 
+			if tt.signingKey != nil {
+				if err := cstore.CreateCommitSigningKey(ctx, tt.signingKey, tt.wantSigning.PrivateKey, tt.wantSigning.Passphrase); err != nil {
+					t.Fatal(err)
+				}
+				defer func() { cstore.DeleteCommitSigningKey(ctx, tt.signingKey.ID) }()
+			}
+
 			batchSpec := ct.CreateBatchSpec(t, ctx, cstore, fmt.Sprintf("reconciler-credentials-%d", i), tt.user.ID)
 			batchChange := ct.CreateBatchChange(t, ctx, cstore, fmt.Sprintf("reconciler-credentials-%d", i), tt.user.ID, batchSpec.ID)
 
@@ -997,6 +1148,17 @@ func TestExecutor_UserCredentialsForGitserver(t *testing.T) {
 			if diff := cmp.Diff(tt.wantPushConfig, gitClient.CreateCommitFromPatchReq.Push); diff != "" {
 				t.Errorf("unexpected push options:\n%s", diff)
 			}
+			if diff := cmp.Diff(tt.wantSigning, gitClient.CreateCommitFromPatchReq.Signing); diff != "" {
+				t.Errorf("unexpected signing options:\n%s", diff)
+			}
+
+			var wantSignature *btypes.ChangesetCommitSignature
+			if tt.signingKey != nil {
+				wantSignature = &btypes.ChangesetCommitSignature{Format: tt.signingKey.Format, Fingerprint: tt.signingKey.Fingerprint}
+			}
+			if diff := cmp.Diff(wantSignature, plan.Changeset.CommitSignature); diff != "" {
+				t.Errorf("unexpected commit signature:\n%s", diff)
+			}
 		})
 	}
 }
@@ -1065,6 +1227,27 @@ func TestBatchChangeURL(t *testing.T) {
 	})
 }
 
+func TestCredentialCandidates(t *testing.T) {
+	admins := []*btypes.BatchChangeAdmin{{UserID: 3}, {OrgID: 4}, {UserID: 1}, {UserID: 5}}
+
+	for name, tc := range map[string]struct {
+		batchChange *btypes.BatchChange
+		admins      []*btypes.BatchChangeAdmin
+		want        []int32
+	}{
+		"last applier and owner": {batchChange: &btypes.BatchChange{LastApplierID: 1, OwnerID: 2}, want: []int32{1, 2}},
+		"deleted last applier":   {batchChange: &btypes.BatchChange{OwnerID: 2}, want: []int32{2}},
+		"owner is last applier":  {batchChange: &btypes.BatchChange{LastApplierID: 2, OwnerID: 2}, want: []int32{2}},
+		"admins":                 {batchChange: &btypes.BatchChange{LastApplierID: 1, OwnerID: 2}, admins: admins, want: []int32{1, 2, 3, 5}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, credentialCandidates(tc.batchChange, tc.admins)); diff != "" {
+				t.Errorf("wrong user IDs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestNamespaceURL(t *testing.T) {
 	t.Parallel()
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
 
@@ -23,6 +24,7 @@ var operationPrecedence = map[btypes.ReconcilerOperation]int{
 	btypes.ReconcilerOperationUpdate:       4,
 	btypes.ReconcilerOperationSleep:        5,
 	btypes.ReconcilerOperationSync:         6,
+	btypes.ReconcilerOperationMerge:        7,
 }
 
 type Operations []btypes.ReconcilerOperation
@@ -97,6 +99,10 @@ type Plan struct {
 	// The Delta between a possible previous ChangesetSpec and the current
 	// ChangesetSpec.
 	Delta *ChangesetSpecDelta
+
+	// The auto-merge configuration of the batch change owning the changeset,
+	// if the plan contains a merge operation.
+	AutoMerge *btypes.ChangesetAutoMerge
 }
 
 func (p *Plan) AddOp(op btypes.ReconcilerOperation) { p.Ops = append(p.Ops, op) }
@@ -242,6 +248,31 @@ func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, ch *btypes.C
 	return pl, nil
 }
 
+// DetermineAutoMerge adds a merge operation to the plan if the changeset is
+// eligible for being merged automatically according to the given auto-merge
+// configuration, and if no other operations are planned, since those could
+// invalidate its check or review state.
+//
+// If the changeset is eligible but the merge window is currently closed, no
+// operation is added and the time at which the window opens next is returned.
+func (p *Plan) DetermineAutoMerge(cfg *btypes.ChangesetAutoMerge, now time.Time) (scheduledFor time.Time, err error) {
+	if cfg == nil || !cfg.Enabled || !p.Ops.IsNone() || !p.Changeset.AutoMergeCandidate() {
+		return time.Time{}, nil
+	}
+
+	next, err := cfg.NextMergeAt(now)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "parsing merge window")
+	}
+	if next.After(now) {
+		return next, nil
+	}
+
+	p.AutoMerge = cfg
+	p.AddOp(btypes.ReconcilerOperationMerge)
+	return time.Time{}, nil
+}
+
 func reopenAfterDetach(ch *btypes.Changeset) bool {
 	closed := ch.ExternalState == btypes.ChangesetExternalStateClosed
 	if !closed {
@@ -2,6 +2,7 @@ package reconciler
 
 import (
 	"testing"
+	"time"
 
 	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
@@ -423,3 +424,113 @@ func TestDetermineReconcilerPlan(t *testing.T) {
 func uiPublicationStatePtr(state btypes.ChangesetUiPublicationState) *btypes.ChangesetUiPublicationState {
 	return &state
 }
+
+func TestDetermineAutoMerge(t *testing.T) {
+	t.Parallel()
+
+	// A Wednesday.
+	now := time.Date(2021, 3, 24, 10, 0, 0, 0, time.UTC)
+
+	mergeable := ct.TestChangesetOpts{
+		PublicationState:    btypes.ChangesetPublicationStatePublished,
+		ExternalState:       btypes.ChangesetExternalStateOpen,
+		ExternalCheckState:  btypes.ChangesetCheckStatePassed,
+		ExternalReviewState: btypes.ChangesetReviewStateApproved,
+		OwnedByBatchChange:  1234,
+		BatchChanges:        []btypes.BatchChangeAssoc{{BatchChangeID: 1234}},
+	}
+	pending := mergeable
+	pending.ExternalCheckState = btypes.ChangesetCheckStatePending
+	unapproved := mergeable
+	unapproved.ExternalReviewState = btypes.ChangesetReviewStatePending
+	imported := mergeable
+	imported.OwnedByBatchChange = 0
+
+	tcs := []struct {
+		name             string
+		changeset        ct.TestChangesetOpts
+		ops              Operations
+		cfg              *btypes.ChangesetAutoMerge
+		wantOperations   Operations
+		wantScheduledFor time.Time
+	}{
+		{
+			name:           "no config",
+			changeset:      mergeable,
+			wantOperations: Operations{},
+		},
+		{
+			name:           "disabled",
+			changeset:      mergeable,
+			cfg:            &btypes.ChangesetAutoMerge{Enabled: false},
+			wantOperations: Operations{},
+		},
+		{
+			name:           "enabled",
+			changeset:      mergeable,
+			cfg:            &btypes.ChangesetAutoMerge{Enabled: true},
+			wantOperations: Operations{btypes.ReconcilerOperationMerge},
+		},
+		{
+			name:           "checks pending",
+			changeset:      pending,
+			cfg:            &btypes.ChangesetAutoMerge{Enabled: true},
+			wantOperations: Operations{},
+		},
+		{
+			name:           "not approved",
+			changeset:      unapproved,
+			cfg:            &btypes.ChangesetAutoMerge{Enabled: true},
+			wantOperations: Operations{},
+		},
+		{
+			name:           "not owned by batch change",
+			changeset:      imported,
+			cfg:            &btypes.ChangesetAutoMerge{Enabled: true},
+			wantOperations: Operations{},
+		},
+		{
+			name:           "other operations planned",
+			changeset:      mergeable,
+			ops:            Operations{btypes.ReconcilerOperationPush},
+			cfg:            &btypes.ChangesetAutoMerge{Enabled: true},
+			wantOperations: Operations{btypes.ReconcilerOperationPush},
+		},
+		{
+			name:      "merge window open",
+			changeset: mergeable,
+			cfg: &btypes.ChangesetAutoMerge{
+				Enabled:     true,
+				MergeWindow: &btypes.ChangesetAutoMergeWindow{Days: []string{"wednesday"}, Start: "09:00", End: "17:00"},
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationMerge},
+		},
+		{
+			name:      "merge window closed",
+			changeset: mergeable,
+			cfg: &btypes.ChangesetAutoMerge{
+				Enabled:     true,
+				MergeWindow: &btypes.ChangesetAutoMergeWindow{Days: []string{"thursday"}, Start: "09:00", End: "17:00"},
+			},
+			wantOperations:   Operations{},
+			wantScheduledFor: time.Date(2021, 3, 25, 9, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			plan := &Plan{Changeset: ct.BuildChangeset(tc.changeset), Ops: tc.ops}
+
+			scheduledFor, err := plan.DetermineAutoMerge(tc.cfg, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have, want := plan.Ops, tc.wantOperations; !have.Equal(want) {
+				t.Fatalf("incorrect plan determined, want=%v have=%v", want, have)
+			}
+			if !scheduledFor.Equal(tc.wantScheduledFor) {
+				t.Fatalf("incorrect scheduled time, want=%v have=%v", tc.wantScheduledFor, scheduledFor)
+			}
+		})
+	}
+}
@@ -88,6 +88,20 @@ func (r *Reconciler) process(ctx context.Context, tx *store.Store, ch *btypes.Ch
 		return err
 	}
 
+	autoMerge, err := LoadAutoMerge(ctx, tx, ch)
+	if err != nil {
+		return err
+	}
+	scheduledFor, err := plan.DetermineAutoMerge(autoMerge, tx.Clock()())
+	if err != nil {
+		return err
+	}
+	if !scheduledFor.IsZero() {
+		if err := scheduleAutoMerge(ctx, tx, ch, autoMerge, scheduledFor); err != nil {
+			return err
+		}
+	}
+
 	log15.Info("Reconciler processing changeset", "changeset", ch.ID, "operations", plan.Ops)
 
 	return executePlan(
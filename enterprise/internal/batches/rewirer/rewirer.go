@@ -5,8 +5,10 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/global"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 )
 
 type ChangesetRewirer struct {
@@ -29,6 +31,13 @@ func New(mappings btypes.RewirerMappings, batchChangeID int64) *ChangesetRewirer
 func (r *ChangesetRewirer) Rewire() (changesets []*btypes.Changeset, err error) {
 	changesets = []*btypes.Changeset{}
 
+	// Since a batch spec can produce multiple changesets per repository, we
+	// need to make sure that no two changeset specs push to the same branch,
+	// which would make them both match, and update, the same changeset.
+	if err := checkUniqueBranches(r.mappings); err != nil {
+		return nil, err
+	}
+
 	for _, m := range r.mappings {
 		// If a Changeset that's currently attached to the batch change wasn't matched to a ChangesetSpec, it needs to be closed/detached.
 		if m.ChangesetSpec == nil {
@@ -206,6 +215,48 @@ func (r *ChangesetRewirer) closeChangeset(changeset *btypes.Changeset) {
 	}
 }
 
+// ErrDuplicateBranch is returned by the rewirer when multiple changeset specs
+// in the same repository want to push to the same branch.
+type ErrDuplicateBranch struct {
+	RepoName string
+	HeadRef  string
+}
+
+func (e ErrDuplicateBranch) Error() string {
+	return fmt.Sprintf(
+		"Multiple changesets in repository %q push to branch %q, but each changeset in a repository needs its own branch",
+		e.RepoName,
+		e.HeadRef,
+	)
+}
+
+var _ error = ErrDuplicateBranch{}
+
+// checkUniqueBranches checks that no two branch changeset specs in the given
+// mappings push to the same branch in the same repository.
+func checkUniqueBranches(mappings btypes.RewirerMappings) error {
+	type repoBranch struct {
+		repo    api.RepoID
+		headRef string
+	}
+
+	seen := make(map[repoBranch]struct{})
+	for _, m := range mappings {
+		// Mappings for inaccessible repositories are rejected by the
+		// rewirer anyway.
+		if m.ChangesetSpec == nil || !m.ChangesetSpec.Spec.IsBranch() || m.Repo == nil {
+			continue
+		}
+
+		key := repoBranch{repo: m.Repo.ID, headRef: git.EnsureRefPrefix(m.ChangesetSpec.Spec.HeadRef)}
+		if _, ok := seen[key]; ok {
+			return ErrDuplicateBranch{RepoName: string(m.Repo.Name), HeadRef: key.headRef}
+		}
+		seen[key] = struct{}{}
+	}
+	return nil
+}
+
 // ErrRepoNotSupported is thrown by the rewirer when it encounters a mapping
 // targetting a repo on a code host that's not supported by batches.
 type ErrRepoNotSupported struct {
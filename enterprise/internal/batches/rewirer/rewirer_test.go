@@ -275,6 +275,76 @@ func TestRewirer_Rewire(t *testing.T) {
 			}},
 			wantErr: &database.RepoNotFoundErr{ID: testRepoID},
 		},
+		{
+			name: "duplicate branches",
+			mappings: btypes.RewirerMappings{
+				{
+					ChangesetSpec: ct.BuildChangesetSpec(t, ct.TestSpecOpts{
+						Repo:      testRepoID,
+						HeadRef:   "refs/heads/my-branch",
+						Published: true,
+					}),
+					RepoID: testRepoID,
+					Repo:   testRepo,
+				},
+				{
+					ChangesetSpec: ct.BuildChangesetSpec(t, ct.TestSpecOpts{
+						Repo:      testRepoID,
+						HeadRef:   "my-branch",
+						Published: true,
+					}),
+					RepoID: testRepoID,
+					Repo:   testRepo,
+				},
+			},
+			wantErr: ErrDuplicateBranch{
+				RepoName: string(testRepo.Name),
+				HeadRef:  "refs/heads/my-branch",
+			},
+		},
+		{
+			name: "multiple branches in the same repository",
+			mappings: btypes.RewirerMappings{
+				{
+					ChangesetSpec: ct.BuildChangesetSpec(t, ct.TestSpecOpts{
+						ID:        testChangesetSpecID,
+						Repo:      testRepoID,
+						HeadRef:   "refs/heads/my-branch",
+						Published: true,
+					}),
+					RepoID: testRepoID,
+					Repo:   testRepo,
+				},
+				{
+					ChangesetSpec: ct.BuildChangesetSpec(t, ct.TestSpecOpts{
+						ID:        testChangesetSpecID + 1,
+						Repo:      testRepoID,
+						HeadRef:   "refs/heads/my-branch-go",
+						Published: true,
+					}),
+					RepoID: testRepoID,
+					Repo:   testRepo,
+				},
+			},
+			wantChangesets: []ct.ChangesetAssertions{
+				assertResetReconcilerState(ct.ChangesetAssertions{
+					Repo:               testRepoID,
+					CurrentSpec:        testChangesetSpecID,
+					PublicationState:   btypes.ChangesetPublicationStateUnpublished,
+					AttachedTo:         []int64{testBatchChangeID},
+					OwnedByBatchChange: testBatchChangeID,
+					DiffStat:           ct.TestChangsetSpecDiffStat,
+				}),
+				assertResetReconcilerState(ct.ChangesetAssertions{
+					Repo:               testRepoID,
+					CurrentSpec:        testChangesetSpecID + 1,
+					PublicationState:   btypes.ChangesetPublicationStateUnpublished,
+					AttachedTo:         []int64{testBatchChangeID},
+					OwnedByBatchChange: testBatchChangeID,
+					DiffStat:           ct.TestChangsetSpecDiffStat,
+				}),
+			},
+		},
 		// END NO CHANGESET
 		// CHANGESET SPEC AND CHANGESET
 		{
@@ -73,9 +73,13 @@ func (s *Service) CreateBatchSpec(ctx context.Context, opts CreateBatchSpecOpts)
 	}
 
 	// Check whether the current user has access to either one of the namespaces.
+	// The owner and the admins of an existing batch change can create specs
+	// for it without having access to its namespace.
 	err = s.CheckNamespaceAccess(ctx, opts.NamespaceUserID, opts.NamespaceOrgID)
 	if err != nil {
-		return nil, err
+		if s.checkBatchChangeAdminByName(ctx, spec.Spec.Name, opts.NamespaceUserID, opts.NamespaceOrgID) != nil {
+			return nil, err
+		}
 	}
 	spec.NamespaceOrgID = opts.NamespaceOrgID
 	spec.NamespaceUserID = opts.NamespaceUserID
@@ -260,8 +264,8 @@ func (s *Service) MoveBatchChange(ctx context.Context, opts MoveBatchChangeOpts)
 		return nil, err
 	}
 
-	// 🚨 SECURITY: Only the Author of the batch change can move it.
-	if err := backend.CheckSiteAdminOrSameUser(ctx, s.store.DB(), batchChange.InitialApplierID); err != nil {
+	// 🚨 SECURITY: Only the owner of the batch change can move it.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, s.store.DB(), batchChange.OwnerID); err != nil {
 		return nil, err
 	}
 	// Check if current user has access to target namespace if set.
@@ -305,7 +309,8 @@ func (s *Service) CloseBatchChange(ctx context.Context, id int64, closeChangeset
 		return batchChange, nil
 	}
 
-	if err := backend.CheckSiteAdminOrSameUser(ctx, s.store.DB(), batchChange.InitialApplierID); err != nil {
+	// 🚨 SECURITY: Only the owner and the admins of the batch change can close it.
+	if err := s.CheckBatchChangeAdmin(ctx, batchChange); err != nil {
 		return nil, err
 	}
 
@@ -351,7 +356,8 @@ func (s *Service) DeleteBatchChange(ctx context.Context, id int64) (err error) {
 		return err
 	}
 
-	if err := backend.CheckSiteAdminOrSameUser(ctx, s.store.DB(), batchChange.InitialApplierID); err != nil {
+	// 🚨 SECURITY: Only the owner of the batch change can delete it.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, s.store.DB(), batchChange.OwnerID); err != nil {
 		return err
 	}
 
@@ -393,7 +399,7 @@ func (s *Service) EnqueueChangesetSync(ctx context.Context, id int64) (err error
 	)
 
 	for _, c := range batchChanges {
-		err := backend.CheckSiteAdminOrSameUser(ctx, s.store.DB(), c.InitialApplierID)
+		err := s.CheckBatchChangeAdmin(ctx, c)
 		if err != nil {
 			authErr = err
 		} else {
@@ -448,7 +454,7 @@ func (s *Service) ReenqueueChangeset(ctx context.Context, id int64) (changeset *
 	)
 
 	for _, c := range attachedBatchChanges {
-		err := backend.CheckSiteAdminOrSameUser(ctx, s.store.DB(), c.InitialApplierID)
+		err := s.CheckBatchChangeAdmin(ctx, c)
 		if err != nil {
 			authErr = err
 		} else {
@@ -582,8 +588,8 @@ func (s *Service) CreateChangesetJobs(ctx context.Context, batchChangeID int64,
 		return bulkGroupID, errors.Wrap(err, "loading batch change")
 	}
 
-	// 🚨 SECURITY: Only the author of the batch change can create jobs.
-	if err := backend.CheckSiteAdminOrSameUser(ctx, s.store.DB(), batchChange.InitialApplierID); err != nil {
+	// 🚨 SECURITY: Only the owner and the admins of the batch change can create jobs.
+	if err := s.CheckBatchChangeAdmin(ctx, batchChange); err != nil {
 		return bulkGroupID, err
 	}
 
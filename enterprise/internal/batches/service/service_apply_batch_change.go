@@ -79,6 +79,14 @@ func (s *Service) ApplyBatchChange(
 		return nil, ErrMatchingBatchChangeExists
 	}
 
+	// 🚨 SECURITY: Only the owner and the admins of an existing batch change
+	// can apply a new batch spec to it.
+	if batchChange.ID != 0 {
+		if err := s.CheckBatchChangeAdmin(ctx, batchChange); err != nil {
+			return nil, err
+		}
+	}
+
 	if opts.EnsureBatchChangeID != 0 && batchChange.ID != opts.EnsureBatchChangeID {
 		return nil, ErrEnsureBatchChangeFailed
 	}
@@ -185,6 +193,9 @@ func (s *Service) ReconcileBatchChange(
 	batchChange.NamespaceUserID = batchSpec.NamespaceUserID
 	batchChange.Name = batchSpec.Spec.Name
 	a := actor.FromContext(ctx)
+	if batchChange.ID == 0 {
+		batchChange.OwnerID = a.UID
+	}
 	if batchChange.InitialApplierID == 0 {
 		batchChange.InitialApplierID = a.UID
 	}
@@ -61,6 +61,7 @@ func TestServiceApplyBatchChange(t *testing.T) {
 				InitialApplierID: admin.ID,
 				LastApplierID:    admin.ID,
 				LastAppliedAt:    now,
+				OwnerID:          admin.ID,
 				NamespaceUserID:  batchSpec.NamespaceUserID,
 				BatchSpecID:      batchSpec.ID,
 
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+// ErrBatchChangeAdminIsOwner is returned by AddBatchChangeAdmin when the
+// given user already owns the batch change.
+var ErrBatchChangeAdminIsOwner = errors.New("the owner of a batch change is always an admin")
+
+// CheckBatchChangeAdmin returns an error if the current user is NEITHER a site
+// admin, NOR the owner of the batch change, NOR one of its admins, either
+// directly or through membership in an admin org.
+//
+// Admins can apply, close and run bulk operations on a batch change. Only
+// the owner can transfer ownership, manage admins, move or delete it.
+func (s *Service) CheckBatchChangeAdmin(ctx context.Context, batchChange *btypes.BatchChange) error {
+	a := actor.FromContext(ctx)
+	if a.IsInternal() || (a.IsAuthenticated() && a.UID == batchChange.OwnerID) {
+		return nil
+	}
+
+	if a.IsAuthenticated() {
+		isAdmin, err := s.store.IsBatchChangeAdmin(ctx, batchChange.ID, a.UID)
+		if err != nil {
+			return err
+		}
+		if isAdmin {
+			return nil
+		}
+	}
+
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, s.store.DB()); err != nil {
+		return &backend.InsufficientAuthorizationError{
+			Message: fmt.Sprintf("must be authenticated as the owner or an admin of the batch change, or as a site admin (%s)", err.Error()),
+		}
+	}
+	return nil
+}
+
+// checkBatchChangeAdminByName returns an error if there is no batch change
+// with the given name in the given namespace, or if the current user is not
+// allowed to administer it.
+func (s *Service) checkBatchChangeAdminByName(ctx context.Context, name string, namespaceUserID, namespaceOrgID int32) error {
+	if namespaceUserID == 0 && namespaceOrgID == 0 {
+		return ErrNoNamespace
+	}
+
+	batchChange, err := s.store.GetBatchChange(ctx, store.GetBatchChangeOpts{
+		Name:            name,
+		NamespaceUserID: namespaceUserID,
+		NamespaceOrgID:  namespaceOrgID,
+	})
+	if err != nil {
+		return err
+	}
+	return s.CheckBatchChangeAdmin(ctx, batchChange)
+}
+
+// TransferBatchChangeOwnership makes the given user the owner of the batch
+// change. The previous owner loses their owner rights, unless they are also
+// an admin of the batch change.
+//
+// If the namespace of the batch change has been deleted, for example because
+// its previous owner left, the batch change is moved into the namespace of
+// the new owner. Otherwise it would stay hidden and couldn't be applied again.
+func (s *Service) TransferBatchChangeOwnership(ctx context.Context, batchChangeID int64, newOwnerID int32) (batchChange *btypes.BatchChange, err error) {
+	traceTitle := fmt.Sprintf("batchChange: %d, newOwner: %d", batchChangeID, newOwnerID)
+	tr, ctx := trace.New(ctx, "service.TransferBatchChangeOwnership", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	batchChange, err = tx.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: batchChangeID, IncludeDeletedNamespace: true})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only the owner of the batch change can transfer it.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, s.store.DB(), batchChange.OwnerID); err != nil {
+		return nil, err
+	}
+
+	// Make sure the new owner exists and hasn't been deleted.
+	if _, err := database.UsersWith(tx).GetByID(ctx, newOwnerID); err != nil {
+		return nil, err
+	}
+
+	namespaceDeleted, err := isNamespaceDeleted(ctx, tx, batchChange.NamespaceUserID, batchChange.NamespaceOrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if batchChange.OwnerID == newOwnerID && !namespaceDeleted {
+		return batchChange, nil
+	}
+
+	batchChange.OwnerID = newOwnerID
+	if namespaceDeleted {
+		batchChange.NamespaceUserID = newOwnerID
+		batchChange.NamespaceOrgID = 0
+	}
+	return batchChange, tx.UpdateBatchChange(ctx, batchChange)
+}
+
+// isNamespaceDeleted returns whether the given user or org namespace has been
+// deleted.
+func isNamespaceDeleted(ctx context.Context, tx *store.Store, namespaceUserID, namespaceOrgID int32) (bool, error) {
+	var err error
+	if namespaceOrgID != 0 {
+		_, err = database.OrgsWith(tx).GetByID(ctx, namespaceOrgID)
+	} else {
+		_, err = database.UsersWith(tx).GetByID(ctx, namespaceUserID)
+	}
+	if errcode.IsNotFound(err) {
+		return true, nil
+	}
+	return false, err
+}
+
+// AddBatchChangeAdmin grants the given user, or all members of the given org,
+// admin rights on the batch change. Exactly one of userID and orgID must be
+// non-zero. Adding an existing admin is a noop.
+func (s *Service) AddBatchChangeAdmin(ctx context.Context, batchChangeID int64, userID, orgID int32) (batchChange *btypes.BatchChange, err error) {
+	traceTitle := fmt.Sprintf("batchChange: %d, user: %d, org: %d", batchChangeID, userID, orgID)
+	tr, ctx := trace.New(ctx, "service.AddBatchChangeAdmin", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if (userID == 0) == (orgID == 0) {
+		return nil, errors.New("exactly one of user and org must be given")
+	}
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	batchChange, err = tx.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: batchChangeID})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only the owner of the batch change can manage its admins.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, s.store.DB(), batchChange.OwnerID); err != nil {
+		return nil, err
+	}
+
+	if userID != 0 {
+		if userID == batchChange.OwnerID {
+			return nil, ErrBatchChangeAdminIsOwner
+		}
+		if _, err := database.UsersWith(tx).GetByID(ctx, userID); err != nil {
+			return nil, err
+		}
+	} else {
+		if _, err := database.OrgsWith(tx).GetByID(ctx, orgID); err != nil {
+			return nil, err
+		}
+	}
+
+	admins, err := tx.ListBatchChangeAdmins(ctx, store.ListBatchChangeAdminsOpts{BatchChangeID: batchChange.ID})
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range admins {
+		if a.UserID == userID && a.OrgID == orgID {
+			return batchChange, nil
+		}
+	}
+
+	return batchChange, tx.CreateBatchChangeAdmin(ctx, &btypes.BatchChangeAdmin{
+		BatchChangeID: batchChange.ID,
+		UserID:        userID,
+		OrgID:         orgID,
+	})
+}
+
+// RemoveBatchChangeAdmin revokes the admin rights granted to the given user or
+// org on the batch change. Exactly one of userID and orgID must be non-zero.
+func (s *Service) RemoveBatchChangeAdmin(ctx context.Context, batchChangeID int64, userID, orgID int32) (batchChange *btypes.BatchChange, err error) {
+	traceTitle := fmt.Sprintf("batchChange: %d, user: %d, org: %d", batchChangeID, userID, orgID)
+	tr, ctx := trace.New(ctx, "service.RemoveBatchChangeAdmin", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if (userID == 0) == (orgID == 0) {
+		return nil, errors.New("exactly one of user and org must be given")
+	}
+
+	batchChange, err = s.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: batchChangeID})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only the owner of the batch change can manage its admins.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, s.store.DB(), batchChange.OwnerID); err != nil {
+		return nil, err
+	}
+
+	err = s.store.DeleteBatchChangeAdmin(ctx, store.DeleteBatchChangeAdminOpts{
+		BatchChangeID: batchChange.ID,
+		UserID:        userID,
+		OrgID:         orgID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return batchChange, nil
+}
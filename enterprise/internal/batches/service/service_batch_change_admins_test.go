@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestServiceBatchChangeAdmins(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := actor.WithInternalActor(context.Background())
+	db := dbtest.NewDB(t, "")
+
+	s := store.New(db, &observation.TestContext, nil)
+	svc := New(s)
+
+	owner := ct.CreateTestUser(t, db, false)
+	admin := ct.CreateTestUser(t, db, false)
+	member := ct.CreateTestUser(t, db, false)
+	outsider := ct.CreateTestUser(t, db, false)
+
+	orgID := ct.InsertTestOrg(t, db, "batch-change-admins")
+	if _, err := database.OrgMembers(db).Create(ctx, orgID, member.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	userCtx := func(userID int32) context.Context {
+		return actor.WithActor(context.Background(), actor.FromUser(userID))
+	}
+
+	assertAuthError := func(t *testing.T, err error) {
+		t.Helper()
+
+		if !errors.HasType(err, &backend.InsufficientAuthorizationError{}) {
+			t.Fatalf("wrong error: %s (%T)", err, err)
+		}
+	}
+
+	createBatchChange := func(t *testing.T) *btypes.BatchChange {
+		t.Helper()
+
+		spec := testBatchSpec(owner.ID)
+		if err := s.CreateBatchSpec(ctx, spec); err != nil {
+			t.Fatal(err)
+		}
+		batchChange := testBatchChange(owner.ID, spec)
+		if err := s.CreateBatchChange(ctx, batchChange); err != nil {
+			t.Fatal(err)
+		}
+		return batchChange
+	}
+
+	t.Run("AddBatchChangeAdmin", func(t *testing.T) {
+		batchChange := createBatchChange(t)
+
+		if _, err := svc.AddBatchChangeAdmin(userCtx(admin.ID), batchChange.ID, admin.ID, 0); err == nil {
+			t.Fatal("non-owner could add themselves as admin")
+		} else {
+			assertAuthError(t, err)
+		}
+
+		if _, err := svc.AddBatchChangeAdmin(userCtx(owner.ID), batchChange.ID, owner.ID, 0); err != ErrBatchChangeAdminIsOwner {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrBatchChangeAdminIsOwner, err)
+		}
+
+		for _, subject := range []struct{ userID, orgID int32 }{{userID: admin.ID}, {orgID: orgID}} {
+			// Adding an admin twice is a noop.
+			for i := 0; i < 2; i++ {
+				if _, err := svc.AddBatchChangeAdmin(userCtx(owner.ID), batchChange.ID, subject.userID, subject.orgID); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+
+		admins, err := s.ListBatchChangeAdmins(ctx, store.ListBatchChangeAdminsOpts{BatchChangeID: batchChange.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := len(admins), 2; have != want {
+			t.Fatalf("wrong number of admins. want=%d, have=%d", want, have)
+		}
+
+		t.Run("CheckBatchChangeAdmin", func(t *testing.T) {
+			for _, userID := range []int32{owner.ID, admin.ID, member.ID} {
+				if err := svc.CheckBatchChangeAdmin(userCtx(userID), batchChange); err != nil {
+					t.Fatalf("user %d: unexpected error: %s", userID, err)
+				}
+			}
+			assertAuthError(t, svc.CheckBatchChangeAdmin(userCtx(outsider.ID), batchChange))
+		})
+
+		t.Run("admins can close but not delete", func(t *testing.T) {
+			assertAuthError(t, svc.DeleteBatchChange(userCtx(admin.ID), batchChange.ID))
+
+			if _, err := svc.CloseBatchChange(userCtx(member.ID), batchChange.ID, false); err != nil {
+				t.Fatal(err)
+			}
+		})
+
+		t.Run("RemoveBatchChangeAdmin", func(t *testing.T) {
+			_, err := svc.RemoveBatchChangeAdmin(userCtx(admin.ID), batchChange.ID, 0, orgID)
+			assertAuthError(t, err)
+
+			if _, err := svc.RemoveBatchChangeAdmin(userCtx(owner.ID), batchChange.ID, 0, orgID); err != nil {
+				t.Fatal(err)
+			}
+			assertAuthError(t, svc.CheckBatchChangeAdmin(userCtx(member.ID), batchChange))
+
+			if _, err := svc.RemoveBatchChangeAdmin(userCtx(owner.ID), batchChange.ID, 0, orgID); err != store.ErrNoResults {
+				t.Fatalf("wrong error. want=%s, have=%v", store.ErrNoResults, err)
+			}
+		})
+	})
+
+	t.Run("ApplyBatchChange as admin", func(t *testing.T) {
+		spec := testBatchSpec(owner.ID)
+		if err := s.CreateBatchSpec(ctx, spec); err != nil {
+			t.Fatal(err)
+		}
+		batchChange := testBatchChange(owner.ID, spec)
+		batchChange.Name = "apply-as-admin"
+		if err := s.CreateBatchChange(ctx, batchChange); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := svc.AddBatchChangeAdmin(userCtx(owner.ID), batchChange.ID, admin.ID, 0); err != nil {
+			t.Fatal(err)
+		}
+
+		opts := CreateBatchSpecOpts{RawSpec: "name: apply-as-admin", NamespaceUserID: owner.ID}
+
+		// Users without access to the namespace can only create specs for
+		// batch changes they administer.
+		if _, err := svc.CreateBatchSpec(userCtx(outsider.ID), opts); err == nil {
+			t.Fatal("outsider could create batch spec in the namespace of the owner")
+		}
+		if _, err := svc.CreateBatchSpec(userCtx(admin.ID), CreateBatchSpecOpts{RawSpec: "name: not-administered", NamespaceUserID: owner.ID}); err == nil {
+			t.Fatal("admin could create batch spec for another batch change in the namespace of the owner")
+		}
+
+		newSpec, err := svc.CreateBatchSpec(userCtx(admin.ID), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		applied, err := svc.ApplyBatchChange(userCtx(admin.ID), ApplyBatchChangeOpts{BatchSpecRandID: newSpec.RandID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if applied.ID != batchChange.ID {
+			t.Fatalf("batch spec applied to wrong batch change. want=%d, have=%d", batchChange.ID, applied.ID)
+		}
+		if have, want := applied.BatchSpecID, newSpec.ID; have != want {
+			t.Fatalf("wrong batch spec. want=%d, have=%d", want, have)
+		}
+		if have, want := applied.LastApplierID, admin.ID; have != want {
+			t.Fatalf("wrong last applier. want=%d, have=%d", want, have)
+		}
+	})
+
+	t.Run("TransferBatchChangeOwnership", func(t *testing.T) {
+		batchChange := createBatchChange(t)
+
+		_, err := svc.TransferBatchChangeOwnership(userCtx(outsider.ID), batchChange.ID, outsider.ID)
+		assertAuthError(t, err)
+
+		transferred, err := svc.TransferBatchChangeOwnership(userCtx(owner.ID), batchChange.ID, outsider.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := transferred.OwnerID, outsider.ID; have != want {
+			t.Fatalf("wrong owner. want=%d, have=%d", want, have)
+		}
+		if have, want := transferred.InitialApplierID, owner.ID; have != want {
+			t.Fatalf("initial applier changed. want=%d, have=%d", want, have)
+		}
+
+		// The previous owner has lost their rights.
+		assertAuthError(t, svc.CheckBatchChangeAdmin(userCtx(owner.ID), transferred))
+		if err := svc.CheckBatchChangeAdmin(userCtx(outsider.ID), transferred); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := svc.TransferBatchChangeOwnership(userCtx(outsider.ID), batchChange.ID, 123456); err == nil {
+			t.Fatal("ownership transferred to non-existent user")
+		}
+
+		t.Run("from a deleted namespace", func(t *testing.T) {
+			departed := ct.CreateTestUser(t, db, false)
+			siteAdmin := ct.CreateTestUser(t, db, true)
+
+			spec := testBatchSpec(departed.ID)
+			if err := s.CreateBatchSpec(ctx, spec); err != nil {
+				t.Fatal(err)
+			}
+			batchChange := testBatchChange(departed.ID, spec)
+			batchChange.Name = "departed"
+			if err := s.CreateBatchChange(ctx, batchChange); err != nil {
+				t.Fatal(err)
+			}
+			if err := database.Users(db).Delete(ctx, departed.ID); err != nil {
+				t.Fatal(err)
+			}
+
+			transferred, err := svc.TransferBatchChangeOwnership(userCtx(siteAdmin.ID), batchChange.ID, outsider.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if transferred.OwnerID != outsider.ID || transferred.NamespaceUserID != outsider.ID {
+				t.Fatalf("batch change not moved to the new owner: owner=%d, namespace=%d", transferred.OwnerID, transferred.NamespaceUserID)
+			}
+
+			// The new owner can apply the batch change in their namespace.
+			newSpec, err := svc.CreateBatchSpec(userCtx(outsider.ID), CreateBatchSpecOpts{RawSpec: "name: departed", NamespaceUserID: outsider.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			applied, err := svc.ApplyBatchChange(userCtx(outsider.ID), ApplyBatchChangeOpts{BatchSpecRandID: newSpec.RandID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if applied.ID != batchChange.ID {
+				t.Fatalf("batch spec applied to wrong batch change. want=%d, have=%d", batchChange.ID, applied.ID)
+			}
+		})
+	})
+}
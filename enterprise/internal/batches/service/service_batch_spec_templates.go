@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+// ErrInvalidBatchSpecTemplate is returned by CreateBatchSpecTemplate when the
+// template fails validation. It wraps the validation error.
+type ErrInvalidBatchSpecTemplate struct {
+	SourceErr error
+}
+
+func (e ErrInvalidBatchSpecTemplate) Error() string {
+	return fmt.Sprintf("invalid batch spec template: %s", e.SourceErr)
+}
+
+func (e ErrInvalidBatchSpecTemplate) Unwrap() error { return e.SourceErr }
+
+// CheckBatchSpecTemplateAdmin returns an error if the current user may not
+// create or delete templates in the given namespace. Site-wide templates, with
+// a zero namespaceOrgID, can only be managed by site admins. Org templates can
+// be managed by members of the org and site admins.
+func (s *Service) CheckBatchSpecTemplateAdmin(ctx context.Context, namespaceOrgID int32) error {
+	if namespaceOrgID == 0 {
+		return backend.CheckCurrentUserIsSiteAdmin(ctx, s.store.DB())
+	}
+	return backend.CheckOrgAccessOrSiteAdmin(ctx, s.store.DB(), namespaceOrgID)
+}
+
+// CheckBatchSpecTemplateAccess returns an error if the current user may not
+// see and use the template. Site-wide templates can be used by all
+// authenticated users, org templates by members of the org and site admins.
+func (s *Service) CheckBatchSpecTemplateAccess(ctx context.Context, template *btypes.BatchSpecTemplate) error {
+	if template.NamespaceOrgID == 0 {
+		if a := actor.FromContext(ctx); !a.IsAuthenticated() && !a.IsInternal() {
+			return backend.ErrNotAuthenticated
+		}
+		return nil
+	}
+	return backend.CheckOrgAccessOrSiteAdmin(ctx, s.store.DB(), template.NamespaceOrgID)
+}
+
+// CreateBatchSpecTemplate validates and creates the given template, recording
+// the current user as its creator.
+func (s *Service) CreateBatchSpecTemplate(ctx context.Context, template *btypes.BatchSpecTemplate) (err error) {
+	tr, ctx := trace.New(ctx, "Service.CreateBatchSpecTemplate", fmt.Sprintf("name: %q, org: %d", template.Name, template.NamespaceOrgID))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	// 🚨 SECURITY: Only site admins can create site-wide templates, and only
+	// org members can create templates in an org.
+	if err := s.CheckBatchSpecTemplateAdmin(ctx, template.NamespaceOrgID); err != nil {
+		return err
+	}
+
+	if err := template.Validate(); err != nil {
+		return ErrInvalidBatchSpecTemplate{SourceErr: err}
+	}
+
+	template.CreatorUserID = actor.FromContext(ctx).UID
+	return s.store.CreateBatchSpecTemplate(ctx, template)
+}
+
+// DeleteBatchSpecTemplate deletes the template with the given ID.
+func (s *Service) DeleteBatchSpecTemplate(ctx context.Context, id int64) (err error) {
+	tr, ctx := trace.New(ctx, "Service.DeleteBatchSpecTemplate", fmt.Sprintf("ID: %d", id))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	template, err := s.store.GetBatchSpecTemplate(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// 🚨 SECURITY: Only users who can create a template can delete it.
+	if err := s.CheckBatchSpecTemplateAdmin(ctx, template.NamespaceOrgID); err != nil {
+		return err
+	}
+
+	return s.store.DeleteBatchSpecTemplate(ctx, id)
+}
+
+// InstantiateBatchSpecTemplateOpts are the options for
+// InstantiateBatchSpecTemplate.
+type InstantiateBatchSpecTemplateOpts struct {
+	TemplateID int64
+	Inputs     map[string]interface{}
+
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+}
+
+// InstantiateBatchSpecTemplate renders the template with the given inputs and
+// creates a BatchSpec from the result in the given namespace.
+func (s *Service) InstantiateBatchSpecTemplate(ctx context.Context, opts InstantiateBatchSpecTemplateOpts) (spec *btypes.BatchSpec, err error) {
+	tr, ctx := trace.New(ctx, "Service.InstantiateBatchSpecTemplate", fmt.Sprintf("template: %d", opts.TemplateID))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	template, err := s.store.GetBatchSpecTemplate(ctx, opts.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Check that the current user may use the template. Access to
+	// the namespace is checked by CreateBatchSpec.
+	if err := s.CheckBatchSpecTemplateAccess(ctx, template); err != nil {
+		return nil, err
+	}
+
+	rawSpec, err := template.Render(opts.Inputs)
+	if err != nil {
+		return nil, errors.Wrap(err, "rendering batch spec template")
+	}
+
+	return s.CreateBatchSpec(ctx, CreateBatchSpecOpts{
+		RawSpec:         rawSpec,
+		NamespaceUserID: opts.NamespaceUserID,
+		NamespaceOrgID:  opts.NamespaceOrgID,
+	})
+}
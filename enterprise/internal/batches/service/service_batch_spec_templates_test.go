@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+const testBatchSpecTemplateYAML = `name: bump-${{ inputs.package }}
+on:
+  - repositoriesMatchingQuery: ${{ inputs.package }} file:package.json
+steps:
+  - run: npm install ${{ inputs.package }}@latest
+    container: node:16
+changesetTemplate:
+  title: Bump ${{ inputs.package }}
+  body: Bump ${{ inputs.package }}
+  branch: bump-${{ inputs.package }}
+  commit:
+    message: Bump ${{ inputs.package }}
+  published: false
+`
+
+func TestServiceBatchSpecTemplates(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := actor.WithInternalActor(context.Background())
+	db := dbtest.NewDB(t, "")
+
+	s := store.New(db, &observation.TestContext, nil)
+	svc := New(s)
+
+	admin := ct.CreateTestUser(t, db, true)
+	member := ct.CreateTestUser(t, db, false)
+	outsider := ct.CreateTestUser(t, db, false)
+
+	orgID := ct.InsertTestOrg(t, db, "batch-spec-templates")
+	if _, err := database.OrgMembers(db).Create(ctx, orgID, member.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	userCtx := func(userID int32) context.Context {
+		return actor.WithActor(context.Background(), actor.FromUser(userID))
+	}
+
+	newTemplate := func(orgID int32) *btypes.BatchSpecTemplate {
+		return &btypes.BatchSpecTemplate{
+			Name:           "bump",
+			NamespaceOrgID: orgID,
+			Template:       testBatchSpecTemplateYAML,
+			Parameters: []btypes.BatchSpecTemplateParameter{
+				{Name: "package", Type: btypes.BatchSpecTemplateParameterTypeString, Required: true},
+			},
+		}
+	}
+
+	siteTemplate := newTemplate(0)
+	orgTemplate := newTemplate(orgID)
+
+	t.Run("CreateBatchSpecTemplate", func(t *testing.T) {
+		if err := svc.CreateBatchSpecTemplate(userCtx(member.ID), newTemplate(0)); err != backend.ErrMustBeSiteAdmin {
+			t.Fatalf("non-admin could create site template: %v", err)
+		}
+		if err := svc.CreateBatchSpecTemplate(userCtx(outsider.ID), newTemplate(orgID)); err != backend.ErrNotAnOrgMember {
+			t.Fatalf("non-member could create org template: %v", err)
+		}
+
+		invalid := newTemplate(0)
+		invalid.Parameters = nil
+		if err := svc.CreateBatchSpecTemplate(userCtx(admin.ID), invalid); !errors.HasType(err, ErrInvalidBatchSpecTemplate{}) {
+			t.Fatalf("invalid template created: %v", err)
+		}
+
+		if err := svc.CreateBatchSpecTemplate(userCtx(admin.ID), siteTemplate); err != nil {
+			t.Fatal(err)
+		}
+		if err := svc.CreateBatchSpecTemplate(userCtx(member.ID), orgTemplate); err != nil {
+			t.Fatal(err)
+		}
+		if have, want := orgTemplate.CreatorUserID, member.ID; have != want {
+			t.Fatalf("wrong creator. want=%d, have=%d", want, have)
+		}
+	})
+
+	t.Run("InstantiateBatchSpecTemplate", func(t *testing.T) {
+		spec, err := svc.InstantiateBatchSpecTemplate(userCtx(outsider.ID), InstantiateBatchSpecTemplateOpts{
+			TemplateID:      siteTemplate.ID,
+			Inputs:          map[string]interface{}{"package": "lodash"},
+			NamespaceUserID: outsider.ID,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if spec.ID == 0 {
+			t.Fatal("batch spec not created")
+		}
+		if have, want := spec.Spec.Name, "bump-lodash"; have != want {
+			t.Fatalf("wrong batch spec name. want=%q, have=%q", want, have)
+		}
+		if have, want := spec.NamespaceUserID, outsider.ID; have != want {
+			t.Fatalf("wrong namespace. want=%d, have=%d", want, have)
+		}
+
+		if _, err := svc.InstantiateBatchSpecTemplate(userCtx(outsider.ID), InstantiateBatchSpecTemplateOpts{
+			TemplateID:      orgTemplate.ID,
+			Inputs:          map[string]interface{}{"package": "lodash"},
+			NamespaceUserID: outsider.ID,
+		}); err != backend.ErrNotAnOrgMember {
+			t.Fatalf("non-member could use org template: %v", err)
+		}
+
+		if _, err := svc.InstantiateBatchSpecTemplate(userCtx(member.ID), InstantiateBatchSpecTemplateOpts{
+			TemplateID:      orgTemplate.ID,
+			Inputs:          map[string]interface{}{},
+			NamespaceUserID: member.ID,
+		}); err == nil {
+			t.Fatal("template instantiated without required input")
+		}
+	})
+
+	t.Run("DeleteBatchSpecTemplate", func(t *testing.T) {
+		if err := svc.DeleteBatchSpecTemplate(userCtx(member.ID), siteTemplate.ID); err != backend.ErrMustBeSiteAdmin {
+			t.Fatalf("non-admin could delete site template: %v", err)
+		}
+		if err := svc.DeleteBatchSpecTemplate(userCtx(member.ID), orgTemplate.ID); err != nil {
+			t.Fatal(err)
+		}
+		if err := svc.DeleteBatchSpecTemplate(userCtx(admin.ID), siteTemplate.ID); err != nil {
+			t.Fatal(err)
+		}
+		if err := svc.DeleteBatchSpecTemplate(userCtx(admin.ID), siteTemplate.ID); err != store.ErrNoResults {
+			t.Fatalf("wrong error. want=%s, have=%v", store.ErrNoResults, err)
+		}
+	})
+}
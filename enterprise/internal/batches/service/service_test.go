@@ -629,6 +629,7 @@ func TestService(t *testing.T) {
 				Name:             name,
 				LastApplierID:    authorID,
 				LastAppliedAt:    time.Now(),
+				OwnerID:          authorID,
 				BatchSpecID:      spec.ID,
 			}
 
@@ -1110,6 +1111,7 @@ func testBatchChange(user int32, spec *btypes.BatchSpec) *btypes.BatchChange {
 		BatchSpecID:      spec.ID,
 		LastApplierID:    user,
 		LastAppliedAt:    time.Now(),
+		OwnerID:          user,
 	}
 
 	return c
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+// RepoWorkspace is a repository revision in which the steps of a batch spec
+// are executed.
+type RepoWorkspace struct {
+	Repo *types.Repo
+	// Branch is the fully qualified name of the branch the steps are executed
+	// on, e.g. refs/heads/main.
+	Branch string
+	Commit api.CommitID
+}
+
+// WorkspaceResolver resolves the `on` entries of a batch spec into the
+// workspaces that the steps are executed in.
+type WorkspaceResolver interface {
+	ResolveWorkspacesForBatchSpec(ctx context.Context, spec *btypes.BatchSpecFields) ([]*RepoWorkspace, error)
+}
+
+// NewWorkspaceResolver returns a WorkspaceResolver that resolves repositories
+// with the given store and runs searches through the internal GraphQL API.
+//
+// Repository permissions are enforced for the actor in the context passed to
+// ResolveWorkspacesForBatchSpec.
+func NewWorkspaceResolver(s *store.Store) WorkspaceResolver {
+	return &workspaceResolver{
+		store:                s,
+		searchRepoIDs:        searchRepoIDs,
+		resolveDefaultBranch: resolveDefaultBranch,
+	}
+}
+
+type workspaceResolver struct {
+	store *store.Store
+
+	searchRepoIDs        func(ctx context.Context, query string) ([]api.RepoID, error)
+	resolveDefaultBranch func(ctx context.Context, repo api.RepoName) (string, api.CommitID, error)
+}
+
+// ErrNoWorkspaces is returned by ResolveWorkspacesForBatchSpec if none of the
+// `on` entries matched a repository the actor has access to.
+var ErrNoWorkspaces = errors.New("no repositories matched the on entries of the batch spec")
+
+func (r *workspaceResolver) ResolveWorkspacesForBatchSpec(ctx context.Context, spec *btypes.BatchSpecFields) ([]*RepoWorkspace, error) {
+	var (
+		ids   []api.RepoID
+		names []string
+	)
+	for _, on := range spec.On {
+		switch {
+		case on.Repository != "":
+			names = append(names, on.Repository)
+
+		case on.RepositoriesMatchingQuery != "":
+			found, err := r.searchRepoIDs(ctx, on.RepositoriesMatchingQuery)
+			if err != nil {
+				return nil, errors.Wrapf(err, "searching repositories matching %q", on.RepositoriesMatchingQuery)
+			}
+			ids = append(ids, found...)
+		}
+	}
+
+	// 🚨 SECURITY: database.Repos.List enforces the repository permissions of
+	// the actor in the context, which is the user that runs the batch spec.
+	repos := make(map[api.RepoID]*types.Repo)
+	for _, opts := range []database.ReposListOptions{{IDs: ids}, {Names: names}} {
+		if len(opts.IDs) == 0 && len(opts.Names) == 0 {
+			continue
+		}
+
+		rs, err := r.store.Repos().List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, repo := range rs {
+			repos[repo.ID] = repo
+		}
+	}
+
+	if len(repos) == 0 {
+		return nil, ErrNoWorkspaces
+	}
+
+	workspaces := make([]*RepoWorkspace, 0, len(repos))
+	for _, repo := range repos {
+		branch, commit, err := r.resolveDefaultBranch(ctx, repo.Name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolving default branch of %q", repo.Name)
+		}
+		workspaces = append(workspaces, &RepoWorkspace{Repo: repo, Branch: branch, Commit: commit})
+	}
+
+	sort.Slice(workspaces, func(i, j int) bool { return workspaces[i].Repo.Name < workspaces[j].Repo.Name })
+
+	return workspaces, nil
+}
+
+// resolveDefaultBranch returns the fully qualified name of the branch HEAD
+// points to in the given repository and the commit at its tip.
+func resolveDefaultBranch(ctx context.Context, repo api.RepoName) (string, api.CommitID, error) {
+	out, _, _, err := git.ExecSafe(ctx, repo, []string{"symbolic-ref", "HEAD"})
+	if err != nil {
+		return "", "", err
+	}
+
+	branch := strings.TrimSpace(string(out))
+	if branch == "" {
+		return "", "", errors.New("HEAD doesn't point to a branch")
+	}
+
+	commit, err := git.ResolveRevision(ctx, repo, branch, git.ResolveRevisionOptions{})
+	if err != nil {
+		return "", "", err
+	}
+
+	return branch, commit, nil
+}
+
+const searchRepoIDsQuery = `query BatchSpecWorkspaceSearch($query: String!) {
+	search(query: $query, version: V2) {
+		results {
+			results {
+				__typename
+				... on Repository {
+					id
+				}
+				... on FileMatch {
+					repository {
+						id
+					}
+				}
+				... on CommitSearchResult {
+					commit {
+						repository {
+							id
+						}
+					}
+				}
+			}
+		}
+	}
+}`
+
+type searchRepoIDsResponse struct {
+	Data struct {
+		Search struct {
+			Results struct {
+				Results []searchRepoIDsResult
+			}
+		}
+	}
+	Errors []interface{}
+}
+
+type searchRepoIDsResult struct {
+	ID         graphql.ID
+	Repository struct {
+		ID graphql.ID
+	}
+	Commit struct {
+		Repository struct {
+			ID graphql.ID
+		}
+	}
+}
+
+// searchRepoIDs returns the IDs of the repositories in which the given search
+// query has results, in the order they first appear.
+func searchRepoIDs(ctx context.Context, query string) ([]api.RepoID, error) {
+	var buf bytes.Buffer
+	err := json.NewEncoder(&buf).Encode(map[string]interface{}{
+		"query":     searchRepoIDsQuery,
+		"variables": map[string]string{"query": query},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Encode")
+	}
+
+	u, err := url.Parse(api.InternalClient.URL)
+	if err != nil {
+		return nil, errors.Wrap(err, "constructing frontend URL")
+	}
+	u.Path = "/.internal/graphql"
+	u.RawQuery = "BatchSpecWorkspaceSearch"
+
+	req, err := http.NewRequest("POST", u.String(), &buf)
+	if err != nil {
+		return nil, errors.Wrap(err, "Post")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpcli.InternalDoer.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "Post")
+	}
+	defer resp.Body.Close()
+
+	var res searchRepoIDsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, errors.Wrap(err, "Decode")
+	}
+	if len(res.Errors) > 0 {
+		return nil, errors.Errorf("graphql: errors: %v", res.Errors)
+	}
+
+	return repoIDsFromSearchResults(res.Data.Search.Results.Results)
+}
+
+func repoIDsFromSearchResults(results []searchRepoIDsResult) ([]api.RepoID, error) {
+	seen := make(map[api.RepoID]struct{})
+	ids := make([]api.RepoID, 0, len(results))
+	for _, r := range results {
+		var gqlID graphql.ID
+		switch {
+		case r.ID != "":
+			gqlID = r.ID
+		case r.Repository.ID != "":
+			gqlID = r.Repository.ID
+		case r.Commit.Repository.ID != "":
+			gqlID = r.Commit.Repository.ID
+		default:
+			continue
+		}
+
+		id, err := graphqlbackend.UnmarshalRepositoryID(gqlID)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestWorkspaceResolver(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := actor.WithInternalActor(context.Background())
+	db := dbtest.NewDB(t, "")
+	s := store.New(db, &observation.TestContext, nil)
+
+	rs, _ := ct.CreateTestRepos(t, ctx, db, 3)
+
+	resolver := &workspaceResolver{
+		store: s,
+		searchRepoIDs: func(ctx context.Context, query string) ([]api.RepoID, error) {
+			return []api.RepoID{rs[2].ID, rs[1].ID}, nil
+		},
+		resolveDefaultBranch: func(ctx context.Context, repo api.RepoName) (string, api.CommitID, error) {
+			return "refs/heads/main", api.CommitID("d34db33f-" + repo), nil
+		},
+	}
+
+	t.Run("repositories and queries", func(t *testing.T) {
+		spec := &btypes.BatchSpecFields{On: []btypes.BatchSpecOn{
+			{RepositoriesMatchingQuery: "lang:go"},
+			{Repository: string(rs[0].Name)},
+			{Repository: string(rs[1].Name)},
+		}}
+
+		workspaces, err := resolver.ResolveWorkspacesForBatchSpec(ctx, spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []*RepoWorkspace{
+			{Repo: rs[0], Branch: "refs/heads/main", Commit: api.CommitID("d34db33f-" + rs[0].Name)},
+			{Repo: rs[1], Branch: "refs/heads/main", Commit: api.CommitID("d34db33f-" + rs[1].Name)},
+			{Repo: rs[2], Branch: "refs/heads/main", Commit: api.CommitID("d34db33f-" + rs[2].Name)},
+		}
+		if diff := cmp.Diff(want, workspaces); diff != "" {
+			t.Fatalf("wrong workspaces (-want +have):\n%s", diff)
+		}
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		spec := &btypes.BatchSpecFields{On: []btypes.BatchSpecOn{
+			{Repository: "github.com/sourcegraph/does-not-exist"},
+		}}
+
+		if _, err := resolver.ResolveWorkspacesForBatchSpec(ctx, spec); err != ErrNoWorkspaces {
+			t.Fatalf("wrong error: %v", err)
+		}
+	})
+}
+
+func TestRepoIDsFromSearchResults(t *testing.T) {
+	var results []searchRepoIDsResult
+	for _, id := range []api.RepoID{1, 2} {
+		var r searchRepoIDsResult
+		r.ID = graphqlbackend.MarshalRepositoryID(id)
+		results = append(results, r)
+	}
+
+	var fileMatch searchRepoIDsResult
+	fileMatch.Repository.ID = graphqlbackend.MarshalRepositoryID(3)
+	var commit searchRepoIDsResult
+	commit.Commit.Repository.ID = graphqlbackend.MarshalRepositoryID(1)
+	results = append(results, fileMatch, commit, searchRepoIDsResult{})
+
+	have, err := repoIDsFromSearchResults(results)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff([]api.RepoID{1, 2, 3}, have); diff != "" {
+		t.Fatalf("wrong repo IDs (-want +have):\n%s", diff)
+	}
+}
@@ -0,0 +1,243 @@
+package sources
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	awscredentials "github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/cockroachdb/errors"
+	"golang.org/x/net/http2"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// ErrReopenNotSupported is returned by AWSCodeCommitSource.ReopenChangeset,
+// since closed pull requests can't be reopened on AWS CodeCommit.
+var ErrReopenNotSupported = errcode.MakeNonRetryable(errors.New("AWS CodeCommit doesn't support reopening pull requests"))
+
+// AWSCodeCommitSource is a ChangesetSource for AWS CodeCommit. The API is
+// always accessed with the access key of the external service, the
+// authenticator is only used to push commits over HTTPS.
+type AWSCodeCommitSource struct {
+	client *awscodecommit.Client
+	au     auth.Authenticator
+}
+
+// NewAWSCodeCommitSource returns a new AWSCodeCommitSource from the given
+// external service.
+func NewAWSCodeCommitSource(svc *types.ExternalService, cf *httpcli.Factory) (*AWSCodeCommitSource, error) {
+	var c schema.AWSCodeCommitConnection
+	if err := jsonc.Unmarshal(svc.Config, &c); err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	return newAWSCodeCommitSource(&c, cf)
+}
+
+func newAWSCodeCommitSource(c *schema.AWSCodeCommitConnection, cf *httpcli.Factory) (*AWSCodeCommitSource, error) {
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer(func(c *http.Client) error {
+		tr := awshttp.NewBuildableClient().GetTransport()
+		if err := http2.ConfigureTransport(tr); err != nil {
+			return err
+		}
+		c.Transport = tr
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// We don't load the default config here, so that the environment and the
+	// shared AWS config files can't override the external service config.
+	awsConfig := aws.Config{
+		Region: c.Region,
+		Credentials: awscredentials.StaticCredentialsProvider{
+			Value: aws.Credentials{
+				AccessKeyID:     c.AccessKeyID,
+				SecretAccessKey: c.SecretAccessKey,
+				Source:          "sourcegraph-site-configuration",
+			},
+		},
+		HTTPClient: cli,
+	}
+
+	var au auth.Authenticator
+	if c.GitCredentials.Username != "" {
+		au = &auth.BasicAuth{
+			Username: c.GitCredentials.Username,
+			Password: c.GitCredentials.Password,
+		}
+	}
+
+	return &AWSCodeCommitSource{
+		client: awscodecommit.NewClient(awsConfig),
+		au:     au,
+	}, nil
+}
+
+func (s AWSCodeCommitSource) GitserverPushConfig(ctx context.Context, store *database.ExternalServiceStore, repo *types.Repo) (*protocol.PushConfig, error) {
+	return gitserverPushConfig(ctx, store, repo, s.au)
+}
+
+func (s AWSCodeCommitSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	switch a.(type) {
+	case *auth.BasicAuth,
+		*auth.BasicAuthWithSSH:
+		break
+
+	default:
+		return nil, newUnsupportedAuthenticatorError("AWSCodeCommitSource", a)
+	}
+
+	sc := s
+	sc.au = a
+
+	return &sc, nil
+}
+
+// ValidateAuthenticator is a noop for AWS CodeCommit, since the Git
+// credentials can only be validated by pushing to a repository.
+func (s AWSCodeCommitSource) ValidateAuthenticator(ctx context.Context) error {
+	return nil
+}
+
+// CreateChangeset creates an AWS CodeCommit pull request. If it already
+// exists, *Changeset will be populated and the return value will be true.
+func (s *AWSCodeCommitSource) CreateChangeset(ctx context.Context, c *Changeset) (bool, error) {
+	repo := c.Repo.Metadata.(*awscodecommit.Repository)
+
+	// AWS CodeCommit happily creates multiple pull requests for the same
+	// branches, so we need to look for an existing one first.
+	exists := true
+	pr, err := s.client.GetOpenPullRequestByRefs(ctx, repo.Name, c.HeadRef, c.BaseRef)
+	if err != nil {
+		if !awscodecommit.IsPullRequestNotFound(err) {
+			return false, errors.Wrap(err, "retrieving an extant pull request")
+		}
+
+		exists = false
+		pr, err = s.client.CreatePullRequest(ctx, awscodecommit.CreatePullRequestInput{
+			RepositoryName:       repo.Name,
+			SourceReference:      c.HeadRef,
+			DestinationReference: c.BaseRef,
+			Title:                c.Title,
+			Description:          c.Body,
+		})
+		if err != nil {
+			return false, errors.Wrap(err, "creating the pull request")
+		}
+	}
+
+	if err := s.setPullRequest(ctx, c, pr); err != nil {
+		return exists, err
+	}
+	return exists, nil
+}
+
+// CloseChangeset closes the pull request on AWS CodeCommit.
+func (s *AWSCodeCommitSource) CloseChangeset(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*awscodecommit.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not an AWS CodeCommit pull request")
+	}
+
+	updated, err := s.client.UpdatePullRequestStatus(ctx, pr, awscodecommit.PullRequestStatusClosed)
+	if err != nil {
+		return errors.Wrap(err, "closing AWS CodeCommit pull request")
+	}
+
+	return s.setPullRequest(ctx, c, updated)
+}
+
+// LoadChangeset loads the given pull request from AWS CodeCommit and updates
+// it.
+func (s *AWSCodeCommitSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	pr, err := s.client.GetPullRequest(ctx, cs.ExternalID)
+	if err != nil {
+		if awscodecommit.IsPullRequestNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrapf(err, "retrieving pull request %s", cs.ExternalID)
+	}
+
+	return s.setPullRequest(ctx, cs, pr)
+}
+
+// ReopenChangeset always fails, since AWS CodeCommit doesn't allow reopening
+// closed pull requests.
+func (s *AWSCodeCommitSource) ReopenChangeset(ctx context.Context, c *Changeset) error {
+	return ErrReopenNotSupported
+}
+
+// UpdateChangeset updates the title and description of the pull request on
+// AWS CodeCommit to reflect the local state of the Changeset. The destination
+// branch of a pull request cannot be changed.
+func (s *AWSCodeCommitSource) UpdateChangeset(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*awscodecommit.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not an AWS CodeCommit pull request")
+	}
+
+	updated, err := s.client.UpdatePullRequest(ctx, pr, c.Title, c.Body)
+	if err != nil {
+		return errors.Wrap(err, "updating AWS CodeCommit pull request")
+	}
+
+	return s.setPullRequest(ctx, c, updated)
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s *AWSCodeCommitSource) CreateComment(ctx context.Context, c *Changeset, text string) error {
+	pr, ok := c.Changeset.Metadata.(*awscodecommit.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not an AWS CodeCommit pull request")
+	}
+
+	return s.client.CreatePullRequestComment(ctx, pr, text)
+}
+
+// MergeChangeset merges a Changeset on the code host, if in a mergeable state.
+// If squash is true, a squash merge will be performed, otherwise a merge
+// commit is created.
+func (s *AWSCodeCommitSource) MergeChangeset(ctx context.Context, c *Changeset, squash bool) error {
+	pr, ok := c.Changeset.Metadata.(*awscodecommit.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not an AWS CodeCommit pull request")
+	}
+
+	updated, err := s.client.MergePullRequest(ctx, pr, squash)
+	if err != nil {
+		if awscodecommit.IsNotMergeable(err) {
+			return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+		}
+		return errors.Wrap(err, "merging AWS CodeCommit pull request")
+	}
+
+	return s.setPullRequest(ctx, c, updated)
+}
+
+// setPullRequest loads the approvals of the given pull request, which aren't
+// returned by the other endpoints, and sets it as the metadata of c.
+func (s *AWSCodeCommitSource) setPullRequest(ctx context.Context, c *Changeset, pr *awscodecommit.PullRequest) error {
+	if err := s.client.LoadPullRequestApprovals(ctx, pr); err != nil {
+		return errors.Wrapf(err, "retrieving approvals for pull request %s", pr.ID)
+	}
+
+	if err := c.SetMetadata(pr); err != nil {
+		return errors.Wrapf(err, "setting changeset metadata for pull request %s", pr.ID)
+	}
+	return nil
+}
@@ -0,0 +1,368 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/go-cmp/cmp"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestAWSCodeCommitSource_CreateChangeset(t *testing.T) {
+	testCases := []struct {
+		name          string
+		exists        bool
+		wantApprovals []*awscodecommit.Approval
+	}{
+		{
+			name:          "success",
+			wantApprovals: []*awscodecommit.Approval{},
+		},
+		{
+			name:   "already exists",
+			exists: true,
+			wantApprovals: []*awscodecommit.Approval{
+				{UserARN: "arn:aws:iam::185007729374:user/alice", Approved: true},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		tc.name = "AWSCodeCommitSource_CreateChangeset_" + tc.name
+
+		t.Run(tc.name, func(t *testing.T) {
+			src, save := newAWSCodeCommitSourceForTest(t, tc.name)
+			defer save(t)
+
+			cs := &Changeset{
+				Title:     "This is a test PR",
+				Body:      "This is the description of the test PR",
+				HeadRef:   "refs/heads/test-pr",
+				BaseRef:   "refs/heads/master",
+				Repo:      testAWSCodeCommitRepo,
+				Changeset: &btypes.Changeset{},
+			}
+
+			exists, err := src.CreateChangeset(context.Background(), cs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have, want := exists, tc.exists; have != want {
+				t.Errorf("exists:\nhave: %t\nwant: %t", have, want)
+			}
+
+			pr, ok := cs.Changeset.Metadata.(*awscodecommit.PullRequest)
+			if !ok {
+				t.Fatal("Metadata does not contain PR")
+			}
+			if have, want := cs.ExternalID, "1"; have != want {
+				t.Errorf("wrong external ID. want=%q, have=%q", want, have)
+			}
+			if have, want := cs.ExternalServiceType, extsvc.TypeAWSCodeCommit; have != want {
+				t.Errorf("wrong external service type. want=%q, have=%q", want, have)
+			}
+			if have, want := cs.ExternalBranch, "refs/heads/test-pr"; have != want {
+				t.Errorf("wrong external branch. want=%q, have=%q", want, have)
+			}
+			if have, want := pr.Region, "us-west-1"; have != want {
+				t.Errorf("wrong region. want=%q, have=%q", want, have)
+			}
+			if diff := cmp.Diff(tc.wantApprovals, pr.Approvals); diff != "" {
+				t.Errorf("wrong approvals (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAWSCodeCommitSource_LoadChangeset(t *testing.T) {
+	testCases := []struct {
+		name       string
+		externalID string
+		err        string
+	}{
+		{
+			name:       "found",
+			externalID: "1",
+		},
+		{
+			name:       "not-found",
+			externalID: "100000",
+			err:        "Changeset with external ID 100000 not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		tc.name = "AWSCodeCommitSource_LoadChangeset_" + tc.name
+
+		t.Run(tc.name, func(t *testing.T) {
+			src, save := newAWSCodeCommitSourceForTest(t, tc.name)
+			defer save(t)
+
+			cs := &Changeset{
+				Repo:      testAWSCodeCommitRepo,
+				Changeset: &btypes.Changeset{ExternalID: tc.externalID},
+			}
+
+			if tc.err == "" {
+				tc.err = "<nil>"
+			}
+			err := src.LoadChangeset(context.Background(), cs)
+			if have, want := fmt.Sprint(err), tc.err; have != want {
+				t.Fatalf("error:\nhave: %q\nwant: %q", have, want)
+			}
+			if err != nil {
+				return
+			}
+
+			pr := cs.Changeset.Metadata.(*awscodecommit.PullRequest)
+			want := &awscodecommit.PullRequest{
+				ID:               "1",
+				Title:            "This is a test PR",
+				Description:      "This is the description of the test PR",
+				Status:           awscodecommit.PullRequestStatusOpen,
+				AuthorARN:        "arn:aws:iam::185007729374:user/batch-changes",
+				RevisionID:       "7f3d2b5a9c1e4f6d8b0a2c4e6f8a0b2c4d6e8f0a1b3c5d7e9f1a3b5c7d9e1f3a",
+				Region:           "us-west-1",
+				CreationDate:     pr.CreationDate,
+				LastActivityDate: pr.LastActivityDate,
+				Targets: []*awscodecommit.PullRequestTarget{{
+					RepositoryName:       "test",
+					SourceReference:      "refs/heads/test-pr",
+					DestinationReference: "refs/heads/master",
+					SourceCommit:         "9e1f3a5b7c9d1e3f5a7b9c1d3e5f7a9b1c3d5e7f",
+					DestinationCommit:    "2d4b8c1a7e3f5d9b0c6a8e2f4d6b8a0c2e4f6a8b",
+					MergeBase:            "2d4b8c1a7e3f5d9b0c6a8e2f4d6b8a0c2e4f6a8b",
+				}},
+				Approvals: []*awscodecommit.Approval{
+					{UserARN: "arn:aws:iam::185007729374:user/alice", Approved: true},
+				},
+			}
+			if diff := cmp.Diff(want, pr); diff != "" {
+				t.Fatalf("wrong pull request (-want +got):\n%s", diff)
+			}
+			if pr.CreationDate.IsZero() {
+				t.Fatal("creation date not set")
+			}
+		})
+	}
+}
+
+func TestAWSCodeCommitSource_UpdateChangeset(t *testing.T) {
+	name := "AWSCodeCommitSource_UpdateChangeset_success"
+	src, save := newAWSCodeCommitSourceForTest(t, name)
+	defer save(t)
+
+	cs := &Changeset{
+		Title:     "This is a new title",
+		Body:      "This is a new description",
+		Repo:      testAWSCodeCommitRepo,
+		Changeset: &btypes.Changeset{Metadata: testAWSCodeCommitPullRequest()},
+	}
+
+	if err := src.UpdateChangeset(context.Background(), cs); err != nil {
+		t.Fatal(err)
+	}
+
+	pr := cs.Changeset.Metadata.(*awscodecommit.PullRequest)
+	if have, want := pr.Title, cs.Title; have != want {
+		t.Errorf("wrong title. want=%q, have=%q", want, have)
+	}
+	if have, want := pr.Description, cs.Body; have != want {
+		t.Errorf("wrong description. want=%q, have=%q", want, have)
+	}
+}
+
+func TestAWSCodeCommitSource_CloseChangeset(t *testing.T) {
+	name := "AWSCodeCommitSource_CloseChangeset_success"
+	src, save := newAWSCodeCommitSourceForTest(t, name)
+	defer save(t)
+
+	cs := &Changeset{
+		Repo:      testAWSCodeCommitRepo,
+		Changeset: &btypes.Changeset{Metadata: testAWSCodeCommitPullRequest()},
+	}
+
+	if err := src.CloseChangeset(context.Background(), cs); err != nil {
+		t.Fatal(err)
+	}
+
+	pr := cs.Changeset.Metadata.(*awscodecommit.PullRequest)
+	if have, want := pr.Status, awscodecommit.PullRequestStatusClosed; have != want {
+		t.Errorf("wrong status. want=%q, have=%q", want, have)
+	}
+	if pr.IsMerged() {
+		t.Error("closed pull request is merged")
+	}
+}
+
+func TestAWSCodeCommitSource_ReopenChangeset(t *testing.T) {
+	src, err := newAWSCodeCommitSource(&schema.AWSCodeCommitConnection{Region: "us-west-1"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cs := &Changeset{
+		Repo:      testAWSCodeCommitRepo,
+		Changeset: &btypes.Changeset{Metadata: testAWSCodeCommitPullRequest()},
+	}
+	if have, want := src.ReopenChangeset(context.Background(), cs), ErrReopenNotSupported; have != want {
+		t.Fatalf("wrong error. want=%v, have=%v", want, have)
+	}
+}
+
+func TestAWSCodeCommitSource_MergeChangeset(t *testing.T) {
+	testCases := []struct {
+		name   string
+		squash bool
+		err    error
+	}{
+		{
+			name:   "success",
+			squash: true,
+		},
+		{
+			name: "not-mergeable",
+			err:  ChangesetNotMergeableError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		tc.name = "AWSCodeCommitSource_MergeChangeset_" + tc.name
+
+		t.Run(tc.name, func(t *testing.T) {
+			src, save := newAWSCodeCommitSourceForTest(t, tc.name)
+			defer save(t)
+
+			cs := &Changeset{
+				Repo:      testAWSCodeCommitRepo,
+				Changeset: &btypes.Changeset{Metadata: testAWSCodeCommitPullRequest()},
+			}
+
+			err := src.MergeChangeset(context.Background(), cs, tc.squash)
+			if tc.err != nil {
+				if !errors.HasType(err, tc.err) {
+					t.Fatalf("wrong error. want=%T, have=%v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			pr := cs.Changeset.Metadata.(*awscodecommit.PullRequest)
+			if !pr.IsMerged() {
+				t.Fatal("pull request is not merged")
+			}
+			if have, want := pr.Target().MergeCommitID, "5c7d9e1f3a5b7c9d1e3f5a7b9c1d3e5f7a9b1c3d"; have != want {
+				t.Errorf("wrong merge commit. want=%q, have=%q", want, have)
+			}
+		})
+	}
+}
+
+func TestAWSCodeCommitSource_CreateComment(t *testing.T) {
+	name := "AWSCodeCommitSource_CreateComment_success"
+	src, save := newAWSCodeCommitSourceForTest(t, name)
+	defer save(t)
+
+	cs := &Changeset{
+		Repo:      testAWSCodeCommitRepo,
+		Changeset: &btypes.Changeset{Metadata: testAWSCodeCommitPullRequest()},
+	}
+
+	if err := src.CreateComment(context.Background(), cs, "test-comment"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAWSCodeCommitSource_WithAuthenticator(t *testing.T) {
+	src, err := newAWSCodeCommitSource(&schema.AWSCodeCommitConnection{Region: "us-west-1"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("supported", func(t *testing.T) {
+		for name, tc := range map[string]auth.Authenticator{
+			"BasicAuth":        &auth.BasicAuth{},
+			"BasicAuthWithSSH": &auth.BasicAuthWithSSH{},
+		} {
+			t.Run(name, func(t *testing.T) {
+				if _, err := src.WithAuthenticator(tc); err != nil {
+					t.Errorf("unexpected non-nil error: %v", err)
+				}
+			})
+		}
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		for name, tc := range map[string]auth.Authenticator{
+			"nil":         nil,
+			"OAuthBearer": &auth.OAuthBearerToken{},
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := src.WithAuthenticator(tc)
+				if err == nil {
+					t.Error("unexpected nil error")
+				} else if !errors.HasType(err, UnsupportedAuthenticatorError{}) {
+					t.Errorf("unexpected error of type %T: %v", err, err)
+				}
+			})
+		}
+	})
+}
+
+var testAWSCodeCommitRepo = &types.Repo{
+	Metadata: &awscodecommit.Repository{
+		ARN:  "arn:aws:codecommit:us-west-1:185007729374:test",
+		ID:   "020a4751-0f46-4e19-82bf-07d0989b67dd",
+		Name: "test",
+	},
+}
+
+func testAWSCodeCommitPullRequest() *awscodecommit.PullRequest {
+	return &awscodecommit.PullRequest{
+		ID:          "1",
+		Title:       "This is a test PR",
+		Description: "This is the description of the test PR",
+		Status:      awscodecommit.PullRequestStatusOpen,
+		RevisionID:  "7f3d2b5a9c1e4f6d8b0a2c4e6f8a0b2c4d6e8f0a1b3c5d7e9f1a3b5c7d9e1f3a",
+		Targets: []*awscodecommit.PullRequestTarget{{
+			RepositoryName:       "test",
+			SourceReference:      "refs/heads/test-pr",
+			DestinationReference: "refs/heads/master",
+			SourceCommit:         "9e1f3a5b7c9d1e3f5a7b9c1d3e5f7a9b1c3d5e7f",
+			DestinationCommit:    "2d4b8c1a7e3f5d9b0c6a8e2f4d6b8a0c2e4f6a8b",
+		}},
+	}
+}
+
+func newAWSCodeCommitSourceForTest(t *testing.T, name string) (*AWSCodeCommitSource, func(testing.TB)) {
+	t.Helper()
+
+	cf, save := newClientFactory(t, name)
+
+	src, err := NewAWSCodeCommitSource(&types.ExternalService{
+		Kind: extsvc.KindAWSCodeCommit,
+		Config: marshalJSON(t, &schema.AWSCodeCommitConnection{
+			AccessKeyID:     "secret-access-key-id",
+			SecretAccessKey: "secret-secret-access-key",
+			Region:          "us-west-1",
+		}),
+	}, cf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return src, save
+}
@@ -37,6 +37,21 @@ type DraftChangesetSource interface {
 	UndraftChangeset(context.Context, *Changeset) error
 }
 
+// A ChecksRerunningChangesetSource can run the checks of changesets again.
+type ChecksRerunningChangesetSource interface {
+	// RerunChecks runs the given checks of the Changeset again on the code
+	// host. Only checks that are Rerunnable are passed in.
+	RerunChecks(ctx context.Context, c *Changeset, checks []btypes.ChangesetCheck) error
+}
+
+// A BranchUpdatingChangesetSource can bring the head branch of changesets up
+// to date with their base branch.
+type BranchUpdatingChangesetSource interface {
+	// UpdateBranch asks the code host to update the head branch of the
+	// Changeset with the latest changes of its base branch.
+	UpdateBranch(context.Context, *Changeset) error
+}
+
 // A ChangesetSource can load the latest state of a list of Changesets.
 type ChangesetSource interface {
 	// GitserverPushConfig returns an authenticated push config used for pushing
@@ -51,6 +51,8 @@ type FakeChangesetSource struct {
 	AuthenticatedUsernameCalled bool
 	ValidateAuthenticatorCalled bool
 	MergeChangesetCalled        bool
+	RerunChecksCalled           bool
+	UpdateBranchCalled          bool
 
 	// The Changeset.HeadRef to be expected in CreateChangeset/UpdateChangeset calls.
 	WantHeadRef string
@@ -91,12 +93,17 @@ type FakeChangesetSource struct {
 	// UndraftedChangesets contains the changesets that were passed to UndraftChangeset
 	UndraftedChangesets []*Changeset
 
+	// RerunnedChecks contains the checks that were passed to RerunChecks
+	RerunnedChecks []btypes.ChangesetCheck
+
 	// Username is the username returned by AuthenticatedUsername
 	Username string
 }
 
 var _ ChangesetSource = &FakeChangesetSource{}
 var _ DraftChangesetSource = &FakeChangesetSource{}
+var _ ChecksRerunningChangesetSource = &FakeChangesetSource{}
+var _ BranchUpdatingChangesetSource = &FakeChangesetSource{}
 
 func (s *FakeChangesetSource) CreateDraftChangeset(ctx context.Context, c *Changeset) (bool, error) {
 	s.CreateDraftChangesetCalled = true
@@ -283,3 +290,19 @@ func (s *FakeChangesetSource) MergeChangeset(ctx context.Context, c *Changeset,
 	s.MergeChangesetCalled = true
 	return s.Err
 }
+
+func (s *FakeChangesetSource) RerunChecks(ctx context.Context, c *Changeset, checks []btypes.ChangesetCheck) error {
+	s.RerunChecksCalled = true
+
+	if s.Err != nil {
+		return s.Err
+	}
+
+	s.RerunnedChecks = append(s.RerunnedChecks, checks...)
+	return nil
+}
+
+func (s *FakeChangesetSource) UpdateBranch(ctx context.Context, c *Changeset) error {
+	s.UpdateBranchCalled = true
+	return s.Err
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/cockroachdb/errors"
 
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
@@ -268,3 +269,41 @@ func (s GithubSource) MergeChangeset(ctx context.Context, c *Changeset, squash b
 
 	return c.Changeset.SetMetadata(pr)
 }
+
+var _ BranchUpdatingChangesetSource = GithubSource{}
+
+// UpdateBranch merges the latest changes of the base branch into the head
+// branch of the pull request.
+func (s GithubSource) UpdateBranch(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+
+	if err := s.client.UpdatePullRequestBranch(ctx, pr); err != nil {
+		return err
+	}
+
+	return c.Changeset.SetMetadata(pr)
+}
+
+var _ ChecksRerunningChangesetSource = GithubSource{}
+
+// RerunChecks requests the check suites of the given check runs again. GitHub
+// can't re-run commit statuses, since they are reported by external systems.
+func (s GithubSource) RerunChecks(ctx context.Context, c *Changeset, checks []btypes.ChangesetCheck) error {
+	repo := c.Repo.Metadata.(*github.Repository)
+
+	seen := make(map[string]struct{}, len(checks))
+	for _, check := range checks {
+		if _, ok := seen[check.RerunID]; ok {
+			continue
+		}
+		seen[check.RerunID] = struct{}{}
+
+		if err := s.client.RerequestCheckSuite(ctx, repo.ID, check.RerunID); err != nil {
+			return errors.Wrapf(err, "re-requesting check suite of %q", check.Name)
+		}
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/cockroachdb/errors"
 
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
@@ -447,3 +448,40 @@ func (s *GitLabSource) MergeChangeset(ctx context.Context, c *Changeset, squash
 
 	return c.Changeset.SetMetadata(updated)
 }
+
+var _ BranchUpdatingChangesetSource = &GitLabSource{}
+
+// UpdateBranch rebases the source branch of the merge request onto its target
+// branch. GitLab performs the rebase asynchronously, so the merge request is
+// only updated on the next sync.
+func (s *GitLabSource) UpdateBranch(ctx context.Context, c *Changeset) error {
+	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitLab merge request")
+	}
+	project := c.Repo.Metadata.(*gitlab.Project)
+
+	if err := s.client.RebaseMergeRequest(ctx, project, mr); err != nil {
+		return errors.Wrap(err, "rebasing GitLab merge request")
+	}
+
+	return nil
+}
+
+var _ ChecksRerunningChangesetSource = &GitLabSource{}
+
+// RerunChecks retries the failed jobs of the given pipelines.
+func (s *GitLabSource) RerunChecks(ctx context.Context, c *Changeset, checks []btypes.ChangesetCheck) error {
+	project := c.Repo.Metadata.(*gitlab.Project)
+
+	for _, check := range checks {
+		id, err := strconv.ParseInt(check.RerunID, 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "parsing pipeline ID of %q", check.Name)
+		}
+		if _, err := s.client.RetryPipeline(ctx, project, gitlab.ID(id)); err != nil {
+			return errors.Wrapf(err, "retrying %q", check.Name)
+		}
+	}
+	return nil
+}
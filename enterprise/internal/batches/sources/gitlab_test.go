@@ -690,6 +690,74 @@ func TestGitLabSource_ChangesetSource(t *testing.T) {
 			})
 		})
 	})
+
+	t.Run("RerunChecks", func(t *testing.T) {
+		checks := []btypes.ChangesetCheck{
+			{Name: "Pipeline #42", State: btypes.ChangesetCheckStateFailed, RerunID: "42"},
+		}
+
+		t.Run("error from RetryPipeline", func(t *testing.T) {
+			inner := errors.New("foo")
+
+			p := newGitLabChangesetSourceTestProvider(t)
+			p.mockRetryPipeline(42, inner)
+
+			have := p.source.RerunChecks(p.ctx, p.changeset, checks)
+			if !errors.Is(have, inner) {
+				t.Errorf("error does not include inner error: have %+v; want %+v", have, inner)
+			}
+		})
+
+		t.Run("invalid pipeline ID", func(t *testing.T) {
+			p := newGitLabChangesetSourceTestProvider(t)
+
+			if err := p.source.RerunChecks(p.ctx, p.changeset, []btypes.ChangesetCheck{{RerunID: "nope"}}); err == nil {
+				t.Error("unexpected nil error")
+			}
+		})
+
+		t.Run("success", func(t *testing.T) {
+			p := newGitLabChangesetSourceTestProvider(t)
+			p.mockRetryPipeline(42, nil)
+
+			if err := p.source.RerunChecks(p.ctx, p.changeset, checks); err != nil {
+				t.Errorf("unexpected error: %+v", err)
+			}
+		})
+	})
+
+	t.Run("UpdateBranch", func(t *testing.T) {
+		t.Run("invalid metadata", func(t *testing.T) {
+			p := newGitLabChangesetSourceTestProvider(t)
+
+			if err := p.source.UpdateBranch(p.ctx, p.changeset); err == nil {
+				t.Error("unexpected nil error")
+			}
+		})
+
+		t.Run("error from RebaseMergeRequest", func(t *testing.T) {
+			inner := errors.New("foo")
+
+			p := newGitLabChangesetSourceTestProvider(t)
+			p.changeset.Changeset.Metadata = p.mr
+			p.mockRebaseMergeRequest(p.mr, inner)
+
+			have := p.source.UpdateBranch(p.ctx, p.changeset)
+			if !errors.Is(have, inner) {
+				t.Errorf("error does not include inner error: have %+v; want %+v", have, inner)
+			}
+		})
+
+		t.Run("success", func(t *testing.T) {
+			p := newGitLabChangesetSourceTestProvider(t)
+			p.changeset.Changeset.Metadata = p.mr
+			p.mockRebaseMergeRequest(p.mr, nil)
+
+			if err := p.source.UpdateBranch(p.ctx, p.changeset); err != nil {
+				t.Errorf("unexpected error: %+v", err)
+			}
+		})
+	})
 }
 
 func TestReadNotesUntilSeen(t *testing.T) {
@@ -948,6 +1016,26 @@ func (p *gitLabChangesetSourceTestProvider) mockCreateComment(expected string, m
 	}
 }
 
+func (p *gitLabChangesetSourceTestProvider) mockRetryPipeline(expected gitlab.ID, err error) {
+	gitlab.MockRetryPipeline = func(client *gitlab.Client, ctx context.Context, project *gitlab.Project, id gitlab.ID) (*gitlab.Pipeline, error) {
+		p.testCommonParams(ctx, client, project)
+		if expected != id {
+			p.t.Errorf("invalid pipeline ID: have %d; want %d", id, expected)
+		}
+		return &gitlab.Pipeline{ID: id}, err
+	}
+}
+
+func (p *gitLabChangesetSourceTestProvider) mockRebaseMergeRequest(expected *gitlab.MergeRequest, err error) {
+	gitlab.MockRebaseMergeRequest = func(client *gitlab.Client, ctx context.Context, project *gitlab.Project, mr *gitlab.MergeRequest) error {
+		p.testCommonParams(ctx, client, project)
+		if expected != mr {
+			p.t.Errorf("unexpected MergeRequest: have %+v; want %+v", mr, expected)
+		}
+		return err
+	}
+}
+
 func (p *gitLabChangesetSourceTestProvider) unmock() {
 	gitlab.MockCreateMergeRequest = nil
 	gitlab.MockGetMergeRequest = nil
@@ -957,6 +1045,8 @@ func (p *gitLabChangesetSourceTestProvider) unmock() {
 	gitlab.MockGetOpenMergeRequestByRefs = nil
 	gitlab.MockUpdateMergeRequest = nil
 	gitlab.MockCreateMergeRequestNote = nil
+	gitlab.MockRetryPipeline = nil
+	gitlab.MockRebaseMergeRequest = nil
 }
 
 // panicDoer provides a httpcli.Doer implementation that panics if any attempt
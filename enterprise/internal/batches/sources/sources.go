@@ -191,20 +191,49 @@ func ToDraftChangesetSource(css ChangesetSource) (DraftChangesetSource, error) {
 	return draftCss, nil
 }
 
+// ToChecksRerunningChangesetSource returns a ChecksRerunningChangesetSource,
+// if the underlying source supports it. Returns an error if not.
+func ToChecksRerunningChangesetSource(css ChangesetSource) (ChecksRerunningChangesetSource, error) {
+	rerunCss, ok := css.(ChecksRerunningChangesetSource)
+	if !ok {
+		return nil, errors.New("changeset source doesn't support re-running checks")
+	}
+	return rerunCss, nil
+}
+
+// ToBranchUpdatingChangesetSource returns a BranchUpdatingChangesetSource, if
+// the underlying source supports it. Returns an error if not.
+func ToBranchUpdatingChangesetSource(css ChangesetSource) (BranchUpdatingChangesetSource, error) {
+	updateCss, ok := css.(BranchUpdatingChangesetSource)
+	if !ok {
+		return nil, errors.New("changeset source doesn't support updating branches")
+	}
+	return updateCss, nil
+}
+
 // WithAuthenticatorForUser authenticates the given ChangesetSource with a credential
 // usable by the given user with userID. User credentials are preferred, with a
 // fallback to site credentials. If none of these exist, ErrMissingCredentials
 // is returned.
 func WithAuthenticatorForUser(ctx context.Context, tx SourcerStore, css ChangesetSource, userID int32, repo *types.Repo) (ChangesetSource, error) {
-	cred, err := loadUserCredential(ctx, tx, userID, repo)
-	if err != nil {
-		return nil, errors.Wrap(err, "loading user credential")
-	}
-	if cred != nil {
-		return css.WithAuthenticator(cred)
+	return WithAuthenticatorForUsers(ctx, tx, css, []int32{userID}, repo)
+}
+
+// WithAuthenticatorForUsers authenticates the given ChangesetSource with the
+// credential of the first of the given users that has one for the code host of
+// the repo. If none of them has one, the site credential is used.
+func WithAuthenticatorForUsers(ctx context.Context, tx SourcerStore, css ChangesetSource, userIDs []int32, repo *types.Repo) (ChangesetSource, error) {
+	for _, userID := range userIDs {
+		cred, err := loadUserCredential(ctx, tx, userID, repo)
+		if err != nil {
+			return nil, errors.Wrap(err, "loading user credential")
+		}
+		if cred != nil {
+			return css.WithAuthenticator(cred)
+		}
 	}
 
-	cred, err = loadSiteCredential(ctx, tx, repo)
+	cred, err := loadSiteCredential(ctx, tx, repo)
 	if err != nil {
 		return nil, errors.Wrap(err, "loading site credential")
 	}
@@ -265,6 +294,10 @@ func loadExternalService(ctx context.Context, s *database.ExternalServiceStore,
 			if cfg.Token != "" {
 				return e, nil
 			}
+		case *schema.AWSCodeCommitConnection:
+			if cfg.AccessKeyID != "" {
+				return e, nil
+			}
 		}
 	}
 
@@ -282,6 +315,8 @@ func buildChangesetSource(store SourcerStore, cf *httpcli.Factory, externalServi
 		return NewGitLabSource(externalService, cf)
 	case extsvc.KindBitbucketServer:
 		return NewBitbucketServerSource(externalService, cf)
+	case extsvc.KindAWSCodeCommit:
+		return NewAWSCodeCommitSource(externalService, cf)
 	default:
 		return nil, errors.Errorf("unsupported external service type %q", extsvc.KindToType(externalService.Kind))
 	}
@@ -334,6 +369,9 @@ func setOAuthTokenAuth(u *vcs.URL, extSvcType, token string) error {
 	case extsvc.TypeBitbucketServer:
 		return errors.New("require username/token to push commits to BitbucketServer")
 
+	case extsvc.TypeAWSCodeCommit:
+		return errors.New("require Git credentials to push commits to AWS CodeCommit")
+
 	default:
 		panic(fmt.Sprintf("setOAuthTokenAuth: invalid external service type %q", extSvcType))
 	}
@@ -347,7 +385,7 @@ func setBasicAuth(u *vcs.URL, extSvcType, username, password string) error {
 	case extsvc.TypeGitHub, extsvc.TypeGitLab:
 		return errors.New("need token to push commits to " + extSvcType)
 
-	case extsvc.TypeBitbucketServer:
+	case extsvc.TypeBitbucketServer, extsvc.TypeAWSCodeCommit:
 		u.User = url.UserPassword(username, password)
 
 	default:
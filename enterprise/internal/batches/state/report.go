@@ -0,0 +1,374 @@
+package state
+
+import (
+	"encoding/csv"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// ChangesetLifecycle describes when a changeset was opened and when it was
+// merged or closed, as derived from its history.
+type ChangesetLifecycle struct {
+	OpenedAt time.Time
+	// MergedAt is the zero value if the changeset hasn't been merged.
+	MergedAt time.Time
+	// ClosedAt is the zero value if the changeset isn't closed.
+	ClosedAt time.Time
+	// State is the latest external state in the history of the changeset.
+	State btypes.ChangesetExternalState
+}
+
+// ComputeLifecycle calculates the ChangesetLifecycle for the given Changeset
+// and its ChangesetEvents.
+// The ChangesetEvents MUST be sorted by their Timestamp.
+func ComputeLifecycle(ch *btypes.Changeset, ce ChangesetEvents) (ChangesetLifecycle, error) {
+	history, err := computeHistory(ch, ce)
+	if err != nil {
+		return ChangesetLifecycle{}, err
+	}
+
+	l := ChangesetLifecycle{OpenedAt: history[0].t}
+	for _, s := range history {
+		// The history also contains entries for changes of the review state,
+		// so we only look at transitions of the external state.
+		if s.externalState == l.State {
+			continue
+		}
+		switch s.externalState {
+		case btypes.ChangesetExternalStateMerged:
+			l.MergedAt = s.t
+		case btypes.ChangesetExternalStateClosed:
+			l.ClosedAt = s.t
+		}
+		l.State = s.externalState
+	}
+	if l.State != btypes.ChangesetExternalStateClosed {
+		// The changeset has been reopened since.
+		l.ClosedAt = time.Time{}
+	}
+
+	return l, nil
+}
+
+// TimeToMerge returns the duration between opening and merging the changeset.
+// The second return value is false if the changeset hasn't been merged.
+func (l ChangesetLifecycle) TimeToMerge() (time.Duration, bool) {
+	if l.MergedAt.IsZero() {
+		return 0, false
+	}
+	return l.MergedAt.Sub(l.OpenedAt), true
+}
+
+// OpenFor returns for how long the changeset has been open or in draft at the
+// given time. It returns 0 if the changeset is merged or closed.
+func (l ChangesetLifecycle) OpenFor(now time.Time) time.Duration {
+	if l.State != btypes.ChangesetExternalStateOpen && l.State != btypes.ChangesetExternalStateDraft {
+		return 0
+	}
+	return now.Sub(l.OpenedAt)
+}
+
+// ReportRow is a single changeset of a batch change in a Report. Changesets
+// that are attached to multiple batch changes have one row per batch change.
+type ReportRow struct {
+	BatchChange *btypes.BatchChange
+	// Namespace is the name of the user or organization that owns the batch
+	// change.
+	Namespace string
+	Changeset *btypes.Changeset
+	Repo      *types.Repo
+	Lifecycle ChangesetLifecycle
+}
+
+// Report aggregates the lifecycles of changesets across batch changes.
+type Report struct {
+	Rows []*ReportRow
+
+	now        time.Time
+	stuckAfter time.Duration
+}
+
+// NewReport returns a Report for the given rows, computed at now. Changesets
+// that are still open longer than stuckAfter are considered stuck.
+func NewReport(now time.Time, stuckAfter time.Duration, rows []*ReportRow) *Report {
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.BatchChange.Name != b.BatchChange.Name {
+			return a.BatchChange.Name < b.BatchChange.Name
+		}
+		if a.Repo.Name != b.Repo.Name {
+			return a.Repo.Name < b.Repo.Name
+		}
+		return a.Changeset.ID < b.Changeset.ID
+	})
+
+	return &Report{Rows: rows, now: now, stuckAfter: stuckAfter}
+}
+
+// TimeToMergeBucket is a bucket of a TimeToMergeDistribution.
+type TimeToMergeBucket struct {
+	// UpperBound is the exclusive upper bound of the bucket. It is 0 for the
+	// last bucket, which has no upper bound.
+	UpperBound time.Duration
+	Count      int32
+}
+
+// timeToMergeBucketBounds are the upper bounds of the buckets of a
+// TimeToMergeDistribution.
+var timeToMergeBucketBounds = []time.Duration{
+	24 * time.Hour,
+	3 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+	14 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+	0,
+}
+
+// TimeToMergeDistribution describes how long it took to merge changesets.
+type TimeToMergeDistribution struct {
+	Merged  int32
+	Mean    time.Duration
+	Median  time.Duration
+	P90     time.Duration
+	Buckets []TimeToMergeBucket
+}
+
+// TimeToMerge computes the TimeToMergeDistribution of the merged changesets
+// in the report. Each changeset is only taken into account once.
+func (r *Report) TimeToMerge() TimeToMergeDistribution {
+	dist := TimeToMergeDistribution{Buckets: make([]TimeToMergeBucket, len(timeToMergeBucketBounds))}
+	for i, b := range timeToMergeBucketBounds {
+		dist.Buckets[i].UpperBound = b
+	}
+
+	var durations []time.Duration
+	r.forEachChangeset(func(row *ReportRow) {
+		if d, ok := row.Lifecycle.TimeToMerge(); ok {
+			durations = append(durations, d)
+		}
+	})
+	if len(durations) == 0 {
+		return dist
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	var sum time.Duration
+	for _, d := range durations {
+		sum += d
+		for i, b := range timeToMergeBucketBounds {
+			if b == 0 || d < b {
+				dist.Buckets[i].Count++
+				break
+			}
+		}
+	}
+
+	dist.Merged = int32(len(durations))
+	dist.Mean = sum / time.Duration(len(durations))
+	dist.Median = percentile(durations, 0.5)
+	dist.P90 = percentile(durations, 0.9)
+
+	return dist
+}
+
+// percentile returns the p-th percentile of the sorted, non-empty durations
+// using the nearest-rank method.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// ReportGroup counts the changesets of a Report by their state.
+type ReportGroup struct {
+	Name   string
+	Total  int32
+	Open   int32
+	Draft  int32
+	Merged int32
+	Closed int32
+}
+
+func (g *ReportGroup) add(l ChangesetLifecycle) {
+	g.Total++
+	switch l.State {
+	case btypes.ChangesetExternalStateOpen:
+		g.Open++
+	case btypes.ChangesetExternalStateDraft:
+		g.Draft++
+	case btypes.ChangesetExternalStateMerged:
+		g.Merged++
+	case btypes.ChangesetExternalStateClosed:
+		g.Closed++
+	}
+}
+
+// ByCodeHost groups the changesets in the report by the URL of the code host
+// their repository lives on. Each changeset is only counted once.
+func (r *Report) ByCodeHost() []*ReportGroup {
+	var groups groupSet
+	r.forEachChangeset(func(row *ReportRow) {
+		groups.get(row.Repo.ExternalRepo.ServiceID).add(row.Lifecycle)
+	})
+	return groups.sorted()
+}
+
+// ByNamespace groups the changesets in the report by the namespace of their
+// batch changes. A changeset is counted once for every namespace it appears in.
+func (r *Report) ByNamespace() []*ReportGroup {
+	type key struct {
+		namespace   string
+		changesetID int64
+	}
+	seen := make(map[key]struct{})
+
+	var groups groupSet
+	for _, row := range r.Rows {
+		k := key{namespace: row.Namespace, changesetID: row.Changeset.ID}
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		groups.get(row.Namespace).add(row.Lifecycle)
+	}
+	return groups.sorted()
+}
+
+// Stuck returns the rows of changesets that have been open for longer than the
+// deadline of the report, longest open first.
+func (r *Report) Stuck() []*ReportRow {
+	var stuck []*ReportRow
+	for _, row := range r.Rows {
+		if r.isStuck(row) {
+			stuck = append(stuck, row)
+		}
+	}
+	sort.SliceStable(stuck, func(i, j int) bool {
+		return stuck[i].Lifecycle.OpenedAt.Before(stuck[j].Lifecycle.OpenedAt)
+	})
+	return stuck
+}
+
+func (r *Report) isStuck(row *ReportRow) bool {
+	return row.Lifecycle.OpenFor(r.now) > r.stuckAfter
+}
+
+var reportCSVHeader = []string{
+	"namespace",
+	"batch_change",
+	"repository",
+	"code_host",
+	"changeset_id",
+	"external_id",
+	"url",
+	"state",
+	"opened_at",
+	"merged_at",
+	"closed_at",
+	"time_to_merge_seconds",
+	"open_for_seconds",
+	"stuck",
+}
+
+// WriteCSV writes all rows of the report as CSV to w.
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(reportCSVHeader); err != nil {
+		return err
+	}
+
+	for _, row := range r.Rows {
+		url, _ := row.Changeset.URL()
+
+		var timeToMerge string
+		if d, ok := row.Lifecycle.TimeToMerge(); ok {
+			timeToMerge = formatSeconds(d)
+		}
+		var openFor string
+		if d := row.Lifecycle.OpenFor(r.now); d > 0 {
+			openFor = formatSeconds(d)
+		}
+
+		record := []string{
+			row.Namespace,
+			row.BatchChange.Name,
+			string(row.Repo.Name),
+			row.Repo.ExternalRepo.ServiceID,
+			strconv.FormatInt(row.Changeset.ID, 10),
+			row.Changeset.ExternalID,
+			url,
+			string(row.Lifecycle.State),
+			formatTime(row.Lifecycle.OpenedAt),
+			formatTime(row.Lifecycle.MergedAt),
+			formatTime(row.Lifecycle.ClosedAt),
+			timeToMerge,
+			openFor,
+			strconv.FormatBool(r.isStuck(row)),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// forEachChangeset calls fn for the first row of every distinct changeset in
+// the report.
+func (r *Report) forEachChangeset(fn func(*ReportRow)) {
+	seen := make(map[int64]struct{}, len(r.Rows))
+	for _, row := range r.Rows {
+		if _, ok := seen[row.Changeset.ID]; ok {
+			continue
+		}
+		seen[row.Changeset.ID] = struct{}{}
+		fn(row)
+	}
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatSeconds(d time.Duration) string {
+	return strconv.FormatInt(int64(d/time.Second), 10)
+}
+
+// groupSet is a set of ReportGroups indexed by their name.
+type groupSet map[string]*ReportGroup
+
+func (s *groupSet) get(name string) *ReportGroup {
+	if *s == nil {
+		*s = make(groupSet)
+	}
+	g, ok := (*s)[name]
+	if !ok {
+		g = &ReportGroup{Name: name}
+		(*s)[name] = g
+	}
+	return g
+}
+
+func (s groupSet) sorted() []*ReportGroup {
+	groups := make([]*ReportGroup, 0, len(s))
+	for _, g := range s {
+		groups = append(groups, g)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups
+}
@@ -0,0 +1,202 @@
+package state
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestComputeLifecycle(t *testing.T) {
+	t.Parallel()
+
+	now := timeutil.Now()
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+
+	tests := []struct {
+		name      string
+		changeset *btypes.Changeset
+		events    []*btypes.ChangesetEvent
+		want      ChangesetLifecycle
+	}{
+		{
+			name:      "open",
+			changeset: ghChangeset(1, daysAgo(3)),
+			want: ChangesetLifecycle{
+				OpenedAt: daysAgo(3),
+				State:    btypes.ChangesetExternalStateOpen,
+			},
+		},
+		{
+			name:      "merged",
+			changeset: ghChangeset(1, daysAgo(3)),
+			events: []*btypes.ChangesetEvent{
+				ghReview(1, daysAgo(2), "alice", "APPROVED"),
+				event(t, daysAgo(1), btypes.ChangesetEventKindGitHubMerged, 1),
+			},
+			want: ChangesetLifecycle{
+				OpenedAt: daysAgo(3),
+				MergedAt: daysAgo(1),
+				State:    btypes.ChangesetExternalStateMerged,
+			},
+		},
+		{
+			name:      "closed",
+			changeset: ghChangeset(1, daysAgo(3)),
+			events: []*btypes.ChangesetEvent{
+				event(t, daysAgo(2), btypes.ChangesetEventKindGitHubClosed, 1),
+			},
+			want: ChangesetLifecycle{
+				OpenedAt: daysAgo(3),
+				ClosedAt: daysAgo(2),
+				State:    btypes.ChangesetExternalStateClosed,
+			},
+		},
+		{
+			name:      "closed, reopened and merged",
+			changeset: ghChangeset(1, daysAgo(4)),
+			events: []*btypes.ChangesetEvent{
+				event(t, daysAgo(3), btypes.ChangesetEventKindGitHubClosed, 1),
+				event(t, daysAgo(2), btypes.ChangesetEventKindGitHubReopened, 1),
+				event(t, daysAgo(1), btypes.ChangesetEventKindGitHubMerged, 1),
+			},
+			want: ChangesetLifecycle{
+				OpenedAt: daysAgo(4),
+				MergedAt: daysAgo(1),
+				State:    btypes.ChangesetExternalStateMerged,
+			},
+		},
+		{
+			name:      "deleted",
+			changeset: setExternalDeletedAt(ghChangeset(1, daysAgo(3)), daysAgo(1)),
+			want: ChangesetLifecycle{
+				OpenedAt: daysAgo(3),
+				ClosedAt: daysAgo(1),
+				State:    btypes.ChangesetExternalStateClosed,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			have, err := ComputeLifecycle(tc.changeset, tc.events)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf("wrong lifecycle (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReport(t *testing.T) {
+	t.Parallel()
+
+	now := timeutil.Now()
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+
+	github := &types.Repo{Name: "github.com/sourcegraph/a", ExternalRepo: api.ExternalRepoSpec{ServiceID: "https://github.com/"}}
+	gitlab := &types.Repo{Name: "gitlab.com/sourcegraph/b", ExternalRepo: api.ExternalRepoSpec{ServiceID: "https://gitlab.com/"}}
+
+	bc1 := &btypes.BatchChange{ID: 1, Name: "bc-1"}
+	bc2 := &btypes.BatchChange{ID: 2, Name: "bc-2"}
+
+	merged := func(opened, merged time.Time) ChangesetLifecycle {
+		return ChangesetLifecycle{OpenedAt: opened, MergedAt: merged, State: btypes.ChangesetExternalStateMerged}
+	}
+	open := func(opened time.Time) ChangesetLifecycle {
+		return ChangesetLifecycle{OpenedAt: opened, State: btypes.ChangesetExternalStateOpen}
+	}
+
+	rows := []*ReportRow{
+		{BatchChange: bc2, Namespace: "sourcegraph", Changeset: &btypes.Changeset{ID: 4}, Repo: gitlab, Lifecycle: open(daysAgo(2))},
+		{BatchChange: bc1, Namespace: "alice", Changeset: &btypes.Changeset{ID: 1}, Repo: github, Lifecycle: merged(daysAgo(10), daysAgo(9))},
+		{BatchChange: bc1, Namespace: "alice", Changeset: &btypes.Changeset{ID: 2}, Repo: github, Lifecycle: merged(daysAgo(10), daysAgo(5))},
+		{BatchChange: bc1, Namespace: "alice", Changeset: &btypes.Changeset{ID: 3}, Repo: gitlab, Lifecycle: open(daysAgo(40))},
+		// Changeset 3 is also tracked by the second batch change.
+		{BatchChange: bc2, Namespace: "sourcegraph", Changeset: &btypes.Changeset{ID: 3}, Repo: gitlab, Lifecycle: open(daysAgo(40))},
+	}
+
+	report := NewReport(now, 30*24*time.Hour, rows)
+
+	t.Run("TimeToMerge", func(t *testing.T) {
+		have := report.TimeToMerge()
+		want := TimeToMergeDistribution{
+			Merged: 2,
+			Mean:   3 * 24 * time.Hour,
+			Median: 24 * time.Hour,
+			P90:    5 * 24 * time.Hour,
+			Buckets: []TimeToMergeBucket{
+				{UpperBound: 24 * time.Hour},
+				{UpperBound: 3 * 24 * time.Hour, Count: 1},
+				{UpperBound: 7 * 24 * time.Hour, Count: 1},
+				{UpperBound: 14 * 24 * time.Hour},
+				{UpperBound: 30 * 24 * time.Hour},
+				{UpperBound: 0},
+			},
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatalf("wrong distribution (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("ByCodeHost", func(t *testing.T) {
+		have := report.ByCodeHost()
+		want := []*ReportGroup{
+			{Name: "https://github.com/", Total: 2, Merged: 2},
+			{Name: "https://gitlab.com/", Total: 2, Open: 2},
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatalf("wrong groups (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("ByNamespace", func(t *testing.T) {
+		have := report.ByNamespace()
+		want := []*ReportGroup{
+			{Name: "alice", Total: 3, Open: 1, Merged: 2},
+			{Name: "sourcegraph", Total: 2, Open: 2},
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatalf("wrong groups (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Stuck", func(t *testing.T) {
+		var have []int64
+		for _, row := range report.Stuck() {
+			have = append(have, row.BatchChange.ID)
+			if row.Changeset.ID != 3 {
+				t.Fatalf("unexpected stuck changeset %d", row.Changeset.ID)
+			}
+		}
+		if diff := cmp.Diff([]int64{1, 2}, have); diff != "" {
+			t.Fatalf("wrong stuck rows (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("WriteCSV", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := report.WriteCSV(&buf); err != nil {
+			t.Fatal(err)
+		}
+
+		ts := func(t time.Time) string { return t.UTC().Format(time.RFC3339) }
+		want := "namespace,batch_change,repository,code_host,changeset_id,external_id,url,state,opened_at,merged_at,closed_at,time_to_merge_seconds,open_for_seconds,stuck\n" +
+			"alice,bc-1,github.com/sourcegraph/a,https://github.com/,1,,,MERGED," + ts(daysAgo(10)) + "," + ts(daysAgo(9)) + ",,86400,,false\n" +
+			"alice,bc-1,github.com/sourcegraph/a,https://github.com/,2,,,MERGED," + ts(daysAgo(10)) + "," + ts(daysAgo(5)) + ",,432000,,false\n" +
+			"alice,bc-1,gitlab.com/sourcegraph/b,https://gitlab.com/,3,,,OPEN," + ts(daysAgo(40)) + ",,,,3456000,true\n" +
+			"sourcegraph,bc-2,gitlab.com/sourcegraph/b,https://gitlab.com/,3,,,OPEN," + ts(daysAgo(40)) + ",,,,3456000,true\n" +
+			"sourcegraph,bc-2,gitlab.com/sourcegraph/b,https://gitlab.com/,4,,,OPEN," + ts(daysAgo(2)) + ",,,,172800,false\n"
+		if diff := cmp.Diff(want, buf.String()); diff != "" {
+			t.Fatalf("wrong CSV (-want +got):\n%s", diff)
+		}
+	})
+}
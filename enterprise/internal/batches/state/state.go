@@ -2,8 +2,10 @@ package state
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -30,7 +33,7 @@ func SetDerivedState(ctx context.Context, repoStore *database.RepoStore, c *btyp
 	copy(events, es)
 	sort.Sort(events)
 
-	c.ExternalCheckState = computeCheckState(c, events)
+	c.ExternalChecks, c.ExternalCheckState = computeChecks(c, events)
 
 	history, err := computeHistory(c, events)
 	if err != nil {
@@ -92,22 +95,27 @@ func SetDerivedState(ctx context.Context, repoStore *database.RepoStore, c *btyp
 	}
 }
 
-// computeCheckState computes the overall check state based on the current
-// synced check state and any webhook events that have arrived after the most
-// recent sync.
-func computeCheckState(c *btypes.Changeset, events ChangesetEvents) btypes.ChangesetCheckState {
+// computeChecks computes the individual checks and the overall check state
+// based on the current synced check state and any webhook events that have
+// arrived after the most recent sync.
+func computeChecks(c *btypes.Changeset, events ChangesetEvents) ([]btypes.ChangesetCheck, btypes.ChangesetCheckState) {
 	switch m := c.Metadata.(type) {
 	case *github.PullRequest:
-		return computeGitHubCheckState(c.UpdatedAt, m, events)
+		checks := computeGitHubChecks(c.UpdatedAt, m, events)
+		return checks.list(), checks.state()
 
 	case *bitbucketserver.PullRequest:
-		return computeBitbucketBuildStatus(c.UpdatedAt, m, events)
+		checks := computeBitbucketBuildStatuses(c.UpdatedAt, m, events)
+		return checks, combineCheckStates(checkStates(checks))
 
 	case *gitlab.MergeRequest:
-		return computeGitLabCheckState(c.UpdatedAt, m, events)
+		if p := latestGitLabPipeline(c.UpdatedAt, m, events); p != nil {
+			check := gitLabPipelineCheck(p)
+			return []btypes.ChangesetCheck{check}, check.State
+		}
 	}
 
-	return btypes.ChangesetCheckStateUnknown
+	return nil, btypes.ChangesetCheckStateUnknown
 }
 
 // computeExternalState computes the external state for the changeset and its
@@ -147,6 +155,12 @@ func computeReviewState(c *btypes.Changeset, history []changesetStatesAtTime) (b
 }
 
 func computeBitbucketBuildStatus(lastSynced time.Time, pr *bitbucketserver.PullRequest, events []*btypes.ChangesetEvent) btypes.ChangesetCheckState {
+	return combineCheckStates(checkStates(computeBitbucketBuildStatuses(lastSynced, pr, events)))
+}
+
+// computeBitbucketBuildStatuses returns the build statuses of the latest
+// commit of the pull request, sorted by name.
+func computeBitbucketBuildStatuses(lastSynced time.Time, pr *bitbucketserver.PullRequest, events []*btypes.ChangesetEvent) []btypes.ChangesetCheck {
 	var latestCommit bitbucketserver.Commit
 	for _, c := range pr.Commits {
 		if latestCommit.CommitterTimestamp <= c.CommitterTimestamp {
@@ -154,11 +168,11 @@ func computeBitbucketBuildStatus(lastSynced time.Time, pr *bitbucketserver.PullR
 		}
 	}
 
-	stateMap := make(map[string]btypes.ChangesetCheckState)
+	checkMap := make(map[string]btypes.ChangesetCheck)
 
 	// States from last sync
 	for _, status := range pr.CommitStatus {
-		stateMap[status.Key()] = parseBitbucketBuildState(status.Status.State)
+		checkMap[status.Key()] = bitbucketBuildStatusCheck(status)
 	}
 
 	// Add any events we've received since our last sync
@@ -172,16 +186,32 @@ func computeBitbucketBuildStatus(lastSynced time.Time, pr *bitbucketserver.PullR
 			if dateAdded.Before(lastSynced) {
 				continue
 			}
-			stateMap[m.Key()] = parseBitbucketBuildState(m.Status.State)
+			checkMap[m.Key()] = bitbucketBuildStatusCheck(m)
 		}
 	}
 
-	states := make([]btypes.ChangesetCheckState, 0, len(stateMap))
-	for _, v := range stateMap {
-		states = append(states, v)
+	checks := make([]btypes.ChangesetCheck, 0, len(checkMap))
+	for _, v := range checkMap {
+		checks = append(checks, v)
 	}
+	sortChecks(checks)
 
-	return combineCheckStates(states)
+	return checks
+}
+
+func bitbucketBuildStatusCheck(s *bitbucketserver.CommitStatus) btypes.ChangesetCheck {
+	check := btypes.ChangesetCheck{
+		Name:  s.Status.Name,
+		State: parseBitbucketBuildState(s.Status.State),
+		URL:   s.Status.Url,
+	}
+	if check.Name == "" {
+		check.Name = s.Status.Key
+	}
+	if check.State != btypes.ChangesetCheckStatePending && s.Status.DateAdded != 0 {
+		check.FinishedAt = unixMilliToTime(s.Status.DateAdded)
+	}
+	return check
 }
 
 func parseBitbucketBuildState(s string) btypes.ChangesetCheckState {
@@ -198,13 +228,56 @@ func parseBitbucketBuildState(s string) btypes.ChangesetCheckState {
 }
 
 func computeGitHubCheckState(lastSynced time.Time, pr *github.PullRequest, events []*btypes.ChangesetEvent) btypes.ChangesetCheckState {
+	return computeGitHubChecks(lastSynced, pr, events).state()
+}
+
+// gitHubChecks are the commit status contexts, check suites and check runs of
+// the latest commit of a pull request.
+type gitHubChecks struct {
+	contexts map[string]btypes.ChangesetCheck
+	suites   map[string]btypes.ChangesetCheckState
+	runs     map[string]btypes.ChangesetCheck
+}
+
+// list returns the commit status contexts and check runs, sorted by name.
+// Check suites are only used to compute the overall state, since their runs
+// are the checks that are visible on GitHub.
+func (c *gitHubChecks) list() []btypes.ChangesetCheck {
+	checks := make([]btypes.ChangesetCheck, 0, len(c.contexts)+len(c.runs))
+	for _, check := range c.contexts {
+		checks = append(checks, check)
+	}
+	for _, check := range c.runs {
+		checks = append(checks, check)
+	}
+	sortChecks(checks)
+	return checks
+}
+
+func (c *gitHubChecks) state() btypes.ChangesetCheckState {
+	states := make([]btypes.ChangesetCheckState, 0, len(c.contexts)+len(c.suites)+len(c.runs))
+	for _, check := range c.contexts {
+		states = append(states, check.State)
+	}
+	for _, state := range c.suites {
+		states = append(states, state)
+	}
+	for _, check := range c.runs {
+		states = append(states, check.State)
+	}
+	return combineCheckStates(states)
+}
+
+func computeGitHubChecks(lastSynced time.Time, pr *github.PullRequest, events []*btypes.ChangesetEvent) *gitHubChecks {
 	// We should only consider the latest commit. This could be from a sync or a webhook that
 	// has occurred later
 	var latestCommitTime time.Time
 	var latestOID string
-	statusPerContext := make(map[string]btypes.ChangesetCheckState)
-	statusPerCheckSuite := make(map[string]btypes.ChangesetCheckState)
-	statusPerCheckRun := make(map[string]btypes.ChangesetCheckState)
+	checks := &gitHubChecks{
+		contexts: make(map[string]btypes.ChangesetCheck),
+		suites:   make(map[string]btypes.ChangesetCheckState),
+		runs:     make(map[string]btypes.ChangesetCheck),
+	}
 
 	if len(pr.Commits.Nodes) > 0 {
 		// We only request the most recent commit
@@ -213,7 +286,11 @@ func computeGitHubCheckState(lastSynced time.Time, pr *github.PullRequest, event
 		latestOID = commit.Commit.OID
 		// Calc status per context for the most recent synced commit
 		for _, c := range commit.Commit.Status.Contexts {
-			statusPerContext[c.Context] = parseGithubCheckState(c.State)
+			checks.contexts[c.Context] = btypes.ChangesetCheck{
+				Name:  c.Context,
+				State: parseGithubCheckState(c.State),
+				URL:   c.TargetURL,
+			}
 		}
 		for _, c := range commit.Commit.CheckSuites.Nodes {
 			if (c.Status == "QUEUED" || c.Status == "COMPLETED") && len(c.CheckRuns.Nodes) == 0 {
@@ -222,9 +299,10 @@ func computeGitHubCheckState(lastSynced time.Time, pr *github.PullRequest, event
 				// forever with zero runs.
 				continue
 			}
-			statusPerCheckSuite[c.ID] = parseGithubCheckSuiteState(c.Status, c.Conclusion)
+			checks.suites[c.ID] = parseGithubCheckSuiteState(c.Status, c.Conclusion)
 			for _, r := range c.CheckRuns.Nodes {
-				statusPerCheckRun[r.ID] = parseGithubCheckSuiteState(r.Status, r.Conclusion)
+				r.CheckSuiteID = c.ID
+				checks.runs[r.ID] = gitHubCheckRunCheck(&r)
 			}
 		}
 	}
@@ -242,8 +320,8 @@ func computeGitHubCheckState(lastSynced time.Time, pr *github.PullRequest, event
 				latestCommitTime = m.Commit.CommittedDate
 				latestOID = m.Commit.OID
 				// statusPerContext is now out of date, reset it
-				for k := range statusPerContext {
-					delete(statusPerContext, k)
+				for k := range checks.contexts {
+					delete(checks.contexts, k)
 				}
 			}
 		case *github.CheckSuite:
@@ -253,11 +331,25 @@ func computeGitHubCheckState(lastSynced time.Time, pr *github.PullRequest, event
 				continue
 			}
 			if m.ReceivedAt.After(lastSynced) {
-				statusPerCheckSuite[m.ID] = parseGithubCheckSuiteState(m.Status, m.Conclusion)
+				checks.suites[m.ID] = parseGithubCheckSuiteState(m.Status, m.Conclusion)
 			}
 		case *github.CheckRun:
 			if m.ReceivedAt.After(lastSynced) {
-				statusPerCheckRun[m.ID] = parseGithubCheckSuiteState(m.Status, m.Conclusion)
+				check := gitHubCheckRunCheck(m)
+				// Webhook payloads of older events may not include all
+				// details of the run, so we keep the ones we already know.
+				if prev, ok := checks.runs[m.ID]; ok {
+					if check.Name == "" {
+						check.Name = prev.Name
+					}
+					if check.URL == "" {
+						check.URL = prev.URL
+					}
+					if check.RerunID == "" {
+						check.RerunID = prev.RerunID
+					}
+				}
+				checks.runs[m.ID] = check
 			}
 		}
 	}
@@ -271,20 +363,49 @@ func computeGitHubCheckState(lastSynced time.Time, pr *github.PullRequest, event
 			if s.SHA != latestOID {
 				continue
 			}
-			statusPerContext[s.Context] = parseGithubCheckState(s.State)
+			check := btypes.ChangesetCheck{
+				Name:  s.Context,
+				State: parseGithubCheckState(s.State),
+				URL:   s.TargetURL,
+			}
+			if check.URL == "" {
+				check.URL = checks.contexts[s.Context].URL
+			}
+			if check.State != btypes.ChangesetCheckStatePending {
+				check.FinishedAt = s.ReceivedAt
+			}
+			checks.contexts[s.Context] = check
 		}
 	}
-	finalStates := make([]btypes.ChangesetCheckState, 0, len(statusPerContext))
-	for k := range statusPerContext {
-		finalStates = append(finalStates, statusPerContext[k])
+
+	return checks
+}
+
+func gitHubCheckRunCheck(r *github.CheckRun) btypes.ChangesetCheck {
+	name := r.Name
+	if name == "" {
+		name = r.ID
 	}
-	for k := range statusPerCheckSuite {
-		finalStates = append(finalStates, statusPerCheckSuite[k])
+	return btypes.ChangesetCheck{
+		Name:       name,
+		State:      parseGithubCheckSuiteState(r.Status, r.Conclusion),
+		URL:        r.DetailsURL,
+		FinishedAt: r.CompletedAt,
+		RerunID:    r.CheckSuiteID,
 	}
-	for k := range statusPerCheckRun {
-		finalStates = append(finalStates, statusPerCheckRun[k])
+}
+
+// checkStates returns the states of the given checks.
+func checkStates(checks []btypes.ChangesetCheck) []btypes.ChangesetCheckState {
+	states := make([]btypes.ChangesetCheckState, 0, len(checks))
+	for _, c := range checks {
+		states = append(states, c.State)
 	}
-	return combineCheckStates(finalStates)
+	return states
+}
+
+func sortChecks(checks []btypes.ChangesetCheck) {
+	sort.SliceStable(checks, func(i, j int) bool { return checks[i].Name < checks[j].Name })
 }
 
 // combineCheckStates combines multiple check states into an overall state
@@ -354,6 +475,15 @@ func parseGithubCheckSuiteState(status, conclusion string) btypes.ChangesetCheck
 }
 
 func computeGitLabCheckState(lastSynced time.Time, mr *gitlab.MergeRequest, events []*btypes.ChangesetEvent) btypes.ChangesetCheckState {
+	if p := latestGitLabPipeline(lastSynced, mr, events); p != nil {
+		return parseGitLabPipelineStatus(p.Status)
+	}
+	return btypes.ChangesetCheckStateUnknown
+}
+
+// latestGitLabPipeline returns the most recent pipeline associated with the
+// merge request, or nil if there is none.
+func latestGitLabPipeline(lastSynced time.Time, mr *gitlab.MergeRequest, events []*btypes.ChangesetEvent) *gitlab.Pipeline {
 	// GitLab pipelines aren't tied to commits in the same way that GitHub
 	// checks are. We're simply looking for the most recent pipeline run that
 	// was associated with the merge request, which may live in a changeset
@@ -384,10 +514,7 @@ func computeGitLabCheckState(lastSynced time.Time, mr *gitlab.MergeRequest, even
 		// HeadPipeline. If that's empty, then we'll shrug and say we don't
 		// know.
 		if len(mr.Pipelines) == 0 {
-			if mr.HeadPipeline != nil {
-				return parseGitLabPipelineStatus(mr.HeadPipeline.Status)
-			}
-			return btypes.ChangesetCheckStateUnknown
+			return mr.HeadPipeline
 		}
 
 		// Sort into descending order so that the pipeline at index 0 is the latest.
@@ -396,10 +523,23 @@ func computeGitLabCheckState(lastSynced time.Time, mr *gitlab.MergeRequest, even
 			return pipelines[i].CreatedAt.After(pipelines[j].CreatedAt.Time)
 		})
 
-		return parseGitLabPipelineStatus(pipelines[0].Status)
+		return pipelines[0]
 	}
 
-	return parseGitLabPipelineStatus(lastPipelineEvent.Status)
+	return lastPipelineEvent
+}
+
+func gitLabPipelineCheck(p *gitlab.Pipeline) btypes.ChangesetCheck {
+	check := btypes.ChangesetCheck{
+		Name:    fmt.Sprintf("Pipeline #%d", p.ID),
+		State:   parseGitLabPipelineStatus(p.Status),
+		URL:     p.WebURL,
+		RerunID: strconv.FormatInt(int64(p.ID), 10),
+	}
+	if check.State != btypes.ChangesetCheckStatePending {
+		check.FinishedAt = p.UpdatedAt.Time
+	}
+	return check
 }
 
 func parseGitLabPipelineStatus(status gitlab.PipelineStatus) btypes.ChangesetCheckState {
@@ -450,6 +590,19 @@ func computeSingleChangesetExternalState(c *btypes.Changeset) (s btypes.Changese
 		default:
 			return "", errors.Errorf("unknown GitLab merge request state: %s", m.State)
 		}
+	case *awscodecommit.PullRequest:
+		switch m.Status {
+		case awscodecommit.PullRequestStatusOpen:
+			s = btypes.ChangesetExternalStateOpen
+		case awscodecommit.PullRequestStatusClosed:
+			if m.IsMerged() {
+				s = btypes.ChangesetExternalStateMerged
+			} else {
+				s = btypes.ChangesetExternalStateClosed
+			}
+		default:
+			return "", errors.Errorf("unknown AWS CodeCommit pull request status: %s", m.Status)
+		}
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -507,6 +660,17 @@ func computeSingleChangesetReviewState(c *btypes.Changeset) (s btypes.ChangesetR
 		}
 		return btypes.ChangesetReviewStatePending, nil
 
+	case *awscodecommit.PullRequest:
+		// AWS CodeCommit only knows approvals, which can be revoked again, so
+		// there's no equivalent of requesting changes.
+		for _, a := range m.Approvals {
+			if a.Approved {
+				states[btypes.ChangesetReviewStateApproved] = true
+			} else {
+				states[btypes.ChangesetReviewStatePending] = true
+			}
+		}
+
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -9,6 +9,7 @@ import (
 
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -406,6 +407,114 @@ func TestComputeGitLabCheckState(t *testing.T) {
 	})
 }
 
+func TestComputeChecks(t *testing.T) {
+	t.Parallel()
+
+	now := timeutil.Now()
+	lastSynced := now.Add(-1 * time.Minute)
+
+	t.Run("GitHub", func(t *testing.T) {
+		pr := &github.PullRequest{}
+		commit := github.CommitWithChecks{}
+		commit.Commit.OID = "deadbeef"
+		commit.Commit.Status.Contexts = []github.Context{
+			{Context: "ci/lint", State: "SUCCESS", TargetURL: "https://ci.example.com/lint"},
+		}
+		suite := github.CheckSuite{ID: "suite-1", Status: "COMPLETED", Conclusion: "FAILURE"}
+		suite.CheckRuns.Nodes = []github.CheckRun{
+			{ID: "run-1", Name: "test", Status: "COMPLETED", Conclusion: "FAILURE", DetailsURL: "https://github.com/runs/1", CompletedAt: lastSynced},
+		}
+		commit.Commit.CheckSuites.Nodes = []github.CheckSuite{suite}
+		pr.Commits.Nodes = []github.CommitWithChecks{commit}
+
+		events := []*btypes.ChangesetEvent{
+			{
+				Kind: btypes.ChangesetEventKindCommitStatus,
+				Metadata: &github.CommitStatus{
+					SHA:        "deadbeef",
+					Context:    "ci/build",
+					State:      "PENDING",
+					TargetURL:  "https://ci.example.com/build",
+					ReceivedAt: now,
+				},
+			},
+		}
+
+		c := &btypes.Changeset{Metadata: pr, UpdatedAt: lastSynced}
+		checks, state := computeChecks(c, events)
+
+		want := []btypes.ChangesetCheck{
+			{Name: "ci/build", State: btypes.ChangesetCheckStatePending, URL: "https://ci.example.com/build"},
+			{Name: "ci/lint", State: btypes.ChangesetCheckStatePassed, URL: "https://ci.example.com/lint"},
+			{Name: "test", State: btypes.ChangesetCheckStateFailed, URL: "https://github.com/runs/1", FinishedAt: lastSynced, RerunID: "suite-1"},
+		}
+		if diff := cmp.Diff(want, checks); diff != "" {
+			t.Errorf("wrong checks (-want +got):\n%s", diff)
+		}
+		if state != btypes.ChangesetCheckStatePending {
+			t.Errorf("wrong state: have %s; want %s", state, btypes.ChangesetCheckStatePending)
+		}
+	})
+
+	t.Run("Bitbucket Server", func(t *testing.T) {
+		pr := &bitbucketserver.PullRequest{
+			Commits: []*bitbucketserver.Commit{{ID: "deadbeef"}},
+			CommitStatus: []*bitbucketserver.CommitStatus{
+				{
+					Commit: "deadbeef",
+					Status: bitbucketserver.BuildStatus{
+						State:     "FAILED",
+						Key:       "build",
+						Name:      "Build",
+						Url:       "https://ci.example.com/build",
+						DateAdded: 1000,
+					},
+				},
+			},
+		}
+
+		c := &btypes.Changeset{Metadata: pr, UpdatedAt: lastSynced}
+		checks, state := computeChecks(c, nil)
+
+		want := []btypes.ChangesetCheck{
+			{Name: "Build", State: btypes.ChangesetCheckStateFailed, URL: "https://ci.example.com/build", FinishedAt: time.Unix(1, 0)},
+		}
+		if diff := cmp.Diff(want, checks); diff != "" {
+			t.Errorf("wrong checks (-want +got):\n%s", diff)
+		}
+		if state != btypes.ChangesetCheckStateFailed {
+			t.Errorf("wrong state: have %s; want %s", state, btypes.ChangesetCheckStateFailed)
+		}
+	})
+
+	t.Run("GitLab", func(t *testing.T) {
+		mr := &gitlab.MergeRequest{
+			Pipelines: []*gitlab.Pipeline{
+				{
+					ID:        42,
+					Status:    gitlab.PipelineStatusFailed,
+					WebURL:    "https://gitlab.com/pipelines/42",
+					CreatedAt: gitlab.Time{Time: lastSynced.Add(-1 * time.Hour)},
+					UpdatedAt: gitlab.Time{Time: lastSynced},
+				},
+			},
+		}
+
+		c := &btypes.Changeset{Metadata: mr, UpdatedAt: lastSynced}
+		checks, state := computeChecks(c, nil)
+
+		want := []btypes.ChangesetCheck{
+			{Name: "Pipeline #42", State: btypes.ChangesetCheckStateFailed, URL: "https://gitlab.com/pipelines/42", FinishedAt: lastSynced, RerunID: "42"},
+		}
+		if diff := cmp.Diff(want, checks); diff != "" {
+			t.Errorf("wrong checks (-want +got):\n%s", diff)
+		}
+		if state != btypes.ChangesetCheckStateFailed {
+			t.Errorf("wrong state: have %s; want %s", state, btypes.ChangesetCheckStateFailed)
+		}
+	})
+}
+
 func TestComputeReviewState(t *testing.T) {
 	t.Parallel()
 
@@ -535,6 +644,24 @@ func TestComputeReviewState(t *testing.T) {
 			},
 			want: btypes.ChangesetReviewStateChangesRequested,
 		},
+		{
+			name:      "awscodecommit - no approvals",
+			changeset: awsCodeCommitChangeset(daysAgo(10), awscodecommit.PullRequestStatusOpen, false),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStatePending,
+		},
+		{
+			name:      "awscodecommit - approved",
+			changeset: awsCodeCommitChangeset(daysAgo(10), awscodecommit.PullRequestStatusOpen, false, true, false),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStateApproved,
+		},
+		{
+			name:      "awscodecommit - approval revoked",
+			changeset: awsCodeCommitChangeset(daysAgo(10), awscodecommit.PullRequestStatusOpen, false, false),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStatePending,
+		},
 	}
 
 	for i, tc := range tests {
@@ -715,6 +842,24 @@ func TestComputeExternalState(t *testing.T) {
 			},
 			want: btypes.ChangesetExternalStateDraft,
 		},
+		{
+			name:      "awscodecommit open - no events",
+			changeset: awsCodeCommitChangeset(daysAgo(10), awscodecommit.PullRequestStatusOpen, false),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateOpen,
+		},
+		{
+			name:      "awscodecommit closed - no events",
+			changeset: awsCodeCommitChangeset(daysAgo(10), awscodecommit.PullRequestStatusClosed, false),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateClosed,
+		},
+		{
+			name:      "awscodecommit merged - no events",
+			changeset: awsCodeCommitChangeset(daysAgo(10), awscodecommit.PullRequestStatusClosed, true),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateMerged,
+		},
 	}
 
 	for i, tc := range tests {
@@ -865,6 +1010,21 @@ func gitLabChangeset(updatedAt time.Time, state gitlab.MergeRequestState, notes
 	}
 }
 
+func awsCodeCommitChangeset(updatedAt time.Time, status awscodecommit.PullRequestStatus, merged bool, approvals ...bool) *btypes.Changeset {
+	pr := &awscodecommit.PullRequest{
+		Status:  status,
+		Targets: []*awscodecommit.PullRequestTarget{{IsMerged: merged}},
+	}
+	for _, approved := range approvals {
+		pr.Approvals = append(pr.Approvals, &awscodecommit.Approval{Approved: approved})
+	}
+	return &btypes.Changeset{
+		ExternalServiceType: extsvc.TypeAWSCodeCommit,
+		UpdatedAt:           updatedAt,
+		Metadata:            pr,
+	}
+}
+
 func setDeletedAt(c *btypes.Changeset, deletedAt time.Time) *btypes.Changeset {
 	c.ExternalDeletedAt = deletedAt
 	return c
@@ -0,0 +1,184 @@
+package store
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// batchChangeAdminColumns are used by the batch change admin related Store
+// methods to insert and query batch change admins.
+var batchChangeAdminColumns = []*sqlf.Query{
+	sqlf.Sprintf("batch_change_admins.id"),
+	sqlf.Sprintf("batch_change_admins.batch_change_id"),
+	sqlf.Sprintf("batch_change_admins.user_id"),
+	sqlf.Sprintf("batch_change_admins.org_id"),
+	sqlf.Sprintf("batch_change_admins.created_at"),
+}
+
+// CreateBatchChangeAdmin creates the given batch change admin.
+func (s *Store) CreateBatchChangeAdmin(ctx context.Context, a *btypes.BatchChangeAdmin) (err error) {
+	ctx, endObservation := s.operations.createBatchChangeAdmin.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(a.BatchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if a.CreatedAt.IsZero() {
+		a.CreatedAt = s.now()
+	}
+
+	q := sqlf.Sprintf(
+		createBatchChangeAdminQueryFmtstr,
+		a.BatchChangeID,
+		nullInt32Column(a.UserID),
+		nullInt32Column(a.OrgID),
+		a.CreatedAt,
+		sqlf.Join(batchChangeAdminColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc scanner) error {
+		return scanBatchChangeAdmin(a, sc)
+	})
+}
+
+var createBatchChangeAdminQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_change_admins.go:CreateBatchChangeAdmin
+INSERT INTO batch_change_admins (batch_change_id, user_id, org_id, created_at)
+VALUES (%s, %s, %s, %s)
+RETURNING %s
+`
+
+// DeleteBatchChangeAdminOpts captures the query options needed for deleting
+// a batch change admin. Exactly one of UserID and OrgID must be set.
+type DeleteBatchChangeAdminOpts struct {
+	BatchChangeID int64
+
+	UserID int32
+	OrgID  int32
+}
+
+// DeleteBatchChangeAdmin deletes the batch change admin matching the given
+// options. ErrNoResults is returned if no such admin exists.
+func (s *Store) DeleteBatchChangeAdmin(ctx context.Context, opts DeleteBatchChangeAdminOpts) (err error) {
+	ctx, endObservation := s.operations.deleteBatchChangeAdmin.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(opts.BatchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	preds := []*sqlf.Query{sqlf.Sprintf("batch_change_id = %s", opts.BatchChangeID)}
+	if opts.UserID != 0 {
+		preds = append(preds, sqlf.Sprintf("user_id = %s", opts.UserID))
+	} else {
+		preds = append(preds, sqlf.Sprintf("org_id = %s", opts.OrgID))
+	}
+
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(deleteBatchChangeAdminQueryFmtstr, sqlf.Join(preds, "\n AND ")))
+	if err != nil {
+		return err
+	}
+
+	if rows, err := res.RowsAffected(); err != nil {
+		return err
+	} else if rows == 0 {
+		return ErrNoResults
+	}
+	return nil
+}
+
+var deleteBatchChangeAdminQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_change_admins.go:DeleteBatchChangeAdmin
+DELETE FROM batch_change_admins WHERE %s
+`
+
+// ListBatchChangeAdminsOpts captures the query options needed for listing
+// batch change admins.
+type ListBatchChangeAdminsOpts struct {
+	BatchChangeID int64
+}
+
+// ListBatchChangeAdmins lists the admins of a batch change, excluding its
+// owner, in the order they were added.
+func (s *Store) ListBatchChangeAdmins(ctx context.Context, opts ListBatchChangeAdminsOpts) (as []*btypes.BatchChangeAdmin, err error) {
+	ctx, endObservation := s.operations.listBatchChangeAdmins.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(opts.BatchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		listBatchChangeAdminsQueryFmtstr,
+		sqlf.Join(batchChangeAdminColumns, ", "),
+		opts.BatchChangeID,
+	)
+
+	err = s.query(ctx, q, func(sc scanner) error {
+		var a btypes.BatchChangeAdmin
+		if err := scanBatchChangeAdmin(&a, sc); err != nil {
+			return err
+		}
+		as = append(as, &a)
+		return nil
+	})
+	return as, err
+}
+
+var listBatchChangeAdminsQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_change_admins.go:ListBatchChangeAdmins
+SELECT %s FROM batch_change_admins
+LEFT JOIN users ON users.id = batch_change_admins.user_id
+LEFT JOIN orgs ON orgs.id = batch_change_admins.org_id
+WHERE
+	batch_change_admins.batch_change_id = %s AND
+	users.deleted_at IS NULL AND
+	orgs.deleted_at IS NULL
+ORDER BY batch_change_admins.id ASC
+`
+
+// IsBatchChangeAdmin returns true if the given user has been made an admin
+// of the batch change, either directly or by being a member of an org that
+// has been made an admin. It doesn't check whether the user owns the batch
+// change.
+func (s *Store) IsBatchChangeAdmin(ctx context.Context, batchChangeID int64, userID int32) (isAdmin bool, err error) {
+	ctx, endObservation := s.operations.isBatchChangeAdmin.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(batchChangeID)),
+		log.Int("userID", int(userID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(isBatchChangeAdminQueryFmtstr, batchChangeID, userID, userID)
+
+	err = s.query(ctx, q, func(sc scanner) error {
+		return sc.Scan(&isAdmin)
+	})
+	return isAdmin, err
+}
+
+var isBatchChangeAdminQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_change_admins.go:IsBatchChangeAdmin
+SELECT EXISTS (
+	SELECT 1
+	FROM batch_change_admins
+	LEFT JOIN orgs ON orgs.id = batch_change_admins.org_id
+	LEFT JOIN org_members ON org_members.org_id = orgs.id
+	WHERE
+		batch_change_admins.batch_change_id = %s AND
+		(
+			batch_change_admins.user_id = %s OR
+			(org_members.user_id = %s AND orgs.deleted_at IS NULL)
+		)
+)
+`
+
+func scanBatchChangeAdmin(a *btypes.BatchChangeAdmin, sc scanner) error {
+	return sc.Scan(
+		&a.ID,
+		&a.BatchChangeID,
+		&dbutil.NullInt32{N: &a.UserID},
+		&dbutil.NullInt32{N: &a.OrgID},
+		&a.CreatedAt,
+	)
+}
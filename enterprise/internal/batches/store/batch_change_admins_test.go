@@ -0,0 +1,137 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+func testStoreBatchChangeAdmins(t *testing.T, ctx context.Context, s *Store, clock ct.Clock) {
+	owner := ct.CreateTestUser(t, s.DB(), false)
+	admin := ct.CreateTestUser(t, s.DB(), false)
+	member := ct.CreateTestUser(t, s.DB(), false)
+	outsider := ct.CreateTestUser(t, s.DB(), false)
+
+	orgID := ct.InsertTestOrg(t, s.DB(), "batch-change-admins")
+	if _, err := database.OrgMembers(s.DB()).Create(ctx, orgID, member.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	spec := ct.CreateBatchSpec(t, ctx, s, "batch-change-admins", owner.ID)
+	batchChange := ct.CreateBatchChange(t, ctx, s, "batch-change-admins", owner.ID, spec.ID)
+
+	admins := []*btypes.BatchChangeAdmin{
+		{BatchChangeID: batchChange.ID, UserID: admin.ID},
+		{BatchChangeID: batchChange.ID, OrgID: orgID},
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		for _, a := range admins {
+			if err := s.CreateBatchChangeAdmin(ctx, a); err != nil {
+				t.Fatal(err)
+			}
+			if a.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+			if have, want := a.CreatedAt, clock.Now(); !have.Equal(want) {
+				t.Fatalf("wrong CreatedAt. want=%s, have=%s", want, have)
+			}
+		}
+
+		t.Run("duplicate", func(t *testing.T) {
+			err := s.CreateBatchChangeAdmin(ctx, &btypes.BatchChangeAdmin{BatchChangeID: batchChange.ID, UserID: admin.ID})
+			if err == nil {
+				t.Fatal("no error returned for duplicate admin")
+			}
+		})
+	})
+
+	t.Run("List", func(t *testing.T) {
+		have, err := s.ListBatchChangeAdmins(ctx, ListBatchChangeAdminsOpts{BatchChangeID: batchChange.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(admins, have); diff != "" {
+			t.Fatalf("wrong admins (-want +have):\n%s", diff)
+		}
+	})
+
+	t.Run("IsBatchChangeAdmin", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			userID int32
+			want   bool
+		}{
+			// The owner isn't stored as an admin.
+			"owner":      {userID: owner.ID, want: false},
+			"admin":      {userID: admin.ID, want: true},
+			"org member": {userID: member.ID, want: true},
+			"outsider":   {userID: outsider.ID, want: false},
+		} {
+			t.Run(name, func(t *testing.T) {
+				have, err := s.IsBatchChangeAdmin(ctx, batchChange.ID, tc.userID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if have != tc.want {
+					t.Fatalf("wrong result. want=%t, have=%t", tc.want, have)
+				}
+			})
+		}
+	})
+
+	t.Run("ListBatchChanges AdministeredByUserID", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			userID int32
+			want   int
+		}{
+			"owner":      {userID: owner.ID, want: 1},
+			"admin":      {userID: admin.ID, want: 1},
+			"org member": {userID: member.ID, want: 1},
+			"outsider":   {userID: outsider.ID, want: 0},
+		} {
+			t.Run(name, func(t *testing.T) {
+				have, _, err := s.ListBatchChanges(ctx, ListBatchChangesOpts{AdministeredByUserID: tc.userID})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(have) != tc.want {
+					t.Fatalf("wrong number of batch changes. want=%d, have=%d", tc.want, len(have))
+				}
+
+				count, err := s.CountBatchChanges(ctx, CountBatchChangesOpts{AdministeredByUserID: tc.userID})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if count != tc.want {
+					t.Fatalf("wrong count. want=%d, have=%d", tc.want, count)
+				}
+			})
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		if err := s.DeleteBatchChangeAdmin(ctx, DeleteBatchChangeAdminOpts{BatchChangeID: batchChange.ID, OrgID: orgID}); err != nil {
+			t.Fatal(err)
+		}
+
+		isAdmin, err := s.IsBatchChangeAdmin(ctx, batchChange.ID, member.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if isAdmin {
+			t.Fatal("org member is still an admin after the org was removed")
+		}
+
+		t.Run("not found", func(t *testing.T) {
+			err := s.DeleteBatchChangeAdmin(ctx, DeleteBatchChangeAdminOpts{BatchChangeID: batchChange.ID, OrgID: orgID})
+			if err != ErrNoResults {
+				t.Fatalf("wrong error. want=%s, have=%v", ErrNoResults, err)
+			}
+		})
+	})
+}
@@ -31,6 +31,7 @@ var batchChangeColumns = []*sqlf.Query{
 	sqlf.Sprintf("batch_changes.updated_at"),
 	sqlf.Sprintf("batch_changes.closed_at"),
 	sqlf.Sprintf("batch_changes.batch_spec_id"),
+	sqlf.Sprintf("batch_changes.owner_id"),
 }
 
 // batchChangeInsertColumns is the list of batch changes columns that are
@@ -48,6 +49,7 @@ var batchChangeInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("updated_at"),
 	sqlf.Sprintf("closed_at"),
 	sqlf.Sprintf("batch_spec_id"),
+	sqlf.Sprintf("owner_id"),
 }
 
 // CreateBatchChange creates the given batch change.
@@ -65,7 +67,7 @@ func (s *Store) CreateBatchChange(ctx context.Context, c *btypes.BatchChange) (e
 var createBatchChangeQueryFmtstr = `
 -- source: enterprise/internal/batches/store.go:CreateBatchChange
 INSERT INTO batch_changes (%s)
-VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING %s
 `
 
@@ -92,6 +94,7 @@ func (s *Store) createBatchChangeQuery(c *btypes.BatchChange) *sqlf.Query {
 		c.UpdatedAt,
 		nullTimeColumn(c.ClosedAt),
 		c.BatchSpecID,
+		nullInt32Column(c.OwnerID),
 		sqlf.Join(batchChangeColumns, ", "),
 	)
 }
@@ -111,7 +114,7 @@ func (s *Store) UpdateBatchChange(ctx context.Context, c *btypes.BatchChange) (e
 var updateBatchChangeQueryFmtstr = `
 -- source: enterprise/internal/batches/store.go:UpdateBatchChange
 UPDATE batch_changes
-SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 WHERE id = %s
 RETURNING %s
 `
@@ -133,6 +136,7 @@ func (s *Store) updateBatchChangeQuery(c *btypes.BatchChange) *sqlf.Query {
 		c.UpdatedAt,
 		nullTimeColumn(c.ClosedAt),
 		c.BatchSpecID,
+		nullInt32Column(c.OwnerID),
 		c.ID,
 		sqlf.Join(batchChangeColumns, ", "),
 	)
@@ -161,6 +165,10 @@ type CountBatchChangesOpts struct {
 
 	InitialApplierID int32
 
+	// AdministeredByUserID limits the batch changes to the ones owned by the
+	// user or that the user is an admin of.
+	AdministeredByUserID int32
+
 	NamespaceUserID int32
 	NamespaceOrgID  int32
 }
@@ -207,6 +215,10 @@ func countBatchChangesQuery(opts *CountBatchChangesOpts) *sqlf.Query {
 		preds = append(preds, sqlf.Sprintf("batch_changes.initial_applier_id = %d", opts.InitialApplierID))
 	}
 
+	if opts.AdministeredByUserID != 0 {
+		preds = append(preds, administeredByUserQuery(opts.AdministeredByUserID))
+	}
+
 	if opts.NamespaceUserID != 0 {
 		preds = append(preds, sqlf.Sprintf("batch_changes.namespace_user_id = %s", opts.NamespaceUserID))
 	}
@@ -231,6 +243,10 @@ type GetBatchChangeOpts struct {
 
 	BatchSpecID int64
 	Name        string
+
+	// IncludeDeletedNamespace also returns batch changes whose user or org
+	// namespace has been deleted.
+	IncludeDeletedNamespace bool
 }
 
 // GetBatchChange gets a batch change matching the given options.
@@ -267,9 +283,12 @@ LIMIT 1
 `
 
 func getBatchChangeQuery(opts *GetBatchChangeOpts) *sqlf.Query {
-	preds := []*sqlf.Query{
-		sqlf.Sprintf("namespace_user.deleted_at IS NULL"),
-		sqlf.Sprintf("namespace_org.deleted_at IS NULL"),
+	var preds []*sqlf.Query
+	if !opts.IncludeDeletedNamespace {
+		preds = append(preds,
+			sqlf.Sprintf("namespace_user.deleted_at IS NULL"),
+			sqlf.Sprintf("namespace_org.deleted_at IS NULL"),
+		)
 	}
 	if opts.ID != 0 {
 		preds = append(preds, sqlf.Sprintf("batch_changes.id = %s", opts.ID))
@@ -401,6 +420,10 @@ type ListBatchChangesOpts struct {
 
 	InitialApplierID int32
 
+	// AdministeredByUserID limits the batch changes to the ones owned by the
+	// user or that the user is an admin of.
+	AdministeredByUserID int32
+
 	NamespaceUserID int32
 	NamespaceOrgID  int32
 
@@ -474,6 +497,10 @@ func listBatchChangesQuery(opts *ListBatchChangesOpts, repoAuthzConds *sqlf.Quer
 		preds = append(preds, sqlf.Sprintf("batch_changes.initial_applier_id = %d", opts.InitialApplierID))
 	}
 
+	if opts.AdministeredByUserID != 0 {
+		preds = append(preds, administeredByUserQuery(opts.AdministeredByUserID))
+	}
+
 	if opts.NamespaceUserID != 0 {
 		preds = append(preds, sqlf.Sprintf("batch_changes.namespace_user_id = %s", opts.NamespaceUserID))
 	}
@@ -507,6 +534,28 @@ func listBatchChangesQuery(opts *ListBatchChangesOpts, repoAuthzConds *sqlf.Quer
 	)
 }
 
+var administeredByUserQueryFmtstr = `
+(
+	batch_changes.owner_id = %s OR
+	EXISTS (
+		SELECT 1
+		FROM batch_change_admins
+		LEFT JOIN orgs ON orgs.id = batch_change_admins.org_id
+		LEFT JOIN org_members ON org_members.org_id = orgs.id
+		WHERE
+			batch_change_admins.batch_change_id = batch_changes.id AND
+			(
+				batch_change_admins.user_id = %s OR
+				(org_members.user_id = %s AND orgs.deleted_at IS NULL)
+			)
+	)
+)
+`
+
+func administeredByUserQuery(userID int32) *sqlf.Query {
+	return sqlf.Sprintf(administeredByUserQueryFmtstr, userID, userID, userID)
+}
+
 func scanBatchChange(c *btypes.BatchChange, s scanner) error {
 	return s.Scan(
 		&c.ID,
@@ -521,5 +570,6 @@ func scanBatchChange(c *btypes.BatchChange, s scanner) error {
 		&c.UpdatedAt,
 		&dbutil.NullTime{Time: &c.ClosedAt},
 		&c.BatchSpecID,
+		&dbutil.NullInt32{N: &c.OwnerID},
 	)
 }
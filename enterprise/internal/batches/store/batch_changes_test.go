@@ -31,6 +31,7 @@ func testStoreBatchChanges(t *testing.T, ctx context.Context, s *Store, clock ct
 				InitialApplierID: int32(i) + 50,
 				LastAppliedAt:    clock.Now(),
 				LastApplierID:    int32(i) + 99,
+				OwnerID:          int32(i) + 50,
 
 				BatchSpecID: 1742 + int64(i),
 				ClosedAt:    clock.Now(),
@@ -427,6 +428,7 @@ func testStoreBatchChanges(t *testing.T, ctx context.Context, s *Store, clock ct
 			c.Name += "-updated"
 			c.Description += "-updated"
 			c.InitialApplierID++
+			c.OwnerID++
 			c.ClosedAt = c.ClosedAt.Add(5 * time.Second)
 
 			if c.NamespaceUserID != 0 {
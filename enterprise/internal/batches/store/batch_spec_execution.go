@@ -100,8 +100,9 @@ func createBatchSpecExecutionQuery(c *btypes.BatchSpecExecution) (*sqlf.Query, e
 
 // GetBatchSpecExecutionOpts captures the query options needed for getting a BatchSpecExecution.
 type GetBatchSpecExecutionOpts struct {
-	ID     int64
-	RandID string
+	ID        int64
+	RandID    string
+	ForUpdate bool
 }
 
 // GetBatchSpecExecution gets a BatchSpecExecution matching the given options.
@@ -137,6 +138,7 @@ var getBatchSpecExecutionQueryFmtstr = `
 SELECT %s FROM batch_spec_executions
 WHERE %s
 LIMIT 1
+%s  -- optional FOR UPDATE
 `
 
 func getBatchSpecExecutionQuery(opts *GetBatchSpecExecutionOpts) (*sqlf.Query, error) {
@@ -154,13 +156,38 @@ func getBatchSpecExecutionQuery(opts *GetBatchSpecExecutionOpts) (*sqlf.Query, e
 		return nil, errors.New("no predicates given")
 	}
 
+	forUpdate := &sqlf.Query{}
+	if opts.ForUpdate {
+		forUpdate = sqlf.Sprintf("FOR UPDATE")
+	}
+
 	return sqlf.Sprintf(
 		getBatchSpecExecutionQueryFmtstr,
 		sqlf.Join(BatchSpecExecutionColumns, ", "),
 		sqlf.Join(preds, "\n AND "),
+		forUpdate,
 	), nil
 }
 
+// SetBatchSpecExecutionBatchSpec records the batch spec that was assembled
+// from the results of the BatchSpecExecution with the given ID.
+func (s *Store) SetBatchSpecExecutionBatchSpec(ctx context.Context, id, batchSpecID int64) (err error) {
+	ctx, endObservation := s.operations.setBatchSpecExecutionBatchSpec.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(id)),
+		log.Int("batchSpecID", int(batchSpecID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.Exec(ctx, sqlf.Sprintf(setBatchSpecExecutionBatchSpecQueryFmtstr, batchSpecID, s.now(), id))
+}
+
+var setBatchSpecExecutionBatchSpecQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_executions.go:SetBatchSpecExecutionBatchSpec
+UPDATE batch_spec_executions
+SET batch_spec_id = %s, updated_at = %s
+WHERE id = %s
+`
+
 func scanBatchSpecExecution(b *btypes.BatchSpecExecution, sc scanner) error {
 	var executionLogs []dbworkerstore.ExecutionLogEntry
 
@@ -0,0 +1,247 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jackc/pgconn"
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// batchSpecTemplateColumns are used by the batch spec template related Store
+// methods to insert and query batch spec templates.
+var batchSpecTemplateColumns = []*sqlf.Query{
+	sqlf.Sprintf("batch_spec_templates.id"),
+	sqlf.Sprintf("batch_spec_templates.name"),
+	sqlf.Sprintf("batch_spec_templates.description"),
+	sqlf.Sprintf("batch_spec_templates.namespace_org_id"),
+	sqlf.Sprintf("batch_spec_templates.template"),
+	sqlf.Sprintf("batch_spec_templates.parameters"),
+	sqlf.Sprintf("batch_spec_templates.creator_user_id"),
+	sqlf.Sprintf("batch_spec_templates.created_at"),
+	sqlf.Sprintf("batch_spec_templates.updated_at"),
+}
+
+// ErrBatchSpecTemplateNameExists is returned by CreateBatchSpecTemplate when
+// the namespace already has a template with the same name.
+var ErrBatchSpecTemplateNameExists = errors.New("a batch spec template with this name already exists")
+
+// CreateBatchSpecTemplate creates the given batch spec template.
+func (s *Store) CreateBatchSpecTemplate(ctx context.Context, t *btypes.BatchSpecTemplate) (err error) {
+	ctx, endObservation := s.operations.createBatchSpecTemplate.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = s.now()
+	}
+
+	if t.UpdatedAt.IsZero() {
+		t.UpdatedAt = t.CreatedAt
+	}
+
+	parameters := t.Parameters
+	if parameters == nil {
+		parameters = []btypes.BatchSpecTemplateParameter{}
+	}
+	encodedParameters, err := json.Marshal(parameters)
+	if err != nil {
+		return err
+	}
+
+	q := sqlf.Sprintf(
+		createBatchSpecTemplateQueryFmtstr,
+		t.Name,
+		t.Description,
+		nullInt32Column(t.NamespaceOrgID),
+		t.Template,
+		encodedParameters,
+		nullInt32Column(t.CreatorUserID),
+		t.CreatedAt,
+		t.UpdatedAt,
+		sqlf.Join(batchSpecTemplateColumns, ", "),
+	)
+
+	err = s.query(ctx, q, func(sc scanner) error {
+		return scanBatchSpecTemplate(t, sc)
+	})
+
+	var e *pgconn.PgError
+	if errors.As(err, &e) {
+		switch e.ConstraintName {
+		case "batch_spec_templates_org_name_unique", "batch_spec_templates_site_name_unique":
+			return ErrBatchSpecTemplateNameExists
+		}
+	}
+	return err
+}
+
+var createBatchSpecTemplateQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_templates.go:CreateBatchSpecTemplate
+INSERT INTO batch_spec_templates (
+	name,
+	description,
+	namespace_org_id,
+	template,
+	parameters,
+	creator_user_id,
+	created_at,
+	updated_at
+)
+VALUES
+	(%s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING
+	%s
+`
+
+// DeleteBatchSpecTemplate deletes the batch spec template with the given ID.
+// ErrNoResults is returned if no such template exists.
+func (s *Store) DeleteBatchSpecTemplate(ctx context.Context, id int64) (err error) {
+	ctx, endObservation := s.operations.deleteBatchSpecTemplate.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(id)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(deleteBatchSpecTemplateQueryFmtstr, id))
+	if err != nil {
+		return err
+	}
+
+	if rows, err := res.RowsAffected(); err != nil {
+		return err
+	} else if rows == 0 {
+		return ErrNoResults
+	}
+	return nil
+}
+
+var deleteBatchSpecTemplateQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_templates.go:DeleteBatchSpecTemplate
+DELETE FROM batch_spec_templates WHERE id = %s
+`
+
+// GetBatchSpecTemplate gets the batch spec template with the given ID.
+// ErrNoResults is returned if no such template exists.
+func (s *Store) GetBatchSpecTemplate(ctx context.Context, id int64) (t *btypes.BatchSpecTemplate, err error) {
+	ctx, endObservation := s.operations.getBatchSpecTemplate.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(id)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		getBatchSpecTemplateQueryFmtstr,
+		sqlf.Join(batchSpecTemplateColumns, ", "),
+		id,
+	)
+
+	var tmpl btypes.BatchSpecTemplate
+	err = s.query(ctx, q, func(sc scanner) error { return scanBatchSpecTemplate(&tmpl, sc) })
+	if err != nil {
+		return nil, err
+	}
+
+	if tmpl.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &tmpl, nil
+}
+
+var getBatchSpecTemplateQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_templates.go:GetBatchSpecTemplate
+SELECT %s FROM batch_spec_templates
+LEFT JOIN orgs ON orgs.id = batch_spec_templates.namespace_org_id
+WHERE
+	batch_spec_templates.id = %s AND
+	orgs.deleted_at IS NULL
+LIMIT 1
+`
+
+// ListBatchSpecTemplatesOpts captures the query options needed for listing
+// batch spec templates.
+type ListBatchSpecTemplatesOpts struct {
+	LimitOpts
+	Cursor int64
+
+	// NamespaceOrgID lists the templates of the given org. If it's zero,
+	// site-wide templates are listed.
+	NamespaceOrgID int32
+}
+
+// ListBatchSpecTemplates lists the batch spec templates of an org, or the
+// site-wide templates, in the order they were created.
+func (s *Store) ListBatchSpecTemplates(ctx context.Context, opts ListBatchSpecTemplatesOpts) (ts []*btypes.BatchSpecTemplate, next int64, err error) {
+	ctx, endObservation := s.operations.listBatchSpecTemplates.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	q := listBatchSpecTemplatesQuery(opts)
+
+	ts = make([]*btypes.BatchSpecTemplate, 0, opts.DBLimit())
+	err = s.query(ctx, q, func(sc scanner) error {
+		var t btypes.BatchSpecTemplate
+		if err := scanBatchSpecTemplate(&t, sc); err != nil {
+			return err
+		}
+		ts = append(ts, &t)
+		return nil
+	})
+
+	if opts.Limit != 0 && len(ts) == opts.DBLimit() {
+		next = ts[len(ts)-1].ID
+		ts = ts[:len(ts)-1]
+	}
+
+	return ts, next, err
+}
+
+var listBatchSpecTemplatesQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_templates.go:ListBatchSpecTemplates
+SELECT %s FROM batch_spec_templates
+LEFT JOIN orgs ON orgs.id = batch_spec_templates.namespace_org_id
+WHERE %s
+ORDER BY batch_spec_templates.id ASC
+`
+
+func listBatchSpecTemplatesQuery(opts ListBatchSpecTemplatesOpts) *sqlf.Query {
+	preds := []*sqlf.Query{sqlf.Sprintf("orgs.deleted_at IS NULL")}
+
+	if opts.NamespaceOrgID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_spec_templates.namespace_org_id = %s", opts.NamespaceOrgID))
+	} else {
+		preds = append(preds, sqlf.Sprintf("batch_spec_templates.namespace_org_id IS NULL"))
+	}
+
+	if opts.Cursor != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_spec_templates.id >= %s", opts.Cursor))
+	}
+
+	return sqlf.Sprintf(
+		listBatchSpecTemplatesQueryFmtstr+opts.LimitOpts.ToDB(),
+		sqlf.Join(batchSpecTemplateColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+func scanBatchSpecTemplate(t *btypes.BatchSpecTemplate, sc scanner) error {
+	var parameters json.RawMessage
+	if err := sc.Scan(
+		&t.ID,
+		&t.Name,
+		&t.Description,
+		&dbutil.NullInt32{N: &t.NamespaceOrgID},
+		&t.Template,
+		&parameters,
+		&dbutil.NullInt32{N: &t.CreatorUserID},
+		&t.CreatedAt,
+		&t.UpdatedAt,
+	); err != nil {
+		return err
+	}
+
+	return json.Unmarshal(parameters, &t.Parameters)
+}
@@ -0,0 +1,137 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+func testStoreBatchSpecTemplates(t *testing.T, ctx context.Context, s *Store, clock ct.Clock) {
+	user := ct.CreateTestUser(t, s.DB(), true)
+	orgID := ct.InsertTestOrg(t, s.DB(), "batch-spec-templates")
+
+	parameters := []btypes.BatchSpecTemplateParameter{
+		{Name: "package", Type: btypes.BatchSpecTemplateParameterTypeString, Required: true},
+		{Name: "version", Type: btypes.BatchSpecTemplateParameterTypeString, Default: "latest"},
+		{Name: "node", Type: btypes.BatchSpecTemplateParameterTypeNumber, Default: float64(16)},
+	}
+
+	siteTemplates := []*btypes.BatchSpecTemplate{
+		{Name: "bump", Description: "Bump a dependency", Template: "name: bump", Parameters: parameters, CreatorUserID: user.ID},
+		{Name: "add-file", Template: "name: add-file", Parameters: []btypes.BatchSpecTemplateParameter{}},
+	}
+	orgTemplates := []*btypes.BatchSpecTemplate{
+		{Name: "bump", NamespaceOrgID: orgID, Template: "name: bump", Parameters: parameters, CreatorUserID: user.ID},
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		for _, tmpl := range append(siteTemplates, orgTemplates...) {
+			if err := s.CreateBatchSpecTemplate(ctx, tmpl); err != nil {
+				t.Fatal(err)
+			}
+			if tmpl.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+			if have, want := tmpl.CreatedAt, clock.Now(); !have.Equal(want) {
+				t.Fatalf("wrong CreatedAt. want=%s, have=%s", want, have)
+			}
+		}
+
+		for name, tmpl := range map[string]*btypes.BatchSpecTemplate{
+			"duplicate site template": {Name: "bump", Template: "name: bump"},
+			"duplicate org template":  {Name: "bump", NamespaceOrgID: orgID, Template: "name: bump"},
+		} {
+			t.Run(name, func(t *testing.T) {
+				if err := s.CreateBatchSpecTemplate(ctx, tmpl); err != ErrBatchSpecTemplateNameExists {
+					t.Fatalf("unexpected error. want=%s, have=%v", ErrBatchSpecTemplateNameExists, err)
+				}
+			})
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		for _, want := range append(siteTemplates, orgTemplates...) {
+			have, err := s.GetBatchSpecTemplate(ctx, want.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, have); diff != "" {
+				t.Fatalf("wrong template (-want +have):\n%s", diff)
+			}
+		}
+
+		t.Run("not found", func(t *testing.T) {
+			if _, err := s.GetBatchSpecTemplate(ctx, 0xdeadbeef); err != ErrNoResults {
+				t.Fatalf("unexpected error. want=%s, have=%v", ErrNoResults, err)
+			}
+		})
+	})
+
+	t.Run("List", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			opts ListBatchSpecTemplatesOpts
+			want []*btypes.BatchSpecTemplate
+		}{
+			"site": {want: siteTemplates},
+			"org":  {opts: ListBatchSpecTemplatesOpts{NamespaceOrgID: orgID}, want: orgTemplates},
+		} {
+			t.Run(name, func(t *testing.T) {
+				have, next, err := s.ListBatchSpecTemplates(ctx, tc.opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if next != 0 {
+					t.Fatalf("unexpected next cursor: %d", next)
+				}
+				if diff := cmp.Diff(tc.want, have); diff != "" {
+					t.Fatalf("wrong templates (-want +have):\n%s", diff)
+				}
+			})
+		}
+
+		t.Run("paginated", func(t *testing.T) {
+			have, next, err := s.ListBatchSpecTemplates(ctx, ListBatchSpecTemplatesOpts{LimitOpts: LimitOpts{Limit: 1}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(siteTemplates[:1], have); diff != "" {
+				t.Fatalf("wrong first page (-want +have):\n%s", diff)
+			}
+			if have, want := next, siteTemplates[1].ID; have != want {
+				t.Fatalf("wrong next cursor. want=%d, have=%d", want, have)
+			}
+
+			have, next, err = s.ListBatchSpecTemplates(ctx, ListBatchSpecTemplatesOpts{LimitOpts: LimitOpts{Limit: 1}, Cursor: next})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(siteTemplates[1:], have); diff != "" {
+				t.Fatalf("wrong second page (-want +have):\n%s", diff)
+			}
+			if next != 0 {
+				t.Fatalf("unexpected next cursor: %d", next)
+			}
+		})
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		for _, tmpl := range append(siteTemplates, orgTemplates...) {
+			if err := s.DeleteBatchSpecTemplate(ctx, tmpl.ID); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := s.GetBatchSpecTemplate(ctx, tmpl.ID); err != ErrNoResults {
+				t.Fatalf("template not deleted: %v", err)
+			}
+		}
+
+		t.Run("not found", func(t *testing.T) {
+			if err := s.DeleteBatchSpecTemplate(ctx, 0xdeadbeef); err != ErrNoResults {
+				t.Fatalf("unexpected error. want=%s, have=%v", ErrNoResults, err)
+			}
+		})
+	})
+}
@@ -0,0 +1,295 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/opentracing/opentracing-go/log"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+)
+
+var BatchSpecWorkspaceJobColumns = []*sqlf.Query{
+	sqlf.Sprintf("batch_spec_workspace_jobs.id"),
+	sqlf.Sprintf("batch_spec_workspace_jobs.batch_spec_execution_id"),
+	sqlf.Sprintf("batch_spec_workspace_jobs.repo_id"),
+	sqlf.Sprintf("batch_spec_workspace_jobs.branch"),
+	sqlf.Sprintf("batch_spec_workspace_jobs.commit"),
+	sqlf.Sprintf("batch_spec_workspace_jobs.changeset_spec_ids"),
+	sqlf.Sprintf("batch_spec_workspace_jobs.state"),
+	sqlf.Sprintf("batch_spec_workspace_jobs.failure_message"),
+	sqlf.Sprintf("batch_spec_workspace_jobs.started_at"),
+	sqlf.Sprintf("batch_spec_workspace_jobs.finished_at"),
+	sqlf.Sprintf("batch_spec_workspace_jobs.process_after"),
+	sqlf.Sprintf("batch_spec_workspace_jobs.num_resets"),
+	sqlf.Sprintf("batch_spec_workspace_jobs.num_failures"),
+	sqlf.Sprintf("batch_spec_workspace_jobs.execution_logs"),
+	sqlf.Sprintf("batch_spec_workspace_jobs.worker_hostname"),
+	sqlf.Sprintf("batch_spec_workspace_jobs.created_at"),
+	sqlf.Sprintf("batch_spec_workspace_jobs.updated_at"),
+}
+
+var batchSpecWorkspaceJobInsertColumns = []*sqlf.Query{
+	sqlf.Sprintf("batch_spec_execution_id"),
+	sqlf.Sprintf("repo_id"),
+	sqlf.Sprintf("branch"),
+	sqlf.Sprintf("commit"),
+	sqlf.Sprintf("created_at"),
+	sqlf.Sprintf("updated_at"),
+}
+
+// CreateBatchSpecWorkspaceJob creates the given BatchSpecWorkspaceJob, which
+// queues it for processing by an executor.
+func (s *Store) CreateBatchSpecWorkspaceJob(ctx context.Context, j *btypes.BatchSpecWorkspaceJob) (err error) {
+	ctx, endObservation := s.operations.createBatchSpecWorkspaceJob.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchSpecExecutionID", int(j.BatchSpecExecutionID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if j.CreatedAt.IsZero() {
+		j.CreatedAt = s.now()
+	}
+
+	if j.UpdatedAt.IsZero() {
+		j.UpdatedAt = j.CreatedAt
+	}
+
+	q := sqlf.Sprintf(
+		createBatchSpecWorkspaceJobQueryFmtstr,
+		sqlf.Join(batchSpecWorkspaceJobInsertColumns, ", "),
+		j.BatchSpecExecutionID,
+		j.RepoID,
+		j.Branch,
+		j.Commit,
+		j.CreatedAt,
+		j.UpdatedAt,
+		sqlf.Join(BatchSpecWorkspaceJobColumns, ", "),
+	)
+	return s.query(ctx, q, func(sc scanner) error { return scanBatchSpecWorkspaceJob(j, sc) })
+}
+
+var createBatchSpecWorkspaceJobQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_workspace_jobs.go:CreateBatchSpecWorkspaceJob
+INSERT INTO batch_spec_workspace_jobs (%s)
+VALUES (%s, %s, %s, %s, %s, %s)
+RETURNING %s`
+
+// GetBatchSpecWorkspaceJobOpts captures the query options needed for getting
+// a BatchSpecWorkspaceJob.
+type GetBatchSpecWorkspaceJobOpts struct {
+	ID int64
+}
+
+// GetBatchSpecWorkspaceJob gets a BatchSpecWorkspaceJob matching the given
+// options.
+func (s *Store) GetBatchSpecWorkspaceJob(ctx context.Context, opts GetBatchSpecWorkspaceJobOpts) (job *btypes.BatchSpecWorkspaceJob, err error) {
+	ctx, endObservation := s.operations.getBatchSpecWorkspaceJob.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(opts.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		getBatchSpecWorkspaceJobQueryFmtstr,
+		sqlf.Join(BatchSpecWorkspaceJobColumns, ", "),
+		opts.ID,
+	)
+
+	var j btypes.BatchSpecWorkspaceJob
+	err = s.query(ctx, q, func(sc scanner) error { return scanBatchSpecWorkspaceJob(&j, sc) })
+	if err != nil {
+		return nil, err
+	}
+
+	if j.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &j, nil
+}
+
+var getBatchSpecWorkspaceJobQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_workspace_jobs.go:GetBatchSpecWorkspaceJob
+SELECT %s FROM batch_spec_workspace_jobs
+WHERE id = %s
+LIMIT 1
+`
+
+// ListBatchSpecWorkspaceJobsOpts captures the query options needed for
+// listing BatchSpecWorkspaceJobs.
+type ListBatchSpecWorkspaceJobsOpts struct {
+	BatchSpecExecutionID int64
+}
+
+// ListBatchSpecWorkspaceJobs lists the BatchSpecWorkspaceJobs matching the
+// given options, ordered by ID.
+func (s *Store) ListBatchSpecWorkspaceJobs(ctx context.Context, opts ListBatchSpecWorkspaceJobsOpts) (jobs []*btypes.BatchSpecWorkspaceJob, err error) {
+	ctx, endObservation := s.operations.listBatchSpecWorkspaceJobs.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchSpecExecutionID", int(opts.BatchSpecExecutionID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.BatchSpecExecutionID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_spec_workspace_jobs.batch_spec_execution_id = %s", opts.BatchSpecExecutionID))
+	}
+
+	q := sqlf.Sprintf(
+		listBatchSpecWorkspaceJobsQueryFmtstr,
+		sqlf.Join(BatchSpecWorkspaceJobColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+
+	err = s.query(ctx, q, func(sc scanner) error {
+		var j btypes.BatchSpecWorkspaceJob
+		if err := scanBatchSpecWorkspaceJob(&j, sc); err != nil {
+			return err
+		}
+		jobs = append(jobs, &j)
+		return nil
+	})
+	return jobs, err
+}
+
+var listBatchSpecWorkspaceJobsQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_workspace_jobs.go:ListBatchSpecWorkspaceJobs
+SELECT %s FROM batch_spec_workspace_jobs
+WHERE %s
+ORDER BY batch_spec_workspace_jobs.id ASC
+`
+
+// SetBatchSpecWorkspaceJobChangesetSpecs records the IDs of the changeset
+// specs created from the diff produced by the job with the given ID.
+func (s *Store) SetBatchSpecWorkspaceJobChangesetSpecs(ctx context.Context, id int64, changesetSpecIDs []int64) (err error) {
+	ctx, endObservation := s.operations.setBatchSpecWorkspaceJobChangesetSpecs.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(id)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if changesetSpecIDs == nil {
+		changesetSpecIDs = []int64{}
+	}
+
+	return s.Exec(ctx, sqlf.Sprintf(
+		setBatchSpecWorkspaceJobChangesetSpecsQueryFmtstr,
+		pq.Array(changesetSpecIDs),
+		s.now(),
+		id,
+	))
+}
+
+var setBatchSpecWorkspaceJobChangesetSpecsQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_workspace_jobs.go:SetBatchSpecWorkspaceJobChangesetSpecs
+UPDATE batch_spec_workspace_jobs
+SET changeset_spec_ids = %s, updated_at = %s
+WHERE id = %s
+`
+
+// RetryBatchSpecWorkspaceJob queues the failed or errored job with the given
+// ID again. It returns ErrNoResults if no such job exists.
+func (s *Store) RetryBatchSpecWorkspaceJob(ctx context.Context, id int64) (job *btypes.BatchSpecWorkspaceJob, err error) {
+	ctx, endObservation := s.operations.retryBatchSpecWorkspaceJob.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(id)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		retryBatchSpecWorkspaceJobQueryFmtstr,
+		btypes.BatchSpecExecutionStateQueued,
+		s.now(),
+		id,
+		btypes.BatchSpecExecutionStateFailed,
+		btypes.BatchSpecExecutionStateErrored,
+		sqlf.Join(BatchSpecWorkspaceJobColumns, ", "),
+	)
+
+	var j btypes.BatchSpecWorkspaceJob
+	err = s.query(ctx, q, func(sc scanner) error { return scanBatchSpecWorkspaceJob(&j, sc) })
+	if err != nil {
+		return nil, err
+	}
+
+	if j.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &j, nil
+}
+
+var retryBatchSpecWorkspaceJobQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_workspace_jobs.go:RetryBatchSpecWorkspaceJob
+UPDATE batch_spec_workspace_jobs
+SET
+	state = %s,
+	failure_message = NULL,
+	started_at = NULL,
+	finished_at = NULL,
+	process_after = NULL,
+	num_resets = 0,
+	num_failures = 0,
+	execution_logs = NULL,
+	changeset_spec_ids = '{}',
+	updated_at = %s
+WHERE id = %s AND state IN (%s, %s)
+RETURNING %s
+`
+
+func scanBatchSpecWorkspaceJob(j *btypes.BatchSpecWorkspaceJob, sc scanner) error {
+	var executionLogs []dbworkerstore.ExecutionLogEntry
+
+	if err := sc.Scan(
+		&j.ID,
+		&j.BatchSpecExecutionID,
+		&j.RepoID,
+		&j.Branch,
+		&j.Commit,
+		pq.Array(&j.ChangesetSpecIDs),
+		&j.State,
+		&j.FailureMessage,
+		&j.StartedAt,
+		&j.FinishedAt,
+		&j.ProcessAfter,
+		&j.NumResets,
+		&j.NumFailures,
+		pq.Array(&executionLogs),
+		&j.WorkerHostname,
+		&j.CreatedAt,
+		&j.UpdatedAt,
+	); err != nil {
+		return err
+	}
+
+	for _, entry := range executionLogs {
+		j.ExecutionLogs = append(j.ExecutionLogs, workerutil.ExecutionLogEntry(entry))
+	}
+
+	return nil
+}
+
+// ScanFirstBatchSpecWorkspaceJob scans a slice of batch spec workspace jobs
+// from the rows and returns the first.
+func ScanFirstBatchSpecWorkspaceJob(rows *sql.Rows, queryErr error) (_ *btypes.BatchSpecWorkspaceJob, _ bool, err error) {
+	if queryErr != nil {
+		return nil, false, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var jobs []*btypes.BatchSpecWorkspaceJob
+	for rows.Next() {
+		j := &btypes.BatchSpecWorkspaceJob{}
+		if err := scanBatchSpecWorkspaceJob(j, rows); err != nil {
+			return nil, false, err
+		}
+		jobs = append(jobs, j)
+	}
+
+	if len(jobs) == 0 {
+		return &btypes.BatchSpecWorkspaceJob{}, false, nil
+	}
+	return jobs[0], true, nil
+}
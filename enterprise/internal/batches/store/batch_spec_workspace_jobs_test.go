@@ -0,0 +1,142 @@
+package store
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
+
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+func testStoreBatchSpecWorkspaceJobs(t *testing.T, ctx context.Context, s *Store, clock ct.Clock) {
+	repo := ct.TestRepo(t, database.ExternalServicesWith(s), extsvc.KindGitHub)
+	if err := database.ReposWith(s).Create(ctx, repo); err != nil {
+		t.Fatal(err)
+	}
+
+	exec := &btypes.BatchSpecExecution{
+		State:           btypes.BatchSpecExecutionStateCompleted,
+		BatchSpec:       `theSpec: yeah`,
+		UserID:          123,
+		NamespaceUserID: 345,
+	}
+	if err := s.CreateBatchSpecExecution(ctx, exec); err != nil {
+		t.Fatal(err)
+	}
+
+	jobs := make([]*btypes.BatchSpecWorkspaceJob, 0, 2)
+	for i := 0; i < cap(jobs); i++ {
+		jobs = append(jobs, &btypes.BatchSpecWorkspaceJob{
+			BatchSpecExecutionID: exec.ID,
+			RepoID:               repo.ID,
+			Branch:               "refs/heads/main",
+			Commit:               "d34db33f" + strconv.Itoa(i),
+		})
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		for _, job := range jobs {
+			if err := s.CreateBatchSpecWorkspaceJob(ctx, job); err != nil {
+				t.Fatal(err)
+			}
+
+			have := job
+			want := &btypes.BatchSpecWorkspaceJob{
+				ID:                   have.ID,
+				BatchSpecExecutionID: exec.ID,
+				RepoID:               repo.ID,
+				Branch:               have.Branch,
+				Commit:               have.Commit,
+				ChangesetSpecIDs:     []int64{},
+				State:                btypes.BatchSpecExecutionStateQueued,
+				CreatedAt:            clock.Now(),
+				UpdatedAt:            clock.Now(),
+			}
+
+			if have.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		for i, job := range jobs {
+			t.Run(strconv.Itoa(i), func(t *testing.T) {
+				have, err := s.GetBatchSpecWorkspaceJob(ctx, GetBatchSpecWorkspaceJobOpts{ID: job.ID})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(have, job); diff != "" {
+					t.Fatal(diff)
+				}
+			})
+		}
+
+		t.Run("NoResults", func(t *testing.T) {
+			_, have := s.GetBatchSpecWorkspaceJob(ctx, GetBatchSpecWorkspaceJobOpts{ID: 0xdeadbeef})
+			if have != ErrNoResults {
+				t.Fatalf("have err %v, want %v", have, ErrNoResults)
+			}
+		})
+	})
+
+	t.Run("List", func(t *testing.T) {
+		have, err := s.ListBatchSpecWorkspaceJobs(ctx, ListBatchSpecWorkspaceJobsOpts{BatchSpecExecutionID: exec.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(have, jobs); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("SetChangesetSpecs", func(t *testing.T) {
+		if err := s.SetBatchSpecWorkspaceJobChangesetSpecs(ctx, jobs[0].ID, []int64{1, 2}); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.GetBatchSpecWorkspaceJob(ctx, GetBatchSpecWorkspaceJobOpts{ID: jobs[0].ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(have.ChangesetSpecIDs, []int64{1, 2}); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("Retry", func(t *testing.T) {
+		// Queued jobs can't be retried.
+		if _, err := s.RetryBatchSpecWorkspaceJob(ctx, jobs[1].ID); err != ErrNoResults {
+			t.Fatalf("have err %v, want %v", err, ErrNoResults)
+		}
+
+		if err := s.Exec(ctx, sqlf.Sprintf("UPDATE batch_spec_workspace_jobs SET state = %s, failure_message = %s WHERE id = %s", btypes.BatchSpecExecutionStateFailed, "boom", jobs[1].ID)); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.RetryBatchSpecWorkspaceJob(ctx, jobs[1].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if have.State != btypes.BatchSpecExecutionStateQueued {
+			t.Fatalf("wrong state: %s", have.State)
+		}
+		if have.FailureMessage != nil {
+			t.Fatalf("failure message not reset: %q", *have.FailureMessage)
+		}
+	})
+}
@@ -202,6 +202,10 @@ func scanChangesetJob(c *btypes.ChangesetJob, s scanner) error {
 		c.Payload = new(btypes.ChangesetJobClosePayload)
 	case btypes.ChangesetJobTypePublish:
 		c.Payload = new(btypes.ChangesetJobPublishPayload)
+	case btypes.ChangesetJobTypeRerunChecks:
+		c.Payload = new(btypes.ChangesetJobRerunChecksPayload)
+	case btypes.ChangesetJobTypeUpdateBranch:
+		c.Payload = new(btypes.ChangesetJobUpdateBranchPayload)
 	default:
 		return errors.Errorf("unknown job type %q", c.JobType)
 	}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 	"github.com/opentracing/opentracing-go/log"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/search"
@@ -21,6 +22,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -45,6 +47,7 @@ var ChangesetColumns = []*sqlf.Query{
 	sqlf.Sprintf("changesets.external_state"),
 	sqlf.Sprintf("changesets.external_review_state"),
 	sqlf.Sprintf("changesets.external_check_state"),
+	sqlf.Sprintf("changesets.external_checks"),
 	sqlf.Sprintf("changesets.diff_stat_added"),
 	sqlf.Sprintf("changesets.diff_stat_changed"),
 	sqlf.Sprintf("changesets.diff_stat_deleted"),
@@ -63,6 +66,7 @@ var ChangesetColumns = []*sqlf.Query{
 	sqlf.Sprintf("changesets.num_failures"),
 	sqlf.Sprintf("changesets.closing"),
 	sqlf.Sprintf("changesets.syncer_error"),
+	sqlf.Sprintf("changesets.commit_signature"),
 }
 
 // changesetInsertColumns is the list of changeset columns that are modified in
@@ -81,6 +85,7 @@ var changesetInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("external_state"),
 	sqlf.Sprintf("external_review_state"),
 	sqlf.Sprintf("external_check_state"),
+	sqlf.Sprintf("external_checks"),
 	sqlf.Sprintf("diff_stat_added"),
 	sqlf.Sprintf("diff_stat_changed"),
 	sqlf.Sprintf("diff_stat_deleted"),
@@ -99,6 +104,7 @@ var changesetInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("num_failures"),
 	sqlf.Sprintf("closing"),
 	sqlf.Sprintf("syncer_error"),
+	sqlf.Sprintf("commit_signature"),
 	// We additionally store the result of changeset.Title() in a column, so
 	// the business logic for determining it is in one place and the field is
 	// indexable for searching.
@@ -115,6 +121,7 @@ var changesetCodeHostStateInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("external_state"),
 	sqlf.Sprintf("external_review_state"),
 	sqlf.Sprintf("external_check_state"),
+	sqlf.Sprintf("external_checks"),
 	sqlf.Sprintf("diff_stat_added"),
 	sqlf.Sprintf("diff_stat_changed"),
 	sqlf.Sprintf("diff_stat_deleted"),
@@ -142,6 +149,16 @@ func (s *Store) changesetWriteQuery(q string, includeID bool, c *btypes.Changese
 		return nil, err
 	}
 
+	externalChecks, err := externalChecksColumn(c)
+	if err != nil {
+		return nil, err
+	}
+
+	commitSignature, err := commitSignatureColumn(c)
+	if err != nil {
+		return nil, err
+	}
+
 	// Not being able to find a title is fine, we just have a NULL in the database then.
 	title, _ := c.Title()
 
@@ -162,6 +179,7 @@ func (s *Store) changesetWriteQuery(q string, includeID bool, c *btypes.Changese
 		nullStringColumn(string(c.ExternalState)),
 		nullStringColumn(string(c.ExternalReviewState)),
 		nullStringColumn(string(c.ExternalCheckState)),
+		externalChecks,
 		c.DiffStatAdded,
 		c.DiffStatChanged,
 		c.DiffStatDeleted,
@@ -180,6 +198,7 @@ func (s *Store) changesetWriteQuery(q string, includeID bool, c *btypes.Changese
 		c.NumFailures,
 		c.Closing,
 		c.SyncErrorMessage,
+		commitSignature,
 		nullStringColumn(title),
 	}
 
@@ -224,7 +243,7 @@ func (s *Store) CreateChangeset(ctx context.Context, c *btypes.Changeset) (err e
 var createChangesetQueryFmtstr = `
 -- source: enterprise/internal/batches/store.go:CreateChangeset
 INSERT INTO changesets (%s)
-VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING %s
 `
 
@@ -464,6 +483,7 @@ func scanChangesetSyncData(h *btypes.ChangesetSyncData, s scanner) error {
 		&dbutil.NullTime{Time: &h.LatestEvent},
 		&dbutil.NullTime{Time: &h.ExternalUpdatedAt},
 		&h.RepoExternalServiceID,
+		&h.RepoExternalServiceType,
 	)
 }
 
@@ -473,7 +493,8 @@ SELECT changesets.id,
 	changesets.updated_at,
 	max(ce.updated_at) AS latest_event,
 	changesets.external_updated_at,
-	r.external_service_id
+	r.external_service_id,
+	r.external_service_type
 FROM changesets
 LEFT JOIN changeset_events ce ON changesets.id = ce.changeset_id
 JOIN batch_changes ON changesets.batch_change_ids ? batch_changes.id::TEXT
@@ -511,10 +532,14 @@ func listChangesetSyncDataQuery(opts ListChangesetSyncDataOpts) *sqlf.Query {
 //
 // Note that TextSearch is potentially expensive, and should only be specified
 // in conjunction with at least one other option (most likely, BatchChangeID).
+//
+// BatchChangeIDs lists the changesets attached to any of the given batch
+// changes. Unlike with BatchChangeID, archived changesets are always included.
 type ListChangesetsOpts struct {
 	LimitOpts
 	Cursor               int64
 	BatchChangeID        int64
+	BatchChangeIDs       []int64
 	OnlyArchived         bool
 	IncludeArchived      bool
 	IDs                  []int64
@@ -584,6 +609,14 @@ func listChangesetsQuery(opts *ListChangesetsOpts, authzConds *sqlf.Query) *sqlf
 		}
 	}
 
+	if len(opts.BatchChangeIDs) > 0 {
+		batchChangeIDs := make([]string, 0, len(opts.BatchChangeIDs))
+		for _, id := range opts.BatchChangeIDs {
+			batchChangeIDs = append(batchChangeIDs, strconv.Itoa(int(id)))
+		}
+		preds = append(preds, sqlf.Sprintf("changesets.batch_change_ids ?| %s", pq.Array(batchChangeIDs)))
+	}
+
 	if len(opts.IDs) > 0 {
 		ids := make([]*sqlf.Query, 0, len(opts.IDs))
 		for _, id := range opts.IDs {
@@ -731,7 +764,7 @@ func (s *Store) UpdateChangeset(ctx context.Context, cs *btypes.Changeset) (err
 var updateChangesetQueryFmtstr = `
 -- source: enterprise/internal/batches/store_changesets.go:UpdateChangeset
 UPDATE changesets
-SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 WHERE id = %s
 RETURNING
   %s
@@ -765,6 +798,22 @@ func (s *Store) UpdateChangesetUiPublicationState(ctx context.Context, cs *btype
 	return s.updateChangesetColumn(ctx, cs, "ui_publication_state", uiPublicationState)
 }
 
+// UpdateChangesetCommitSignature updates only the `commit_signature` &
+// `updated_at` columns of the given Changeset.
+func (s *Store) UpdateChangesetCommitSignature(ctx context.Context, cs *btypes.Changeset) (err error) {
+	ctx, endObservation := s.operations.updateChangesetCommitSignature.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(cs.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	commitSignature, err := commitSignatureColumn(cs)
+	if err != nil {
+		return err
+	}
+
+	return s.updateChangesetColumn(ctx, cs, "commit_signature", commitSignature)
+}
+
 // updateChangesetColumn updates the column with the given name, setting it to
 // the given value, and updating the updated_at column.
 func (s *Store) updateChangesetColumn(ctx context.Context, cs *btypes.Changeset, name string, val interface{}) error {
@@ -826,6 +875,11 @@ func updateChangesetCodeHostStateQuery(c *btypes.Changeset) (*sqlf.Query, error)
 		return nil, err
 	}
 
+	externalChecks, err := externalChecksColumn(c)
+	if err != nil {
+		return nil, err
+	}
+
 	// Not being able to find a title is fine, we just have a NULL in the database then.
 	title, _ := c.Title()
 
@@ -839,6 +893,7 @@ func updateChangesetCodeHostStateQuery(c *btypes.Changeset) (*sqlf.Query, error)
 		nullStringColumn(string(c.ExternalState)),
 		nullStringColumn(string(c.ExternalReviewState)),
 		nullStringColumn(string(c.ExternalCheckState)),
+		externalChecks,
 		c.DiffStatAdded,
 		c.DiffStatChanged,
 		c.DiffStatDeleted,
@@ -855,7 +910,7 @@ func updateChangesetCodeHostStateQuery(c *btypes.Changeset) (*sqlf.Query, error)
 var updateChangesetCodeHostStateQueryFmtstr = `
 -- source: enterprise/internal/batches/store/changesets.go:UpdateChangesetCodeHostState
 UPDATE changesets
-SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 WHERE id = %s
 RETURNING
   %s
@@ -1124,7 +1179,7 @@ func (n jsonBatchChangeChangesetSet) Value() (driver.Value, error) {
 }
 
 func scanChangeset(t *btypes.Changeset, s scanner) error {
-	var metadata, syncState json.RawMessage
+	var metadata, syncState, externalChecks json.RawMessage
 
 	var (
 		externalState       string
@@ -1133,6 +1188,7 @@ func scanChangeset(t *btypes.Changeset, s scanner) error {
 		failureMessage      string
 		syncErrorMessage    string
 		reconcilerState     string
+		commitSignature     dbutil.NullJSONRawMessage
 	)
 	err := s.Scan(
 		&t.ID,
@@ -1149,6 +1205,7 @@ func scanChangeset(t *btypes.Changeset, s scanner) error {
 		&dbutil.NullString{S: &externalState},
 		&dbutil.NullString{S: &externalReviewState},
 		&dbutil.NullString{S: &externalCheckState},
+		&externalChecks,
 		&t.DiffStatAdded,
 		&t.DiffStatChanged,
 		&t.DiffStatDeleted,
@@ -1167,6 +1224,7 @@ func scanChangeset(t *btypes.Changeset, s scanner) error {
 		&t.NumFailures,
 		&t.Closing,
 		&dbutil.NullString{S: &syncErrorMessage},
+		&commitSignature,
 	)
 	if err != nil {
 		return errors.Wrap(err, "scanning changeset")
@@ -1190,6 +1248,8 @@ func scanChangeset(t *btypes.Changeset, s scanner) error {
 		t.Metadata = new(bitbucketserver.PullRequest)
 	case extsvc.TypeGitLab:
 		t.Metadata = new(gitlab.MergeRequest)
+	case extsvc.TypeAWSCodeCommit:
+		t.Metadata = new(awscodecommit.PullRequest)
 	default:
 		return errors.New("unknown external service type")
 	}
@@ -1200,6 +1260,21 @@ func scanChangeset(t *btypes.Changeset, s scanner) error {
 	if err = json.Unmarshal(syncState, &t.SyncState); err != nil {
 		return errors.Wrapf(err, "scanChangeset: failed to unmarshal sync state: %s", syncState)
 	}
+	// Changesets without checks are stored with an empty array, but we want
+	// them to have no checks rather than an empty set.
+	t.ExternalChecks = nil
+	if err = json.Unmarshal(externalChecks, &t.ExternalChecks); err != nil {
+		return errors.Wrapf(err, "scanChangeset: failed to unmarshal external checks: %s", externalChecks)
+	}
+	if len(t.ExternalChecks) == 0 {
+		t.ExternalChecks = nil
+	}
+	t.CommitSignature = nil
+	if len(commitSignature.Raw) != 0 {
+		if err = json.Unmarshal(commitSignature.Raw, &t.CommitSignature); err != nil {
+			return errors.Wrapf(err, "scanChangeset: failed to unmarshal commit signature: %s", commitSignature.Raw)
+		}
+	}
 
 	return nil
 }
@@ -1454,6 +1529,20 @@ func batchChangesColumn(c *btypes.Changeset) ([]byte, error) {
 	return json.Marshal(assocsAsMap)
 }
 
+func externalChecksColumn(c *btypes.Changeset) ([]byte, error) {
+	if c.ExternalChecks == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(c.ExternalChecks)
+}
+
+func commitSignatureColumn(c *btypes.Changeset) ([]byte, error) {
+	if c.CommitSignature == nil {
+		return nil, nil
+	}
+	return json.Marshal(c.CommitSignature)
+}
+
 func uiPublicationStateColumn(c *btypes.Changeset) *string {
 	var uiPublicationState *string
 	if state := c.UiPublicationState; state != nil {
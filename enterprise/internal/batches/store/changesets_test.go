@@ -23,6 +23,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/signing"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
@@ -137,6 +138,11 @@ func testStoreChangesets(t *testing.T, ctx context.Context, s *Store, clock ct.C
 				th.StartedAt = clock.Now()
 				th.FinishedAt = clock.Now()
 				th.ProcessAfter = clock.Now()
+
+				th.CommitSignature = &btypes.ChangesetCommitSignature{
+					Format:      signing.FormatSSH,
+					Fingerprint: "SHA256:abc",
+				}
 			}
 
 			if err := s.CreateChangeset(ctx, th); err != nil {
@@ -603,6 +609,27 @@ func testStoreChangesets(t *testing.T, ctx context.Context, s *Store, clock ct.C
 			}
 		})
 
+		t.Run("BatchChangeIDs", func(t *testing.T) {
+			archivedChangeset := updateForThisTest(t, changesets[0], func(ch *btypes.Changeset) {
+				ch.BatchChanges[0].IsArchived = true
+			})
+
+			opts := ListChangesetsOpts{
+				BatchChangeIDs: []int64{
+					archivedChangeset.BatchChanges[0].BatchChangeID,
+					changesets[2].BatchChanges[0].BatchChangeID,
+				},
+			}
+			cs, _, err := s.ListChangesets(ctx, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(cs.IDs(), []int64{archivedChangeset.ID, changesets[2].ID}); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
 		t.Run("Limit", func(t *testing.T) {
 			for i := 1; i <= len(changesets); i++ {
 				ts, next, err := s.ListChangesets(ctx, ListChangesetsOpts{LimitOpts: LimitOpts{Limit: i}})
@@ -1132,6 +1159,9 @@ func testStoreChangesets(t *testing.T, ctx context.Context, s *Store, clock ct.C
 		cs.ExternalState = btypes.ChangesetExternalStateDeleted
 		cs.ExternalReviewState = btypes.ChangesetReviewStateApproved
 		cs.ExternalCheckState = btypes.ChangesetCheckStateFailed
+		cs.ExternalChecks = []btypes.ChangesetCheck{
+			{Name: "test", State: btypes.ChangesetCheckStateFailed, URL: "https://ci.example.com/test", RerunID: "suite-1"},
+		}
 		cs.DiffStatAdded = intptr(100)
 		cs.DiffStatChanged = intptr(100)
 		cs.DiffStatDeleted = intptr(100)
@@ -1582,25 +1612,28 @@ func testStoreListChangesetSyncData(t *testing.T, ctx context.Context, s *Store,
 		}
 		want := []*btypes.ChangesetSyncData{
 			{
-				ChangesetID:           changesets[0].ID,
-				UpdatedAt:             clock.Now(),
-				LatestEvent:           clock.Now(),
-				ExternalUpdatedAt:     clock.Now(),
-				RepoExternalServiceID: "https://github.com/",
+				ChangesetID:             changesets[0].ID,
+				UpdatedAt:               clock.Now(),
+				LatestEvent:             clock.Now(),
+				ExternalUpdatedAt:       clock.Now(),
+				RepoExternalServiceID:   "https://github.com/",
+				RepoExternalServiceType: extsvc.TypeGitHub,
 			},
 			{
-				ChangesetID:           changesets[1].ID,
-				UpdatedAt:             clock.Now(),
-				LatestEvent:           clock.Now(),
-				ExternalUpdatedAt:     clock.Now(),
-				RepoExternalServiceID: "https://github.com/",
+				ChangesetID:             changesets[1].ID,
+				UpdatedAt:               clock.Now(),
+				LatestEvent:             clock.Now(),
+				ExternalUpdatedAt:       clock.Now(),
+				RepoExternalServiceID:   "https://github.com/",
+				RepoExternalServiceType: extsvc.TypeGitHub,
 			},
 			{
 				// No events
-				ChangesetID:           changesets[2].ID,
-				UpdatedAt:             clock.Now(),
-				ExternalUpdatedAt:     clock.Now(),
-				RepoExternalServiceID: "https://gitlab.com/",
+				ChangesetID:             changesets[2].ID,
+				UpdatedAt:               clock.Now(),
+				ExternalUpdatedAt:       clock.Now(),
+				RepoExternalServiceID:   "https://gitlab.com/",
+				RepoExternalServiceType: extsvc.TypeGitLab,
 			},
 		}
 		if diff := cmp.Diff(want, hs); diff != "" {
@@ -1615,10 +1648,11 @@ func testStoreListChangesetSyncData(t *testing.T, ctx context.Context, s *Store,
 		}
 		want := []*btypes.ChangesetSyncData{
 			{
-				ChangesetID:           changesets[2].ID,
-				UpdatedAt:             clock.Now(),
-				ExternalUpdatedAt:     clock.Now(),
-				RepoExternalServiceID: "https://gitlab.com/",
+				ChangesetID:             changesets[2].ID,
+				UpdatedAt:               clock.Now(),
+				ExternalUpdatedAt:       clock.Now(),
+				RepoExternalServiceID:   "https://gitlab.com/",
+				RepoExternalServiceType: extsvc.TypeGitLab,
 			},
 		}
 		if diff := cmp.Diff(want, hs); diff != "" {
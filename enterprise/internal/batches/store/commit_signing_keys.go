@@ -0,0 +1,183 @@
+package store
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// commitSigningKeyColumns are used by the commit signing key related Store
+// methods to insert and query commit signing keys.
+var commitSigningKeyColumns = []*sqlf.Query{
+	sqlf.Sprintf("batch_changes_commit_signing_keys.id"),
+	sqlf.Sprintf("batch_changes_commit_signing_keys.external_service_type"),
+	sqlf.Sprintf("batch_changes_commit_signing_keys.external_service_id"),
+	sqlf.Sprintf("batch_changes_commit_signing_keys.format"),
+	sqlf.Sprintf("batch_changes_commit_signing_keys.fingerprint"),
+	sqlf.Sprintf("batch_changes_commit_signing_keys.key"),
+	sqlf.Sprintf("batch_changes_commit_signing_keys.encryption_key_id"),
+	sqlf.Sprintf("batch_changes_commit_signing_keys.created_at"),
+	sqlf.Sprintf("batch_changes_commit_signing_keys.updated_at"),
+}
+
+// CreateCommitSigningKey encrypts and stores the given private key as the
+// commit signing key of the code host.
+func (s *Store) CreateCommitSigningKey(ctx context.Context, k *btypes.CommitSigningKey, privateKey, passphrase string) (err error) {
+	ctx, endObservation := s.operations.createCommitSigningKey.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	if k.CreatedAt.IsZero() {
+		k.CreatedAt = s.now()
+	}
+
+	if k.UpdatedAt.IsZero() {
+		k.UpdatedAt = k.CreatedAt
+	}
+
+	k.Key = s.key
+	if err := k.SetPrivateKey(ctx, privateKey, passphrase); err != nil {
+		return err
+	}
+
+	q := sqlf.Sprintf(
+		createCommitSigningKeyQueryFmtstr,
+		k.ExternalServiceType,
+		k.ExternalServiceID,
+		k.Format,
+		k.Fingerprint,
+		k.EncryptedKey,
+		k.EncryptionKeyID,
+		k.CreatedAt,
+		k.UpdatedAt,
+		sqlf.Join(commitSigningKeyColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc scanner) error {
+		return scanCommitSigningKey(k, sc)
+	})
+}
+
+var createCommitSigningKeyQueryFmtstr = `
+-- source: enterprise/internal/batches/store/commit_signing_keys.go:CreateCommitSigningKey
+INSERT INTO batch_changes_commit_signing_keys (
+	external_service_type,
+	external_service_id,
+	format,
+	fingerprint,
+	key,
+	encryption_key_id,
+	created_at,
+	updated_at
+)
+VALUES
+	(%s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING
+	%s
+`
+
+// DeleteCommitSigningKey deletes the commit signing key with the given ID.
+// ErrNoResults is returned if no such key exists.
+func (s *Store) DeleteCommitSigningKey(ctx context.Context, id int64) (err error) {
+	ctx, endObservation := s.operations.deleteCommitSigningKey.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(id)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(deleteCommitSigningKeyQueryFmtstr, id))
+	if err != nil {
+		return err
+	}
+
+	if rows, err := res.RowsAffected(); err != nil {
+		return err
+	} else if rows == 0 {
+		return ErrNoResults
+	}
+	return nil
+}
+
+var deleteCommitSigningKeyQueryFmtstr = `
+-- source: enterprise/internal/batches/store/commit_signing_keys.go:DeleteCommitSigningKey
+DELETE FROM batch_changes_commit_signing_keys WHERE id = %s
+`
+
+// GetCommitSigningKeyOpts captures the query options needed for getting a
+// commit signing key. A key is looked up by its ID, or by its code host, which
+// requires both the ExternalServiceType and the ExternalServiceID.
+type GetCommitSigningKeyOpts struct {
+	ID                  int64
+	ExternalServiceType string
+	ExternalServiceID   string
+}
+
+// GetCommitSigningKey gets the commit signing key matching the given options.
+// ErrNoResults is returned if no such key exists.
+func (s *Store) GetCommitSigningKey(ctx context.Context, opts GetCommitSigningKeyOpts) (k *btypes.CommitSigningKey, err error) {
+	ctx, endObservation := s.operations.getCommitSigningKey.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(opts.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	// 🚨 SECURITY: A partial code host must not match the key of another code
+	// host, e.g. a repository without a service ID must not be signed with the
+	// key of another instance of the same code host type.
+	byCodeHost := opts.ExternalServiceType != "" || opts.ExternalServiceID != ""
+	if byCodeHost && (opts.ExternalServiceType == "" || opts.ExternalServiceID == "") {
+		return nil, ErrNoResults
+	}
+	if !byCodeHost && opts.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	preds := []*sqlf.Query{}
+	if opts.ID != 0 {
+		preds = append(preds, sqlf.Sprintf("id = %s", opts.ID))
+	}
+	if byCodeHost {
+		preds = append(preds, sqlf.Sprintf("external_service_type = %s", opts.ExternalServiceType))
+		preds = append(preds, sqlf.Sprintf("external_service_id = %s", opts.ExternalServiceID))
+	}
+
+	q := sqlf.Sprintf(
+		getCommitSigningKeyQueryFmtstr,
+		sqlf.Join(commitSigningKeyColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+
+	key := btypes.CommitSigningKey{Key: s.key}
+	err = s.query(ctx, q, func(sc scanner) error { return scanCommitSigningKey(&key, sc) })
+	if err != nil {
+		return nil, err
+	}
+
+	if key.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &key, nil
+}
+
+var getCommitSigningKeyQueryFmtstr = `
+-- source: enterprise/internal/batches/store/commit_signing_keys.go:GetCommitSigningKey
+SELECT %s FROM batch_changes_commit_signing_keys
+WHERE %s
+LIMIT 1
+`
+
+func scanCommitSigningKey(k *btypes.CommitSigningKey, sc scanner) error {
+	return sc.Scan(
+		&k.ID,
+		&k.ExternalServiceType,
+		&k.ExternalServiceID,
+		&k.Format,
+		&k.Fingerprint,
+		&k.EncryptedKey,
+		&k.EncryptionKeyID,
+		&k.CreatedAt,
+		&k.UpdatedAt,
+	)
+}
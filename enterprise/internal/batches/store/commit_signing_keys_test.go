@@ -0,0 +1,114 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/signing"
+)
+
+func testStoreCommitSigningKeys(t *testing.T, ctx context.Context, s *Store, clock ct.Clock) {
+	key := &btypes.CommitSigningKey{
+		ExternalServiceType: extsvc.TypeGitHub,
+		ExternalServiceID:   "https://github.com/",
+		Format:              signing.FormatSSH,
+		Fingerprint:         "SHA256:abc",
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		if err := s.CreateCommitSigningKey(ctx, key, "private key", "passphrase"); err != nil {
+			t.Fatal(err)
+		}
+		if key.ID == 0 {
+			t.Fatal("id should not be zero")
+		}
+		if have, want := key.CreatedAt, clock.Now(); !have.Equal(want) {
+			t.Fatalf("wrong CreatedAt. want=%s, have=%s", want, have)
+		}
+
+		t.Run("duplicate", func(t *testing.T) {
+			err := s.CreateCommitSigningKey(ctx, &btypes.CommitSigningKey{
+				ExternalServiceType: key.ExternalServiceType,
+				ExternalServiceID:   key.ExternalServiceID,
+				Format:              signing.FormatOpenPGP,
+			}, "other key", "")
+			if err == nil {
+				t.Fatal("no error returned for second key of code host")
+			}
+		})
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		for name, opts := range map[string]GetCommitSigningKeyOpts{
+			"ByID": {ID: key.ID},
+			"ByCodeHost": {
+				ExternalServiceType: key.ExternalServiceType,
+				ExternalServiceID:   key.ExternalServiceID,
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				have, err := s.GetCommitSigningKey(ctx, opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(key, have); diff != "" {
+					t.Fatal(diff)
+				}
+
+				conf, err := have.SigningConfig(ctx)
+				if err != nil {
+					t.Fatal(err)
+				}
+				want := &protocol.CommitSigningConfig{
+					Format:     string(signing.FormatSSH),
+					PrivateKey: "private key",
+					Passphrase: "passphrase",
+				}
+				if diff := cmp.Diff(want, conf); diff != "" {
+					t.Fatal(diff)
+				}
+			})
+		}
+
+		for name, opts := range map[string]GetCommitSigningKeyOpts{
+			"NoOptions":               {},
+			"OnlyExternalServiceType": {ExternalServiceType: key.ExternalServiceType},
+			"OnlyExternalServiceID":   {ExternalServiceID: key.ExternalServiceID},
+			"IDWithPartialCodeHost":   {ID: key.ID, ExternalServiceType: key.ExternalServiceType},
+		} {
+			t.Run("Partial"+name, func(t *testing.T) {
+				if _, err := s.GetCommitSigningKey(ctx, opts); err != ErrNoResults {
+					t.Fatalf("wrong error. want=%s, have=%v", ErrNoResults, err)
+				}
+			})
+		}
+
+		t.Run("NoResults", func(t *testing.T) {
+			_, err := s.GetCommitSigningKey(ctx, GetCommitSigningKeyOpts{
+				ExternalServiceType: extsvc.TypeGitLab,
+				ExternalServiceID:   "https://gitlab.com/",
+			})
+			if err != ErrNoResults {
+				t.Fatalf("wrong error. want=%s, have=%v", ErrNoResults, err)
+			}
+		})
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		if err := s.DeleteCommitSigningKey(ctx, key.ID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.GetCommitSigningKey(ctx, GetCommitSigningKeyOpts{ID: key.ID}); err != ErrNoResults {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrNoResults, err)
+		}
+		if err := s.DeleteCommitSigningKey(ctx, key.ID); err != ErrNoResults {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrNoResults, err)
+		}
+	})
+}
@@ -35,6 +35,9 @@ func TestIntegration(t *testing.T) {
 		t.Run("ChangesetJobs", storeTest(db, nil, testStoreChangesetJobs))
 		t.Run("BulkOperations", storeTest(db, nil, testStoreBulkOperations))
 		t.Run("BatchSpecExecutions", storeTest(db, nil, testStoreChangesetSpecExecutions))
+		t.Run("BatchSpecWorkspaceJobs", storeTest(db, nil, testStoreBatchSpecWorkspaceJobs))
+		t.Run("BatchChangeAdmins", storeTest(db, nil, testStoreBatchChangeAdmins))
+		t.Run("BatchSpecTemplates", storeTest(db, nil, testStoreBatchSpecTemplates))
 
 		for name, key := range map[string]encryption.Key{
 			"no key":   nil,
@@ -42,6 +45,7 @@ func TestIntegration(t *testing.T) {
 		} {
 			t.Run(name, func(t *testing.T) {
 				t.Run("SiteCredentials", storeTest(db, key, testStoreSiteCredentials))
+				t.Run("CommitSigningKeys", storeTest(db, key, testStoreCommitSigningKeys))
 			})
 		}
 	})
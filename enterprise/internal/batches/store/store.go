@@ -146,8 +146,25 @@ type operations struct {
 	getRepoDiffStat        *observation.Operation
 	listBatchChanges       *observation.Operation
 
-	createBatchSpecExecution *observation.Operation
-	getBatchSpecExecution    *observation.Operation
+	createBatchChangeAdmin *observation.Operation
+	deleteBatchChangeAdmin *observation.Operation
+	listBatchChangeAdmins  *observation.Operation
+	isBatchChangeAdmin     *observation.Operation
+
+	createBatchSpecTemplate *observation.Operation
+	deleteBatchSpecTemplate *observation.Operation
+	getBatchSpecTemplate    *observation.Operation
+	listBatchSpecTemplates  *observation.Operation
+
+	createBatchSpecExecution       *observation.Operation
+	getBatchSpecExecution          *observation.Operation
+	setBatchSpecExecutionBatchSpec *observation.Operation
+
+	createBatchSpecWorkspaceJob            *observation.Operation
+	getBatchSpecWorkspaceJob               *observation.Operation
+	listBatchSpecWorkspaceJobs             *observation.Operation
+	setBatchSpecWorkspaceJobChangesetSpecs *observation.Operation
+	retryBatchSpecWorkspaceJob             *observation.Operation
 
 	createBatchSpec         *observation.Operation
 	updateBatchSpec         *observation.Operation
@@ -190,6 +207,7 @@ type operations struct {
 	updateChangeset                   *observation.Operation
 	updateChangesetBatchChanges       *observation.Operation
 	updateChangesetUIPublicationState *observation.Operation
+	updateChangesetCommitSignature    *observation.Operation
 	updateChangesetCodeHostState      *observation.Operation
 	getChangesetExternalIDs           *observation.Operation
 	cancelQueuedBatchChangeChangesets *observation.Operation
@@ -202,6 +220,10 @@ type operations struct {
 	listCodeHosts         *observation.Operation
 	getExternalServiceIDs *observation.Operation
 
+	createCommitSigningKey *observation.Operation
+	deleteCommitSigningKey *observation.Operation
+	getCommitSigningKey    *observation.Operation
+
 	createSiteCredential *observation.Operation
 	deleteSiteCredential *observation.Operation
 	getSiteCredential    *observation.Operation
@@ -246,8 +268,25 @@ func newOperations(observationContext *observation.Context) *operations {
 			getBatchChangeDiffStat: op("GetBatchChangeDiffStat"),
 			getRepoDiffStat:        op("GetRepoDiffStat"),
 
-			createBatchSpecExecution: op("CreateBatchSpecExecution"),
-			getBatchSpecExecution:    op("GetBatchSpecExecution"),
+			createBatchChangeAdmin: op("CreateBatchChangeAdmin"),
+			deleteBatchChangeAdmin: op("DeleteBatchChangeAdmin"),
+			listBatchChangeAdmins:  op("ListBatchChangeAdmins"),
+			isBatchChangeAdmin:     op("IsBatchChangeAdmin"),
+
+			createBatchSpecTemplate: op("CreateBatchSpecTemplate"),
+			deleteBatchSpecTemplate: op("DeleteBatchSpecTemplate"),
+			getBatchSpecTemplate:    op("GetBatchSpecTemplate"),
+			listBatchSpecTemplates:  op("ListBatchSpecTemplates"),
+
+			createBatchSpecExecution:       op("CreateBatchSpecExecution"),
+			getBatchSpecExecution:          op("GetBatchSpecExecution"),
+			setBatchSpecExecutionBatchSpec: op("SetBatchSpecExecutionBatchSpec"),
+
+			createBatchSpecWorkspaceJob:            op("CreateBatchSpecWorkspaceJob"),
+			getBatchSpecWorkspaceJob:               op("GetBatchSpecWorkspaceJob"),
+			listBatchSpecWorkspaceJobs:             op("ListBatchSpecWorkspaceJobs"),
+			setBatchSpecWorkspaceJobChangesetSpecs: op("SetBatchSpecWorkspaceJobChangesetSpecs"),
+			retryBatchSpecWorkspaceJob:             op("RetryBatchSpecWorkspaceJob"),
 
 			createBatchSpec:         op("CreateBatchSpec"),
 			updateBatchSpec:         op("UpdateBatchSpec"),
@@ -290,6 +329,7 @@ func newOperations(observationContext *observation.Context) *operations {
 			updateChangeset:                   op("UpdateChangeset"),
 			updateChangesetBatchChanges:       op("UpdateChangesetBatchChanges"),
 			updateChangesetUIPublicationState: op("UpdateChangesetUIPublicationState"),
+			updateChangesetCommitSignature:    op("UpdateChangesetCommitSignature"),
 			updateChangesetCodeHostState:      op("UpdateChangesetCodeHostState"),
 			getChangesetExternalIDs:           op("GetChangesetExternalIDs"),
 			cancelQueuedBatchChangeChangesets: op("CancelQueuedBatchChangeChangesets"),
@@ -302,6 +342,10 @@ func newOperations(observationContext *observation.Context) *operations {
 			listCodeHosts:         op("ListCodeHosts"),
 			getExternalServiceIDs: op("GetExternalServiceIDs"),
 
+			createCommitSigningKey: op("CreateCommitSigningKey"),
+			deleteCommitSigningKey: op("DeleteCommitSigningKey"),
+			getCommitSigningKey:    op("GetCommitSigningKey"),
+
 			createSiteCredential: op("CreateSiteCredential"),
 			deleteSiteCredential: op("DeleteSiteCredential"),
 			getSiteCredential:    op("GetSiteCredential"),
@@ -9,6 +9,9 @@ import (
 var (
 	minSyncDelay = 2 * time.Minute
 	maxSyncDelay = 8 * time.Hour
+	// maxPollingSyncDelay is used instead of maxSyncDelay for code hosts that
+	// don't send webhooks, since polling is the only way to notice changes.
+	maxPollingSyncDelay = 30 * time.Minute
 )
 
 // NextSync computes the time we want the next sync to happen.
@@ -42,8 +45,12 @@ func NextSync(clock func() time.Time, h *btypes.ChangesetSyncData) time.Time {
 		return lastChange.Add(minSyncDelay)
 	}
 
-	if diff > maxSyncDelay {
-		diff = maxSyncDelay
+	maxDelay := maxSyncDelay
+	if btypes.ExternalServiceRequiresPolling(h.RepoExternalServiceType) {
+		maxDelay = maxPollingSyncDelay
+	}
+	if diff > maxDelay {
+		diff = maxDelay
 	}
 	if diff < minSyncDelay {
 		diff = minSyncDelay
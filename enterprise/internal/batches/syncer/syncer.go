@@ -11,6 +11,8 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/global"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/reconciler"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/state"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
@@ -459,7 +461,9 @@ func SyncChangeset(ctx context.Context, syncStore SyncStore, source sources.Chan
 	if err != nil {
 		return err
 	}
+	prevCheckState, prevReviewState := c.ExternalCheckState, c.ExternalReviewState
 	state.SetDerivedState(ctx, syncStore.Repos(), c, events)
+	stateChanged := c.ExternalCheckState != prevCheckState || c.ExternalReviewState != prevReviewState
 
 	tx, err := syncStore.Transact(ctx)
 	if err != nil {
@@ -475,7 +479,22 @@ func SyncChangeset(ctx context.Context, syncStore SyncStore, source sources.Chan
 		return err
 	}
 
-	return tx.UpsertChangesetEvents(ctx, events...)
+	if err := tx.UpsertChangesetEvents(ctx, events...); err != nil {
+		return err
+	}
+
+	// If the changeset can now be merged automatically, hand it to the
+	// reconciler, which merges it or schedules the merge for the next merge
+	// window. Changesets that are already queued or being processed by the
+	// reconciler are left alone.
+	if c.ReconcilerState != btypes.ReconcilerStateCompleted {
+		return nil
+	}
+	due, err := reconciler.AutoMergeDue(ctx, tx, c, stateChanged)
+	if err != nil || !due {
+		return err
+	}
+	return tx.EnqueueChangeset(ctx, c, global.DefaultReconcilerEnqueueState(), btypes.ReconcilerStateCompleted)
 }
 
 func loadChangesetSource(ctx context.Context, cf *httpcli.Factory, syncStore SyncStore, repo *types.Repo) (sources.ChangesetSource, error) {
@@ -17,6 +17,7 @@ func BuildBatchChange(store CreateBatchChanger, name string, userID int32, spec
 	b := &btypes.BatchChange{
 		InitialApplierID: userID,
 		LastApplierID:    userID,
+		OwnerID:          userID,
 		LastAppliedAt:    store.Clock()(),
 		NamespaceUserID:  userID,
 		BatchSpecID:      spec,
@@ -23,6 +23,10 @@ type BatchChange struct {
 	LastApplierID    int32
 	LastAppliedAt    time.Time
 
+	// OwnerID is the user that owns the batch change. It starts out as the
+	// initial applier, but ownership can be transferred to another user.
+	OwnerID int32
+
 	NamespaceUserID int32
 	NamespaceOrgID  int32
 
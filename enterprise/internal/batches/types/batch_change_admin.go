@@ -0,0 +1,17 @@
+package types
+
+import "time"
+
+// A BatchChangeAdmin grants a user, or all members of an org, admin rights on
+// a batch change in addition to its owner.
+//
+// Exactly one of UserID and OrgID is set.
+type BatchChangeAdmin struct {
+	ID            int64
+	BatchChangeID int64
+
+	UserID int32
+	OrgID  int32
+
+	CreatedAt time.Time
+}
@@ -1,12 +1,18 @@
 package types
 
 import (
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/cockroachdb/errors"
+	"github.com/gobwas/glob"
+	"github.com/hashicorp/go-multierror"
 	"github.com/sourcegraph/batch-change-utils/env"
 	"github.com/sourcegraph/batch-change-utils/overridable"
 	"github.com/sourcegraph/batch-change-utils/yaml"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types/scheduler/window"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -41,7 +47,103 @@ func (cs *BatchSpec) Clone() *BatchSpec {
 // UnmarshalValidate unmarshals the RawSpec into Spec and validates it against
 // the BatchSpec schema and does additional semantic validation.
 func (cs *BatchSpec) UnmarshalValidate() error {
-	return yaml.UnmarshalValidate(schema.BatchSpecSchemaJSON, []byte(cs.RawSpec), &cs.Spec)
+	if err := yaml.UnmarshalValidate(schema.BatchSpecSchemaJSON, []byte(cs.RawSpec), &cs.Spec); err != nil {
+		return err
+	}
+	return cs.Spec.validate()
+}
+
+// validate checks the parts of the batch spec that the JSON schema can't
+// express.
+func (s *BatchSpecFields) validate() error {
+	var errs *multierror.Error
+
+	for _, o := range s.ChangesetTemplate.Overrides {
+		if _, err := glob.Compile(o.Repository); err != nil {
+			errs = multierror.Append(errs, errors.Wrapf(err, "changesetTemplate.overrides: invalid repository pattern %q", o.Repository))
+		}
+	}
+
+	if s.TransformChanges != nil {
+		groups := s.TransformChanges.Group
+		for i := range groups {
+			for j := i + 1; j < len(groups); j++ {
+				a, b := groups[i], groups[j]
+				sameRepo := a.Repository == "" || b.Repository == "" || a.Repository == b.Repository
+				if sameRepo && a.Branch == b.Branch && a.BranchSuffix == b.BranchSuffix {
+					errs = multierror.Append(errs, errors.Errorf("transformChanges.group: groups for directories %q and %q use the same branch", a.Directory, b.Directory))
+				}
+			}
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// ValidateServerSideExecution checks that the batch spec only uses features
+// that are supported when it is executed server-side. The steps are run as
+// they are written, so templates, files, outputs and step conditions are
+// rejected instead of silently producing different changes than src-cli.
+func (s *BatchSpecFields) ValidateServerSideExecution() error {
+	var errs *multierror.Error
+
+	unsupported := func(format string, args ...interface{}) {
+		errs = multierror.Append(errs, errors.Errorf(format+" is not supported in server-side execution", args...))
+	}
+
+	for i, step := range s.Steps {
+		if isTemplate(step.Run) {
+			unsupported("steps[%d].run: templating", i)
+		}
+		env, err := step.Env.Resolve(nil)
+		if err != nil {
+			errs = multierror.Append(errs, errors.Wrapf(err, "steps[%d].env", i))
+		}
+		names := make([]string, 0, len(env))
+		for name := range env {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if isTemplate(env[name]) {
+				unsupported("steps[%d].env.%s: templating", i, name)
+			}
+		}
+		if len(step.Files) > 0 {
+			unsupported("steps[%d].files", i)
+		}
+		if len(step.Outputs) > 0 {
+			unsupported("steps[%d].outputs", i)
+		}
+		if step.If != nil {
+			unsupported("steps[%d].if", i)
+		}
+	}
+
+	tmpl := s.ChangesetTemplate
+	for _, f := range []struct{ name, value string }{
+		{"title", tmpl.Title},
+		{"body", tmpl.Body},
+		{"branch", tmpl.Branch},
+		{"commit.message", tmpl.Commit.Message},
+	} {
+		if isTemplate(f.value) {
+			unsupported("changesetTemplate.%s: templating", f.name)
+		}
+	}
+	for i, o := range tmpl.Overrides {
+		if isTemplate(o.Title) || isTemplate(o.Body) || isTemplate(o.Branch) || (o.Commit != nil && isTemplate(o.Commit.Message)) {
+			unsupported("changesetTemplate.overrides[%d]: templating", i)
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// isTemplate returns whether the given value of a batch spec field contains a
+// src-cli template expression.
+func isTemplate(value string) bool {
+	return strings.Contains(value, "${{")
 }
 
 // BatchSpecTTL specifies the TTL of BatchSpecs that haven't been applied
@@ -59,6 +161,7 @@ type BatchSpecFields struct {
 	Description       string                       `json:"description,omitempty" yaml:"description,omitempty"`
 	On                []BatchSpecOn                `json:"on,omitempty" yaml:"on,omitempty"`
 	Steps             []BatchSpecStep              `json:"steps,omitempty" yaml:"steps,omitempty"`
+	TransformChanges  *TransformChanges            `json:"transformChanges,omitempty" yaml:"transformChanges,omitempty"`
 	ImportChangeset   []BatchChangeImportChangeset `json:"importChangesets,omitempty" yaml:"importChangesets,omitempty"`
 	ChangesetTemplate ChangesetTemplate            `json:"changesetTemplate,omitempty" yaml:"changesetTemplate,omitempty"`
 }
@@ -69,9 +172,31 @@ type BatchSpecOn struct {
 }
 
 type BatchSpecStep struct {
-	Run       string          `json:"run" yaml:"run"`
-	Container string          `json:"container" yaml:"container"`
-	Env       env.Environment `json:"env,omitempty" yaml:"env,omitempty"`
+	Run       string                `json:"run" yaml:"run"`
+	Container string                `json:"container" yaml:"container"`
+	Env       env.Environment       `json:"env,omitempty" yaml:"env,omitempty"`
+	Files     map[string]string     `json:"files,omitempty" yaml:"files,omitempty"`
+	Outputs   map[string]StepOutput `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+	If        interface{}           `json:"if,omitempty" yaml:"if,omitempty"`
+}
+
+// StepOutput is an output variable of a step.
+type StepOutput struct {
+	Value  string `json:"value" yaml:"value"`
+	Format string `json:"format,omitempty" yaml:"format,omitempty"`
+}
+
+type TransformChanges struct {
+	Group []Group `json:"group,omitempty" yaml:"group"`
+}
+
+// Group describes the changes in a directory of a repository that are split
+// off into a separate changeset on their own branch.
+type Group struct {
+	Directory    string `json:"directory,omitempty" yaml:"directory"`
+	Branch       string `json:"branch,omitempty" yaml:"branch"`
+	BranchSuffix string `json:"branchSuffix,omitempty" yaml:"branchSuffix"`
+	Repository   string `json:"repository,omitempty" yaml:"repository"`
 }
 
 type BatchChangeImportChangeset struct {
@@ -80,13 +205,115 @@ type BatchChangeImportChangeset struct {
 }
 
 type ChangesetTemplate struct {
-	Title     string                   `json:"title,omitempty" yaml:"title,omitempty"`
-	Body      string                   `json:"body,omitempty" yaml:"body,omitempty"`
-	Branch    string                   `json:"branch,omitempty" yaml:"branch,omitempty"`
-	Commit    CommitTemplate           `json:"commit,omitempty" yaml:"commit,omitempty"`
-	Published overridable.BoolOrString `json:"published,omitempty" yaml:"published,omitempty"`
+	Title     string                      `json:"title,omitempty" yaml:"title,omitempty"`
+	Body      string                      `json:"body,omitempty" yaml:"body,omitempty"`
+	Branch    string                      `json:"branch,omitempty" yaml:"branch,omitempty"`
+	Commit    CommitTemplate              `json:"commit,omitempty" yaml:"commit,omitempty"`
+	Published overridable.BoolOrString    `json:"published,omitempty" yaml:"published,omitempty"`
+	AutoMerge *ChangesetAutoMerge         `json:"autoMerge,omitempty" yaml:"autoMerge,omitempty"`
+	Overrides []ChangesetTemplateOverride `json:"overrides,omitempty" yaml:"overrides,omitempty"`
+}
+
+// ChangesetTemplateOverride replaces the fields of a ChangesetTemplate that it
+// sets in the repositories matched by its Repository glob pattern.
+type ChangesetTemplateOverride struct {
+	Repository string          `json:"repository" yaml:"repository"`
+	Title      string          `json:"title,omitempty" yaml:"title,omitempty"`
+	Body       string          `json:"body,omitempty" yaml:"body,omitempty"`
+	Branch     string          `json:"branch,omitempty" yaml:"branch,omitempty"`
+	Commit     *CommitTemplate `json:"commit,omitempty" yaml:"commit,omitempty"`
+}
+
+// ChangesetAutoMerge configures whether published changesets are merged by
+// Sourcegraph once their checks have passed and they have been approved.
+type ChangesetAutoMerge struct {
+	Enabled     bool                      `json:"enabled" yaml:"enabled"`
+	Squash      bool                      `json:"squash,omitempty" yaml:"squash,omitempty"`
+	MergeWindow *ChangesetAutoMergeWindow `json:"mergeWindow,omitempty" yaml:"mergeWindow,omitempty"`
+}
+
+// ChangesetAutoMergeWindow restricts automatic merges to certain days and
+// times of day, in UTC.
+type ChangesetAutoMergeWindow struct {
+	Days  []string `json:"days,omitempty" yaml:"days,omitempty"`
+	Start string   `json:"start,omitempty" yaml:"start,omitempty"`
+	End   string   `json:"end,omitempty" yaml:"end,omitempty"`
+}
+
+// NextMergeAt returns the earliest time at or after now at which changesets
+// may be merged automatically, according to the merge window.
+func (am *ChangesetAutoMerge) NextMergeAt(now time.Time) (time.Time, error) {
+	if am.MergeWindow == nil {
+		return now, nil
+	}
+
+	w, err := window.NewMergeWindow(am.MergeWindow.Days, am.MergeWindow.Start, am.MergeWindow.End)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return w.NextOpenAfter(now.UTC()), nil
 }
 
 type CommitTemplate struct {
-	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+	Message string           `json:"message,omitempty" yaml:"message,omitempty"`
+	Author  *GitCommitAuthor `json:"author,omitempty" yaml:"author,omitempty"`
+}
+
+type GitCommitAuthor struct {
+	Name  string `json:"name" yaml:"name"`
+	Email string `json:"email" yaml:"email"`
+}
+
+// ForRepository returns the changeset template with the overrides that match
+// the given repository name applied in order.
+func (t ChangesetTemplate) ForRepository(repoName string) (ChangesetTemplate, error) {
+	for _, o := range t.Overrides {
+		pattern, err := glob.Compile(o.Repository)
+		if err != nil {
+			return t, errors.Wrapf(err, "compiling repository pattern %q", o.Repository)
+		}
+		if !pattern.Match(repoName) {
+			continue
+		}
+
+		if o.Title != "" {
+			t.Title = o.Title
+		}
+		if o.Body != "" {
+			t.Body = o.Body
+		}
+		if o.Branch != "" {
+			t.Branch = o.Branch
+		}
+		if o.Commit != nil && o.Commit.Message != "" {
+			t.Commit.Message = o.Commit.Message
+		}
+	}
+
+	return t, nil
+}
+
+// BranchFor returns the branch the changes in the group are pushed to, given
+// the branch of the changeset template.
+func (g Group) BranchFor(defaultBranch string) string {
+	if g.Branch != "" {
+		return g.Branch
+	}
+	return defaultBranch + g.BranchSuffix
+}
+
+// GroupsForRepository returns the groups that apply to the repository with
+// the given name.
+func (t *TransformChanges) GroupsForRepository(repoName string) []Group {
+	if t == nil {
+		return nil
+	}
+
+	groups := make([]Group, 0, len(t.Group))
+	for _, g := range t.Group {
+		if g.Repository == "" || g.Repository == repoName {
+			groups = append(groups, g)
+		}
+	}
+	return groups
 }
@@ -0,0 +1,432 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/hashicorp/go-multierror"
+)
+
+// A BatchSpecTemplate is a batch spec with placeholders for typed input
+// parameters, from which batch specs for common tasks are created.
+//
+// Templates with a zero NamespaceOrgID are site-wide templates.
+type BatchSpecTemplate struct {
+	ID          int64
+	Name        string
+	Description string
+
+	NamespaceOrgID int32
+
+	// Template is the batch spec YAML with ${{ inputs.<name> }} placeholders.
+	// All other ${{ }} expressions are left untouched, so that they're
+	// evaluated when the batch spec is executed.
+	Template   string
+	Parameters []BatchSpecTemplateParameter
+
+	CreatorUserID int32
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// BatchSpecTemplateParameterType is the type of the value of a
+// BatchSpecTemplateParameter.
+type BatchSpecTemplateParameterType string
+
+const (
+	BatchSpecTemplateParameterTypeString  BatchSpecTemplateParameterType = "string"
+	BatchSpecTemplateParameterTypeNumber  BatchSpecTemplateParameterType = "number"
+	BatchSpecTemplateParameterTypeBoolean BatchSpecTemplateParameterType = "boolean"
+	// BatchSpecTemplateParameterTypeList is a list of strings.
+	BatchSpecTemplateParameterTypeList BatchSpecTemplateParameterType = "list"
+)
+
+// Valid returns true if the type is supported.
+func (t BatchSpecTemplateParameterType) Valid() bool {
+	switch t {
+	case BatchSpecTemplateParameterTypeString,
+		BatchSpecTemplateParameterTypeNumber,
+		BatchSpecTemplateParameterTypeBoolean,
+		BatchSpecTemplateParameterTypeList:
+		return true
+	default:
+		return false
+	}
+}
+
+// BatchSpecTemplateParameter is an input parameter of a BatchSpecTemplate.
+type BatchSpecTemplateParameter struct {
+	Name        string                         `json:"name"`
+	Type        BatchSpecTemplateParameterType `json:"type"`
+	Description string                         `json:"description,omitempty"`
+	Required    bool                           `json:"required,omitempty"`
+	// Default is used when no input is given for the parameter. It is a
+	// value as decoded by encoding/json.
+	Default interface{} `json:"default,omitempty"`
+}
+
+var (
+	batchSpecTemplateParameterNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+	batchSpecTemplatePlaceholderPattern   = regexp.MustCompile(`\$\{\{\s*inputs\.([^\s}]*)\s*\}\}`)
+)
+
+// Validate checks that the parameters are well-formed, that the template only
+// references declared parameters, and that the template renders into a valid
+// batch spec.
+func (t *BatchSpecTemplate) Validate() error {
+	var errs *multierror.Error
+
+	if strings.TrimSpace(t.Name) == "" {
+		errs = multierror.Append(errs, errors.New("template name must not be empty"))
+	}
+
+	declared := make(map[string]BatchSpecTemplateParameter, len(t.Parameters))
+	for _, p := range t.Parameters {
+		if !batchSpecTemplateParameterNamePattern.MatchString(p.Name) {
+			errs = multierror.Append(errs, errors.Errorf("parameter %q: invalid name", p.Name))
+			continue
+		}
+		if _, ok := declared[p.Name]; ok {
+			errs = multierror.Append(errs, errors.Errorf("parameter %q: declared more than once", p.Name))
+			continue
+		}
+		declared[p.Name] = p
+
+		if !p.Type.Valid() {
+			errs = multierror.Append(errs, errors.Errorf("parameter %q: unsupported type %q", p.Name, p.Type))
+			continue
+		}
+		if p.Default != nil {
+			if _, err := p.format(p.Default); err != nil {
+				errs = multierror.Append(errs, errors.Wrapf(err, "parameter %q: invalid default", p.Name))
+			}
+		}
+	}
+
+	for _, m := range batchSpecTemplatePlaceholderPattern.FindAllStringSubmatch(t.Template, -1) {
+		if _, ok := declared[m[1]]; !ok {
+			errs = multierror.Append(errs, errors.Errorf("template references undeclared parameter %q", m[1]))
+		}
+	}
+
+	if errs.ErrorOrNil() != nil {
+		return errs
+	}
+
+	// Render the template with example values for the parameters without a
+	// default, to catch templates that can never produce a valid batch spec.
+	inputs := make(map[string]interface{}, len(t.Parameters))
+	for _, p := range t.Parameters {
+		if p.Default == nil {
+			inputs[p.Name] = p.Type.exampleValue()
+		}
+	}
+	_, err := t.Render(inputs)
+	return err
+}
+
+// Render replaces the placeholders in the template with the given inputs, or
+// the parameters' defaults for missing inputs, and returns the resulting raw
+// batch spec. An error is returned if the inputs don't match the parameters or
+// if the result isn't a valid batch spec.
+func (t *BatchSpecTemplate) Render(inputs map[string]interface{}) (string, error) {
+	var errs *multierror.Error
+
+	values := make(map[string]string, len(t.Parameters))
+	for _, p := range t.Parameters {
+		input, ok := inputs[p.Name]
+		if !ok || input == nil {
+			if p.Required && p.Default == nil {
+				errs = multierror.Append(errs, errors.Errorf("missing input for required parameter %q", p.Name))
+				continue
+			}
+			input = p.Default
+		}
+		if input == nil {
+			input = p.Type.zeroValue()
+		}
+
+		value, err := p.format(input)
+		if err != nil {
+			errs = multierror.Append(errs, errors.Wrapf(err, "parameter %q", p.Name))
+			continue
+		}
+		values[p.Name] = value
+	}
+
+	for name := range inputs {
+		if _, ok := t.parameter(name); !ok {
+			errs = multierror.Append(errs, errors.Errorf("input for unknown parameter %q", name))
+		}
+	}
+
+	if err := errs.ErrorOrNil(); err != nil {
+		return "", err
+	}
+
+	rawSpec, err := t.render(values)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := NewBatchSpecFromRaw(rawSpec); err != nil {
+		return "", errors.Wrap(err, "rendered template is not a valid batch spec")
+	}
+	return rawSpec, nil
+}
+
+// blockScalarHeaderPattern matches the value of a line that starts a literal
+// or folded block scalar, e.g. "|", ">-" or "| # comment".
+var blockScalarHeaderPattern = regexp.MustCompile(`^[|>][-+0-9]*\s*(#.*)?$`)
+
+// render replaces the placeholders in the template with the given formatted
+// values. The template is processed line by line, and the values are inserted
+// as YAML scalars rather than verbatim, so that they can't change the
+// structure of the batch spec:
+//
+//   - In a plain scalar, a placeholder that is the whole scalar is replaced
+//     with a double-quoted string, or the value itself for other types. If the
+//     scalar contains more text, the whole scalar is turned into a
+//     double-quoted string.
+//   - In a quoted scalar or flow collection, the value is escaped accordingly.
+//   - In a block scalar, line breaks in the value are indented like the line.
+//
+// Placeholders in mapping keys are not supported.
+func (t *BatchSpecTemplate) render(values map[string]string) (string, error) {
+	replace := func(text string, f func(p BatchSpecTemplateParameter, value string) string) string {
+		return batchSpecTemplatePlaceholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+			name := batchSpecTemplatePlaceholderPattern.FindStringSubmatch(placeholder)[1]
+			p, ok := t.parameter(name)
+			if !ok {
+				return placeholder
+			}
+			return f(p, values[name])
+		})
+	}
+	verbatim := func(p BatchSpecTemplateParameter, value string) string { return value }
+	quoteStrings := func(p BatchSpecTemplateParameter, value string) string {
+		if p.Type == BatchSpecTemplateParameterTypeString {
+			return doubleQuoteYAML(value)
+		}
+		return value
+	}
+
+	lines := strings.Split(t.Template, "\n")
+	// The indentation of the node that started the current block scalar, or
+	// -1 outside of block scalars.
+	blockIndent := -1
+	for i, line := range lines {
+		cr := strings.HasSuffix(line, "\r")
+		line = strings.TrimSuffix(line, "\r")
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+
+		if blockIndent >= 0 {
+			if strings.TrimSpace(line) == "" || indent > blockIndent {
+				lines[i] = replace(line, func(p BatchSpecTemplateParameter, value string) string {
+					return strings.ReplaceAll(value, "\n", "\n"+line[:indent])
+				})
+				if cr {
+					lines[i] += "\r"
+				}
+				continue
+			}
+			blockIndent = -1
+		}
+
+		prefix, value := splitYAMLLine(line, indent)
+		if blockScalarHeaderPattern.MatchString(value) {
+			// The content of a block scalar that is a mapping value is
+			// indented relative to the key, which can follow sequence entry
+			// indicators.
+			blockIndent = indent
+			if key := strings.TrimLeft(prefix, " -"); key != "" {
+				blockIndent = len(prefix) - len(key)
+			}
+		}
+		if !batchSpecTemplatePlaceholderPattern.MatchString(line) {
+			continue
+		}
+		if batchSpecTemplatePlaceholderPattern.MatchString(prefix) {
+			return "", errors.Errorf("line %d: parameter placeholders are only supported in values", i+1)
+		}
+
+		switch value[0] {
+		case '"':
+			value = replace(value, func(p BatchSpecTemplateParameter, value string) string {
+				quoted := doubleQuoteYAML(value)
+				return quoted[1 : len(quoted)-1]
+			})
+
+		case '\'':
+			var err error
+			value = replace(value, func(p BatchSpecTemplateParameter, value string) string {
+				if strings.Contains(value, "\n") {
+					err = errors.Errorf("line %d: inputs in single-quoted strings must not contain line breaks", i+1)
+				}
+				return strings.ReplaceAll(value, "'", "''")
+			})
+			if err != nil {
+				return "", err
+			}
+
+		case '[', '{':
+			value = replace(value, quoteStrings)
+
+		default:
+			// A plain scalar ends at a comment.
+			scalar, comment := value, ""
+			if j := strings.Index(value, " #"); j >= 0 {
+				scalar, comment = strings.TrimRight(value[:j], " "), value[j:]
+			}
+			if loc := batchSpecTemplatePlaceholderPattern.FindStringIndex(scalar); loc[0] == 0 && loc[1] == len(scalar) {
+				scalar = replace(scalar, quoteStrings)
+			} else {
+				scalar = doubleQuoteYAML(replace(scalar, verbatim))
+			}
+			value = scalar + comment
+		}
+
+		lines[i] = prefix + value
+		if cr {
+			lines[i] += "\r"
+		}
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// splitYAMLLine splits a line of a YAML block into the prefix made of the
+// indentation, sequence entry indicators and mapping key, and the value.
+func splitYAMLLine(line string, indent int) (prefix, value string) {
+	p := indent
+	for strings.HasPrefix(line[p:], "- ") {
+		p += 2
+		p += len(line[p:]) - len(strings.TrimLeft(line[p:], " "))
+	}
+
+	rest := line[p:]
+	end := len(rest)
+	if loc := batchSpecTemplatePlaceholderPattern.FindStringIndex(rest); loc != nil {
+		end = loc[0]
+	}
+	if j := strings.Index(rest[:end], " #"); j >= 0 {
+		end = j
+	}
+	if j := strings.Index(rest[:end], ": "); j >= 0 && rest[0] != '"' && rest[0] != '\'' {
+		p += j + 2
+		p += len(line[p:]) - len(strings.TrimLeft(line[p:], " "))
+	}
+
+	return line[:p], line[p:]
+}
+
+// doubleQuoteYAML returns s as a double-quoted YAML scalar. A JSON string is a
+// valid double-quoted YAML scalar.
+func doubleQuoteYAML(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func (t *BatchSpecTemplate) parameter(name string) (BatchSpecTemplateParameter, bool) {
+	for _, p := range t.Parameters {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return BatchSpecTemplateParameter{}, false
+}
+
+// format checks that the value has the parameter's type and returns the text
+// that replaces the parameter's placeholders.
+func (p BatchSpecTemplateParameter) format(value interface{}) (string, error) {
+	switch p.Type {
+	case BatchSpecTemplateParameterTypeString:
+		s, ok := value.(string)
+		if !ok {
+			return "", errors.Errorf("expected a string, got %T", value)
+		}
+		// Strings are quoted or indented when the template is rendered.
+		return strings.ReplaceAll(strings.ReplaceAll(s, "\r\n", "\n"), "\r", "\n"), nil
+
+	case BatchSpecTemplateParameterTypeNumber:
+		var f float64
+		// Integers are decoded as int32 from GraphQL literals.
+		switch n := value.(type) {
+		case float64:
+			f = n
+		case int32:
+			f = float64(n)
+		case int:
+			f = float64(n)
+		default:
+			return "", errors.Errorf("expected a number, got %T", value)
+		}
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return "", errors.Errorf("expected a finite number, got %v", f)
+		}
+		return strconv.FormatFloat(f, 'f', -1, 64), nil
+
+	case BatchSpecTemplateParameterTypeBoolean:
+		b, ok := value.(bool)
+		if !ok {
+			return "", errors.Errorf("expected a boolean, got %T", value)
+		}
+		return strconv.FormatBool(b), nil
+
+	case BatchSpecTemplateParameterTypeList:
+		list, ok := value.([]interface{})
+		if !ok {
+			return "", errors.Errorf("expected a list, got %T", value)
+		}
+		for i, elem := range list {
+			if _, ok := elem.(string); !ok {
+				return "", errors.Errorf("expected a list of strings, got %T at index %d", elem, i)
+			}
+		}
+		// A JSON array is a YAML flow sequence.
+		encoded, err := json.Marshal(list)
+		if err != nil {
+			return "", err
+		}
+		return string(encoded), nil
+
+	default:
+		return "", errors.Errorf("unsupported type %q", p.Type)
+	}
+}
+
+func (t BatchSpecTemplateParameterType) zeroValue() interface{} {
+	switch t {
+	case BatchSpecTemplateParameterTypeNumber:
+		return float64(0)
+	case BatchSpecTemplateParameterTypeBoolean:
+		return false
+	case BatchSpecTemplateParameterTypeList:
+		return []interface{}{}
+	default:
+		return ""
+	}
+}
+
+func (t BatchSpecTemplateParameterType) exampleValue() interface{} {
+	switch t {
+	case BatchSpecTemplateParameterTypeNumber:
+		return float64(1)
+	case BatchSpecTemplateParameterTypeBoolean:
+		return true
+	case BatchSpecTemplateParameterTypeList:
+		return []interface{}{"example"}
+	default:
+		return "example"
+	}
+}
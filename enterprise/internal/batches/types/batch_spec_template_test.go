@@ -0,0 +1,228 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+const testBatchSpecTemplate = `name: bump-${{ inputs.package }}
+on:
+  - repositoriesMatchingQuery: ${{ inputs.package }} file:package.json
+steps:
+  - run: npm install ${{ inputs.package }}@${{ inputs.version }} && echo ${{ repository.name }}
+    container: node:${{ inputs.node }}
+importChangesets:
+  - repository: github.com/sourcegraph/sourcegraph
+    externalIDs: ${{ inputs.imports }}
+changesetTemplate:
+  title: Bump ${{ inputs.package }}
+  body: Bumps ${{ inputs.package }} to ${{ inputs.version }}
+  branch: bump-${{ inputs.package }}
+  commit:
+    message: Bump ${{ inputs.package }}
+  published: ${{ inputs.publish }}
+`
+
+func testBatchSpecTemplateParameters() []BatchSpecTemplateParameter {
+	return []BatchSpecTemplateParameter{
+		{Name: "package", Type: BatchSpecTemplateParameterTypeString, Required: true},
+		{Name: "version", Type: BatchSpecTemplateParameterTypeString, Default: "latest"},
+		{Name: "node", Type: BatchSpecTemplateParameterTypeNumber, Default: float64(16)},
+		{Name: "publish", Type: BatchSpecTemplateParameterTypeBoolean},
+		{Name: "imports", Type: BatchSpecTemplateParameterTypeList},
+	}
+}
+
+func TestBatchSpecTemplateRender(t *testing.T) {
+	tmpl := &BatchSpecTemplate{
+		Name:       "bump",
+		Template:   testBatchSpecTemplate,
+		Parameters: testBatchSpecTemplateParameters(),
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		rawSpec, err := tmpl.Render(map[string]interface{}{
+			"package": "lodash",
+			"publish": true,
+			"imports": []interface{}{"123", "456"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		spec, err := NewBatchSpecFromRaw(rawSpec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := spec.Spec.Name, "bump-lodash"; have != want {
+			t.Fatalf("wrong name. want=%q, have=%q", want, have)
+		}
+		for _, want := range []string{
+			"npm install lodash@latest && echo ${{ repository.name }}",
+			`container: "node:16"`,
+			"published: true",
+			`externalIDs: ["123","456"]`,
+		} {
+			if !strings.Contains(rawSpec, want) {
+				t.Fatalf("rendered spec doesn't contain %q:\n%s", want, rawSpec)
+			}
+		}
+	})
+
+	t.Run("inputs are quoted", func(t *testing.T) {
+		version := "1.0 # comment\nchangesetTemplate: {}"
+		rawSpec, err := tmpl.Render(map[string]interface{}{
+			"package": "lodash",
+			"version": version,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		spec, err := NewBatchSpecFromRaw(rawSpec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := spec.Spec.ChangesetTemplate.Body, "Bumps lodash to "+version; have != want {
+			t.Fatalf("wrong body. want=%q, have=%q", want, have)
+		}
+		if have, want := len(spec.Spec.Steps), 1; have != want {
+			t.Fatalf("wrong number of steps. want=%d, have=%d", want, have)
+		}
+		if have, want := spec.Spec.Steps[0].Run, "npm install lodash@"+version+" && echo ${{ repository.name }}"; have != want {
+			t.Fatalf("wrong run. want=%q, have=%q", want, have)
+		}
+	})
+
+	t.Run("inputs in block scalars are indented", func(t *testing.T) {
+		tmpl := &BatchSpecTemplate{
+			Name: "bump",
+			Template: strings.Replace(testBatchSpecTemplate,
+				"  - run: npm install ${{ inputs.package }}@${{ inputs.version }} && echo ${{ repository.name }}\n",
+				"  - run: |\n      echo ${{ inputs.version }}\n      npm install ${{ inputs.package }}\n", 1),
+			Parameters: testBatchSpecTemplateParameters(),
+		}
+		rawSpec, err := tmpl.Render(map[string]interface{}{
+			"package": "lodash",
+			"version": "1.0\ncontainer: alpine",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		spec, err := NewBatchSpecFromRaw(rawSpec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := spec.Spec.Steps[0].Run, "echo 1.0\ncontainer: alpine\nnpm install lodash\n"; have != want {
+			t.Fatalf("wrong run. want=%q, have=%q", want, have)
+		}
+		if have, want := spec.Spec.Steps[0].Container, "node:16"; have != want {
+			t.Fatalf("wrong container. want=%q, have=%q", want, have)
+		}
+	})
+
+	for name, tc := range map[string]struct {
+		inputs map[string]interface{}
+		err    string
+	}{
+		"missing required input": {
+			inputs: map[string]interface{}{},
+			err:    `missing input for required parameter "package"`,
+		},
+		"unknown input": {
+			inputs: map[string]interface{}{"package": "lodash", "nope": "x"},
+			err:    `input for unknown parameter "nope"`,
+		},
+		"wrong type": {
+			inputs: map[string]interface{}{"package": "lodash", "node": "16"},
+			err:    "expected a number",
+		},
+		"wrong list element type": {
+			inputs: map[string]interface{}{"package": "lodash", "imports": []interface{}{"123", float64(456)}},
+			err:    "expected a list of strings",
+		},
+		"invalid batch spec": {
+			inputs: map[string]interface{}{"package": "not a valid name"},
+			err:    "rendered template is not a valid batch spec",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := tmpl.Render(tc.inputs)
+			if err == nil {
+				t.Fatal("no error returned")
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("wrong error. want substring=%q, have=%q", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestBatchSpecTemplateValidate(t *testing.T) {
+	if err := (&BatchSpecTemplate{
+		Name:       "bump",
+		Template:   testBatchSpecTemplate,
+		Parameters: testBatchSpecTemplateParameters(),
+	}).Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		template *BatchSpecTemplate
+		err      string
+	}{
+		"empty name": {
+			template: &BatchSpecTemplate{Template: testBatchSpecTemplate, Parameters: testBatchSpecTemplateParameters()},
+			err:      "template name must not be empty",
+		},
+		"undeclared parameter": {
+			template: &BatchSpecTemplate{Name: "bump", Template: testBatchSpecTemplate, Parameters: testBatchSpecTemplateParameters()[:1]},
+			err:      `template references undeclared parameter "version"`,
+		},
+		"duplicate parameter": {
+			template: &BatchSpecTemplate{
+				Name:     "bump",
+				Template: testBatchSpecTemplate,
+				Parameters: append(testBatchSpecTemplateParameters(), BatchSpecTemplateParameter{
+					Name: "package", Type: BatchSpecTemplateParameterTypeString,
+				}),
+			},
+			err: `parameter "package": declared more than once`,
+		},
+		"unsupported type": {
+			template: &BatchSpecTemplate{
+				Name:     "bump",
+				Template: testBatchSpecTemplate,
+				Parameters: append(testBatchSpecTemplateParameters(), BatchSpecTemplateParameter{
+					Name: "file", Type: "file",
+				}),
+			},
+			err: `parameter "file": unsupported type "file"`,
+		},
+		"invalid default": {
+			template: &BatchSpecTemplate{
+				Name:     "bump",
+				Template: testBatchSpecTemplate,
+				Parameters: append(testBatchSpecTemplateParameters()[1:], BatchSpecTemplateParameter{
+					Name: "package", Type: BatchSpecTemplateParameterTypeString, Default: true,
+				}),
+			},
+			err: `parameter "package": invalid default`,
+		},
+		"not a batch spec": {
+			template: &BatchSpecTemplate{Name: "bump", Template: "name: ${{ inputs.package }}\nfoo: bar\n", Parameters: testBatchSpecTemplateParameters()[:1]},
+			err:      "rendered template is not a valid batch spec",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := tc.template.Validate()
+			if err == nil {
+				t.Fatal("no error returned")
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("wrong error. want substring=%q, have=%q", tc.err, err)
+			}
+		})
+	}
+}
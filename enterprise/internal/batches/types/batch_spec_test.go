@@ -64,6 +64,99 @@ changesetTemplate:
   published: false
 `,
 		},
+		{
+			name: "valid auto-merge",
+			rawSpec: `
+name: my-unique-name
+on:
+- repository: github.com/sourcegraph/src-cli
+changesetTemplate:
+  title: Hello World
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  published: true
+  autoMerge:
+    enabled: true
+    squash: true
+    mergeWindow:
+      days: [monday]
+      start: "08:00"
+      end: "12:00"
+`,
+		},
+		{
+			name: "invalid auto-merge window",
+			rawSpec: `
+name: my-unique-name
+on:
+- repository: github.com/sourcegraph/src-cli
+changesetTemplate:
+  title: Hello World
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  autoMerge:
+    enabled: true
+    mergeWindow:
+      start: "08:00"
+`,
+			err: "1 error occurred:\n\t* changesetTemplate.autoMerge.mergeWindow: Has a dependency on end\n\n",
+		},
+		{
+			name: "transformChanges and overrides",
+			rawSpec: `
+name: my-unique-name
+changesetTemplate:
+  title: Hello World
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  overrides:
+  - repository: github.com/sourcegraph/*
+    title: Hello Sourcegraph
+transformChanges:
+  group:
+  - directory: client
+    branchSuffix: -client
+  - directory: enterprise
+    branch: hello-enterprise
+    repository: github.com/sourcegraph/sourcegraph
+`,
+		},
+		{
+			name: "transformChanges groups with the same branch",
+			rawSpec: `
+name: my-unique-name
+changesetTemplate:
+  title: Hello World
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+transformChanges:
+  group:
+  - directory: client
+    branchSuffix: -client
+  - directory: web
+    branchSuffix: -client
+`,
+			err: "1 error occurred:\n\t* transformChanges.group: groups for directories \"client\" and \"web\" use the same branch\n\n",
+		},
+		{
+			name: "invalid override repository pattern",
+			rawSpec: `
+name: my-unique-name
+changesetTemplate:
+  title: Hello World
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  overrides:
+  - repository: github.com/[sourcegraph
+    title: Hello Sourcegraph
+`,
+			err: "1 error occurred:\n\t* changesetTemplate.overrides: invalid repository pattern \"github.com/[sourcegraph\": unexpected end of input\n\n",
+		},
 		{
 			name: "invalid name",
 			rawSpec: `{
@@ -109,3 +202,81 @@ changesetTemplate:
 		})
 	}
 }
+
+func TestBatchSpecFields_ValidateServerSideExecution(t *testing.T) {
+	tests := []struct {
+		name    string
+		rawSpec string
+		err     string
+	}{
+		{
+			name: "supported",
+			rawSpec: `
+name: my-unique-name
+on:
+- repository: github.com/sourcegraph/src-cli
+steps:
+- run: echo 'foobar' >> README.md
+  container: alpine
+  env:
+    PATH: "/work/foobar:$PATH"
+changesetTemplate:
+  title: Hello World
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+`,
+		},
+		{
+			name: "unsupported",
+			rawSpec: `
+name: my-unique-name
+on:
+- repository: github.com/sourcegraph/src-cli
+steps:
+- run: echo ${{ repository.name }} >> README.md
+  container: alpine
+  env:
+    NAME: ${{ repository.name }}
+  files:
+    /tmp/hello.txt: Hello World
+  outputs:
+    greeting:
+      value: ${{ step.stdout }}
+  if: ${{ eq repository.name "github.com/sourcegraph/src-cli" }}
+changesetTemplate:
+  title: Hello ${{ repository.name }}
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  overrides:
+  - repository: github.com/sourcegraph/*
+    body: ${{ outputs.greeting }}
+`,
+			err: "7 errors occurred:\n" +
+				"\t* steps[0].run: templating is not supported in server-side execution\n" +
+				"\t* steps[0].env.NAME: templating is not supported in server-side execution\n" +
+				"\t* steps[0].files is not supported in server-side execution\n" +
+				"\t* steps[0].outputs is not supported in server-side execution\n" +
+				"\t* steps[0].if is not supported in server-side execution\n" +
+				"\t* changesetTemplate.title: templating is not supported in server-side execution\n" +
+				"\t* changesetTemplate.overrides[0]: templating is not supported in server-side execution\n\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			spec, err := NewBatchSpecFromRaw(tc.rawSpec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			haveErr := fmt.Sprintf("%v", spec.Spec.ValidateServerSideExecution())
+			if haveErr == "<nil>" {
+				haveErr = ""
+			}
+			if diff := cmp.Diff(tc.err, haveErr); diff != "" {
+				t.Fatalf("unexpected response (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package types
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+)
+
+// BatchSpecWorkspaceJob is the executor job that runs the steps of a batch
+// spec execution in a single repository workspace. It uses the same states as
+// BatchSpecExecution.
+type BatchSpecWorkspaceJob struct {
+	ID                   int64
+	BatchSpecExecutionID int64
+	RepoID               api.RepoID
+	Branch               string
+	Commit               string
+	ChangesetSpecIDs     []int64
+
+	State          BatchSpecExecutionState
+	FailureMessage *string
+	StartedAt      *time.Time
+	FinishedAt     *time.Time
+	ProcessAfter   *time.Time
+	NumResets      int64
+	NumFailures    int64
+	ExecutionLogs  []workerutil.ExecutionLogEntry
+	WorkerHostname string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (j BatchSpecWorkspaceJob) RecordID() int {
+	return int(j.ID)
+}
+
+// Finished returns whether the job won't be processed again unless it's
+// retried.
+func (j *BatchSpecWorkspaceJob) Finished() bool {
+	switch j.State {
+	case BatchSpecExecutionStateCompleted, BatchSpecExecutionStateFailed, BatchSpecExecutionStateErrored:
+		return true
+	default:
+		return false
+	}
+}
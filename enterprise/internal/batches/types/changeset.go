@@ -11,9 +11,11 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/signing"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 	"github.com/sourcegraph/sourcegraph/lib/batches"
@@ -229,6 +231,36 @@ func (s ChangesetCheckState) Valid() bool {
 	}
 }
 
+// ChangesetCheck is a single check run, commit status, build status or
+// pipeline reported by the code host for the head commit of a changeset.
+type ChangesetCheck struct {
+	Name  string              `json:"name"`
+	State ChangesetCheckState `json:"state"`
+	// URL points to the details of the check on the code host or CI system,
+	// if known.
+	URL        string    `json:"url,omitempty"`
+	FinishedAt time.Time `json:"finishedAt,omitempty"`
+	// RerunID is the ID the code host uses to run the check again: the check
+	// suite of a GitHub check run or the ID of a GitLab pipeline. It's empty
+	// if the check can't be re-run through the code host.
+	RerunID string `json:"rerunID,omitempty"`
+}
+
+// Rerunnable returns true if the check can be re-run through the code host.
+func (c ChangesetCheck) Rerunnable() bool { return c.RerunID != "" }
+
+// FailedRerunnableChecks returns the failed checks of the changeset that can
+// be re-run through the code host.
+func (c *Changeset) FailedRerunnableChecks() []ChangesetCheck {
+	var checks []ChangesetCheck
+	for _, check := range c.ExternalChecks {
+		if check.State == ChangesetCheckStateFailed && check.Rerunnable() {
+			checks = append(checks, check)
+		}
+	}
+	return checks
+}
+
 // BatchChangeAssoc stores the details of a association to a BatchChange.
 type BatchChangeAssoc struct {
 	BatchChangeID int64 `json:"-"`
@@ -255,10 +287,12 @@ type Changeset struct {
 	ExternalState       ChangesetExternalState
 	ExternalReviewState ChangesetReviewState
 	ExternalCheckState  ChangesetCheckState
-	DiffStatAdded       *int32
-	DiffStatChanged     *int32
-	DiffStatDeleted     *int32
-	SyncState           ChangesetSyncState
+	// ExternalChecks are the individual checks that make up ExternalCheckState.
+	ExternalChecks  []ChangesetCheck
+	DiffStatAdded   *int32
+	DiffStatChanged *int32
+	DiffStatDeleted *int32
+	SyncState       ChangesetSyncState
 
 	// The batch change that "owns" this changeset: it can create/close
 	// it on code host. If this is 0, it is imported/tracked by a batch change.
@@ -284,6 +318,17 @@ type Changeset struct {
 	// Closing is set to true (along with the ReocncilerState) when the
 	// reconciler should close the changeset.
 	Closing bool
+
+	// CommitSignature describes the key the last commit pushed for the
+	// changeset was signed with. It is nil if the commit was not signed.
+	CommitSignature *ChangesetCommitSignature
+}
+
+// ChangesetCommitSignature describes the key a commit pushed for a changeset
+// was signed with.
+type ChangesetCommitSignature struct {
+	Format      signing.Format `json:"format"`
+	Fingerprint string         `json:"fingerprint"`
 }
 
 // RecordID is needed to implement the workerutil.Record interface.
@@ -294,6 +339,14 @@ func (c *Changeset) Clone() *Changeset {
 	tt := *c
 	tt.BatchChanges = make([]BatchChangeAssoc, len(c.BatchChanges))
 	copy(tt.BatchChanges, c.BatchChanges)
+	if c.ExternalChecks != nil {
+		tt.ExternalChecks = make([]ChangesetCheck, len(c.ExternalChecks))
+		copy(tt.ExternalChecks, c.ExternalChecks)
+	}
+	if c.CommitSignature != nil {
+		sig := *c.CommitSignature
+		tt.CommitSignature = &sig
+	}
 	return &tt
 }
 
@@ -316,6 +369,19 @@ func (c *Changeset) Published() bool { return c.PublicationState.Published() }
 // Unpublished returns whether the Changeset's PublicationState is Unpublished.
 func (c *Changeset) Unpublished() bool { return c.PublicationState.Unpublished() }
 
+// AutoMergeCandidate returns whether the Changeset is an open changeset owned
+// by a batch change whose checks have passed and that has been approved, which
+// makes it eligible for being merged automatically.
+func (c *Changeset) AutoMergeCandidate() bool {
+	if c.OwnedByBatchChangeID == 0 || !c.AttachedTo(c.OwnedByBatchChangeID) || c.ArchivedIn(c.OwnedByBatchChangeID) {
+		return false
+	}
+	return c.Published() && !c.Closing &&
+		c.ExternalState == ChangesetExternalStateOpen &&
+		c.ExternalCheckState == ChangesetCheckStatePassed &&
+		c.ExternalReviewState == ChangesetReviewStateApproved
+}
+
 // IsImporting returns whether the Changeset is being imported but it's not finished yet.
 func (c *Changeset) IsImporting() bool { return c.Unpublished() && c.CurrentSpecID == 0 }
 
@@ -379,6 +445,12 @@ func (c *Changeset) SetMetadata(meta interface{}) error {
 		c.ExternalServiceType = extsvc.TypeGitLab
 		c.ExternalBranch = git.EnsureRefPrefix(pr.SourceBranch)
 		c.ExternalUpdatedAt = pr.UpdatedAt.Time
+	case *awscodecommit.PullRequest:
+		c.Metadata = pr
+		c.ExternalID = pr.ID
+		c.ExternalServiceType = extsvc.TypeAWSCodeCommit
+		c.ExternalBranch = git.EnsureRefPrefix(pr.Target().SourceReference)
+		c.ExternalUpdatedAt = pr.LastActivityDate
 	default:
 		return errors.New("unknown changeset type")
 	}
@@ -404,6 +476,8 @@ func (c *Changeset) Title() (string, error) {
 		return m.Title, nil
 	case *gitlab.MergeRequest:
 		return m.Title, nil
+	case *awscodecommit.PullRequest:
+		return m.Title, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -421,6 +495,8 @@ func (c *Changeset) AuthorName() (string, error) {
 		return m.Author.User.Name, nil
 	case *gitlab.MergeRequest:
 		return m.Author.Username, nil
+	case *awscodecommit.PullRequest:
+		return m.AuthorName(), nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -444,6 +520,10 @@ func (c *Changeset) AuthorEmail() (string, error) {
 		return m.Author.User.EmailAddress, nil
 	case *gitlab.MergeRequest:
 		return m.Author.Email, nil
+	case *awscodecommit.PullRequest:
+		// AWS CodeCommit only knows the ARN of the IAM user or role that
+		// created the pull request.
+		return "", nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -460,6 +540,8 @@ func (c *Changeset) ExternalCreatedAt() time.Time {
 		return unixMilliToTime(int64(m.CreatedDate))
 	case *gitlab.MergeRequest:
 		return m.CreatedAt.Time
+	case *awscodecommit.PullRequest:
+		return m.CreationDate
 	default:
 		return time.Time{}
 	}
@@ -474,6 +556,8 @@ func (c *Changeset) Body() (string, error) {
 		return m.Description, nil
 	case *gitlab.MergeRequest:
 		return m.Description, nil
+	case *awscodecommit.PullRequest:
+		return m.Description, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -511,6 +595,8 @@ func (c *Changeset) URL() (s string, err error) {
 		return selfLink.Href, nil
 	case *gitlab.MergeRequest:
 		return m.WebURL, nil
+	case *awscodecommit.PullRequest:
+		return m.URL(), nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -658,6 +744,8 @@ func (c *Changeset) HeadRefOid() (string, error) {
 		return "", nil
 	case *gitlab.MergeRequest:
 		return m.DiffRefs.HeadSHA, nil
+	case *awscodecommit.PullRequest:
+		return m.Target().SourceCommit, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -673,6 +761,8 @@ func (c *Changeset) HeadRef() (string, error) {
 		return m.FromRef.ID, nil
 	case *gitlab.MergeRequest:
 		return "refs/heads/" + m.SourceBranch, nil
+	case *awscodecommit.PullRequest:
+		return git.EnsureRefPrefix(m.Target().SourceReference), nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -689,6 +779,8 @@ func (c *Changeset) BaseRefOid() (string, error) {
 		return "", nil
 	case *gitlab.MergeRequest:
 		return m.DiffRefs.BaseSHA, nil
+	case *awscodecommit.PullRequest:
+		return m.Target().DestinationCommit, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -704,6 +796,8 @@ func (c *Changeset) BaseRef() (string, error) {
 		return m.ToRef.ID, nil
 	case *gitlab.MergeRequest:
 		return "refs/heads/" + m.TargetBranch, nil
+	case *awscodecommit.PullRequest:
+		return git.EnsureRefPrefix(m.Target().DestinationReference), nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -1061,6 +1155,13 @@ func NewChangesetEventMetadata(k ChangesetEventKind) (interface{}, error) {
 		case ChangesetEventKindGitLabReopened:
 			return new(gitlab.MergeRequestReopenedEvent), nil
 		}
+	case strings.HasPrefix(string(k), "sourcegraph"):
+		switch k {
+		case ChangesetEventKindAutoMergeScheduled,
+			ChangesetEventKindAutoMerged,
+			ChangesetEventKindAutoMergeFailed:
+			return new(AutoMergeEvent), nil
+		}
 	}
 	return nil, errors.Errorf("unknown changeset event kind %q", k)
 }
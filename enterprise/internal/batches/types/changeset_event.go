@@ -78,9 +78,28 @@ const (
 	ChangesetEventKindGitLabMarkWorkInProgress   ChangesetEventKind = "gitlab:mark_wip"
 	ChangesetEventKindGitLabUnmarkWorkInProgress ChangesetEventKind = "gitlab:unmark_wip"
 
+	// Events recorded by Sourcegraph itself, rather than synced from the code
+	// host, when automatically merging changesets.
+	ChangesetEventKindAutoMergeScheduled ChangesetEventKind = "sourcegraph:auto_merge_scheduled"
+	ChangesetEventKindAutoMerged         ChangesetEventKind = "sourcegraph:auto_merged"
+	ChangesetEventKindAutoMergeFailed    ChangesetEventKind = "sourcegraph:auto_merge_failed"
+
 	ChangesetEventKindInvalid ChangesetEventKind = "invalid"
 )
 
+// AutoMergeEvent is the metadata of the changeset events that are recorded
+// when Sourcegraph schedules, performs or fails to perform an automatic merge
+// of a changeset.
+type AutoMergeEvent struct {
+	CreatedAt time.Time
+	Squash    bool
+	// ScheduledFor is set on scheduled events and is the time the auto-merge
+	// window opens next.
+	ScheduledFor time.Time
+	// Error is set on failed events.
+	Error string `json:",omitempty"`
+}
+
 // A ChangesetEvent is an event that happened in the lifetime
 // and context of a Changeset.
 type ChangesetEvent struct {
@@ -235,6 +254,8 @@ func (e *ChangesetEvent) Timestamp() time.Time {
 		t = ev.CreatedAt.Time
 	case *gitlab.MergeRequestMergedEvent:
 		t = ev.CreatedAt.Time
+	case *AutoMergeEvent:
+		t = ev.CreatedAt
 	case *gitlabwebhooks.PipelineEvent:
 		// These events do not inherently have timestamps from GitLab, so we
 		// fall back to the event record we created when we received the
@@ -604,12 +625,24 @@ func (e *ChangesetEvent) Update(o *ChangesetEvent) error {
 
 	case *github.CheckRun:
 		o := o.Metadata.(*github.CheckRun)
+		if e.Name == "" {
+			e.Name = o.Name
+		}
 		if e.Status == "" {
 			e.Status = o.Status
 		}
 		if e.Conclusion == "" {
 			e.Conclusion = o.Conclusion
 		}
+		if e.DetailsURL == "" {
+			e.DetailsURL = o.DetailsURL
+		}
+		if e.CompletedAt.IsZero() {
+			e.CompletedAt = o.CompletedAt
+		}
+		if e.CheckSuiteID == "" {
+			e.CheckSuiteID = o.CheckSuiteID
+		}
 
 	case *github.CheckSuite:
 		o := o.Metadata.(*github.CheckSuite)
@@ -722,6 +755,10 @@ func (e *ChangesetEvent) Update(o *ChangesetEvent) error {
 		// We always get the full event, so safe to replace it
 		*e = *o
 
+	case *AutoMergeEvent:
+		o := o.Metadata.(*AutoMergeEvent)
+		*e = *o
+
 	default:
 		return errors.Errorf("unknown changeset event metadata %T", e)
 	}
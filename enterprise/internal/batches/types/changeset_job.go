@@ -41,12 +41,14 @@ func (s ChangesetJobState) ToDB() string { return strings.ToLower(string(s)) }
 type ChangesetJobType string
 
 var (
-	ChangesetJobTypeComment   ChangesetJobType = "commentatore"
-	ChangesetJobTypeDetach    ChangesetJobType = "detach"
-	ChangesetJobTypeReenqueue ChangesetJobType = "reenqueue"
-	ChangesetJobTypeMerge     ChangesetJobType = "merge"
-	ChangesetJobTypeClose     ChangesetJobType = "close"
-	ChangesetJobTypePublish   ChangesetJobType = "publish"
+	ChangesetJobTypeComment      ChangesetJobType = "commentatore"
+	ChangesetJobTypeDetach       ChangesetJobType = "detach"
+	ChangesetJobTypeReenqueue    ChangesetJobType = "reenqueue"
+	ChangesetJobTypeMerge        ChangesetJobType = "merge"
+	ChangesetJobTypeClose        ChangesetJobType = "close"
+	ChangesetJobTypePublish      ChangesetJobType = "publish"
+	ChangesetJobTypeRerunChecks  ChangesetJobType = "rerun_checks"
+	ChangesetJobTypeUpdateBranch ChangesetJobType = "update_branch"
 )
 
 type ChangesetJobCommentPayload struct {
@@ -67,6 +69,10 @@ type ChangesetJobPublishPayload struct {
 	Draft bool `json:"draft"`
 }
 
+type ChangesetJobRerunChecksPayload struct{}
+
+type ChangesetJobUpdateBranchPayload struct{}
+
 // ChangesetJob describes a one-time action to be taken on a changeset.
 type ChangesetJob struct {
 	ID int64
@@ -0,0 +1,91 @@
+package types
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/signing"
+)
+
+// CommitSigningKey is a key registered by a site admin that gitserver uses to
+// sign the commits it creates for changesets on the code host.
+type CommitSigningKey struct {
+	ID                  int64
+	ExternalServiceType string
+	ExternalServiceID   string
+	Format              signing.Format
+	// Fingerprint is the fingerprint of the public key, so that admins can
+	// tell which key is in use without the private key being exposed.
+	Fingerprint     string
+	EncryptedKey    []byte
+	EncryptionKeyID string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+
+	Key encryption.Key
+}
+
+// commitSigningKeySecret is the encrypted part of a CommitSigningKey.
+type commitSigningKeySecret struct {
+	PrivateKey string `json:"privateKey"`
+	Passphrase string `json:"passphrase,omitempty"`
+}
+
+// SigningConfig decrypts the key and returns the configuration gitserver
+// needs to sign commits with it.
+func (k *CommitSigningKey) SigningConfig(ctx context.Context) (*protocol.CommitSigningConfig, error) {
+	raw := k.EncryptedKey
+	if k.EncryptionKeyID != "" {
+		if k.Key == nil {
+			return nil, errors.New("commit signing key is encrypted, but no key is available to decrypt it")
+		}
+
+		secret, err := k.Key.Decrypt(ctx, k.EncryptedKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "decrypting commit signing key")
+		}
+		raw = []byte(secret.Secret())
+	}
+
+	var secret commitSigningKeySecret
+	if err := json.Unmarshal(raw, &secret); err != nil {
+		return nil, errors.Wrap(err, "unmarshalling commit signing key")
+	}
+
+	return &protocol.CommitSigningConfig{
+		Format:     string(k.Format),
+		PrivateKey: secret.PrivateKey,
+		Passphrase: secret.Passphrase,
+	}, nil
+}
+
+// SetPrivateKey encrypts and sets the private key and its passphrase within
+// the commit signing key.
+func (k *CommitSigningKey) SetPrivateKey(ctx context.Context, privateKey, passphrase string) error {
+	id, err := keyID(ctx, k.Key)
+	if err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(commitSigningKeySecret{PrivateKey: privateKey, Passphrase: passphrase})
+	if err != nil {
+		return errors.Wrap(err, "marshalling commit signing key")
+	}
+
+	if k.Key != nil {
+		raw, err = k.Key.Encrypt(ctx, raw)
+		if err != nil {
+			return errors.Wrap(err, "encrypting commit signing key")
+		}
+	}
+
+	k.EncryptedKey = raw
+	k.EncryptionKeyID = id
+
+	return nil
+}
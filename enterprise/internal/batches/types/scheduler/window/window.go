@@ -60,6 +60,21 @@ func (w *Window) NextOpenAfter(after time.Time) time.Time {
 	}
 }
 
+// NewMergeWindow constructs a Window without a rate limit from the given
+// days and times, as used for the auto-merge window in a changeset template.
+func NewMergeWindow(days []string, start, end string) (*Window, error) {
+	w, err := parseWindow(&schema.BatchChangeRolloutWindow{
+		Days:  days,
+		Start: start,
+		End:   end,
+		Rate:  "unlimited",
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
 func parseWindowTime(raw string) (*timeOfDay, error) {
 	// An empty time is valid.
 	if raw == "" {
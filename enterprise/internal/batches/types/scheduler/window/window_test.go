@@ -375,3 +375,23 @@ func TestParseWindow(t *testing.T) {
 		}
 	})
 }
+
+func TestNewMergeWindow(t *testing.T) {
+	if _, err := NewMergeWindow(nil, "01:00", ""); err == nil {
+		t.Error("unexpected nil error")
+	}
+
+	w, err := NewMergeWindow([]string{"monday", "tuesday"}, "01:15", "02:30")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &Window{
+		days:  newWeekdaySet(time.Monday, time.Tuesday),
+		rate:  rate{n: -1},
+		start: timeOfDayPtr(1, 15),
+		end:   timeOfDayPtr(2, 30),
+	}
+	if diff := cmp.Diff(w, want, cmpOptions); diff != "" {
+		t.Errorf("unexpected window (-have +want):\n%s", diff)
+	}
+}
@@ -28,4 +28,6 @@ type ChangesetSyncData struct {
 	// RepoExternalServiceID is the external_service_id in the repo table, usually
 	// represented by the code host URL
 	RepoExternalServiceID string
+	// RepoExternalServiceType is the external_service_type in the repo table
+	RepoExternalServiceType string
 }
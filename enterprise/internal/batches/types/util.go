@@ -12,6 +12,7 @@ type CodehostCapability string
 const (
 	CodehostCapabilityLabels          CodehostCapability = "Labels"
 	CodehostCapabilityDraftChangesets CodehostCapability = "DraftChangesets"
+	CodehostCapabilityWebhooks        CodehostCapability = "Webhooks"
 )
 
 type CodehostCapabilities map[CodehostCapability]bool
@@ -21,9 +22,10 @@ type CodehostCapabilities map[CodehostCapability]bool
 // whose type is not in this list will simply be filtered out from the search
 // results.
 var SupportedExternalServices = map[string]CodehostCapabilities{
-	extsvc.TypeGitHub:          {CodehostCapabilityLabels: true, CodehostCapabilityDraftChangesets: true},
-	extsvc.TypeBitbucketServer: {},
-	extsvc.TypeGitLab:          {CodehostCapabilityLabels: true, CodehostCapabilityDraftChangesets: true},
+	extsvc.TypeGitHub:          {CodehostCapabilityLabels: true, CodehostCapabilityDraftChangesets: true, CodehostCapabilityWebhooks: true},
+	extsvc.TypeBitbucketServer: {CodehostCapabilityWebhooks: true},
+	extsvc.TypeGitLab:          {CodehostCapabilityLabels: true, CodehostCapabilityDraftChangesets: true, CodehostCapabilityWebhooks: true},
+	extsvc.TypeAWSCodeCommit:   {},
 }
 
 // IsRepoSupported returns whether the given ExternalRepoSpec is supported by
@@ -48,6 +50,14 @@ func ExternalServiceSupports(extSvcType string, capability CodehostCapability) b
 	return false
 }
 
+// ExternalServiceRequiresPolling returns whether changesets on the given
+// external service type can only be kept up to date by polling, because the
+// code host doesn't send webhooks for them.
+func ExternalServiceRequiresPolling(extSvcType string) bool {
+	_, ok := SupportedExternalServices[extSvcType]
+	return ok && !ExternalServiceSupports(extSvcType, CodehostCapabilityWebhooks)
+}
+
 // Keyer represents items that return a unique key
 type Keyer interface {
 	Key() string
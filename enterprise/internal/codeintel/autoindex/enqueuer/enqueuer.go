@@ -2,6 +2,7 @@ package enqueuer
 
 import (
 	"context"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/inconshreveable/log15"
@@ -52,6 +53,65 @@ func (s *IndexEnqueuer) QueueIndexesForRepository(ctx context.Context, repositor
 	return s.queueIndexForRepository(ctx, repositoryID, "HEAD", false)
 }
 
+// QueueIndexesForPush attempts to queue an index for the given revision of the given repository, which was just
+// pushed, to be processed once the given delay has elapsed. Indexes queued for a previous push of the repository
+// that are still delayed are replaced and their processing time is kept, so that a burst of pushes results in a
+// single set of index records for the most recently pushed revision. If this repository and commit already has
+// an index or upload record associated with it, this method does nothing.
+func (s *IndexEnqueuer) QueueIndexesForPush(ctx context.Context, repositoryID int, rev string, delay time.Duration) (err error) {
+	ctx, traceLog, endObservation := s.operations.QueueIndex.WithAndLogger(ctx, &err, observation.Args{
+		LogFields: []log.Field{
+			log.Int("repositoryID", repositoryID),
+		},
+	})
+	defer endObservation(1, observation.Args{})
+
+	commitID, err := s.gitserverClient.ResolveRevision(ctx, repositoryID, rev)
+	if err != nil {
+		return errors.Wrap(err, "gitserver.ResolveRevision")
+	}
+	commit := string(commitID)
+	traceLog(log.String("commit", commit))
+
+	isQueued, err := s.dbStore.IsQueued(ctx, repositoryID, commit)
+	if err != nil {
+		return errors.Wrap(err, "dbstore.IsQueued")
+	}
+	if isQueued {
+		return nil
+	}
+
+	indexes, err := s.getIndexRecords(ctx, repositoryID, commit)
+	if err != nil {
+		return err
+	}
+	if len(indexes) == 0 {
+		return nil
+	}
+	traceLog(log.Int("numIndexes", len(indexes)))
+
+	tx, err := s.dbStore.Transact(ctx)
+	if err != nil {
+		return errors.Wrap(err, "dbstore.Transact")
+	}
+	defer func() {
+		err = tx.Done(err)
+	}()
+
+	processAfter, ok, err := tx.DeleteDelayedIndexes(ctx, repositoryID)
+	if err != nil {
+		return errors.Wrap(err, "dbstore.DeleteDelayedIndexes")
+	}
+	if !ok {
+		processAfter = time.Now().Add(delay)
+	}
+	for i := range indexes {
+		indexes[i].ProcessAfter = &processAfter
+	}
+
+	return queueIndexesInTransaction(ctx, tx, repositoryID, commit, indexes)
+}
+
 // ForceQueueIndexesForRepository attempts to queue an index for the lastest commit on the default branch of the given
 // repository. If this repository and commit already has an index or upload record associated with it, a new index job
 // record will still be enqueued.
@@ -75,6 +135,37 @@ func (s *IndexEnqueuer) InferIndexConfiguration(ctx context.Context, repositoryI
 	}
 	traceLog(log.String("commit", commit))
 
+	return s.inferIndexConfigurationForCommit(ctx, repositoryID, commit)
+}
+
+// InferIndexConfigurationForRevision looks at the repository contents at the given revision of the given
+// repository and determines an index configuration that is likely to succeed. The resolved commit is also
+// returned. Any configuration stored in the database or committed to the repository is ignored.
+func (s *IndexEnqueuer) InferIndexConfigurationForRevision(ctx context.Context, repositoryID int, rev string) (_ *config.IndexConfiguration, _ string, err error) {
+	ctx, traceLog, endObservation := s.operations.InferIndexConfiguration.WithAndLogger(ctx, &err, observation.Args{
+		LogFields: []log.Field{
+			log.Int("repositoryID", repositoryID),
+			log.String("rev", rev),
+		},
+	})
+	defer endObservation(1, observation.Args{})
+
+	commitID, err := s.gitserverClient.ResolveRevision(ctx, repositoryID, rev)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "gitserver.ResolveRevision")
+	}
+	commit := string(commitID)
+	traceLog(log.String("commit", commit))
+
+	indexConfiguration, err := s.inferIndexConfigurationForCommit(ctx, repositoryID, commit)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return indexConfiguration, commit, nil
+}
+
+func (s *IndexEnqueuer) inferIndexConfigurationForCommit(ctx context.Context, repositoryID int, commit string) (*config.IndexConfiguration, error) {
 	indexJobs, err := s.inferIndexJobsFromRepositoryStructure(ctx, repositoryID, commit)
 	if err != nil || len(indexJobs) == 0 {
 		return nil, err
@@ -85,8 +176,58 @@ func (s *IndexEnqueuer) InferIndexConfiguration(ctx context.Context, repositoryI
 	}, nil
 }
 
+// PlanIndexesForRevision determines the index jobs that would be enqueued for the given revision of the
+// given repository without inserting any records. Jobs are marked as deduplicated if they would be skipped
+// because an upload or index record already exists for the resolved commit.
+func (s *IndexEnqueuer) PlanIndexesForRevision(ctx context.Context, repositoryID int, rev string) (_ IndexPlan, err error) {
+	ctx, traceLog, endObservation := s.operations.PlanIndexes.WithAndLogger(ctx, &err, observation.Args{
+		LogFields: []log.Field{
+			log.Int("repositoryID", repositoryID),
+			log.String("rev", rev),
+		},
+	})
+	defer endObservation(1, observation.Args{})
+
+	commitID, err := s.gitserverClient.ResolveRevision(ctx, repositoryID, rev)
+	if err != nil {
+		return IndexPlan{}, errors.Wrap(err, "gitserver.ResolveRevision")
+	}
+	commit := string(commitID)
+	traceLog(log.String("commit", commit))
+
+	isQueued, err := s.dbStore.IsQueued(ctx, repositoryID, commit)
+	if err != nil {
+		return IndexPlan{}, errors.Wrap(err, "dbstore.IsQueued")
+	}
+
+	indexes, source, err := s.getIndexRecordsWithSource(ctx, repositoryID, commit)
+	if err != nil {
+		return IndexPlan{}, err
+	}
+	traceLog(log.Int("numIndexes", len(indexes)), log.Bool("isQueued", isQueued))
+
+	plannedIndexes := make([]PlannedIndex, 0, len(indexes))
+	for _, index := range indexes {
+		plannedIndexes = append(plannedIndexes, PlannedIndex{
+			Index:        index,
+			Deduplicated: isQueued,
+		})
+	}
+
+	return IndexPlan{
+		Commit:  commit,
+		Source:  source,
+		Indexes: plannedIndexes,
+	}, nil
+}
+
 // QueueIndexesForPackage enqueues index jobs for a dependency of a recently-processed precise code intelligence
 // index. Currently we only support recognition of "gomod" import monikers.
+//
+// Modules hosted on GitHub are indexed from their GitHub repository when it exists. Otherwise the module
+// version is recorded as a dependency repo and indexed from the synthetic "go/<module>" repository that
+// gitserver builds from the configured Go module proxies. If that repository doesn't exist yet, it will be
+// created by the next sync of the Go modules code host connection.
 func (s *IndexEnqueuer) QueueIndexesForPackage(ctx context.Context, pkg precise.Package) (err error) {
 	ctx, traceLog, endObservation := s.operations.QueueIndexForPackage.WithAndLogger(ctx, &err, observation.Args{
 		LogFields: []log.Field{
@@ -97,36 +238,58 @@ func (s *IndexEnqueuer) QueueIndexesForPackage(ctx context.Context, pkg precise.
 	})
 	defer endObservation(1, observation.Args{})
 
-	repoName, revision, ok := InferGoRepositoryAndRevision(pkg)
+	if repoName, revision, ok := InferGoRepositoryAndRevision(pkg); ok {
+		traceLog(log.String("repoName", repoName))
+		traceLog(log.String("revision", revision))
+
+		if found, err := s.queueIndexesForRepositoryAndRevision(ctx, api.RepoName(repoName), revision, traceLog); err != nil || found {
+			return err
+		}
+	}
+
+	modulePath, version, ok := InferGoModuleAndVersion(pkg)
 	if !ok {
 		return nil
 	}
-	traceLog(log.String("repoName", repoName))
-	traceLog(log.String("revision", revision))
+	traceLog(log.String("modulePath", modulePath))
+
+	isNew, err := s.dbStore.InsertDependencyRepo(ctx, pkg.Scheme, modulePath, version)
+	if err != nil {
+		return errors.Wrap(err, "dbstore.InsertDependencyRepo")
+	}
+	traceLog(log.Bool("isNewDependencyRepo", isNew))
+
+	_, err = s.queueIndexesForRepositoryAndRevision(ctx, api.RepoName("go/"+modulePath), version, traceLog)
+	return err
+}
 
+// queueIndexesForRepositoryAndRevision requests an update of the given repository, resolves the given revision,
+// and attempts to enqueue index jobs for the resulting commit. The returned flag is false if either the repository
+// or the revision is unknown.
+func (s *IndexEnqueuer) queueIndexesForRepositoryAndRevision(ctx context.Context, repoName api.RepoName, revision string, traceLog observation.TraceLogger) (bool, error) {
 	if err := s.repoUpdaterLimiter.Wait(ctx); err != nil {
-		return err
+		return false, err
 	}
 
-	resp, err := s.repoUpdater.EnqueueRepoUpdate(ctx, api.RepoName(repoName))
+	resp, err := s.repoUpdater.EnqueueRepoUpdate(ctx, repoName)
 	if err != nil {
 		if errcode.IsNotFound(err) {
-			return nil
+			return false, nil
 		}
 
-		return errors.Wrap(err, "repoUpdater.EnqueueRepoUpdate")
+		return false, errors.Wrap(err, "repoUpdater.EnqueueRepoUpdate")
 	}
 
 	commit, err := s.gitserverClient.ResolveRevision(ctx, int(resp.ID), revision)
 	if err != nil {
 		if errcode.IsNotFound(err) {
-			return nil
+			return false, nil
 		}
 
-		return errors.Wrap(err, "gitserverClient.ResolveRevision")
+		return false, errors.Wrap(err, "gitserverClient.ResolveRevision")
 	}
 
-	return s.queueIndexForRepositoryAndCommit(ctx, int(resp.ID), string(commit), false, traceLog)
+	return true, s.queueIndexForRepositoryAndCommit(ctx, int(resp.ID), string(commit), false, traceLog)
 }
 
 // queueIndexForRepository determines the head of the default branch of the given repository and attempts to
@@ -191,6 +354,11 @@ func (s *IndexEnqueuer) queueIndexes(ctx context.Context, repositoryID int, comm
 		err = tx.Done(err)
 	}()
 
+	return queueIndexesInTransaction(ctx, tx, repositoryID, commit, indexes)
+}
+
+// queueIndexesInTransaction inserts the given index records with the given transactional store.
+func queueIndexesInTransaction(ctx context.Context, tx DBStore, repositoryID int, commit string, indexes []store.Index) error {
 	for _, index := range indexes {
 		id, err := tx.InsertIndex(ctx, index)
 		if err != nil {
@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"golang.org/x/time/rate"
@@ -318,6 +319,73 @@ func TestQueueIndexesForRepositoryInferred(t *testing.T) {
 	}
 }
 
+func TestQueueIndexesForPush(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockDBStore.TransactFunc.SetDefaultReturn(mockDBStore, nil)
+	mockDBStore.DoneFunc.SetDefaultHook(func(err error) error { return err })
+	mockDBStore.GetIndexConfigurationByRepositoryIDFunc.SetDefaultReturn(store.IndexConfiguration{ID: 1, RepositoryID: 42}, true, nil)
+
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitserverClient.ResolveRevisionFunc.SetDefaultHook(func(ctx context.Context, repositoryID int, rev string) (api.CommitID, error) {
+		return api.CommitID(fmt.Sprintf("c-%s", rev)), nil
+	})
+	mockGitserverClient.ListFilesFunc.SetDefaultReturn([]string{"go.mod"}, nil)
+
+	scheduler := NewIndexEnqueuer(mockDBStore, mockGitserverClient, nil, &testConfig, &observation.TestContext)
+
+	before := time.Now()
+	if err := scheduler.QueueIndexesForPush(context.Background(), 42, "deadbeef", time.Minute); err != nil {
+		t.Fatalf("unexpected error performing update: %s", err)
+	}
+
+	if len(mockDBStore.InsertIndexFunc.History()) != 1 {
+		t.Fatalf("unexpected number of calls to InsertIndex. want=%d have=%d", 1, len(mockDBStore.InsertIndexFunc.History()))
+	}
+	index := mockDBStore.InsertIndexFunc.History()[0].Arg1
+	if index.Commit != "c-deadbeef" {
+		t.Errorf("unexpected commit. want=%q have=%q", "c-deadbeef", index.Commit)
+	}
+	if index.ProcessAfter == nil || index.ProcessAfter.Before(before.Add(time.Minute)) {
+		t.Errorf("expected index to be delayed by a minute, have process after %v", index.ProcessAfter)
+	}
+
+	if len(mockDBStore.IsQueuedFunc.History()) != 1 {
+		t.Errorf("unexpected number of calls to IsQueued. want=%d have=%d", 1, len(mockDBStore.IsQueuedFunc.History()))
+	}
+	if len(mockDBStore.DeleteDelayedIndexesFunc.History()) != 1 {
+		t.Errorf("unexpected number of calls to DeleteDelayedIndexes. want=%d have=%d", 1, len(mockDBStore.DeleteDelayedIndexesFunc.History()))
+	}
+}
+
+func TestQueueIndexesForPushReplacesDelayedIndexes(t *testing.T) {
+	processAfter := time.Now().Add(time.Second * 30)
+
+	mockDBStore := NewMockDBStore()
+	mockDBStore.TransactFunc.SetDefaultReturn(mockDBStore, nil)
+	mockDBStore.DoneFunc.SetDefaultHook(func(err error) error { return err })
+	mockDBStore.GetIndexConfigurationByRepositoryIDFunc.SetDefaultReturn(store.IndexConfiguration{ID: 1, RepositoryID: 42}, true, nil)
+	mockDBStore.DeleteDelayedIndexesFunc.SetDefaultReturn(processAfter, true, nil)
+
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitserverClient.ResolveRevisionFunc.SetDefaultHook(func(ctx context.Context, repositoryID int, rev string) (api.CommitID, error) {
+		return api.CommitID(fmt.Sprintf("c-%s", rev)), nil
+	})
+	mockGitserverClient.ListFilesFunc.SetDefaultReturn([]string{"go.mod"}, nil)
+
+	scheduler := NewIndexEnqueuer(mockDBStore, mockGitserverClient, nil, &testConfig, &observation.TestContext)
+
+	if err := scheduler.QueueIndexesForPush(context.Background(), 42, "deadbeef", time.Minute); err != nil {
+		t.Fatalf("unexpected error performing update: %s", err)
+	}
+
+	// The replaced indexes were due earlier, so the new ones keep their processing time.
+	if len(mockDBStore.InsertIndexFunc.History()) != 1 {
+		t.Fatalf("unexpected number of calls to InsertIndex. want=%d have=%d", 1, len(mockDBStore.InsertIndexFunc.History()))
+	} else if have := mockDBStore.InsertIndexFunc.History()[0].Arg1.ProcessAfter; have == nil || !have.Equal(processAfter) {
+		t.Errorf("unexpected process after. want=%s have=%v", processAfter, have)
+	}
+}
+
 func TestQueueIndexesForRepositoryInferredTooLarge(t *testing.T) {
 	mockDBStore := NewMockDBStore()
 	mockDBStore.TransactFunc.SetDefaultReturn(mockDBStore, nil)
@@ -353,6 +421,93 @@ func TestQueueIndexesForRepositoryInferredTooLarge(t *testing.T) {
 	}
 }
 
+func TestInferIndexConfigurationForRevision(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockDBStore.GetIndexConfigurationByRepositoryIDFunc.SetDefaultReturn(store.IndexConfiguration{ID: 1, RepositoryID: 42, Data: []byte(`{"index_jobs": []}`)}, true, nil)
+
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitserverClient.ResolveRevisionFunc.SetDefaultHook(func(ctx context.Context, repositoryID int, rev string) (api.CommitID, error) {
+		return api.CommitID(fmt.Sprintf("c-%s", rev)), nil
+	})
+	mockGitserverClient.ListFilesFunc.SetDefaultReturn([]string{"a/go.mod", "b/go.mod"}, nil)
+
+	scheduler := NewIndexEnqueuer(mockDBStore, mockGitserverClient, nil, &testConfig, &observation.TestContext)
+
+	indexConfiguration, commit, err := scheduler.InferIndexConfigurationForRevision(context.Background(), 42, "feature")
+	if err != nil {
+		t.Fatalf("unexpected error inferring configuration: %s", err)
+	}
+	if commit != "c-feature" {
+		t.Errorf("unexpected commit. want=%q have=%q", "c-feature", commit)
+	}
+	if indexConfiguration == nil {
+		t.Fatalf("expected an inferred configuration")
+	}
+
+	var roots []string
+	for _, indexJob := range indexConfiguration.IndexJobs {
+		roots = append(roots, indexJob.Root)
+	}
+	if diff := cmp.Diff([]string{"a", "b"}, roots); diff != "" {
+		t.Errorf("unexpected roots (-want +got):\n%s", diff)
+	}
+
+	if len(mockDBStore.GetIndexConfigurationByRepositoryIDFunc.History()) != 0 {
+		t.Errorf("unexpected call to GetIndexConfigurationByRepositoryID")
+	}
+}
+
+func TestPlanIndexesForRevision(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockDBStore.IsQueuedFunc.SetDefaultHook(func(ctx context.Context, repositoryID int, commit string) (bool, error) {
+		return commit == "c-main", nil
+	})
+
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitserverClient.ResolveRevisionFunc.SetDefaultHook(func(ctx context.Context, repositoryID int, rev string) (api.CommitID, error) {
+		return api.CommitID(fmt.Sprintf("c-%s", rev)), nil
+	})
+	mockGitserverClient.ListFilesFunc.SetDefaultReturn([]string{"a/go.mod", "b/go.mod"}, nil)
+
+	scheduler := NewIndexEnqueuer(mockDBStore, mockGitserverClient, nil, &testConfig, &observation.TestContext)
+
+	for _, testCase := range []struct {
+		rev          string
+		deduplicated bool
+	}{
+		{"main", true},
+		{"feature", false},
+	} {
+		plan, err := scheduler.PlanIndexesForRevision(context.Background(), 42, testCase.rev)
+		if err != nil {
+			t.Fatalf("unexpected error planning indexes: %s", err)
+		}
+
+		if plan.Commit != "c-"+testCase.rev {
+			t.Errorf("unexpected commit. want=%q have=%q", "c-"+testCase.rev, plan.Commit)
+		}
+		if plan.Source != ConfigurationSourceInferred {
+			t.Errorf("unexpected source. want=%q have=%q", ConfigurationSourceInferred, plan.Source)
+		}
+
+		var roots []string
+		for _, plannedIndex := range plan.Indexes {
+			roots = append(roots, plannedIndex.Index.Root)
+
+			if plannedIndex.Deduplicated != testCase.deduplicated {
+				t.Errorf("unexpected deduplicated flag for %s. want=%v have=%v", testCase.rev, testCase.deduplicated, plannedIndex.Deduplicated)
+			}
+		}
+		if diff := cmp.Diff([]string{"a", "b"}, roots); diff != "" {
+			t.Errorf("unexpected roots (-want +got):\n%s", diff)
+		}
+	}
+
+	if len(mockDBStore.InsertIndexFunc.History()) != 0 {
+		t.Errorf("unexpected number of calls to InsertIndex. want=%d have=%d", 0, len(mockDBStore.InsertIndexFunc.History()))
+	}
+}
+
 func TestQueueIndexesForPackage(t *testing.T) {
 	mockDBStore := NewMockDBStore()
 	mockDBStore.TransactFunc.SetDefaultReturn(mockDBStore, nil)
@@ -427,3 +582,50 @@ func TestQueueIndexesForPackage(t *testing.T) {
 		}
 	}
 }
+
+func TestQueueIndexesForPackageGoModuleProxy(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockDBStore.TransactFunc.SetDefaultReturn(mockDBStore, nil)
+	mockDBStore.DoneFunc.SetDefaultHook(func(err error) error { return err })
+	mockDBStore.IsQueuedFunc.SetDefaultReturn(false, nil)
+	mockDBStore.InsertDependencyRepoFunc.SetDefaultReturn(true, nil)
+
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitserverClient.ResolveRevisionFunc.SetDefaultHook(func(ctx context.Context, repoID int, versionString string) (api.CommitID, error) {
+		if repoID != 43 || versionString != "v0.1.5" {
+			t.Errorf("unexpected (repoID, versionString) (%v, %v) supplied to ResolveRevision", repoID, versionString)
+		}
+		return "c43", nil
+	})
+	mockGitserverClient.ListFilesFunc.SetDefaultReturn([]string{"go.mod"}, nil)
+
+	mockRepoUpdater := NewMockRepoUpdaterClient()
+	mockRepoUpdater.EnqueueRepoUpdateFunc.SetDefaultHook(func(ctx context.Context, repoName api.RepoName) (*protocol.RepoUpdateResponse, error) {
+		if repoName != "go/golang.org/x/tools" {
+			t.Errorf("unexpected repo %v supplied to EnqueueRepoUpdate", repoName)
+		}
+		return &protocol.RepoUpdateResponse{ID: 43}, nil
+	})
+
+	scheduler := NewIndexEnqueuer(mockDBStore, mockGitserverClient, mockRepoUpdater, &testConfig, &observation.TestContext)
+
+	if err := scheduler.QueueIndexesForPackage(context.Background(), precise.Package{
+		Scheme:  "gomod",
+		Name:    "https://golang.org/x/tools",
+		Version: "v0.1.5",
+	}); err != nil {
+		t.Fatalf("unexpected error queueing indexes: %s", err)
+	}
+
+	if history := mockDBStore.InsertDependencyRepoFunc.History(); len(history) != 1 {
+		t.Errorf("unexpected number of calls to InsertDependencyRepo. want=%d have=%d", 1, len(history))
+	} else if call := history[0]; call.Arg1 != "gomod" || call.Arg2 != "golang.org/x/tools" || call.Arg3 != "v0.1.5" {
+		t.Errorf("unexpected dependency repo (%s, %s, %s)", call.Arg1, call.Arg2, call.Arg3)
+	}
+
+	if history := mockDBStore.InsertIndexFunc.History(); len(history) != 1 {
+		t.Errorf("unexpected number of calls to InsertIndex. want=%d have=%d", 1, len(history))
+	} else if index := history[0].Arg1; index.RepositoryID != 43 || index.Commit != "c43" {
+		t.Errorf("unexpected index for repository %d at commit %s", index.RepositoryID, index.Commit)
+	}
+}
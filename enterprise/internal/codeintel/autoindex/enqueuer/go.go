@@ -28,3 +28,18 @@ func InferGoRepositoryAndRevision(pkg precise.Package) (repoName, gitTagOrCommit
 
 	return strings.Join(repoParts, "/"), version, true
 }
+
+// InferGoModuleAndVersion returns the module path and version of the given "gomod" package. These
+// identify the synthetic repository built from the sources served by the configured Go module proxies.
+func InferGoModuleAndVersion(pkg precise.Package) (modulePath, version string, ok bool) {
+	if pkg.Scheme != "gomod" || pkg.Version == "" {
+		return "", "", false
+	}
+
+	modulePath = strings.TrimPrefix(pkg.Name, GitHubScheme)
+	if modulePath == "" {
+		return "", "", false
+	}
+
+	return modulePath, pkg.Version, true
+}
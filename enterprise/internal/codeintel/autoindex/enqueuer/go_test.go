@@ -55,3 +55,21 @@ func TestInferGoRepositoryAndRevision(t *testing.T) {
 		}
 	}
 }
+
+func TestInferGoModuleAndVersion(t *testing.T) {
+	modulePath, version, ok := InferGoModuleAndVersion(precise.Package{
+		Scheme:  "gomod",
+		Name:    "https://golang.org/x/tools",
+		Version: "v0.1.5",
+	})
+	if !ok {
+		t.Fatalf("expected module to be inferred")
+	}
+	if modulePath != "golang.org/x/tools" || version != "v0.1.5" {
+		t.Errorf("unexpected module. want=%s@%s have=%s@%s", "golang.org/x/tools", "v0.1.5", modulePath, version)
+	}
+
+	if _, _, ok := InferGoModuleAndVersion(precise.Package{Scheme: "npm", Name: "left-pad", Version: "1.3.0"}); ok {
+		t.Errorf("expected non-gomod package to be ignored")
+	}
+}
@@ -3,6 +3,7 @@ package enqueuer
 import (
 	"context"
 	"regexp"
+	"time"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -20,8 +21,10 @@ type DBStore interface {
 	DirtyRepositories(ctx context.Context) (map[int]int, error)
 	IsQueued(ctx context.Context, repositoryID int, commit string) (bool, error)
 	InsertIndex(ctx context.Context, index dbstore.Index) (int, error)
+	DeleteDelayedIndexes(ctx context.Context, repositoryID int) (time.Time, bool, error)
 	GetRepositoriesWithIndexConfiguration(ctx context.Context) ([]int, error)
 	GetIndexConfigurationByRepositoryID(ctx context.Context, repositoryID int) (dbstore.IndexConfiguration, bool, error)
+	InsertDependencyRepo(ctx context.Context, scheme, name, version string) (bool, error)
 }
 
 type DBStoreShim struct {
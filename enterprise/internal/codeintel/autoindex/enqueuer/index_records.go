@@ -10,6 +10,30 @@ import (
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
 )
 
+// ConfigurationSource describes where the index configuration used to produce a set of index records
+// was found.
+type ConfigurationSource string
+
+const (
+	ConfigurationSourceDatabase   ConfigurationSource = "database"
+	ConfigurationSourceRepository ConfigurationSource = "repository"
+	ConfigurationSourceInferred   ConfigurationSource = "inferred"
+)
+
+// IndexPlan describes the index records that would be enqueued for a commit.
+type IndexPlan struct {
+	Commit  string
+	Source  ConfigurationSource
+	Indexes []PlannedIndex
+}
+
+// PlannedIndex is an index record that would be enqueued, along with a flag indicating whether it
+// would be skipped due to existing upload or index records for the same commit.
+type PlannedIndex struct {
+	Index        store.Index
+	Deduplicated bool
+}
+
 // getIndexRecords determines the set of index records that should be enqueued for the given commit.
 // For each repository, we look for index configuration in the following order:
 //
@@ -17,21 +41,31 @@ import (
 //  - committed to `sourcegraph.yaml` in the repository
 //  - inferred from the repository structure
 func (s *IndexEnqueuer) getIndexRecords(ctx context.Context, repositoryID int, commit string) ([]store.Index, error) {
-	fns := []func(ctx context.Context, repositoryID int, commit string) ([]store.Index, bool, error){
-		s.getIndexRecordsFromConfigurationInDatabase,
-		s.getIndexRecordsFromConfigurationInRepository,
-		s.inferIndexRecordsFromRepositoryStructure,
+	indexRecords, _, err := s.getIndexRecordsWithSource(ctx, repositoryID, commit)
+	return indexRecords, err
+}
+
+// getIndexRecordsWithSource behaves like getIndexRecords, but also returns the source of the configuration
+// that produced the index records. The source is empty if no configuration was found.
+func (s *IndexEnqueuer) getIndexRecordsWithSource(ctx context.Context, repositoryID int, commit string) ([]store.Index, ConfigurationSource, error) {
+	sources := []struct {
+		source ConfigurationSource
+		fn     func(ctx context.Context, repositoryID int, commit string) ([]store.Index, bool, error)
+	}{
+		{ConfigurationSourceDatabase, s.getIndexRecordsFromConfigurationInDatabase},
+		{ConfigurationSourceRepository, s.getIndexRecordsFromConfigurationInRepository},
+		{ConfigurationSourceInferred, s.inferIndexRecordsFromRepositoryStructure},
 	}
 
-	for _, fn := range fns {
-		if indexRecords, ok, err := fn(ctx, repositoryID, commit); err != nil {
-			return nil, err
+	for _, source := range sources {
+		if indexRecords, ok, err := source.fn(ctx, repositoryID, commit); err != nil {
+			return nil, "", err
 		} else if ok {
-			return indexRecords, nil
+			return indexRecords, source.source, nil
 		}
 	}
 
-	return nil, nil
+	return nil, "", nil
 }
 
 // getIndexRecordsFromConfigurationInDatabase returns a set of index jobs configured via the UI for
@@ -41,7 +75,9 @@ func (s *IndexEnqueuer) getIndexRecordsFromConfigurationInDatabase(ctx context.C
 	if err != nil {
 		return nil, false, errors.Wrap(err, "dbstore.GetIndexConfigurationByRepositoryID")
 	}
-	if !ok {
+	if !ok || len(indexConfigurationRecord.Data) == 0 {
+		// A record without data only carries flags (such as index_on_push) and
+		// should not prevent us from looking elsewhere for configuration.
 		return nil, false, nil
 	}
 
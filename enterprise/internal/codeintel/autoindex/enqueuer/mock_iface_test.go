@@ -6,6 +6,7 @@ import (
 	"context"
 	"regexp"
 	"sync"
+	"time"
 
 	dbstore "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
 	api "github.com/sourcegraph/sourcegraph/internal/api"
@@ -18,6 +19,9 @@ import (
 // github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindex/enqueuer)
 // used for unit testing.
 type MockDBStore struct {
+	// DeleteDelayedIndexesFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteDelayedIndexes.
+	DeleteDelayedIndexesFunc *DBStoreDeleteDelayedIndexesFunc
 	// DirtyRepositoriesFunc is an instance of a mock function object
 	// controlling the behavior of the method DirtyRepositories.
 	DirtyRepositoriesFunc *DBStoreDirtyRepositoriesFunc
@@ -35,6 +39,9 @@ type MockDBStore struct {
 	// HandleFunc is an instance of a mock function object controlling the
 	// behavior of the method Handle.
 	HandleFunc *DBStoreHandleFunc
+	// InsertDependencyRepoFunc is an instance of a mock function object
+	// controlling the behavior of the method InsertDependencyRepo.
+	InsertDependencyRepoFunc *DBStoreInsertDependencyRepoFunc
 	// InsertIndexFunc is an instance of a mock function object controlling
 	// the behavior of the method InsertIndex.
 	InsertIndexFunc *DBStoreInsertIndexFunc
@@ -50,6 +57,11 @@ type MockDBStore struct {
 // return zero values for all results, unless overwritten.
 func NewMockDBStore() *MockDBStore {
 	return &MockDBStore{
+		DeleteDelayedIndexesFunc: &DBStoreDeleteDelayedIndexesFunc{
+			defaultHook: func(context.Context, int) (time.Time, bool, error) {
+				return time.Time{}, false, nil
+			},
+		},
 		DirtyRepositoriesFunc: &DBStoreDirtyRepositoriesFunc{
 			defaultHook: func(context.Context) (map[int]int, error) {
 				return nil, nil
@@ -75,6 +87,11 @@ func NewMockDBStore() *MockDBStore {
 				return nil
 			},
 		},
+		InsertDependencyRepoFunc: &DBStoreInsertDependencyRepoFunc{
+			defaultHook: func(context.Context, string, string, string) (bool, error) {
+				return false, nil
+			},
+		},
 		InsertIndexFunc: &DBStoreInsertIndexFunc{
 			defaultHook: func(context.Context, dbstore.Index) (int, error) {
 				return 0, nil
@@ -97,6 +114,9 @@ func NewMockDBStore() *MockDBStore {
 // methods delegate to the given implementation, unless overwritten.
 func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 	return &MockDBStore{
+		DeleteDelayedIndexesFunc: &DBStoreDeleteDelayedIndexesFunc{
+			defaultHook: i.DeleteDelayedIndexes,
+		},
 		DirtyRepositoriesFunc: &DBStoreDirtyRepositoriesFunc{
 			defaultHook: i.DirtyRepositories,
 		},
@@ -112,6 +132,9 @@ func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 		HandleFunc: &DBStoreHandleFunc{
 			defaultHook: i.Handle,
 		},
+		InsertDependencyRepoFunc: &DBStoreInsertDependencyRepoFunc{
+			defaultHook: i.InsertDependencyRepo,
+		},
 		InsertIndexFunc: &DBStoreInsertIndexFunc{
 			defaultHook: i.InsertIndex,
 		},
@@ -124,6 +147,119 @@ func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 	}
 }
 
+// DBStoreDeleteDelayedIndexesFunc describes the behavior when the
+// DeleteDelayedIndexes method of the parent MockDBStore instance is
+// invoked.
+type DBStoreDeleteDelayedIndexesFunc struct {
+	defaultHook func(context.Context, int) (time.Time, bool, error)
+	hooks       []func(context.Context, int) (time.Time, bool, error)
+	history     []DBStoreDeleteDelayedIndexesFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteDelayedIndexes delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockDBStore) DeleteDelayedIndexes(v0 context.Context, v1 int) (time.Time, bool, error) {
+	r0, r1, r2 := m.DeleteDelayedIndexesFunc.nextHook()(v0, v1)
+	m.DeleteDelayedIndexesFunc.appendCall(DBStoreDeleteDelayedIndexesFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the DeleteDelayedIndexes
+// method of the parent MockDBStore instance is invoked and the hook queue
+// is empty.
+func (f *DBStoreDeleteDelayedIndexesFunc) SetDefaultHook(hook func(context.Context, int) (time.Time, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteDelayedIndexes method of the parent MockDBStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *DBStoreDeleteDelayedIndexesFunc) PushHook(hook func(context.Context, int) (time.Time, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreDeleteDelayedIndexesFunc) SetDefaultReturn(r0 time.Time, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int) (time.Time, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreDeleteDelayedIndexesFunc) PushReturn(r0 time.Time, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int) (time.Time, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *DBStoreDeleteDelayedIndexesFunc) nextHook() func(context.Context, int) (time.Time, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreDeleteDelayedIndexesFunc) appendCall(r0 DBStoreDeleteDelayedIndexesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreDeleteDelayedIndexesFuncCall objects
+// describing the invocations of this function.
+func (f *DBStoreDeleteDelayedIndexesFunc) History() []DBStoreDeleteDelayedIndexesFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreDeleteDelayedIndexesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreDeleteDelayedIndexesFuncCall is an object that describes an
+// invocation of method DeleteDelayedIndexes on an instance of MockDBStore.
+type DBStoreDeleteDelayedIndexesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 time.Time
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreDeleteDelayedIndexesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreDeleteDelayedIndexesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // DBStoreDirtyRepositoriesFunc describes the behavior when the
 // DirtyRepositories method of the parent MockDBStore instance is invoked.
 type DBStoreDirtyRepositoriesFunc struct {
@@ -659,6 +795,122 @@ func (c DBStoreHandleFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// DBStoreInsertDependencyRepoFunc describes the behavior when the
+// InsertDependencyRepo method of the parent MockDBStore instance is
+// invoked.
+type DBStoreInsertDependencyRepoFunc struct {
+	defaultHook func(context.Context, string, string, string) (bool, error)
+	hooks       []func(context.Context, string, string, string) (bool, error)
+	history     []DBStoreInsertDependencyRepoFuncCall
+	mutex       sync.Mutex
+}
+
+// InsertDependencyRepo delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockDBStore) InsertDependencyRepo(v0 context.Context, v1 string, v2 string, v3 string) (bool, error) {
+	r0, r1 := m.InsertDependencyRepoFunc.nextHook()(v0, v1, v2, v3)
+	m.InsertDependencyRepoFunc.appendCall(DBStoreInsertDependencyRepoFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the InsertDependencyRepo
+// method of the parent MockDBStore instance is invoked and the hook queue
+// is empty.
+func (f *DBStoreInsertDependencyRepoFunc) SetDefaultHook(hook func(context.Context, string, string, string) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InsertDependencyRepo method of the parent MockDBStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *DBStoreInsertDependencyRepoFunc) PushHook(hook func(context.Context, string, string, string) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreInsertDependencyRepoFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string, string) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreInsertDependencyRepoFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, string, string, string) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *DBStoreInsertDependencyRepoFunc) nextHook() func(context.Context, string, string, string) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreInsertDependencyRepoFunc) appendCall(r0 DBStoreInsertDependencyRepoFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreInsertDependencyRepoFuncCall objects
+// describing the invocations of this function.
+func (f *DBStoreInsertDependencyRepoFunc) History() []DBStoreInsertDependencyRepoFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreInsertDependencyRepoFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreInsertDependencyRepoFuncCall is an object that describes an
+// invocation of method InsertDependencyRepo on an instance of MockDBStore.
+type DBStoreInsertDependencyRepoFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreInsertDependencyRepoFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreInsertDependencyRepoFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // DBStoreInsertIndexFunc describes the behavior when the InsertIndex method
 // of the parent MockDBStore instance is invoked.
 type DBStoreInsertIndexFunc struct {
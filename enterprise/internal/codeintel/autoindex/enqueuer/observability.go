@@ -11,6 +11,7 @@ type operations struct {
 	QueueIndex              *observation.Operation
 	InferIndexConfiguration *observation.Operation
 	QueueIndexForPackage    *observation.Operation
+	PlanIndexes             *observation.Operation
 }
 
 func newOperations(observationContext *observation.Context) *operations {
@@ -33,5 +34,6 @@ func newOperations(observationContext *observation.Context) *operations {
 		QueueIndex:              op("QueueIndex"),
 		InferIndexConfiguration: op("InferIndexConfiguration"),
 		QueueIndexForPackage:    op("QueueIndexForPackage"),
+		PlanIndexes:             op("PlanIndexes"),
 	}
 }
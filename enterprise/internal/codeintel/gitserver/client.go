@@ -72,6 +72,36 @@ func (c *Client) Head(ctx context.Context, repositoryID int) (_ string, revision
 	return revision, true, nil
 }
 
+// DefaultBranch returns the fully qualified name of the default branch for the given repository,
+// e.g. refs/heads/main. If HEAD does not point to a branch, a false-valued flag is returned along
+// with a nil error and empty ref name.
+func (c *Client) DefaultBranch(ctx context.Context, repositoryID int) (_ string, exists bool, err error) {
+	ctx, endObservation := c.operations.defaultBranch.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	repo, err := c.repositoryIDToRepo(ctx, repositoryID)
+	if err != nil {
+		return "", false, err
+	}
+
+	cmd := gitserver.DefaultClient.Command("git", "symbolic-ref", "-q", "HEAD")
+	cmd.Repo = repo
+
+	out, err := cmd.Output(ctx)
+	if err != nil {
+		// git symbolic-ref -q exits with status 1 and no output when HEAD is detached
+		if cmd.ExitStatus == 1 && len(bytes.TrimSpace(out)) == 0 {
+			return "", false, nil
+		}
+
+		return "", false, errors.Wrap(err, "gitserver.Command")
+	}
+
+	return string(bytes.TrimSpace(out)), true, nil
+}
+
 // CommitDate returns the time that the given commit was committed.
 func (c *Client) CommitDate(ctx context.Context, repositoryID int, commit string) (_ time.Time, err error) {
 	ctx, endObservation := c.operations.commitDate.With(ctx, &err, observation.Args{LogFields: []log.Field{
@@ -88,6 +118,37 @@ func (c *Client) CommitDate(ctx context.Context, repositoryID int, commit string
 	return time.Parse(time.RFC3339, strings.TrimSpace(out))
 }
 
+// MergeBase returns the best common ancestor of the two given commits. If the commits share no
+// history, a false-valued flag is returned along with a nil error and an empty commit.
+func (c *Client) MergeBase(ctx context.Context, repositoryID int, a, b string) (_ string, exists bool, err error) {
+	ctx, endObservation := c.operations.mergeBase.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+		log.String("a", a),
+		log.String("b", b),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	repo, err := c.repositoryIDToRepo(ctx, repositoryID)
+	if err != nil {
+		return "", false, err
+	}
+
+	cmd := gitserver.DefaultClient.Command("git", "merge-base", a, b)
+	cmd.Repo = repo
+
+	out, err := cmd.Output(ctx)
+	if err != nil {
+		// git merge-base exits with status 1 and no output when the commits are unrelated
+		if cmd.ExitStatus == 1 && len(bytes.TrimSpace(out)) == 0 {
+			return "", false, nil
+		}
+
+		return "", false, errors.Wrap(err, "gitserver.Command")
+	}
+
+	return string(bytes.TrimSpace(out)), true, nil
+}
+
 type CommitGraph struct {
 	graph map[string][]string
 	order []string
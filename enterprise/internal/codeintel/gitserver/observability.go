@@ -14,10 +14,12 @@ type operations struct {
 	commitDate        *observation.Operation
 	commitExists      *observation.Operation
 	commitGraph       *observation.Operation
+	defaultBranch     *observation.Operation
 	directoryChildren *observation.Operation
 	fileExists        *observation.Operation
 	head              *observation.Operation
 	listFiles         *observation.Operation
+	mergeBase         *observation.Operation
 	rawContents       *observation.Operation
 	refDescriptions   *observation.Operation
 	resolveRevision   *observation.Operation
@@ -46,10 +48,12 @@ func newOperations(observationContext *observation.Context) *operations {
 		commitDate:        op("CommitDate"),
 		commitExists:      op("CommitExists"),
 		commitGraph:       op("CommitGraph"),
+		defaultBranch:     op("DefaultBranch"),
 		directoryChildren: op("DirectoryChildren"),
 		fileExists:        op("FileExists"),
 		head:              op("Head"),
 		listFiles:         op("ListFiles"),
+		mergeBase:         op("MergeBase"),
 		rawContents:       op("RawContents"),
 		refDescriptions:   op("RefDescriptions"),
 		resolveRevision:   op("ResolveRevision"),
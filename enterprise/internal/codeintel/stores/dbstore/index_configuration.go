@@ -130,3 +130,43 @@ const updateIndexConfigurationByRepositoryIDQuery = `
 INSERT INTO lsif_index_configuration (repository_id, data) VALUES (%s, %s)
 	ON CONFLICT (repository_id) DO UPDATE SET data = %s
 `
+
+// IsIndexOnPushEnabled returns true if pushes to the default branch of the given repository should
+// enqueue an index job for the pushed commit. This is never true for repositories that are disabled
+// for auto-indexing.
+func (s *Store) IsIndexOnPushEnabled(ctx context.Context, repositoryID int) (_ bool, err error) {
+	ctx, endObservation := s.operations.isIndexOnPushEnabled.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	enabled, _, err := basestore.ScanFirstBool(s.Store.Query(ctx, sqlf.Sprintf(isIndexOnPushEnabledQuery, repositoryID)))
+	return enabled, err
+}
+
+const isIndexOnPushEnabledQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/index_configuration.go:IsIndexOnPushEnabled
+SELECT c.index_on_push AND c.autoindex_enabled
+FROM lsif_index_configuration c
+JOIN repo r ON r.id = c.repository_id
+WHERE c.repository_id = %s AND r.deleted_at IS NULL
+`
+
+// UpdateIndexOnPushByRepositoryID sets whether or not pushes to the default branch of the given repository
+// should enqueue an index job for the pushed commit. If the repository does not yet have an index configuration
+// record, one is created with empty configuration data, which causes index jobs to be inferred.
+func (s *Store) UpdateIndexOnPushByRepositoryID(ctx context.Context, repositoryID int, enabled bool) (err error) {
+	ctx, endObservation := s.operations.updateIndexOnPushByRepositoryID.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+		log.Bool("enabled", enabled),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.Store.Exec(ctx, sqlf.Sprintf(updateIndexOnPushByRepositoryIDQuery, repositoryID, enabled, enabled))
+}
+
+const updateIndexOnPushByRepositoryIDQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/index_configuration.go:UpdateIndexOnPushByRepositoryID
+INSERT INTO lsif_index_configuration (repository_id, data, index_on_push) VALUES (%s, '', %s)
+	ON CONFLICT (repository_id) DO UPDATE SET index_on_push = %s
+`
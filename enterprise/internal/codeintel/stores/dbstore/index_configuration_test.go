@@ -170,3 +170,65 @@ func TestGetRepositoriesWithIndexConfigurationIgnoresDisabledRepos(t *testing.T)
 		t.Errorf("unexpected repository identifiers (-want +got):\n%s", diff)
 	}
 }
+
+func TestUpdateIndexOnPushByRepositoryID(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtesting.GetDB(t)
+	store := testStore(db)
+
+	for _, repositoryID := range []int{42, 43, 44} {
+		query := sqlf.Sprintf(
+			`INSERT INTO repo (id, name) VALUES (%s, %s)`,
+			repositoryID,
+			fmt.Sprintf("github.com/baz/honk%2d", repositoryID),
+		)
+		if _, err := db.Exec(query.Query(sqlf.PostgresBindVar), query.Args()...); err != nil {
+			t.Fatalf("unexpected error inserting repo: %s", err)
+		}
+	}
+
+	// 43 has explicit configuration; 44 is disabled for auto-indexing
+	for i, repositoryID := range []int{43, 44} {
+		query := sqlf.Sprintf(
+			`INSERT INTO lsif_index_configuration (id, repository_id, autoindex_enabled, data) VALUES (%s, %s, %s, %s)`,
+			i,
+			repositoryID,
+			repositoryID != 44,
+			[]byte(`test`),
+		)
+		if _, err := db.Exec(query.Query(sqlf.PostgresBindVar), query.Args()...); err != nil {
+			t.Fatalf("unexpected error inserting index configuration: %s", err)
+		}
+	}
+
+	for _, repositoryID := range []int{42, 43, 44} {
+		if enabled, err := store.IsIndexOnPushEnabled(context.Background(), repositoryID); err != nil {
+			t.Fatalf("unexpected error checking index on push: %s", err)
+		} else if enabled {
+			t.Errorf("expected index on push to be disabled for repository %d", repositoryID)
+		}
+
+		if err := store.UpdateIndexOnPushByRepositoryID(context.Background(), repositoryID, true); err != nil {
+			t.Fatalf("unexpected error updating index on push: %s", err)
+		}
+	}
+
+	for repositoryID, expected := range map[int]bool{42: true, 43: true, 44: false} {
+		if enabled, err := store.IsIndexOnPushEnabled(context.Background(), repositoryID); err != nil {
+			t.Fatalf("unexpected error checking index on push: %s", err)
+		} else if enabled != expected {
+			t.Errorf("unexpected index on push for repository %d. want=%v have=%v", repositoryID, expected, enabled)
+		}
+	}
+
+	// Existing configuration is not overwritten
+	indexConfiguration, _, err := store.GetIndexConfigurationByRepositoryID(context.Background(), 43)
+	if err != nil {
+		t.Fatalf("unexpected error while fetching index configuration: %s", err)
+	}
+	if diff := cmp.Diff([]byte(`test`), indexConfiguration.Data); diff != "" {
+		t.Errorf("unexpected configuration payload (-want +got):\n%s", diff)
+	}
+}
@@ -399,6 +399,7 @@ func (s *Store) InsertIndex(ctx context.Context, index Index) (id int, err error
 			pq.Array(index.IndexerArgs),
 			index.Outfile,
 			pq.Array(dbworkerstore.ExecutionLogEntries(index.ExecutionLogs)),
+			index.ProcessAfter,
 		),
 	))
 
@@ -417,8 +418,9 @@ INSERT INTO lsif_indexes (
 	indexer,
 	indexer_args,
 	outfile,
-	execution_logs
-) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+	execution_logs,
+	process_after
+) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING id
 `
 
@@ -470,6 +472,32 @@ const deleteIndexByIDQuery = `
 DELETE FROM lsif_indexes WHERE id = %s RETURNING repository_id
 `
 
+// DeleteDelayedIndexes deletes the queued indexes of the given repository that are not yet processed
+// because their process_after time is in the future. This returns the earliest process_after time
+// of the deleted indexes, and false if no index was deleted.
+func (s *Store) DeleteDelayedIndexes(ctx context.Context, repositoryID int) (_ time.Time, _ bool, err error) {
+	ctx, endObservation := s.operations.deleteDelayedIndexes.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	var processAfter *time.Time
+	if err := s.Store.QueryRow(ctx, sqlf.Sprintf(deleteDelayedIndexesQuery, repositoryID)).Scan(&processAfter); err != nil || processAfter == nil {
+		return time.Time{}, false, err
+	}
+	return *processAfter, true, nil
+}
+
+const deleteDelayedIndexesQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/indexes.go:DeleteDelayedIndexes
+WITH deleted AS (
+	DELETE FROM lsif_indexes
+	WHERE repository_id = %s AND state = 'queued' AND process_after > NOW()
+	RETURNING process_after
+)
+SELECT MIN(process_after) FROM deleted
+`
+
 // DeleteIndexesWithoutRepository deletes indexes associated with repositories that were deleted at least
 // DeletedRepositoryGracePeriod ago. This returns the repository identifier mapped to the number of indexes
 // that were removed for that repository.
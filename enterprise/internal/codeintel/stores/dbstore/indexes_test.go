@@ -471,6 +471,53 @@ func TestDeleteIndexByID(t *testing.T) {
 	}
 }
 
+func TestDeleteDelayedIndexes(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtesting.GetDB(t)
+	store := testStore(db)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	t1 := now.Add(time.Minute)
+	t2 := now.Add(time.Minute * 2)
+	t3 := now.Add(-time.Minute)
+
+	insertIndexes(t, db,
+		Index{ID: 1, RepositoryID: 50, State: "queued", ProcessAfter: &t2},
+		Index{ID: 2, RepositoryID: 50, State: "queued", ProcessAfter: &t1},
+		Index{ID: 3, RepositoryID: 50, State: "queued", ProcessAfter: &t3}, // ready to be processed
+		Index{ID: 4, RepositoryID: 50, State: "queued"},                    // not delayed
+		Index{ID: 5, RepositoryID: 50, State: "processing", ProcessAfter: &t1},
+		Index{ID: 6, RepositoryID: 51, State: "queued", ProcessAfter: &t1}, // another repository
+	)
+
+	processAfter, ok, err := store.DeleteDelayedIndexes(context.Background(), 50)
+	if err != nil {
+		t.Fatalf("unexpected error deleting delayed indexes: %s", err)
+	}
+	if !ok {
+		t.Fatalf("expected delayed indexes to be deleted")
+	}
+	if !processAfter.Equal(t1) {
+		t.Errorf("unexpected process after time. want=%s have=%s", t1, processAfter)
+	}
+
+	for id, expected := range map[int]bool{1: false, 2: false, 3: true, 4: true, 5: true, 6: true} {
+		if _, exists, err := store.GetIndexByID(context.Background(), id); err != nil {
+			t.Fatalf("unexpected error getting index: %s", err)
+		} else if exists != expected {
+			t.Errorf("unexpected existence of index %d. want=%v have=%v", id, expected, exists)
+		}
+	}
+
+	if _, ok, err := store.DeleteDelayedIndexes(context.Background(), 50); err != nil {
+		t.Fatalf("unexpected error deleting delayed indexes: %s", err)
+	} else if ok {
+		t.Errorf("expected no delayed indexes to be deleted")
+	}
+}
+
 func TestDeleteIndexByIDMissingRow(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
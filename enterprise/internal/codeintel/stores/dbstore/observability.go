@@ -12,6 +12,7 @@ type operations struct {
 	calculateVisibleUploads                *observation.Operation
 	commitGraphMetadata                    *observation.Operation
 	definitionDumps                        *observation.Operation
+	deleteDelayedIndexes                   *observation.Operation
 	deleteIndexByID                        *observation.Operation
 	deleteIndexesWithoutRepository         *observation.Operation
 	deleteOldIndexes                       *observation.Operation
@@ -42,6 +43,7 @@ type operations struct {
 	insertDependencyIndexingJob            *observation.Operation
 	insertIndex                            *observation.Operation
 	insertUpload                           *observation.Operation
+	isIndexOnPushEnabled                   *observation.Operation
 	isQueued                               *observation.Operation
 	markComplete                           *observation.Operation
 	markErrored                            *observation.Operation
@@ -61,6 +63,7 @@ type operations struct {
 	staleSourcedCommits                    *observation.Operation
 	updateCommitedAt                       *observation.Operation
 	updateIndexConfigurationByRepositoryID *observation.Operation
+	updateIndexOnPushByRepositoryID        *observation.Operation
 	updatePackageReferences                *observation.Operation
 	updatePackages                         *observation.Operation
 
@@ -93,6 +96,7 @@ func newOperations(observationContext *observation.Context, metrics *metrics.Ope
 		calculateVisibleUploads:                op("CalculateVisibleUploads"),
 		commitGraphMetadata:                    op("CommitGraphMetadata"),
 		definitionDumps:                        op("DefinitionDumps"),
+		deleteDelayedIndexes:                   op("DeleteDelayedIndexes"),
 		deleteIndexByID:                        op("DeleteIndexByID"),
 		deleteIndexesWithoutRepository:         op("DeleteIndexesWithoutRepository"),
 		deleteOldIndexes:                       op("DeleteOldIndexes"),
@@ -123,6 +127,7 @@ func newOperations(observationContext *observation.Context, metrics *metrics.Ope
 		insertDependencyIndexingJob:            op("InsertDependencyIndexingJob"),
 		insertIndex:                            op("InsertIndex"),
 		insertUpload:                           op("InsertUpload"),
+		isIndexOnPushEnabled:                   op("IsIndexOnPushEnabled"),
 		isQueued:                               op("IsQueued"),
 		markComplete:                           op("MarkComplete"),
 		markErrored:                            op("MarkErrored"),
@@ -142,6 +147,7 @@ func newOperations(observationContext *observation.Context, metrics *metrics.Ope
 		staleSourcedCommits:                    op("StaleSourcedCommits"),
 		updateCommitedAt:                       op("UpdateCommitedAt"),
 		updateIndexConfigurationByRepositoryID: op("UpdateIndexConfigurationByRepositoryID"),
+		updateIndexOnPushByRepositoryID:        op("UpdateIndexOnPushByRepositoryID"),
 		updatePackageReferences:                op("UpdatePackageReferences"),
 		updatePackages:                         op("UpdatePackages"),
 
@@ -13,12 +13,22 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 )
 
+// The digest modes of email actions. An email is sent for every trigger event of an action in
+// immediate mode, and a single email summarizing the trigger events of the last hour or day
+// otherwise.
+const (
+	DigestImmediate = "IMMEDIATE"
+	DigestHourly    = "HOURLY"
+	DigestDaily     = "DAILY"
+)
+
 type MonitorEmail struct {
 	Id        int64
 	Monitor   int64
 	Enabled   bool
 	Priority  string
 	Header    string
+	Digest    string
 	CreatedBy int32
 	CreatedAt time.Time
 	ChangedBy int32
@@ -79,7 +89,7 @@ func (s *Store) TotalCountActionEmails(ctx context.Context, monitorID int64) (co
 }
 
 const actionEmailByIDFmtStr = `
-SELECT id, monitor, enabled, priority, header, created_by, created_at, changed_by, changed_at
+SELECT id, monitor, enabled, priority, header, digest, created_by, created_at, changed_by, changed_at
 FROM cm_emails
 WHERE id = %s
 `
@@ -109,6 +119,7 @@ UPDATE cm_emails
 SET enabled = %s,
 	priority = %s,
 	header = %s,
+	digest = COALESCE(%s, digest),
 	changed_by = %s,
 	changed_at = %s
 WHERE id = %s
@@ -132,6 +143,7 @@ func (s *Store) updateActionEmailQuery(ctx context.Context, monitorID int64, arg
 		args.Update.Enabled,
 		args.Update.Priority,
 		args.Update.Header,
+		args.Update.Digest,
 		a.UID,
 		now,
 		actionID,
@@ -141,7 +153,7 @@ func (s *Store) updateActionEmailQuery(ctx context.Context, monitorID int64, arg
 }
 
 const readActionEmailFmtStr = `
-SELECT id, monitor, enabled, priority, header, created_by, created_at, changed_by, changed_at
+SELECT id, monitor, enabled, priority, header, digest, created_by, created_at, changed_by, changed_at
 FROM cm_emails
 WHERE monitor = %s
 AND id > %s
@@ -163,12 +175,16 @@ func (s *Store) ReadActionEmailQuery(ctx context.Context, monitorID int64, args
 
 const createActionEmailFmtStr = `
 INSERT INTO cm_emails
-(monitor, enabled, priority, header, created_by, created_at, changed_by, changed_at)
-VALUES (%s,%s,%s,%s,%s,%s,%s,%s)
+(monitor, enabled, priority, header, digest, created_by, created_at, changed_by, changed_at)
+VALUES (%s,%s,%s,%s,%s,%s,%s,%s,%s)
 RETURNING %s;
 `
 
 func (s *Store) createActionEmailQuery(ctx context.Context, monitorID int64, args *graphqlbackend.CreateActionEmailArgs) (*sqlf.Query, error) {
+	digest := DigestImmediate
+	if args.Digest != nil {
+		digest = *args.Digest
+	}
 	now := s.Now()
 	a := actor.FromContext(ctx)
 	return sqlf.Sprintf(
@@ -177,6 +193,7 @@ func (s *Store) createActionEmailQuery(ctx context.Context, monitorID int64, arg
 		args.Enabled,
 		args.Priority,
 		args.Header,
+		digest,
 		a.UID,
 		now,
 		a.UID,
@@ -205,6 +222,7 @@ var EmailsColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_emails.enabled"),
 	sqlf.Sprintf("cm_emails.priority"),
 	sqlf.Sprintf("cm_emails.header"),
+	sqlf.Sprintf("cm_emails.digest"),
 	sqlf.Sprintf("cm_emails.created_by"),
 	sqlf.Sprintf("cm_emails.created_at"),
 	sqlf.Sprintf("cm_emails.changed_by"),
@@ -220,6 +238,7 @@ func ScanEmails(rows *sql.Rows) (ms []*MonitorEmail, err error) {
 			&m.Enabled,
 			&m.Priority,
 			&m.Header,
+			&m.Digest,
 			&m.CreatedBy,
 			&m.CreatedAt,
 			&m.ChangedBy,
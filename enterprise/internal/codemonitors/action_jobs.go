@@ -18,6 +18,10 @@ type ActionJob struct {
 	Email        int64
 	TriggerEvent int
 
+	// DigestAfter is set for digest emails, which cover the trigger events after DigestAfter up
+	// to and including TriggerEvent.
+	DigestAfter *int
+
 	// Fields demanded by any dbworker.
 	State          string
 	FailureMessage *string
@@ -46,6 +50,7 @@ var ActionJobsColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_action_jobs.id"),
 	sqlf.Sprintf("cm_action_jobs.email"),
 	sqlf.Sprintf("cm_action_jobs.trigger_event"),
+	sqlf.Sprintf("cm_action_jobs.digest_after"),
 	sqlf.Sprintf("cm_action_jobs.state"),
 	sqlf.Sprintf("cm_action_jobs.failure_message"),
 	sqlf.Sprintf("cm_action_jobs.started_at"),
@@ -57,7 +62,7 @@ var ActionJobsColumns = []*sqlf.Query{
 }
 
 const readActionEmailEventsFmtStr = `
-SELECT id, email, trigger_event, state, failure_message, started_at, finished_at, process_after, num_resets, num_failures, log_contents
+SELECT id, email, trigger_event, digest_after, state, failure_message, started_at, finished_at, process_after, num_resets, num_failures, log_contents
 FROM cm_action_jobs
 WHERE %s
 AND id > %s
@@ -109,7 +114,7 @@ const enqueueActionEmailFmtStr = `
 WITH due AS (
 	SELECT e.id, e.monitor, e.enabled, e.priority, e.header, e.created_by, e.created_at, e.changed_by, e.changed_at
 	FROM cm_emails e INNER JOIN cm_queries q ON e.monitor = q.monitor
-	WHERE q.id = %s AND e.enabled = true
+	WHERE q.id = %s AND e.enabled = true AND e.digest = 'IMMEDIATE'
 ),
 busy AS (
     SELECT DISTINCT email as id FROM cm_action_jobs
@@ -120,6 +125,8 @@ INSERT INTO cm_action_jobs (email, trigger_event)
 SELECT id, %s::integer from due EXCEPT SELECT id, %s::integer from busy ORDER BY id
 `
 
+// EnqueueActionEmailsForQueryIDInt64 enqueues a job for each enabled email action of the monitor
+// of the given trigger query that isn't a digest. Digests are enqueued by EnqueueDigestActionEmails.
 func (s *Store) EnqueueActionEmailsForQueryIDInt64(ctx context.Context, queryID int64, triggerEventID int) (err error) {
 	return s.Store.Exec(ctx, sqlf.Sprintf(enqueueActionEmailFmtStr, queryID, triggerEventID, triggerEventID))
 }
@@ -144,7 +151,7 @@ func (s *Store) GetActionJobMetadata(ctx context.Context, recordID int) (m *Acti
 }
 
 const actionJobForIDFmtStr = `
-SELECT id, email, trigger_event, state, failure_message, started_at, finished_at, process_after, num_resets, num_failures, log_contents
+SELECT id, email, trigger_event, digest_after, state, failure_message, started_at, finished_at, process_after, num_resets, num_failures, log_contents
 FROM cm_action_jobs
 WHERE id = %s
 `
@@ -191,6 +198,7 @@ func scanActionJobs(rows *sql.Rows, err error) ([]*ActionJob, error) {
 			&aj.Id,
 			&aj.Email,
 			&aj.TriggerEvent,
+			&aj.DigestAfter,
 			&aj.State,
 			&aj.FailureMessage,
 			&aj.StartedAt,
@@ -16,6 +16,7 @@ func StartBackgroundJobs(ctx context.Context, db *sql.DB) {
 
 	routines := []goroutine.BackgroundRoutine{
 		newTriggerQueryEnqueuer(ctx, codeMonitorsStore),
+		newDigestEnqueuer(ctx, codeMonitorsStore),
 		newTriggerJobsLogDeleter(ctx, codeMonitorsStore),
 		newTriggerQueryRunner(ctx, codeMonitorsStore, triggerMetrics),
 		newTriggerQueryResetter(ctx, codeMonitorsStore, triggerMetrics),
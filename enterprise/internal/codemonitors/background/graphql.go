@@ -10,6 +10,10 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	cm "github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 
@@ -79,6 +83,7 @@ const gqlSearchQuery = `query Search(
 						}
 						oid
 						abbreviatedOID
+						url
 						author {
 							person {
 								displayName
@@ -111,6 +116,7 @@ type gqlSearchResponse struct {
 		Search struct {
 			Results struct {
 				ApproximateResultCount string
+				LimitHit               bool
 				Cloning                []*api.Repo
 				Timedout               []*api.Repo
 				Results                []interface{}
@@ -121,40 +127,121 @@ type gqlSearchResponse struct {
 }
 
 func search(ctx context.Context, query string) (*gqlSearchResponse, error) {
+	var res *gqlSearchResponse
+	if err := doGraphQL(ctx, "Search", gqlSearchQuery, gqlSearchVars{Query: query}, &res); err != nil {
+		return nil, err
+	}
+	if len(res.Errors) > 0 {
+		return res, errors.Errorf("graphql: errors: %v", res.Errors)
+	}
+	return res, nil
+}
+
+const gqlRepositoriesQuery = `query Repositories(
+	$query: String!,
+) {
+	search(query: $query) {
+		results {
+			results {
+				__typename
+				... on Repository {
+					id
+					name
+					defaultBranch {
+						target {
+							oid
+						}
+					}
+				}
+			}
+		}
+	}
+}`
+
+type gqlRepositoriesResponse struct {
+	Data struct {
+		Search struct {
+			Results struct {
+				Results []struct {
+					Typename      string `json:"__typename"`
+					ID            graphql.ID
+					Name          string
+					DefaultBranch *struct {
+						Target struct {
+							OID string
+						}
+					}
+				}
+			}
+		}
+	}
+	Errors []interface{}
+}
+
+// repositoryHead is a repository and the head of its default branch.
+type repositoryHead struct {
+	ID   api.RepoID
+	Name string
+	Head string
+}
+
+// searchRepositories returns the repositories matched by the given type:repo search query, with
+// the heads of their default branches. Repositories that are empty or not cloned yet are skipped.
+func searchRepositories(ctx context.Context, query string) ([]repositoryHead, error) {
+	var res *gqlRepositoriesResponse
+	if err := doGraphQL(ctx, "Repositories", gqlRepositoriesQuery, gqlSearchVars{Query: query}, &res); err != nil {
+		return nil, err
+	}
+	if len(res.Errors) > 0 {
+		return nil, errors.Errorf("graphql: errors: %v", res.Errors)
+	}
+
+	var repos []repositoryHead
+	for _, result := range res.Data.Search.Results.Results {
+		if result.Typename != "Repository" || result.DefaultBranch == nil || result.DefaultBranch.Target.OID == "" {
+			continue
+		}
+		id, err := graphqlbackend.UnmarshalRepositoryID(result.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "UnmarshalRepositoryID")
+		}
+		repos = append(repos, repositoryHead{ID: id, Name: result.Name, Head: result.DefaultBranch.Target.OID})
+	}
+	return repos, nil
+}
+
+// doGraphQL sends the given GraphQL query to the frontend and decodes the response into res.
+func doGraphQL(ctx context.Context, queryName, query string, vars interface{}, res interface{}) error {
 	var buf bytes.Buffer
 	err := json.NewEncoder(&buf).Encode(graphQLQuery{
-		Query:     gqlSearchQuery,
-		Variables: gqlSearchVars{Query: query},
+		Query:     query,
+		Variables: vars,
 	})
 	if err != nil {
-		return nil, errors.Wrap(err, "Encode")
+		return errors.Wrap(err, "Encode")
 	}
 
-	url, err := gqlURL("Search")
+	url, err := gqlURL(queryName)
 	if err != nil {
-		return nil, errors.Wrap(err, "constructing frontend URL")
+		return errors.Wrap(err, "constructing frontend URL")
 	}
 
 	req, err := http.NewRequest("POST", url, &buf)
 	if err != nil {
-		return nil, errors.Wrap(err, "Post")
+		return errors.Wrap(err, "Post")
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 	resp, err := httpcli.InternalDoer.Do(req.WithContext(ctx))
 	if err != nil {
-		return nil, errors.Wrap(err, "Post")
+		return errors.Wrap(err, "Post")
 	}
 	defer resp.Body.Close()
 
-	var res *gqlSearchResponse
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, errors.Wrap(err, "Decode")
-	}
-	if len(res.Errors) > 0 {
-		return res, errors.Errorf("graphql: errors: %v", res.Errors)
+	if err := json.NewDecoder(resp.Body).Decode(res); err != nil {
+		return errors.Wrap(err, "Decode")
 	}
-	return res, nil
+	return nil
 }
 
 func gqlURL(queryName string) (string, error) {
@@ -201,3 +288,35 @@ func extractTime(result interface{}) (t *time.Time, err error) {
 		return nil, errors.Errorf("unexpected result __typename %q", typeName)
 	}
 }
+
+// extractCommit extracts the matched commit from the given search result.
+func extractCommit(result interface{}) (c *cm.MatchedCommit, err error) {
+	// Use recover because we assume the data structure here a lot, for less
+	// error checking.
+	defer func() {
+		if r := recover(); r != nil {
+			// Same as net/http
+			const size = 64 << 10
+			buf := make([]byte, size)
+			buf = buf[:runtime.Stack(buf, false)]
+			log.Printf("failed to extract commit from search result: %v\n%s", r, buf)
+			err = errors.Errorf("failed to extract commit from search result")
+		}
+	}()
+
+	m := result.(map[string]interface{})
+	typeName := m["__typename"].(string)
+	switch typeName {
+	case "CommitSearchResult":
+		commit := m["commit"].(map[string]interface{})
+		repository := commit["repository"].(map[string]interface{})
+		return &cm.MatchedCommit{
+			Repository:     repository["name"].(string),
+			OID:            commit["oid"].(string),
+			AbbreviatedOID: commit["abbreviatedOID"].(string),
+			URL:            commit["url"].(string),
+		}, nil
+	default:
+		return nil, errors.Errorf("unexpected result __typename %q", typeName)
+	}
+}
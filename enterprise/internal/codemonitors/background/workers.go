@@ -3,6 +3,8 @@ package background
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -12,7 +14,11 @@ import (
 
 	cm "github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors/email"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
@@ -43,6 +49,15 @@ func newTriggerQueryEnqueuer(ctx context.Context, store *cm.Store) goroutine.Bac
 	return goroutine.NewPeriodicGoroutine(ctx, 1*time.Minute, enqueueActive)
 }
 
+func newDigestEnqueuer(ctx context.Context, store *cm.Store) goroutine.BackgroundRoutine {
+	enqueueDigests := goroutine.NewHandlerWithErrorMessage(
+		"code_monitors_digest_enqueuer",
+		func(ctx context.Context) error {
+			return store.EnqueueDigestActionEmails(ctx)
+		})
+	return goroutine.NewPeriodicGoroutine(ctx, 1*time.Minute, enqueueDigests)
+}
+
 func newTriggerQueryResetter(ctx context.Context, s *cm.Store, metrics codeMonitorsMetrics) *dbworker.Resetter {
 	workerStore := createDBWorkerStoreForTriggerJobs(s)
 
@@ -141,25 +156,37 @@ func (r *queryRunner) Handle(ctx context.Context, record workerutil.Record) (err
 		}
 	}()
 
-	s, err := r.Store.Transact(ctx)
+	var q *cm.MonitorQuery
+	q, err = r.Store.GetQueryByRecordID(ctx, record.RecordID())
 	if err != nil {
 		return err
 	}
-	defer func() { err = s.Done(err) }()
 
-	var q *cm.MonitorQuery
-	q, err = s.GetQueryByRecordID(ctx, record.RecordID())
+	// Search before opening the transaction, since searching may take a while. The commits
+	// searched in each repository are recorded in the same transaction as the actions we
+	// enqueue, so a failed run searches the same commits again when retried.
+	var (
+		queries  []string
+		results  *gqlSearchResponse
+		searched []repositoryHead
+	)
+	queries, results, searched, err = searchNewCommits(ctx, r.Store, q)
 	if err != nil {
 		return err
 	}
-	newQuery := newQueryWithAfterFilter(q)
 
-	// Search.
-	var results *gqlSearchResponse
-	results, err = search(ctx, newQuery)
+	s, err := r.Store.Transact(ctx)
 	if err != nil {
 		return err
 	}
+	defer func() { err = s.Done(err) }()
+
+	for _, repo := range searched {
+		err = s.SetLastSearched(ctx, q.Id, repo.ID, repo.Head)
+		if err != nil {
+			return errors.Errorf("store.SetLastSearched: %w", err)
+		}
+	}
 	var numResults int
 	if results != nil {
 		numResults = len(results.Data.Search.Results.Results)
@@ -176,11 +203,18 @@ func (r *queryRunner) Handle(ctx context.Context, record workerutil.Record) (err
 	if err != nil {
 		return err
 	}
-	// Log the actual query we ran and whether we got any new results.
-	err = s.LogSearch(ctx, newQuery, numResults, record.RecordID())
+	// Log the actual queries we ran and whether we got any new results.
+	err = s.LogSearch(ctx, strings.Join(queries, "\n"), numResults, record.RecordID())
 	if err != nil {
 		return errors.Errorf("LogSearch: %w", err)
 	}
+	// Keep the matched commits around for digest emails.
+	if numResults > 0 {
+		err = s.LogSearchResults(ctx, matchedCommits(results), record.RecordID())
+		if err != nil {
+			return errors.Errorf("LogSearchResults: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -231,9 +265,19 @@ func (r *actionRunner) Handle(ctx context.Context, record workerutil.Record) (er
 		return errors.Errorf("store.AllRecipientsForEmailIDInt64: %w", err)
 	}
 
-	data, err = email.NewTemplateDataForNewSearchResults(ctx, m.Description, m.Query, e, zeroOrVal(m.NumResults))
-	if err != nil {
-		return errors.Errorf("email.NewTemplateDataForNewSearchResults: %w", err)
+	// A digest summarizes all trigger events since the previous digest. Its data depends on the
+	// recipient, because it lists the matched commits.
+	var d *cm.ActionJobDigest
+	if j.DigestAfter != nil {
+		d, err = s.GetActionJobDigest(ctx, record.RecordID())
+		if err != nil {
+			return errors.Errorf("store.GetActionJobDigest: %w", err)
+		}
+	} else {
+		data, err = email.NewTemplateDataForNewSearchResults(ctx, m.Description, m.Query, e, zeroOrVal(m.NumResults))
+		if err != nil {
+			return errors.Errorf("email.NewTemplateDataForNewSearchResults: %w", err)
+		}
 	}
 	for _, rec := range recs {
 		if rec.NamespaceOrgID != nil {
@@ -243,7 +287,17 @@ func (r *actionRunner) Handle(ctx context.Context, record workerutil.Record) (er
 		if rec.NamespaceUserID == nil {
 			return errors.Errorf("nil recipient")
 		}
-		err = email.SendEmailForNewSearchResult(ctx, *rec.NamespaceUserID, data)
+		recData := data
+		if d != nil {
+			recData, err = digestTemplateData(ctx, s, m.Description, e, d, *rec.NamespaceUserID)
+			if err != nil {
+				return err
+			}
+			if recData == nil {
+				continue // none of the commits are visible to the recipient
+			}
+		}
+		err = email.SendEmailForNewSearchResult(ctx, *rec.NamespaceUserID, recData)
 		if err != nil {
 			return err
 		}
@@ -251,6 +305,242 @@ func (r *actionRunner) Handle(ctx context.Context, record workerutil.Record) (er
 	return nil
 }
 
+// digestTemplateData returns the data of the given digest for the given recipient, or nil if the
+// recipient can't see any of the matched commits.
+func digestTemplateData(ctx context.Context, s *cm.Store, description string, e *cm.MonitorEmail, d *cm.ActionJobDigest, userID int32) (*email.TemplateDataNewSearchResults, error) {
+	commits, err := visibleCommits(ctx, s.Handle().DB(), userID, d.Commits)
+	if err != nil {
+		return nil, errors.Errorf("visibleCommits: %w", err)
+	}
+	if len(commits) == 0 {
+		return nil, nil
+	}
+	// Commit searches have a result per commit, so the visible commits are the visible results.
+	data, err := email.NewTemplateDataForDigest(ctx, description, e, &cm.ActionJobDigest{
+		Query:      d.Query,
+		NumEvents:  d.NumEvents,
+		NumResults: len(commits),
+		Commits:    commits,
+	})
+	if err != nil {
+		return nil, errors.Errorf("email.NewTemplateDataForDigest: %w", err)
+	}
+	return data, nil
+}
+
+// visibleCommits returns the commits of the repositories the given user has access to. Trigger
+// queries are searched by the internal actor, so their results aren't limited by repository
+// permissions, and the commits listed in digests have to be.
+func visibleCommits(ctx context.Context, db dbutil.DB, userID int32, commits []cm.MatchedCommit) ([]cm.MatchedCommit, error) {
+	if len(commits) == 0 {
+		return nil, nil
+	}
+	names := make([]string, 0, len(commits))
+	for _, c := range commits {
+		names = append(names, c.Repository)
+	}
+	// 🚨 SECURITY: Repositories are listed as the recipient, so that repository permissions
+	// apply.
+	repos, err := database.Repos(db).ListRepoNames(actor.WithActor(ctx, actor.FromUser(userID)), database.ReposListOptions{Names: names})
+	if err != nil {
+		return nil, err
+	}
+	visible := make(map[string]struct{}, len(repos))
+	for _, repo := range repos {
+		visible[string(repo.Name)] = struct{}{}
+	}
+
+	var filtered []cm.MatchedCommit
+	for _, c := range commits {
+		if _, ok := visible[c.Repository]; ok {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered, nil
+}
+
+const (
+	// maxListedRepositories is the maximum number of repositories a trigger query can match and
+	// still be searched per repository. Trigger queries matching more repositories are run with
+	// an after: filter instead.
+	maxListedRepositories = 10000
+
+	// repositoriesPerSearch is the maximum number of repositories searched by a single query.
+	repositoriesPerSearch = 20
+)
+
+// searchNewCommits searches the commits made since the last run of the given trigger query. If
+// the repositories of the query can be listed, every repository whose default branch changed
+// since the last run is searched for the commits between the previously searched head and the
+// current head, so that commits are neither missed nor found twice regardless of their dates or
+// when they were synced. Otherwise, the query is run once with an after: filter. It returns the
+// queries it ran, their combined results and the repositories whose heads were searched, which
+// the caller records as searched.
+func searchNewCommits(ctx context.Context, s *cm.Store, q *cm.MonitorQuery) (queries []string, results *gqlSearchResponse, searched []repositoryHead, err error) {
+	repoQuery, ok := repositoryQuery(q.QueryString)
+	if !ok {
+		return searchWithAfterFilter(ctx, q)
+	}
+
+	repos, err := searchRepositories(ctx, repoQuery)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "searchRepositories")
+	}
+	if len(repos) > maxListedRepositories {
+		log15.Warn("code monitor trigger query matches too many repositories to be searched per repository", "query", q.Id, "max", maxListedRepositories)
+		return searchWithAfterFilter(ctx, q)
+	}
+	lastSearched, err := s.LastSearched(ctx, q.Id)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "store.LastSearched")
+	}
+
+	var (
+		moved       []repositoryHead
+		repoQueries []string
+	)
+	for _, repo := range repos {
+		last, ok := lastSearched[repo.ID]
+		if ok && last == repo.Head {
+			continue // no new commits
+		}
+		moved = append(moved, repo)
+		repoQueries = append(repoQueries, newQueryForRepository(q, repo, last))
+	}
+
+	results = &gqlSearchResponse{}
+	for start := 0; start < len(repoQueries); start += repositoriesPerSearch {
+		end := start + repositoriesPerSearch
+		if end > len(repoQueries) {
+			end = len(repoQueries)
+		}
+		batch := repoQueries[start:end]
+
+		newQuery := batchQuery(batch)
+		batchResults, err := search(ctx, newQuery)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		// The repositories of a batch share the result limit of the query, so search them one
+		// by one if it was hit, to not miss the results of some repositories.
+		if batchResults.Data.Search.Results.LimitHit && len(batch) > 1 {
+			for _, repoQuery := range batch {
+				repoResults, err := search(ctx, repoQuery)
+				if err != nil {
+					return nil, nil, nil, err
+				}
+				queries = append(queries, repoQuery)
+				results.Data.Search.Results.Results = append(results.Data.Search.Results.Results, repoResults.Data.Search.Results.Results...)
+			}
+			continue
+		}
+		queries = append(queries, newQuery)
+		results.Data.Search.Results.Results = append(results.Data.Search.Results.Results, batchResults.Data.Search.Results.Results...)
+	}
+	return queries, results, moved, nil
+}
+
+// searchWithAfterFilter runs the given trigger query once with an after: filter.
+func searchWithAfterFilter(ctx context.Context, q *cm.MonitorQuery) ([]string, *gqlSearchResponse, []repositoryHead, error) {
+	newQuery := newQueryWithAfterFilter(q)
+	results, err := search(ctx, newQuery)
+	return []string{newQuery}, results, nil, err
+}
+
+// batchQuery combines the given queries into a single query, which finds the union of their
+// results.
+func batchQuery(queries []string) string {
+	if len(queries) == 1 {
+		return queries[0]
+	}
+	return "(" + strings.Join(queries, ") or (") + ")"
+}
+
+// repositoryScopeFields are the fields of a search query that determine which repositories are
+// searched.
+var repositoryScopeFields = map[string]struct{}{
+	query.FieldRepo:               {},
+	query.FieldRepoGroup:          {},
+	query.FieldContext:            {},
+	query.FieldFork:               {},
+	query.FieldArchived:           {},
+	query.FieldVisibility:         {},
+	query.FieldRepoHasFile:        {},
+	query.FieldRepoHasCommitAfter: {},
+}
+
+// repositoryQuery returns a type:repo search query that lists the repositories searched by the
+// given trigger query. It returns false if the repositories can't be listed separately, because
+// the query can't be parsed, uses or-expressions or specifies revisions to search.
+func repositoryQuery(queryString string) (string, bool) {
+	q, err := query.ParseLiteral(queryString)
+	if err != nil {
+		return "", false
+	}
+
+	ok := !containsOr(q)
+
+	parts := []string{}
+	query.VisitParameter(q, func(field, value string, negated bool, _ query.Annotation) {
+		if field == query.FieldRev || (field == query.FieldRepo && strings.Contains(value, "@")) {
+			ok = false
+		}
+		if _, scope := repositoryScopeFields[field]; !scope {
+			return
+		}
+		if strings.ContainsAny(value, " \t\"") {
+			value = strconv.Quote(value)
+		}
+		part := field + ":" + value
+		if negated {
+			part = "-" + part
+		}
+		parts = append(parts, part)
+	})
+	if !ok {
+		return "", false
+	}
+	// List one more repository than we search per repository, to know if there are too many.
+	return strings.Join(append(parts, "type:repo", "count:"+strconv.Itoa(maxListedRepositories+1)), " "), true
+}
+
+func containsOr(nodes []query.Node) bool {
+	for _, node := range nodes {
+		if op, ok := node.(query.Operator); ok && (op.Kind == query.Or || containsOr(op.Operands)) {
+			return true
+		}
+	}
+	return false
+}
+
+// newQueryForRepository constructs a new query which finds the search results of the given
+// repository introduced by the commits reachable from its current head but not from lastHead,
+// the head that was searched last. If the repository wasn't searched yet, it finds the search
+// results introduced after the last time we queried instead.
+//
+// The repository is recorded as searched up to its current head afterwards, so the query must
+// find all of these results: its result count is not limited unless the trigger query limits it.
+func newQueryForRepository(q *cm.MonitorQuery, repo repositoryHead, lastHead string) string {
+	revisions := repo.Head
+	newQuery := q.QueryString
+	if lastHead != "" {
+		revisions += ":^" + lastHead
+	} else {
+		newQuery = newQueryWithAfterFilter(q)
+	}
+	return fmt.Sprintf("%s repo:^%s$@%s", withCountUnlimited(newQuery), regexp.QuoteMeta(repo.Name), revisions)
+}
+
+// withCountUnlimited adds `count:9999999` to the given search query string iff `count:` does not
+// exist in the query string, so that the results of the query are not cut off at the default
+// result limit.
+func withCountUnlimited(s string) string {
+	if strings.Contains(s, "count:") {
+		return s
+	}
+	return s + " count:9999999"
+}
+
 // newQueryWithAfterFilter constructs a new query which finds search results
 // introduced after the last time we queried.
 func newQueryWithAfterFilter(q *cm.MonitorQuery) string {
@@ -260,14 +550,7 @@ func newQueryWithAfterFilter(q *cm.MonitorQuery) string {
 	if q.LatestResult == nil {
 		return q.QueryString
 	}
-	// ATTENTION: This is a stop gap. Add(time.Second) is necessary because currently
-	// the after: filter is implemented as "at OR after". If we didn't add a second
-	// here, we would send out emails for every run, always showing at least the last
-	// result. This means there is non-zero chance that we miss results whenever
-	// commits have a timestamp equal to the value of :after but arrive after this
-	// job has run.
-	afterTime := q.LatestResult.UTC().Add(time.Second).Format(time.RFC3339)
-	return strings.Join([]string{q.QueryString, fmt.Sprintf(`after:"%s"`, afterTime)}, " ")
+	return cm.QueryWithAfterFilter(q.QueryString, *q.LatestResult)
 }
 
 func latestResultTime(previousLastResult *time.Time, v *gqlSearchResponse, searchErr error) time.Time {
@@ -280,13 +563,35 @@ func latestResultTime(previousLastResult *time.Time, v *gqlSearchResponse, searc
 		return time.Now()
 	}
 
-	// Results are ordered chronologically, so first result is the latest.
-	t, err := extractTime(v.Data.Search.Results.Results[0])
-	if err != nil {
-		// Error already logged by extractTime.
-		return time.Now()
+	// Results are ordered chronologically per search, but they may be combined from the searches
+	// of several repositories, so look for the latest.
+	var latest *time.Time
+	for _, result := range v.Data.Search.Results.Results {
+		t, err := extractTime(result)
+		if err != nil {
+			// Error already logged by extractTime.
+			return time.Now()
+		}
+		if latest == nil || t.After(*latest) {
+			latest = t
+		}
+	}
+	return *latest
+}
+
+// matchedCommits returns the commits matched by the given search results. Results the commit
+// can't be extracted from are skipped.
+func matchedCommits(v *gqlSearchResponse) []cm.MatchedCommit {
+	commits := make([]cm.MatchedCommit, 0, len(v.Data.Search.Results.Results))
+	for _, result := range v.Data.Search.Results.Results {
+		c, err := extractCommit(result)
+		if err != nil {
+			// Error already logged by extractCommit.
+			continue
+		}
+		commits = append(commits, *c)
 	}
-	return *t
+	return commits
 }
 
 func zeroOrVal(i *int) int {
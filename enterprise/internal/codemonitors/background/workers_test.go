@@ -8,11 +8,17 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors/email"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors/storetest"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
 )
 
 func init() {
@@ -117,3 +123,199 @@ func TestActionRunner(t *testing.T) {
 		})
 	}
 }
+
+func TestDigestTemplateData(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	email.MockExternalURL = func() *url.URL {
+		externalURL, _ := url.Parse("https://www.sourcegraph.com")
+		return externalURL
+	}
+	t.Cleanup(func() { email.MockExternalURL = nil })
+
+	db := dbtesting.GetDB(t)
+	ctx := context.Background()
+	s := codemonitors.NewStore(db)
+
+	newUser := func(name string) int32 {
+		user, err := database.Users(db).Create(ctx, database.NewUser{Username: name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The first user is a site admin, which would bypass repository permissions.
+		if err := database.Users(db).SetIsSiteAdmin(ctx, user.ID, false); err != nil {
+			t.Fatal(err)
+		}
+		return user.ID
+	}
+	alice, bob := newUser("alice"), newUser("bob")
+
+	newRepo := func(name string, private bool) api.RepoID {
+		var id api.RepoID
+		if err := s.QueryRow(ctx, sqlf.Sprintf("INSERT INTO repo (name, private) VALUES (%s, %s) RETURNING id", name, private)).Scan(&id); err != nil {
+			t.Fatal(err)
+		}
+		return id
+	}
+	newRepo("github.com/sourcegraph/public", false)
+	privateRepo := newRepo("github.com/sourcegraph/private", true)
+
+	// Only alice has access to the private repository.
+	if err := s.Exec(ctx, sqlf.Sprintf(
+		"INSERT INTO user_permissions (user_id, permission, object_type, object_ids_ints, updated_at) VALUES (%s, 'read', 'repos', %s, NOW())",
+		alice, pq.Array([]int32{int32(privateRepo)}),
+	)); err != nil {
+		t.Fatal(err)
+	}
+	authz.SetProviders(false, nil)
+	t.Cleanup(func() { authz.SetProviders(true, nil) })
+
+	publicCommit := codemonitors.MatchedCommit{Repository: "github.com/sourcegraph/public", OID: "deadbeef00", AbbreviatedOID: "deadbee", URL: "/github.com/sourcegraph/public/-/commit/deadbeef00"}
+	privateCommit := codemonitors.MatchedCommit{Repository: "github.com/sourcegraph/private", OID: "cafebabe00", AbbreviatedOID: "cafebab", URL: "/github.com/sourcegraph/private/-/commit/cafebabe00"}
+	e := &codemonitors.MonitorEmail{Monitor: 1, Priority: "NORMAL", Digest: codemonitors.DigestDaily}
+
+	listedRepos := func(d *codemonitors.ActionJobDigest, userID int32) []string {
+		data, err := digestTemplateData(ctx, s, "test description", e, d, userID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data == nil {
+			return nil
+		}
+		var repos []string
+		for _, c := range data.Commits {
+			repos = append(repos, c.Repository)
+		}
+		return repos
+	}
+
+	d := &codemonitors.ActionJobDigest{Query: "type:diff TODO", NumEvents: 1, NumResults: 2, Commits: []codemonitors.MatchedCommit{publicCommit, privateCommit}}
+	if diff := cmp.Diff([]string{"github.com/sourcegraph/public", "github.com/sourcegraph/private"}, listedRepos(d, alice)); diff != "" {
+		t.Fatalf("unexpected commits for alice (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"github.com/sourcegraph/public"}, listedRepos(d, bob)); diff != "" {
+		t.Fatalf("unexpected commits for bob (-want +got):\n%s", diff)
+	}
+
+	// Recipients who can't see any of the commits get no digest.
+	d = &codemonitors.ActionJobDigest{Query: "type:diff TODO", NumEvents: 1, NumResults: 1, Commits: []codemonitors.MatchedCommit{privateCommit}}
+	if repos := listedRepos(d, bob); repos != nil {
+		t.Fatalf("expected no digest for bob, got commits of %v", repos)
+	}
+}
+
+func TestRepositoryQuery(t *testing.T) {
+	for name, tc := range map[string]struct {
+		query  string
+		want   string
+		wantOK bool
+	}{
+		"repo filters": {
+			query:  `repo:github\.com/sourcegraph/ -repo:about fork:yes func type:diff author:"Jane Doe"`,
+			want:   `repo:github\.com/sourcegraph/ -repo:about fork:yes type:repo count:10001`,
+			wantOK: true,
+		},
+		"no repo filters": {
+			query:  `type:commit message:CVE`,
+			want:   `type:repo count:10001`,
+			wantOK: true,
+		},
+		"or expression": {query: `(repo:a or repo:b) type:commit fix`},
+		"revision":      {query: `repo:a@main type:commit fix`},
+		"rev field":     {query: `repo:a rev:main type:commit fix`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, ok := repositoryQuery(tc.query)
+			if ok != tc.wantOK {
+				t.Fatalf("unexpected ok. want=%v have=%v", tc.wantOK, ok)
+			}
+			if got != tc.want {
+				t.Fatalf("unexpected query. want=%q have=%q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestNewQueryForRepository(t *testing.T) {
+	latestResult := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	q := &codemonitors.MonitorQuery{QueryString: "type:diff TODO", LatestResult: &latestResult}
+	repo := repositoryHead{ID: 1, Name: "github.com/sourcegraph/sourcegraph", Head: "cafebabe"}
+
+	t.Run("searched before", func(t *testing.T) {
+		want := `type:diff TODO count:9999999 repo:^github\.com/sourcegraph/sourcegraph$@cafebabe:^deadbeef`
+		if got := newQueryForRepository(q, repo, "deadbeef"); got != want {
+			t.Fatalf("unexpected query. want=%q have=%q", want, got)
+		}
+	})
+
+	t.Run("not searched yet", func(t *testing.T) {
+		want := `type:diff TODO after:"2021-06-01T12:00:01Z" count:9999999 repo:^github\.com/sourcegraph/sourcegraph$@cafebabe`
+		if got := newQueryForRepository(q, repo, ""); got != want {
+			t.Fatalf("unexpected query. want=%q have=%q", want, got)
+		}
+	})
+
+	t.Run("limited by the trigger query", func(t *testing.T) {
+		q := &codemonitors.MonitorQuery{QueryString: "type:diff TODO count:100"}
+		want := `type:diff TODO count:100 repo:^github\.com/sourcegraph/sourcegraph$@cafebabe:^deadbeef`
+		if got := newQueryForRepository(q, repo, "deadbeef"); got != want {
+			t.Fatalf("unexpected query. want=%q have=%q", want, got)
+		}
+	})
+}
+
+func TestBatchQuery(t *testing.T) {
+	queries := []string{
+		`type:diff TODO repo:^github\.com/sourcegraph/sourcegraph$@cafebabe:^deadbeef`,
+		`type:diff TODO after:"2021-06-01T12:00:01Z" repo:^github\.com/sourcegraph/about$@deadbeef`,
+	}
+
+	if got := batchQuery(queries[:1]); got != queries[0] {
+		t.Fatalf("unexpected query. want=%q have=%q", queries[0], got)
+	}
+
+	got := batchQuery(queries)
+	want := `(type:diff TODO repo:^github\.com/sourcegraph/sourcegraph$@cafebabe:^deadbeef) or (type:diff TODO after:"2021-06-01T12:00:01Z" repo:^github\.com/sourcegraph/about$@deadbeef)`
+	if got != want {
+		t.Fatalf("unexpected query. want=%q have=%q", want, got)
+	}
+	// Every query of the batch is searched separately.
+	q, err := query.ParseLiteral(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(query.Dnf(q)); n != len(queries) {
+		t.Fatalf("got %d disjuncts, want %d", n, len(queries))
+	}
+}
+
+func TestMatchedCommits(t *testing.T) {
+	var v gqlSearchResponse
+	v.Data.Search.Results.Results = []interface{}{
+		map[string]interface{}{
+			"__typename": "CommitSearchResult",
+			"commit": map[string]interface{}{
+				"repository":     map[string]interface{}{"name": "github.com/sourcegraph/sourcegraph"},
+				"oid":            "deadbeef00",
+				"abbreviatedOID": "deadbee",
+				"url":            "/github.com/sourcegraph/sourcegraph/-/commit/deadbeef00",
+			},
+		},
+		// Results other than commits are skipped.
+		map[string]interface{}{
+			"__typename": "FileMatch",
+		},
+	}
+
+	want := []codemonitors.MatchedCommit{{
+		Repository:     "github.com/sourcegraph/sourcegraph",
+		OID:            "deadbeef00",
+		AbbreviatedOID: "deadbee",
+		URL:            "/github.com/sourcegraph/sourcegraph/-/commit/deadbeef00",
+	}}
+	if diff := cmp.Diff(want, matchedCommits(&v)); diff != "" {
+		t.Fatalf("unexpected matched commits (-want +got):\n%s", diff)
+	}
+}
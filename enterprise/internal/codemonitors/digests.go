@@ -0,0 +1,138 @@
+package codemonitors
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+)
+
+// MatchedCommit is a commit matched by the search of a trigger event. Only what is needed to link
+// to the commit is stored, because search results may contain confidential data.
+type MatchedCommit struct {
+	Repository     string `json:"repository"`
+	OID            string `json:"oid"`
+	AbbreviatedOID string `json:"abbreviatedOID"`
+	// URL is the URL of the commit, relative to the Sourcegraph instance.
+	URL string `json:"url"`
+}
+
+const logSearchResultsFmtStr = `
+UPDATE cm_trigger_jobs
+SET search_results = %s
+WHERE id = %s
+`
+
+// LogSearchResults records the commits matched by the search of the given trigger event, so that
+// they can be listed in digest emails.
+func (s *Store) LogSearchResults(ctx context.Context, commits []MatchedCommit, recordID int) error {
+	searchResults, err := json.Marshal(commits)
+	if err != nil {
+		return err
+	}
+	return s.Store.Exec(ctx, sqlf.Sprintf(logSearchResultsFmtStr, searchResults, recordID))
+}
+
+const enqueueDigestActionEmailsFmtStr = `
+WITH due AS (
+	SELECT e.id, e.monitor, COALESCE((SELECT MAX(trigger_event) FROM cm_action_jobs WHERE email = e.id), 0) AS digest_after
+	FROM cm_emails e INNER JOIN cm_monitors m ON e.monitor = m.id
+	WHERE e.enabled = true AND m.enabled = true
+	AND e.digest <> 'IMMEDIATE'
+	AND (
+		e.last_digest_at IS NULL
+		OR e.last_digest_at + CASE e.digest WHEN 'HOURLY' THEN INTERVAL '1 hour' ELSE INTERVAL '1 day' END <= %s
+	)
+	AND NOT EXISTS (
+		SELECT 1 FROM cm_action_jobs
+		WHERE email = e.id
+		AND (state = 'queued' OR state = 'processing')
+	)
+),
+digested AS (
+	UPDATE cm_emails
+	SET last_digest_at = %s
+	FROM due
+	WHERE cm_emails.id = due.id
+	RETURNING due.id, due.monitor, due.digest_after
+)
+INSERT INTO cm_action_jobs (email, trigger_event, digest_after)
+SELECT d.id, latest.trigger_event, d.digest_after
+FROM digested d, LATERAL (
+	SELECT MAX(j.id) AS trigger_event
+	FROM cm_trigger_jobs j INNER JOIN cm_queries q ON j.query = q.id
+	WHERE q.monitor = d.monitor
+	AND j.id > d.digest_after
+	AND j.state = 'completed'
+	AND j.results = true
+) latest
+WHERE latest.trigger_event IS NOT NULL
+ORDER BY d.id
+`
+
+// EnqueueDigestActionEmails enqueues a digest for every enabled email action in hourly or daily
+// mode whose last digest is at least an hour or a day old. A digest covers the trigger events with
+// results since the last trigger event sent by the email action. Nothing is enqueued if there are
+// none, but the next digest is still due only an hour or a day later.
+func (s *Store) EnqueueDigestActionEmails(ctx context.Context) error {
+	now := s.Now()
+	return s.Store.Exec(ctx, sqlf.Sprintf(enqueueDigestActionEmailsFmtStr, now, now))
+}
+
+// ActionJobDigest summarizes the trigger events covered by a digest email.
+type ActionJobDigest struct {
+	// The query of the trigger, without after: filter.
+	Query      string
+	NumEvents  int
+	NumResults int
+	Commits    []MatchedCommit
+}
+
+const getActionJobDigestFmtStr = `
+SELECT cq.query, ctj.num_results, ctj.search_results
+FROM cm_action_jobs caj
+INNER JOIN cm_emails ce ON ce.id = caj.email
+INNER JOIN cm_queries cq ON cq.monitor = ce.monitor
+INNER JOIN cm_trigger_jobs ctj ON ctj.query = cq.id
+WHERE caj.id = %s
+AND ctj.id > caj.digest_after
+AND ctj.id <= caj.trigger_event
+AND ctj.results = true
+ORDER BY ctj.id
+`
+
+// GetActionJobDigest returns the summary of the trigger events covered by the given digest action
+// job.
+func (s *Store) GetActionJobDigest(ctx context.Context, recordID int) (_ *ActionJobDigest, err error) {
+	rows, err := s.Query(ctx, sqlf.Sprintf(getActionJobDigestFmtStr, recordID))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	d := &ActionJobDigest{}
+	for rows.Next() {
+		var (
+			numResults    *int
+			searchResults []byte
+		)
+		if err := rows.Scan(&d.Query, &numResults, &searchResults); err != nil {
+			return nil, err
+		}
+		d.NumEvents++
+		if numResults != nil {
+			d.NumResults += *numResults
+		}
+		if len(searchResults) == 0 {
+			continue
+		}
+		var commits []MatchedCommit
+		if err := json.Unmarshal(searchResults, &commits); err != nil {
+			return nil, err
+		}
+		d.Commits = append(d.Commits, commits...)
+	}
+	return d, nil
+}
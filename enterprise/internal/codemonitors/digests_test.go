@@ -0,0 +1,97 @@
+package codemonitors
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
+)
+
+func TestDigestActionEmails(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx, s := newTestStore(t)
+	_, _, _, userCTX := newTestUser(ctx, t)
+	_, err := s.insertTestMonitor(userCTX, t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The first email action of the test monitor sends hourly digests, the second one an email for
+	// every trigger event.
+	if err := s.Exec(ctx, sqlf.Sprintf("UPDATE cm_emails SET digest = %s WHERE id = 1", DigestHourly)); err != nil {
+		t.Fatal(err)
+	}
+	err = s.EnqueueTriggerQueries(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const triggerEventID = 1
+	commits := []MatchedCommit{
+		{Repository: "github.com/sourcegraph/sourcegraph", OID: "deadbeef00", AbbreviatedOID: "deadbee", URL: "/github.com/sourcegraph/sourcegraph/-/commit/deadbeef00"},
+		{Repository: "github.com/sourcegraph/sourcegraph", OID: "cafebabe00", AbbreviatedOID: "cafebab", URL: "/github.com/sourcegraph/sourcegraph/-/commit/cafebabe00"},
+	}
+	if err := s.LogSearch(ctx, testQuery, len(commits), triggerEventID); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.LogSearchResults(ctx, commits, triggerEventID); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Exec(ctx, sqlf.Sprintf("UPDATE cm_trigger_jobs SET state = 'completed' WHERE id = %s", triggerEventID)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the email action in immediate mode is enqueued for the trigger event.
+	if err := s.EnqueueActionEmailsForQueryIDInt64(ctx, 1, triggerEventID); err != nil {
+		t.Fatal(err)
+	}
+	immediate, err := s.ActionJobForIDInt(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if immediate.Email != 2 || immediate.DigestAfter != nil {
+		t.Fatalf("unexpected action job %+v", immediate)
+	}
+
+	if err := s.EnqueueDigestActionEmails(ctx); err != nil {
+		t.Fatal(err)
+	}
+	digest, err := s.ActionJobForIDInt(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if digest.Email != 1 || digest.TriggerEvent != triggerEventID || digest.DigestAfter == nil || *digest.DigestAfter != 0 {
+		t.Fatalf("unexpected digest action job %+v", digest)
+	}
+
+	got, err := s.GetActionJobDigest(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &ActionJobDigest{
+		Query:      testQuery,
+		NumEvents:  1,
+		NumResults: len(commits),
+		Commits:    commits,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected digest (-want +got):\n%s", diff)
+	}
+
+	// The next digest is only due an hour later.
+	if err := s.Exec(ctx, sqlf.Sprintf("UPDATE cm_action_jobs SET state = 'completed'")); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.EnqueueDigestActionEmails(ctx); err != nil {
+		t.Fatal(err)
+	}
+	var count int
+	if err := s.QueryRow(ctx, sqlf.Sprintf("SELECT COUNT(*) FROM cm_action_jobs")).Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Fatalf("got %d action jobs, want 2", count)
+	}
+}
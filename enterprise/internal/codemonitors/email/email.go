@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 	"github.com/graph-gophers/graphql-go/relay"
@@ -21,6 +22,9 @@ const MonitorKind = "CodeMonitor"
 const utmSourceEmail = "code-monitoring-email"
 const priorityCritical = "CRITICAL"
 
+// maxDigestCommits is the maximum number of commits listed in a digest email.
+const maxDigestCommits = 50
+
 var MockSendEmailForNewSearchResult func(ctx context.Context, userID int32, data *TemplateDataNewSearchResults) error
 var MockExternalURL func() *url.URL
 
@@ -38,6 +42,19 @@ type TemplateDataNewSearchResults struct {
 	Description               string
 	NumberOfResultsWithDetail string
 	IsTest                    bool
+
+	// Digest is the period of a digest email, such as "hourly", and empty for the email of a single
+	// trigger event. Digest emails list the matched commits.
+	Digest      string
+	Commits     []*TemplateDataCommit
+	MoreCommits int
+}
+
+// TemplateDataCommit is a commit listed in a digest email.
+type TemplateDataCommit struct {
+	Repository     string
+	AbbreviatedOID string
+	URL            string
 }
 
 func NewTemplateDataForNewSearchResults(ctx context.Context, monitorDescription, queryString string, email *codemonitors.MonitorEmail, numResults int) (d *TemplateDataNewSearchResults, err error) {
@@ -57,17 +74,8 @@ func NewTemplateDataForNewSearchResults(ctx context.Context, monitorDescription,
 		return nil, err
 	}
 
-	if email.Priority == priorityCritical {
-		priority = "Critical"
-	} else {
-		priority = "New"
-	}
-
-	if numResults == 1 {
-		numberOfResultsWithDetail = fmt.Sprintf("There was %d new search result for your query", numResults)
-	} else {
-		numberOfResultsWithDetail = fmt.Sprintf("There were %d new search results for your query", numResults)
-	}
+	priority = priorityLabel(email.Priority)
+	numberOfResultsWithDetail = numberOfResults(numResults)
 
 	return &TemplateDataNewSearchResults{
 		Priority:                  priority,
@@ -78,6 +86,59 @@ func NewTemplateDataForNewSearchResults(ctx context.Context, monitorDescription,
 	}, nil
 }
 
+// NewTemplateDataForDigest returns the data of a digest email, which summarizes the given trigger
+// events and lists their matched commits.
+func NewTemplateDataForDigest(ctx context.Context, monitorDescription string, email *codemonitors.MonitorEmail, digest *codemonitors.ActionJobDigest) (*TemplateDataNewSearchResults, error) {
+	d, err := NewTemplateDataForNewSearchResults(ctx, monitorDescription, digest.Query, email, digest.NumResults)
+	if err != nil {
+		return nil, err
+	}
+	d.Digest = strings.ToLower(email.Digest)
+	if err := d.setCommits(ctx, digest.Commits); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// setCommits sets the commits listed in a digest email.
+func (d *TemplateDataNewSearchResults) setCommits(ctx context.Context, commits []codemonitors.MatchedCommit) error {
+	if len(commits) > maxDigestCommits {
+		d.MoreCommits = len(commits) - maxDigestCommits
+		commits = commits[:maxDigestCommits]
+	}
+	d.Commits = make([]*TemplateDataCommit, 0, len(commits))
+	for _, c := range commits {
+		commit := &TemplateDataCommit{
+			Repository:     c.Repository,
+			AbbreviatedOID: c.AbbreviatedOID,
+		}
+		// Links are disabled in test emails.
+		if !d.IsTest {
+			commitURL, err := sourcegraphURL(ctx, strings.TrimPrefix(c.URL, "/"), "", utmSourceEmail)
+			if err != nil {
+				return err
+			}
+			commit.URL = commitURL
+		}
+		d.Commits = append(d.Commits, commit)
+	}
+	return nil
+}
+
+func priorityLabel(priority string) string {
+	if priority == priorityCritical {
+		return "Critical"
+	}
+	return "New"
+}
+
+func numberOfResults(numResults int) string {
+	if numResults == 1 {
+		return fmt.Sprintf("There was %d new search result for your query", numResults)
+	}
+	return fmt.Sprintf("There were %d new search results for your query", numResults)
+}
+
 func NewTestTemplateDataForNewSearchResults(ctx context.Context, monitorDescription string) *TemplateDataNewSearchResults {
 	return &TemplateDataNewSearchResults{
 		Priority:                  "New",
@@ -87,6 +148,25 @@ func NewTestTemplateDataForNewSearchResults(ctx context.Context, monitorDescript
 	}
 }
 
+// NewTestTemplateDataForSearchResults returns the data of a sample email sent by a code monitor test
+// run, with the number of results the test run found. Email actions in digest mode list the
+// matched commits, as their digests would.
+func NewTestTemplateDataForSearchResults(ctx context.Context, monitorDescription string, email *codemonitors.MonitorEmail, commits []codemonitors.MatchedCommit) (*TemplateDataNewSearchResults, error) {
+	d := &TemplateDataNewSearchResults{
+		Priority:                  priorityLabel(email.Priority),
+		Description:               monitorDescription,
+		NumberOfResultsWithDetail: numberOfResults(len(commits)),
+		IsTest:                    true,
+	}
+	if email.Digest != "" && email.Digest != codemonitors.DigestImmediate {
+		d.Digest = strings.ToLower(email.Digest)
+		if err := d.setCommits(ctx, commits); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
 func sendEmail(ctx context.Context, userID int32, template txtypes.Templates, data interface{}) error {
 	email, err := api.InternalClient.UserEmailsGetEmail(ctx, userID)
 	if err != nil {
@@ -6,15 +6,20 @@ import (
 )
 
 var newSearchResultsEmailTemplates = txemail.MustValidate(txtypes.Templates{
-	Subject: `{{ if .IsTest }}Test: {{ end }}[{{.Priority}} event] {{.Description}}`,
+	Subject: `{{ if .IsTest }}Test: {{ end }}[{{.Priority}} {{ if .Digest }}{{.Digest}} digest{{ else }}event{{ end }}] {{.Description}}`,
 	Text: `
 {{ if .IsTest }}This email is a preview. Links are disabled.{{ end }}
 
-Code monitoring triggered a new event:
+{{ if .Digest }}Code monitoring triggered new events since the last {{.Digest}} digest:{{ else }}Code monitoring triggered a new event:{{ end }}
 
 {{.Description}}
 {{.NumberOfResultsWithDetail}}
-
+{{ if .Commits }}
+Matched commits:
+{{ range .Commits }}
+- {{.Repository}}@{{.AbbreviatedOID}} {{.URL}}{{ end }}{{ if .MoreCommits }}
+- and {{.MoreCommits}} more{{ end }}
+{{ end }}
 View search on Sourcegraph {{.SearchURL}}
 
 __
@@ -36,7 +41,7 @@ Sourcegraph limits what information is contained in this notification.
 	{{ end }}
 
     <p style="font-size: 16px; line-height: 24px">
-      Code monitoring triggered a new event:
+      {{ if .Digest }}Code monitoring triggered new events since the last {{.Digest}} digest:{{ else }}Code monitoring triggered a new event:{{ end }}
     </p>
     <p style="font-size: 20px; line-height: 30px; font-weight: 700">
       {{.Description}}<br />
@@ -44,6 +49,17 @@ Sourcegraph limits what information is contained in this notification.
         >{{.NumberOfResultsWithDetail}}</span
       >
     </p>
+    {{ if .Commits }}
+    <p style="font-size: 16px; line-height: 24px">Matched commits:</p>
+    <ul style="font-size: 14px; line-height: 24px">
+      {{ range .Commits }}
+      <li>{{.Repository}}@<a href="{{.URL}}" {{ if $.IsTest }}style="color: #9C9FA6; font-weight: 400; text-decoration: underline; cursor: default"{{ end }}>{{.AbbreviatedOID}}</a></li>
+      {{ end }}
+      {{ if .MoreCommits }}
+      <li>and {{.MoreCommits}} more</li>
+      {{ end }}
+    </ul>
+    {{ end }}
 	<p style="font-size: 16px; line-height: 24px">
 	  <a href="{{.SearchURL}}" {{ if .IsTest }}style="color: #9C9FA6; font-weight: 400; text-decoration: underline; cursor: default"{{ end }}>
         View search on Sourcegraph
@@ -0,0 +1,61 @@
+package codemonitors
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+)
+
+const lastSearchedFmtStr = `
+SELECT repo_id, commit_oid
+FROM cm_last_searched
+WHERE query_id = %s
+`
+
+// LastSearched returns the last commit searched by the given trigger query per repository.
+func (s *Store) LastSearched(ctx context.Context, queryID int64) (_ map[api.RepoID]string, err error) {
+	rows, err := s.Query(ctx, sqlf.Sprintf(lastSearchedFmtStr, queryID))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	lastSearched := make(map[api.RepoID]string)
+	for rows.Next() {
+		var (
+			repoID    api.RepoID
+			commitOID string
+		)
+		if err := rows.Scan(&repoID, &commitOID); err != nil {
+			return nil, err
+		}
+		lastSearched[repoID] = commitOID
+	}
+	return lastSearched, nil
+}
+
+const setLastSearchedFmtStr = `
+INSERT INTO cm_last_searched (query_id, repo_id, commit_oid)
+VALUES (%s, %s, %s)
+ON CONFLICT (query_id, repo_id) DO UPDATE SET commit_oid = EXCLUDED.commit_oid
+`
+
+// SetLastSearched records the last commit searched by the given trigger query in the given
+// repository.
+func (s *Store) SetLastSearched(ctx context.Context, queryID int64, repoID api.RepoID, commitOID string) error {
+	return s.Exec(ctx, sqlf.Sprintf(setLastSearchedFmtStr, queryID, repoID, commitOID))
+}
+
+const resetLastSearchedFmtStr = `
+DELETE FROM cm_last_searched
+WHERE query_id = %s
+`
+
+// ResetLastSearched forgets the last commits searched by the given trigger query, for example
+// because the query changed.
+func (s *Store) ResetLastSearched(ctx context.Context, queryID int64) error {
+	return s.Exec(ctx, sqlf.Sprintf(resetLastSearchedFmtStr, queryID))
+}
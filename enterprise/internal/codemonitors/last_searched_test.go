@@ -0,0 +1,54 @@
+package codemonitors
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestLastSearched(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx, s := newTestStore(t)
+	_, _, _, userCTX := newTestUser(ctx, t)
+	_, err := s.insertTestMonitor(userCTX, t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var repoID api.RepoID
+	if err := s.QueryRow(ctx, sqlf.Sprintf("INSERT INTO repo (name) VALUES (%s) RETURNING id", "github.com/sourcegraph/sourcegraph")).Scan(&repoID); err != nil {
+		t.Fatal(err)
+	}
+
+	// The query of the test monitor has ID 1.
+	const queryID = 1
+	for _, commitOID := range []string{"deadbeef", "cafebabe"} {
+		if err := s.SetLastSearched(ctx, queryID, repoID, commitOID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := s.LastSearched(ctx, queryID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[api.RepoID]string{repoID: "cafebabe"}, got); diff != "" {
+		t.Fatalf("unexpected last searched commits (-want +got):\n%s", diff)
+	}
+
+	// Resetting the trigger query forgets the commits searched so far.
+	if err := s.ResetTriggerQueryTimestamps(ctx, queryID); err != nil {
+		t.Fatal(err)
+	}
+	got, err = s.LastSearched(ctx, queryID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("expected no last searched commits, got %v", got)
+	}
+}
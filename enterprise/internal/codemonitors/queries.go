@@ -3,6 +3,8 @@ package codemonitors
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -25,6 +27,19 @@ type MonitorQuery struct {
 	ChangedAt    time.Time
 }
 
+// QueryWithAfterFilter constructs a new query which finds the search results of the given trigger
+// query introduced after the given time.
+func QueryWithAfterFilter(queryString string, after time.Time) string {
+	// ATTENTION: This is a stop gap. Add(time.Second) is necessary because currently
+	// the after: filter is implemented as "at OR after". If we didn't add a second
+	// here, we would send out emails for every run, always showing at least the last
+	// result. This means there is non-zero chance that we miss results whenever
+	// commits have a timestamp equal to the value of :after but arrive after this
+	// job has run.
+	afterTime := after.UTC().Add(time.Second).Format(time.RFC3339)
+	return strings.Join([]string{queryString, fmt.Sprintf(`after:"%s"`, afterTime)}, " ")
+}
+
 var queryColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_queries.id"),
 	sqlf.Sprintf("cm_queries.monitor"),
@@ -45,13 +60,23 @@ func (s *Store) CreateTriggerQuery(ctx context.Context, monitorID int64, args *g
 	return s.Exec(ctx, q)
 }
 
+// UpdateTriggerQuery updates the query of a trigger. The commits searched so far are forgotten,
+// since they were searched with the previous query.
 func (s *Store) UpdateTriggerQuery(ctx context.Context, args *graphqlbackend.UpdateCodeMonitorArgs) (err error) {
 	var q *sqlf.Query
 	q, err = s.updateTriggerQueryQuery(ctx, args)
 	if err != nil {
 		return err
 	}
-	return s.Exec(ctx, q)
+	if err = s.Exec(ctx, q); err != nil {
+		return err
+	}
+
+	var triggerID int64
+	if err = relay.UnmarshalSpec(args.Trigger.Id, &triggerID); err != nil {
+		return err
+	}
+	return s.ResetLastSearched(ctx, triggerID)
 }
 
 const triggerQueryByMonitorFmtStr = `
@@ -81,8 +106,13 @@ SET latest_result = null,
 WHERE id = %s;
 `
 
+// ResetTriggerQueryTimestamps resets the trigger query so that the next run searches all commits
+// again, as if the monitor was just created.
 func (s *Store) ResetTriggerQueryTimestamps(ctx context.Context, queryID int64) error {
-	return s.Exec(ctx, sqlf.Sprintf(resetTriggerQueryTimestamps, s.Now(), queryID))
+	if err := s.Exec(ctx, sqlf.Sprintf(resetTriggerQueryTimestamps, s.Now(), queryID)); err != nil {
+		return err
+	}
+	return s.ResetLastSearched(ctx, queryID)
 }
 
 const createTriggerQueryFmtStr = `
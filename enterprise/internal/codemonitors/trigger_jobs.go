@@ -122,7 +122,8 @@ type TriggerJobs struct {
 	Id    int
 	Query int64
 
-	// The query we ran including after: filter.
+	// The queries we ran, one per line, including their after: filter or
+	// range of commits.
 	QueryString *string
 
 	// Whether we got any results.
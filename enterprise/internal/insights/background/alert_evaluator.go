@@ -0,0 +1,230 @@
+package background
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// newAlertEvaluator returns a background goroutine which will periodically evaluate the alerts of
+// insight series that recorded new data points since the alert was last evaluated, and notify the
+// user of an alert by email and webhook when it triggers.
+func newAlertEvaluator(ctx context.Context, insightsStore store.Interface, alertStore store.SeriesAlertStore, observationContext *observation.Context) goroutine.BackgroundRoutine {
+	metrics := metrics.NewOperationMetrics(
+		observationContext.Registerer,
+		"insights_alert_evaluator",
+		metrics.WithCountHelp("Total number of insights alert evaluator executions"),
+	)
+	operation := observationContext.Operation(observation.Op{
+		Name:    "AlertEvaluator.Run",
+		Metrics: metrics,
+	})
+
+	evaluator := &alertEvaluator{
+		now:           time.Now,
+		insightsStore: insightsStore,
+		alertStore:    alertStore,
+		sendEmail:     sendAlertEmail,
+		sendWebhook:   sendAlertWebhook,
+	}
+	return goroutine.NewPeriodicGoroutineWithMetrics(ctx, 1*time.Minute, goroutine.NewHandlerWithErrorMessage(
+		"insights_alert_evaluator",
+		evaluator.Handler,
+	), operation)
+}
+
+// alertEvaluator evaluates insight series alerts. An alert is evaluated once after every recording
+// of its series (i.e. whenever RecordSeriesPoint recorded new points for the series since the last
+// evaluation), and notifies its user only when its condition starts to hold - not on every
+// evaluation while it holds - so that users are not notified about the same crossing twice.
+type alertEvaluator struct {
+	// Required fields used for mocking in tests.
+	now           func() time.Time
+	insightsStore store.Interface
+	alertStore    store.SeriesAlertStore
+	sendEmail     func(ctx context.Context, userID int32, data *alertEmailData) error
+	sendWebhook   func(ctx context.Context, url string, payload *alertWebhookPayload) error
+}
+
+func (e *alertEvaluator) Handler(ctx context.Context) error {
+	alerts, err := e.alertStore.ListAlerts(ctx, store.ListAlertsArgs{})
+	if err != nil {
+		return errors.Wrap(err, "ListAlerts")
+	}
+
+	var multi error
+	for _, alert := range alerts {
+		if err := e.evaluate(ctx, alert); err != nil {
+			multi = multierror.Append(multi, errors.Wrapf(err, "evaluating alert %d", alert.ID))
+		}
+	}
+	return multi
+}
+
+func (e *alertEvaluator) evaluate(ctx context.Context, alert types.InsightSeriesAlert) error {
+	now := e.now()
+
+	// Only evaluate the alert if new data points were recorded since the last evaluation.
+	since := alert.CreatedAt
+	if alert.LastEvaluatedAt != nil {
+		since = *alert.LastEvaluatedAt
+	}
+	seriesID := alert.SeriesID
+	newPoints, err := e.insightsStore.CountData(ctx, store.CountDataOpts{
+		SeriesID: &seriesID,
+		From:     &since,
+	})
+	if err != nil {
+		return errors.Wrap(err, "CountData")
+	}
+	if newPoints == 0 {
+		return nil
+	}
+
+	// 🚨 SECURITY: The values are sent to the user of the alert, so they must only include the
+	// repositories that user has access to.
+	userCtx := actor.WithActor(ctx, actor.FromUser(alert.UserID))
+	value, err := e.seriesValue(userCtx, alert, now)
+	if err != nil {
+		return err
+	}
+	var previousValue *float64
+	if alert.Condition.Percentage() {
+		previous, err := e.seriesValue(userCtx, alert, now.AddDate(0, 0, -alert.WindowDays))
+		if err != nil {
+			return err
+		}
+		previousValue = &previous
+	}
+
+	triggered := alertTriggered(alert, value, previousValue)
+	if triggered && !alert.Triggered {
+		return e.notify(ctx, alert, value, previousValue, now)
+	}
+	return e.alertStore.UpdateAlertEvaluation(ctx, alert.ID, now, triggered)
+}
+
+// seriesValue returns the value of the series of the given alert at the given time, i.e. the sum
+// of the most recent data point of every repository at that time.
+func (e *alertEvaluator) seriesValue(ctx context.Context, alert types.InsightSeriesAlert, at time.Time) (float64, error) {
+	points, err := e.insightsStore.RepoSeriesPoints(ctx, store.RepoSeriesPointsOpts{
+		SeriesID: alert.SeriesID,
+		Time:     at,
+		Capture:  alert.Capture,
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "RepoSeriesPoints")
+	}
+	var value float64
+	for _, point := range points {
+		value += point.Value
+	}
+	return value, nil
+}
+
+// notify records that the given alert triggered and sends its notifications. The alert is recorded
+// as triggered before its notifications are sent, so that an evaluation which fails afterwards or
+// runs concurrently does not notify the user twice. Failing to deliver a notification does not
+// fail the evaluation, the error is recorded in the alert history instead.
+func (e *alertEvaluator) notify(ctx context.Context, alert types.InsightSeriesAlert, value float64, previousValue *float64, now time.Time) error {
+	event, ok, err := e.alertStore.TriggerAlert(ctx, types.InsightSeriesAlertEvent{
+		AlertID:       alert.ID,
+		Value:         value,
+		PreviousValue: previousValue,
+		TriggeredAt:   now,
+	}, now)
+	if err != nil {
+		return errors.Wrap(err, "TriggerAlert")
+	}
+	if !ok {
+		// The alert was already triggered by another evaluation, which sent the notifications.
+		return nil
+	}
+
+	description := alertDescription(alert, value, previousValue)
+
+	if alert.EmailEnabled {
+		if err := e.sendEmail(ctx, alert.UserID, &alertEmailData{
+			SeriesID:    alert.SeriesID,
+			Description: description,
+		}); err != nil {
+			msg := err.Error()
+			event.EmailError = &msg
+		}
+	}
+	if alert.WebhookURL != nil {
+		if err := e.sendWebhook(ctx, *alert.WebhookURL, &alertWebhookPayload{
+			AlertID:       alert.ID,
+			SeriesID:      alert.SeriesID,
+			Capture:       alert.Capture,
+			Condition:     string(alert.Condition),
+			Threshold:     alert.Threshold,
+			Value:         value,
+			PreviousValue: previousValue,
+			TriggeredAt:   now,
+			Description:   description,
+		}); err != nil {
+			msg := err.Error()
+			event.WebhookError = &msg
+		}
+	}
+
+	if event.EmailError == nil && event.WebhookError == nil {
+		return nil
+	}
+	if err := e.alertStore.UpdateAlertEventErrors(ctx, event.ID, event.EmailError, event.WebhookError); err != nil {
+		return errors.Wrap(err, "UpdateAlertEventErrors")
+	}
+	return nil
+}
+
+// alertTriggered returns whether the condition of the given alert holds for the given value. For
+// percentage conditions, previousValue is the value WindowDays earlier; the condition never holds
+// if it is missing or zero, since the change can't be expressed as a percentage.
+func alertTriggered(alert types.InsightSeriesAlert, value float64, previousValue *float64) bool {
+	switch alert.Condition {
+	case types.AlertAbove:
+		return value > alert.Threshold
+	case types.AlertBelow:
+		return value < alert.Threshold
+	case types.AlertIncreasePercent, types.AlertDecreasePercent:
+		if previousValue == nil || *previousValue <= 0 {
+			return false
+		}
+		change := (value - *previousValue) / *previousValue * 100
+		if alert.Condition == types.AlertDecreasePercent {
+			change = -change
+		}
+		return change >= alert.Threshold
+	}
+	return false
+}
+
+// alertDescription returns a human readable description of why the given alert triggered.
+func alertDescription(alert types.InsightSeriesAlert, value float64, previousValue *float64) string {
+	subject := "The insight series value"
+	if alert.Capture != nil {
+		subject = fmt.Sprintf("The insight series value for %q", *alert.Capture)
+	}
+	switch alert.Condition {
+	case types.AlertAbove:
+		return fmt.Sprintf("%s is %v, above the threshold of %v.", subject, value, alert.Threshold)
+	case types.AlertBelow:
+		return fmt.Sprintf("%s is %v, below the threshold of %v.", subject, value, alert.Threshold)
+	case types.AlertIncreasePercent:
+		return fmt.Sprintf("%s increased from %v to %v in %d days, by at least %v%%.", subject, *previousValue, value, alert.WindowDays, alert.Threshold)
+	case types.AlertDecreasePercent:
+		return fmt.Sprintf("%s decreased from %v to %v in %d days, by at least %v%%.", subject, *previousValue, value, alert.WindowDays, alert.Threshold)
+	}
+	return fmt.Sprintf("%s is %v.", subject, value)
+}
@@ -0,0 +1,198 @@
+package background
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+)
+
+func TestAlertTriggered(t *testing.T) {
+	float := func(v float64) *float64 { return &v }
+
+	for name, tc := range map[string]struct {
+		condition types.AlertCondition
+		threshold float64
+		value     float64
+		previous  *float64
+		want      bool
+	}{
+		"above":                       {condition: types.AlertAbove, threshold: 50, value: 51, want: true},
+		"not above":                   {condition: types.AlertAbove, threshold: 50, value: 50},
+		"below":                       {condition: types.AlertBelow, threshold: 50, value: 49, want: true},
+		"not below":                   {condition: types.AlertBelow, threshold: 50, value: 50},
+		"increased":                   {condition: types.AlertIncreasePercent, threshold: 10, value: 110, previous: float(100), want: true},
+		"not increased enough":        {condition: types.AlertIncreasePercent, threshold: 10, value: 109, previous: float(100)},
+		"increased from zero":         {condition: types.AlertIncreasePercent, threshold: 10, value: 5, previous: float(0)},
+		"decreased":                   {condition: types.AlertDecreasePercent, threshold: 10, value: 90, previous: float(100), want: true},
+		"increased but decrease rule": {condition: types.AlertDecreasePercent, threshold: 10, value: 120, previous: float(100)},
+		"no previous value":           {condition: types.AlertIncreasePercent, threshold: 10, value: 120},
+	} {
+		t.Run(name, func(t *testing.T) {
+			alert := types.InsightSeriesAlert{Condition: tc.condition, Threshold: tc.threshold, WindowDays: 7}
+			if have := alertTriggered(alert, tc.value, tc.previous); have != tc.want {
+				t.Fatalf("wrong result. want=%v, have=%v", tc.want, have)
+			}
+		})
+	}
+}
+
+func TestAlertEvaluator(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	webhookURL := "https://example.com/hook"
+
+	type sent struct {
+		emails   []*alertEmailData
+		webhooks []*alertWebhookPayload
+	}
+
+	setup := func(alert types.InsightSeriesAlert, newPoints int, values map[time.Time]float64, emailErr error) (*alertEvaluator, *store.MockSeriesAlertStore, *sent) {
+		insightsStore := store.NewMockInterface()
+		insightsStore.CountDataFunc.SetDefaultReturn(newPoints, nil)
+		insightsStore.RepoSeriesPointsFunc.SetDefaultHook(func(ctx context.Context, opts store.RepoSeriesPointsOpts) ([]store.RepoSeriesPoint, error) {
+			// Split the value across two repositories to check that they are summed.
+			value := values[opts.Time]
+			return []store.RepoSeriesPoint{{RepoID: 1, Value: value / 2}, {RepoID: 2, Value: value / 2}}, nil
+		})
+
+		alertStore := store.NewMockSeriesAlertStore()
+		alertStore.ListAlertsFunc.SetDefaultReturn([]types.InsightSeriesAlert{alert}, nil)
+		alertStore.TriggerAlertFunc.SetDefaultHook(func(ctx context.Context, event types.InsightSeriesAlertEvent, evaluatedAt time.Time) (types.InsightSeriesAlertEvent, bool, error) {
+			event.ID = 5
+			return event, true, nil
+		})
+
+		s := &sent{}
+		return &alertEvaluator{
+			now:           func() time.Time { return now },
+			insightsStore: insightsStore,
+			alertStore:    alertStore,
+			sendEmail: func(ctx context.Context, userID int32, data *alertEmailData) error {
+				s.emails = append(s.emails, data)
+				return emailErr
+			},
+			sendWebhook: func(ctx context.Context, url string, payload *alertWebhookPayload) error {
+				s.webhooks = append(s.webhooks, payload)
+				return nil
+			},
+		}, alertStore, s
+	}
+
+	alert := types.InsightSeriesAlert{
+		ID:           1,
+		SeriesID:     "series1",
+		Condition:    types.AlertAbove,
+		Threshold:    50,
+		WindowDays:   7,
+		UserID:       2,
+		EmailEnabled: true,
+		WebhookURL:   &webhookURL,
+		CreatedAt:    now.Add(-time.Hour),
+	}
+
+	t.Run("no new points", func(t *testing.T) {
+		evaluator, alertStore, sent := setup(alert, 0, map[time.Time]float64{now: 60}, nil)
+		if err := evaluator.Handler(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if len(sent.emails) != 0 || len(alertStore.UpdateAlertEvaluationFunc.History()) != 0 {
+			t.Fatal("expected alert not to be evaluated")
+		}
+	})
+
+	t.Run("triggers", func(t *testing.T) {
+		evaluator, alertStore, sent := setup(alert, 3, map[time.Time]float64{now: 60}, nil)
+		if err := evaluator.Handler(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		wantEmails := []*alertEmailData{{SeriesID: "series1", Description: "The insight series value is 60, above the threshold of 50."}}
+		if diff := cmp.Diff(wantEmails, sent.emails); diff != "" {
+			t.Fatalf("unexpected emails (-want +have):\n%s", diff)
+		}
+		wantWebhooks := []*alertWebhookPayload{{
+			AlertID:     1,
+			SeriesID:    "series1",
+			Condition:   "above",
+			Threshold:   50,
+			Value:       60,
+			TriggeredAt: now,
+			Description: "The insight series value is 60, above the threshold of 50.",
+		}}
+		if diff := cmp.Diff(wantWebhooks, sent.webhooks); diff != "" {
+			t.Fatalf("unexpected webhooks (-want +have):\n%s", diff)
+		}
+
+		triggers := alertStore.TriggerAlertFunc.History()
+		if len(triggers) != 1 {
+			t.Fatalf("expected alert to be triggered once, got %d", len(triggers))
+		}
+		if diff := cmp.Diff(types.InsightSeriesAlertEvent{AlertID: 1, Value: 60, TriggeredAt: now}, triggers[0].Arg1); diff != "" {
+			t.Fatalf("unexpected alert event (-want +have):\n%s", diff)
+		}
+		if updates := alertStore.UpdateAlertEvaluationFunc.History(); len(updates) != 0 {
+			t.Fatalf("expected the evaluation to be recorded with the trigger, got %+v", updates)
+		}
+		if updates := alertStore.UpdateAlertEventErrorsFunc.History(); len(updates) != 0 {
+			t.Fatalf("expected no delivery errors to be recorded, got %+v", updates)
+		}
+	})
+
+	t.Run("triggered concurrently", func(t *testing.T) {
+		evaluator, alertStore, sent := setup(alert, 3, map[time.Time]float64{now: 60}, nil)
+		alertStore.TriggerAlertFunc.SetDefaultReturn(types.InsightSeriesAlertEvent{}, false, nil)
+		if err := evaluator.Handler(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if len(sent.emails) != 0 || len(sent.webhooks) != 0 {
+			t.Fatal("expected no notifications for an alert triggered by another evaluation")
+		}
+	})
+
+	t.Run("already triggered", func(t *testing.T) {
+		triggered := alert
+		triggered.Triggered = true
+		evaluator, alertStore, sent := setup(triggered, 3, map[time.Time]float64{now: 60}, nil)
+		if err := evaluator.Handler(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if len(sent.emails) != 0 || len(sent.webhooks) != 0 || len(alertStore.TriggerAlertFunc.History()) != 0 {
+			t.Fatal("expected no notifications for an alert that already triggered")
+		}
+		if updates := alertStore.UpdateAlertEvaluationFunc.History(); len(updates) != 1 || !updates[0].Arg3 {
+			t.Fatalf("expected alert to stay triggered, got %+v", updates)
+		}
+	})
+
+	t.Run("percentage change", func(t *testing.T) {
+		increase := alert
+		increase.Condition = types.AlertIncreasePercent
+		increase.Threshold = 10
+		increase.WebhookURL = nil
+		evaluator, _, sent := setup(increase, 1, map[time.Time]float64{now: 120, now.AddDate(0, 0, -7): 100}, nil)
+		if err := evaluator.Handler(ctx); err != nil {
+			t.Fatal(err)
+		}
+		wantEmails := []*alertEmailData{{SeriesID: "series1", Description: "The insight series value increased from 100 to 120 in 7 days, by at least 10%."}}
+		if diff := cmp.Diff(wantEmails, sent.emails); diff != "" {
+			t.Fatalf("unexpected emails (-want +have):\n%s", diff)
+		}
+	})
+
+	t.Run("email error is recorded", func(t *testing.T) {
+		evaluator, alertStore, _ := setup(alert, 1, map[time.Time]float64{now: 60}, errors.New("no email"))
+		if err := evaluator.Handler(ctx); err != nil {
+			t.Fatal(err)
+		}
+		updates := alertStore.UpdateAlertEventErrorsFunc.History()
+		if len(updates) != 1 || updates[0].Arg1 != 5 || updates[0].Arg2 == nil || *updates[0].Arg2 != "no email" || updates[0].Arg3 != nil {
+			t.Fatalf("expected email error to be recorded, got %+v", updates)
+		}
+	})
+}
@@ -0,0 +1,143 @@
+package background
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+)
+
+// alertEmailData is the data of the email sent when an insight series alert triggers.
+type alertEmailData struct {
+	SeriesID    string
+	Description string
+	InsightsURL string
+}
+
+var alertEmailTemplates = txemail.MustValidate(txtypes.Templates{
+	Subject: `[Code insights alert] {{.Description}}`,
+	Text: `
+A code insights alert triggered:
+
+{{.Description}}
+
+View code insights on Sourcegraph: {{.InsightsURL}}
+
+__
+You are receiving this notification because you created an alert on a code insights series ({{.SeriesID}}).
+`,
+	HTML: `
+<!DOCTYPE html>
+<html>
+  <body>
+    <p style="font-size: 16px; line-height: 24px">
+      A code insights alert triggered:
+    </p>
+    <p style="font-size: 20px; line-height: 30px; font-weight: 700">
+      {{.Description}}
+    </p>
+    <p style="font-size: 16px; line-height: 24px">
+      <a href="{{.InsightsURL}}">View code insights on Sourcegraph</a>
+    </p>
+    <br />
+    __
+    <p style="font-size: 14px; line-height: 24px">
+      You are receiving this notification because you created an alert on a code insights series
+      ({{.SeriesID}}).
+    </p>
+  </body>
+</html>
+`,
+})
+
+// sendAlertEmail sends the email of a triggered alert to the primary email address of the given
+// user.
+func sendAlertEmail(ctx context.Context, userID int32, data *alertEmailData) error {
+	email, err := api.InternalClient.UserEmailsGetEmail(ctx, userID)
+	if err != nil {
+		return errors.Wrapf(err, "InternalClient.UserEmailsGetEmail for userID=%d", userID)
+	}
+	if email == nil {
+		return errors.Errorf("unable to send email to user ID %d with unknown email address", userID)
+	}
+
+	externalURL, err := api.InternalClient.ExternalURL(ctx)
+	if err != nil {
+		return errors.Wrap(err, "InternalClient.ExternalURL")
+	}
+	u, err := url.Parse(externalURL)
+	if err != nil {
+		return errors.Wrap(err, "parsing external URL")
+	}
+	data.InsightsURL = u.ResolveReference(&url.URL{Path: "insights"}).String()
+
+	if err := api.InternalClient.SendEmail(ctx, txtypes.Message{
+		To:       []string{*email},
+		Template: alertEmailTemplates,
+		Data:     data,
+	}); err != nil {
+		return errors.Wrapf(err, "InternalClient.SendEmail to email=%q userID=%d", *email, userID)
+	}
+	return nil
+}
+
+// alertWebhookPayload is the JSON payload POSTed to the webhook URL of a triggered alert.
+type alertWebhookPayload struct {
+	AlertID       int       `json:"alertID"`
+	SeriesID      string    `json:"seriesID"`
+	Capture       *string   `json:"capture"`
+	Condition     string    `json:"condition"`
+	Threshold     float64   `json:"threshold"`
+	Value         float64   `json:"value"`
+	PreviousValue *float64  `json:"previousValue"`
+	TriggeredAt   time.Time `json:"triggeredAt"`
+	Description   string    `json:"description"`
+}
+
+// alertWebhookDoer sends the requests to the webhook URLs of alerts. Alerts can be created by
+// any user, so it refuses to connect to internal addresses.
+var alertWebhookDoer, _ = httpcli.NewFactory(
+	httpcli.NewMiddleware(
+		httpcli.ContextErrorMiddleware,
+	),
+	// NewPublicAddressesOnlyOpt needs to be before ExternalTransportOpt since it wants to
+	// extract a http.Transport, not a generic http.RoundTripper.
+	httpcli.NewPublicAddressesOnlyOpt(),
+	httpcli.ExternalTransportOpt,
+	httpcli.TracedTransportOpt,
+).Doer()
+
+// sendAlertWebhook POSTs the given payload to the given webhook URL.
+func sendAlertWebhook(ctx context.Context, webhookURL string, payload *alertWebhookPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "building webhook request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := alertWebhookDoer.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "sending webhook request")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
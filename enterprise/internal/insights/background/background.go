@@ -51,7 +51,7 @@ func GetBackgroundJobs(ctx context.Context, mainAppDB *sql.DB, insightsDB *sql.D
 
 		// Register the query-runner worker and resetter, which executes search queries and records
 		// results to TimescaleDB.
-		queryrunner.NewWorker(ctx, workerBaseStore, insightsStore, queryRunnerWorkerMetrics),
+		queryrunner.NewWorker(ctx, workerBaseStore, insightsStore, insightsMetadataStore, queryRunnerWorkerMetrics),
 		queryrunner.NewResetter(ctx, workerBaseStore, queryRunnerResetterMetrics),
 		// disabling the cleaner job while we debug mismatched results from historical insights
 		// queryrunner.NewCleaner(ctx, workerBaseStore, observationContext),
@@ -71,6 +71,10 @@ func GetBackgroundJobs(ctx context.Context, mainAppDB *sql.DB, insightsDB *sql.D
 
 	routines = append(routines, discovery.NewMigrateSettingInsightsJob(ctx, mainAppDB, insightsDB))
 
+	// Register the background goroutine which evaluates alerts on insight series after new data
+	// points were recorded.
+	routines = append(routines, newAlertEvaluator(ctx, insightsStore, store.NewAlertStore(insightsDB), observationContext))
+
 	return routines
 }
 
@@ -126,6 +126,14 @@ func newInsightHistoricalEnqueuer(ctx context.Context, workerBaseStore *basestor
 			_, err := queryrunner.EnqueueJob(ctx, workerBaseStore, job)
 			return err
 		},
+		pauseQueryRunnerJobs: func(ctx context.Context, seriesID string) error {
+			_, err := queryrunner.PauseBackfillJobs(ctx, workerBaseStore, seriesID)
+			return err
+		},
+		cancelQueryRunnerJobs: func(ctx context.Context, seriesID string) error {
+			_, err := queryrunner.CancelBackfillJobs(ctx, workerBaseStore, seriesID)
+			return err
+		},
 		gitFirstEverCommit: (&cachedGitFirstEverCommit{impl: git.FirstEverCommit}).gitFirstEverCommit,
 		gitFindRecentCommit: func(ctx context.Context, repoName api.RepoName, target time.Time) ([]*git.Commit, error) {
 			return git.Commits(ctx, repoName, git.CommitsOptions{N: 1, Before: target.Format(time.RFC3339), DateOrder: true})
@@ -181,13 +189,15 @@ type RepoStore interface {
 }
 
 // historicalEnqueuer effectively enqueues jobs that generate historical data for insights. Right
-// now, it only supports search insights. It does this by adjusting the user's search query to be
-// for a specific repo and commit like `repo:<repo>@<commit>`, where `<repo>` is every repository
-// on Sourcegraph (one search per) and `<commit>` is a Git commit closest in time to the historical
-// point in time we're trying to generate data for. A lot of effort is placed into doing the work
-// slowly, linearly, and consistently over time without harming any other part of Sourcegraph
-// (including the search API, by performing searches slowly and on single repositories at a time
-// only.)
+// now, it supports search insights and language statistics insights. It does this by adjusting the
+// user's search query to be for a specific repo and commit like `repo:<repo>@<commit>`, where
+// `<repo>` is every repository on Sourcegraph (one search per) and `<commit>` is a Git commit
+// closest in time to the historical point in time we're trying to generate data for. A lot of
+// effort is placed into doing the work slowly, linearly, and consistently over time without harming
+// any other part of Sourcegraph (including the search API, by performing searches slowly and on
+// single repositories at a time only.) Language statistics insights are handled the same way, except
+// that only the repositories of the series are considered and the language statistics of the
+// commit are computed instead of running a search.
 //
 // It works roughly like this:
 //
@@ -220,6 +230,8 @@ type historicalEnqueuer struct {
 	dataSeriesStore       store.DataSeriesStore
 	repoStore             RepoStore
 	enqueueQueryRunnerJob func(ctx context.Context, job *queryrunner.Job) error
+	pauseQueryRunnerJobs  func(ctx context.Context, seriesID string) error
+	cancelQueryRunnerJobs func(ctx context.Context, seriesID string) error
 	gitFirstEverCommit    func(ctx context.Context, repoName api.RepoName) (*git.Commit, error)
 	gitFindRecentCommit   func(ctx context.Context, repoName api.RepoName, target time.Time) ([]*git.Commit, error)
 	frameFilter           compression.DataFrameFilter
@@ -256,13 +268,30 @@ func (h *historicalEnqueuer) Handler(ctx context.Context) error {
 		if _, exists := uniqueSeries[seriesID]; exists {
 			continue
 		}
+
+		// Series whose backfill is paused or canceled are not backfilled. Jobs enqueued for them
+		// by a previous run that was still in progress when the backfill was paused or canceled
+		// are paused or canceled as well.
+		switch series.BackfillState {
+		case itypes.BackfillPaused:
+			if err := h.pauseQueryRunnerJobs(ctx, seriesID); err != nil {
+				multi = multierror.Append(multi, errors.Wrapf(err, "pausing jobs of series %q", seriesID))
+			}
+			continue
+		case itypes.BackfillCanceled:
+			if err := h.cancelQueryRunnerJobs(ctx, seriesID); err != nil {
+				multi = multierror.Append(multi, errors.Wrapf(err, "canceling jobs of series %q", seriesID))
+			}
+			continue
+		}
+
 		uniqueSeries[seriesID] = series
 		sortedSeriesIDs = append(sortedSeriesIDs, seriesID)
 	}
 	if err := h.buildFrames(ctx, uniqueSeries, sortedSeriesIDs); err != nil {
 		return multierror.Append(multi, err)
 	}
-	return nil
+	return multi
 }
 
 // buildFrames is invoked to build historical data for all past timeframes that we care about
@@ -277,10 +306,65 @@ func (h *historicalEnqueuer) buildFrames(ctx context.Context, uniqueSeries map[s
 	}
 	var multi error
 
+	for _, seriesID := range sortedSeriesIDs {
+		if series := uniqueSeries[seriesID]; series.SeriesType == itypes.CommitSeries {
+			if err := h.buildCommitFrames(ctx, series); err != nil {
+				return err
+			}
+		}
+	}
+
 	hardErr := h.allReposIterator(ctx, h.buildForRepo(ctx, uniqueSeries, sortedSeriesIDs, multi))
 	return hardErr
 }
 
+// buildCommitFrames enqueues a job for every frame of the given commit series that has no data
+// yet. Commit series count the commits made during a frame instead of searching repositories at
+// a point in time, so a single search over all repositories is run per frame and recorded at the
+// end of the frame. The frames are not compressed, since the number of commits made during a
+// frame doesn't depend on whether the repositories changed during earlier frames.
+//
+// The frames are derived from the creation time of the series instead of the current time, so
+// that they are the same on every run and already recorded frames are found.
+func (h *historicalEnqueuer) buildCommitFrames(ctx context.Context, series itypes.InsightSeries) error {
+	duration := series.CreatedAt.Sub(series.OldestHistoricalAt) / time.Duration(h.framesToBackfill())
+	frames := Frames(h.framesToBackfill(), duration, series.CreatedAt)
+
+	for i := len(frames) - 1; i >= 0; i-- {
+		from, to := frames[i].From.Truncate(time.Second), frames[i].To.Truncate(time.Second)
+
+		err := h.limiter.Wait(ctx)
+		if err != nil {
+			return err
+		}
+
+		// If we already have data for this frame+series, then there's nothing to do.
+		numDataPoints, err := h.insightsStore.CountData(ctx, store.CountDataOpts{
+			From:     &to,
+			To:       &to,
+			SeriesID: &series.SeriesID,
+		})
+		if err == nil && numDataPoints > 0 {
+			continue
+		}
+		// Otherwise (or if we couldn't tell) we query for it. Recording the same frame twice is
+		// prevented by the query runner.
+
+		err = h.enqueueQueryRunnerJob(ctx, &queryrunner.Job{
+			SeriesID:    series.SeriesID,
+			SearchQuery: withCommitRange(withCountUnlimited(series.Query), from, to),
+			RecordTime:  &to,
+			State:       "queued",
+			Priority:    int(priority.FromTimeInterval(from, series.CreatedAt)),
+			Cost:        int(priority.Unindexed),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (h *historicalEnqueuer) buildForRepo(ctx context.Context, uniqueSeries map[string]itypes.InsightSeries, sortedSeriesIDs []string, softErr error) func(repoName string) error {
 	return func(repoName string) error {
 		// Lookup the repository (we need its database ID)
@@ -297,6 +381,12 @@ func (h *historicalEnqueuer) buildForRepo(ctx context.Context, uniqueSeries map[
 		// For every series that we want to potentially gather historical data for, try.
 		for _, seriesID := range sortedSeriesIDs {
 			series := uniqueSeries[seriesID]
+			if series.SeriesType == itypes.LanguageStatsSeries && !containsString(series.Repositories, repoName) {
+				continue // language statistics series only record data for their own repositories
+			}
+			if series.SeriesType == itypes.CommitSeries {
+				continue // commit series are backfilled for all repositories at once, see buildCommitFrames
+			}
 
 			duration := h.now().Sub(series.OldestHistoricalAt) / time.Duration(h.framesToBackfill())
 			frames := Frames(h.framesToBackfill(), duration, series.CreatedAt)
@@ -405,9 +495,10 @@ func Frames(numFrames int, frameLength time.Duration, current time.Time) []compr
 // and soft errors (e.g. user's search query is invalid, future series are likely to build.)
 func (h *historicalEnqueuer) buildSeries(ctx context.Context, bctx *buildSeriesContext) (hardErr, softErr error) {
 	query := bctx.series.Query
+	languageStats := bctx.series.SeriesType == itypes.LanguageStatsSeries
 	// TODO(slimsag): future: use the search query parser here to avoid any false-positives like a
 	// search query with `content:"repo:"`.
-	if strings.Contains(query, "repo:") {
+	if !languageStats && strings.Contains(query, "repo:") {
 		// We need to specify the repo: filter ourselves, so rewriting their query which already
 		// contains this would be complex (we would need to enumerate all repos their query would
 		// have matched the same way the search backend would've). We don't support this today.
@@ -422,6 +513,9 @@ func (h *historicalEnqueuer) buildSeries(ctx context.Context, bctx *buildSeriesC
 	// at that point in time.)
 	repoName := string(bctx.repo.Name)
 	if bctx.from.Before(bctx.firstHEADCommit.Author.Date) {
+		if languageStats {
+			return // there are no lines in any language to record
+		}
 		if err := h.insightsStore.RecordSeriesPoint(ctx, store.RecordSeriesPointArgs{
 			SeriesID: bctx.seriesID,
 			Point: store.SeriesPoint{
@@ -477,6 +571,21 @@ func (h *historicalEnqueuer) buildSeries(ctx context.Context, bctx *buildSeriesC
 	}
 	log15.Debug("nearest_commit", "repo_id", bctx.repo.ID, "series_id", bctx.series.SeriesID, "from", bctx.from, "to", bctx.to, "revhash", nearestCommit.ID.Short(), "time", nearestCommit.Committer.Date)
 
+	if languageStats {
+		// Compute the language statistics of the repository at the nearest commit.
+		revision := string(nearestCommit.ID)
+		hardErr = h.enqueueQueryRunnerJob(ctx, &queryrunner.Job{
+			SeriesID:       bctx.seriesID,
+			RepositoryName: &repoName,
+			Revision:       &revision,
+			RecordTime:     &nearestCommit.Committer.Date,
+			State:          "queued",
+			Priority:       int(priority.FromTimeInterval(bctx.from, bctx.series.CreatedAt)),
+			Cost:           int(priority.Unindexed),
+		})
+		return
+	}
+
 	// Build the search query we will run. The most important part here is
 	query = withCountUnlimited(query)
 	query = fmt.Sprintf("%s repo:^%s$@%s", query, regexp.QuoteMeta(repoName), string(nearestCommit.ID))
@@ -492,6 +601,15 @@ func (h *historicalEnqueuer) buildSeries(ctx context.Context, bctx *buildSeriesC
 	return
 }
 
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
 // cachedGitFirstEverCommit is a simple in-memory cache for gitFirstEverCommit calls. It does so
 // using a map, and entries are never evicted because they are expected to be small and in general
 // unchanging.
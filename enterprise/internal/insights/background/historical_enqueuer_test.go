@@ -28,6 +28,15 @@ type testParams struct {
 	frames                int
 	recordSleepOperations bool
 	haveData              bool
+
+	// languageStatsRepos, if non-nil, adds a language statistics series for these repositories.
+	languageStatsRepos []string
+
+	// commitAggregation, if non-empty, adds a commit series with this aggregation.
+	commitAggregation itypes.CommitAggregation
+
+	// backfillStates sets the backfill state of the series with the given series IDs.
+	backfillStates map[string]itypes.BackfillState
 }
 
 type testResults struct {
@@ -53,7 +62,7 @@ func testHistoricalEnqueuer(t *testing.T, p *testParams) *testResults {
 	}
 
 	dataSeriesStore := store.NewMockDataSeriesStore()
-	dataSeriesStore.GetDataSeriesFunc.SetDefaultReturn([]itypes.InsightSeries{
+	dataSeries := []itypes.InsightSeries{
 		{
 			ID:                    1,
 			SeriesID:              "series1",
@@ -72,7 +81,36 @@ func testHistoricalEnqueuer(t *testing.T, p *testParams) *testResults {
 			OldestHistoricalAt:    clock().Add(-time.Hour * 24 * 365),
 			RecordingIntervalDays: 1,
 		},
-	}, nil)
+	}
+	if p.languageStatsRepos != nil {
+		dataSeries = append(dataSeries, itypes.InsightSeries{
+			ID:                    3,
+			SeriesID:              "series3",
+			NextRecordingAfter:    clock().Add(-1 * time.Hour),
+			CreatedAt:             clock(),
+			OldestHistoricalAt:    clock().Add(-time.Hour * 24 * 365),
+			RecordingIntervalDays: 1,
+			SeriesType:            itypes.LanguageStatsSeries,
+			Repositories:          p.languageStatsRepos,
+		})
+	}
+	if p.commitAggregation != "" {
+		dataSeries = append(dataSeries, itypes.InsightSeries{
+			ID:                    4,
+			SeriesID:              "series4",
+			Query:                 "type:commit message:CVE",
+			NextRecordingAfter:    clock().Add(-1 * time.Hour),
+			CreatedAt:             clock(),
+			OldestHistoricalAt:    clock().Add(-time.Hour * 24 * 365),
+			RecordingIntervalDays: 1,
+			SeriesType:            itypes.CommitSeries,
+			CommitAggregation:     p.commitAggregation,
+		})
+	}
+	for i := range dataSeries {
+		dataSeries[i].BackfillState = p.backfillStates[dataSeries[i].SeriesID]
+	}
+	dataSeriesStore.GetDataSeriesFunc.SetDefaultReturn(dataSeries, nil)
 
 	dataFrameFilter := compression.NoopFilter{}
 
@@ -103,10 +141,23 @@ func testHistoricalEnqueuer(t *testing.T, p *testParams) *testResults {
 	})
 
 	enqueueQueryRunnerJob := func(ctx context.Context, job *queryrunner.Job) error {
+		if job.RepositoryName != nil {
+			r.operations = append(r.operations, fmt.Sprintf(`enqueueQueryRunnerJob("%s", repository="%s", revision="%s")`, job.RecordTime.Format(time.RFC3339), *job.RepositoryName, *job.Revision))
+			return nil
+		}
 		r.operations = append(r.operations, fmt.Sprintf(`enqueueQueryRunnerJob("%s", "%s")`, job.RecordTime.Format(time.RFC3339), job.SearchQuery))
 		return nil
 	}
 
+	pauseQueryRunnerJobs := func(ctx context.Context, seriesID string) error {
+		r.operations = append(r.operations, fmt.Sprintf(`pauseQueryRunnerJobs("%s")`, seriesID))
+		return nil
+	}
+	cancelQueryRunnerJobs := func(ctx context.Context, seriesID string) error {
+		r.operations = append(r.operations, fmt.Sprintf(`cancelQueryRunnerJobs("%s")`, seriesID))
+		return nil
+	}
+
 	allReposIterator := func(ctx context.Context, each func(repoName string) error) error {
 		r.allReposIteratorCalls++
 		for i := 0; i < p.numRepos; i++ {
@@ -138,6 +189,8 @@ func testHistoricalEnqueuer(t *testing.T, p *testParams) *testResults {
 		insightsStore:         insightsStore,
 		repoStore:             repoStore,
 		enqueueQueryRunnerJob: enqueueQueryRunnerJob,
+		pauseQueryRunnerJobs:  pauseQueryRunnerJobs,
+		cancelQueryRunnerJobs: cancelQueryRunnerJobs,
 		allReposIterator:      allReposIterator,
 		gitFirstEverCommit:    gitFirstEverCommit,
 		gitFindRecentCommit:   gitFindRecentCommit,
@@ -210,4 +263,83 @@ func Test_historicalEnqueuer(t *testing.T) {
 			recordSleepOperations: true,
 		}))
 	})
+	// Test that language statistics series only enqueue jobs for their own repositories, which
+	// compute the language statistics at the nearest commit instead of running a search.
+	t.Run("language_stats", func(t *testing.T) {
+		want := autogold.Want("language_stats", &testResults{
+			allReposIteratorCalls: 1, reposGetByName: 2,
+			operations: []string{
+				`enqueueQueryRunnerJob("2020-06-30T12:00:01Z", "query1 count:9999999 repo:^repo/0$@")`,
+				`enqueueQueryRunnerJob("2019-12-31T00:00:01Z", "query1 count:9999999 repo:^repo/0$@")`,
+				`enqueueQueryRunnerJob("2020-06-30T12:00:01Z", "query2 count:9999999 repo:^repo/0$@")`,
+				`enqueueQueryRunnerJob("2019-12-31T00:00:01Z", "query2 count:9999999 repo:^repo/0$@")`,
+				`enqueueQueryRunnerJob("2020-06-30T12:00:01Z", "query1 count:9999999 repo:^repo/1$@")`,
+				`enqueueQueryRunnerJob("2019-12-31T00:00:01Z", "query1 count:9999999 repo:^repo/1$@")`,
+				`enqueueQueryRunnerJob("2020-06-30T12:00:01Z", "query2 count:9999999 repo:^repo/1$@")`,
+				`enqueueQueryRunnerJob("2019-12-31T00:00:01Z", "query2 count:9999999 repo:^repo/1$@")`,
+				`enqueueQueryRunnerJob("2020-06-30T12:00:01Z", repository="repo/1", revision="")`,
+				`enqueueQueryRunnerJob("2019-12-31T00:00:01Z", repository="repo/1", revision="")`,
+			},
+		})
+		want.Equal(t, testHistoricalEnqueuer(t, &testParams{
+			settings:              testRealGlobalSettings,
+			numRepos:              2,
+			frames:                2,
+			recordSleepOperations: true,
+			languageStatsRepos:    []string{"repo/1"},
+		}))
+	})
+	// Test that commit series search the history of all repositories at once for the commits made
+	// during each frame, recorded at the end of the frame, instead of searching each repository at
+	// the nearest commit.
+	t.Run("commits", func(t *testing.T) {
+		want := autogold.Want("commits", &testResults{
+			allReposIteratorCalls: 1, reposGetByName: 2,
+			operations: []string{
+				`enqueueQueryRunnerJob("2021-01-01T00:00:01Z", "type:commit message:CVE count:9999999 after:2020-07-02T12:00:01Z before:2021-01-01T00:00:01Z")`,
+				`enqueueQueryRunnerJob("2020-07-02T12:00:01Z", "type:commit message:CVE count:9999999 after:2020-01-02T00:00:01Z before:2020-07-02T12:00:01Z")`,
+				`enqueueQueryRunnerJob("2020-06-30T12:00:01Z", "query1 count:9999999 repo:^repo/0$@")`,
+				`enqueueQueryRunnerJob("2019-12-31T00:00:01Z", "query1 count:9999999 repo:^repo/0$@")`,
+				`enqueueQueryRunnerJob("2020-06-30T12:00:01Z", "query2 count:9999999 repo:^repo/0$@")`,
+				`enqueueQueryRunnerJob("2019-12-31T00:00:01Z", "query2 count:9999999 repo:^repo/0$@")`,
+				`enqueueQueryRunnerJob("2020-06-30T12:00:01Z", "query1 count:9999999 repo:^repo/1$@")`,
+				`enqueueQueryRunnerJob("2019-12-31T00:00:01Z", "query1 count:9999999 repo:^repo/1$@")`,
+				`enqueueQueryRunnerJob("2020-06-30T12:00:01Z", "query2 count:9999999 repo:^repo/1$@")`,
+				`enqueueQueryRunnerJob("2019-12-31T00:00:01Z", "query2 count:9999999 repo:^repo/1$@")`,
+			},
+		})
+		want.Equal(t, testHistoricalEnqueuer(t, &testParams{
+			settings:              testRealGlobalSettings,
+			numRepos:              2,
+			frames:                2,
+			recordSleepOperations: true,
+			commitAggregation:     itypes.DistinctAuthors,
+		}))
+	})
+	// Test that series whose backfill is paused or canceled are not backfilled, and that their
+	// remaining jobs are paused or canceled.
+	t.Run("paused_and_canceled", func(t *testing.T) {
+		want := autogold.Want("paused_and_canceled", &testResults{
+			allReposIteratorCalls: 1, reposGetByName: 2,
+			operations: []string{
+				`pauseQueryRunnerJobs("series2")`,
+				`cancelQueryRunnerJobs("series3")`,
+				`enqueueQueryRunnerJob("2020-06-30T12:00:01Z", "query1 count:9999999 repo:^repo/0$@")`,
+				`enqueueQueryRunnerJob("2019-12-31T00:00:01Z", "query1 count:9999999 repo:^repo/0$@")`,
+				`enqueueQueryRunnerJob("2020-06-30T12:00:01Z", "query1 count:9999999 repo:^repo/1$@")`,
+				`enqueueQueryRunnerJob("2019-12-31T00:00:01Z", "query1 count:9999999 repo:^repo/1$@")`,
+			},
+		})
+		want.Equal(t, testHistoricalEnqueuer(t, &testParams{
+			settings:              testRealGlobalSettings,
+			numRepos:              2,
+			frames:                2,
+			recordSleepOperations: true,
+			languageStatsRepos:    []string{"repo/1"},
+			backfillStates: map[string]itypes.BackfillState{
+				"series2": itypes.BackfillPaused,
+				"series3": itypes.BackfillCanceled,
+			},
+		}))
+	})
 }
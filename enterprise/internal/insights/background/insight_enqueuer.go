@@ -2,6 +2,7 @@ package background
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"time"
 
@@ -88,16 +89,11 @@ func discoverAndEnqueueInsights(
 		// don't execute all queries at once and harm search performance in general.
 		processAfter := now().Add(offset)
 		offset += queryJobOffsetTime
-		err = enqueueQueryRunnerJob(ctx, &queryrunner.Job{
-			SeriesID:     seriesID,
-			SearchQuery:  withCountUnlimited(series.Query),
-			ProcessAfter: &processAfter,
-			State:        "queued",
-			Priority:     int(priority.High),
-			Cost:         int(priority.Indexed),
-		})
-		if err != nil {
-			multi = multierror.Append(multi, err)
+		for _, job := range recordingJobs(series, processAfter) {
+			err = enqueueQueryRunnerJob(ctx, job)
+			if err != nil {
+				multi = multierror.Append(multi, err)
+			}
 		}
 
 		// The recording timestamp update can't be transactional because this is a separate database currently, so we will use
@@ -112,6 +108,48 @@ func discoverAndEnqueueInsights(
 	return multi
 }
 
+// recordingJobs returns the query runner jobs that record the current data of the given series.
+// Search series run their search query once, commit series run their query once for the commits
+// of the last recording interval, while language statistics series compute the language
+// statistics of every repository of the series at the head of its default branch.
+func recordingJobs(series types.InsightSeries, processAfter time.Time) []*queryrunner.Job {
+	if series.SeriesType != types.LanguageStatsSeries {
+		query := withCountUnlimited(series.Query)
+		var recordTime *time.Time
+		if series.SeriesType == types.CommitSeries {
+			// Like historical points, the points of commit series are recorded at the end of
+			// their interval.
+			end := processAfter.Truncate(time.Second)
+			interval := time.Duration(series.RecordingIntervalDays) * 24 * time.Hour
+			query = withCommitRange(query, end.Add(-interval), end)
+			recordTime = &end
+		}
+		return []*queryrunner.Job{{
+			SeriesID:     series.SeriesID,
+			SearchQuery:  query,
+			RecordTime:   recordTime,
+			ProcessAfter: &processAfter,
+			State:        "queued",
+			Priority:     int(priority.High),
+			Cost:         int(priority.Indexed),
+		}}
+	}
+
+	jobs := make([]*queryrunner.Job, 0, len(series.Repositories))
+	for _, repoName := range series.Repositories {
+		repoName := repoName
+		jobs = append(jobs, &queryrunner.Job{
+			SeriesID:       series.SeriesID,
+			RepositoryName: &repoName,
+			ProcessAfter:   &processAfter,
+			State:          "queued",
+			Priority:       int(priority.High),
+			Cost:           int(priority.Unindexed),
+		})
+	}
+	return jobs
+}
+
 // withCountUnlimited adds `count:9999999` to the given search query string iff `count:` does not
 // exist in the query string. This is extremely important as otherwise the number of results our
 // search query would return would be incomplete and fluctuate.
@@ -124,3 +162,9 @@ func withCountUnlimited(s string) string {
 	}
 	return s + " count:9999999"
 }
+
+// withCommitRange restricts the given commit or diff search query to the commits made in the
+// `[from, to]` time range.
+func withCommitRange(s string, from, to time.Time) string {
+	return fmt.Sprintf("%s after:%s before:%s", s, from.UTC().Format(time.RFC3339), to.UTC().Format(time.RFC3339))
+}
@@ -63,6 +63,9 @@ var testRealGlobalSettings = &api.Settings{ID: 1, Contents: `{
 // 1. Webhook insights are not enqueued (not yet supported.)
 // 2. Duplicate insights are deduplicated / do not submit multiple jobs.
 // 3. Jobs are scheduled not to all run at the same time.
+// 4. Language statistics series enqueue one job per repository.
+// 5. Commit series are restricted to the commits of the last recording interval, and recorded at
+//    its end.
 //
 func Test_discoverAndEnqueueInsights(t *testing.T) {
 	// Setup the setting store and job enqueuer mocks.
@@ -97,6 +100,23 @@ func Test_discoverAndEnqueueInsights(t *testing.T) {
 			NextRecordingAfter:    now.Add(1 * time.Hour),
 			RecordingIntervalDays: 1,
 		},
+		{
+			ID:                    3,
+			SeriesID:              "series3",
+			NextRecordingAfter:    now.Add(-1 * time.Hour),
+			RecordingIntervalDays: 1,
+			SeriesType:            types.LanguageStatsSeries,
+			Repositories:          []string{"github.com/sourcegraph/sourcegraph", "github.com/sourcegraph/about"},
+		},
+		{
+			ID:                    4,
+			SeriesID:              "series4",
+			Query:                 "type:commit message:CVE",
+			NextRecordingAfter:    now.Add(-1 * time.Hour),
+			RecordingIntervalDays: 7,
+			SeriesType:            types.CommitSeries,
+			CommitAggregation:     types.CommitCount,
+		},
 	}, nil)
 
 	if err := discoverAndEnqueueInsights(ctx, clock, dataSeriesStore, enqueueQueryRunnerJob); err != nil {
@@ -115,6 +135,8 @@ func Test_discoverAndEnqueueInsights(t *testing.T) {
     "RecordTime": null,
     "Cost": 500,
     "Priority": 10,
+    "RepositoryName": null,
+    "Revision": null,
     "ID": 0,
     "State": "queued",
     "FailureMessage": null,
@@ -131,6 +153,8 @@ func Test_discoverAndEnqueueInsights(t *testing.T) {
     "RecordTime": null,
     "Cost": 500,
     "Priority": 10,
+    "RepositoryName": null,
+    "Revision": null,
     "ID": 0,
     "State": "queued",
     "FailureMessage": null,
@@ -140,6 +164,60 @@ func Test_discoverAndEnqueueInsights(t *testing.T) {
     "NumResets": 0,
     "NumFailures": 0,
     "ExecutionLogs": null
+  },
+  {
+    "SeriesID": "series3",
+    "SearchQuery": "",
+    "RecordTime": null,
+    "Cost": 5000,
+    "Priority": 10,
+    "RepositoryName": "github.com/sourcegraph/sourcegraph",
+    "Revision": null,
+    "ID": 0,
+    "State": "queued",
+    "FailureMessage": null,
+    "StartedAt": null,
+    "FinishedAt": null,
+    "ProcessAfter": "2020-03-01T00:01:00Z",
+    "NumResets": 0,
+    "NumFailures": 0,
+    "ExecutionLogs": null
+  },
+  {
+    "SeriesID": "series3",
+    "SearchQuery": "",
+    "RecordTime": null,
+    "Cost": 5000,
+    "Priority": 10,
+    "RepositoryName": "github.com/sourcegraph/about",
+    "Revision": null,
+    "ID": 0,
+    "State": "queued",
+    "FailureMessage": null,
+    "StartedAt": null,
+    "FinishedAt": null,
+    "ProcessAfter": "2020-03-01T00:01:00Z",
+    "NumResets": 0,
+    "NumFailures": 0,
+    "ExecutionLogs": null
+  },
+  {
+    "SeriesID": "series4",
+    "SearchQuery": "type:commit message:CVE count:9999999 after:2020-02-23T00:01:30Z before:2020-03-01T00:01:30Z",
+    "RecordTime": "2020-03-01T00:01:30Z",
+    "Cost": 500,
+    "Priority": 10,
+    "RepositoryName": null,
+    "Revision": null,
+    "ID": 0,
+    "State": "queued",
+    "FailureMessage": null,
+    "StartedAt": null,
+    "FinishedAt": null,
+    "ProcessAfter": "2020-03-01T00:01:30Z",
+    "NumResets": 0,
+    "NumFailures": 0,
+    "ExecutionLogs": null
   }
 ]`).Equal(t, string(enqueuedJSON))
 }
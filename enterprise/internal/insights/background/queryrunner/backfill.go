@@ -0,0 +1,124 @@
+package queryrunner
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+)
+
+// This file contains the methods used to track and control the historical backfill of a series,
+// i.e. the jobs enqueued by the historical enqueuer. Those are the jobs with a record time, jobs
+// recording the current value of a series are never affected.
+
+// backfillRateWindow is the time window over which the rate at which jobs are completed is
+// measured to estimate when a backfill completes.
+const backfillRateWindow = time.Hour
+
+// BackfillStatus describes the historical jobs of a series per state.
+type BackfillStatus struct {
+	Queued, Processing uint64
+	Completed          uint64
+	Errored, Failed    uint64
+	Paused             uint64
+
+	// CompletedRecently is the number of jobs completed within the last backfillRateWindow.
+	CompletedRecently uint64
+}
+
+// EstimatedCompletion estimates when the remaining jobs of the backfill complete, assuming they
+// are completed at the same rate as the recently completed jobs. It returns nil if there are no
+// remaining jobs, or if no job was completed recently so that the rate is unknown. Only the jobs
+// already enqueued are counted, not the ones the historical enqueuer has yet to enqueue.
+func (s *BackfillStatus) EstimatedCompletion(now time.Time) *time.Time {
+	remaining := s.Queued + s.Processing + s.Errored
+	if remaining == 0 || s.CompletedRecently == 0 {
+		return nil
+	}
+	estimate := now.Add(time.Duration(float64(remaining) / float64(s.CompletedRecently) * float64(backfillRateWindow)))
+	return &estimate
+}
+
+// QueryBackfillStatus queries the current status of the historical jobs of the specified series.
+// The cleaner keeps completed and failed historical jobs until the backfill is finished, so the
+// counts cover the whole backfill while it is running.
+func QueryBackfillStatus(ctx context.Context, workerBaseStore *basestore.Store, seriesID string, now time.Time) (*BackfillStatus, error) {
+	var status BackfillStatus
+	row := workerBaseStore.QueryRow(ctx, sqlf.Sprintf(queryBackfillStatusFmtStr, now.Add(-backfillRateWindow), seriesID))
+	if err := row.Scan(
+		&status.Queued,
+		&status.Processing,
+		&status.Completed,
+		&status.Errored,
+		&status.Failed,
+		&status.Paused,
+		&status.CompletedRecently,
+	); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+const queryBackfillStatusFmtStr = `
+-- source: enterprise/internal/insights/background/queryrunner/backfill.go:QueryBackfillStatus
+SELECT
+	COUNT(*) FILTER (WHERE state = 'queued'),
+	COUNT(*) FILTER (WHERE state = 'processing'),
+	COUNT(*) FILTER (WHERE state = 'completed'),
+	COUNT(*) FILTER (WHERE state = 'errored'),
+	COUNT(*) FILTER (WHERE state = 'failed'),
+	COUNT(*) FILTER (WHERE state = 'paused'),
+	COUNT(*) FILTER (WHERE state = 'completed' AND finished_at >= %s)
+FROM insights_query_runner_jobs
+WHERE series_id = %s AND record_time IS NOT NULL
+`
+
+// PauseBackfillJobs pauses the queued historical jobs of the specified series, so that the worker
+// does not execute them until they are resumed. Jobs that are already processing are not
+// interrupted.
+func PauseBackfillJobs(ctx context.Context, workerBaseStore *basestore.Store, seriesID string) (numPaused int, err error) {
+	numPaused, _, err = basestore.ScanFirstInt(workerBaseStore.Query(ctx, sqlf.Sprintf(pauseBackfillJobsFmtStr, seriesID)))
+	return
+}
+
+const pauseBackfillJobsFmtStr = `
+-- source: enterprise/internal/insights/background/queryrunner/backfill.go:PauseBackfillJobs
+WITH paused AS (
+	UPDATE insights_query_runner_jobs SET state = 'paused'
+	WHERE series_id = %s AND record_time IS NOT NULL AND state IN ('queued', 'errored')
+	RETURNING 1
+) SELECT count(*) FROM paused
+`
+
+// ResumeBackfillJobs queues the paused historical jobs of the specified series again.
+func ResumeBackfillJobs(ctx context.Context, workerBaseStore *basestore.Store, seriesID string) (numResumed int, err error) {
+	numResumed, _, err = basestore.ScanFirstInt(workerBaseStore.Query(ctx, sqlf.Sprintf(resumeBackfillJobsFmtStr, seriesID)))
+	return
+}
+
+const resumeBackfillJobsFmtStr = `
+-- source: enterprise/internal/insights/background/queryrunner/backfill.go:ResumeBackfillJobs
+WITH resumed AS (
+	UPDATE insights_query_runner_jobs SET state = 'queued'
+	WHERE series_id = %s AND record_time IS NOT NULL AND state = 'paused'
+	RETURNING 1
+) SELECT count(*) FROM resumed
+`
+
+// CancelBackfillJobs deletes the queued and paused historical jobs of the specified series. Jobs
+// that are already processing are not interrupted.
+func CancelBackfillJobs(ctx context.Context, workerBaseStore *basestore.Store, seriesID string) (numCanceled int, err error) {
+	numCanceled, _, err = basestore.ScanFirstInt(workerBaseStore.Query(ctx, sqlf.Sprintf(cancelBackfillJobsFmtStr, seriesID)))
+	return
+}
+
+const cancelBackfillJobsFmtStr = `
+-- source: enterprise/internal/insights/background/queryrunner/backfill.go:CancelBackfillJobs
+WITH canceled AS (
+	DELETE FROM insights_query_runner_jobs
+	WHERE series_id = %s AND record_time IS NOT NULL AND state IN ('queued', 'errored', 'paused')
+	RETURNING 1
+) SELECT count(*) FROM canceled
+`
@@ -0,0 +1,107 @@
+package queryrunner
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtesting"
+)
+
+func TestBackfillStatus_EstimatedCompletion(t *testing.T) {
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		status BackfillStatus
+		want   *time.Time
+	}{
+		"no remaining jobs":       {status: BackfillStatus{Completed: 10, CompletedRecently: 10}},
+		"no recent completions":   {status: BackfillStatus{Queued: 10, Completed: 10}},
+		"half the recent amount":  {status: BackfillStatus{Queued: 3, Processing: 1, Errored: 1, CompletedRecently: 10}, want: timePtr(now.Add(30 * time.Minute))},
+		"paused jobs don't count": {status: BackfillStatus{Queued: 10, Paused: 100, CompletedRecently: 5}, want: timePtr(now.Add(2 * time.Hour))},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.status.EstimatedCompletion(now)); diff != "" {
+				t.Fatalf("unexpected estimated completion (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
+
+func timePtr(t time.Time) *time.Time { return &t }
+
+func TestBackfillJobs(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := actor.WithInternalActor(context.Background())
+	mainAppDB := dbtesting.GetDB(t)
+	workerBaseStore := basestore.NewWithDB(mainAppDB, sql.TxOptions{})
+	now := time.Now()
+
+	for _, job := range []*Job{
+		{SeriesID: "series1", SearchQuery: "historical", RecordTime: &now, State: "queued"},
+		{SeriesID: "series1", SearchQuery: "historical", RecordTime: &now, State: "queued"},
+		{SeriesID: "series1", SearchQuery: "current", State: "queued"},
+		{SeriesID: "series2", SearchQuery: "historical", RecordTime: &now, State: "queued"},
+	} {
+		if _, err := EnqueueJob(ctx, workerBaseStore, job); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	status := func() BackfillStatus {
+		status, err := QueryBackfillStatus(ctx, workerBaseStore, "series1", now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return *status
+	}
+
+	if diff := cmp.Diff(BackfillStatus{Queued: 2}, status()); diff != "" {
+		t.Fatalf("unexpected status (-want +have):\n%s", diff)
+	}
+
+	if n, err := PauseBackfillJobs(ctx, workerBaseStore, "series1"); err != nil || n != 2 {
+		t.Fatalf("unexpected result pausing jobs: %d, %v", n, err)
+	}
+	if diff := cmp.Diff(BackfillStatus{Paused: 2}, status()); diff != "" {
+		t.Fatalf("unexpected status after pausing (-want +have):\n%s", diff)
+	}
+
+	if n, err := ResumeBackfillJobs(ctx, workerBaseStore, "series1"); err != nil || n != 2 {
+		t.Fatalf("unexpected result resuming jobs: %d, %v", n, err)
+	}
+	if diff := cmp.Diff(BackfillStatus{Queued: 2}, status()); diff != "" {
+		t.Fatalf("unexpected status after resuming (-want +have):\n%s", diff)
+	}
+
+	if n, err := CancelBackfillJobs(ctx, workerBaseStore, "series1"); err != nil || n != 2 {
+		t.Fatalf("unexpected result canceling jobs: %d, %v", n, err)
+	}
+	if diff := cmp.Diff(BackfillStatus{}, status()); diff != "" {
+		t.Fatalf("unexpected status after canceling (-want +have):\n%s", diff)
+	}
+
+	// The jobs of other series, and jobs recording the current value, are not affected.
+	jobsStatus, err := QueryJobsStatus(ctx, workerBaseStore, "series1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jobsStatus.Queued != 1 {
+		t.Fatalf("expected current job to stay queued, got %+v", jobsStatus)
+	}
+	other, err := QueryBackfillStatus(ctx, workerBaseStore, "series2", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.Queued != 1 {
+		t.Fatalf("expected other series' job to stay queued, got %+v", other)
+	}
+}
@@ -0,0 +1,85 @@
+package queryrunner
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+// This file contains the methods required to record series that are generated from capture
+// groups. Instead of counting the matches of the search query, such a series counts the matches
+// per distinct value of the first capture group of the query's regular expression. For example,
+// `go\s1\.(\d+) file:go.mod` results in one series per Go version found in go.mod files.
+
+// captureGroupPattern returns the regular expression of the given search query, used to extract
+// capture group values from the matched lines. The query must contain exactly one pattern with
+// at least one capture group.
+func captureGroupPattern(searchQuery string) (*regexp.Regexp, error) {
+	q, err := query.ParseRegexp(searchQuery)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing query")
+	}
+
+	var patternTypeErr error
+	query.VisitField(q, query.FieldPatternType, func(value string, _ bool, _ query.Annotation) {
+		if v := strings.ToLower(value); v != "regexp" && v != "regex" {
+			patternTypeErr = errors.Errorf("capture group series require a regexp query, got patternType:%s", value)
+		}
+	})
+	if patternTypeErr != nil {
+		return nil, patternTypeErr
+	}
+
+	var patterns []string
+	query.VisitPattern(q, func(value string, negated bool, _ query.Annotation) {
+		if !negated {
+			patterns = append(patterns, value)
+		}
+	})
+	if len(patterns) != 1 {
+		return nil, errors.Errorf("capture group series require a query with exactly one pattern, got %d", len(patterns))
+	}
+
+	pattern := patterns[0]
+	// Search patterns are case-insensitive unless case:yes is given.
+	if !q.IsCaseSensitive() {
+		pattern = "(?i:" + pattern + ")"
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errors.Wrap(err, "compiling pattern")
+	}
+	if re.NumSubexp() == 0 {
+		return nil, errors.Errorf("capture group series require a pattern with a capture group, got %q", patterns[0])
+	}
+	return re, nil
+}
+
+// withRegexpPatternType adds `patternType:regexp` to the given search query string iff
+// `patternType:` does not exist in the query string, since capture groups only exist in regular
+// expression searches.
+func withRegexpPatternType(s string) string {
+	if strings.Contains(strings.ToLower(s), "patterntype:") {
+		return s
+	}
+	return s + " patternType:regexp"
+}
+
+// captureGroupMatches counts the matches of the given file match per value of the first capture
+// group of re. Matches are found in the previews of the matched lines, so patterns matching
+// multiple lines are not supported. Matches in which the capture group is empty or doesn't
+// participate are not counted.
+func captureGroupMatches(re *regexp.Regexp, fm *fileMatch) map[string]int {
+	counts := make(map[string]int)
+	for _, lineMatch := range fm.LineMatches {
+		for _, submatches := range re.FindAllStringSubmatch(lineMatch.Preview, -1) {
+			if value := submatches[1]; value != "" {
+				counts[value]++
+			}
+		}
+	}
+	return counts
+}
@@ -0,0 +1,75 @@
+package queryrunner
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCaptureGroupPattern(t *testing.T) {
+	for name, tc := range map[string]struct {
+		query   string
+		want    string
+		wantErr bool
+	}{
+		"case-insensitive by default": {query: `go\s1\.(\d+) file:go.mod`, want: `(?i:go\s1\.(\d+))`},
+		"case-sensitive":              {query: `go\s1\.(\d+) file:go.mod case:yes`, want: `go\s1\.(\d+)`},
+		"regexp pattern type":         {query: `go\s1\.(\d+) patternType:regexp`, want: `(?i:go\s1\.(\d+))`},
+		"literal pattern type":        {query: `go\s1\.(\d+) patternType:literal`, wantErr: true},
+		"no capture group":            {query: `go\s1\.\d+ file:go.mod`, wantErr: true},
+		"no pattern":                  {query: `file:go.mod`, wantErr: true},
+		"multiple patterns":           {query: `go\s1\.(\d+) or toolchain\s(\w+)`, wantErr: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			re, err := captureGroupPattern(tc.query)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got pattern %q", re)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have := re.String(); have != tc.want {
+				t.Fatalf("wrong pattern. want=%q, have=%q", tc.want, have)
+			}
+		})
+	}
+}
+
+func TestCaptureGroupMatches(t *testing.T) {
+	re, err := captureGroupPattern(`go\s1\.(\d+)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var fm fileMatch
+	if err := json.Unmarshal([]byte(`{
+		"repository": {"id": "UmVwb3NpdG9yeTox", "name": "github.com/sourcegraph/sourcegraph"},
+		"lineMatches": [
+			{"preview": "go 1.16", "offsetAndLengths": [[0, 7]]},
+			{"preview": "// Go 1.15 and go 1.16", "offsetAndLengths": [[3, 7], [15, 7]]}
+		]
+	}`), &fm); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]int{"15": 1, "16": 2}
+	if diff := cmp.Diff(want, captureGroupMatches(re, &fm)); diff != "" {
+		t.Fatalf("wrong matches (-want +have):\n%s", diff)
+	}
+}
+
+func TestWithRegexpPatternType(t *testing.T) {
+	for query, want := range map[string]string{
+		`go\s1\.(\d+)`:                     `go\s1\.(\d+) patternType:regexp`,
+		`go\s1\.(\d+) patterntype:regexp`:  `go\s1\.(\d+) patterntype:regexp`,
+		`go\s1\.(\d+) patternType:literal`: `go\s1\.(\d+) patternType:literal`,
+	} {
+		if have := withRegexpPatternType(query); have != want {
+			t.Errorf("wrong query. want=%q, have=%q", want, have)
+		}
+	}
+}
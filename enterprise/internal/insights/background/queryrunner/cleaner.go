@@ -18,6 +18,10 @@ import (
 // This is particularly important because the historical enqueuer can produce e.g.
 // num_series*num_repos*num_timeframes jobs (example: 20*40,000*6 in an average case) which
 // can quickly add up to be millions of jobs left in a "completed" state in the DB.
+//
+// The historical jobs of a series are kept until its backfill is finished, i.e. until it has no
+// queued, processing, errored or paused historical jobs left, since QueryBackfillStatus counts
+// them to report the progress of the backfill.
 func NewCleaner(ctx context.Context, workerBaseStore *basestore.Store, observationContext *observation.Context) goroutine.BackgroundRoutine {
 	metrics := metrics.NewOperationMetrics(
 		observationContext.Registerer,
@@ -40,7 +44,9 @@ func NewCleaner(ctx context.Context, workerBaseStore *basestore.Store, observati
 	), operation)
 }
 
-// cleanJobs
+// cleanJobs deletes the jobs left in the "completed" or "failed" state that were started over 12
+// hours ago, except for the historical jobs of unfinished backfills, and returns the number of
+// jobs deleted.
 func cleanJobs(ctx context.Context, workerBaseStore *basestore.Store) (numCleaned int, err error) {
 	numCleaned, _, err = basestore.ScanFirstInt(workerBaseStore.Query(
 		ctx,
@@ -52,6 +58,13 @@ func cleanJobs(ctx context.Context, workerBaseStore *basestore.Store) (numCleane
 const cleanJobsFmtStr = `
 -- source: enterprise/internal/insights/background/queryrunner/cleaner.go:cleanJobs
 WITH deleted AS (
-	DELETE FROM insights_query_runner_jobs WHERE (state='completed' OR state='failed') AND started_at >= %s RETURNING *
+	DELETE FROM insights_query_runner_jobs
+	WHERE (state='completed' OR state='failed') AND started_at < %s
+	-- Keep the historical jobs of unfinished backfills, they are counted to track backfill progress.
+	AND NOT (record_time IS NOT NULL AND series_id IN (
+		SELECT series_id FROM insights_query_runner_jobs
+		WHERE record_time IS NOT NULL AND state IN ('queued', 'processing', 'errored', 'paused')
+	))
+	RETURNING *
 ) SELECT count(*) FROM deleted
 `
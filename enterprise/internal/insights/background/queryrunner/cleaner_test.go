@@ -0,0 +1,75 @@
+package queryrunner
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtesting"
+)
+
+func TestCleanJobs(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := actor.WithInternalActor(context.Background())
+	mainAppDB := dbtesting.GetDB(t)
+	workerBaseStore := basestore.NewWithDB(mainAppDB, sql.TxOptions{})
+	now := time.Now()
+
+	for _, job := range []struct {
+		seriesID   string
+		recordTime *time.Time
+		state      string
+		startedAt  time.Time
+	}{
+		{seriesID: "series1", state: "completed", startedAt: now.Add(-24 * time.Hour)},
+		{seriesID: "series1", state: "failed", startedAt: now.Add(-24 * time.Hour)},
+		{seriesID: "series1", state: "completed", startedAt: now.Add(-time.Hour)},
+		{seriesID: "series1", state: "failed", startedAt: now.Add(-time.Hour)},
+		{seriesID: "series1", state: "processing", startedAt: now.Add(-24 * time.Hour)},
+		// The backfill of series2 is still running, the one of series3 is finished.
+		{seriesID: "series2", recordTime: &now, state: "completed", startedAt: now.Add(-24 * time.Hour)},
+		{seriesID: "series2", recordTime: &now, state: "paused", startedAt: now.Add(-24 * time.Hour)},
+		{seriesID: "series2", state: "completed", startedAt: now.Add(-24 * time.Hour)},
+		{seriesID: "series3", recordTime: &now, state: "completed", startedAt: now.Add(-24 * time.Hour)},
+	} {
+		id, err := EnqueueJob(ctx, workerBaseStore, &Job{SeriesID: job.seriesID, SearchQuery: "query", RecordTime: job.recordTime, State: job.state})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := workerBaseStore.Exec(ctx, sqlf.Sprintf("UPDATE insights_query_runner_jobs SET started_at = %s WHERE id = %s", job.startedAt, id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Only the completed and failed jobs started over 12 hours ago are cleaned, except for the
+	// historical jobs of unfinished backfills.
+	numCleaned, err := cleanJobs(ctx, workerBaseStore)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if numCleaned != 4 {
+		t.Fatalf("got %d cleaned jobs, want 4", numCleaned)
+	}
+	status, err := QueryJobsStatus(ctx, workerBaseStore, "series1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Completed != 1 || status.Failed != 1 || status.Processing != 1 {
+		t.Fatalf("unexpected remaining jobs %+v", status)
+	}
+	backfill, err := QueryBackfillStatus(ctx, workerBaseStore, "series2", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if backfill.Completed != 1 || backfill.Paused != 1 {
+		t.Fatalf("unexpected backfill status %+v", backfill)
+	}
+}
@@ -0,0 +1,136 @@
+package queryrunner
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+// This file contains the methods required to record commit series. Instead of counting the
+// matches of the search query, such a series counts the commits matching a type:commit or
+// type:diff query (or their distinct authors) in a time range. The enqueuers restrict the query
+// to the interval being recorded with after: and before:, e.g. `type:commit message:CVE` results
+// in the number of commits mentioning CVE per interval. The points are recorded at the end of
+// the interval.
+
+// ValidateCommitQuery returns an error if the given search query is not a commit or diff search.
+func ValidateCommitQuery(searchQuery string) error {
+	q, err := query.ParseLiteral(searchQuery)
+	if err != nil {
+		return errors.Wrap(err, "parsing query")
+	}
+
+	var resultTypes []string
+	query.VisitField(q, query.FieldType, func(value string, negated bool, _ query.Annotation) {
+		if !negated {
+			resultTypes = append(resultTypes, strings.ToLower(value))
+		}
+	})
+	if len(resultTypes) != 1 || (resultTypes[0] != "commit" && resultTypes[0] != "diff") {
+		return errors.Errorf("commit series require a query with type:commit or type:diff, got %q", searchQuery)
+	}
+	return nil
+}
+
+// commitCounter aggregates commit search results.
+type commitCounter struct {
+	aggregation types.CommitAggregation
+
+	// commits is the number of commits per repository ID.
+	commits map[string]int
+
+	// authors is the set of authors across all repositories.
+	authors map[string]struct{}
+}
+
+func newCommitCounter(aggregation types.CommitAggregation) *commitCounter {
+	return &commitCounter{
+		aggregation: aggregation,
+		commits:     make(map[string]int),
+		authors:     make(map[string]struct{}),
+	}
+}
+
+// add records the given commit. Diff search results have one result per commit too, so the
+// number of matched lines of a diff doesn't matter.
+func (c *commitCounter) add(r *commitSearchResult) {
+	c.commits[r.repoID()]++
+
+	// Authors are identified by their email, falling back to their name for commits without one.
+	author := strings.ToLower(r.Commit.Author.Person.Email)
+	if author == "" {
+		author = r.Commit.Author.Person.Name
+	}
+	c.authors[author] = struct{}{}
+}
+
+// distinctAuthors returns the number of distinct authors across all repositories, so an author
+// of commits in two repositories is counted once.
+func (c *commitCounter) distinctAuthors() int {
+	return len(c.authors)
+}
+
+// recordCommitPoints records the points of a commit series for the interval ending at recordTime
+// in a single transaction. The points are summed per interval instead of carried forward when
+// they are read, so an interval without any matching commits is recorded as a single zero point
+// that isn't associated with a repository, and an interval that already has points (e.g. because
+// the job was enqueued twice) isn't recorded again, as its commits would be counted twice.
+//
+// The number of commits is recorded per repository. The number of distinct authors can't be
+// summed across repositories, so it is recorded as a single point that isn't associated with a
+// repository.
+//
+// 🚨 SECURITY: The distinct authors point counts the authors of commits in all repositories,
+// including the ones a user viewing the series may not have access to. Like total result counts,
+// the number alone doesn't expose the repositories or their commits.
+func (r *workHandler) recordCommitPoints(ctx context.Context, seriesID string, recordTime time.Time, commits *commitCounter, repoNames map[string]string) error {
+	recorded, err := r.insightsStore.CountData(ctx, store.CountDataOpts{
+		From:     &recordTime,
+		To:       &recordTime,
+		SeriesID: &seriesID,
+	})
+	if err != nil {
+		return errors.Wrap(err, "CountData")
+	}
+	if recorded > 0 {
+		return nil
+	}
+
+	var points []store.RecordSeriesPointArgs
+	if commits.aggregation == types.DistinctAuthors {
+		points = append(points, store.RecordSeriesPointArgs{
+			SeriesID: seriesID,
+			Point: store.SeriesPoint{
+				Time:  recordTime,
+				Value: float64(commits.distinctAuthors()),
+			},
+		})
+	} else {
+		for graphQLRepoID, count := range commits.commits {
+			point, err := seriesPointArgs(seriesID, recordTime, graphQLRepoID, repoNames[graphQLRepoID], nil, count)
+			if err != nil {
+				return err
+			}
+			points = append(points, point)
+		}
+	}
+	if len(points) == 0 {
+		points = append(points, store.RecordSeriesPointArgs{
+			SeriesID: seriesID,
+			Point: store.SeriesPoint{
+				Time:  recordTime,
+				Value: 0, // no matching commits
+			},
+		})
+	}
+	if err := r.insightsStore.RecordSeriesPoints(ctx, points); err != nil {
+		return errors.Wrap(err, "RecordSeriesPoints")
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package queryrunner
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+)
+
+func TestValidateCommitQuery(t *testing.T) {
+	for name, tc := range map[string]struct {
+		query   string
+		wantErr bool
+	}{
+		"commit":        {query: `type:commit message:CVE`},
+		"diff":          {query: `type:diff author:alice file:payments/`},
+		"case":          {query: `TYPE:Commit CVE`},
+		"file":          {query: `type:file CVE`, wantErr: true},
+		"no type":       {query: `CVE`, wantErr: true},
+		"negated type":  {query: `-type:commit CVE`, wantErr: true},
+		"several types": {query: `type:commit type:diff CVE`, wantErr: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateCommitQuery(tc.query)
+			if have := err != nil; have != tc.wantErr {
+				t.Fatalf("unexpected error. wantErr=%v, have=%v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCommitCounter(t *testing.T) {
+	commit := func(repoID, email, name string) *commitSearchResult {
+		var r commitSearchResult
+		r.Commit.Repository.ID = repoID
+		r.Commit.Author.Person.Email = email
+		r.Commit.Author.Person.Name = name
+		return &r
+	}
+	results := []*commitSearchResult{
+		commit("repo1", "alice@example.com", "Alice"),
+		commit("repo1", "Alice@example.com", "Alice"),
+		commit("repo1", "bob@example.com", "Bob"),
+		commit("repo1", "", "Carol"),
+		commit("repo2", "alice@example.com", "Alice"),
+	}
+
+	counter := newCommitCounter(types.DistinctAuthors)
+	for _, r := range results {
+		counter.add(r)
+	}
+	if diff := cmp.Diff(map[string]int{"repo1": 4, "repo2": 1}, counter.commits); diff != "" {
+		t.Fatalf("unexpected commits (-want +got):\n%s", diff)
+	}
+	// alice@example.com authored commits in both repositories, but is counted once.
+	if have, want := counter.distinctAuthors(), 3; have != want {
+		t.Fatalf("unexpected number of distinct authors. want=%d have=%d", want, have)
+	}
+}
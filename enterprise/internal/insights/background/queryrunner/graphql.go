@@ -24,6 +24,7 @@ type graphQLQuery struct {
 
 const gqlSearchQuery = `query Search(
 	$query: String!,
+	$includePreviews: Boolean!,
 ) {
 	search(query: $query, version: V2, patternType:literal) {
 		results {
@@ -40,6 +41,7 @@ const gqlSearchQuery = `query Search(
 						name
 					}
 					lineMatches {
+						preview @include(if: $includePreviews)
 						offsetAndLengths
 					}
 					symbols {
@@ -57,6 +59,12 @@ const gqlSearchQuery = `query Search(
 							id
 							name
 						}
+						author {
+							person {
+								email
+								name
+							}
+						}
 					}
 				}
 				... on Repository {
@@ -73,7 +81,8 @@ const gqlSearchQuery = `query Search(
 }`
 
 type gqlSearchVars struct {
-	Query string `json:"query"`
+	Query           string `json:"query"`
+	IncludePreviews bool   `json:"includePreviews"`
 }
 
 type gqlSearchResponse struct {
@@ -96,12 +105,13 @@ type gqlSearchResponse struct {
 	Errors []interface{}
 }
 
-// search executes the given search query.
-func search(ctx context.Context, query string) (*gqlSearchResponse, error) {
+// search executes the given search query. The previews of matched lines are only returned if
+// includePreviews is true, since they inflate the response considerably.
+func search(ctx context.Context, query string, includePreviews bool) (*gqlSearchResponse, error) {
 	var buf bytes.Buffer
 	err := json.NewEncoder(&buf).Encode(graphQLQuery{
 		Query:     gqlSearchQuery,
-		Variables: gqlSearchVars{Query: query},
+		Variables: gqlSearchVars{Query: query, IncludePreviews: includePreviews},
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "Encode")
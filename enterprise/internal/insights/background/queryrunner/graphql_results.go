@@ -114,6 +114,7 @@ type fileMatch struct {
 		Name string
 	}
 	LineMatches []struct {
+		Preview          string
 		OffsetAndLengths [][]int
 	}
 	Symbols []struct {
@@ -151,6 +152,12 @@ type commitSearchResult struct {
 			ID   string
 			Name string
 		}
+		Author struct {
+			Person struct {
+				Email string
+				Name  string
+			}
+		}
 	}
 }
 
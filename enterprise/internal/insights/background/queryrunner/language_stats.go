@@ -0,0 +1,96 @@
+package queryrunner
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/inventory"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+// This file contains the methods required to record language statistics series. Instead of
+// running a search query, a job of such a series computes the inventory of a single repository at
+// a single commit and records the number of lines per language, one data point per language. The
+// language is stored as the capture of the data point, so that the series is split into one series
+// per language the same way as series generated from capture groups are.
+
+// handleLanguageStats records the number of lines per language in the repository of the given job.
+func (r *workHandler) handleLanguageStats(ctx context.Context, job *Job) error {
+	if job.RepositoryName == nil {
+		return errors.Errorf("language statistics job for series %q has no repository", job.SeriesID)
+	}
+	repo, err := database.Repos(r.workerBaseStore.Handle().DB()).GetByName(ctx, api.RepoName(*job.RepositoryName))
+	if err != nil {
+		if errors.HasType(err, &database.RepoNotFoundErr{}) {
+			log15.Warn("insights: repository of language statistics job not found", "repo", *job.RepositoryName, "series_id", job.SeriesID)
+			return nil // the repository was deleted since the job was enqueued
+		}
+		return errors.Wrap(err, "GetByName")
+	}
+
+	var commitID api.CommitID
+	if job.Revision != nil {
+		commitID = api.CommitID(*job.Revision)
+	} else {
+		commitID, err = git.ResolveRevision(ctx, repo.Name, "HEAD", git.ResolveRevisionOptions{})
+		if err != nil {
+			if errors.HasType(err, &gitserver.RevisionNotFoundError{}) || vcs.IsRepoNotExist(err) {
+				return nil // repo may not be cloned yet (or not even pushed to code host yet)
+			}
+			return errors.Wrap(err, "ResolveRevision")
+		}
+	}
+
+	if err := r.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	// Line counts are only available with enhanced language detection, which reads the files.
+	inv, err := backend.Repos.GetInventory(ctx, repo, commitID, true)
+	if err != nil {
+		return errors.Wrap(err, "GetInventory")
+	}
+
+	recordTime := time.Now()
+	if job.RecordTime != nil {
+		recordTime = *job.RecordTime
+	}
+	repoName := string(repo.Name)
+	for language, lines := range linesPerLanguage(inv) {
+		language := language
+		if err := r.insightsStore.RecordSeriesPoint(ctx, store.RecordSeriesPointArgs{
+			SeriesID: job.SeriesID,
+			Point: store.SeriesPoint{
+				Time:    recordTime,
+				Value:   float64(lines),
+				Capture: &language,
+			},
+			RepoName: &repoName,
+			RepoID:   &repo.ID,
+		}); err != nil {
+			return errors.Wrap(err, "RecordSeriesPoint")
+		}
+	}
+	return nil
+}
+
+// linesPerLanguage returns the number of lines per language of the given inventory. Languages
+// without any lines are omitted.
+func linesPerLanguage(inv *inventory.Inventory) map[string]uint64 {
+	lines := make(map[string]uint64, len(inv.Languages))
+	for _, lang := range inv.Languages {
+		if lang.Name == "" || lang.TotalLines == 0 {
+			continue
+		}
+		lines[lang.Name] += lang.TotalLines
+	}
+	return lines
+}
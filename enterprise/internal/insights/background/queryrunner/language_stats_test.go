@@ -0,0 +1,25 @@
+package queryrunner
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/inventory"
+)
+
+func TestLinesPerLanguage(t *testing.T) {
+	inv := &inventory.Inventory{
+		Languages: []inventory.Lang{
+			{Name: "Go", TotalBytes: 1000, TotalLines: 40},
+			{Name: "Markdown", TotalBytes: 50, TotalLines: 0},
+			{Name: "", TotalBytes: 10, TotalLines: 2},
+			{Name: "TypeScript", TotalBytes: 300, TotalLines: 12},
+		},
+	}
+
+	want := map[string]uint64{"Go": 40, "TypeScript": 12}
+	if diff := cmp.Diff(want, linesPerLanguage(inv)); diff != "" {
+		t.Fatalf("wrong lines per language (-want +have):\n%s", diff)
+	}
+}
@@ -3,6 +3,7 @@ package queryrunner
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
@@ -15,17 +16,19 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 )
 
 var _ workerutil.Handler = &workHandler{}
 
-// workHandler implements the dbworker.Handler interface by executing search queries and
-// inserting insights about them to the insights Timescale database.
+// workHandler implements the dbworker.Handler interface by executing search queries (or computing
+// language statistics) and inserting insights about them to the insights Timescale database.
 type workHandler struct {
 	workerBaseStore *basestore.Store
 	insightsStore   *store.Store
+	metadataStore   store.DataSeriesStore
 	limiter         *rate.Limiter
 }
 
@@ -42,6 +45,32 @@ func (r *workHandler) Handle(ctx context.Context, record workerutil.Record) (err
 		return err
 	}
 
+	// Series generated from capture groups record one data point per capture group value instead
+	// of one per repository, which requires the matched lines. Language statistics series don't run
+	// a search query at all.
+	series, err := r.metadataStore.GetDataSeries(ctx, store.GetDataSeriesArgs{SeriesID: job.SeriesID})
+	if err != nil {
+		return errors.Wrap(err, "GetDataSeries")
+	}
+	if len(series) > 0 && series[0].SeriesType == types.LanguageStatsSeries {
+		return r.handleLanguageStats(ctx, job)
+	}
+	var captureGroups *regexp.Regexp
+	var commits *commitCounter
+	searchQuery := job.SearchQuery
+	if len(series) > 0 && series[0].SeriesType == types.CommitSeries {
+		if err := ValidateCommitQuery(job.SearchQuery); err != nil {
+			return err
+		}
+		commits = newCommitCounter(series[0].CommitAggregation)
+	} else if len(series) > 0 && series[0].GeneratedFromCaptureGroups {
+		captureGroups, err = captureGroupPattern(job.SearchQuery)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf(`for query "%s"`, job.SearchQuery))
+		}
+		searchQuery = withRegexpPatternType(searchQuery)
+	}
+
 	err = r.limiter.Wait(ctx)
 	if err != nil {
 		return err
@@ -54,7 +83,7 @@ func (r *workHandler) Handle(ctx context.Context, record workerutil.Record) (err
 	// that a repository exists may or may not be fine, exposing individual results is definitely
 	// not, etc.)
 	var results *gqlSearchResponse
-	results, err = search(ctx, job.SearchQuery)
+	results, err = search(ctx, searchQuery, captureGroups != nil)
 	if err != nil {
 		return err
 	}
@@ -106,8 +135,10 @@ func (r *workHandler) Handle(ctx context.Context, record workerutil.Record) (err
 	}
 
 	// Figure out how many matches we got for every unique repository returned in the search
-	// results.
+	// results, for series generated from capture groups for every capture group value in each
+	// repository, and for commit series how many commits or distinct authors.
 	matchesPerRepo := make(map[string]int, len(results.Data.Search.Results.Results)*4)
+	matchesPerRepoCapture := make(map[string]map[string]int)
 	repoNames := make(map[string]string, len(matchesPerRepo))
 	for _, result := range results.Data.Search.Results.Results {
 		decoded, err := decodeResult(result)
@@ -115,33 +146,85 @@ func (r *workHandler) Handle(ctx context.Context, record workerutil.Record) (err
 			return errors.Wrap(err, fmt.Sprintf(`for query "%s"`, job.SearchQuery))
 		}
 		repoNames[decoded.repoID()] = decoded.repoName()
-		matchesPerRepo[decoded.repoID()] = matchesPerRepo[decoded.repoID()] + decoded.matchCount()
+		if commits != nil {
+			if cs, ok := decoded.(*commitSearchResult); ok {
+				commits.add(cs)
+			}
+			continue
+		}
+		if captureGroups == nil {
+			matchesPerRepo[decoded.repoID()] = matchesPerRepo[decoded.repoID()] + decoded.matchCount()
+			continue
+		}
+
+		// Only file matches have matched lines from which capture group values can be extracted.
+		fm, ok := decoded.(*fileMatch)
+		if !ok {
+			continue
+		}
+		captures, ok := matchesPerRepoCapture[decoded.repoID()]
+		if !ok {
+			captures = make(map[string]int)
+			matchesPerRepoCapture[decoded.repoID()] = captures
+		}
+		for value, count := range captureGroupMatches(captureGroups, fm) {
+			captures[value] += count
+		}
+	}
+
+	if commits != nil {
+		return r.recordCommitPoints(ctx, job.SeriesID, recordTime, commits, repoNames)
 	}
 
 	// Record the number of results we got, one data point per-repository.
 	for graphQLRepoID, matchCount := range matchesPerRepo {
-		dbRepoID, idErr := graphqlbackend.UnmarshalRepositoryID(graphql.ID(graphQLRepoID))
-		if idErr != nil {
-			err = multierror.Append(err, errors.Wrap(idErr, "UnmarshalRepositoryID"))
-			continue
-		}
-		repoName := repoNames[graphQLRepoID]
-		if len(repoName) == 0 {
-			// this really should never happen, expect if for some reason the gql response is broken
-			err = multierror.Append(err, errors.Newf("MissingRepositoryName for repo_id: %v", string(dbRepoID)))
-			continue
+		if recordErr := r.recordSeriesPoint(ctx, job.SeriesID, recordTime, graphQLRepoID, repoNames[graphQLRepoID], nil, matchCount); recordErr != nil {
+			err = multierror.Append(err, recordErr)
 		}
-		if recordErr := r.insightsStore.RecordSeriesPoint(ctx, store.RecordSeriesPointArgs{
-			SeriesID: job.SeriesID,
-			Point: store.SeriesPoint{
-				Time:  recordTime,
-				Value: float64(matchCount),
-			},
-			RepoName: &repoName,
-			RepoID:   &dbRepoID,
-		}); recordErr != nil {
-			err = multierror.Append(err, errors.Wrap(recordErr, "RecordSeriesPoint"))
+	}
+
+	// Record the number of matches per capture group value, one data point per-repository and
+	// value.
+	for graphQLRepoID, captures := range matchesPerRepoCapture {
+		for value, matchCount := range captures {
+			value := value
+			if recordErr := r.recordSeriesPoint(ctx, job.SeriesID, recordTime, graphQLRepoID, repoNames[graphQLRepoID], &value, matchCount); recordErr != nil {
+				err = multierror.Append(err, recordErr)
+			}
 		}
 	}
 	return err
 }
+
+func (r *workHandler) recordSeriesPoint(ctx context.Context, seriesID string, recordTime time.Time, graphQLRepoID, repoName string, capture *string, matchCount int) error {
+	args, err := seriesPointArgs(seriesID, recordTime, graphQLRepoID, repoName, capture, matchCount)
+	if err != nil {
+		return err
+	}
+	if err := r.insightsStore.RecordSeriesPoint(ctx, args); err != nil {
+		return errors.Wrap(err, "RecordSeriesPoint")
+	}
+	return nil
+}
+
+// seriesPointArgs returns the arguments to record a data point of the given repository.
+func seriesPointArgs(seriesID string, recordTime time.Time, graphQLRepoID, repoName string, capture *string, matchCount int) (store.RecordSeriesPointArgs, error) {
+	dbRepoID, err := graphqlbackend.UnmarshalRepositoryID(graphql.ID(graphQLRepoID))
+	if err != nil {
+		return store.RecordSeriesPointArgs{}, errors.Wrap(err, "UnmarshalRepositoryID")
+	}
+	if len(repoName) == 0 {
+		// this really should never happen, expect if for some reason the gql response is broken
+		return store.RecordSeriesPointArgs{}, errors.Newf("MissingRepositoryName for repo_id: %v", string(dbRepoID))
+	}
+	return store.RecordSeriesPointArgs{
+		SeriesID: seriesID,
+		Point: store.SeriesPoint{
+			Time:    recordTime,
+			Value:   float64(matchCount),
+			Capture: capture,
+		},
+		RepoName: &repoName,
+		RepoID:   &dbRepoID,
+	}, nil
+}
@@ -33,7 +33,7 @@ import (
 
 // NewWorker returns a worker that will execute search queries and insert information about the
 // results into the code insights database.
-func NewWorker(ctx context.Context, workerBaseStore *basestore.Store, insightsStore *store.Store, metrics workerutil.WorkerMetrics) *workerutil.Worker {
+func NewWorker(ctx context.Context, workerBaseStore *basestore.Store, insightsStore *store.Store, metadataStore store.DataSeriesStore, metrics workerutil.WorkerMetrics) *workerutil.Worker {
 	workerStore := createDBWorkerStore(workerBaseStore)
 
 	numHandlers := conf.Get().InsightsQueryWorkerConcurrency
@@ -63,6 +63,7 @@ func NewWorker(ctx context.Context, workerBaseStore *basestore.Store, insightsSt
 	return dbworker.NewWorker(ctx, workerStore, &workHandler{
 		workerBaseStore: workerBaseStore,
 		insightsStore:   insightsStore,
+		metadataStore:   metadataStore,
 		limiter:         limiter,
 	}, options)
 }
@@ -129,6 +130,8 @@ func EnqueueJob(ctx context.Context, workerBaseStore *basestore.Store, job *Job)
 			job.ProcessAfter,
 			job.Cost,
 			job.Priority,
+			job.RepositoryName,
+			job.Revision,
 		),
 	))
 	return
@@ -143,8 +146,10 @@ INSERT INTO insights_query_runner_jobs (
 	state,
 	process_after,
 	cost,
-	priority
-) VALUES (%s, %s, %s, %s, %s, %s, %s)
+	priority,
+	repository_name,
+	revision
+) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING id
 `
 
@@ -171,6 +176,8 @@ SELECT
 	record_time,
 	cost,
 	priority,
+	repository_name,
+	revision,
 	id,
 	state,
 	failure_message,
@@ -233,6 +240,12 @@ type Job struct {
 	Cost        int
 	Priority    int
 
+	// RepositoryName and Revision are only set for jobs of language statistics series, which
+	// compute the language statistics of a single repository instead of running SearchQuery. A nil
+	// Revision means the head of the default branch.
+	RepositoryName *string
+	Revision       *string
+
 	// Standard/required dbworker fields. If enqueuing a job, these may all be zero values except State.
 	//
 	// See https://sourcegraph.com/github.com/sourcegraph/sourcegraph@cd0b3904c674ee3568eb2ef5d7953395b6432d20/-/blob/internal/workerutil/dbworker/store/store.go#L114-134
@@ -276,6 +289,8 @@ func doScanJobs(rows *sql.Rows, err error) ([]*Job, error) {
 			&j.RecordTime,
 			&j.Cost,
 			&j.Priority,
+			&j.RepositoryName,
+			&j.Revision,
 
 			// Standard/required dbworker fields.
 			&j.ID,
@@ -308,6 +323,8 @@ var jobsColumns = []*sqlf.Query{
 	sqlf.Sprintf("insights_query_runner_jobs.record_time"),
 	sqlf.Sprintf("insights_query_runner_jobs.cost"),
 	sqlf.Sprintf("insights_query_runner_jobs.priority"),
+	sqlf.Sprintf("insights_query_runner_jobs.repository_name"),
+	sqlf.Sprintf("insights_query_runner_jobs.revision"),
 	sqlf.Sprintf("id"),
 	sqlf.Sprintf("state"),
 	sqlf.Sprintf("failure_message"),
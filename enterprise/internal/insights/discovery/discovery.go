@@ -8,6 +8,7 @@ import (
 
 	"github.com/inconshreveable/log15"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/background/queryrunner"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
@@ -201,9 +202,32 @@ func migrateSeries(ctx context.Context, insightStore *store.InsightStore, from i
 
 	for i, timeSeries := range from.Series {
 		temp := types.InsightSeries{
-			SeriesID:              Encode(timeSeries),
-			Query:                 timeSeries.Query,
-			RecordingIntervalDays: 1,
+			SeriesID:                   Encode(timeSeries),
+			Query:                      timeSeries.Query,
+			RecordingIntervalDays:      1,
+			GeneratedFromCaptureGroups: timeSeries.GeneratedFromCaptureGroups,
+			SeriesType:                 types.SearchSeries,
+		}
+		if timeSeries.LanguageStats {
+			if len(from.Repositories) == 0 {
+				return errors.Errorf("unable to migrate insight unique_id: %s language statistics series require repositories", from.ID)
+			}
+			temp = types.InsightSeries{
+				SeriesID:              EncodeLanguageStats(from.Repositories),
+				RecordingIntervalDays: 1,
+				SeriesType:            types.LanguageStatsSeries,
+				Repositories:          from.Repositories,
+			}
+		} else if timeSeries.CommitAggregation != "" {
+			aggregation := types.CommitAggregation(timeSeries.CommitAggregation)
+			if !aggregation.Valid() {
+				return errors.Errorf("unable to migrate insight unique_id: %s invalid commit aggregation %q", from.ID, timeSeries.CommitAggregation)
+			}
+			if err := queryrunner.ValidateCommitQuery(timeSeries.Query); err != nil {
+				return errors.Wrapf(err, "unable to migrate insight unique_id: %s", from.ID)
+			}
+			temp.SeriesType = types.CommitSeries
+			temp.CommitAggregation = aggregation
 		}
 		result, err := tx.CreateSeries(ctx, temp)
 		if err != nil {
@@ -3,6 +3,8 @@ package discovery
 import (
 	"crypto/sha256"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 
@@ -29,10 +31,27 @@ func EncodeSeriesID(series *schema.InsightSeries) (string, error) {
 	}
 }
 
+// Encode returns the unique series ID of the given series. Series generated from capture groups
+// and commit series get a different prefix, since their data is recorded differently from a
+// regular search series with the same query.
 func Encode(series insights.TimeSeries) string {
+	if series.CommitAggregation != "" {
+		return fmt.Sprintf("k:%s:%s", series.CommitAggregation, sha256String(series.Query))
+	}
+	if series.GeneratedFromCaptureGroups {
+		return fmt.Sprintf("c:%s", sha256String(series.Query))
+	}
 	return fmt.Sprintf("s:%s", sha256String(series.Query))
 }
 
+// EncodeLanguageStats returns the unique series ID of a language statistics series of the given
+// repositories. The order of the repositories doesn't matter.
+func EncodeLanguageStats(repositories []string) string {
+	sorted := append([]string(nil), repositories...)
+	sort.Strings(sorted)
+	return fmt.Sprintf("l:%s", sha256String(strings.Join(sorted, "\n")))
+}
+
 func sha256String(s string) string {
 	return fmt.Sprintf("%X", sha256.Sum256([]byte(s)))
 }
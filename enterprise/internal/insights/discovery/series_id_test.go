@@ -6,6 +6,7 @@ import (
 
 	"github.com/hexops/autogold"
 
+	"github.com/sourcegraph/sourcegraph/internal/insights"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -40,3 +41,27 @@ func TestEncodeSeriesID(t *testing.T) {
 		})
 	}
 }
+
+func TestEncode(t *testing.T) {
+	query := `go\s1\.(\d+) file:go.mod`
+
+	search := Encode(insights.TimeSeries{Query: query})
+	captureGroups := Encode(insights.TimeSeries{Query: query, GeneratedFromCaptureGroups: true})
+	commits := Encode(insights.TimeSeries{Query: query, CommitAggregation: "commits"})
+	authors := Encode(insights.TimeSeries{Query: query, CommitAggregation: "authors"})
+
+	autogold.Want("search", "s:E8BC3FF7E5D7C69956251372F5F78D52AB00299AE49941364ACAEC21ADE3E9F9").Equal(t, search)
+	autogold.Want("capture_groups", "c:E8BC3FF7E5D7C69956251372F5F78D52AB00299AE49941364ACAEC21ADE3E9F9").Equal(t, captureGroups)
+	autogold.Want("commits", "k:commits:E8BC3FF7E5D7C69956251372F5F78D52AB00299AE49941364ACAEC21ADE3E9F9").Equal(t, commits)
+	autogold.Want("authors", "k:authors:E8BC3FF7E5D7C69956251372F5F78D52AB00299AE49941364ACAEC21ADE3E9F9").Equal(t, authors)
+}
+
+func TestEncodeLanguageStats(t *testing.T) {
+	got := EncodeLanguageStats([]string{"github.com/sourcegraph/sourcegraph", "github.com/sourcegraph/about"})
+	reordered := EncodeLanguageStats([]string{"github.com/sourcegraph/about", "github.com/sourcegraph/sourcegraph"})
+
+	autogold.Want("language_stats", "l:92ADA14B9340729D4C65996CE5F872A01448D0488DC329FA1E283E3404F2BB4F").Equal(t, got)
+	if got != reordered {
+		t.Fatalf("expected series ID to not depend on the order of repositories, got %q and %q", got, reordered)
+	}
+}
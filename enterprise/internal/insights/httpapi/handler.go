@@ -0,0 +1,329 @@
+// Package httpapi implements the HTTP endpoints used to export the data of code insights, and to
+// import it into another Sourcegraph instance.
+package httpapi
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+// maxImportSize is the maximum size of the request body of an import.
+const maxImportSize = 512 * 1024 * 1024
+
+type handler struct {
+	insightsStore        store.Interface
+	insightMetadataStore store.InsightMetadataStore
+
+	// checkSiteAdmin returns an error if the current user is not a site admin.
+	checkSiteAdmin func(ctx context.Context) error
+
+	// repoID returns the ID of the repository with the given name, or false if it doesn't exist.
+	repoID func(ctx context.Context, name string) (api.RepoID, bool, error)
+}
+
+// NewHandler returns the HTTP handler of the insights data endpoints, whose stores use the given
+// Timescale and Postgres DBs:
+//
+// GET ?id=<insight ID>&format=<json|csv> exports all data points of an insight, including the
+// repository and series they were recorded for.
+//
+// POST imports the data points of a JSON export into series that have no data yet. Only site
+// admins can import data.
+func NewHandler(timescale, postgres dbutil.DB) http.Handler {
+	repos := database.Repos(postgres)
+	return &handler{
+		insightsStore:        store.New(timescale, store.NewInsightPermissionStore(postgres)),
+		insightMetadataStore: store.NewInsightStore(timescale),
+		checkSiteAdmin: func(ctx context.Context) error {
+			return backend.CheckCurrentUserIsSiteAdmin(ctx, postgres)
+		},
+		repoID: func(ctx context.Context, name string) (api.RepoID, bool, error) {
+			repo, err := repos.GetByName(ctx, api.RepoName(name))
+			if err != nil {
+				if errcode.IsNotFound(err) {
+					return 0, false, nil
+				}
+				return 0, false, err
+			}
+			return repo.ID, true, nil
+		},
+	}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// 🚨 SECURITY: Only authenticated users can export data, the data points of repositories they
+	// cannot access are excluded by the store.
+	if !actor.FromContext(r.Context()).IsAuthenticated() {
+		http.Error(w, backend.ErrNotAuthenticated.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.serveExport(w, r)
+	case http.MethodPost:
+		h.serveImport(w, r)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// Export is the JSON representation of the data of an insight, which can be imported again.
+type Export struct {
+	InsightID string         `json:"insightId"`
+	Title     string         `json:"title"`
+	Series    []ExportSeries `json:"series"`
+}
+
+// ExportSeries is the JSON representation of the data of a single insight series.
+type ExportSeries struct {
+	SeriesID string        `json:"seriesId"`
+	Label    string        `json:"label"`
+	Points   []ExportPoint `json:"points"`
+}
+
+// ExportPoint is the JSON representation of a single recorded data point.
+type ExportPoint struct {
+	Time           time.Time `json:"time"`
+	Value          float64   `json:"value"`
+	RepositoryName *string   `json:"repositoryName"`
+	Capture        *string   `json:"capture"`
+}
+
+// GET ?id=<insight ID>&format=<json|csv>
+func (h *handler) serveExport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "id is required", http.StatusBadRequest)
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" {
+		http.Error(w, fmt.Sprintf("unsupported format %q, expected json or csv", format), http.StatusBadRequest)
+		return
+	}
+
+	export, err := h.export(ctx, id)
+	if err != nil {
+		log15.Error("Failed to export insight data", "insightID", id, "error", err)
+		http.Error(w, fmt.Sprintf("failed to export insight data: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	if export == nil {
+		http.Error(w, fmt.Sprintf("insight %q not found", id), http.StatusNotFound)
+		return
+	}
+
+	if format == "csv" {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", id+".csv"))
+		if err := writeCSV(w, export); err != nil {
+			log15.Error("Failed to write insight data export", "insightID", id, "error", err)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", id+".json"))
+	if err := json.NewEncoder(w).Encode(export); err != nil {
+		log15.Error("Failed to write insight data export", "insightID", id, "error", err)
+	}
+}
+
+// export returns all data points of the insight with the given unique ID, or nil if the insight
+// doesn't exist.
+func (h *handler) export(ctx context.Context, id string) (*Export, error) {
+	insights, err := h.insightMetadataStore.GetMapped(ctx, store.InsightQueryArgs{UniqueID: id})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetMapped")
+	}
+	if len(insights) == 0 {
+		return nil, nil
+	}
+	insight := insights[0]
+
+	export := &Export{
+		InsightID: insight.UniqueID,
+		Title:     insight.Title,
+		Series:    make([]ExportSeries, 0, len(insight.Series)),
+	}
+	for _, series := range insight.Series {
+		points, err := h.insightsStore.ExportSeriesPoints(ctx, series.SeriesID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "ExportSeriesPoints for series %q", series.SeriesID)
+		}
+		exportSeries := ExportSeries{
+			SeriesID: series.SeriesID,
+			Label:    series.Label,
+			Points:   make([]ExportPoint, 0, len(points)),
+		}
+		for _, point := range points {
+			exportSeries.Points = append(exportSeries.Points, ExportPoint{
+				Time:           point.Time,
+				Value:          point.Value,
+				RepositoryName: point.RepoName,
+				Capture:        point.Capture,
+			})
+		}
+		export.Series = append(export.Series, exportSeries)
+	}
+	return export, nil
+}
+
+// writeCSV writes the given export as CSV, with one row per data point.
+func writeCSV(w http.ResponseWriter, export *Export) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"series_id", "series_label", "time", "repository_name", "capture", "value"}); err != nil {
+		return err
+	}
+	for _, series := range export.Series {
+		for _, point := range series.Points {
+			if err := cw.Write([]string{
+				series.SeriesID,
+				series.Label,
+				point.Time.UTC().Format(time.RFC3339),
+				stringOrEmpty(point.RepositoryName),
+				stringOrEmpty(point.Capture),
+				strconv.FormatFloat(point.Value, 'f', -1, 64),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// ImportResult is the JSON response of an import.
+type ImportResult struct {
+	// ImportedPoints is the number of data points that were imported.
+	ImportedPoints int `json:"importedPoints"`
+
+	// SkippedPoints is the number of data points that were not imported because they were
+	// recorded for a repository that doesn't exist on this instance.
+	SkippedPoints int `json:"skippedPoints"`
+
+	// SkippedSeries are the IDs of the series that were not imported because they already have
+	// data on this instance.
+	SkippedSeries []string `json:"skippedSeries"`
+}
+
+// POST
+func (h *handler) serveImport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 🚨 SECURITY: Imported data is shown to all users with access to its repositories, so only
+	// site admins can import it.
+	if err := h.checkSiteAdmin(ctx); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	var export Export
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxImportSize)).Decode(&export); err != nil {
+		http.Error(w, fmt.Sprintf("invalid insight data export: %s", err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.importData(ctx, &export)
+	if err != nil {
+		log15.Error("Failed to import insight data", "insightID", export.InsightID, "error", err)
+		http.Error(w, fmt.Sprintf("failed to import insight data: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		log15.Error("Failed to write insight data import result", "error", err)
+	}
+}
+
+// importData records the data points of the given export. Series that already have data are
+// skipped entirely so that importing the same export twice doesn't record duplicate points, and
+// points are associated with the repository of the same name on this instance, so that the
+// historical enqueuer doesn't backfill the imported time frames again. The points of each series
+// are recorded in a single transaction, so that a series is either imported entirely or not at
+// all.
+func (h *handler) importData(ctx context.Context, export *Export) (*ImportResult, error) {
+	result := &ImportResult{SkippedSeries: []string{}}
+	repoIDs := map[string]*api.RepoID{}
+
+	for _, series := range export.Series {
+		if series.SeriesID == "" {
+			return nil, errors.New("series without seriesId")
+		}
+		seriesID := series.SeriesID
+		count, err := h.insightsStore.CountData(ctx, store.CountDataOpts{SeriesID: &seriesID})
+		if err != nil {
+			return nil, errors.Wrap(err, "CountData")
+		}
+		if count > 0 {
+			result.SkippedSeries = append(result.SkippedSeries, seriesID)
+			continue
+		}
+
+		points := make([]store.RecordSeriesPointArgs, 0, len(series.Points))
+		for _, point := range series.Points {
+			args := store.RecordSeriesPointArgs{
+				SeriesID: seriesID,
+				Point: store.SeriesPoint{
+					Time:    point.Time,
+					Value:   point.Value,
+					Capture: point.Capture,
+				},
+			}
+			if point.RepositoryName != nil {
+				name := *point.RepositoryName
+				repoID, ok := repoIDs[name]
+				if !ok {
+					id, exists, err := h.repoID(ctx, name)
+					if err != nil {
+						return nil, errors.Wrapf(err, "looking up repository %q", name)
+					}
+					if exists {
+						repoID = &id
+					}
+					repoIDs[name] = repoID
+				}
+				if repoID == nil {
+					result.SkippedPoints++
+					continue
+				}
+				args.RepoName = &name
+				args.RepoID = repoID
+			}
+			points = append(points, args)
+		}
+		if err := h.insightsStore.RecordSeriesPoints(ctx, points); err != nil {
+			return nil, errors.Wrapf(err, "RecordSeriesPoints for series %q", seriesID)
+		}
+		result.ImportedPoints += len(points)
+	}
+	return result, nil
+}
@@ -0,0 +1,200 @@
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestHandler(t *testing.T) {
+	str := func(v string) *string { return &v }
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	setup := func(siteAdmin bool) (*handler, *store.MockInterface) {
+		insightsStore := store.NewMockInterface()
+		insightsStore.ExportSeriesPointsFunc.SetDefaultHook(func(ctx context.Context, seriesID string) ([]store.ExportedSeriesPoint, error) {
+			return []store.ExportedSeriesPoint{
+				{SeriesID: seriesID, Time: now, Value: 3, RepoName: str("github.com/a/b")},
+				{SeriesID: seriesID, Time: now, Value: 1.5, RepoName: str("github.com/a/c"), Capture: str("Go")},
+			}, nil
+		})
+		insightsStore.CountDataFunc.SetDefaultHook(func(ctx context.Context, opts store.CountDataOpts) (int, error) {
+			if *opts.SeriesID == "existing" {
+				return 1, nil
+			}
+			return 0, nil
+		})
+
+		metadataStore := store.NewMockInsightMetadataStore()
+		metadataStore.GetMappedFunc.SetDefaultHook(func(ctx context.Context, args store.InsightQueryArgs) ([]types.Insight, error) {
+			if args.UniqueID != "insight1" {
+				return nil, nil
+			}
+			return []types.Insight{{
+				UniqueID: "insight1",
+				Title:    "Insight",
+				Series:   []types.InsightViewSeries{{SeriesID: "series1", Label: "Series"}},
+			}}, nil
+		})
+
+		return &handler{
+			insightsStore:        insightsStore,
+			insightMetadataStore: metadataStore,
+			checkSiteAdmin: func(ctx context.Context) error {
+				if !siteAdmin {
+					return errors.New("must be site admin")
+				}
+				return nil
+			},
+			repoID: func(ctx context.Context, name string) (api.RepoID, bool, error) {
+				if name == "github.com/a/b" {
+					return 42, true, nil
+				}
+				return 0, false, nil
+			},
+		}, insightsStore
+	}
+
+	serve := func(h *handler, req *http.Request, authenticated bool) *httptest.ResponseRecorder {
+		if authenticated {
+			req = req.WithContext(actor.WithActor(req.Context(), actor.FromUser(1)))
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		h, _ := setup(false)
+		w := serve(h, httptest.NewRequest("GET", "/?id=insight1", nil), false)
+		if w.Code != http.StatusUnauthorized {
+			t.Fatalf("unexpected status %d", w.Code)
+		}
+	})
+
+	t.Run("export unknown insight", func(t *testing.T) {
+		h, _ := setup(false)
+		w := serve(h, httptest.NewRequest("GET", "/?id=unknown", nil), true)
+		if w.Code != http.StatusNotFound {
+			t.Fatalf("unexpected status %d", w.Code)
+		}
+	})
+
+	t.Run("export json", func(t *testing.T) {
+		h, _ := setup(false)
+		w := serve(h, httptest.NewRequest("GET", "/?id=insight1", nil), true)
+		if w.Code != http.StatusOK {
+			t.Fatalf("unexpected status %d: %s", w.Code, w.Body.String())
+		}
+		var have Export
+		if err := json.Unmarshal(w.Body.Bytes(), &have); err != nil {
+			t.Fatal(err)
+		}
+		want := Export{
+			InsightID: "insight1",
+			Title:     "Insight",
+			Series: []ExportSeries{{
+				SeriesID: "series1",
+				Label:    "Series",
+				Points: []ExportPoint{
+					{Time: now, Value: 3, RepositoryName: str("github.com/a/b")},
+					{Time: now, Value: 1.5, RepositoryName: str("github.com/a/c"), Capture: str("Go")},
+				},
+			}},
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatalf("unexpected export (-want +have):\n%s", diff)
+		}
+	})
+
+	t.Run("export csv", func(t *testing.T) {
+		h, _ := setup(false)
+		w := serve(h, httptest.NewRequest("GET", "/?id=insight1&format=csv", nil), true)
+		want := "series_id,series_label,time,repository_name,capture,value\n" +
+			"series1,Series,2021-06-01T00:00:00Z,github.com/a/b,,3\n" +
+			"series1,Series,2021-06-01T00:00:00Z,github.com/a/c,Go,1.5\n"
+		if have := w.Body.String(); have != want {
+			t.Fatalf("unexpected csv (-want +have):\n%s", cmp.Diff(want, have))
+		}
+	})
+
+	export := Export{
+		InsightID: "insight1",
+		Series: []ExportSeries{
+			{SeriesID: "series1", Points: []ExportPoint{
+				{Time: now, Value: 3, RepositoryName: str("github.com/a/b")},
+				{Time: now, Value: 2, RepositoryName: str("github.com/a/unknown")},
+				{Time: now, Value: 7},
+			}},
+			{SeriesID: "existing", Points: []ExportPoint{{Time: now, Value: 1}}},
+		},
+	}
+	body, err := json.Marshal(export)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("import requires site admin", func(t *testing.T) {
+		h, insightsStore := setup(false)
+		w := serve(h, httptest.NewRequest("POST", "/", bytes.NewReader(body)), true)
+		if w.Code != http.StatusForbidden {
+			t.Fatalf("unexpected status %d", w.Code)
+		}
+		if len(insightsStore.RecordSeriesPointsFunc.History()) != 0 {
+			t.Fatal("expected no points to be recorded")
+		}
+	})
+
+	t.Run("import", func(t *testing.T) {
+		h, insightsStore := setup(true)
+		w := serve(h, httptest.NewRequest("POST", "/", bytes.NewReader(body)), true)
+		if w.Code != http.StatusOK {
+			t.Fatalf("unexpected status %d: %s", w.Code, w.Body.String())
+		}
+		var have ImportResult
+		if err := json.Unmarshal(w.Body.Bytes(), &have); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(ImportResult{ImportedPoints: 2, SkippedPoints: 1, SkippedSeries: []string{"existing"}}, have); diff != "" {
+			t.Fatalf("unexpected result (-want +have):\n%s", diff)
+		}
+
+		// The points of a series are recorded at once.
+		repoID := api.RepoID(42)
+		var recorded [][]store.RecordSeriesPointArgs
+		for _, call := range insightsStore.RecordSeriesPointsFunc.History() {
+			recorded = append(recorded, call.Arg1)
+		}
+		want := [][]store.RecordSeriesPointArgs{{
+			{SeriesID: "series1", Point: store.SeriesPoint{Time: now, Value: 3}, RepoName: str("github.com/a/b"), RepoID: &repoID},
+			{SeriesID: "series1", Point: store.SeriesPoint{Time: now, Value: 7}},
+		}}
+		if diff := cmp.Diff(want, recorded); diff != "" {
+			t.Fatalf("unexpected recorded points (-want +have):\n%s", diff)
+		}
+	})
+
+	t.Run("import fails", func(t *testing.T) {
+		h, insightsStore := setup(true)
+		insightsStore.RecordSeriesPointsFunc.SetDefaultReturn(errors.New("connection reset"))
+		w := serve(h, httptest.NewRequest("POST", "/", bytes.NewReader(body)), true)
+		if w.Code != http.StatusInternalServerError {
+			t.Fatalf("unexpected status %d", w.Code)
+		}
+		if len(insightsStore.RecordSeriesPointFunc.History()) != 0 {
+			t.Fatal("expected no points to be recorded one by one")
+		}
+	})
+}
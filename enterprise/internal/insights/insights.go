@@ -4,12 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"log"
+	"net/http"
 	"os"
 	"strconv"
 
 	"github.com/cockroachdb/errors"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/httpapi"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/resolvers"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbconn"
@@ -35,14 +37,18 @@ func IsEnabled() bool {
 	return true
 }
 
-// Init initializes the given enterpriseServices to include the required resolvers for insights.
+// Init initializes the given enterpriseServices to include the required resolvers and HTTP
+// handlers for insights.
 func Init(ctx context.Context, postgres dbutil.DB, outOfBandMigrationRunner *oobmigration.Runner, enterpriseServices *enterprise.Services) error {
 	if !IsEnabled() {
+		reason := "code insights has been disabled"
 		if conf.IsDeployTypeSingleDockerContainer(conf.DeployType()) {
-			enterpriseServices.InsightsResolver = resolvers.NewDisabledResolver("backend-run code insights are not available on single-container deployments")
-		} else {
-			enterpriseServices.InsightsResolver = resolvers.NewDisabledResolver("code insights has been disabled")
+			reason = "backend-run code insights are not available on single-container deployments"
 		}
+		enterpriseServices.InsightsResolver = resolvers.NewDisabledResolver(reason)
+		enterpriseServices.InsightsDataHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, reason, http.StatusNotFound)
+		})
 		return nil
 	}
 	timescale, err := InitializeCodeInsightsDB("frontend")
@@ -50,6 +56,7 @@ func Init(ctx context.Context, postgres dbutil.DB, outOfBandMigrationRunner *oob
 		return err
 	}
 	enterpriseServices.InsightsResolver = resolvers.New(timescale, postgres)
+	enterpriseServices.InsightsDataHandler = httpapi.NewHandler(timescale, postgres)
 	return nil
 }
 
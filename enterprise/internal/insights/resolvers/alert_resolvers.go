@@ -0,0 +1,207 @@
+package resolvers
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+const insightSeriesAlertKind = "InsightSeriesAlert"
+
+// defaultAlertWindowDays is the number of days percentage conditions compare against if the
+// input does not specify windowDays.
+const defaultAlertWindowDays = 7
+
+// maxAlertHistory is the maximum number of events returned by the history of an alert.
+const maxAlertHistory = 1000
+
+func (r *Resolver) InsightSeriesAlerts(ctx context.Context, args *graphqlbackend.InsightSeriesAlertsArgs) ([]graphqlbackend.InsightSeriesAlertResolver, error) {
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() {
+		return nil, backend.ErrNotAuthenticated
+	}
+
+	listArgs := store.ListAlertsArgs{UserID: a.UID}
+	if args.SeriesID != nil {
+		listArgs.SeriesID = *args.SeriesID
+	}
+	alerts, err := r.alertStore.ListAlerts(ctx, listArgs)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]graphqlbackend.InsightSeriesAlertResolver, 0, len(alerts))
+	for _, alert := range alerts {
+		resolvers = append(resolvers, &insightSeriesAlertResolver{alertStore: r.alertStore, alert: alert})
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) CreateInsightSeriesAlert(ctx context.Context, args *graphqlbackend.CreateInsightSeriesAlertArgs) (graphqlbackend.InsightSeriesAlertResolver, error) {
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() {
+		return nil, backend.ErrNotAuthenticated
+	}
+
+	alert, err := alertFromInput(args.Input)
+	if err != nil {
+		return nil, err
+	}
+	alert.UserID = a.UID
+
+	series, err := r.dataSeriesStore.GetDataSeries(ctx, store.GetDataSeriesArgs{SeriesID: alert.SeriesID})
+	if err != nil {
+		return nil, err
+	}
+	if len(series) == 0 {
+		return nil, errors.Errorf("insight series %q not found", alert.SeriesID)
+	}
+
+	alert, err = r.alertStore.CreateAlert(ctx, alert)
+	if err != nil {
+		return nil, err
+	}
+	return &insightSeriesAlertResolver{alertStore: r.alertStore, alert: alert}, nil
+}
+
+func (r *Resolver) DeleteInsightSeriesAlert(ctx context.Context, args *graphqlbackend.DeleteInsightSeriesAlertArgs) (*graphqlbackend.EmptyResponse, error) {
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() {
+		return nil, backend.ErrNotAuthenticated
+	}
+
+	var id int
+	if err := relay.UnmarshalSpec(args.ID, &id); err != nil {
+		return nil, err
+	}
+	alert, err := r.alertStore.GetAlert(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: Only the user who created an alert can delete it. Alerts of other users are
+	// reported as not found so that their existence is not revealed.
+	if alert == nil || alert.UserID != a.UID {
+		return nil, errors.Errorf("insight series alert %q not found", args.ID)
+	}
+
+	if err := r.alertStore.DeleteAlert(ctx, id); err != nil {
+		return nil, err
+	}
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+// alertFromInput validates the given input and returns the alert it describes.
+func alertFromInput(input graphqlbackend.CreateInsightSeriesAlertInput) (types.InsightSeriesAlert, error) {
+	alert := types.InsightSeriesAlert{
+		SeriesID:     input.SeriesID,
+		Capture:      input.Capture,
+		Condition:    types.AlertCondition(strings.ToLower(input.Condition)),
+		Threshold:    input.Threshold,
+		WindowDays:   defaultAlertWindowDays,
+		EmailEnabled: true,
+		WebhookURL:   input.WebhookURL,
+	}
+	if input.WindowDays != nil {
+		alert.WindowDays = int(*input.WindowDays)
+	}
+	if input.Email != nil {
+		alert.EmailEnabled = *input.Email
+	}
+
+	if !alert.Condition.Valid() {
+		return types.InsightSeriesAlert{}, errors.Errorf("invalid alert condition %q", input.Condition)
+	}
+	if alert.Condition.Percentage() && alert.Threshold <= 0 {
+		return types.InsightSeriesAlert{}, errors.New("threshold must be a positive percentage")
+	}
+	if alert.WindowDays <= 0 {
+		return types.InsightSeriesAlert{}, errors.New("windowDays must be positive")
+	}
+	if !alert.EmailEnabled && alert.WebhookURL == nil {
+		return types.InsightSeriesAlert{}, errors.New("an alert must send an email or call a webhook")
+	}
+	if alert.WebhookURL != nil {
+		u, err := url.Parse(*alert.WebhookURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return types.InsightSeriesAlert{}, errors.Errorf("invalid webhook URL %q", *alert.WebhookURL)
+		}
+		// 🚨 SECURITY: Webhooks must not be used to reach internal services. Host names are
+		// checked again when the webhook is called, after they have been resolved.
+		host := u.Hostname()
+		if ip := net.ParseIP(host); strings.EqualFold(host, "localhost") || (ip != nil && !httpcli.IsPublicIP(ip)) {
+			return types.InsightSeriesAlert{}, errors.Errorf("webhook URL %q must not point to an internal address", *alert.WebhookURL)
+		}
+	}
+	return alert, nil
+}
+
+var _ graphqlbackend.InsightSeriesAlertResolver = &insightSeriesAlertResolver{}
+
+type insightSeriesAlertResolver struct {
+	alertStore store.SeriesAlertStore
+	alert      types.InsightSeriesAlert
+}
+
+func (r *insightSeriesAlertResolver) ID() graphql.ID {
+	return relay.MarshalID(insightSeriesAlertKind, r.alert.ID)
+}
+
+func (r *insightSeriesAlertResolver) SeriesID() string { return r.alert.SeriesID }
+func (r *insightSeriesAlertResolver) Capture() *string { return r.alert.Capture }
+
+func (r *insightSeriesAlertResolver) Condition() string {
+	return strings.ToUpper(string(r.alert.Condition))
+}
+
+func (r *insightSeriesAlertResolver) Threshold() float64  { return r.alert.Threshold }
+func (r *insightSeriesAlertResolver) WindowDays() int32   { return int32(r.alert.WindowDays) }
+func (r *insightSeriesAlertResolver) Email() bool         { return r.alert.EmailEnabled }
+func (r *insightSeriesAlertResolver) WebhookURL() *string { return r.alert.WebhookURL }
+func (r *insightSeriesAlertResolver) Triggered() bool     { return r.alert.Triggered }
+
+func (r *insightSeriesAlertResolver) LastEvaluatedAt() *graphqlbackend.DateTime {
+	return graphqlbackend.DateTimeOrNil(r.alert.LastEvaluatedAt)
+}
+
+func (r *insightSeriesAlertResolver) History(ctx context.Context, args *graphqlbackend.InsightSeriesAlertHistoryArgs) ([]graphqlbackend.InsightSeriesAlertEventResolver, error) {
+	if args.First < 0 || args.First > maxAlertHistory {
+		return nil, errors.Errorf("first must be between 0 and %d", maxAlertHistory)
+	}
+	if args.First == 0 {
+		return []graphqlbackend.InsightSeriesAlertEventResolver{}, nil
+	}
+
+	events, err := r.alertStore.ListAlertEvents(ctx, r.alert.ID, int(args.First))
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]graphqlbackend.InsightSeriesAlertEventResolver, 0, len(events))
+	for _, event := range events {
+		resolvers = append(resolvers, insightSeriesAlertEventResolver{event})
+	}
+	return resolvers, nil
+}
+
+var _ graphqlbackend.InsightSeriesAlertEventResolver = insightSeriesAlertEventResolver{}
+
+type insightSeriesAlertEventResolver struct{ e types.InsightSeriesAlertEvent }
+
+func (i insightSeriesAlertEventResolver) TriggeredAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: i.e.TriggeredAt}
+}
+
+func (i insightSeriesAlertEventResolver) Value() float64          { return i.e.Value }
+func (i insightSeriesAlertEventResolver) PreviousValue() *float64 { return i.e.PreviousValue }
+func (i insightSeriesAlertEventResolver) EmailError() *string     { return i.e.EmailError }
+func (i insightSeriesAlertEventResolver) WebhookError() *string   { return i.e.WebhookError }
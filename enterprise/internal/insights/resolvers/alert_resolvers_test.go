@@ -0,0 +1,101 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+func TestAlertFromInput(t *testing.T) {
+	str := func(v string) *string { return &v }
+	boolean := func(v bool) *bool { return &v }
+	int32p := func(v int32) *int32 { return &v }
+
+	for name, tc := range map[string]struct {
+		input   graphqlbackend.CreateInsightSeriesAlertInput
+		wantErr bool
+	}{
+		"above":               {input: graphqlbackend.CreateInsightSeriesAlertInput{SeriesID: "s", Condition: "ABOVE", Threshold: 10}},
+		"percentage":          {input: graphqlbackend.CreateInsightSeriesAlertInput{SeriesID: "s", Condition: "INCREASE_PERCENT", Threshold: 10, WindowDays: int32p(30)}},
+		"webhook only":        {input: graphqlbackend.CreateInsightSeriesAlertInput{SeriesID: "s", Condition: "BELOW", Email: boolean(false), WebhookURL: str("https://example.com/hook")}},
+		"invalid condition":   {input: graphqlbackend.CreateInsightSeriesAlertInput{SeriesID: "s", Condition: "SIDEWAYS"}, wantErr: true},
+		"negative percentage": {input: graphqlbackend.CreateInsightSeriesAlertInput{SeriesID: "s", Condition: "DECREASE_PERCENT", Threshold: -5}, wantErr: true},
+		"zero window":         {input: graphqlbackend.CreateInsightSeriesAlertInput{SeriesID: "s", Condition: "ABOVE", WindowDays: int32p(0)}, wantErr: true},
+		"no notification":     {input: graphqlbackend.CreateInsightSeriesAlertInput{SeriesID: "s", Condition: "ABOVE", Email: boolean(false)}, wantErr: true},
+		"invalid webhook":     {input: graphqlbackend.CreateInsightSeriesAlertInput{SeriesID: "s", Condition: "ABOVE", WebhookURL: str("file:///etc/passwd")}, wantErr: true},
+		"loopback webhook":    {input: graphqlbackend.CreateInsightSeriesAlertInput{SeriesID: "s", Condition: "ABOVE", WebhookURL: str("http://127.0.0.1:3178/hook")}, wantErr: true},
+		"localhost webhook":   {input: graphqlbackend.CreateInsightSeriesAlertInput{SeriesID: "s", Condition: "ABOVE", WebhookURL: str("http://LOCALHOST/hook")}, wantErr: true},
+		"metadata webhook":    {input: graphqlbackend.CreateInsightSeriesAlertInput{SeriesID: "s", Condition: "ABOVE", WebhookURL: str("http://169.254.169.254/latest")}, wantErr: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := alertFromInput(tc.input)
+			if have := err != nil; have != tc.wantErr {
+				t.Fatalf("unexpected error. wantErr=%v, have=%v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestResolver_InsightSeriesAlerts(t *testing.T) {
+	ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+
+	dataSeriesStore := store.NewMockDataSeriesStore()
+	dataSeriesStore.GetDataSeriesFunc.SetDefaultHook(func(ctx context.Context, args store.GetDataSeriesArgs) ([]types.InsightSeries, error) {
+		if args.SeriesID == "series1" {
+			return []types.InsightSeries{{SeriesID: "series1"}}, nil
+		}
+		return nil, nil
+	})
+	alertStore := store.NewMockSeriesAlertStore()
+	alertStore.CreateAlertFunc.SetDefaultHook(func(ctx context.Context, alert types.InsightSeriesAlert) (types.InsightSeriesAlert, error) {
+		alert.ID = 5
+		return alert, nil
+	})
+	alertStore.GetAlertFunc.SetDefaultReturn(&types.InsightSeriesAlert{ID: 5, UserID: 2}, nil)
+	resolver := &Resolver{dataSeriesStore: dataSeriesStore, alertStore: alertStore}
+
+	t.Run("create", func(t *testing.T) {
+		alert, err := resolver.CreateInsightSeriesAlert(ctx, &graphqlbackend.CreateInsightSeriesAlertArgs{
+			Input: graphqlbackend.CreateInsightSeriesAlertInput{SeriesID: "series1", Condition: "ABOVE", Threshold: 50},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if alert.Condition() != "ABOVE" || alert.WindowDays() != 7 || !alert.Email() {
+			t.Fatalf("unexpected alert %+v", alert)
+		}
+		created := alertStore.CreateAlertFunc.History()[0].Arg1
+		if created.UserID != 1 || created.Condition != types.AlertAbove {
+			t.Fatalf("unexpected created alert %+v", created)
+		}
+	})
+
+	t.Run("create for unknown series", func(t *testing.T) {
+		if _, err := resolver.CreateInsightSeriesAlert(ctx, &graphqlbackend.CreateInsightSeriesAlertArgs{
+			Input: graphqlbackend.CreateInsightSeriesAlertInput{SeriesID: "unknown", Condition: "ABOVE"},
+		}); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		if _, err := resolver.InsightSeriesAlerts(context.Background(), &graphqlbackend.InsightSeriesAlertsArgs{}); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("delete alert of other user", func(t *testing.T) {
+		if _, err := resolver.DeleteInsightSeriesAlert(ctx, &graphqlbackend.DeleteInsightSeriesAlertArgs{ID: relay.MarshalID(insightSeriesAlertKind, 5)}); err == nil {
+			t.Fatal("expected error")
+		}
+		if len(alertStore.DeleteAlertFunc.History()) != 0 {
+			t.Fatal("expected alert not to be deleted")
+		}
+	})
+}
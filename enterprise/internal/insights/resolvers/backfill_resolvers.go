@@ -0,0 +1,122 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/background/queryrunner"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+)
+
+func (r *Resolver) PauseInsightSeriesBackfill(ctx context.Context, args *graphqlbackend.InsightSeriesBackfillArgs) (graphqlbackend.InsightSeriesBackfillResolver, error) {
+	if err := r.checkBackfillSeries(ctx, args.SeriesID); err != nil {
+		return nil, err
+	}
+
+	// Stop the historical enqueuer from enqueueing new jobs before pausing the queued ones.
+	if err := r.dataSeriesStore.SetBackfillState(ctx, args.SeriesID, types.BackfillPaused); err != nil {
+		return nil, err
+	}
+	if _, err := queryrunner.PauseBackfillJobs(ctx, r.workerBaseStore, args.SeriesID); err != nil {
+		return nil, err
+	}
+	return newBackfillResolver(ctx, r.workerBaseStore, args.SeriesID, types.BackfillPaused)
+}
+
+func (r *Resolver) ResumeInsightSeriesBackfill(ctx context.Context, args *graphqlbackend.InsightSeriesBackfillArgs) (graphqlbackend.InsightSeriesBackfillResolver, error) {
+	if err := r.checkBackfillSeries(ctx, args.SeriesID); err != nil {
+		return nil, err
+	}
+
+	// Resume the paused jobs before the historical enqueuer enqueues new jobs, so that it doesn't
+	// enqueue jobs for the same time frames again.
+	if _, err := queryrunner.ResumeBackfillJobs(ctx, r.workerBaseStore, args.SeriesID); err != nil {
+		return nil, err
+	}
+	if err := r.dataSeriesStore.SetBackfillState(ctx, args.SeriesID, types.BackfillActive); err != nil {
+		return nil, err
+	}
+	return newBackfillResolver(ctx, r.workerBaseStore, args.SeriesID, types.BackfillActive)
+}
+
+func (r *Resolver) CancelInsightSeriesBackfill(ctx context.Context, args *graphqlbackend.InsightSeriesBackfillArgs) (graphqlbackend.InsightSeriesBackfillResolver, error) {
+	if err := r.checkBackfillSeries(ctx, args.SeriesID); err != nil {
+		return nil, err
+	}
+
+	// Stop the historical enqueuer from enqueueing new jobs before deleting the queued ones.
+	if err := r.dataSeriesStore.SetBackfillState(ctx, args.SeriesID, types.BackfillCanceled); err != nil {
+		return nil, err
+	}
+	if _, err := queryrunner.CancelBackfillJobs(ctx, r.workerBaseStore, args.SeriesID); err != nil {
+		return nil, err
+	}
+	return newBackfillResolver(ctx, r.workerBaseStore, args.SeriesID, types.BackfillCanceled)
+}
+
+// checkBackfillSeries returns an error if the current user is not allowed to control the backfill
+// of the series with the given series ID, or if the series doesn't exist.
+func (r *Resolver) checkBackfillSeries(ctx context.Context, seriesID string) error {
+	// 🚨 SECURITY: Only site admins may control backfills, since they affect the load of the
+	// whole instance.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.workerBaseStore.Handle().DB()); err != nil {
+		return err
+	}
+
+	series, err := r.dataSeriesStore.GetDataSeries(ctx, store.GetDataSeriesArgs{SeriesID: seriesID})
+	if err != nil {
+		return err
+	}
+	if len(series) == 0 {
+		return errors.Errorf("insight series %q not found", seriesID)
+	}
+	return nil
+}
+
+func newBackfillResolver(ctx context.Context, workerBaseStore *basestore.Store, seriesID string, state types.BackfillState) (*insightSeriesBackfillResolver, error) {
+	now := time.Now()
+	status, err := queryrunner.QueryBackfillStatus(ctx, workerBaseStore, seriesID, now)
+	if err != nil {
+		return nil, err
+	}
+	return &insightSeriesBackfillResolver{seriesID: seriesID, state: state, status: *status, now: now}, nil
+}
+
+var _ graphqlbackend.InsightSeriesBackfillResolver = &insightSeriesBackfillResolver{}
+
+type insightSeriesBackfillResolver struct {
+	seriesID string
+	state    types.BackfillState
+	status   queryrunner.BackfillStatus
+	now      time.Time
+}
+
+func (r *insightSeriesBackfillResolver) SeriesID() string { return r.seriesID }
+
+func (r *insightSeriesBackfillResolver) State() string {
+	if r.state == "" {
+		return strings.ToUpper(string(types.BackfillActive))
+	}
+	return strings.ToUpper(string(r.state))
+}
+
+// Include errored because they'll be retried before becoming failures.
+func (r *insightSeriesBackfillResolver) QueuedJobs() int32 {
+	return int32(r.status.Queued + r.status.Errored)
+}
+
+func (r *insightSeriesBackfillResolver) ProcessingJobs() int32 { return int32(r.status.Processing) }
+func (r *insightSeriesBackfillResolver) CompletedJobs() int32  { return int32(r.status.Completed) }
+func (r *insightSeriesBackfillResolver) FailedJobs() int32     { return int32(r.status.Failed) }
+func (r *insightSeriesBackfillResolver) PausedJobs() int32     { return int32(r.status.Paused) }
+
+func (r *insightSeriesBackfillResolver) EstimatedCompletion() *graphqlbackend.DateTime {
+	return graphqlbackend.DateTimeOrNil(r.status.EstimatedCompletion(r.now))
+}
@@ -0,0 +1,41 @@
+package resolvers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/background/queryrunner"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+)
+
+func TestInsightSeriesBackfillResolver(t *testing.T) {
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("defaults to active", func(t *testing.T) {
+		r := &insightSeriesBackfillResolver{seriesID: "s", now: now}
+		if have, want := r.State(), "ACTIVE"; have != want {
+			t.Fatalf("unexpected state. want=%q have=%q", want, have)
+		}
+		if r.EstimatedCompletion() != nil {
+			t.Fatal("expected no estimated completion without any jobs")
+		}
+	})
+
+	t.Run("counts", func(t *testing.T) {
+		r := &insightSeriesBackfillResolver{
+			seriesID: "s",
+			state:    types.BackfillPaused,
+			status:   queryrunner.BackfillStatus{Queued: 3, Errored: 2, Processing: 1, Completed: 4, Failed: 5, Paused: 6},
+			now:      now,
+		}
+		if have, want := r.State(), "PAUSED"; have != want {
+			t.Fatalf("unexpected state. want=%q have=%q", want, have)
+		}
+		if have, want := r.QueuedJobs(), int32(5); have != want {
+			t.Fatalf("unexpected queued jobs. want=%d have=%d", want, have)
+		}
+		if have, want := r.PausedJobs(), int32(6); have != want {
+			t.Fatalf("unexpected paused jobs. want=%d have=%d", want, have)
+		}
+	})
+}
@@ -92,15 +92,47 @@ func (r *insightResolver) Title() string { return r.insight.Title }
 
 func (r *insightResolver) Description() string { return r.insight.Description }
 
-func (r *insightResolver) Series() []graphqlbackend.InsightSeriesResolver {
+func (r *insightResolver) Series(ctx context.Context) ([]graphqlbackend.InsightSeriesResolver, error) {
 	series := r.insight.Series
 	resolvers := make([]graphqlbackend.InsightSeriesResolver, 0, len(series))
 	for _, series := range series {
+		if series.GeneratedFromCaptureGroups || series.SeriesType == types.LanguageStatsSeries {
+			captureResolvers, err := r.captureGroupSeries(ctx, series)
+			if err != nil {
+				return nil, err
+			}
+			resolvers = append(resolvers, captureResolvers...)
+			continue
+		}
 		resolvers = append(resolvers, &insightSeriesResolver{
 			insightsStore:   r.insightsStore,
 			workerBaseStore: r.workerBaseStore,
 			series:          series,
 		})
 	}
-	return resolvers
+	return resolvers, nil
+}
+
+// captureGroupSeries returns one series resolver per distinct capture group value recorded for
+// the given series generated from capture groups, sorted by value. Language statistics series are
+// split the same way, since their points record the language as the capture.
+func (r *insightResolver) captureGroupSeries(ctx context.Context, series types.InsightViewSeries) ([]graphqlbackend.InsightSeriesResolver, error) {
+	// The values are taken from the points the user can see, so that values that are only found
+	// in repositories the user doesn't have access to are not exposed.
+	captures, err := r.insightsStore.SeriesCaptures(ctx, series.SeriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.InsightSeriesResolver, 0, len(captures))
+	for _, capture := range captures {
+		capture := capture
+		resolvers = append(resolvers, &insightSeriesResolver{
+			insightsStore:   r.insightsStore,
+			workerBaseStore: r.workerBaseStore,
+			series:          series,
+			capture:         &capture,
+		})
+	}
+	return resolvers, nil
 }
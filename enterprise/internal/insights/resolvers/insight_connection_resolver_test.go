@@ -116,7 +116,11 @@ func TestResolver_InsightConnection(t *testing.T) {
 			"description": nodes[0].Description(),
 		})
 		// TODO(slimsag): put series length into map (autogold bug, omits the field for some reason?)
-		autogold.Want("first insight: series length", int(1)).Equal(t, len(nodes[0].Series()))
+		series, err := nodes[0].Series(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		autogold.Want("first insight: series length", int(1)).Equal(t, len(series))
 	})
 }
 
@@ -273,7 +277,10 @@ func resolvePoints(ctx context.Context, conn graphqlbackend.InsightConnectionRes
 	}
 
 	expected := nodes[0]
-	seriesResolvers := expected.Series()
+	seriesResolvers, err := expected.Series(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
 	if len(seriesResolvers) != 1 {
 		t.Errorf("unexpected length of series resolvers: want: %v got: %v", 1, len(seriesResolvers))
 	}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/cockroachdb/errors"
+
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
@@ -18,9 +20,20 @@ type insightSeriesResolver struct {
 	insightsStore   store.Interface
 	workerBaseStore *basestore.Store
 	series          types.InsightViewSeries
+
+	// capture is the capture group value this resolver represents, if the series is generated
+	// from capture groups.
+	capture *string
 }
 
-func (r *insightSeriesResolver) Label() string { return r.series.Label }
+func (r *insightSeriesResolver) SeriesID() string { return r.series.SeriesID }
+
+func (r *insightSeriesResolver) Label() string {
+	if r.capture != nil {
+		return *r.capture
+	}
+	return r.series.Label
+}
 
 func (r *insightSeriesResolver) Points(ctx context.Context, args *graphqlbackend.InsightsPointsArgs) ([]graphqlbackend.InsightsDataPointResolver, error) {
 	var opts store.SeriesPointsOpts
@@ -28,6 +41,8 @@ func (r *insightSeriesResolver) Points(ctx context.Context, args *graphqlbackend
 	// Query data points only for the series we are representing.
 	seriesID := r.series.SeriesID
 	opts.SeriesID = &seriesID
+	opts.Capture = r.capture
+	opts.PerInterval = r.series.SeriesType == types.CommitSeries
 
 	if args.From == nil {
 		// Default to last 6mo of data.
@@ -58,6 +73,41 @@ func (r *insightSeriesResolver) Points(ctx context.Context, args *graphqlbackend
 	return resolvers, nil
 }
 
+// maxRepositoryBreakdown is the maximum number of repositories returned by RepositoryBreakdown.
+const maxRepositoryBreakdown = 1000
+
+func (r *insightSeriesResolver) RepositoryBreakdown(ctx context.Context, args *graphqlbackend.InsightsRepositoryBreakdownArgs) ([]graphqlbackend.InsightRepositoryDataPointResolver, error) {
+	if args.First < 0 || args.First > maxRepositoryBreakdown {
+		return nil, errors.Errorf("first must be between 0 and %d", maxRepositoryBreakdown)
+	}
+	if args.First == 0 {
+		return []graphqlbackend.InsightRepositoryDataPointResolver{}, nil
+	}
+
+	opts := store.RepoSeriesPointsOpts{
+		SeriesID: r.series.SeriesID,
+		Time:     args.DateTime.Time,
+		Capture:  r.capture,
+		Limit:    int(args.First),
+	}
+	if args.IncludeRepoRegex != nil {
+		opts.IncludeRepoRegex = *args.IncludeRepoRegex
+	}
+	if args.ExcludeRepoRegex != nil {
+		opts.ExcludeRepoRegex = *args.ExcludeRepoRegex
+	}
+
+	points, err := r.insightsStore.RepoSeriesPoints(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]graphqlbackend.InsightRepositoryDataPointResolver, 0, len(points))
+	for _, point := range points {
+		resolvers = append(resolvers, insightRepositoryDataPointResolver{point})
+	}
+	return resolvers, nil
+}
+
 func (r *insightSeriesResolver) Status(ctx context.Context) (graphqlbackend.InsightStatusResolver, error) {
 	seriesID := r.series.SeriesID
 
@@ -84,6 +134,10 @@ func (r *insightSeriesResolver) Status(ctx context.Context) (graphqlbackend.Insi
 	}, nil
 }
 
+func (r *insightSeriesResolver) Backfill(ctx context.Context) (graphqlbackend.InsightSeriesBackfillResolver, error) {
+	return newBackfillResolver(ctx, r.workerBaseStore, r.series.SeriesID, r.series.BackfillState)
+}
+
 var _ graphqlbackend.InsightsDataPointResolver = insightsDataPointResolver{}
 
 type insightsDataPointResolver struct{ p store.SeriesPoint }
@@ -94,6 +148,18 @@ func (i insightsDataPointResolver) DateTime() graphqlbackend.DateTime {
 
 func (i insightsDataPointResolver) Value() float64 { return i.p.Value }
 
+var _ graphqlbackend.InsightRepositoryDataPointResolver = insightRepositoryDataPointResolver{}
+
+type insightRepositoryDataPointResolver struct{ p store.RepoSeriesPoint }
+
+func (i insightRepositoryDataPointResolver) RepositoryName() string { return i.p.RepoName }
+
+func (i insightRepositoryDataPointResolver) DateTime() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: i.p.Time}
+}
+
+func (i insightRepositoryDataPointResolver) Value() float64 { return i.p.Value }
+
 type insightStatusResolver struct {
 	totalPoints, pendingJobs, completedJobs, failedJobs int32
 }
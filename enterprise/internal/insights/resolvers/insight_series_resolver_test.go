@@ -78,7 +78,12 @@ func TestResolver_InsightSeries(t *testing.T) {
 		}
 		var series [][]graphqlbackend.InsightSeriesResolver
 		for _, node := range nodes {
-			series = append(series, node.Series())
+			nodeSeries, err := node.Series(ctx)
+			if err != nil {
+				cleanup()
+				t.Fatal(err)
+			}
+			series = append(series, nodeSeries)
 		}
 		return ctx, series, mockStore, cleanup
 	}
@@ -110,7 +115,7 @@ func TestResolver_InsightSeries(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
-			autogold.Want("insights[0][0].Points store opts", `{"SeriesID":"1234567","RepoID":null,"Excluded":null,"Included":null,"IncludeRepoRegex":"","ExcludeRepoRegex":"","From":"2006-01-02T15:04:05Z","To":"2006-01-03T15:04:05Z","Limit":0}`).Equal(t, string(json))
+			autogold.Want("insights[0][0].Points store opts", `{"SeriesID":"1234567","RepoID":null,"Excluded":null,"Included":null,"IncludeRepoRegex":"","ExcludeRepoRegex":"","From":"2006-01-02T15:04:05Z","To":"2006-01-03T15:04:05Z","Limit":0,"Capture":null,"PerInterval":false}`).Equal(t, string(json))
 			return []store.SeriesPoint{
 				{Time: args.From.Time, Value: 1},
 				{Time: args.From.Time, Value: 2},
@@ -121,6 +126,45 @@ func TestResolver_InsightSeries(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
-		autogold.Want("insights[0][0].Points mocked", "[{p:{SeriesID: Time:{wall:0 ext:63271811045 loc:<nil>} Value:1 Metadata:[]}} {p:{SeriesID: Time:{wall:0 ext:63271811045 loc:<nil>} Value:2 Metadata:[]}} {p:{SeriesID: Time:{wall:0 ext:63271811045 loc:<nil>} Value:3 Metadata:[]}}]").Equal(t, fmt.Sprintf("%+v", points))
+		autogold.Want("insights[0][0].Points mocked", "[{p:{SeriesID: Time:{wall:0 ext:63271811045 loc:<nil>} Value:1 Metadata:[] Capture:<nil>}} {p:{SeriesID: Time:{wall:0 ext:63271811045 loc:<nil>} Value:2 Metadata:[] Capture:<nil>}} {p:{SeriesID: Time:{wall:0 ext:63271811045 loc:<nil>} Value:3 Metadata:[] Capture:<nil>}}]").Equal(t, fmt.Sprintf("%+v", points))
+	})
+
+	t.Run("RepositoryBreakdown", func(t *testing.T) {
+		ctx, insights, mock, cleanup := testSetup(t)
+		defer cleanup()
+
+		dateTime, _ := time.Parse(time.RFC3339, "2006-01-02T15:04:05Z")
+		includeRepoRegex := "github.com/sourcegraph/"
+		args := &graphqlbackend.InsightsRepositoryBreakdownArgs{
+			DateTime:         graphqlbackend.DateTime{Time: dateTime},
+			First:            2,
+			IncludeRepoRegex: &includeRepoRegex,
+		}
+
+		mock.RepoSeriesPointsFunc.SetDefaultHook(func(ctx context.Context, opts store.RepoSeriesPointsOpts) ([]store.RepoSeriesPoint, error) {
+			json, err := json.Marshal(opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			autogold.Want("insights[0][0].RepositoryBreakdown store opts", `{"SeriesID":"1234567","Time":"2006-01-02T15:04:05Z","Capture":null,"Excluded":null,"Included":null,"IncludeRepoRegex":"github.com/sourcegraph/","ExcludeRepoRegex":"","Limit":2}`).Equal(t, string(json))
+			return []store.RepoSeriesPoint{
+				{RepoID: 1, RepoName: "github.com/sourcegraph/sourcegraph", Time: dateTime, Value: 5},
+				{RepoID: 2, RepoName: "github.com/sourcegraph/src-cli", Time: dateTime, Value: 3},
+			}, nil
+		})
+		breakdown, err := insights[0][0].RepositoryBreakdown(ctx, args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, r := range breakdown {
+			got = append(got, fmt.Sprintf("%s=%v", r.RepositoryName(), r.Value()))
+		}
+		autogold.Want("insights[0][0].RepositoryBreakdown mocked", []string{"github.com/sourcegraph/sourcegraph=5", "github.com/sourcegraph/src-cli=3"}).Equal(t, got)
+
+		args.First = -1
+		if _, err := insights[0][0].RepositoryBreakdown(ctx, args); err == nil {
+			t.Fatal("expected error for negative first")
+		}
 	})
 }
@@ -21,6 +21,8 @@ type Resolver struct {
 	insightsStore        store.Interface
 	workerBaseStore      *basestore.Store
 	insightMetadataStore store.InsightMetadataStore
+	dataSeriesStore      store.DataSeriesStore
+	alertStore           store.SeriesAlertStore
 }
 
 // New returns a new Resolver whose store uses the given Timescale and Postgres DBs.
@@ -31,10 +33,13 @@ func New(timescale, postgres dbutil.DB) graphqlbackend.InsightsResolver {
 // newWithClock returns a new Resolver whose store uses the given Timescale and Postgres DBs, and the given
 // clock for timestamps.
 func newWithClock(timescale, postgres dbutil.DB, clock func() time.Time) *Resolver {
+	insightStore := store.NewInsightStore(timescale)
 	return &Resolver{
 		insightsStore:        store.NewWithClock(timescale, store.NewInsightPermissionStore(postgres), clock),
 		workerBaseStore:      basestore.NewWithDB(postgres, sql.TxOptions{}),
-		insightMetadataStore: store.NewInsightStore(timescale),
+		insightMetadataStore: insightStore,
+		dataSeriesStore:      insightStore,
+		alertStore:           store.NewAlertStore(timescale),
 	}
 }
 
@@ -65,3 +70,27 @@ func NewDisabledResolver(reason string) graphqlbackend.InsightsResolver {
 func (r *disabledResolver) Insights(ctx context.Context, args *graphqlbackend.InsightsArgs) (graphqlbackend.InsightConnectionResolver, error) {
 	return nil, errors.New(r.reason)
 }
+
+func (r *disabledResolver) InsightSeriesAlerts(ctx context.Context, args *graphqlbackend.InsightSeriesAlertsArgs) ([]graphqlbackend.InsightSeriesAlertResolver, error) {
+	return nil, errors.New(r.reason)
+}
+
+func (r *disabledResolver) CreateInsightSeriesAlert(ctx context.Context, args *graphqlbackend.CreateInsightSeriesAlertArgs) (graphqlbackend.InsightSeriesAlertResolver, error) {
+	return nil, errors.New(r.reason)
+}
+
+func (r *disabledResolver) DeleteInsightSeriesAlert(ctx context.Context, args *graphqlbackend.DeleteInsightSeriesAlertArgs) (*graphqlbackend.EmptyResponse, error) {
+	return nil, errors.New(r.reason)
+}
+
+func (r *disabledResolver) PauseInsightSeriesBackfill(ctx context.Context, args *graphqlbackend.InsightSeriesBackfillArgs) (graphqlbackend.InsightSeriesBackfillResolver, error) {
+	return nil, errors.New(r.reason)
+}
+
+func (r *disabledResolver) ResumeInsightSeriesBackfill(ctx context.Context, args *graphqlbackend.InsightSeriesBackfillArgs) (graphqlbackend.InsightSeriesBackfillResolver, error) {
+	return nil, errors.New(r.reason)
+}
+
+func (r *disabledResolver) CancelInsightSeriesBackfill(ctx context.Context, args *graphqlbackend.InsightSeriesBackfillArgs) (graphqlbackend.InsightSeriesBackfillResolver, error) {
+	return nil, errors.New(r.reason)
+}
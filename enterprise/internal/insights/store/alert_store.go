@@ -0,0 +1,274 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// SeriesAlertStore is the interface describing the storage of insight series alerts and their
+// history. See the AlertStore struct for actual API usage.
+type SeriesAlertStore interface {
+	GetAlert(ctx context.Context, id int) (*types.InsightSeriesAlert, error)
+	ListAlerts(ctx context.Context, args ListAlertsArgs) ([]types.InsightSeriesAlert, error)
+	CreateAlert(ctx context.Context, alert types.InsightSeriesAlert) (types.InsightSeriesAlert, error)
+	DeleteAlert(ctx context.Context, id int) error
+	UpdateAlertEvaluation(ctx context.Context, id int, evaluatedAt time.Time, triggered bool) error
+	TriggerAlert(ctx context.Context, event types.InsightSeriesAlertEvent, evaluatedAt time.Time) (types.InsightSeriesAlertEvent, bool, error)
+	CreateAlertEvent(ctx context.Context, event types.InsightSeriesAlertEvent) (types.InsightSeriesAlertEvent, error)
+	UpdateAlertEventErrors(ctx context.Context, id int, emailError, webhookError *string) error
+	ListAlertEvents(ctx context.Context, alertID int, limit int) ([]types.InsightSeriesAlertEvent, error)
+}
+
+var _ SeriesAlertStore = &AlertStore{}
+
+// AlertStore exposes methods to read and write insight series alerts and their history from
+// the insights Timescale database.
+type AlertStore struct {
+	*basestore.Store
+	Now func() time.Time
+}
+
+// NewAlertStore returns a new AlertStore backed by the given Timescale db.
+func NewAlertStore(db dbutil.DB) *AlertStore {
+	return &AlertStore{Store: basestore.NewWithDB(db, sql.TxOptions{}), Now: time.Now}
+}
+
+// Handle returns the underlying transactable database handle.
+// Needed to implement the ShareableStore interface.
+func (s *AlertStore) Handle() *basestore.TransactableHandle { return s.Store.Handle() }
+
+// ListAlertsArgs describes options for listing insight series alerts.
+type ListAlertsArgs struct {
+	// SeriesID will filter for the alerts of the series with the given unique series ID, if non-empty.
+	SeriesID string
+	// UserID will filter for the alerts of the given user, if non-zero.
+	UserID int32
+}
+
+// GetAlert returns the alert with the given ID, or nil if it doesn't exist.
+func (s *AlertStore) GetAlert(ctx context.Context, id int) (*types.InsightSeriesAlert, error) {
+	alerts, err := scanAlerts(s.Query(ctx, sqlf.Sprintf(listAlertsSql, sqlf.Sprintf("id = %s", id))))
+	if err != nil || len(alerts) == 0 {
+		return nil, err
+	}
+	return &alerts[0], nil
+}
+
+// ListAlerts returns the alerts matching the given args, ordered by ID.
+func (s *AlertStore) ListAlerts(ctx context.Context, args ListAlertsArgs) ([]types.InsightSeriesAlert, error) {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if args.SeriesID != "" {
+		preds = append(preds, sqlf.Sprintf("series_id = %s", args.SeriesID))
+	}
+	if args.UserID != 0 {
+		preds = append(preds, sqlf.Sprintf("user_id = %s", args.UserID))
+	}
+	return scanAlerts(s.Query(ctx, sqlf.Sprintf(listAlertsSql, sqlf.Join(preds, "\n AND"))))
+}
+
+// CreateAlert will create a new alert for an existing insight data series.
+func (s *AlertStore) CreateAlert(ctx context.Context, alert types.InsightSeriesAlert) (types.InsightSeriesAlert, error) {
+	if alert.CreatedAt.IsZero() {
+		alert.CreatedAt = s.Now()
+	}
+	row := s.QueryRow(ctx, sqlf.Sprintf(createAlertSql,
+		alert.SeriesID,
+		alert.Capture,
+		alert.Condition,
+		alert.Threshold,
+		alert.WindowDays,
+		alert.UserID,
+		alert.EmailEnabled,
+		alert.WebhookURL,
+		alert.CreatedAt,
+	))
+	if err := row.Scan(&alert.ID); err != nil {
+		return types.InsightSeriesAlert{}, err
+	}
+	return alert, nil
+}
+
+// DeleteAlert deletes the alert with the given ID and its history.
+func (s *AlertStore) DeleteAlert(ctx context.Context, id int) error {
+	return s.Exec(ctx, sqlf.Sprintf(deleteAlertSql, id))
+}
+
+// UpdateAlertEvaluation records that the alert with the given ID was evaluated at the given time,
+// and whether its condition held.
+func (s *AlertStore) UpdateAlertEvaluation(ctx context.Context, id int, evaluatedAt time.Time, triggered bool) error {
+	return s.Exec(ctx, sqlf.Sprintf(updateAlertEvaluationSql, evaluatedAt, triggered, id))
+}
+
+// TriggerAlert records that the alert of the given event was evaluated at the given time and its
+// condition started to hold, and records the event, in a single transaction. If the alert is
+// already triggered (e.g. because it was evaluated concurrently), nothing is recorded and false is
+// returned, so that the caller sends the notifications of the event only once.
+func (s *AlertStore) TriggerAlert(ctx context.Context, event types.InsightSeriesAlertEvent, evaluatedAt time.Time) (_ types.InsightSeriesAlertEvent, _ bool, err error) {
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return types.InsightSeriesAlertEvent{}, false, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	_, ok, err := basestore.ScanFirstInt(tx.Query(ctx, sqlf.Sprintf(triggerAlertSql, evaluatedAt, event.AlertID)))
+	if err != nil || !ok {
+		return types.InsightSeriesAlertEvent{}, false, err
+	}
+	event, err = (&AlertStore{Store: tx, Now: s.Now}).CreateAlertEvent(ctx, event)
+	if err != nil {
+		return types.InsightSeriesAlertEvent{}, false, err
+	}
+	return event, true, nil
+}
+
+// CreateAlertEvent records that an alert triggered.
+func (s *AlertStore) CreateAlertEvent(ctx context.Context, event types.InsightSeriesAlertEvent) (types.InsightSeriesAlertEvent, error) {
+	if event.TriggeredAt.IsZero() {
+		event.TriggeredAt = s.Now()
+	}
+	row := s.QueryRow(ctx, sqlf.Sprintf(createAlertEventSql,
+		event.AlertID,
+		event.Value,
+		event.PreviousValue,
+		event.TriggeredAt,
+		event.EmailError,
+		event.WebhookError,
+	))
+	if err := row.Scan(&event.ID); err != nil {
+		return types.InsightSeriesAlertEvent{}, err
+	}
+	return event, nil
+}
+
+// UpdateAlertEventErrors records the errors that occurred delivering the notifications of the
+// alert event with the given ID.
+func (s *AlertStore) UpdateAlertEventErrors(ctx context.Context, id int, emailError, webhookError *string) error {
+	return s.Exec(ctx, sqlf.Sprintf(updateAlertEventErrorsSql, emailError, webhookError, id))
+}
+
+// ListAlertEvents returns the history of the alert with the given ID, most recent first. If limit
+// is non-zero, at most limit events are returned.
+func (s *AlertStore) ListAlertEvents(ctx context.Context, alertID int, limit int) (_ []types.InsightSeriesAlertEvent, err error) {
+	limitClause := sqlf.Sprintf("")
+	if limit > 0 {
+		limitClause = sqlf.Sprintf("LIMIT %s", limit)
+	}
+	rows, err := s.Query(ctx, sqlf.Sprintf(listAlertEventsSql, alertID, limitClause))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	events := make([]types.InsightSeriesAlertEvent, 0)
+	for rows.Next() {
+		var event types.InsightSeriesAlertEvent
+		if err := rows.Scan(
+			&event.ID,
+			&event.AlertID,
+			&event.Value,
+			&event.PreviousValue,
+			&event.TriggeredAt,
+			&event.EmailError,
+			&event.WebhookError,
+		); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func scanAlerts(rows *sql.Rows, queryErr error) (_ []types.InsightSeriesAlert, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	results := make([]types.InsightSeriesAlert, 0)
+	for rows.Next() {
+		var temp types.InsightSeriesAlert
+		if err := rows.Scan(
+			&temp.ID,
+			&temp.SeriesID,
+			&temp.Capture,
+			&temp.Condition,
+			&temp.Threshold,
+			&temp.WindowDays,
+			&temp.UserID,
+			&temp.EmailEnabled,
+			&temp.WebhookURL,
+			&temp.CreatedAt,
+			&temp.LastEvaluatedAt,
+			&temp.Triggered,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, temp)
+	}
+	return results, nil
+}
+
+const listAlertsSql = `
+-- source: enterprise/internal/insights/store/alert_store.go:ListAlerts
+SELECT id, series_id, capture, condition, threshold, window_days, user_id, email_enabled, webhook_url,
+       created_at, last_evaluated_at, triggered
+FROM insight_series_alerts
+WHERE %s
+ORDER BY id
+`
+
+const createAlertSql = `
+-- source: enterprise/internal/insights/store/alert_store.go:CreateAlert
+INSERT INTO insight_series_alerts (series_id, capture, condition, threshold, window_days, user_id,
+                                   email_enabled, webhook_url, created_at)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING id;`
+
+const deleteAlertSql = `
+-- source: enterprise/internal/insights/store/alert_store.go:DeleteAlert
+DELETE FROM insight_series_alerts WHERE id = %s
+`
+
+const updateAlertEvaluationSql = `
+-- source: enterprise/internal/insights/store/alert_store.go:UpdateAlertEvaluation
+UPDATE insight_series_alerts
+SET last_evaluated_at = %s, triggered = %s
+WHERE id = %s
+`
+
+const triggerAlertSql = `
+-- source: enterprise/internal/insights/store/alert_store.go:TriggerAlert
+UPDATE insight_series_alerts
+SET last_evaluated_at = %s, triggered = TRUE
+WHERE id = %s AND NOT triggered
+RETURNING id
+`
+
+const createAlertEventSql = `
+-- source: enterprise/internal/insights/store/alert_store.go:CreateAlertEvent
+INSERT INTO insight_series_alert_events (alert_id, value, previous_value, triggered_at, email_error, webhook_error)
+VALUES (%s, %s, %s, %s, %s, %s)
+RETURNING id;`
+
+const updateAlertEventErrorsSql = `
+-- source: enterprise/internal/insights/store/alert_store.go:UpdateAlertEventErrors
+UPDATE insight_series_alert_events
+SET email_error = %s, webhook_error = %s
+WHERE id = %s
+`
+
+const listAlertEventsSql = `
+-- source: enterprise/internal/insights/store/alert_store.go:ListAlertEvents
+SELECT id, alert_id, value, previous_value, triggered_at, email_error, webhook_error
+FROM insight_series_alert_events
+WHERE alert_id = %s
+ORDER BY triggered_at DESC, id DESC
+%s
+`
@@ -0,0 +1,165 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+
+	insightsdbtesting "github.com/sourcegraph/sourcegraph/enterprise/internal/insights/dbtesting"
+)
+
+func TestAlertStore(t *testing.T) {
+	timescale, cleanup := insightsdbtesting.TimescaleDB(t)
+	defer cleanup()
+	now := time.Now().Round(0).Truncate(time.Microsecond)
+	ctx := context.Background()
+
+	insightStore := NewInsightStore(timescale)
+	insightStore.Now = func() time.Time { return now }
+	if _, err := insightStore.CreateSeries(ctx, types.InsightSeries{
+		SeriesID:              "series-1",
+		Query:                 "query-1",
+		RecordingIntervalDays: 1,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewAlertStore(timescale)
+	store.Now = func() time.Time { return now }
+
+	webhookURL := "https://example.com/hook"
+	alert, err := store.CreateAlert(ctx, types.InsightSeriesAlert{
+		SeriesID:     "series-1",
+		Condition:    types.AlertAbove,
+		Threshold:    50,
+		WindowDays:   7,
+		UserID:       1,
+		EmailEnabled: true,
+		WebhookURL:   &webhookURL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("list", func(t *testing.T) {
+		want := []types.InsightSeriesAlert{alert}
+		got, err := store.ListAlerts(ctx, ListAlertsArgs{SeriesID: "series-1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("unexpected alerts (want/got): %s", diff)
+		}
+
+		got, err = store.ListAlerts(ctx, ListAlertsArgs{UserID: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 0 {
+			t.Errorf("expected no alerts for other user, got %d", len(got))
+		}
+	})
+
+	t.Run("update evaluation", func(t *testing.T) {
+		if err := store.UpdateAlertEvaluation(ctx, alert.ID, now, true); err != nil {
+			t.Fatal(err)
+		}
+		got, err := store.GetAlert(ctx, alert.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := alert
+		want.LastEvaluatedAt = &now
+		want.Triggered = true
+		if diff := cmp.Diff(&want, got); diff != "" {
+			t.Errorf("unexpected alert (want/got): %s", diff)
+		}
+	})
+
+	t.Run("events", func(t *testing.T) {
+		emailError := "no verified email"
+		first, err := store.CreateAlertEvent(ctx, types.InsightSeriesAlertEvent{AlertID: alert.ID, Value: 51, TriggeredAt: now.Add(-time.Hour), EmailError: &emailError})
+		if err != nil {
+			t.Fatal(err)
+		}
+		second, err := store.CreateAlertEvent(ctx, types.InsightSeriesAlertEvent{AlertID: alert.ID, Value: 60})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []types.InsightSeriesAlertEvent{second, first}
+		got, err := store.ListAlertEvents(ctx, alert.ID, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("unexpected alert events (want/got): %s", diff)
+		}
+
+		got, err = store.ListAlertEvents(ctx, alert.ID, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(want[:1], got); diff != "" {
+			t.Errorf("unexpected limited alert events (want/got): %s", diff)
+		}
+	})
+
+	t.Run("trigger", func(t *testing.T) {
+		if err := store.UpdateAlertEvaluation(ctx, alert.ID, now, false); err != nil {
+			t.Fatal(err)
+		}
+		later := now.Add(time.Hour)
+		event, ok, err := store.TriggerAlert(ctx, types.InsightSeriesAlertEvent{AlertID: alert.ID, Value: 70, TriggeredAt: later}, later)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			t.Fatal("expected alert to be triggered")
+		}
+		if _, ok, err := store.TriggerAlert(ctx, types.InsightSeriesAlertEvent{AlertID: alert.ID, Value: 70, TriggeredAt: later}, later); err != nil || ok {
+			t.Fatalf("expected an already triggered alert not to be triggered again, got ok=%v err=%v", ok, err)
+		}
+
+		got, err := store.GetAlert(ctx, alert.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := alert
+		want.LastEvaluatedAt = &later
+		want.Triggered = true
+		if diff := cmp.Diff(&want, got); diff != "" {
+			t.Errorf("unexpected alert (want/got): %s", diff)
+		}
+
+		webhookError := "connection refused"
+		if err := store.UpdateAlertEventErrors(ctx, event.ID, nil, &webhookError); err != nil {
+			t.Fatal(err)
+		}
+		event.WebhookError = &webhookError
+		events, err := store.ListAlertEvents(ctx, alert.ID, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]types.InsightSeriesAlertEvent{event}, events); diff != "" {
+			t.Errorf("unexpected alert events (want/got): %s", diff)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		if err := store.DeleteAlert(ctx, alert.ID); err != nil {
+			t.Fatal(err)
+		}
+		got, err := store.GetAlert(ctx, alert.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != nil {
+			t.Errorf("expected alert to be deleted, got %+v", got)
+		}
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
 
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
@@ -100,6 +101,8 @@ type GetDataSeriesArgs struct {
 	// NextRecordingBefore will filter for results for which the next_recording_after field falls before the specified time.
 	NextRecordingBefore time.Time
 	Deleted             bool
+	// SeriesID will filter for the series with the given unique series ID, if non-empty.
+	SeriesID string
 }
 
 func (s *InsightStore) GetDataSeries(ctx context.Context, args GetDataSeriesArgs) ([]types.InsightSeries, error) {
@@ -108,6 +111,9 @@ func (s *InsightStore) GetDataSeries(ctx context.Context, args GetDataSeriesArgs
 	if !args.NextRecordingBefore.IsZero() {
 		preds = append(preds, sqlf.Sprintf("next_recording_after < %s", args.NextRecordingBefore))
 	}
+	if args.SeriesID != "" {
+		preds = append(preds, sqlf.Sprintf("series_id = %s", args.SeriesID))
+	}
 	if args.Deleted {
 		preds = append(preds, sqlf.Sprintf("deleted_at IS NOT NULL"))
 	} else {
@@ -139,6 +145,11 @@ func scanDataSeries(rows *sql.Rows, queryErr error) (_ []types.InsightSeries, er
 			&temp.LastRecordedAt,
 			&temp.NextRecordingAfter,
 			&temp.RecordingIntervalDays,
+			&temp.GeneratedFromCaptureGroups,
+			&temp.SeriesType,
+			pq.Array(&temp.Repositories),
+			&temp.BackfillState,
+			&dbutil.NullString{S: (*string)(&temp.CommitAggregation)},
 		); err != nil {
 			return []types.InsightSeries{}, err
 		}
@@ -170,6 +181,11 @@ func scanInsightViewSeries(rows *sql.Rows, queryErr error) (_ []types.InsightVie
 			&temp.LastRecordedAt,
 			&temp.NextRecordingAfter,
 			&temp.RecordingIntervalDays,
+			&temp.GeneratedFromCaptureGroups,
+			&temp.SeriesType,
+			pq.Array(&temp.Repositories),
+			&temp.BackfillState,
+			&dbutil.NullString{S: (*string)(&temp.CommitAggregation)},
 		); err != nil {
 			return []types.InsightViewSeries{}, err
 		}
@@ -216,6 +232,12 @@ func (s *InsightStore) CreateSeries(ctx context.Context, series types.InsightSer
 	if series.NextRecordingAfter.IsZero() {
 		series.NextRecordingAfter = s.Now()
 	}
+	if series.SeriesType == "" {
+		series.SeriesType = types.SearchSeries
+	}
+	if series.BackfillState == "" {
+		series.BackfillState = types.BackfillActive
+	}
 	if series.OldestHistoricalAt.IsZero() {
 		// TODO(insights): this value should probably somewhere more discoverable / obvious than here
 		series.OldestHistoricalAt = s.Now().Add(-time.Hour * 24 * 7 * 26)
@@ -228,6 +250,11 @@ func (s *InsightStore) CreateSeries(ctx context.Context, series types.InsightSer
 		series.LastRecordedAt,
 		series.NextRecordingAfter,
 		series.RecordingIntervalDays,
+		series.GeneratedFromCaptureGroups,
+		series.SeriesType,
+		pq.Array(series.Repositories),
+		series.BackfillState,
+		dbutil.NewNullString(string(series.CommitAggregation)),
 	))
 	var id int
 	err := row.Scan(&id)
@@ -241,6 +268,7 @@ func (s *InsightStore) CreateSeries(ctx context.Context, series types.InsightSer
 type DataSeriesStore interface {
 	GetDataSeries(ctx context.Context, args GetDataSeriesArgs) ([]types.InsightSeries, error)
 	StampRecording(ctx context.Context, series types.InsightSeries) (types.InsightSeries, error)
+	SetBackfillState(ctx context.Context, seriesID string, state types.BackfillState) error
 }
 
 type InsightMetadataStore interface {
@@ -259,6 +287,19 @@ func (s *InsightStore) StampRecording(ctx context.Context, series types.InsightS
 	return series, nil
 }
 
+// SetBackfillState sets whether historical data is backfilled for the series with the given
+// unique series ID.
+func (s *InsightStore) SetBackfillState(ctx context.Context, seriesID string, state types.BackfillState) error {
+	return s.Exec(ctx, sqlf.Sprintf(setBackfillStateSql, state, seriesID))
+}
+
+const setBackfillStateSql = `
+-- source: enterprise/internal/insights/store/insight_store.go:SetBackfillState
+UPDATE insight_series
+SET backfill_state = %s
+WHERE series_id = %s;
+`
+
 const stampRecordingSql = `
 -- source: enterprise/internal/insights/store/insight_store.go:StampRecording
 UPDATE insight_series
@@ -282,15 +323,17 @@ returning id;`
 const createInsightSeriesSql = `
 -- source: enterprise/internal/insights/store/insight_store.go:CreateSeries
 INSERT INTO insight_series (series_id, query, created_at, oldest_historical_at, last_recorded_at,
-                            next_recording_after, recording_interval_days)
-VALUES (%s, %s, %s, %s, %s, %s, %s)
+                            next_recording_after, recording_interval_days, generated_from_capture_groups,
+                            series_type, repositories, backfill_state, commit_aggregation)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING id;`
 
 const getInsightByViewSql = `
 -- source: enterprise/internal/insights/store/insight_store.go:Get
 SELECT iv.unique_id, iv.title, iv.description, ivs.label, ivs.stroke,
 i.series_id, i.query, i.created_at, i.oldest_historical_at, i.last_recorded_at,
-i.next_recording_after, i.recording_interval_days
+i.next_recording_after, i.recording_interval_days, i.generated_from_capture_groups,
+i.series_type, i.repositories, i.backfill_state, i.commit_aggregation
 FROM insight_view iv
          JOIN insight_view_series ivs ON iv.id = ivs.insight_view_id
          JOIN insight_series i ON ivs.insight_series_id = i.id
@@ -300,6 +343,7 @@ ORDER BY iv.unique_id, i.series_id
 
 const getInsightDataSeriesSql = `
 -- source: enterprise/internal/insights/store/insight_store.go:GetDataSeries
-select id, series_id, query, created_at, oldest_historical_at, last_recorded_at, next_recording_after, recording_interval_days from insight_series
+select id, series_id, query, created_at, oldest_historical_at, last_recorded_at, next_recording_after, recording_interval_days, generated_from_capture_groups,
+       series_type, repositories, backfill_state, commit_aggregation from insight_series
 WHERE %s
 `
@@ -63,6 +63,8 @@ func TestGet(t *testing.T) {
 				RecordingIntervalDays: 5,
 				Label:                 "label1",
 				Stroke:                "color1",
+				SeriesType:            types.SearchSeries,
+				BackfillState:         types.BackfillActive,
 			},
 			{
 				UniqueID:              "unique-1",
@@ -77,6 +79,8 @@ func TestGet(t *testing.T) {
 				RecordingIntervalDays: 6,
 				Label:                 "label2",
 				Stroke:                "color2",
+				SeriesType:            types.SearchSeries,
+				BackfillState:         types.BackfillActive,
 			},
 			{
 				UniqueID:              "unique-2",
@@ -91,6 +95,8 @@ func TestGet(t *testing.T) {
 				RecordingIntervalDays: 6,
 				Label:                 "second-label-2",
 				Stroke:                "second-color-2",
+				SeriesType:            types.SearchSeries,
+				BackfillState:         types.BackfillActive,
 			},
 		}
 
@@ -121,6 +127,8 @@ func TestGet(t *testing.T) {
 				RecordingIntervalDays: 5,
 				Label:                 "label1",
 				Stroke:                "color1",
+				SeriesType:            types.SearchSeries,
+				BackfillState:         types.BackfillActive,
 			},
 			{
 				UniqueID:              "unique-1",
@@ -135,6 +143,8 @@ func TestGet(t *testing.T) {
 				RecordingIntervalDays: 6,
 				Label:                 "label2",
 				Stroke:                "color2",
+				SeriesType:            types.SearchSeries,
+				BackfillState:         types.BackfillActive,
 			},
 		}
 
@@ -164,6 +174,8 @@ func TestGet(t *testing.T) {
 				RecordingIntervalDays: 5,
 				Label:                 "label1",
 				Stroke:                "color1",
+				SeriesType:            types.SearchSeries,
+				BackfillState:         types.BackfillActive,
 			},
 			{
 				UniqueID:              "unique-1",
@@ -178,6 +190,8 @@ func TestGet(t *testing.T) {
 				RecordingIntervalDays: 6,
 				Label:                 "label2",
 				Stroke:                "color2",
+				SeriesType:            types.SearchSeries,
+				BackfillState:         types.BackfillActive,
 			},
 		}
 
@@ -224,6 +238,8 @@ func TestCreateSeries(t *testing.T) {
 			NextRecordingAfter:    now,
 			RecordingIntervalDays: 4,
 			CreatedAt:             now,
+			SeriesType:            types.SearchSeries,
+			BackfillState:         types.BackfillActive,
 		}
 
 		log15.Info("values", "want", want, "got", got)
@@ -330,6 +346,8 @@ func TestAttachSeriesView(t *testing.T) {
 			RecordingIntervalDays: series.RecordingIntervalDays,
 			Label:                 "my label",
 			Stroke:                "my stroke",
+			SeriesType:            types.SearchSeries,
+			BackfillState:         types.BackfillActive,
 		}}
 
 		if diff := cmp.Diff(want, got); diff != "" {
@@ -373,6 +391,85 @@ func TestInsightStore_GetDataSeries(t *testing.T) {
 			t.Errorf("mismatched insight data series want/got: %v", diff)
 		}
 	})
+
+	t.Run("test get series by series ID", func(t *testing.T) {
+		series := types.InsightSeries{
+			SeriesID:                   "unique-2",
+			Query:                      `go\s1\.(\d+) file:go.mod`,
+			OldestHistoricalAt:         now.Add(-time.Hour * 24 * 365),
+			LastRecordedAt:             now.Add(-time.Hour * 24 * 365),
+			NextRecordingAfter:         now,
+			RecordingIntervalDays:      4,
+			GeneratedFromCaptureGroups: true,
+		}
+		created, err := store.CreateSeries(ctx, series)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []types.InsightSeries{created}
+
+		got, err := store.GetDataSeries(ctx, GetDataSeriesArgs{SeriesID: "unique-2"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("mismatched insight data series want/got: %v", diff)
+		}
+	})
+
+	t.Run("test create and get language stats series", func(t *testing.T) {
+		series := types.InsightSeries{
+			SeriesID:              "unique-3",
+			OldestHistoricalAt:    now.Add(-time.Hour * 24 * 365),
+			LastRecordedAt:        now.Add(-time.Hour * 24 * 365),
+			NextRecordingAfter:    now,
+			RecordingIntervalDays: 4,
+			SeriesType:            types.LanguageStatsSeries,
+			Repositories:          []string{"github.com/sourcegraph/sourcegraph", "github.com/sourcegraph/about"},
+		}
+		created, err := store.CreateSeries(ctx, series)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []types.InsightSeries{created}
+
+		got, err := store.GetDataSeries(ctx, GetDataSeriesArgs{SeriesID: "unique-3"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("mismatched insight data series want/got: %v", diff)
+		}
+	})
+
+	t.Run("test create and get commit series", func(t *testing.T) {
+		series := types.InsightSeries{
+			SeriesID:              "unique-4",
+			Query:                 "type:commit message:CVE",
+			OldestHistoricalAt:    now.Add(-time.Hour * 24 * 365),
+			LastRecordedAt:        now.Add(-time.Hour * 24 * 365),
+			NextRecordingAfter:    now,
+			RecordingIntervalDays: 4,
+			SeriesType:            types.CommitSeries,
+			CommitAggregation:     types.DistinctAuthors,
+		}
+		created, err := store.CreateSeries(ctx, series)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []types.InsightSeries{created}
+
+		got, err := store.GetDataSeries(ctx, GetDataSeriesArgs{SeriesID: "unique-4"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("mismatched insight data series want/got: %v", diff)
+		}
+	})
 }
 
 func TestInsightStore_StampRecording(t *testing.T) {
@@ -414,3 +511,41 @@ func TestInsightStore_StampRecording(t *testing.T) {
 		}
 	})
 }
+
+func TestInsightStore_SetBackfillState(t *testing.T) {
+	timescale, cleanup := insightsdbtesting.TimescaleDB(t)
+	defer cleanup()
+	now := time.Now().Round(0).Truncate(time.Microsecond)
+	ctx := context.Background()
+
+	store := NewInsightStore(timescale)
+	store.Now = func() time.Time {
+		return now
+	}
+
+	created, err := store.CreateSeries(ctx, types.InsightSeries{
+		SeriesID:              "unique-1",
+		Query:                 "query-1",
+		RecordingIntervalDays: 4,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.BackfillState != types.BackfillActive {
+		t.Fatalf("expected new series to be backfilled, got %q", created.BackfillState)
+	}
+
+	if err := store.SetBackfillState(ctx, "unique-1", types.BackfillPaused); err != nil {
+		t.Fatal(err)
+	}
+	want := created
+	want.BackfillState = types.BackfillPaused
+
+	got, err := store.GetDataSeries(ctx, GetDataSeriesArgs{SeriesID: "unique-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]types.InsightSeries{want}, got); diff != "" {
+		t.Errorf("mismatched insight data series want/got: %v", diff)
+	}
+}
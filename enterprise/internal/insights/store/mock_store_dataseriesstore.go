@@ -17,6 +17,9 @@ type MockDataSeriesStore struct {
 	// GetDataSeriesFunc is an instance of a mock function object
 	// controlling the behavior of the method GetDataSeries.
 	GetDataSeriesFunc *DataSeriesStoreGetDataSeriesFunc
+	// SetBackfillStateFunc is an instance of a mock function object
+	// controlling the behavior of the method SetBackfillState.
+	SetBackfillStateFunc *DataSeriesStoreSetBackfillStateFunc
 	// StampRecordingFunc is an instance of a mock function object
 	// controlling the behavior of the method StampRecording.
 	StampRecordingFunc *DataSeriesStoreStampRecordingFunc
@@ -32,6 +35,11 @@ func NewMockDataSeriesStore() *MockDataSeriesStore {
 				return nil, nil
 			},
 		},
+		SetBackfillStateFunc: &DataSeriesStoreSetBackfillStateFunc{
+			defaultHook: func(context.Context, string, types.BackfillState) error {
+				return nil
+			},
+		},
 		StampRecordingFunc: &DataSeriesStoreStampRecordingFunc{
 			defaultHook: func(context.Context, types.InsightSeries) (types.InsightSeries, error) {
 				return types.InsightSeries{}, nil
@@ -48,6 +56,9 @@ func NewMockDataSeriesStoreFrom(i DataSeriesStore) *MockDataSeriesStore {
 		GetDataSeriesFunc: &DataSeriesStoreGetDataSeriesFunc{
 			defaultHook: i.GetDataSeries,
 		},
+		SetBackfillStateFunc: &DataSeriesStoreSetBackfillStateFunc{
+			defaultHook: i.SetBackfillState,
+		},
 		StampRecordingFunc: &DataSeriesStoreStampRecordingFunc{
 			defaultHook: i.StampRecording,
 		},
@@ -164,6 +175,118 @@ func (c DataSeriesStoreGetDataSeriesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// DataSeriesStoreSetBackfillStateFunc describes the behavior when the
+// SetBackfillState method of the parent MockDataSeriesStore instance is
+// invoked.
+type DataSeriesStoreSetBackfillStateFunc struct {
+	defaultHook func(context.Context, string, types.BackfillState) error
+	hooks       []func(context.Context, string, types.BackfillState) error
+	history     []DataSeriesStoreSetBackfillStateFuncCall
+	mutex       sync.Mutex
+}
+
+// SetBackfillState delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockDataSeriesStore) SetBackfillState(v0 context.Context, v1 string, v2 types.BackfillState) error {
+	r0 := m.SetBackfillStateFunc.nextHook()(v0, v1, v2)
+	m.SetBackfillStateFunc.appendCall(DataSeriesStoreSetBackfillStateFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetBackfillState
+// method of the parent MockDataSeriesStore instance is invoked and the hook
+// queue is empty.
+func (f *DataSeriesStoreSetBackfillStateFunc) SetDefaultHook(hook func(context.Context, string, types.BackfillState) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetBackfillState method of the parent MockDataSeriesStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *DataSeriesStoreSetBackfillStateFunc) PushHook(hook func(context.Context, string, types.BackfillState) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DataSeriesStoreSetBackfillStateFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string, types.BackfillState) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DataSeriesStoreSetBackfillStateFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string, types.BackfillState) error {
+		return r0
+	})
+}
+
+func (f *DataSeriesStoreSetBackfillStateFunc) nextHook() func(context.Context, string, types.BackfillState) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DataSeriesStoreSetBackfillStateFunc) appendCall(r0 DataSeriesStoreSetBackfillStateFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DataSeriesStoreSetBackfillStateFuncCall
+// objects describing the invocations of this function.
+func (f *DataSeriesStoreSetBackfillStateFunc) History() []DataSeriesStoreSetBackfillStateFuncCall {
+	f.mutex.Lock()
+	history := make([]DataSeriesStoreSetBackfillStateFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DataSeriesStoreSetBackfillStateFuncCall is an object that describes an
+// invocation of method SetBackfillState on an instance of
+// MockDataSeriesStore.
+type DataSeriesStoreSetBackfillStateFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 types.BackfillState
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DataSeriesStoreSetBackfillStateFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DataSeriesStoreSetBackfillStateFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // DataSeriesStoreStampRecordingFunc describes the behavior when the
 // StampRecording method of the parent MockDataSeriesStore instance is
 // invoked.
@@ -15,9 +15,21 @@ type MockInterface struct {
 	// CountDataFunc is an instance of a mock function object controlling
 	// the behavior of the method CountData.
 	CountDataFunc *InterfaceCountDataFunc
+	// ExportSeriesPointsFunc is an instance of a mock function object
+	// controlling the behavior of the method ExportSeriesPoints.
+	ExportSeriesPointsFunc *InterfaceExportSeriesPointsFunc
 	// RecordSeriesPointFunc is an instance of a mock function object
 	// controlling the behavior of the method RecordSeriesPoint.
 	RecordSeriesPointFunc *InterfaceRecordSeriesPointFunc
+	// RecordSeriesPointsFunc is an instance of a mock function object
+	// controlling the behavior of the method RecordSeriesPoints.
+	RecordSeriesPointsFunc *InterfaceRecordSeriesPointsFunc
+	// RepoSeriesPointsFunc is an instance of a mock function object
+	// controlling the behavior of the method RepoSeriesPoints.
+	RepoSeriesPointsFunc *InterfaceRepoSeriesPointsFunc
+	// SeriesCapturesFunc is an instance of a mock function object
+	// controlling the behavior of the method SeriesCaptures.
+	SeriesCapturesFunc *InterfaceSeriesCapturesFunc
 	// SeriesPointsFunc is an instance of a mock function object controlling
 	// the behavior of the method SeriesPoints.
 	SeriesPointsFunc *InterfaceSeriesPointsFunc
@@ -32,11 +44,31 @@ func NewMockInterface() *MockInterface {
 				return 0, nil
 			},
 		},
+		ExportSeriesPointsFunc: &InterfaceExportSeriesPointsFunc{
+			defaultHook: func(context.Context, string) ([]ExportedSeriesPoint, error) {
+				return nil, nil
+			},
+		},
 		RecordSeriesPointFunc: &InterfaceRecordSeriesPointFunc{
 			defaultHook: func(context.Context, RecordSeriesPointArgs) error {
 				return nil
 			},
 		},
+		RecordSeriesPointsFunc: &InterfaceRecordSeriesPointsFunc{
+			defaultHook: func(context.Context, []RecordSeriesPointArgs) error {
+				return nil
+			},
+		},
+		RepoSeriesPointsFunc: &InterfaceRepoSeriesPointsFunc{
+			defaultHook: func(context.Context, RepoSeriesPointsOpts) ([]RepoSeriesPoint, error) {
+				return nil, nil
+			},
+		},
+		SeriesCapturesFunc: &InterfaceSeriesCapturesFunc{
+			defaultHook: func(context.Context, string) ([]string, error) {
+				return nil, nil
+			},
+		},
 		SeriesPointsFunc: &InterfaceSeriesPointsFunc{
 			defaultHook: func(context.Context, SeriesPointsOpts) ([]SeriesPoint, error) {
 				return nil, nil
@@ -52,9 +84,21 @@ func NewMockInterfaceFrom(i Interface) *MockInterface {
 		CountDataFunc: &InterfaceCountDataFunc{
 			defaultHook: i.CountData,
 		},
+		ExportSeriesPointsFunc: &InterfaceExportSeriesPointsFunc{
+			defaultHook: i.ExportSeriesPoints,
+		},
 		RecordSeriesPointFunc: &InterfaceRecordSeriesPointFunc{
 			defaultHook: i.RecordSeriesPoint,
 		},
+		RecordSeriesPointsFunc: &InterfaceRecordSeriesPointsFunc{
+			defaultHook: i.RecordSeriesPoints,
+		},
+		RepoSeriesPointsFunc: &InterfaceRepoSeriesPointsFunc{
+			defaultHook: i.RepoSeriesPoints,
+		},
+		SeriesCapturesFunc: &InterfaceSeriesCapturesFunc{
+			defaultHook: i.SeriesCaptures,
+		},
 		SeriesPointsFunc: &InterfaceSeriesPointsFunc{
 			defaultHook: i.SeriesPoints,
 		},
@@ -169,6 +213,116 @@ func (c InterfaceCountDataFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// InterfaceExportSeriesPointsFunc describes the behavior when the
+// ExportSeriesPoints method of the parent MockInterface instance is
+// invoked.
+type InterfaceExportSeriesPointsFunc struct {
+	defaultHook func(context.Context, string) ([]ExportedSeriesPoint, error)
+	hooks       []func(context.Context, string) ([]ExportedSeriesPoint, error)
+	history     []InterfaceExportSeriesPointsFuncCall
+	mutex       sync.Mutex
+}
+
+// ExportSeriesPoints delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockInterface) ExportSeriesPoints(v0 context.Context, v1 string) ([]ExportedSeriesPoint, error) {
+	r0, r1 := m.ExportSeriesPointsFunc.nextHook()(v0, v1)
+	m.ExportSeriesPointsFunc.appendCall(InterfaceExportSeriesPointsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ExportSeriesPoints
+// method of the parent MockInterface instance is invoked and the hook queue
+// is empty.
+func (f *InterfaceExportSeriesPointsFunc) SetDefaultHook(hook func(context.Context, string) ([]ExportedSeriesPoint, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ExportSeriesPoints method of the parent MockInterface instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *InterfaceExportSeriesPointsFunc) PushHook(hook func(context.Context, string) ([]ExportedSeriesPoint, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *InterfaceExportSeriesPointsFunc) SetDefaultReturn(r0 []ExportedSeriesPoint, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) ([]ExportedSeriesPoint, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *InterfaceExportSeriesPointsFunc) PushReturn(r0 []ExportedSeriesPoint, r1 error) {
+	f.PushHook(func(context.Context, string) ([]ExportedSeriesPoint, error) {
+		return r0, r1
+	})
+}
+
+func (f *InterfaceExportSeriesPointsFunc) nextHook() func(context.Context, string) ([]ExportedSeriesPoint, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *InterfaceExportSeriesPointsFunc) appendCall(r0 InterfaceExportSeriesPointsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of InterfaceExportSeriesPointsFuncCall objects
+// describing the invocations of this function.
+func (f *InterfaceExportSeriesPointsFunc) History() []InterfaceExportSeriesPointsFuncCall {
+	f.mutex.Lock()
+	history := make([]InterfaceExportSeriesPointsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// InterfaceExportSeriesPointsFuncCall is an object that describes an
+// invocation of method ExportSeriesPoints on an instance of MockInterface.
+type InterfaceExportSeriesPointsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []ExportedSeriesPoint
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c InterfaceExportSeriesPointsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c InterfaceExportSeriesPointsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // InterfaceRecordSeriesPointFunc describes the behavior when the
 // RecordSeriesPoint method of the parent MockInterface instance is invoked.
 type InterfaceRecordSeriesPointFunc struct {
@@ -275,6 +429,331 @@ func (c InterfaceRecordSeriesPointFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// InterfaceRecordSeriesPointsFunc describes the behavior when the
+// RecordSeriesPoints method of the parent MockInterface instance is
+// invoked.
+type InterfaceRecordSeriesPointsFunc struct {
+	defaultHook func(context.Context, []RecordSeriesPointArgs) error
+	hooks       []func(context.Context, []RecordSeriesPointArgs) error
+	history     []InterfaceRecordSeriesPointsFuncCall
+	mutex       sync.Mutex
+}
+
+// RecordSeriesPoints delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockInterface) RecordSeriesPoints(v0 context.Context, v1 []RecordSeriesPointArgs) error {
+	r0 := m.RecordSeriesPointsFunc.nextHook()(v0, v1)
+	m.RecordSeriesPointsFunc.appendCall(InterfaceRecordSeriesPointsFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the RecordSeriesPoints
+// method of the parent MockInterface instance is invoked and the hook queue
+// is empty.
+func (f *InterfaceRecordSeriesPointsFunc) SetDefaultHook(hook func(context.Context, []RecordSeriesPointArgs) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RecordSeriesPoints method of the parent MockInterface instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *InterfaceRecordSeriesPointsFunc) PushHook(hook func(context.Context, []RecordSeriesPointArgs) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *InterfaceRecordSeriesPointsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, []RecordSeriesPointArgs) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *InterfaceRecordSeriesPointsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, []RecordSeriesPointArgs) error {
+		return r0
+	})
+}
+
+func (f *InterfaceRecordSeriesPointsFunc) nextHook() func(context.Context, []RecordSeriesPointArgs) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *InterfaceRecordSeriesPointsFunc) appendCall(r0 InterfaceRecordSeriesPointsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of InterfaceRecordSeriesPointsFuncCall objects
+// describing the invocations of this function.
+func (f *InterfaceRecordSeriesPointsFunc) History() []InterfaceRecordSeriesPointsFuncCall {
+	f.mutex.Lock()
+	history := make([]InterfaceRecordSeriesPointsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// InterfaceRecordSeriesPointsFuncCall is an object that describes an
+// invocation of method RecordSeriesPoints on an instance of MockInterface.
+type InterfaceRecordSeriesPointsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []RecordSeriesPointArgs
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c InterfaceRecordSeriesPointsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c InterfaceRecordSeriesPointsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// InterfaceRepoSeriesPointsFunc describes the behavior when the
+// RepoSeriesPoints method of the parent MockInterface instance is invoked.
+type InterfaceRepoSeriesPointsFunc struct {
+	defaultHook func(context.Context, RepoSeriesPointsOpts) ([]RepoSeriesPoint, error)
+	hooks       []func(context.Context, RepoSeriesPointsOpts) ([]RepoSeriesPoint, error)
+	history     []InterfaceRepoSeriesPointsFuncCall
+	mutex       sync.Mutex
+}
+
+// RepoSeriesPoints delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockInterface) RepoSeriesPoints(v0 context.Context, v1 RepoSeriesPointsOpts) ([]RepoSeriesPoint, error) {
+	r0, r1 := m.RepoSeriesPointsFunc.nextHook()(v0, v1)
+	m.RepoSeriesPointsFunc.appendCall(InterfaceRepoSeriesPointsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the RepoSeriesPoints
+// method of the parent MockInterface instance is invoked and the hook queue
+// is empty.
+func (f *InterfaceRepoSeriesPointsFunc) SetDefaultHook(hook func(context.Context, RepoSeriesPointsOpts) ([]RepoSeriesPoint, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RepoSeriesPoints method of the parent MockInterface instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *InterfaceRepoSeriesPointsFunc) PushHook(hook func(context.Context, RepoSeriesPointsOpts) ([]RepoSeriesPoint, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *InterfaceRepoSeriesPointsFunc) SetDefaultReturn(r0 []RepoSeriesPoint, r1 error) {
+	f.SetDefaultHook(func(context.Context, RepoSeriesPointsOpts) ([]RepoSeriesPoint, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *InterfaceRepoSeriesPointsFunc) PushReturn(r0 []RepoSeriesPoint, r1 error) {
+	f.PushHook(func(context.Context, RepoSeriesPointsOpts) ([]RepoSeriesPoint, error) {
+		return r0, r1
+	})
+}
+
+func (f *InterfaceRepoSeriesPointsFunc) nextHook() func(context.Context, RepoSeriesPointsOpts) ([]RepoSeriesPoint, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *InterfaceRepoSeriesPointsFunc) appendCall(r0 InterfaceRepoSeriesPointsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of InterfaceRepoSeriesPointsFuncCall objects
+// describing the invocations of this function.
+func (f *InterfaceRepoSeriesPointsFunc) History() []InterfaceRepoSeriesPointsFuncCall {
+	f.mutex.Lock()
+	history := make([]InterfaceRepoSeriesPointsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// InterfaceRepoSeriesPointsFuncCall is an object that describes an
+// invocation of method RepoSeriesPoints on an instance of MockInterface.
+type InterfaceRepoSeriesPointsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 RepoSeriesPointsOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []RepoSeriesPoint
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c InterfaceRepoSeriesPointsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c InterfaceRepoSeriesPointsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// InterfaceSeriesCapturesFunc describes the behavior when the
+// SeriesCaptures method of the parent MockInterface instance is invoked.
+type InterfaceSeriesCapturesFunc struct {
+	defaultHook func(context.Context, string) ([]string, error)
+	hooks       []func(context.Context, string) ([]string, error)
+	history     []InterfaceSeriesCapturesFuncCall
+	mutex       sync.Mutex
+}
+
+// SeriesCaptures delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockInterface) SeriesCaptures(v0 context.Context, v1 string) ([]string, error) {
+	r0, r1 := m.SeriesCapturesFunc.nextHook()(v0, v1)
+	m.SeriesCapturesFunc.appendCall(InterfaceSeriesCapturesFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the SeriesCaptures
+// method of the parent MockInterface instance is invoked and the hook queue
+// is empty.
+func (f *InterfaceSeriesCapturesFunc) SetDefaultHook(hook func(context.Context, string) ([]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SeriesCaptures method of the parent MockInterface instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *InterfaceSeriesCapturesFunc) PushHook(hook func(context.Context, string) ([]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *InterfaceSeriesCapturesFunc) SetDefaultReturn(r0 []string, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) ([]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *InterfaceSeriesCapturesFunc) PushReturn(r0 []string, r1 error) {
+	f.PushHook(func(context.Context, string) ([]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *InterfaceSeriesCapturesFunc) nextHook() func(context.Context, string) ([]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *InterfaceSeriesCapturesFunc) appendCall(r0 InterfaceSeriesCapturesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of InterfaceSeriesCapturesFuncCall objects
+// describing the invocations of this function.
+func (f *InterfaceSeriesCapturesFunc) History() []InterfaceSeriesCapturesFuncCall {
+	f.mutex.Lock()
+	history := make([]InterfaceSeriesCapturesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// InterfaceSeriesCapturesFuncCall is an object that describes an invocation
+// of method SeriesCaptures on an instance of MockInterface.
+type InterfaceSeriesCapturesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c InterfaceSeriesCapturesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c InterfaceSeriesCapturesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // InterfaceSeriesPointsFunc describes the behavior when the SeriesPoints
 // method of the parent MockInterface instance is invoked.
 type InterfaceSeriesPointsFunc struct {
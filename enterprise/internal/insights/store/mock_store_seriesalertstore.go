@@ -0,0 +1,1143 @@
+// Code generated by go-mockgen 1.1.2; DO NOT EDIT.
+
+package store
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	types "github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+)
+
+// MockSeriesAlertStore is a mock implementation of the SeriesAlertStore
+// interface (from the package
+// github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store)
+// used for unit testing.
+type MockSeriesAlertStore struct {
+	// CreateAlertFunc is an instance of a mock function object controlling
+	// the behavior of the method CreateAlert.
+	CreateAlertFunc *SeriesAlertStoreCreateAlertFunc
+	// CreateAlertEventFunc is an instance of a mock function object
+	// controlling the behavior of the method CreateAlertEvent.
+	CreateAlertEventFunc *SeriesAlertStoreCreateAlertEventFunc
+	// DeleteAlertFunc is an instance of a mock function object controlling
+	// the behavior of the method DeleteAlert.
+	DeleteAlertFunc *SeriesAlertStoreDeleteAlertFunc
+	// GetAlertFunc is an instance of a mock function object controlling the
+	// behavior of the method GetAlert.
+	GetAlertFunc *SeriesAlertStoreGetAlertFunc
+	// ListAlertEventsFunc is an instance of a mock function object
+	// controlling the behavior of the method ListAlertEvents.
+	ListAlertEventsFunc *SeriesAlertStoreListAlertEventsFunc
+	// ListAlertsFunc is an instance of a mock function object controlling
+	// the behavior of the method ListAlerts.
+	ListAlertsFunc *SeriesAlertStoreListAlertsFunc
+	// TriggerAlertFunc is an instance of a mock function object controlling
+	// the behavior of the method TriggerAlert.
+	TriggerAlertFunc *SeriesAlertStoreTriggerAlertFunc
+	// UpdateAlertEvaluationFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateAlertEvaluation.
+	UpdateAlertEvaluationFunc *SeriesAlertStoreUpdateAlertEvaluationFunc
+	// UpdateAlertEventErrorsFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateAlertEventErrors.
+	UpdateAlertEventErrorsFunc *SeriesAlertStoreUpdateAlertEventErrorsFunc
+}
+
+// NewMockSeriesAlertStore creates a new mock of the SeriesAlertStore
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockSeriesAlertStore() *MockSeriesAlertStore {
+	return &MockSeriesAlertStore{
+		CreateAlertFunc: &SeriesAlertStoreCreateAlertFunc{
+			defaultHook: func(context.Context, types.InsightSeriesAlert) (types.InsightSeriesAlert, error) {
+				return types.InsightSeriesAlert{}, nil
+			},
+		},
+		CreateAlertEventFunc: &SeriesAlertStoreCreateAlertEventFunc{
+			defaultHook: func(context.Context, types.InsightSeriesAlertEvent) (types.InsightSeriesAlertEvent, error) {
+				return types.InsightSeriesAlertEvent{}, nil
+			},
+		},
+		DeleteAlertFunc: &SeriesAlertStoreDeleteAlertFunc{
+			defaultHook: func(context.Context, int) error {
+				return nil
+			},
+		},
+		GetAlertFunc: &SeriesAlertStoreGetAlertFunc{
+			defaultHook: func(context.Context, int) (*types.InsightSeriesAlert, error) {
+				return nil, nil
+			},
+		},
+		ListAlertEventsFunc: &SeriesAlertStoreListAlertEventsFunc{
+			defaultHook: func(context.Context, int, int) ([]types.InsightSeriesAlertEvent, error) {
+				return nil, nil
+			},
+		},
+		ListAlertsFunc: &SeriesAlertStoreListAlertsFunc{
+			defaultHook: func(context.Context, ListAlertsArgs) ([]types.InsightSeriesAlert, error) {
+				return nil, nil
+			},
+		},
+		TriggerAlertFunc: &SeriesAlertStoreTriggerAlertFunc{
+			defaultHook: func(context.Context, types.InsightSeriesAlertEvent, time.Time) (types.InsightSeriesAlertEvent, bool, error) {
+				return types.InsightSeriesAlertEvent{}, false, nil
+			},
+		},
+		UpdateAlertEvaluationFunc: &SeriesAlertStoreUpdateAlertEvaluationFunc{
+			defaultHook: func(context.Context, int, time.Time, bool) error {
+				return nil
+			},
+		},
+		UpdateAlertEventErrorsFunc: &SeriesAlertStoreUpdateAlertEventErrorsFunc{
+			defaultHook: func(context.Context, int, *string, *string) error {
+				return nil
+			},
+		},
+	}
+}
+
+// NewMockSeriesAlertStoreFrom creates a new mock of the
+// MockSeriesAlertStore interface. All methods delegate to the given
+// implementation, unless overwritten.
+func NewMockSeriesAlertStoreFrom(i SeriesAlertStore) *MockSeriesAlertStore {
+	return &MockSeriesAlertStore{
+		CreateAlertFunc: &SeriesAlertStoreCreateAlertFunc{
+			defaultHook: i.CreateAlert,
+		},
+		CreateAlertEventFunc: &SeriesAlertStoreCreateAlertEventFunc{
+			defaultHook: i.CreateAlertEvent,
+		},
+		DeleteAlertFunc: &SeriesAlertStoreDeleteAlertFunc{
+			defaultHook: i.DeleteAlert,
+		},
+		GetAlertFunc: &SeriesAlertStoreGetAlertFunc{
+			defaultHook: i.GetAlert,
+		},
+		ListAlertEventsFunc: &SeriesAlertStoreListAlertEventsFunc{
+			defaultHook: i.ListAlertEvents,
+		},
+		ListAlertsFunc: &SeriesAlertStoreListAlertsFunc{
+			defaultHook: i.ListAlerts,
+		},
+		TriggerAlertFunc: &SeriesAlertStoreTriggerAlertFunc{
+			defaultHook: i.TriggerAlert,
+		},
+		UpdateAlertEvaluationFunc: &SeriesAlertStoreUpdateAlertEvaluationFunc{
+			defaultHook: i.UpdateAlertEvaluation,
+		},
+		UpdateAlertEventErrorsFunc: &SeriesAlertStoreUpdateAlertEventErrorsFunc{
+			defaultHook: i.UpdateAlertEventErrors,
+		},
+	}
+}
+
+// SeriesAlertStoreCreateAlertFunc describes the behavior when the
+// CreateAlert method of the parent MockSeriesAlertStore instance is
+// invoked.
+type SeriesAlertStoreCreateAlertFunc struct {
+	defaultHook func(context.Context, types.InsightSeriesAlert) (types.InsightSeriesAlert, error)
+	hooks       []func(context.Context, types.InsightSeriesAlert) (types.InsightSeriesAlert, error)
+	history     []SeriesAlertStoreCreateAlertFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateAlert delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockSeriesAlertStore) CreateAlert(v0 context.Context, v1 types.InsightSeriesAlert) (types.InsightSeriesAlert, error) {
+	r0, r1 := m.CreateAlertFunc.nextHook()(v0, v1)
+	m.CreateAlertFunc.appendCall(SeriesAlertStoreCreateAlertFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CreateAlert method
+// of the parent MockSeriesAlertStore instance is invoked and the hook queue
+// is empty.
+func (f *SeriesAlertStoreCreateAlertFunc) SetDefaultHook(hook func(context.Context, types.InsightSeriesAlert) (types.InsightSeriesAlert, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateAlert method of the parent MockSeriesAlertStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *SeriesAlertStoreCreateAlertFunc) PushHook(hook func(context.Context, types.InsightSeriesAlert) (types.InsightSeriesAlert, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *SeriesAlertStoreCreateAlertFunc) SetDefaultReturn(r0 types.InsightSeriesAlert, r1 error) {
+	f.SetDefaultHook(func(context.Context, types.InsightSeriesAlert) (types.InsightSeriesAlert, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *SeriesAlertStoreCreateAlertFunc) PushReturn(r0 types.InsightSeriesAlert, r1 error) {
+	f.PushHook(func(context.Context, types.InsightSeriesAlert) (types.InsightSeriesAlert, error) {
+		return r0, r1
+	})
+}
+
+func (f *SeriesAlertStoreCreateAlertFunc) nextHook() func(context.Context, types.InsightSeriesAlert) (types.InsightSeriesAlert, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SeriesAlertStoreCreateAlertFunc) appendCall(r0 SeriesAlertStoreCreateAlertFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SeriesAlertStoreCreateAlertFuncCall objects
+// describing the invocations of this function.
+func (f *SeriesAlertStoreCreateAlertFunc) History() []SeriesAlertStoreCreateAlertFuncCall {
+	f.mutex.Lock()
+	history := make([]SeriesAlertStoreCreateAlertFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SeriesAlertStoreCreateAlertFuncCall is an object that describes an
+// invocation of method CreateAlert on an instance of MockSeriesAlertStore.
+type SeriesAlertStoreCreateAlertFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 types.InsightSeriesAlert
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 types.InsightSeriesAlert
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SeriesAlertStoreCreateAlertFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SeriesAlertStoreCreateAlertFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SeriesAlertStoreCreateAlertEventFunc describes the behavior when the
+// CreateAlertEvent method of the parent MockSeriesAlertStore instance is
+// invoked.
+type SeriesAlertStoreCreateAlertEventFunc struct {
+	defaultHook func(context.Context, types.InsightSeriesAlertEvent) (types.InsightSeriesAlertEvent, error)
+	hooks       []func(context.Context, types.InsightSeriesAlertEvent) (types.InsightSeriesAlertEvent, error)
+	history     []SeriesAlertStoreCreateAlertEventFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateAlertEvent delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockSeriesAlertStore) CreateAlertEvent(v0 context.Context, v1 types.InsightSeriesAlertEvent) (types.InsightSeriesAlertEvent, error) {
+	r0, r1 := m.CreateAlertEventFunc.nextHook()(v0, v1)
+	m.CreateAlertEventFunc.appendCall(SeriesAlertStoreCreateAlertEventFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CreateAlertEvent
+// method of the parent MockSeriesAlertStore instance is invoked and the
+// hook queue is empty.
+func (f *SeriesAlertStoreCreateAlertEventFunc) SetDefaultHook(hook func(context.Context, types.InsightSeriesAlertEvent) (types.InsightSeriesAlertEvent, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateAlertEvent method of the parent MockSeriesAlertStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *SeriesAlertStoreCreateAlertEventFunc) PushHook(hook func(context.Context, types.InsightSeriesAlertEvent) (types.InsightSeriesAlertEvent, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *SeriesAlertStoreCreateAlertEventFunc) SetDefaultReturn(r0 types.InsightSeriesAlertEvent, r1 error) {
+	f.SetDefaultHook(func(context.Context, types.InsightSeriesAlertEvent) (types.InsightSeriesAlertEvent, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *SeriesAlertStoreCreateAlertEventFunc) PushReturn(r0 types.InsightSeriesAlertEvent, r1 error) {
+	f.PushHook(func(context.Context, types.InsightSeriesAlertEvent) (types.InsightSeriesAlertEvent, error) {
+		return r0, r1
+	})
+}
+
+func (f *SeriesAlertStoreCreateAlertEventFunc) nextHook() func(context.Context, types.InsightSeriesAlertEvent) (types.InsightSeriesAlertEvent, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SeriesAlertStoreCreateAlertEventFunc) appendCall(r0 SeriesAlertStoreCreateAlertEventFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SeriesAlertStoreCreateAlertEventFuncCall
+// objects describing the invocations of this function.
+func (f *SeriesAlertStoreCreateAlertEventFunc) History() []SeriesAlertStoreCreateAlertEventFuncCall {
+	f.mutex.Lock()
+	history := make([]SeriesAlertStoreCreateAlertEventFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SeriesAlertStoreCreateAlertEventFuncCall is an object that describes an
+// invocation of method CreateAlertEvent on an instance of
+// MockSeriesAlertStore.
+type SeriesAlertStoreCreateAlertEventFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 types.InsightSeriesAlertEvent
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 types.InsightSeriesAlertEvent
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SeriesAlertStoreCreateAlertEventFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SeriesAlertStoreCreateAlertEventFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SeriesAlertStoreDeleteAlertFunc describes the behavior when the
+// DeleteAlert method of the parent MockSeriesAlertStore instance is
+// invoked.
+type SeriesAlertStoreDeleteAlertFunc struct {
+	defaultHook func(context.Context, int) error
+	hooks       []func(context.Context, int) error
+	history     []SeriesAlertStoreDeleteAlertFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteAlert delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockSeriesAlertStore) DeleteAlert(v0 context.Context, v1 int) error {
+	r0 := m.DeleteAlertFunc.nextHook()(v0, v1)
+	m.DeleteAlertFunc.appendCall(SeriesAlertStoreDeleteAlertFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the DeleteAlert method
+// of the parent MockSeriesAlertStore instance is invoked and the hook queue
+// is empty.
+func (f *SeriesAlertStoreDeleteAlertFunc) SetDefaultHook(hook func(context.Context, int) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteAlert method of the parent MockSeriesAlertStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *SeriesAlertStoreDeleteAlertFunc) PushHook(hook func(context.Context, int) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *SeriesAlertStoreDeleteAlertFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *SeriesAlertStoreDeleteAlertFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int) error {
+		return r0
+	})
+}
+
+func (f *SeriesAlertStoreDeleteAlertFunc) nextHook() func(context.Context, int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SeriesAlertStoreDeleteAlertFunc) appendCall(r0 SeriesAlertStoreDeleteAlertFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SeriesAlertStoreDeleteAlertFuncCall objects
+// describing the invocations of this function.
+func (f *SeriesAlertStoreDeleteAlertFunc) History() []SeriesAlertStoreDeleteAlertFuncCall {
+	f.mutex.Lock()
+	history := make([]SeriesAlertStoreDeleteAlertFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SeriesAlertStoreDeleteAlertFuncCall is an object that describes an
+// invocation of method DeleteAlert on an instance of MockSeriesAlertStore.
+type SeriesAlertStoreDeleteAlertFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SeriesAlertStoreDeleteAlertFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SeriesAlertStoreDeleteAlertFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// SeriesAlertStoreGetAlertFunc describes the behavior when the GetAlert
+// method of the parent MockSeriesAlertStore instance is invoked.
+type SeriesAlertStoreGetAlertFunc struct {
+	defaultHook func(context.Context, int) (*types.InsightSeriesAlert, error)
+	hooks       []func(context.Context, int) (*types.InsightSeriesAlert, error)
+	history     []SeriesAlertStoreGetAlertFuncCall
+	mutex       sync.Mutex
+}
+
+// GetAlert delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSeriesAlertStore) GetAlert(v0 context.Context, v1 int) (*types.InsightSeriesAlert, error) {
+	r0, r1 := m.GetAlertFunc.nextHook()(v0, v1)
+	m.GetAlertFunc.appendCall(SeriesAlertStoreGetAlertFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetAlert method of
+// the parent MockSeriesAlertStore instance is invoked and the hook queue is
+// empty.
+func (f *SeriesAlertStoreGetAlertFunc) SetDefaultHook(hook func(context.Context, int) (*types.InsightSeriesAlert, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetAlert method of the parent MockSeriesAlertStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SeriesAlertStoreGetAlertFunc) PushHook(hook func(context.Context, int) (*types.InsightSeriesAlert, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *SeriesAlertStoreGetAlertFunc) SetDefaultReturn(r0 *types.InsightSeriesAlert, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) (*types.InsightSeriesAlert, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *SeriesAlertStoreGetAlertFunc) PushReturn(r0 *types.InsightSeriesAlert, r1 error) {
+	f.PushHook(func(context.Context, int) (*types.InsightSeriesAlert, error) {
+		return r0, r1
+	})
+}
+
+func (f *SeriesAlertStoreGetAlertFunc) nextHook() func(context.Context, int) (*types.InsightSeriesAlert, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SeriesAlertStoreGetAlertFunc) appendCall(r0 SeriesAlertStoreGetAlertFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SeriesAlertStoreGetAlertFuncCall objects
+// describing the invocations of this function.
+func (f *SeriesAlertStoreGetAlertFunc) History() []SeriesAlertStoreGetAlertFuncCall {
+	f.mutex.Lock()
+	history := make([]SeriesAlertStoreGetAlertFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SeriesAlertStoreGetAlertFuncCall is an object that describes an
+// invocation of method GetAlert on an instance of MockSeriesAlertStore.
+type SeriesAlertStoreGetAlertFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.InsightSeriesAlert
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SeriesAlertStoreGetAlertFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SeriesAlertStoreGetAlertFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SeriesAlertStoreListAlertEventsFunc describes the behavior when the
+// ListAlertEvents method of the parent MockSeriesAlertStore instance is
+// invoked.
+type SeriesAlertStoreListAlertEventsFunc struct {
+	defaultHook func(context.Context, int, int) ([]types.InsightSeriesAlertEvent, error)
+	hooks       []func(context.Context, int, int) ([]types.InsightSeriesAlertEvent, error)
+	history     []SeriesAlertStoreListAlertEventsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListAlertEvents delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockSeriesAlertStore) ListAlertEvents(v0 context.Context, v1 int, v2 int) ([]types.InsightSeriesAlertEvent, error) {
+	r0, r1 := m.ListAlertEventsFunc.nextHook()(v0, v1, v2)
+	m.ListAlertEventsFunc.appendCall(SeriesAlertStoreListAlertEventsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListAlertEvents
+// method of the parent MockSeriesAlertStore instance is invoked and the
+// hook queue is empty.
+func (f *SeriesAlertStoreListAlertEventsFunc) SetDefaultHook(hook func(context.Context, int, int) ([]types.InsightSeriesAlertEvent, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListAlertEvents method of the parent MockSeriesAlertStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *SeriesAlertStoreListAlertEventsFunc) PushHook(hook func(context.Context, int, int) ([]types.InsightSeriesAlertEvent, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *SeriesAlertStoreListAlertEventsFunc) SetDefaultReturn(r0 []types.InsightSeriesAlertEvent, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, int) ([]types.InsightSeriesAlertEvent, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *SeriesAlertStoreListAlertEventsFunc) PushReturn(r0 []types.InsightSeriesAlertEvent, r1 error) {
+	f.PushHook(func(context.Context, int, int) ([]types.InsightSeriesAlertEvent, error) {
+		return r0, r1
+	})
+}
+
+func (f *SeriesAlertStoreListAlertEventsFunc) nextHook() func(context.Context, int, int) ([]types.InsightSeriesAlertEvent, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SeriesAlertStoreListAlertEventsFunc) appendCall(r0 SeriesAlertStoreListAlertEventsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SeriesAlertStoreListAlertEventsFuncCall
+// objects describing the invocations of this function.
+func (f *SeriesAlertStoreListAlertEventsFunc) History() []SeriesAlertStoreListAlertEventsFuncCall {
+	f.mutex.Lock()
+	history := make([]SeriesAlertStoreListAlertEventsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SeriesAlertStoreListAlertEventsFuncCall is an object that describes an
+// invocation of method ListAlertEvents on an instance of
+// MockSeriesAlertStore.
+type SeriesAlertStoreListAlertEventsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []types.InsightSeriesAlertEvent
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SeriesAlertStoreListAlertEventsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SeriesAlertStoreListAlertEventsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SeriesAlertStoreListAlertsFunc describes the behavior when the ListAlerts
+// method of the parent MockSeriesAlertStore instance is invoked.
+type SeriesAlertStoreListAlertsFunc struct {
+	defaultHook func(context.Context, ListAlertsArgs) ([]types.InsightSeriesAlert, error)
+	hooks       []func(context.Context, ListAlertsArgs) ([]types.InsightSeriesAlert, error)
+	history     []SeriesAlertStoreListAlertsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListAlerts delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockSeriesAlertStore) ListAlerts(v0 context.Context, v1 ListAlertsArgs) ([]types.InsightSeriesAlert, error) {
+	r0, r1 := m.ListAlertsFunc.nextHook()(v0, v1)
+	m.ListAlertsFunc.appendCall(SeriesAlertStoreListAlertsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListAlerts method of
+// the parent MockSeriesAlertStore instance is invoked and the hook queue is
+// empty.
+func (f *SeriesAlertStoreListAlertsFunc) SetDefaultHook(hook func(context.Context, ListAlertsArgs) ([]types.InsightSeriesAlert, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListAlerts method of the parent MockSeriesAlertStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *SeriesAlertStoreListAlertsFunc) PushHook(hook func(context.Context, ListAlertsArgs) ([]types.InsightSeriesAlert, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *SeriesAlertStoreListAlertsFunc) SetDefaultReturn(r0 []types.InsightSeriesAlert, r1 error) {
+	f.SetDefaultHook(func(context.Context, ListAlertsArgs) ([]types.InsightSeriesAlert, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *SeriesAlertStoreListAlertsFunc) PushReturn(r0 []types.InsightSeriesAlert, r1 error) {
+	f.PushHook(func(context.Context, ListAlertsArgs) ([]types.InsightSeriesAlert, error) {
+		return r0, r1
+	})
+}
+
+func (f *SeriesAlertStoreListAlertsFunc) nextHook() func(context.Context, ListAlertsArgs) ([]types.InsightSeriesAlert, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SeriesAlertStoreListAlertsFunc) appendCall(r0 SeriesAlertStoreListAlertsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SeriesAlertStoreListAlertsFuncCall objects
+// describing the invocations of this function.
+func (f *SeriesAlertStoreListAlertsFunc) History() []SeriesAlertStoreListAlertsFuncCall {
+	f.mutex.Lock()
+	history := make([]SeriesAlertStoreListAlertsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SeriesAlertStoreListAlertsFuncCall is an object that describes an
+// invocation of method ListAlerts on an instance of MockSeriesAlertStore.
+type SeriesAlertStoreListAlertsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 ListAlertsArgs
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []types.InsightSeriesAlert
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SeriesAlertStoreListAlertsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SeriesAlertStoreListAlertsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// SeriesAlertStoreTriggerAlertFunc describes the behavior when the
+// TriggerAlert method of the parent MockSeriesAlertStore instance is
+// invoked.
+type SeriesAlertStoreTriggerAlertFunc struct {
+	defaultHook func(context.Context, types.InsightSeriesAlertEvent, time.Time) (types.InsightSeriesAlertEvent, bool, error)
+	hooks       []func(context.Context, types.InsightSeriesAlertEvent, time.Time) (types.InsightSeriesAlertEvent, bool, error)
+	history     []SeriesAlertStoreTriggerAlertFuncCall
+	mutex       sync.Mutex
+}
+
+// TriggerAlert delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockSeriesAlertStore) TriggerAlert(v0 context.Context, v1 types.InsightSeriesAlertEvent, v2 time.Time) (types.InsightSeriesAlertEvent, bool, error) {
+	r0, r1, r2 := m.TriggerAlertFunc.nextHook()(v0, v1, v2)
+	m.TriggerAlertFunc.appendCall(SeriesAlertStoreTriggerAlertFuncCall{v0, v1, v2, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the TriggerAlert method
+// of the parent MockSeriesAlertStore instance is invoked and the hook queue
+// is empty.
+func (f *SeriesAlertStoreTriggerAlertFunc) SetDefaultHook(hook func(context.Context, types.InsightSeriesAlertEvent, time.Time) (types.InsightSeriesAlertEvent, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// TriggerAlert method of the parent MockSeriesAlertStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *SeriesAlertStoreTriggerAlertFunc) PushHook(hook func(context.Context, types.InsightSeriesAlertEvent, time.Time) (types.InsightSeriesAlertEvent, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *SeriesAlertStoreTriggerAlertFunc) SetDefaultReturn(r0 types.InsightSeriesAlertEvent, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, types.InsightSeriesAlertEvent, time.Time) (types.InsightSeriesAlertEvent, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *SeriesAlertStoreTriggerAlertFunc) PushReturn(r0 types.InsightSeriesAlertEvent, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, types.InsightSeriesAlertEvent, time.Time) (types.InsightSeriesAlertEvent, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *SeriesAlertStoreTriggerAlertFunc) nextHook() func(context.Context, types.InsightSeriesAlertEvent, time.Time) (types.InsightSeriesAlertEvent, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SeriesAlertStoreTriggerAlertFunc) appendCall(r0 SeriesAlertStoreTriggerAlertFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SeriesAlertStoreTriggerAlertFuncCall
+// objects describing the invocations of this function.
+func (f *SeriesAlertStoreTriggerAlertFunc) History() []SeriesAlertStoreTriggerAlertFuncCall {
+	f.mutex.Lock()
+	history := make([]SeriesAlertStoreTriggerAlertFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SeriesAlertStoreTriggerAlertFuncCall is an object that describes an
+// invocation of method TriggerAlert on an instance of MockSeriesAlertStore.
+type SeriesAlertStoreTriggerAlertFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 types.InsightSeriesAlertEvent
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 types.InsightSeriesAlertEvent
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SeriesAlertStoreTriggerAlertFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SeriesAlertStoreTriggerAlertFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// SeriesAlertStoreUpdateAlertEvaluationFunc describes the behavior when the
+// UpdateAlertEvaluation method of the parent MockSeriesAlertStore instance
+// is invoked.
+type SeriesAlertStoreUpdateAlertEvaluationFunc struct {
+	defaultHook func(context.Context, int, time.Time, bool) error
+	hooks       []func(context.Context, int, time.Time, bool) error
+	history     []SeriesAlertStoreUpdateAlertEvaluationFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateAlertEvaluation delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockSeriesAlertStore) UpdateAlertEvaluation(v0 context.Context, v1 int, v2 time.Time, v3 bool) error {
+	r0 := m.UpdateAlertEvaluationFunc.nextHook()(v0, v1, v2, v3)
+	m.UpdateAlertEvaluationFunc.appendCall(SeriesAlertStoreUpdateAlertEvaluationFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateAlertEvaluation method of the parent MockSeriesAlertStore instance
+// is invoked and the hook queue is empty.
+func (f *SeriesAlertStoreUpdateAlertEvaluationFunc) SetDefaultHook(hook func(context.Context, int, time.Time, bool) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateAlertEvaluation method of the parent MockSeriesAlertStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *SeriesAlertStoreUpdateAlertEvaluationFunc) PushHook(hook func(context.Context, int, time.Time, bool) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *SeriesAlertStoreUpdateAlertEvaluationFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, time.Time, bool) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *SeriesAlertStoreUpdateAlertEvaluationFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, time.Time, bool) error {
+		return r0
+	})
+}
+
+func (f *SeriesAlertStoreUpdateAlertEvaluationFunc) nextHook() func(context.Context, int, time.Time, bool) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SeriesAlertStoreUpdateAlertEvaluationFunc) appendCall(r0 SeriesAlertStoreUpdateAlertEvaluationFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SeriesAlertStoreUpdateAlertEvaluationFuncCall objects describing the
+// invocations of this function.
+func (f *SeriesAlertStoreUpdateAlertEvaluationFunc) History() []SeriesAlertStoreUpdateAlertEvaluationFuncCall {
+	f.mutex.Lock()
+	history := make([]SeriesAlertStoreUpdateAlertEvaluationFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SeriesAlertStoreUpdateAlertEvaluationFuncCall is an object that describes
+// an invocation of method UpdateAlertEvaluation on an instance of
+// MockSeriesAlertStore.
+type SeriesAlertStoreUpdateAlertEvaluationFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 time.Time
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SeriesAlertStoreUpdateAlertEvaluationFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SeriesAlertStoreUpdateAlertEvaluationFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// SeriesAlertStoreUpdateAlertEventErrorsFunc describes the behavior when
+// the UpdateAlertEventErrors method of the parent MockSeriesAlertStore
+// instance is invoked.
+type SeriesAlertStoreUpdateAlertEventErrorsFunc struct {
+	defaultHook func(context.Context, int, *string, *string) error
+	hooks       []func(context.Context, int, *string, *string) error
+	history     []SeriesAlertStoreUpdateAlertEventErrorsFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateAlertEventErrors delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockSeriesAlertStore) UpdateAlertEventErrors(v0 context.Context, v1 int, v2 *string, v3 *string) error {
+	r0 := m.UpdateAlertEventErrorsFunc.nextHook()(v0, v1, v2, v3)
+	m.UpdateAlertEventErrorsFunc.appendCall(SeriesAlertStoreUpdateAlertEventErrorsFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateAlertEventErrors method of the parent MockSeriesAlertStore instance
+// is invoked and the hook queue is empty.
+func (f *SeriesAlertStoreUpdateAlertEventErrorsFunc) SetDefaultHook(hook func(context.Context, int, *string, *string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateAlertEventErrors method of the parent MockSeriesAlertStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *SeriesAlertStoreUpdateAlertEventErrorsFunc) PushHook(hook func(context.Context, int, *string, *string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *SeriesAlertStoreUpdateAlertEventErrorsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, *string, *string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *SeriesAlertStoreUpdateAlertEventErrorsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, *string, *string) error {
+		return r0
+	})
+}
+
+func (f *SeriesAlertStoreUpdateAlertEventErrorsFunc) nextHook() func(context.Context, int, *string, *string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SeriesAlertStoreUpdateAlertEventErrorsFunc) appendCall(r0 SeriesAlertStoreUpdateAlertEventErrorsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// SeriesAlertStoreUpdateAlertEventErrorsFuncCall objects describing the
+// invocations of this function.
+func (f *SeriesAlertStoreUpdateAlertEventErrorsFunc) History() []SeriesAlertStoreUpdateAlertEventErrorsFuncCall {
+	f.mutex.Lock()
+	history := make([]SeriesAlertStoreUpdateAlertEventErrorsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SeriesAlertStoreUpdateAlertEventErrorsFuncCall is an object that
+// describes an invocation of method UpdateAlertEventErrors on an instance
+// of MockSeriesAlertStore.
+type SeriesAlertStoreUpdateAlertEventErrorsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 *string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 *string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SeriesAlertStoreUpdateAlertEventErrorsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SeriesAlertStoreUpdateAlertEventErrorsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 )
@@ -21,7 +22,11 @@ import (
 // for actual API usage.
 type Interface interface {
 	SeriesPoints(ctx context.Context, opts SeriesPointsOpts) ([]SeriesPoint, error)
+	RepoSeriesPoints(ctx context.Context, opts RepoSeriesPointsOpts) ([]RepoSeriesPoint, error)
+	SeriesCaptures(ctx context.Context, seriesID string) ([]string, error)
+	ExportSeriesPoints(ctx context.Context, seriesID string) ([]ExportedSeriesPoint, error)
 	RecordSeriesPoint(ctx context.Context, v RecordSeriesPointArgs) error
+	RecordSeriesPoints(ctx context.Context, pts []RecordSeriesPointArgs) error
 	CountData(ctx context.Context, opts CountDataOpts) (int, error)
 }
 
@@ -72,9 +77,16 @@ type SeriesPoint struct {
 	Time     time.Time
 	Value    float64
 	Metadata []byte
+
+	// Capture is the value of the capture group the point was recorded for, if the series is
+	// generated from capture groups.
+	Capture *string
 }
 
 func (s *SeriesPoint) String() string {
+	if s.Capture != nil {
+		return fmt.Sprintf("SeriesPoint{Time: %q, Value: %v, Metadata: %s, Capture: %q}", s.Time, s.Value, s.Metadata, *s.Capture)
+	}
 	return fmt.Sprintf("SeriesPoint{Time: %q, Value: %v, Metadata: %s}", s.Time, s.Value, s.Metadata)
 }
 
@@ -100,6 +112,15 @@ type SeriesPointsOpts struct {
 
 	// Limit is the number of data points to query, if non-zero.
 	Limit int
+
+	// Capture, if non-nil, indicates to filter results to only points recorded for this capture
+	// group value.
+	Capture *string
+
+	// PerInterval indicates that the points of the series are the number of events during the
+	// interval ending at their time (e.g. the commits of commit series) instead of a value at that
+	// time. Such points are summed per recording time instead of being carried forward.
+	PerInterval bool
 }
 
 // SeriesPoints queries data points over time for a specific insights' series.
@@ -131,6 +152,7 @@ func (s *Store) SeriesPoints(ctx context.Context, opts SeriesPointsOpts) ([]Seri
 			&point.Time,
 			&point.Value,
 			&point.Metadata,
+			&point.Capture,
 		)
 		if err != nil {
 			return err
@@ -143,21 +165,44 @@ func (s *Store) SeriesPoints(ctx context.Context, opts SeriesPointsOpts) ([]Seri
 
 // This query is a barebones implementation of per-repo per-series last-observation carried forward. Long term
 // this query is too expensive to run in real-time and should be moved to a materialized view.
-const lastObservationCarriedPointsSql = `select sub.series_id, sub.interval_time, sum(value) as value, null as metadata from (WITH target_times AS (SELECT *
+//
+// Points of series generated from capture groups (and language statistics series) are carried
+// forward per repository as a whole: the last recording of a repository is carried forward with
+// all of its capture group values, so a value that is no longer found in the repository stops
+// counting instead of being carried forward forever. The values are then aggregated per capture
+// group value.
+const lastObservationCarriedPointsSql = `select sub.series_id, sub.interval_time, sum(value) as value, null as metadata, sub.capture from (WITH target_times AS (SELECT *
 FROM GENERATE_SERIES(CURRENT_TIMESTAMP::date - INTERVAL '26 weeks', CURRENT_TIMESTAMP::date, '2 weeks') as interval_time)
-SELECT sub.series_id, sub.repo_id, sub.value, interval_time, repo_name_id
+SELECT sub.series_id, sub.repo_id, sub.value, interval_time, repo_name_id, sub.capture
 FROM (select distinct repo_id, series_id from series_points) as r
 cross join target_times tt
 join LATERAL (
-    select sp.* from series_points as sp
-    where sp.repo_id = r.repo_id and sp.time <= tt.interval_time and sp.series_id = r.series_id
-    order by time DESC
-    limit 1
+    select distinct on (sp.capture) sp.* from series_points as sp
+    where sp.repo_id = r.repo_id and sp.series_id = r.series_id
+      and sp.time = (
+        select max(latest.time) from series_points as latest
+        where latest.repo_id = r.repo_id and latest.time <= tt.interval_time and latest.series_id = r.series_id
+      )
+    order by sp.capture
     ) sub on sub.repo_id = r.repo_id and r.series_id = sub.series_id
 order by interval_time, repo_id) as sub
 join repo_names rn on sub.repo_name_id = rn.id
 where %s
-group by sub.series_id, sub.interval_time
+group by sub.series_id, sub.interval_time, sub.capture
+order by interval_time desc
+`
+
+// This query sums the points of series that record the number of events per interval, such as
+// commit series. Carrying such a point forward would count the same events again in the
+// following intervals, so each point only contributes to the interval it was recorded for.
+// Points that aren't associated with a repository are included, since intervals without any
+// events are recorded as a single zero point without a repository.
+const perIntervalPointsSql = `select sub.series_id, sub.interval_time, sum(value) as value, null as metadata, sub.capture from (
+SELECT sp.series_id, sp.repo_id, sp.value, sp.time as interval_time, sp.repo_name_id, sp.capture
+FROM series_points sp) as sub
+left join repo_names rn on sub.repo_name_id = rn.id
+where %s
+group by sub.series_id, sub.interval_time, sub.capture
 order by interval_time desc
 `
 
@@ -189,6 +234,9 @@ func seriesPointsQuery(opts SeriesPointsOpts) *sqlf.Query {
 	if opts.RepoID != nil {
 		preds = append(preds, sqlf.Sprintf("repo_id = %d", int32(*opts.RepoID)))
 	}
+	if opts.Capture != nil {
+		preds = append(preds, sqlf.Sprintf("sub.capture = %s", *opts.Capture))
+	}
 	if opts.From != nil {
 		preds = append(preds, sqlf.Sprintf("interval_time >= %s", *opts.From))
 	}
@@ -204,7 +252,7 @@ func seriesPointsQuery(opts SeriesPointsOpts) *sqlf.Query {
 		preds = append(preds, sqlf.Sprintf(s))
 	}
 	if len(opts.Excluded) > 0 {
-		s := fmt.Sprintf("repo_id != all(%v)", values(opts.Excluded))
+		s := fmt.Sprintf("(repo_id IS NULL OR repo_id != all(%v))", values(opts.Excluded))
 		preds = append(preds, sqlf.Sprintf(s))
 	}
 	if len(opts.IncludeRepoRegex) > 0 {
@@ -217,8 +265,12 @@ func seriesPointsQuery(opts SeriesPointsOpts) *sqlf.Query {
 	if len(preds) == 0 {
 		preds = append(preds, sqlf.Sprintf("TRUE"))
 	}
+	pointsSql := lastObservationCarriedPointsSql
+	if opts.PerInterval {
+		pointsSql = perIntervalPointsSql
+	}
 	return sqlf.Sprintf(
-		lastObservationCarriedPointsSql+limitClause,
+		pointsSql+limitClause,
 		sqlf.Join(preds, "\n AND "),
 	)
 }
@@ -242,6 +294,206 @@ func values(ids []api.RepoID) string {
 	return query
 }
 
+// RepoSeriesPoint describes the value of an insights' series for a single repository.
+type RepoSeriesPoint struct {
+	RepoID   api.RepoID
+	RepoName string
+
+	// Time is the time at which the value was recorded (always UTC).
+	Time  time.Time
+	Value float64
+}
+
+// RepoSeriesPointsOpts describes options for querying the per-repository values of an insights'
+// series.
+type RepoSeriesPointsOpts struct {
+	// SeriesID is the unique series ID to query.
+	SeriesID string
+
+	// Time is the point in time to return the values at. The value of a repository is the one
+	// of its last recording at or before this time, in the same way SeriesPoints carries
+	// observations forward.
+	Time time.Time
+
+	// Capture is the capture group value to query the values of. It must be nil for series that
+	// are not generated from capture groups.
+	Capture *string
+
+	Excluded []api.RepoID
+	Included []api.RepoID
+
+	IncludeRepoRegex string
+	ExcludeRepoRegex string
+
+	// Limit is the number of repositories to return, if non-zero.
+	Limit int
+}
+
+// RepoSeriesPoints returns the values of a series per repository at a point in time, ordered by
+// value so that the repositories contributing the most to the series' value come first.
+func (s *Store) RepoSeriesPoints(ctx context.Context, opts RepoSeriesPointsOpts) ([]RepoSeriesPoint, error) {
+	points := make([]RepoSeriesPoint, 0, opts.Limit)
+
+	// 🚨 SECURITY: Exclude the repositories the current user cannot see, see SeriesPoints.
+	denylist, err := s.permStore.GetUnauthorizedRepoIDs(ctx)
+	if err != nil {
+		return []RepoSeriesPoint{}, err
+	}
+	opts.Excluded = append(opts.Excluded, denylist...)
+
+	err = s.query(ctx, repoSeriesPointsQuery(opts), func(sc scanner) error {
+		var point RepoSeriesPoint
+		if err := sc.Scan(
+			&point.RepoID,
+			&point.RepoName,
+			&point.Time,
+			&point.Value,
+		); err != nil {
+			return err
+		}
+		points = append(points, point)
+		return nil
+	})
+	return points, err
+}
+
+const repoSeriesPointsFmtstr = `
+-- source: enterprise/internal/insights/store/store.go:RepoSeriesPoints
+SELECT sub.repo_id, rn.name, sub.time, sub.value
+FROM (
+	SELECT DISTINCT ON (sp.repo_id) sp.repo_id, sp.repo_name_id, sp.time, sp.value
+	FROM series_points sp
+	JOIN (
+		SELECT repo_id, MAX(time) AS time
+		FROM series_points
+		WHERE series_id = %s AND time <= %s AND repo_id IS NOT NULL
+		GROUP BY repo_id
+	) latest ON latest.repo_id = sp.repo_id AND latest.time = sp.time
+	WHERE sp.series_id = %s AND sp.capture IS NOT DISTINCT FROM %s
+	ORDER BY sp.repo_id
+) sub
+JOIN repo_names rn ON sub.repo_name_id = rn.id
+WHERE %s
+ORDER BY sub.value DESC, rn.name
+`
+
+func repoSeriesPointsQuery(opts RepoSeriesPointsOpts) *sqlf.Query {
+	preds := []*sqlf.Query{}
+
+	if len(opts.Included) > 0 {
+		s := fmt.Sprintf("sub.repo_id = any(%v)", values(opts.Included))
+		preds = append(preds, sqlf.Sprintf(s))
+	}
+	if len(opts.Excluded) > 0 {
+		s := fmt.Sprintf("sub.repo_id != all(%v)", values(opts.Excluded))
+		preds = append(preds, sqlf.Sprintf(s))
+	}
+	if len(opts.IncludeRepoRegex) > 0 {
+		preds = append(preds, sqlf.Sprintf("rn.name ~ %s", opts.IncludeRepoRegex))
+	}
+	if len(opts.ExcludeRepoRegex) > 0 {
+		preds = append(preds, sqlf.Sprintf("rn.name !~ %s", opts.ExcludeRepoRegex))
+	}
+	if len(preds) == 0 {
+		preds = append(preds, sqlf.Sprintf("TRUE"))
+	}
+
+	limitClause := ""
+	if opts.Limit > 0 {
+		limitClause = fmt.Sprintf("LIMIT %d", opts.Limit)
+	}
+	return sqlf.Sprintf(
+		repoSeriesPointsFmtstr+limitClause,
+		opts.SeriesID,
+		opts.Time.UTC(),
+		opts.SeriesID,
+		opts.Capture,
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+// SeriesCaptures returns the distinct capture group values (or languages) recorded for the given
+// series, sorted by value. Values only recorded for repositories the current user cannot see are
+// not returned.
+func (s *Store) SeriesCaptures(ctx context.Context, seriesID string) ([]string, error) {
+	// 🚨 SECURITY: Exclude the repositories the current user cannot see, see SeriesPoints.
+	denylist, err := s.permStore.GetUnauthorizedRepoIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("series_id = %s", seriesID),
+		sqlf.Sprintf("capture IS NOT NULL"),
+	}
+	if len(denylist) > 0 {
+		preds = append(preds, sqlf.Sprintf(fmt.Sprintf("repo_id != all(%v)", values(denylist))))
+	}
+	return basestore.ScanStrings(s.Store.Query(ctx, sqlf.Sprintf(seriesCapturesFmtstr, sqlf.Join(preds, "\n AND "))))
+}
+
+const seriesCapturesFmtstr = `
+-- source: enterprise/internal/insights/store/store.go:SeriesCaptures
+SELECT DISTINCT capture FROM series_points WHERE %s ORDER BY capture
+`
+
+// ExportedSeriesPoint describes a single data point of an insights' series as it was recorded,
+// i.e. without carrying observations forward or aggregating repositories.
+type ExportedSeriesPoint struct {
+	SeriesID string
+
+	// Time is the time at which the point was recorded (always UTC).
+	Time  time.Time
+	Value float64
+
+	// RepoName is the name of the repository the point was recorded for, if any.
+	RepoName *string
+
+	// Capture is the capture group value or language the point was recorded for, if any.
+	Capture *string
+}
+
+// ExportSeriesPoints returns all data points recorded for the given series, ordered by time.
+func (s *Store) ExportSeriesPoints(ctx context.Context, seriesID string) ([]ExportedSeriesPoint, error) {
+	// 🚨 SECURITY: Exclude the repositories the current user cannot see, see SeriesPoints.
+	denylist, err := s.permStore.GetUnauthorizedRepoIDs(ctx)
+	if err != nil {
+		return []ExportedSeriesPoint{}, err
+	}
+
+	preds := []*sqlf.Query{sqlf.Sprintf("sp.series_id = %s", seriesID)}
+	if len(denylist) > 0 {
+		s := fmt.Sprintf("(sp.repo_id IS NULL OR sp.repo_id != all(%v))", values(denylist))
+		preds = append(preds, sqlf.Sprintf(s))
+	}
+
+	points := make([]ExportedSeriesPoint, 0)
+	err = s.query(ctx, sqlf.Sprintf(exportSeriesPointsFmtstr, sqlf.Join(preds, "\n AND ")), func(sc scanner) error {
+		var point ExportedSeriesPoint
+		if err := sc.Scan(
+			&point.SeriesID,
+			&point.Time,
+			&point.Value,
+			&point.RepoName,
+			&point.Capture,
+		); err != nil {
+			return err
+		}
+		points = append(points, point)
+		return nil
+	})
+	return points, err
+}
+
+const exportSeriesPointsFmtstr = `
+-- source: enterprise/internal/insights/store/store.go:ExportSeriesPoints
+SELECT sp.series_id, sp.time, sp.value, rn.name, sp.capture
+FROM series_points sp
+LEFT JOIN repo_names rn ON sp.repo_name_id = rn.id
+WHERE %s
+ORDER BY sp.time, rn.name, sp.capture
+`
+
 type CountDataOpts struct {
 	// The time range to look for data, if non-nil.
 	From, To *time.Time
@@ -298,7 +550,8 @@ type RecordSeriesPointArgs struct {
 	// but is not a DB table primary key ID.
 	SeriesID string
 
-	// Point is the actual data point recorded and at what time.
+	// Point is the actual data point recorded and at what time. Point.Capture must be set for
+	// series generated from capture groups.
 	Point SeriesPoint
 
 	// Repository name and DB ID to associate with this data point, if any.
@@ -325,53 +578,116 @@ func (s *Store) RecordSeriesPoint(ctx context.Context, v RecordSeriesPointArgs)
 	}
 	defer func() { err = txStore.Done(err) }()
 
+	repoNameID, metadataID, err := upsertPointReferences(ctx, txStore, v, nil)
+	if err != nil {
+		return err
+	}
+
+	// Insert the actual data point.
+	return txStore.Exec(ctx, sqlf.Sprintf(
+		recordSeriesPointFmtstr,
+		v.SeriesID,         // series_id
+		v.Point.Time.UTC(), // time
+		v.Point.Value,      // value
+		metadataID,         // metadata_id
+		v.RepoID,           // repo_id
+		repoNameID,         // repo_name_id
+		repoNameID,         // original_repo_name_id
+		v.Point.Capture,    // capture
+	))
+}
+
+// RecordSeriesPoints records the given data points in a single transaction, so that either all
+// or none of them are recorded. The points are inserted in bulk, which makes it suitable for
+// recording many points at once, e.g. when importing data.
+func (s *Store) RecordSeriesPoints(ctx context.Context, pts []RecordSeriesPointArgs) (err error) {
+	var txStore *basestore.Store
+	txStore, err = s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = txStore.Done(err) }()
+
+	repoNameIDs := map[string]int{}
+	inserter := batch.NewInserter(
+		ctx,
+		txStore.Handle().DB(),
+		"series_points",
+		"series_id",
+		"time",
+		"value",
+		"metadata_id",
+		"repo_id",
+		"repo_name_id",
+		"original_repo_name_id",
+		"capture",
+	)
+	for _, v := range pts {
+		repoNameID, metadataID, err := upsertPointReferences(ctx, txStore, v, repoNameIDs)
+		if err != nil {
+			return err
+		}
+		if err := inserter.Insert(
+			ctx,
+			v.SeriesID,         // series_id
+			v.Point.Time.UTC(), // time
+			v.Point.Value,      // value
+			metadataID,         // metadata_id
+			v.RepoID,           // repo_id
+			repoNameID,         // repo_name_id
+			repoNameID,         // original_repo_name_id
+			v.Point.Capture,    // capture
+		); err != nil {
+			return errors.Wrap(err, "inserting data point")
+		}
+	}
+	return inserter.Flush(ctx)
+}
+
+// upsertPointReferences upserts the repository name and metadata of the given data point, and
+// returns their IDs. If repoNameIDs is not nil, it is used to look up and remember the IDs of
+// repository names, so that they are upserted only once.
+func upsertPointReferences(ctx context.Context, txStore *basestore.Store, v RecordSeriesPointArgs, repoNameIDs map[string]int) (repoNameID, metadataID *int, err error) {
 	if (v.RepoName != nil && v.RepoID == nil) || (v.RepoID != nil && v.RepoName == nil) {
-		return errors.New("RepoName and RepoID must be mutually specified")
+		return nil, nil, errors.New("RepoName and RepoID must be mutually specified")
 	}
 
 	// Upsert the repository name into a separate table, so we get a small ID we can reference
 	// many times from the series_points table without storing the repo name multiple times.
-	var repoNameID *int
 	if v.RepoName != nil {
-		repoNameIDValue, ok, err := basestore.ScanFirstInt(txStore.Query(ctx, sqlf.Sprintf(upsertRepoNameFmtStr, *v.RepoName, *v.RepoName)))
-		if err != nil {
-			return errors.Wrap(err, "upserting repo name ID")
-		}
+		repoNameIDValue, ok := repoNameIDs[*v.RepoName]
 		if !ok {
-			return errors.Wrap(err, "repo name ID not found (this should never happen)")
+			repoNameIDValue, ok, err = basestore.ScanFirstInt(txStore.Query(ctx, sqlf.Sprintf(upsertRepoNameFmtStr, *v.RepoName, *v.RepoName)))
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "upserting repo name ID")
+			}
+			if !ok {
+				return nil, nil, errors.Wrap(err, "repo name ID not found (this should never happen)")
+			}
+			if repoNameIDs != nil {
+				repoNameIDs[*v.RepoName] = repoNameIDValue
+			}
 		}
 		repoNameID = &repoNameIDValue
 	}
 
 	// Upsert the metadata into a separate table, so we get a small ID we can reference many times
 	// from the series_points table without storing the metadata multiple times.
-	var metadataID *int
 	if v.Metadata != nil {
 		jsonMetadata, err := json.Marshal(v.Metadata)
 		if err != nil {
-			return errors.Wrap(err, "upserting: encoding metadata")
+			return nil, nil, errors.Wrap(err, "upserting: encoding metadata")
 		}
 		metadataIDValue, ok, err := basestore.ScanFirstInt(txStore.Query(ctx, sqlf.Sprintf(upsertMetadataFmtStr, jsonMetadata, jsonMetadata)))
 		if err != nil {
-			return errors.Wrap(err, "upserting metadata ID")
+			return nil, nil, errors.Wrap(err, "upserting metadata ID")
 		}
 		if !ok {
-			return errors.Wrap(err, "metadata ID not found (this should never happen)")
+			return nil, nil, errors.Wrap(err, "metadata ID not found (this should never happen)")
 		}
 		metadataID = &metadataIDValue
 	}
-
-	// Insert the actual data point.
-	return txStore.Exec(ctx, sqlf.Sprintf(
-		recordSeriesPointFmtstr,
-		v.SeriesID,         // series_id
-		v.Point.Time.UTC(), // time
-		v.Point.Value,      // value
-		metadataID,         // metadata_id
-		v.RepoID,           // repo_id
-		repoNameID,         // repo_name_id
-		repoNameID,         // original_repo_name_id
-	))
+	return repoNameID, metadataID, nil
 }
 
 const upsertRepoNameFmtStr = `
@@ -409,8 +725,9 @@ INSERT INTO series_points(
 	metadata_id,
 	repo_id,
 	repo_name_id,
-	original_repo_name_id)
-VALUES (%s, %s, %s, %s, %s, %s, %s);
+	original_repo_name_id,
+	capture)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s);
 `
 
 func (s *Store) query(ctx context.Context, q *sqlf.Query, sc scanFunc) error {
@@ -330,6 +330,313 @@ func TestRecordSeriesPoints(t *testing.T) {
 	// autogold.Want("forOriginalRepoNamePoints[0].String()", nil).Equal(t, forOriginalRepoNamePoints[0].String())
 }
 
+func TestRecordSeriesPointsBulk(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	clock := timeutil.Now
+	timescale, cleanup := insightsdbtesting.TimescaleDB(t)
+	defer cleanup()
+	postgres := dbtest.NewDB(t, "")
+	permStore := NewInsightPermissionStore(postgres)
+	store := NewWithClock(timescale, permStore, clock)
+
+	repoName := "repo1"
+	repoID := api.RepoID(3)
+	current := time.Now().Truncate(24 * time.Hour)
+	count := func(seriesID string) int {
+		n, err := store.CountData(ctx, CountDataOpts{SeriesID: &seriesID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	if err := store.RecordSeriesPoints(ctx, []RecordSeriesPointArgs{
+		{SeriesID: "one", Point: SeriesPoint{Time: current, Value: 1}, RepoName: &repoName, RepoID: &repoID},
+		{SeriesID: "one", Point: SeriesPoint{Time: current.Add(-24 * time.Hour), Value: 2}, RepoName: &repoName, RepoID: &repoID},
+		{SeriesID: "one", Point: SeriesPoint{Time: current, Value: 3}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if n := count("one"); n != 3 {
+		t.Fatalf("got %d points, want 3", n)
+	}
+
+	// None of the points are recorded if recording fails partway.
+	if err := store.RecordSeriesPoints(ctx, []RecordSeriesPointArgs{
+		{SeriesID: "two", Point: SeriesPoint{Time: current, Value: 1}, RepoName: &repoName, RepoID: &repoID},
+		{SeriesID: "two", Point: SeriesPoint{Time: current, Value: 2}, RepoName: &repoName},
+	}); err == nil {
+		t.Fatal("expected error recording a point without repository ID")
+	}
+	if n := count("two"); n != 0 {
+		t.Fatalf("got %d points, want 0", n)
+	}
+}
+
+func TestRecordSeriesPointsCaptureGroup(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	clock := timeutil.Now
+	timescale, cleanup := insightsdbtesting.TimescaleDB(t)
+	defer cleanup()
+	postgres := dbtest.NewDB(t, "")
+	permStore := NewInsightPermissionStore(postgres)
+	store := NewWithClock(timescale, permStore, clock)
+
+	optionalString := func(v string) *string { return &v }
+	optionalRepoID := func(v api.RepoID) *api.RepoID { return &v }
+
+	current := time.Now().Truncate(24 * time.Hour)
+
+	// Record the same capture group value in two repositories, and another value in one of them.
+	// A value that was found in one of them before, but isn't anymore, must not be carried forward.
+	for _, record := range []RecordSeriesPointArgs{
+		{
+			SeriesID: "capture",
+			Point:    SeriesPoint{Time: current.Add(-7 * 24 * time.Hour), Value: 8, Capture: optionalString("17")},
+			RepoName: optionalString("repo2"),
+			RepoID:   optionalRepoID(4),
+		},
+		{
+			SeriesID: "capture",
+			Point:    SeriesPoint{Time: current, Value: 1, Capture: optionalString("15")},
+			RepoName: optionalString("repo1"),
+			RepoID:   optionalRepoID(3),
+		},
+		{
+			SeriesID: "capture",
+			Point:    SeriesPoint{Time: current, Value: 2, Capture: optionalString("15")},
+			RepoName: optionalString("repo2"),
+			RepoID:   optionalRepoID(4),
+		},
+		{
+			SeriesID: "capture",
+			Point:    SeriesPoint{Time: current, Value: 4, Capture: optionalString("16")},
+			RepoName: optionalString("repo2"),
+			RepoID:   optionalRepoID(4),
+		},
+	} {
+		if err := store.RecordSeriesPoint(ctx, record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for capture, want := range map[string]float64{"15": 3, "16": 4} {
+		points, err := store.SeriesPoints(ctx, SeriesPointsOpts{Capture: optionalString(capture), Limit: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(points) != 1 {
+			t.Fatalf("capture %q: expected 1 point, got %d", capture, len(points))
+		}
+		if diff := cmp.Diff(SeriesPoint{SeriesID: "capture", Time: current, Value: want, Capture: optionalString(capture)}, points[0]); diff != "" {
+			t.Errorf("capture %q: unexpected point: %v", capture, diff)
+		}
+	}
+
+	points, err := store.SeriesPoints(ctx, SeriesPointsOpts{Capture: optionalString("17")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 0 {
+		t.Fatalf("expected the value no longer found to not be carried forward, got %v", points)
+	}
+
+	captures, err := store.SeriesCaptures(ctx, "capture")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"15", "16", "17"}, captures); diff != "" {
+		t.Errorf("unexpected captures (-want +got):\n%s", diff)
+	}
+}
+
+func TestSeriesPointsPerInterval(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	clock := timeutil.Now
+	timescale, cleanup := insightsdbtesting.TimescaleDB(t)
+	defer cleanup()
+	postgres := dbtest.NewDB(t, "")
+	permStore := NewInsightPermissionStore(postgres)
+	store := NewWithClock(timescale, permStore, clock)
+
+	optionalString := func(v string) *string { return &v }
+	optionalRepoID := func(v api.RepoID) *api.RepoID { return &v }
+
+	current := time.Now().Truncate(24 * time.Hour)
+	previous := current.Add(-7 * 24 * time.Hour)
+
+	// Record commits in two repositories in the previous interval, and none in the current one.
+	if err := store.RecordSeriesPoints(ctx, []RecordSeriesPointArgs{
+		{
+			SeriesID: "commits",
+			Point:    SeriesPoint{Time: previous, Value: 2},
+			RepoName: optionalString("repo1"),
+			RepoID:   optionalRepoID(3),
+		},
+		{
+			SeriesID: "commits",
+			Point:    SeriesPoint{Time: previous, Value: 3},
+			RepoName: optionalString("repo2"),
+			RepoID:   optionalRepoID(4),
+		},
+		{
+			SeriesID: "commits",
+			Point:    SeriesPoint{Time: current, Value: 0},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	points, err := store.SeriesPoints(ctx, SeriesPointsOpts{SeriesID: optionalString("commits"), PerInterval: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []SeriesPoint{
+		{SeriesID: "commits", Time: current, Value: 0},
+		{SeriesID: "commits", Time: previous, Value: 5},
+	}
+	if diff := cmp.Diff(want, points); diff != "" {
+		t.Errorf("unexpected points (-want +got):\n%s", diff)
+	}
+}
+
+func TestRepoSeriesPoints(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	clock := timeutil.Now
+	timescale, cleanup := insightsdbtesting.TimescaleDB(t)
+	defer cleanup()
+	postgres := dbtest.NewDB(t, "")
+	permStore := NewInsightPermissionStore(postgres)
+	store := NewWithClock(timescale, permStore, clock)
+
+	optionalString := func(v string) *string { return &v }
+	optionalRepoID := func(v api.RepoID) *api.RepoID { return &v }
+
+	current := time.Now().Truncate(24 * time.Hour).UTC()
+	past := current.Add(-time.Hour * 24 * 14)
+
+	for _, record := range []RecordSeriesPointArgs{
+		{SeriesID: "one", Point: SeriesPoint{Time: past, Value: 5}, RepoName: optionalString("github.com/a/one"), RepoID: optionalRepoID(1)},
+		{SeriesID: "one", Point: SeriesPoint{Time: current, Value: 1}, RepoName: optionalString("github.com/a/one"), RepoID: optionalRepoID(1)},
+		{SeriesID: "one", Point: SeriesPoint{Time: past, Value: 3}, RepoName: optionalString("github.com/b/two"), RepoID: optionalRepoID(2)},
+		{SeriesID: "one", Point: SeriesPoint{Time: current, Value: 2}, RepoName: optionalString("github.com/b/three"), RepoID: optionalRepoID(3)},
+		{SeriesID: "two", Point: SeriesPoint{Time: current, Value: 10}, RepoName: optionalString("github.com/a/one"), RepoID: optionalRepoID(1)},
+	} {
+		if err := store.RecordSeriesPoint(ctx, record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		opts RepoSeriesPointsOpts
+		want []RepoSeriesPoint
+	}{
+		"latest values": {
+			opts: RepoSeriesPointsOpts{SeriesID: "one", Time: current},
+			want: []RepoSeriesPoint{
+				{RepoID: 2, RepoName: "github.com/b/two", Time: past, Value: 3},
+				{RepoID: 3, RepoName: "github.com/b/three", Time: current, Value: 2},
+				{RepoID: 1, RepoName: "github.com/a/one", Time: current, Value: 1},
+			},
+		},
+		"values in the past": {
+			opts: RepoSeriesPointsOpts{SeriesID: "one", Time: past},
+			want: []RepoSeriesPoint{
+				{RepoID: 1, RepoName: "github.com/a/one", Time: past, Value: 5},
+				{RepoID: 2, RepoName: "github.com/b/two", Time: past, Value: 3},
+			},
+		},
+		"limit": {
+			opts: RepoSeriesPointsOpts{SeriesID: "one", Time: current, Limit: 1},
+			want: []RepoSeriesPoint{
+				{RepoID: 2, RepoName: "github.com/b/two", Time: past, Value: 3},
+			},
+		},
+		"include and exclude regex": {
+			opts: RepoSeriesPointsOpts{SeriesID: "one", Time: current, IncludeRepoRegex: "github.com/b/", ExcludeRepoRegex: "two$"},
+			want: []RepoSeriesPoint{
+				{RepoID: 3, RepoName: "github.com/b/three", Time: current, Value: 2},
+			},
+		},
+		"exclude list": {
+			opts: RepoSeriesPointsOpts{SeriesID: "one", Time: current, Excluded: []api.RepoID{2, 3}},
+			want: []RepoSeriesPoint{
+				{RepoID: 1, RepoName: "github.com/a/one", Time: current, Value: 1},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			points, err := store.RepoSeriesPoints(ctx, tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, points); diff != "" {
+				t.Errorf("unexpected points (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestExportSeriesPoints(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	clock := timeutil.Now
+	timescale, cleanup := insightsdbtesting.TimescaleDB(t)
+	defer cleanup()
+	postgres := dbtest.NewDB(t, "")
+	permStore := NewInsightPermissionStore(postgres)
+	store := NewWithClock(timescale, permStore, clock)
+
+	optionalString := func(v string) *string { return &v }
+	optionalRepoID := func(v api.RepoID) *api.RepoID { return &v }
+
+	current := time.Now().Truncate(24 * time.Hour).UTC()
+	past := current.Add(-time.Hour * 24 * 14)
+
+	for _, record := range []RecordSeriesPointArgs{
+		{SeriesID: "one", Point: SeriesPoint{Time: current, Value: 1}, RepoName: optionalString("github.com/a/one"), RepoID: optionalRepoID(1)},
+		{SeriesID: "one", Point: SeriesPoint{Time: past, Value: 5, Capture: optionalString("Go")}, RepoName: optionalString("github.com/a/one"), RepoID: optionalRepoID(1)},
+		{SeriesID: "one", Point: SeriesPoint{Time: past, Value: 3}},
+		{SeriesID: "two", Point: SeriesPoint{Time: current, Value: 10}, RepoName: optionalString("github.com/a/one"), RepoID: optionalRepoID(1)},
+	} {
+		if err := store.RecordSeriesPoint(ctx, record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	points, err := store.ExportSeriesPoints(ctx, "one")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []ExportedSeriesPoint{
+		{SeriesID: "one", Time: past, Value: 5, RepoName: optionalString("github.com/a/one"), Capture: optionalString("Go")},
+		{SeriesID: "one", Time: past, Value: 3},
+		{SeriesID: "one", Time: current, Value: 1, RepoName: optionalString("github.com/a/one")},
+	}
+	if diff := cmp.Diff(want, points); diff != "" {
+		t.Errorf("unexpected points (-want +got): %v", diff)
+	}
+}
+
 func TestValues(t *testing.T) {
 	ids := []api.RepoID{1, 2, 3, 4, 5, 6}
 	got := values(ids)
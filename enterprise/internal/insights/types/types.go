@@ -18,6 +18,21 @@ type InsightViewSeries struct {
 	RecordingIntervalDays int
 	Label                 string
 	Stroke                string
+
+	// GeneratedFromCaptureGroups indicates that the series is split into one
+	// series per distinct value of the first capture group in Query.
+	GeneratedFromCaptureGroups bool
+
+	// SeriesType is the kind of data recorded for the series, and
+	// Repositories the repositories of a language statistics series.
+	SeriesType   SeriesType
+	Repositories []string
+
+	// CommitAggregation is what a commit series counts per interval.
+	CommitAggregation CommitAggregation
+
+	// BackfillState is whether historical data is backfilled for the series.
+	BackfillState BackfillState
 }
 
 type Insight struct {
@@ -52,4 +67,138 @@ type InsightSeries struct {
 	LastRecordedAt        time.Time
 	NextRecordingAfter    time.Time
 	RecordingIntervalDays int
+
+	// GeneratedFromCaptureGroups indicates that the data points of the series
+	// are recorded per distinct value of the first capture group in Query,
+	// instead of per match of Query.
+	GeneratedFromCaptureGroups bool
+
+	// SeriesType is the kind of data recorded for the series.
+	SeriesType SeriesType
+
+	// Repositories are the names of the repositories that a language
+	// statistics series records data for.
+	Repositories []string
+
+	// CommitAggregation is what a commit series counts per interval of
+	// commits matching Query. Empty for all other series.
+	CommitAggregation CommitAggregation
+
+	// BackfillState is whether historical data is backfilled for the series.
+	BackfillState BackfillState
+}
+
+// SeriesType is the kind of data recorded for an insight series.
+type SeriesType string
+
+const (
+	// SearchSeries record the number of matches of a search query.
+	SearchSeries SeriesType = "search"
+
+	// LanguageStatsSeries record the number of lines per language in a set
+	// of repositories, one data point per repository and language.
+	LanguageStatsSeries SeriesType = "language_stats"
+
+	// CommitSeries record an aggregate of the commits matching a commit or
+	// diff search query per interval.
+	CommitSeries SeriesType = "commit"
+)
+
+// CommitAggregation is what a commit series counts of the commits matching its
+// query in each interval.
+type CommitAggregation string
+
+const (
+	// CommitCount counts the matching commits.
+	CommitCount CommitAggregation = "commits"
+
+	// DistinctAuthors counts the distinct authors of the matching commits
+	// across all repositories, recorded as a single data point per interval
+	// that isn't associated with a repository.
+	DistinctAuthors CommitAggregation = "authors"
+)
+
+// Valid reports whether a is a known commit aggregation.
+func (a CommitAggregation) Valid() bool {
+	return a == CommitCount || a == DistinctAuthors
+}
+
+// BackfillState is whether historical data is backfilled for an insight series.
+type BackfillState string
+
+const (
+	// BackfillActive series are backfilled by the historical enqueuer.
+	BackfillActive BackfillState = "active"
+
+	// BackfillPaused series are not backfilled, and their queued historical
+	// jobs are paused until the backfill is resumed.
+	BackfillPaused BackfillState = "paused"
+
+	// BackfillCanceled series are not backfilled, and their queued historical
+	// jobs were deleted. Resuming the backfill enqueues them again.
+	BackfillCanceled BackfillState = "canceled"
+)
+
+// InsightSeriesAlert is an alert rule attached to an insight series. It notifies its user when the
+// value of the series crosses a threshold.
+type InsightSeriesAlert struct {
+	ID       int
+	SeriesID string
+	// Capture is the capture group value (or language) of the series to evaluate, for series that
+	// are split into one series per value.
+	Capture   *string
+	Condition AlertCondition
+	Threshold float64
+	// WindowDays is the number of days to compare the current value with for percentage
+	// conditions.
+	WindowDays   int
+	UserID       int32
+	EmailEnabled bool
+	WebhookURL   *string
+	CreatedAt    time.Time
+
+	LastEvaluatedAt *time.Time
+	// Triggered indicates that the condition held at the last evaluation.
+	Triggered bool
+}
+
+// AlertCondition is the condition under which an insight series alert triggers.
+type AlertCondition string
+
+const (
+	// AlertAbove triggers when the value is greater than the threshold.
+	AlertAbove AlertCondition = "above"
+	// AlertBelow triggers when the value is less than the threshold.
+	AlertBelow AlertCondition = "below"
+	// AlertIncreasePercent triggers when the value increased by at least threshold percent
+	// compared to the value WindowDays earlier.
+	AlertIncreasePercent AlertCondition = "increase_percent"
+	// AlertDecreasePercent triggers when the value decreased by at least threshold percent
+	// compared to the value WindowDays earlier.
+	AlertDecreasePercent AlertCondition = "decrease_percent"
+)
+
+// Valid returns whether c is a known alert condition.
+func (c AlertCondition) Valid() bool {
+	switch c {
+	case AlertAbove, AlertBelow, AlertIncreasePercent, AlertDecreasePercent:
+		return true
+	}
+	return false
+}
+
+// Percentage returns whether the threshold of c is a percentage of the previous value.
+func (c AlertCondition) Percentage() bool {
+	return c == AlertIncreasePercent || c == AlertDecreasePercent
+}
+
+// InsightSeriesAlertEvent is a single time an insight series alert triggered.
+type InsightSeriesAlertEvent struct {
+	ID            int
+	AlertID       int
+	Value         float64
+	PreviousValue *float64
+	TriggeredAt   time.Time
+	EmailError    *string
+	WebhookError  *string
 }
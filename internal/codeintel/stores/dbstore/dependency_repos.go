@@ -0,0 +1,99 @@
+package dbstore
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// DependencyRepo is a package version referenced by a precise code intelligence index.
+type DependencyRepo struct {
+	ID      int
+	Scheme  string
+	Name    string
+	Version string
+}
+
+// scanDependencyRepos scans a slice of dependency repos from the return value of `*Store.query`.
+func scanDependencyRepos(rows *sql.Rows, queryErr error) (dependencyRepos []DependencyRepo, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	for rows.Next() {
+		var dependencyRepo DependencyRepo
+		if err := rows.Scan(&dependencyRepo.ID, &dependencyRepo.Scheme, &dependencyRepo.Name, &dependencyRepo.Version); err != nil {
+			return nil, err
+		}
+
+		dependencyRepos = append(dependencyRepos, dependencyRepo)
+	}
+
+	return dependencyRepos, nil
+}
+
+// GetDependencyReposOptions filters the results of GetDependencyRepos.
+type GetDependencyReposOptions struct {
+	// Scheme is the moniker scheme of the returned packages.
+	Scheme string
+
+	// Name, if set, restricts results to versions of the package with the given name.
+	Name string
+}
+
+// GetDependencyRepos returns the package versions with the given scheme (and optionally name), ordered
+// by name and then by insertion order.
+func (s *Store) GetDependencyRepos(ctx context.Context, opts GetDependencyReposOptions) (_ []DependencyRepo, err error) {
+	ctx, endObservation := s.operations.getDependencyRepos.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("scheme", opts.Scheme),
+		log.String("name", opts.Name),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	conds := []*sqlf.Query{sqlf.Sprintf("scheme = %s", opts.Scheme)}
+	if opts.Name != "" {
+		conds = append(conds, sqlf.Sprintf("name = %s", opts.Name))
+	}
+
+	return scanDependencyRepos(s.Store.Query(ctx, sqlf.Sprintf(getDependencyReposQuery, sqlf.Join(conds, "AND"))))
+}
+
+const getDependencyReposQuery = `
+-- source: internal/codeintel/stores/dbstore/dependency_repos.go:GetDependencyRepos
+SELECT id, scheme, name, version
+FROM lsif_dependency_repos
+WHERE %s
+ORDER BY name, id
+`
+
+// InsertDependencyRepo records a package version referenced by a precise code intelligence index. The
+// returned flag is false if the package version was already recorded.
+func (s *Store) InsertDependencyRepo(ctx context.Context, scheme, name, version string) (isNew bool, err error) {
+	ctx, endObservation := s.operations.insertDependencyRepo.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("scheme", scheme),
+		log.String("name", name),
+		log.String("version", version),
+	}})
+	defer func() {
+		endObservation(1, observation.Args{LogFields: []log.Field{
+			log.Bool("isNew", isNew),
+		}})
+	}()
+
+	_, isNew, err = basestore.ScanFirstInt(s.Store.Query(ctx, sqlf.Sprintf(insertDependencyRepoQuery, scheme, name, version)))
+	return isNew, err
+}
+
+const insertDependencyRepoQuery = `
+-- source: internal/codeintel/stores/dbstore/dependency_repos.go:InsertDependencyRepo
+INSERT INTO lsif_dependency_repos (scheme, name, version)
+VALUES (%s, %s, %s)
+ON CONFLICT DO NOTHING
+RETURNING id
+`
@@ -0,0 +1,59 @@
+package dbstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtesting"
+)
+
+func TestDependencyRepos(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtesting.GetDB(t)
+	store := testStore(db)
+
+	for _, dep := range []struct{ scheme, name, version string }{
+		{"gomod", "golang.org/x/tools", "v0.1.5"},
+		{"gomod", "golang.org/x/mod", "v0.4.2"},
+		{"gomod", "golang.org/x/tools", "v0.1.4"},
+		{"semanticdb", "org.example:lib", "1.0.0"},
+	} {
+		if isNew, err := store.InsertDependencyRepo(context.Background(), dep.scheme, dep.name, dep.version); err != nil {
+			t.Fatalf("unexpected error inserting dependency repo: %s", err)
+		} else if !isNew {
+			t.Errorf("expected dependency repo %s@%s to be new", dep.name, dep.version)
+		}
+	}
+
+	if isNew, err := store.InsertDependencyRepo(context.Background(), "gomod", "golang.org/x/tools", "v0.1.5"); err != nil {
+		t.Fatalf("unexpected error inserting dependency repo: %s", err)
+	} else if isNew {
+		t.Errorf("expected duplicate dependency repo to not be new")
+	}
+
+	dependencyRepos, err := store.GetDependencyRepos(context.Background(), GetDependencyReposOptions{Scheme: "gomod"})
+	if err != nil {
+		t.Fatalf("unexpected error getting dependency repos: %s", err)
+	}
+
+	var versions []string
+	for _, dependencyRepo := range dependencyRepos {
+		versions = append(versions, dependencyRepo.Name+"@"+dependencyRepo.Version)
+	}
+	expectedVersions := []string{"golang.org/x/mod@v0.4.2", "golang.org/x/tools@v0.1.5", "golang.org/x/tools@v0.1.4"}
+	if diff := cmp.Diff(expectedVersions, versions); diff != "" {
+		t.Errorf("unexpected dependency repos (-want +got):\n%s", diff)
+	}
+
+	dependencyRepos, err = store.GetDependencyRepos(context.Background(), GetDependencyReposOptions{Scheme: "gomod", Name: "golang.org/x/mod"})
+	if err != nil {
+		t.Fatalf("unexpected error getting dependency repos: %s", err)
+	}
+	if len(dependencyRepos) != 1 || dependencyRepos[0].Version != "v0.4.2" {
+		t.Errorf("unexpected dependency repos: %+v", dependencyRepos)
+	}
+}
@@ -8,7 +8,9 @@ import (
 )
 
 type Operations struct {
-	repoName *observation.Operation
+	getDependencyRepos   *observation.Operation
+	insertDependencyRepo *observation.Operation
+	repoName             *observation.Operation
 }
 
 func NewOperationsMetrics(observationContext *observation.Context) *metrics.OperationMetrics {
@@ -30,6 +32,8 @@ func NewOperationsFromMetrics(observationContext *observation.Context, metrics *
 	}
 
 	return &Operations{
-		repoName: op("RepoName"),
+		getDependencyRepos:   op("GetDependencyRepos"),
+		insertDependencyRepo: op("InsertDependencyRepo"),
+		repoName:             op("RepoName"),
 	}
 }
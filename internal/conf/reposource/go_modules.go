@@ -0,0 +1,92 @@
+package reposource
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// GoModule is a Go module identified by its module path, e.g. "golang.org/x/tools".
+type GoModule struct {
+	Path string
+}
+
+// RepoName returns the name of the synthetic repository that mirrors the sources of the module.
+func (m *GoModule) RepoName() api.RepoName {
+	return api.RepoName("go/" + m.Path)
+}
+
+func (m *GoModule) CloneURL() string {
+	cloneURL := url.URL{Path: string(m.RepoName())}
+	return cloneURL.String()
+}
+
+type GoDependency struct {
+	GoModule
+	Version         string
+	SemanticVersion *semver.Version
+}
+
+// String returns the dependency in the "module@version" syntax used by the go command.
+func (d *GoDependency) String() string {
+	return d.Path + "@" + d.Version
+}
+
+// GitTagFromVersion returns the name of the git tag holding the sources of this version.
+// Go module versions are already prefixed with "v", so they are used verbatim.
+func (d *GoDependency) GitTagFromVersion() string {
+	return d.Version
+}
+
+// SortGoDependencies sorts the dependencies by the semantic version in descending
+// order. The latest version of a dependency becomes the first element of the
+// slice
+func SortGoDependencies(dependencies []GoDependency) {
+	sort.Slice(dependencies, func(i, j int) bool {
+		if dependencies[i].GoModule == dependencies[j].GoModule {
+			a, b := dependencies[i].SemanticVersion, dependencies[j].SemanticVersion
+			if a == nil || b == nil {
+				return dependencies[i].Version > dependencies[j].Version
+			}
+			return a.GreaterThan(b)
+		}
+		return dependencies[i].Path > dependencies[j].Path
+	})
+}
+
+// ParseGoDependency parses a dependency in the "module@version" syntax.
+func ParseGoDependency(dependency string) (GoDependency, error) {
+	i := strings.LastIndex(dependency, "@")
+	if i <= 0 || i == len(dependency)-1 {
+		return GoDependency{}, fmt.Errorf("dependency %q must be of the form module@version", dependency)
+	}
+
+	return NewGoDependency(dependency[:i], dependency[i+1:]), nil
+}
+
+// NewGoDependency returns the dependency on the given version of the given module.
+func NewGoDependency(modulePath, version string) GoDependency {
+	// Pseudo-versions and +incompatible versions are still valid semantic
+	// versions. Anything else falls back to lexicographical ordering.
+	semanticVersion, _ := semver.NewVersion(version)
+
+	return GoDependency{
+		GoModule:        GoModule{Path: modulePath},
+		Version:         version,
+		SemanticVersion: semanticVersion,
+	}
+}
+
+// ParseGoModule returns a parsed Go module from the provided URL path, without a leading `/`
+func ParseGoModule(urlPath string) (GoModule, error) {
+	if !strings.HasPrefix(urlPath, "go/") || len(urlPath) == len("go/") {
+		return GoModule{}, fmt.Errorf("failed to parse a Go module from the path %s", urlPath)
+	}
+
+	return GoModule{Path: strings.TrimPrefix(urlPath, "go/")}, nil
+}
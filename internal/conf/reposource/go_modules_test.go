@@ -0,0 +1,54 @@
+package reposource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestParseGoModule(t *testing.T) {
+	obtained, err := ParseGoModule("go/golang.org/x/tools")
+	assert.Nil(t, err)
+	assert.Equal(t, "golang.org/x/tools", obtained.Path)
+	assert.Equal(t, api.RepoName("go/golang.org/x/tools"), obtained.RepoName())
+
+	_, err = ParseGoModule("maven/org.hamcrest/hamcrest-core")
+	assert.NotNil(t, err)
+}
+
+func TestParseGoDependency(t *testing.T) {
+	obtained, err := ParseGoDependency("github.com/Azure/go-autorest@v14.2.0+incompatible")
+	assert.Nil(t, err)
+	assert.Equal(t, "github.com/Azure/go-autorest", obtained.Path)
+	assert.Equal(t, "v14.2.0+incompatible", obtained.GitTagFromVersion())
+	assert.Equal(t, "github.com/Azure/go-autorest@v14.2.0+incompatible", obtained.String())
+
+	for _, dependency := range []string{"golang.org/x/tools", "@v1.0.0", "golang.org/x/tools@"} {
+		if _, err := ParseGoDependency(dependency); err == nil {
+			t.Errorf("expected error parsing %q", dependency)
+		}
+	}
+}
+
+func TestSortGoDependencies(t *testing.T) {
+	dependencies := []GoDependency{
+		NewGoDependency("a/c", "v1.2.0"),
+		NewGoDependency("a/a", "v1.2.0"),
+		NewGoDependency("a/b", "v1.2.0"),
+		NewGoDependency("a/b", "v1.11.0"),
+		NewGoDependency("a/b", "v0.0.0-20210101000000-abcdefabcdef"),
+		NewGoDependency("a/b", "v1.1.0"),
+	}
+	expected := []GoDependency{
+		NewGoDependency("a/c", "v1.2.0"),
+		NewGoDependency("a/b", "v1.11.0"),
+		NewGoDependency("a/b", "v1.2.0"),
+		NewGoDependency("a/b", "v1.1.0"),
+		NewGoDependency("a/b", "v0.0.0-20210101000000-abcdefabcdef"),
+		NewGoDependency("a/a", "v1.2.0"),
+	}
+	SortGoDependencies(dependencies)
+	assert.Equal(t, expected, dependencies)
+}
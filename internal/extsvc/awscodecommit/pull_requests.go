@@ -0,0 +1,355 @@
+package awscodecommit
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/codecommit"
+	codecommittypes "github.com/aws/aws-sdk-go-v2/service/codecommit/types"
+	"github.com/cockroachdb/errors"
+)
+
+// PullRequestStatus is the status of an AWS CodeCommit pull request.
+type PullRequestStatus string
+
+const (
+	PullRequestStatusOpen   PullRequestStatus = "OPEN"
+	PullRequestStatusClosed PullRequestStatus = "CLOSED"
+)
+
+// PullRequest is an AWS CodeCommit pull request.
+type PullRequest struct {
+	ID               string            // the system-generated ID of the pull request
+	Title            string            // the title of the pull request
+	Description      string            // the description of the pull request
+	Status           PullRequestStatus // the status of the pull request
+	AuthorARN        string            // the ARN of the user who created the pull request
+	RevisionID       string            // the ID of the current revision of the pull request
+	Region           string            // the AWS region of the repository of the pull request
+	CreationDate     time.Time         // the date the pull request was created
+	LastActivityDate time.Time         // the date of the most recent change to the pull request
+	Targets          []*PullRequestTarget
+
+	// Approvals are the approval states of the current revision of the pull
+	// request. They are not returned by the pull request endpoints and have
+	// to be loaded with (*Client).LoadPullRequestApprovals.
+	Approvals []*Approval
+}
+
+// PullRequestTarget is a source and destination branch pair of a pull request
+// in a repository.
+type PullRequestTarget struct {
+	RepositoryName       string
+	SourceReference      string // the full ref of the source branch
+	DestinationReference string // the full ref of the destination branch
+	SourceCommit         string // the tip of the source branch
+	DestinationCommit    string // the tip of the destination branch
+	MergeBase            string
+	IsMerged             bool
+	MergeCommitID        string
+	MergedBy             string // the ARN of the user who merged the pull request
+}
+
+// Approval is the approval state of a single user on a pull request.
+type Approval struct {
+	UserARN  string
+	Approved bool
+}
+
+// Target returns the target of the pull request. Pull requests created by
+// Sourcegraph always have exactly one target. If the pull request has no
+// targets, an empty target is returned.
+func (pr *PullRequest) Target() *PullRequestTarget {
+	if len(pr.Targets) == 0 {
+		return &PullRequestTarget{}
+	}
+	return pr.Targets[0]
+}
+
+// IsMerged reports whether the pull request has been merged.
+func (pr *PullRequest) IsMerged() bool {
+	return pr.Target().IsMerged
+}
+
+// AuthorName returns the name of the IAM user or role that created the pull
+// request, which is the last segment of the author ARN.
+func (pr *PullRequest) AuthorName() string {
+	if i := strings.LastIndex(pr.AuthorARN, "/"); i >= 0 {
+		return pr.AuthorARN[i+1:]
+	}
+	return pr.AuthorARN
+}
+
+// URL returns the URL of the pull request in the AWS console.
+func (pr *PullRequest) URL() string {
+	return fmt.Sprintf(
+		"https://%s.console.aws.amazon.com/codesuite/codecommit/repositories/%s/pull-requests/%s/details?region=%s",
+		pr.Region,
+		url.PathEscape(pr.Target().RepositoryName),
+		url.PathEscape(pr.ID),
+		url.QueryEscape(pr.Region),
+	)
+}
+
+// ErrPullRequestNotFound is when the requested AWS CodeCommit pull request is
+// not found.
+var ErrPullRequestNotFound = errors.New("AWS CodeCommit pull request not found")
+
+// IsPullRequestNotFound reports whether err is a AWS CodeCommit API error
+// indicating that a pull request doesn't exist.
+func IsPullRequestNotFound(err error) bool {
+	return errors.Is(err, ErrPullRequestNotFound) ||
+		errors.HasType(err, &codecommittypes.PullRequestDoesNotExistException{})
+}
+
+// IsNotMergeable reports whether err is a AWS CodeCommit API error indicating
+// that a pull request cannot be merged in its current state.
+func IsNotMergeable(err error) bool {
+	return errors.HasType(err, &codecommittypes.ManualMergeRequiredException{}) ||
+		errors.HasType(err, &codecommittypes.TipOfSourceReferenceIsDifferentException{}) ||
+		errors.HasType(err, &codecommittypes.TipsDivergenceExceededException{}) ||
+		errors.HasType(err, &codecommittypes.PullRequestApprovalRulesNotSatisfiedException{}) ||
+		errors.HasType(err, &codecommittypes.PullRequestAlreadyClosedException{})
+}
+
+// CreatePullRequestInput contains the parameters to create a pull request.
+type CreatePullRequestInput struct {
+	RepositoryName       string
+	SourceReference      string
+	DestinationReference string
+	Title                string
+	Description          string
+}
+
+// CreatePullRequest creates a pull request in the given repository.
+func (c *Client) CreatePullRequest(ctx context.Context, in CreatePullRequestInput) (*PullRequest, error) {
+	svc := codecommit.NewFromConfig(c.aws)
+	result, err := svc.CreatePullRequest(ctx, &codecommit.CreatePullRequestInput{
+		Title:       &in.Title,
+		Description: &in.Description,
+		Targets: []codecommittypes.Target{{
+			RepositoryName:       &in.RepositoryName,
+			SourceReference:      &in.SourceReference,
+			DestinationReference: &in.DestinationReference,
+		}},
+	})
+	if err != nil {
+		return nil, &wrappedError{err: err}
+	}
+	return c.fromPullRequest(result.PullRequest), nil
+}
+
+// GetPullRequest gets the pull request with the given ID.
+func (c *Client) GetPullRequest(ctx context.Context, id string) (*PullRequest, error) {
+	svc := codecommit.NewFromConfig(c.aws)
+	result, err := svc.GetPullRequest(ctx, &codecommit.GetPullRequestInput{PullRequestId: &id})
+	if err != nil {
+		return nil, &wrappedError{err: err}
+	}
+	return c.fromPullRequest(result.PullRequest), nil
+}
+
+// GetOpenPullRequestByRefs returns the open pull request in the given
+// repository that merges the source into the destination reference. If no
+// such pull request exists, ErrPullRequestNotFound is returned.
+func (c *Client) GetOpenPullRequestByRefs(ctx context.Context, repositoryName, source, destination string) (*PullRequest, error) {
+	svc := codecommit.NewFromConfig(c.aws)
+
+	var nextToken *string
+	for {
+		result, err := svc.ListPullRequests(ctx, &codecommit.ListPullRequestsInput{
+			RepositoryName:    &repositoryName,
+			PullRequestStatus: codecommittypes.PullRequestStatusEnumOpen,
+			NextToken:         nextToken,
+		})
+		if err != nil {
+			return nil, &wrappedError{err: err}
+		}
+
+		// The list endpoint only returns the IDs, so we have to fetch every
+		// pull request to compare its branches.
+		for _, id := range result.PullRequestIds {
+			pr, err := c.GetPullRequest(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			t := pr.Target()
+			if t.SourceReference == source && t.DestinationReference == destination {
+				return pr, nil
+			}
+		}
+
+		if result.NextToken == nil {
+			return nil, ErrPullRequestNotFound
+		}
+		nextToken = result.NextToken
+	}
+}
+
+// UpdatePullRequest updates the title and description of the given pull
+// request, if they changed.
+func (c *Client) UpdatePullRequest(ctx context.Context, pr *PullRequest, title, description string) (*PullRequest, error) {
+	svc := codecommit.NewFromConfig(c.aws)
+
+	updated := pr
+	if title != pr.Title {
+		result, err := svc.UpdatePullRequestTitle(ctx, &codecommit.UpdatePullRequestTitleInput{
+			PullRequestId: &pr.ID,
+			Title:         &title,
+		})
+		if err != nil {
+			return nil, &wrappedError{err: err}
+		}
+		updated = c.fromPullRequest(result.PullRequest)
+	}
+	if description != pr.Description {
+		result, err := svc.UpdatePullRequestDescription(ctx, &codecommit.UpdatePullRequestDescriptionInput{
+			PullRequestId: &pr.ID,
+			Description:   &description,
+		})
+		if err != nil {
+			return nil, &wrappedError{err: err}
+		}
+		updated = c.fromPullRequest(result.PullRequest)
+	}
+	return updated, nil
+}
+
+// UpdatePullRequestStatus opens or closes the given pull request.
+func (c *Client) UpdatePullRequestStatus(ctx context.Context, pr *PullRequest, status PullRequestStatus) (*PullRequest, error) {
+	svc := codecommit.NewFromConfig(c.aws)
+	result, err := svc.UpdatePullRequestStatus(ctx, &codecommit.UpdatePullRequestStatusInput{
+		PullRequestId:     &pr.ID,
+		PullRequestStatus: codecommittypes.PullRequestStatusEnum(status),
+	})
+	if err != nil {
+		return nil, &wrappedError{err: err}
+	}
+	return c.fromPullRequest(result.PullRequest), nil
+}
+
+// MergePullRequest merges the given pull request. If squash is true, the
+// changes are squashed into a single commit, otherwise a merge commit is
+// created. The merge fails if the source branch has changed since the pull
+// request was loaded.
+func (c *Client) MergePullRequest(ctx context.Context, pr *PullRequest, squash bool) (*PullRequest, error) {
+	svc := codecommit.NewFromConfig(c.aws)
+	t := pr.Target()
+
+	var (
+		merged *codecommittypes.PullRequest
+		err    error
+	)
+	if squash {
+		var result *codecommit.MergePullRequestBySquashOutput
+		result, err = svc.MergePullRequestBySquash(ctx, &codecommit.MergePullRequestBySquashInput{
+			PullRequestId:  &pr.ID,
+			RepositoryName: &t.RepositoryName,
+			SourceCommitId: &t.SourceCommit,
+		})
+		if err == nil {
+			merged = result.PullRequest
+		}
+	} else {
+		var result *codecommit.MergePullRequestByThreeWayOutput
+		result, err = svc.MergePullRequestByThreeWay(ctx, &codecommit.MergePullRequestByThreeWayInput{
+			PullRequestId:  &pr.ID,
+			RepositoryName: &t.RepositoryName,
+			SourceCommitId: &t.SourceCommit,
+		})
+		if err == nil {
+			merged = result.PullRequest
+		}
+	}
+	if err != nil {
+		return nil, &wrappedError{err: err}
+	}
+	return c.fromPullRequest(merged), nil
+}
+
+// CreatePullRequestComment posts a general comment on the given pull request.
+func (c *Client) CreatePullRequestComment(ctx context.Context, pr *PullRequest, content string) error {
+	svc := codecommit.NewFromConfig(c.aws)
+	t := pr.Target()
+	_, err := svc.PostCommentForPullRequest(ctx, &codecommit.PostCommentForPullRequestInput{
+		PullRequestId:  &pr.ID,
+		RepositoryName: &t.RepositoryName,
+		BeforeCommitId: &t.DestinationCommit,
+		AfterCommitId:  &t.SourceCommit,
+		Content:        &content,
+	})
+	if err != nil {
+		return &wrappedError{err: err}
+	}
+	return nil
+}
+
+// LoadPullRequestApprovals loads the approval states of the current revision
+// of the given pull request into its Approvals.
+func (c *Client) LoadPullRequestApprovals(ctx context.Context, pr *PullRequest) error {
+	svc := codecommit.NewFromConfig(c.aws)
+	result, err := svc.GetPullRequestApprovalStates(ctx, &codecommit.GetPullRequestApprovalStatesInput{
+		PullRequestId: &pr.ID,
+		RevisionId:    &pr.RevisionID,
+	})
+	if err != nil {
+		return &wrappedError{err: err}
+	}
+
+	pr.Approvals = make([]*Approval, 0, len(result.Approvals))
+	for _, a := range result.Approvals {
+		pr.Approvals = append(pr.Approvals, &Approval{
+			UserARN:  stringValue(a.UserArn),
+			Approved: a.ApprovalState == codecommittypes.ApprovalStateApprove,
+		})
+	}
+	return nil
+}
+
+func (c *Client) fromPullRequest(p *codecommittypes.PullRequest) *PullRequest {
+	pr := PullRequest{
+		ID:          stringValue(p.PullRequestId),
+		Title:       stringValue(p.Title),
+		Description: stringValue(p.Description),
+		Status:      PullRequestStatus(p.PullRequestStatus),
+		AuthorARN:   stringValue(p.AuthorArn),
+		RevisionID:  stringValue(p.RevisionId),
+		Region:      c.aws.Region,
+	}
+	if p.CreationDate != nil {
+		pr.CreationDate = *p.CreationDate
+	}
+	if p.LastActivityDate != nil {
+		pr.LastActivityDate = *p.LastActivityDate
+	}
+
+	pr.Targets = make([]*PullRequestTarget, 0, len(p.PullRequestTargets))
+	for _, t := range p.PullRequestTargets {
+		target := PullRequestTarget{
+			RepositoryName:       stringValue(t.RepositoryName),
+			SourceReference:      stringValue(t.SourceReference),
+			DestinationReference: stringValue(t.DestinationReference),
+			SourceCommit:         stringValue(t.SourceCommit),
+			DestinationCommit:    stringValue(t.DestinationCommit),
+			MergeBase:            stringValue(t.MergeBase),
+		}
+		if m := t.MergeMetadata; m != nil {
+			target.IsMerged = m.IsMerged
+			target.MergeCommitID = stringValue(m.MergeCommitId)
+			target.MergedBy = stringValue(m.MergedBy)
+		}
+		pr.Targets = append(pr.Targets, &target)
+	}
+
+	return &pr
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -30,6 +30,9 @@ func ParseWebhookEvent(eventType string, payload []byte) (e interface{}, err err
 	case "pr:participant:status":
 		e = &PullRequestParticipantStatusEvent{}
 		return e, json.Unmarshal(payload, e)
+	case "repo:refs_changed":
+		e = &RepoRefsChangedEvent{}
+		return e, json.Unmarshal(payload, e)
 	default:
 		return nil, errors.Errorf("unknown webhook event type: %q", eventType)
 	}
@@ -65,3 +68,36 @@ type BuildStatusEvent struct {
 	Status       BuildStatus   `json:"status"`
 	PullRequests []PullRequest `json:"pullRequests"`
 }
+
+// RepoRefsChangedEvent is sent when one or more refs of a repository are created,
+// updated, or deleted, most commonly as the result of a push.
+type RepoRefsChangedEvent struct {
+	Date       time.Time   `json:"date"`
+	Actor      User        `json:"actor"`
+	Repository Repo        `json:"repository"`
+	Changes    []RefChange `json:"changes"`
+}
+
+type RefChange struct {
+	Ref      RefChangeRef  `json:"ref"`
+	RefID    string        `json:"refId"`
+	FromHash string        `json:"fromHash"`
+	ToHash   string        `json:"toHash"`
+	Type     RefChangeType `json:"type"`
+}
+
+type RefChangeRef struct {
+	ID        string `json:"id"`
+	DisplayID string `json:"displayId"`
+	Type      string `json:"type"`
+}
+
+// RefChangeType is the type of change made to a ref.
+type RefChangeType string
+
+// RefChangeType constants.
+const (
+	RefChangeTypeAdd    RefChangeType = "ADD"
+	RefChangeTypeUpdate RefChangeType = "UPDATE"
+	RefChangeTypeDelete RefChangeType = "DELETE"
+)
@@ -24,10 +24,14 @@ var (
 	MavenURL    = &url.URL{Host: "maven"}
 	JVMPackages = NewCodeHost(MavenURL, TypeJVMPackages)
 
+	GoProxyURL = &url.URL{Host: "go"}
+	GoModules  = NewCodeHost(GoProxyURL, TypeGoModules)
+
 	PublicCodeHosts = []*CodeHost{
 		GitHubDotCom,
 		GitLabDotCom,
 		JVMPackages,
+		GoModules,
 	}
 )
 
@@ -63,10 +67,12 @@ func NormalizeBaseURL(baseURL *url.URL) *url.URL {
 
 // CodeHostOf returns the CodeHost of the given repo, if any, as
 // determined by a common prefix between the repo name and the
-// code hosts' URL hostname component.
+// code hosts' URL hostname component. The hostname must be followed
+// by a path separator so that e.g. "golang.org/x/tools" isn't
+// mistaken for a repo of the "go" code host.
 func CodeHostOf(name api.RepoName, codehosts ...*CodeHost) *CodeHost {
 	for _, c := range codehosts {
-		if strings.HasPrefix(strings.ToLower(string(name)), c.BaseURL.Hostname()) {
+		if strings.HasPrefix(strings.ToLower(string(name)), c.BaseURL.Hostname()+"/") {
 			return c
 		}
 	}
@@ -32,6 +32,16 @@ func TestCodeHostOf(t *testing.T) {
 		repo:      "GITHUB.COM/foo/bar",
 		codehosts: PublicCodeHosts,
 		want:      GitHubDotCom,
+	}, {
+		name:      "go modules",
+		repo:      "go/golang.org/x/tools",
+		codehosts: PublicCodeHosts,
+		want:      GoModules,
+	}, {
+		name:      "hostname prefix",
+		repo:      "golang.org/x/tools",
+		codehosts: PublicCodeHosts,
+		want:      nil,
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			have := CodeHostOf(tc.repo, tc.codehosts...)
@@ -89,11 +89,17 @@ func (c *CheckSuite) Key() string {
 
 // CheckRun represents the status of a checkrun
 type CheckRun struct {
-	ID string
+	ID   string
+	Name string
 	// One of COMPLETED, IN_PROGRESS, QUEUED, REQUESTED
 	Status string
 	// One of ACTION_REQUIRED, CANCELLED, FAILURE, NEUTRAL, SUCCESS, TIMED_OUT
-	Conclusion string
+	Conclusion  string
+	DetailsURL  string
+	CompletedAt time.Time
+	// The ID of the check suite the run belongs to. Only set for runs
+	// received via a webhook, synced runs are nested in their suite.
+	CheckSuiteID string
 	// When the run was received via a webhook
 	ReceivedAt time.Time
 }
@@ -126,6 +132,7 @@ type CommitStatus struct {
 	SHA        string
 	Context    string
 	State      string
+	TargetURL  string
 	ReceivedAt time.Time
 }
 
@@ -140,6 +147,7 @@ type Context struct {
 	Context     string
 	Description string
 	State       string
+	TargetURL   string
 }
 
 type Label struct {
@@ -948,6 +956,88 @@ func (c *V4Client) CreatePullRequestComment(ctx context.Context, pr *PullRequest
 	return c.requestGraphQL(ctx, createPullRequestCommentMutation, input, &result)
 }
 
+// RerequestCheckSuite requests that the check suite with the given node ID in
+// the given repository is run again.
+func (c *V4Client) RerequestCheckSuite(ctx context.Context, repositoryID, checkSuiteID string) error {
+	var result struct {
+		RerequestCheckSuite struct {
+			CheckSuite struct {
+				ID string
+			} `json:"checkSuite"`
+		} `json:"rerequestCheckSuite"`
+	}
+
+	input := map[string]interface{}{"input": struct {
+		RepositoryID string `json:"repositoryId"`
+		CheckSuiteID string `json:"checkSuiteId"`
+	}{RepositoryID: repositoryID, CheckSuiteID: checkSuiteID}}
+	return c.requestGraphQL(ctx, rerequestCheckSuiteMutation, input, &result)
+}
+
+const rerequestCheckSuiteMutation = `
+mutation RerequestCheckSuite($input: RerequestCheckSuiteInput!) {
+  rerequestCheckSuite(input: $input) {
+    checkSuite {
+      id
+    }
+  }
+}
+`
+
+const updatePullRequestBranchMutation = `
+mutation UpdatePullRequestBranch($input: UpdatePullRequestBranchInput!) {
+  updatePullRequestBranch(input: $input) {
+	  pullRequest {
+		  ...pr
+	  }
+  }
+}
+`
+
+// UpdatePullRequestBranch merges the latest changes of the base branch into
+// the head branch of the PullRequest on GitHub. The update is rejected if the
+// head branch has moved since pr was last loaded.
+func (c *V4Client) UpdatePullRequestBranch(ctx context.Context, pr *PullRequest) error {
+	version := c.determineGitHubVersion(ctx)
+	prFragment, err := pullRequestFragments(version)
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		UpdatePullRequestBranch struct {
+			PullRequest struct {
+				PullRequest
+				Participants  struct{ Nodes []Actor }
+				TimelineItems TimelineItemConnection
+			} `json:"pullRequest"`
+		} `json:"updatePullRequestBranch"`
+	}
+
+	input := map[string]interface{}{"input": struct {
+		PullRequestID   string `json:"pullRequestId"`
+		ExpectedHeadOid string `json:"expectedHeadOid,omitempty"`
+	}{
+		PullRequestID:   pr.ID,
+		ExpectedHeadOid: pr.HeadRefOid,
+	}}
+	if err := c.requestGraphQL(ctx, prFragment+"\n"+updatePullRequestBranchMutation, input, &result); err != nil {
+		return err
+	}
+
+	ti := result.UpdatePullRequestBranch.PullRequest.TimelineItems
+	*pr = result.UpdatePullRequestBranch.PullRequest.PullRequest
+	pr.TimelineItems = ti.Nodes
+	pr.Participants = result.UpdatePullRequestBranch.PullRequest.Participants.Nodes
+
+	items, err := c.loadRemainingTimelineItems(ctx, pr.ID, ti.PageInfo)
+	if err != nil {
+		return err
+	}
+	pr.TimelineItems = append(pr.TimelineItems, items...)
+	return nil
+}
+
 const mergePullRequestMutation = `
 mutation MergePullRequest($input: MergePullRequestInput!) {
   mergePullRequest(input: $input) {
@@ -1344,6 +1434,7 @@ fragment commitWithChecks on Commit {
       context
       state
       description
+      targetUrl
     }
   }
   checkSuites(last: 20) {
@@ -1354,8 +1445,11 @@ fragment commitWithChecks on Commit {
       checkRuns(last: 20) {
         nodes {
           id
+          name
           status
           conclusion
+          detailsUrl
+          completedAt
         }
       }
     }
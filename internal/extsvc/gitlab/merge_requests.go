@@ -314,3 +314,28 @@ func (c *Client) CreateMergeRequestNote(ctx context.Context, project *Project, m
 
 	return nil
 }
+
+// RebaseMergeRequest asks GitLab to rebase the source branch of the merge
+// request onto its target branch. The rebase happens asynchronously; the
+// outcome is reflected in the merge request once it has finished.
+func (c *Client) RebaseMergeRequest(ctx context.Context, project *Project, mr *MergeRequest) error {
+	if MockRebaseMergeRequest != nil {
+		return MockRebaseMergeRequest(c, ctx, project, mr)
+	}
+
+	time.Sleep(c.rateLimitMonitor.RecommendedWaitForBackgroundOp(1))
+
+	req, err := http.NewRequest("PUT", fmt.Sprintf("projects/%d/merge_requests/%d/rebase", project.ID, mr.IID), nil)
+	if err != nil {
+		return errors.Wrap(err, "creating request to rebase a merge request")
+	}
+
+	var resp struct {
+		RebaseInProgress bool `json:"rebase_in_progress"`
+	}
+	if _, _, err := c.do(ctx, req, &resp); err != nil {
+		return errors.Wrap(err, "sending request to rebase a merge request")
+	}
+
+	return nil
+}
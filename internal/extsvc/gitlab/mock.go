@@ -52,3 +52,11 @@ var MockMergeMergeRequest func(c *Client, ctx context.Context, project *Project,
 // MockCreateMergeRequestNote, if non-nil, will be called instead of
 // Client.CreateMergeRequestNote
 var MockCreateMergeRequestNote func(c *Client, ctx context.Context, project *Project, mr *MergeRequest, body string) error
+
+// MockRebaseMergeRequest, if non-nil, will be called instead of
+// Client.RebaseMergeRequest
+var MockRebaseMergeRequest func(c *Client, ctx context.Context, project *Project, mr *MergeRequest) error
+
+// MockRetryPipeline, if non-nil, will be called instead of
+// Client.RetryPipeline
+var MockRetryPipeline func(c *Client, ctx context.Context, project *Project, id ID) (*Pipeline, error)
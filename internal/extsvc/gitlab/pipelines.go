@@ -59,6 +59,28 @@ func (c *Client) GetMergeRequestPipelines(ctx context.Context, project *Project,
 	}
 }
 
+// RetryPipeline retries the failed and canceled jobs of the pipeline with the
+// given ID.
+func (c *Client) RetryPipeline(ctx context.Context, project *Project, id ID) (*Pipeline, error) {
+	if MockRetryPipeline != nil {
+		return MockRetryPipeline(c, ctx, project, id)
+	}
+
+	time.Sleep(c.rateLimitMonitor.RecommendedWaitForBackgroundOp(1))
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("projects/%d/pipelines/%d/retry", project.ID, id), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request to retry a pipeline")
+	}
+
+	resp := &Pipeline{}
+	if _, _, err := c.do(ctx, req, resp); err != nil {
+		return nil, errors.Wrap(err, "sending request to retry a pipeline")
+	}
+
+	return resp, nil
+}
+
 type Pipeline struct {
 	ID        ID             `json:"id"`
 	SHA       string         `json:"sha"`
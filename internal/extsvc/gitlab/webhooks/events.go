@@ -25,6 +25,25 @@ type PipelineEvent struct {
 	MergeRequest *gitlab.MergeRequest `json:"merge_request"`
 }
 
+// PushEvent is sent when one or more commits are pushed to a branch. When a
+// branch is deleted, After is the null SHA.
+type PushEvent struct {
+	EventCommon
+
+	Ref    string `json:"ref"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// nullSHA is the commit SHA GitLab reports for the missing side of a created or
+// deleted branch.
+const nullSHA = "0000000000000000000000000000000000000000"
+
+// Deleted returns true if the push deleted the branch.
+func (e *PushEvent) Deleted() bool {
+	return e.After == nullSHA
+}
+
 var ErrObjectKindUnknown = errors.New("unknown object kind")
 
 type downcaster interface {
@@ -32,7 +51,7 @@ type downcaster interface {
 }
 
 // UnmarshalEvent unmarshals the given JSON into an event type. Possible return
-// types are *MergeRequestEvent.
+// types are *MergeRequestEvent, *PipelineEvent, and *PushEvent.
 //
 // Errors caused by a valid payload being of an unknown type may be
 // distinguished from other errors by checking for ErrObjectKindUnknown in the
@@ -60,6 +79,8 @@ func UnmarshalEvent(data []byte) (interface{}, error) {
 		typedEvent = &mergeRequestEvent{}
 	case "pipeline":
 		typedEvent = &PipelineEvent{}
+	case "push":
+		typedEvent = &PushEvent{}
 	default:
 		return nil, errors.Wrapf(ErrObjectKindUnknown, "kind: %s", event.ObjectKind)
 	}
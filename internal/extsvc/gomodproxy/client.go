@@ -0,0 +1,187 @@
+// Package gomodproxy implements a client for the Go module proxy protocol.
+//
+// See https://golang.org/ref/mod#goproxy-protocol.
+package gomodproxy
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/time/rate"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// Client fetches Go module metadata and sources from a list of Go module proxies.
+type Client struct {
+	urls    []string
+	cli     httpcli.Doer
+	limiter *rate.Limiter
+}
+
+// NewClient returns a client for the Go module proxies of the given connection. If a nil
+// doer is provided, httpcli.ExternalDoer will be used.
+func NewClient(config *schema.GoModulesConnection, cli httpcli.Doer) *Client {
+	if cli == nil {
+		cli = httpcli.ExternalDoer
+	}
+
+	return &Client{
+		urls:    config.Urls,
+		cli:     cli,
+		limiter: ratelimit.DefaultRegistry.Get("go"),
+	}
+}
+
+// Versions returns the list of known tagged versions of the given module. Pseudo-versions
+// are not included.
+func (c *Client) Versions(ctx context.Context, mod reposource.GoModule) ([]string, error) {
+	body, err := c.get(ctx, mod, "@v/list")
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []string
+	for _, line := range strings.Split(string(body), "\n") {
+		if version := strings.TrimSpace(line); version != "" {
+			versions = append(versions, version)
+		}
+	}
+
+	return versions, nil
+}
+
+// GoMod returns the go.mod file of the given module version.
+func (c *Client) GoMod(ctx context.Context, dep reposource.GoDependency) ([]byte, error) {
+	escapedVersion, err := escapeVersion(dep.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.get(ctx, dep.GoModule, "@v/"+escapedVersion+".mod")
+}
+
+// Zip returns the zip archive holding the sources of the given module version. Every file
+// in the archive is prefixed with "<module>@<version>/".
+func (c *Client) Zip(ctx context.Context, dep reposource.GoDependency) (*zip.Reader, error) {
+	escapedVersion, err := escapeVersion(dep.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.get(ctx, dep.GoModule, "@v/"+escapedVersion+".zip")
+	if err != nil {
+		return nil, err
+	}
+
+	return zip.NewReader(bytes.NewReader(body), int64(len(body)))
+}
+
+// get requests the given path of the module from each configured proxy in turn. A 404 or 410
+// response from a proxy falls through to the next one, as the go command does.
+func (c *Client) get(ctx context.Context, mod reposource.GoModule, path string) ([]byte, error) {
+	escapedModule, err := escapePath(mod.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, baseURL := range c.urls {
+		if c.limiter != nil {
+			if err := c.limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		body, status, err := c.do(ctx, strings.TrimSuffix(baseURL, "/")+"/"+escapedModule+"/"+path)
+		if err != nil {
+			return nil, err
+		}
+		if status == http.StatusOK {
+			return body, nil
+		}
+		if status != http.StatusNotFound && status != http.StatusGone {
+			return nil, errors.Errorf("unexpected status code %d from Go module proxy %s: %s", status, baseURL, bytes.TrimSpace(body))
+		}
+	}
+
+	return nil, &NotFoundError{Module: mod.Path}
+}
+
+func (c *Client) do(ctx context.Context, url string) ([]byte, int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resp, err := c.cli.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return body, resp.StatusCode, nil
+}
+
+// NotFoundError is returned when none of the configured proxies know about a module.
+type NotFoundError struct {
+	Module string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("module %q not found", e.Module)
+}
+
+func (e *NotFoundError) NotFound() bool {
+	return true
+}
+
+// escapePath returns the module path with every upper-case letter replaced by an
+// exclamation mark followed by its lower-case equivalent, as required by the proxy
+// protocol for case-insensitive file systems.
+func escapePath(path string) (string, error) {
+	if path == "" || strings.HasPrefix(path, "/") || strings.Contains(path, "..") {
+		return "", errors.Errorf("invalid module path %q", path)
+	}
+
+	return escapeString(path)
+}
+
+func escapeVersion(version string) (string, error) {
+	if version == "" || strings.ContainsAny(version, "/\\") {
+		return "", errors.Errorf("invalid module version %q", version)
+	}
+
+	return escapeString(version)
+}
+
+func escapeString(s string) (string, error) {
+	var b strings.Builder
+	for _, r := range s {
+		if r == '!' || r >= unicode.MaxASCII {
+			return "", errors.Errorf("invalid character %q in %q", r, s)
+		}
+		if unicode.IsUpper(r) {
+			b.WriteByte('!')
+			b.WriteRune(unicode.ToLower(r))
+		} else {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,68 @@
+package gomodproxy
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestClientVersions(t *testing.T) {
+	var requested []string
+	missing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, "missing"+r.URL.Path)
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer missing.Close()
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, "proxy"+r.URL.Path)
+		if r.URL.Path != "/github.com/!burnt!sushi/toml/@v/list" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = io.WriteString(w, "v0.3.1\nv0.4.0\n\n")
+	}))
+	defer proxy.Close()
+
+	client := NewClient(&schema.GoModulesConnection{Urls: []string{missing.URL, proxy.URL + "/"}}, http.DefaultClient)
+
+	versions, err := client.Versions(context.Background(), reposource.GoModule{Path: "github.com/BurntSushi/toml"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff([]string{"v0.3.1", "v0.4.0"}, versions); diff != "" {
+		t.Errorf("unexpected versions (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"missing/github.com/!burnt!sushi/toml/@v/list", "proxy/github.com/!burnt!sushi/toml/@v/list"}, requested); diff != "" {
+		t.Errorf("unexpected requests (-want +got):\n%s", diff)
+	}
+
+	if _, err := client.Versions(context.Background(), reposource.GoModule{Path: "example.com/unknown"}); !errcode.IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestEscapePath(t *testing.T) {
+	for input, expected := range map[string]string{
+		"golang.org/x/tools":         "golang.org/x/tools",
+		"github.com/BurntSushi/toml": "github.com/!burnt!sushi/toml",
+	} {
+		if escaped, err := escapePath(input); err != nil || escaped != expected {
+			t.Errorf("unexpected escaped path for %q. want=%q have=%q err=%v", input, expected, escaped, err)
+		}
+	}
+
+	for _, input := range []string{"", "/abs", "a/../b", "a!b"} {
+		if _, err := escapePath(input); err == nil {
+			t.Errorf("expected error escaping %q", input)
+		}
+	}
+}
@@ -0,0 +1,7 @@
+package gomodproxy
+
+import "github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+
+type Metadata struct {
+	Module reposource.GoModule
+}
@@ -83,6 +83,7 @@ const (
 	KindPerforce        = "PERFORCE"
 	KindPhabricator     = "PHABRICATOR"
 	KindJVMPackages     = "JVMPACKAGES"
+	KindGoModules       = "GOMODULES"
 	KindOther           = "OTHER"
 )
 
@@ -122,6 +123,9 @@ const (
 	// TypeJVMPackages is the (api.ExternalRepoSpec).ServiceType value for Maven packages (Java/JVM ecosystem libraries).
 	TypeJVMPackages = "jvmPackages"
 
+	// TypeGoModules is the (api.ExternalRepoSpec).ServiceType value for Go modules fetched from a Go module proxy.
+	TypeGoModules = "goModules"
+
 	// TypeOther is the (api.ExternalRepoSpec).ServiceType value for other projects.
 	TypeOther = "other"
 
@@ -150,6 +154,8 @@ func KindToType(kind string) string {
 		return TypePerforce
 	case KindJVMPackages:
 		return TypeJVMPackages
+	case KindGoModules:
+		return TypeGoModules
 	case KindOther:
 		return TypeOther
 	default:
@@ -179,6 +185,8 @@ func TypeToKind(t string) string {
 		return KindPhabricator
 	case TypeJVMPackages:
 		return KindJVMPackages
+	case TypeGoModules:
+		return KindGoModules
 	case TypeOther:
 		return KindOther
 	default:
@@ -191,6 +199,7 @@ var (
 	bbsLower = strings.ToLower(TypeBitbucketServer)
 	bbcLower = strings.ToLower(TypeBitbucketCloud)
 	jvmLower = strings.ToLower(TypeJVMPackages)
+	goLower  = strings.ToLower(TypeGoModules)
 )
 
 // ParseServiceType will return a ServiceType constant after doing a case insensitive match on s.
@@ -215,6 +224,8 @@ func ParseServiceType(s string) (string, bool) {
 		return TypePhabricator, true
 	case jvmLower:
 		return TypeJVMPackages, true
+	case goLower:
+		return TypeGoModules, true
 	case TypeOther:
 		return TypeOther, true
 	default:
@@ -244,6 +255,8 @@ func ParseServiceKind(s string) (string, bool) {
 		return KindPhabricator, true
 	case KindJVMPackages:
 		return KindJVMPackages, true
+	case KindGoModules:
+		return KindGoModules, true
 	case KindOther:
 		return KindOther, true
 	default:
@@ -285,6 +298,8 @@ func ParseConfig(kind, config string) (cfg interface{}, _ error) {
 		cfg = &schema.PhabricatorConnection{}
 	case KindJVMPackages:
 		cfg = &schema.JVMPackagesConnection{}
+	case KindGoModules:
+		cfg = &schema.GoModulesConnection{}
 	case KindOther:
 		cfg = &schema.OtherExternalServiceConnection{}
 	default:
@@ -410,6 +425,13 @@ func GetLimitFromConfig(kind string, config interface{}) (rlc RateLimitConfig, e
 			rlc.IsDefault = false
 		}
 		rlc.BaseURL = "maven"
+	case *schema.GoModulesConnection:
+		rlc.Limit = defaultRateLimit
+		if c != nil && c.RateLimit != nil {
+			rlc.Limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+			rlc.IsDefault = false
+		}
+		rlc.BaseURL = "go"
 	default:
 		return rlc, ErrRateLimitUnsupported{codehostKind: kind}
 	}
@@ -507,6 +529,8 @@ func UniqueCodeHostIdentifier(kind, config string) (string, error) {
 		return c.P4Port, nil
 	case *schema.JVMPackagesConnection:
 		return KindJVMPackages, nil
+	case *schema.GoModulesConnection:
+		return KindGoModules, nil
 	default:
 		return "", errors.Errorf("unknown external service kind: %s", kind)
 	}
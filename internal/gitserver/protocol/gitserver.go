@@ -185,6 +185,9 @@ type CreateCommitFromPatchRequest struct {
 	// GitApplyArgs are the arguments that will be passed to `git apply` along
 	// with `--cached`.
 	GitApplyArgs []string
+	// Signing specifies whether the commit will be signed: if nil, the commit
+	// is unsigned, if non-nil, the commit is signed with the given key.
+	Signing *CommitSigningConfig
 }
 
 // PatchCommitInfo will be used for commit information when creating a commit from a patch
@@ -215,6 +218,22 @@ type PushConfig struct {
 	Passphrase string
 }
 
+// CommitSigningConfig provides the key used to sign the commits created by
+// gitserver.
+type CommitSigningConfig struct {
+	// Format is the format of the key, either "openpgp" or "ssh", matching
+	// the values of git's gpg.format setting.
+	Format string
+
+	// PrivateKey is the armored OpenPGP private key or the PEM encoded SSH
+	// private key.
+	PrivateKey string
+
+	// Passphrase is the passphrase to decrypt the private key, if it is
+	// encrypted.
+	Passphrase string
+}
+
 // CreateCommitFromPatchResponse is the response type returned after creating
 // a commit from a patch
 type CreateCommitFromPatchResponse struct {
@@ -0,0 +1,56 @@
+package signing
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/crypto/openpgp"
+)
+
+type openPGPSigner struct {
+	entity *openpgp.Entity
+}
+
+func newOpenPGPSigner(privateKey, passphrase string) (*openPGPSigner, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(privateKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing OpenPGP key")
+	}
+	if len(entities) != 1 {
+		return nil, errors.Errorf("expected exactly one OpenPGP key, got %d", len(entities))
+	}
+
+	entity := entities[0]
+	if entity.PrivateKey == nil {
+		return nil, errors.New("OpenPGP key is not a private key")
+	}
+
+	if entity.PrivateKey.Encrypted {
+		if err := entity.PrivateKey.Decrypt([]byte(passphrase)); err != nil {
+			return nil, errors.Wrap(err, "decrypting OpenPGP key")
+		}
+	}
+	for _, subkey := range entity.Subkeys {
+		if subkey.PrivateKey != nil && subkey.PrivateKey.Encrypted {
+			if err := subkey.PrivateKey.Decrypt([]byte(passphrase)); err != nil {
+				return nil, errors.Wrap(err, "decrypting OpenPGP subkey")
+			}
+		}
+	}
+
+	return &openPGPSigner{entity: entity}, nil
+}
+
+func (s *openPGPSigner) Sign(data []byte) (string, error) {
+	var sig bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&sig, s.entity, bytes.NewReader(data), nil); err != nil {
+		return "", err
+	}
+	return sig.String(), nil
+}
+
+func (s *openPGPSigner) Fingerprint() string {
+	return fmt.Sprintf("%X", s.entity.PrimaryKey.Fingerprint)
+}
@@ -0,0 +1,77 @@
+// Package signing implements the signing of git commits with OpenPGP and SSH
+// keys, producing signatures that `git verify-commit` accepts.
+package signing
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Format is the format of a signing key. The values match the values of git's
+// gpg.format setting.
+type Format string
+
+const (
+	FormatOpenPGP Format = "openpgp"
+	FormatSSH     Format = "ssh"
+)
+
+// Valid returns true if the format is supported.
+func (f Format) Valid() bool {
+	return f == FormatOpenPGP || f == FormatSSH
+}
+
+// Signer creates detached, armored signatures.
+type Signer interface {
+	// Sign returns the armored signature of data.
+	Sign(data []byte) (string, error)
+	// Fingerprint returns the fingerprint of the public key matching the
+	// signing key, in the notation used by the key's tooling.
+	Fingerprint() string
+}
+
+// NewSigner parses the given private key, decrypting it with the passphrase if
+// it is encrypted, and returns a Signer for it.
+func NewSigner(format Format, privateKey, passphrase string) (Signer, error) {
+	switch format {
+	case FormatOpenPGP:
+		return newOpenPGPSigner(privateKey, passphrase)
+	case FormatSSH:
+		return newSSHSigner(privateKey, passphrase)
+	default:
+		return nil, errors.Errorf("unsupported signing key format %q", format)
+	}
+}
+
+// SignCommit signs the given raw commit object, as printed by `git cat-file
+// commit`, and returns the commit object with the signature added in a gpgsig
+// header, which is how git stores signatures of both formats.
+func SignCommit(commit []byte, s Signer) ([]byte, error) {
+	if bytes.Contains(commit, []byte("\ngpgsig ")) {
+		return nil, errors.New("commit is already signed")
+	}
+
+	// The headers end at the first empty line. A commit created with an empty
+	// message still has the empty line.
+	end := bytes.Index(commit, []byte("\n\n"))
+	if end < 0 {
+		return nil, errors.New("malformed commit: no end of headers")
+	}
+
+	sig, err := s.Sign(commit)
+	if err != nil {
+		return nil, errors.Wrap(err, "signing commit")
+	}
+
+	// Multi-line header values are continued on lines starting with a space.
+	header := "gpgsig " + strings.ReplaceAll(strings.TrimRight(sig, "\n"), "\n", "\n ")
+
+	var signed bytes.Buffer
+	signed.Grow(len(commit) + len(header) + 1)
+	signed.Write(commit[:end+1])
+	signed.WriteString(header)
+	signed.Write(commit[end:])
+	return signed.Bytes(), nil
+}
@@ -0,0 +1,226 @@
+package signing
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+	"golang.org/x/crypto/ssh"
+)
+
+const testCommit = `tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904
+parent 4f1a8b2e7a0de6d1e4bbcf3ffb3d6e8dd0d1d7c2
+author Sourcegraph <batch-changes@sourcegraph.com> 1626271200 +0000
+committer Sourcegraph <batch-changes@sourcegraph.com> 1626271200 +0000
+
+Update the README
+
+With a body.
+`
+
+type fakeSigner struct{}
+
+func (fakeSigner) Sign(data []byte) (string, error) {
+	return "-----BEGIN SIGNATURE-----\n\nc2lnbmF0dXJl\n-----END SIGNATURE-----\n", nil
+}
+
+func (fakeSigner) Fingerprint() string { return "fake" }
+
+func TestSignCommit(t *testing.T) {
+	signed, err := SignCommit([]byte(testCommit), fakeSigner{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Empty lines of the signature are continued with a single space.
+	want := strings.Join([]string{
+		"tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904",
+		"parent 4f1a8b2e7a0de6d1e4bbcf3ffb3d6e8dd0d1d7c2",
+		"author Sourcegraph <batch-changes@sourcegraph.com> 1626271200 +0000",
+		"committer Sourcegraph <batch-changes@sourcegraph.com> 1626271200 +0000",
+		"gpgsig -----BEGIN SIGNATURE-----",
+		" ",
+		" c2lnbmF0dXJl",
+		" -----END SIGNATURE-----",
+		"",
+		"Update the README",
+		"",
+		"With a body.",
+		"",
+	}, "\n")
+	if string(signed) != want {
+		t.Fatalf("wrong signed commit.\nwant:\n%s\nhave:\n%s", want, signed)
+	}
+
+	t.Run("already signed", func(t *testing.T) {
+		if _, err := SignCommit(signed, fakeSigner{}); err == nil {
+			t.Fatal("no error returned for signed commit")
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		if _, err := SignCommit([]byte("tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n"), fakeSigner{}); err == nil {
+			t.Fatal("no error returned for malformed commit")
+		}
+	})
+}
+
+func TestNewSigner(t *testing.T) {
+	if _, err := NewSigner("x509", "", ""); err == nil {
+		t.Fatal("no error returned for unsupported format")
+	}
+	if _, err := NewSigner(FormatSSH, "not a key", ""); err == nil {
+		t.Fatal("no error returned for invalid SSH key")
+	}
+	if _, err := NewSigner(FormatOpenPGP, "not a key", ""); err == nil {
+		t.Fatal("no error returned for invalid OpenPGP key")
+	}
+}
+
+func TestSSHSigner(t *testing.T) {
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		key        interface{}
+		wantFormat string
+	}{
+		"ed25519": {key: ed25519Key, wantFormat: ssh.KeyAlgoED25519},
+		"rsa":     {key: rsaKey, wantFormat: ssh.SigAlgoRSASHA2512},
+	} {
+		t.Run(name, func(t *testing.T) {
+			der, err := x509.MarshalPKCS8PrivateKey(tc.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			privateKey := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+
+			s, err := NewSigner(FormatSSH, privateKey, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(s.Fingerprint(), "SHA256:") {
+				t.Fatalf("wrong fingerprint: %q", s.Fingerprint())
+			}
+
+			armored, err := s.Sign([]byte(testCommit))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sig := parseSSHSignature(t, armored)
+			if sig.Version != 1 || sig.Namespace != sshSigNamespace || sig.HashAlgorithm != sshSigHash {
+				t.Fatalf("wrong signature header: %+v", sig)
+			}
+
+			pub, err := ssh.ParsePublicKey(sig.PublicKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have, want := ssh.FingerprintSHA256(pub), s.Fingerprint(); have != want {
+				t.Fatalf("wrong public key. want=%s, have=%s", want, have)
+			}
+
+			var inner ssh.Signature
+			if err := ssh.Unmarshal(sig.Signature, &inner); err != nil {
+				t.Fatal(err)
+			}
+			if inner.Format != tc.wantFormat {
+				t.Fatalf("wrong signature format. want=%s, have=%s", tc.wantFormat, inner.Format)
+			}
+
+			hash := sha512.Sum512([]byte(testCommit))
+			signedData := append([]byte(sshSigMagic), ssh.Marshal(sshSignedData{
+				Namespace:     sshSigNamespace,
+				HashAlgorithm: sshSigHash,
+				Hash:          hash[:],
+			})...)
+			if err := pub.Verify(signedData, &inner); err != nil {
+				t.Fatalf("signature doesn't verify: %s", err)
+			}
+		})
+	}
+}
+
+func parseSSHSignature(t *testing.T, armored string) sshSignature {
+	t.Helper()
+
+	lines := strings.Split(strings.TrimSpace(armored), "\n")
+	if lines[0] != "-----BEGIN SSH SIGNATURE-----" || lines[len(lines)-1] != "-----END SSH SIGNATURE-----" {
+		t.Fatalf("signature not armored:\n%s", armored)
+	}
+	for _, l := range lines[1 : len(lines)-1] {
+		if len(l) > 70 {
+			t.Fatalf("line too long: %q", l)
+		}
+	}
+
+	blob, err := base64.StdEncoding.DecodeString(strings.Join(lines[1:len(lines)-1], ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(blob, []byte(sshSigMagic)) {
+		t.Fatal("signature doesn't start with magic preamble")
+	}
+
+	var sig sshSignature
+	if err := ssh.Unmarshal(blob[len(sshSigMagic):], &sig); err != nil {
+		t.Fatal(err)
+	}
+	return sig
+}
+
+func TestOpenPGPSigner(t *testing.T) {
+	entity, err := openpgp.NewEntity("Sourcegraph", "", "batch-changes@sourcegraph.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var privateKey bytes.Buffer
+	w, err := armor.Encode(&privateKey, openpgp.PrivateKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := entity.SerializePrivate(w, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewSigner(FormatOpenPGP, privateKey.String(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := s.Fingerprint(), strings.ToUpper(entity.PrimaryKey.KeyIdString()); !strings.HasSuffix(have, want) {
+		t.Fatalf("wrong fingerprint. want suffix=%s, have=%s", want, have)
+	}
+
+	sig, err := s.Sign([]byte(testCommit))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := openpgp.CheckArmoredDetachedSignature(openpgp.EntityList{entity}, strings.NewReader(testCommit), strings.NewReader(sig))
+	if err != nil {
+		t.Fatalf("signature doesn't verify: %s", err)
+	}
+	if signer.PrimaryKey.KeyId != entity.PrimaryKey.KeyId {
+		t.Fatal("signature made by wrong key")
+	}
+}
@@ -0,0 +1,111 @@
+package signing
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// sshSigMagic is the preamble of SSH signatures, see
+	// https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig.
+	sshSigMagic = "SSHSIG"
+	// sshSigNamespace is the namespace git uses for signatures.
+	sshSigNamespace = "git"
+	sshSigHash      = "sha512"
+)
+
+type sshSigner struct {
+	signer ssh.Signer
+}
+
+func newSSHSigner(privateKey, passphrase string) (*sshSigner, error) {
+	var (
+		signer ssh.Signer
+		err    error
+	)
+	if passphrase != "" {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase([]byte(privateKey), []byte(passphrase))
+	} else {
+		signer, err = ssh.ParsePrivateKey([]byte(privateKey))
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing SSH key")
+	}
+	return &sshSigner{signer: signer}, nil
+}
+
+// sshSignedData is the blob that is actually signed.
+type sshSignedData struct {
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Hash          []byte
+}
+
+// sshSignature is the signature blob that is armored.
+type sshSignature struct {
+	Version       uint32
+	PublicKey     []byte
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Signature     []byte
+}
+
+func (s *sshSigner) Sign(data []byte) (string, error) {
+	hash := sha512.Sum512(data)
+	signedData := append([]byte(sshSigMagic), ssh.Marshal(sshSignedData{
+		Namespace:     sshSigNamespace,
+		HashAlgorithm: sshSigHash,
+		Hash:          hash[:],
+	})...)
+
+	var (
+		sig *ssh.Signature
+		err error
+	)
+	// ssh-rsa signatures use SHA-1, which ssh-keygen refuses to verify.
+	if as, ok := s.signer.(ssh.AlgorithmSigner); ok && s.signer.PublicKey().Type() == ssh.KeyAlgoRSA {
+		sig, err = as.SignWithAlgorithm(rand.Reader, signedData, ssh.SigAlgoRSASHA2512)
+	} else {
+		sig, err = s.signer.Sign(rand.Reader, signedData)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	blob := append([]byte(sshSigMagic), ssh.Marshal(sshSignature{
+		Version:       1,
+		PublicKey:     s.signer.PublicKey().Marshal(),
+		Namespace:     sshSigNamespace,
+		HashAlgorithm: sshSigHash,
+		Signature:     ssh.Marshal(sig),
+	})...)
+
+	return armorSSHSignature(blob), nil
+}
+
+func (s *sshSigner) Fingerprint() string {
+	return ssh.FingerprintSHA256(s.signer.PublicKey())
+}
+
+// armorSSHSignature armors the signature the same way ssh-keygen does.
+func armorSSHSignature(blob []byte) string {
+	encoded := base64.StdEncoding.EncodeToString(blob)
+
+	var b strings.Builder
+	b.WriteString("-----BEGIN SSH SIGNATURE-----\n")
+	for len(encoded) > 70 {
+		b.WriteString(encoded[:70])
+		b.WriteByte('\n')
+		encoded = encoded[70:]
+	}
+	b.WriteString(encoded)
+	b.WriteString("\n-----END SSH SIGNATURE-----\n")
+	return b.String()
+}
@@ -6,12 +6,14 @@ import (
 	"crypto/x509"
 	"math"
 	"math/rand"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"strconv"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/PuerkitoBio/rehttp"
@@ -532,6 +534,82 @@ func NewTimeoutOpt(timeout time.Duration) Opt {
 	}
 }
 
+// NewPublicAddressesOnlyOpt returns a Opt that makes the transport of an
+// http.Client refuse to connect to addresses that are not publicly routable,
+// such as loopback, private and link-local addresses. It is meant for clients
+// that send requests to URLs provided by users (e.g. webhooks), which must not
+// be able to reach internal services through Sourcegraph.
+//
+// The addresses are checked when connecting, after host names have been
+// resolved, so a host name resolving to an internal address is refused too.
+// Requests are not sent through a proxy, since only the address of the proxy
+// could be checked otherwise.
+func NewPublicAddressesOnlyOpt() Opt {
+	return func(cli *http.Client) error {
+		tr, err := getTransportForMutation(cli)
+		if err != nil {
+			return errors.Wrap(err, "httpcli.NewPublicAddressesOnlyOpt")
+		}
+
+		dialer := &net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			Control: func(network, address string, _ syscall.RawConn) error {
+				host, _, err := net.SplitHostPort(address)
+				if err != nil {
+					return err
+				}
+				if ip := net.ParseIP(host); ip == nil || !IsPublicIP(ip) {
+					return errors.Errorf("refusing to connect to non-public address %s", host)
+				}
+				return nil
+			},
+		}
+		tr.DialContext = dialer.DialContext
+		tr.Proxy = nil
+
+		return nil
+	}
+}
+
+// nonPublicNetworks are the networks that are not publicly routable, in
+// addition to the loopback, link-local, multicast and unspecified addresses.
+var nonPublicNetworks = func() []*net.IPNet {
+	var networks []*net.IPNet
+	for _, cidr := range []string{
+		"0.0.0.0/8",      // "this" network
+		"10.0.0.0/8",     // private
+		"100.64.0.0/10",  // carrier-grade NAT
+		"172.16.0.0/12",  // private
+		"192.0.0.0/24",   // IETF protocol assignments
+		"192.168.0.0/16", // private
+		"198.18.0.0/15",  // benchmarking
+		"240.0.0.0/4",    // reserved
+		"fc00::/7",       // unique local
+	} {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}()
+
+// IsPublicIP returns whether ip is a publicly routable address.
+func IsPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
 // getTransport returns the http.Transport for cli. If Transport is nil, it is
 // set to a copy of the DefaultTransport. If it is the DefaultTransport, it is
 // updated to a copy of the DefaultTransport.
@@ -10,6 +10,7 @@ import (
 	"encoding/pem"
 	"fmt"
 	"math/big"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
@@ -279,6 +280,50 @@ func TestNewTimeoutOpt(t *testing.T) {
 	}
 }
 
+func TestNewPublicAddressesOnlyOpt(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	var cli http.Client
+	if err := NewPublicAddressesOnlyOpt()(&cli); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// The test server listens on a loopback address.
+	resp, err := cli.Get(srv.URL)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("expected the request to a loopback address to be refused")
+	}
+	if !strings.Contains(err.Error(), "refusing to connect to non-public address") {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestIsPublicIP(t *testing.T) {
+	for addr, want := range map[string]bool{
+		"8.8.8.8":          true,
+		"2001:4860::8888":  true,
+		"127.0.0.1":        false,
+		"::1":              false,
+		"10.1.2.3":         false,
+		"172.16.0.1":       false,
+		"192.168.1.1":      false,
+		"169.254.169.254":  false,
+		"100.64.0.1":       false,
+		"0.0.0.0":          false,
+		"fd00::1":          false,
+		"fe80::1":          false,
+		"::ffff:127.0.0.1": false,
+	} {
+		if have := IsPublicIP(net.ParseIP(addr)); have != want {
+			t.Errorf("IsPublicIP(%s): want %v, have %v", addr, want, have)
+		}
+	}
+}
+
 func TestErrorResilience(t *testing.T) {
 	failures := int64(5)
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
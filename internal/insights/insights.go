@@ -222,6 +222,19 @@ type TimeSeries struct {
 	Name   string
 	Stroke string
 	Query  string
+
+	// GeneratedFromCaptureGroups splits the series into one series per distinct value of the
+	// first capture group of the query's regular expression.
+	GeneratedFromCaptureGroups bool
+
+	// LanguageStats records the number of lines per language in the repositories of the insight
+	// instead of the matches of Query, split into one series per language.
+	LanguageStats bool
+
+	// CommitAggregation records an aggregate of the commits matching Query, which must be a
+	// type:commit or type:diff query, per interval instead of the matches of Query: "commits"
+	// counts the commits and "authors" counts their distinct authors across all repositories.
+	CommitAggregation string
 }
 
 type Interval struct {
@@ -0,0 +1,189 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/inconshreveable/log15"
+	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gomodproxy"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// GoModulesScheme is the moniker scheme of Go packages referenced by precise code
+// intelligence indexes, as recorded in the lsif_dependency_repos table.
+const GoModulesScheme = "gomod"
+
+// A GoModulesSource creates git repositories from the source archives of Go
+// modules served by the configured Go module proxies.
+type GoModulesSource struct {
+	svc       *types.ExternalService
+	config    *schema.GoModulesConnection
+	client    *gomodproxy.Client
+	depsStore DependencyReposStore
+}
+
+// DependencyReposStore lists the package versions referenced by precise code
+// intelligence indexes.
+type DependencyReposStore interface {
+	GetDependencyRepos(ctx context.Context, opts dbstore.GetDependencyReposOptions) ([]dbstore.DependencyRepo, error)
+}
+
+// NewGoModulesSource returns a new GoModulesSource from the given external
+// service. Modules referenced by precise code intelligence indexes are read
+// from the given database, if any.
+func NewGoModulesSource(svc *types.ExternalService, cf *httpcli.Factory, db dbutil.DB) (*GoModulesSource, error) {
+	var c schema.GoModulesConnection
+	if err := jsonc.Unmarshal(svc.Config, &c); err != nil {
+		return nil, fmt.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	var depsStore DependencyReposStore
+	if db != nil {
+		depsStore = NewDependencyReposStore(db)
+	}
+
+	return &GoModulesSource{
+		svc:       svc,
+		config:    &c,
+		client:    gomodproxy.NewClient(&c, cli),
+		depsStore: depsStore,
+	}, nil
+}
+
+var (
+	dependencyReposOnce               sync.Once
+	dependencyReposObservationContext *observation.Context
+	dependencyReposMetrics            *metrics.OperationMetrics
+)
+
+// NewDependencyReposStore returns a store reading the package versions referenced by
+// precise code intelligence indexes. The operation metrics of the store are
+// registered once per process.
+func NewDependencyReposStore(db dbutil.DB) *dbstore.Store {
+	dependencyReposOnce.Do(func() {
+		dependencyReposObservationContext = &observation.Context{
+			Logger:     log15.Root(),
+			Tracer:     &trace.Tracer{Tracer: opentracing.GlobalTracer()},
+			Registerer: prometheus.DefaultRegisterer,
+		}
+		dependencyReposMetrics = dbstore.NewOperationsMetrics(dependencyReposObservationContext)
+	})
+
+	return dbstore.NewWithDB(db, dependencyReposObservationContext, dependencyReposMetrics)
+}
+
+// ListRepos returns all Go modules listed in the connection configuration as
+// well as those referenced by precise code intelligence indexes.
+func (s *GoModulesSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	dependencies, err := GoDependencies(*s.config)
+	if err != nil {
+		results <- SourceResult{Source: s, Err: err}
+		return
+	}
+
+	if s.depsStore != nil {
+		dependencyRepos, err := s.depsStore.GetDependencyRepos(ctx, dbstore.GetDependencyReposOptions{
+			Scheme: GoModulesScheme,
+		})
+		if err != nil {
+			results <- SourceResult{Source: s, Err: err}
+			return
+		}
+
+		for _, dependencyRepo := range dependencyRepos {
+			dependencies = append(dependencies, reposource.NewGoDependency(dependencyRepo.Name, dependencyRepo.Version))
+		}
+	}
+
+	seen := make(map[reposource.GoModule]struct{}, len(dependencies))
+	for _, dep := range dependencies {
+		if _, ok := seen[dep.GoModule]; ok {
+			continue
+		}
+		seen[dep.GoModule] = struct{}{}
+
+		results <- SourceResult{
+			Source: s,
+			Repo:   s.makeRepo(dep.GoModule),
+		}
+	}
+}
+
+// GetRepo returns the repository for the Go module with the given repository
+// name, if any of the configured proxies knows about it.
+func (s *GoModulesSource) GetRepo(ctx context.Context, name string) (*types.Repo, error) {
+	mod, err := reposource.ParseGoModule(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.client.Versions(ctx, mod); err != nil {
+		return nil, err
+	}
+
+	return s.makeRepo(mod), nil
+}
+
+func (s *GoModulesSource) makeRepo(mod reposource.GoModule) *types.Repo {
+	urn := s.svc.URN()
+	return &types.Repo{
+		Name: mod.RepoName(),
+		URI:  string(mod.RepoName()),
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          string(mod.RepoName()),
+			ServiceID:   extsvc.TypeGoModules,
+			ServiceType: extsvc.TypeGoModules,
+		},
+		Private: false,
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: mod.CloneURL(),
+			},
+		},
+		Metadata: &gomodproxy.Metadata{
+			Module: mod,
+		},
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s *GoModulesSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+// GoDependencies returns the dependencies listed in the given connection configuration.
+func GoDependencies(connection schema.GoModulesConnection) (dependencies []reposource.GoDependency, err error) {
+	for _, dep := range connection.Dependencies {
+		dependency, err := reposource.ParseGoDependency(dep)
+		if err != nil {
+			return nil, err
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	return dependencies, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/hashicorp/go-multierror"
 
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
@@ -25,8 +26,12 @@ type Sourcer func(...*types.ExternalService) (Sources, error)
 //
 // Deleted external services are ignored.
 //
+// Sources that need to read from the database, such as the Go modules source, use
+// the given handle. It may be nil in which case those sources only yield
+// repositories from their configuration.
+//
 // The provided decorator functions will be applied to each Source.
-func NewSourcer(cf *httpcli.Factory, decs ...func(Source) Source) Sourcer {
+func NewSourcer(db dbutil.DB, cf *httpcli.Factory, decs ...func(Source) Source) Sourcer {
 	return func(svcs ...*types.ExternalService) (Sources, error) {
 		srcs := make([]Source, 0, len(svcs))
 		var errs *multierror.Error
@@ -36,7 +41,7 @@ func NewSourcer(cf *httpcli.Factory, decs ...func(Source) Source) Sourcer {
 				continue
 			}
 
-			src, err := NewSource(svc, cf)
+			src, err := NewSource(db, svc, cf)
 			if err != nil {
 				errs = multierror.Append(errs, &SourceError{Err: err, ExtSvc: svc})
 				continue
@@ -54,7 +59,7 @@ func NewSourcer(cf *httpcli.Factory, decs ...func(Source) Source) Sourcer {
 }
 
 // NewSource returns a repository yielding Source from the given ExternalService configuration.
-func NewSource(svc *types.ExternalService, cf *httpcli.Factory) (Source, error) {
+func NewSource(db dbutil.DB, svc *types.ExternalService, cf *httpcli.Factory) (Source, error) {
 	switch strings.ToUpper(svc.Kind) {
 	case extsvc.KindGitHub:
 		return NewGithubSource(svc, cf)
@@ -74,6 +79,8 @@ func NewSource(svc *types.ExternalService, cf *httpcli.Factory) (Source, error)
 		return NewPerforceSource(svc)
 	case extsvc.KindJVMPackages:
 		return NewJVMPackagesSource(svc)
+	case extsvc.KindGoModules:
+		return NewGoModulesSource(svc, cf, db)
 	case extsvc.KindOther:
 		return NewOtherSource(svc, cf)
 	default:
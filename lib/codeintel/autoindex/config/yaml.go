@@ -5,6 +5,10 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
+func MarshalYAML(config IndexConfiguration) ([]byte, error) {
+	return yaml.Marshal(config)
+}
+
 func UnmarshalYAML(data []byte) (IndexConfiguration, error) {
 	configuration := IndexConfiguration{}
 	if err := yaml.Unmarshal(data, &configuration); err != nil {
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
 const yamlTestInput = `
@@ -68,3 +69,23 @@ func TestUnmarshalYAML(t *testing.T) {
 		t.Errorf("unexpected configuration (-want +got):\n%s", diff)
 	}
 }
+
+func TestMarshalYAMLRoundTrip(t *testing.T) {
+	expected, err := UnmarshalYAML([]byte(yamlTestInput))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	marshaled, err := MarshalYAML(expected)
+	if err != nil {
+		t.Fatalf("unexpected error marshalling configuration: %s", err)
+	}
+
+	actual, err := UnmarshalYAML(marshaled)
+	if err != nil {
+		t.Fatalf("unexpected error unmarshalling configuration: %s", err)
+	}
+	if diff := cmp.Diff(expected, actual, cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("unexpected configuration (-want +got):\n%s", diff)
+	}
+}
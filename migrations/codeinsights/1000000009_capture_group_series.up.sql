@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TABLE insight_series ADD COLUMN IF NOT EXISTS generated_from_capture_groups BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN insight_series.generated_from_capture_groups IS 'When true, the series is dynamically split into one series per distinct value of the first capture group of the query''s regular expression.';
+
+ALTER TABLE series_points ADD COLUMN IF NOT EXISTS capture TEXT;
+
+COMMENT ON COLUMN series_points.capture IS 'The value of the capture group that this data point counts the matches of, for series generated from capture groups. null for all other series.';
+
+COMMIT;
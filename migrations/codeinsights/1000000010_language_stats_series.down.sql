@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE insight_series DROP COLUMN IF EXISTS repositories;
+
+ALTER TABLE insight_series DROP COLUMN IF EXISTS series_type;
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TABLE insight_series ADD COLUMN IF NOT EXISTS series_type TEXT NOT NULL DEFAULT 'search';
+
+COMMENT ON COLUMN insight_series.series_type IS 'The kind of data recorded for the series: search records the number of matches of the query, language_stats records the number of lines per language in the repositories of the series.';
+
+ALTER TABLE insight_series ADD COLUMN IF NOT EXISTS repositories TEXT[];
+
+COMMENT ON COLUMN insight_series.repositories IS 'The names of the repositories that a language_stats series records data for. null for all other series.';
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE IF EXISTS insight_series_alert_events;
+
+DROP TABLE IF EXISTS insight_series_alerts;
+
+COMMIT;
@@ -0,0 +1,56 @@
+BEGIN;
+
+CREATE TABLE insight_series_alerts
+(
+    id                SERIAL           NOT NULL PRIMARY KEY,
+    series_id         TEXT             NOT NULL REFERENCES insight_series (series_id) ON DELETE CASCADE,
+    capture           TEXT,
+    condition         TEXT             NOT NULL,
+    threshold         DOUBLE PRECISION NOT NULL,
+    window_days       INT              NOT NULL DEFAULT 7,
+    user_id           INT              NOT NULL,
+    email_enabled     BOOLEAN          NOT NULL DEFAULT TRUE,
+    webhook_url       TEXT,
+    created_at        TIMESTAMP        NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_evaluated_at TIMESTAMP,
+    triggered         BOOLEAN          NOT NULL DEFAULT FALSE
+);
+
+comment on table insight_series_alerts is 'Alert rules that notify a user when the value of an insight series crosses a threshold.';
+
+comment on column insight_series_alerts.id is 'Primary key ID of this alert.';
+comment on column insight_series_alerts.series_id is 'Unique Series ID of the series this alert is evaluated for.';
+comment on column insight_series_alerts.capture is 'The capture group value (or language) of the series this alert is evaluated for. null for series that are not split.';
+comment on column insight_series_alerts.condition is 'One of above, below, increase_percent or decrease_percent.';
+comment on column insight_series_alerts.threshold is 'The value (above, below) or percentage (increase_percent, decrease_percent) the series must cross to trigger the alert.';
+comment on column insight_series_alerts.window_days is 'The number of days to compare the current value with for percentage conditions.';
+comment on column insight_series_alerts.user_id is 'The user who created the alert in the main app database. Values are computed from the repositories this user can access.';
+comment on column insight_series_alerts.email_enabled is 'Whether to send an email to the primary email address of the user when the alert triggers.';
+comment on column insight_series_alerts.webhook_url is 'The URL to POST a JSON payload to when the alert triggers, if any.';
+comment on column insight_series_alerts.last_evaluated_at is 'Timestamp when this alert was last evaluated.';
+comment on column insight_series_alerts.triggered is 'Whether the condition held at the last evaluation. Notifications are only sent when this changes from false to true.';
+
+CREATE INDEX insight_series_alerts_series_id_idx ON insight_series_alerts (series_id);
+
+CREATE TABLE insight_series_alert_events
+(
+    id             SERIAL           NOT NULL PRIMARY KEY,
+    alert_id       INT              NOT NULL REFERENCES insight_series_alerts (id) ON DELETE CASCADE,
+    value          DOUBLE PRECISION NOT NULL,
+    previous_value DOUBLE PRECISION,
+    triggered_at   TIMESTAMP        NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    email_error    TEXT,
+    webhook_error  TEXT
+);
+
+comment on table insight_series_alert_events is 'History of triggered insight series alerts.';
+
+comment on column insight_series_alert_events.alert_id is 'Foreign key to the alert that triggered.';
+comment on column insight_series_alert_events.value is 'The value of the series that triggered the alert.';
+comment on column insight_series_alert_events.previous_value is 'The value of the series window_days earlier, for percentage conditions.';
+comment on column insight_series_alert_events.email_error is 'The error that occurred while sending the email notification, if any.';
+comment on column insight_series_alert_events.webhook_error is 'The error that occurred while sending the webhook notification, if any.';
+
+CREATE INDEX insight_series_alert_events_alert_id_idx ON insight_series_alert_events (alert_id, triggered_at);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE insight_series DROP COLUMN IF EXISTS backfill_state;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE insight_series ADD COLUMN IF NOT EXISTS backfill_state TEXT NOT NULL DEFAULT 'active';
+
+COMMENT ON COLUMN insight_series.backfill_state IS 'Whether historical data is backfilled for the series: active, paused (its queued historical jobs are paused) or canceled (its queued historical jobs were deleted).';
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE insight_series ADD COLUMN IF NOT EXISTS commit_aggregation TEXT;
+
+COMMENT ON COLUMN insight_series.commit_aggregation IS 'What a commit series counts of the commits matching its query in each interval: commits counts the commits, authors counts their distinct authors. null for all other series.';
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE lsif_index_configuration
+  DROP COLUMN IF EXISTS "index_on_push";
+
+COMMIT;
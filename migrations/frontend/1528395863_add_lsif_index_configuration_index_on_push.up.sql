@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE lsif_index_configuration
+  ADD COLUMN IF NOT EXISTS "index_on_push" BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN lsif_index_configuration.index_on_push IS 'Whether or not pushes to the default branch of this repository received through a code host webhook should enqueue an auto-index job for the pushed commit.';
+
+COMMIT;
@@ -0,0 +1,16 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS lsif_dependency_repos (
+    id bigserial NOT NULL PRIMARY KEY,
+    scheme text NOT NULL,
+    name text NOT NULL,
+    version text NOT NULL,
+    CONSTRAINT lsif_dependency_repos_unique_triplet UNIQUE (scheme, name, version)
+);
+
+COMMENT ON TABLE lsif_dependency_repos IS 'Tracks package versions referenced by precise code intelligence indexes so that package repositories can be created and materialized on demand.';
+COMMENT ON COLUMN lsif_dependency_repos.scheme IS 'The moniker scheme of the package, e.g. gomod.';
+COMMENT ON COLUMN lsif_dependency_repos.name IS 'The name of the package, e.g. a Go module path.';
+COMMENT ON COLUMN lsif_dependency_repos.version IS 'The version of the package.';
+
+COMMIT;
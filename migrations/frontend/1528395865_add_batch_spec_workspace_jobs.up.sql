@@ -0,0 +1,33 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS batch_spec_workspace_jobs (
+  id                      BIGSERIAL PRIMARY KEY,
+  batch_spec_execution_id BIGINT NOT NULL REFERENCES batch_spec_executions(id) ON DELETE CASCADE DEFERRABLE,
+  repo_id                 INTEGER NOT NULL REFERENCES repo(id) DEFERRABLE,
+  branch                  TEXT NOT NULL,
+  commit                  TEXT NOT NULL,
+  changeset_spec_ids      BIGINT[] NOT NULL DEFAULT '{}',
+
+  state             TEXT DEFAULT 'queued',
+  failure_message   TEXT,
+  started_at        TIMESTAMP WITH TIME ZONE,
+  finished_at       TIMESTAMP WITH TIME ZONE,
+  process_after     TIMESTAMP WITH TIME ZONE,
+  num_resets        INTEGER NOT NULL DEFAULT 0,
+  num_failures      INTEGER NOT NULL DEFAULT 0,
+  execution_logs    JSON[],
+  worker_hostname   TEXT NOT NULL DEFAULT '',
+  last_heartbeat_at TIMESTAMP WITH TIME ZONE,
+
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS batch_spec_workspace_jobs_batch_spec_execution_id ON batch_spec_workspace_jobs(batch_spec_execution_id);
+
+COMMENT ON TABLE batch_spec_workspace_jobs IS 'One executor job per repository workspace a server-side batch spec execution runs its steps in.';
+COMMENT ON COLUMN batch_spec_workspace_jobs.branch IS 'The branch the steps are run on, which becomes the base ref of the resulting changeset specs.';
+COMMENT ON COLUMN batch_spec_workspace_jobs.commit IS 'The commit of the branch the steps are run on.';
+COMMENT ON COLUMN batch_spec_workspace_jobs.changeset_spec_ids IS 'The changeset specs created from the diff produced by the steps.';
+
+COMMIT;
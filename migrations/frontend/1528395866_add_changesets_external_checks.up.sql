@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE changesets ADD COLUMN IF NOT EXISTS external_checks jsonb NOT NULL DEFAULT '[]'::jsonb;
+
+COMMENT ON COLUMN changesets.external_checks IS 'The individual check runs, commit statuses, build statuses or pipelines reported by the code host for the head commit of the changeset.';
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE IF EXISTS batch_change_admins;
+
+ALTER TABLE batch_changes DROP COLUMN IF EXISTS owner_id;
+
+COMMIT;
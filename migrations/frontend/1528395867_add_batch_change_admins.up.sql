@@ -0,0 +1,23 @@
+BEGIN;
+
+ALTER TABLE batch_changes ADD COLUMN IF NOT EXISTS owner_id integer REFERENCES users(id) ON DELETE SET NULL DEFERRABLE;
+
+COMMENT ON COLUMN batch_changes.owner_id IS 'The user that owns the batch change. Only the owner and site admins can transfer ownership, manage admins, move or delete the batch change.';
+
+UPDATE batch_changes SET owner_id = initial_applier_id;
+
+CREATE TABLE IF NOT EXISTS batch_change_admins (
+  id bigserial PRIMARY KEY,
+  batch_change_id bigint NOT NULL REFERENCES batch_changes(id) ON DELETE CASCADE DEFERRABLE,
+  user_id integer REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+  org_id integer REFERENCES orgs(id) ON DELETE CASCADE DEFERRABLE,
+  created_at timestamp with time zone NOT NULL DEFAULT now(),
+  CONSTRAINT batch_change_admins_has_1_subject CHECK ((user_id IS NULL) <> (org_id IS NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS batch_change_admins_unique_user ON batch_change_admins (batch_change_id, user_id) WHERE user_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS batch_change_admins_unique_org ON batch_change_admins (batch_change_id, org_id) WHERE org_id IS NOT NULL;
+
+COMMENT ON TABLE batch_change_admins IS 'Users and orgs, other than the owner, that can apply, close and run bulk operations on a batch change.';
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE changesets DROP COLUMN IF EXISTS commit_signature;
+
+DROP TABLE IF EXISTS batch_changes_commit_signing_keys;
+
+COMMIT;
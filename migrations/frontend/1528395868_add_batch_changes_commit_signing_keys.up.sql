@@ -0,0 +1,23 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS batch_changes_commit_signing_keys (
+  id bigserial PRIMARY KEY,
+  external_service_type text NOT NULL,
+  external_service_id text NOT NULL,
+  format text NOT NULL,
+  fingerprint text NOT NULL,
+  key bytea NOT NULL,
+  encryption_key_id text NOT NULL DEFAULT '',
+  created_at timestamp with time zone NOT NULL DEFAULT now(),
+  updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS batch_changes_commit_signing_keys_unique ON batch_changes_commit_signing_keys (external_service_type, external_service_id);
+
+COMMENT ON TABLE batch_changes_commit_signing_keys IS 'Keys registered by site admins that gitserver uses to sign the commits it creates for batch changes on a code host.';
+
+ALTER TABLE changesets ADD COLUMN IF NOT EXISTS commit_signature jsonb;
+
+COMMENT ON COLUMN changesets.commit_signature IS 'The format and fingerprint of the key the last pushed commit was signed with. NULL if the commit was not signed.';
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS batch_spec_templates;
+
+COMMIT;
@@ -0,0 +1,22 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS batch_spec_templates (
+  id bigserial PRIMARY KEY,
+  name text NOT NULL,
+  description text NOT NULL DEFAULT '',
+  namespace_org_id integer REFERENCES orgs(id) ON DELETE CASCADE DEFERRABLE,
+  template text NOT NULL,
+  parameters jsonb NOT NULL DEFAULT '[]'::jsonb,
+  creator_user_id integer REFERENCES users(id) ON DELETE SET NULL DEFERRABLE,
+  created_at timestamp with time zone NOT NULL DEFAULT now(),
+  updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS batch_spec_templates_org_name_unique ON batch_spec_templates (namespace_org_id, name) WHERE namespace_org_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS batch_spec_templates_site_name_unique ON batch_spec_templates (name) WHERE namespace_org_id IS NULL;
+
+COMMENT ON TABLE batch_spec_templates IS 'Batch spec templates with typed input parameters that are rendered into batch specs.';
+COMMENT ON COLUMN batch_spec_templates.namespace_org_id IS 'The org the template belongs to. NULL for site-wide templates.';
+COMMENT ON COLUMN batch_spec_templates.parameters IS 'The input parameters of the template, as a JSON array of objects with the keys name, type, description, required and default.';
+
+COMMIT;
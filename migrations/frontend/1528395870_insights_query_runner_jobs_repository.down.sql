@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE insights_query_runner_jobs DROP COLUMN IF EXISTS revision;
+ALTER TABLE insights_query_runner_jobs DROP COLUMN IF EXISTS repository_name;
+
+COMMIT;
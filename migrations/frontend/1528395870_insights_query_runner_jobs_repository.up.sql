@@ -0,0 +1,9 @@
+BEGIN;
+
+ALTER TABLE insights_query_runner_jobs ADD COLUMN IF NOT EXISTS repository_name TEXT;
+ALTER TABLE insights_query_runner_jobs ADD COLUMN IF NOT EXISTS revision TEXT;
+
+COMMENT ON COLUMN insights_query_runner_jobs.repository_name IS 'The repository to compute language statistics for, for jobs of language statistics series. null for search jobs.';
+COMMENT ON COLUMN insights_query_runner_jobs.revision IS 'The commit to compute language statistics at. null to use the head of the default branch.';
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS cm_last_searched;
+
+COMMIT;
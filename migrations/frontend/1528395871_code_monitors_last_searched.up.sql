@@ -0,0 +1,13 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS cm_last_searched (
+    query_id BIGINT NOT NULL REFERENCES cm_queries(id) ON DELETE CASCADE,
+    repo_id INTEGER NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    commit_oid TEXT NOT NULL,
+    PRIMARY KEY (query_id, repo_id)
+);
+
+COMMENT ON TABLE cm_last_searched IS 'The last commit searched by a code monitor trigger query in each repository, so that the next run only searches the commits made since.';
+COMMENT ON COLUMN cm_last_searched.commit_oid IS 'The head of the default branch of the repository when it was last searched.';
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+
+ALTER TABLE cm_action_jobs DROP COLUMN IF EXISTS digest_after;
+
+ALTER TABLE cm_trigger_jobs DROP COLUMN IF EXISTS search_results;
+
+ALTER TABLE cm_emails
+    DROP COLUMN IF EXISTS digest,
+    DROP COLUMN IF EXISTS last_digest_at;
+
+DROP TYPE IF EXISTS cm_email_digest;
+
+COMMIT;
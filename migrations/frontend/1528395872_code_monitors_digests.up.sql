@@ -0,0 +1,18 @@
+BEGIN;
+
+CREATE TYPE cm_email_digest AS ENUM ('IMMEDIATE', 'HOURLY', 'DAILY');
+
+ALTER TABLE cm_emails
+    ADD COLUMN IF NOT EXISTS digest cm_email_digest NOT NULL DEFAULT 'IMMEDIATE',
+    ADD COLUMN IF NOT EXISTS last_digest_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE cm_trigger_jobs ADD COLUMN IF NOT EXISTS search_results JSONB;
+
+ALTER TABLE cm_action_jobs ADD COLUMN IF NOT EXISTS digest_after INTEGER;
+
+COMMENT ON COLUMN cm_emails.digest IS 'Whether an email is sent for every trigger event, or a single email summarizing the events of the last hour or day.';
+COMMENT ON COLUMN cm_emails.last_digest_at IS 'When the last digest of the email action was enqueued.';
+COMMENT ON COLUMN cm_trigger_jobs.search_results IS 'The commits matched by the search, listed in digest emails.';
+COMMENT ON COLUMN cm_action_jobs.digest_after IS 'For digest emails, the last trigger event included in the previous digest. The digest covers the trigger events after it, up to and including trigger_event.';
+
+COMMIT;
@@ -10,7 +10,7 @@ import (
 
 // AWSCodeCommitConnection description: Configuration for a connection to AWS CodeCommit.
 type AWSCodeCommitConnection struct {
-	// AccessKeyID description: The AWS access key ID to use when listing and updating repositories from AWS CodeCommit. Must have the AWSCodeCommitReadOnly IAM policy.
+	// AccessKeyID description: The AWS access key ID to use when listing and updating repositories from AWS CodeCommit. Must have the AWSCodeCommitReadOnly IAM policy. To publish changesets with Batch Changes, it must also be allowed to create, update and merge pull requests.
 	AccessKeyID string `json:"accessKeyID"`
 	// Exclude description: A list of repositories to never mirror from AWS CodeCommit.
 	//
@@ -360,19 +360,61 @@ type BuiltinAuthProvider struct {
 	Type        string `json:"type"`
 }
 
+// ChangesetAutoMerge description: Whether and how to automatically merge published changesets once all checks have passed and the changeset has been approved.
+type ChangesetAutoMerge struct {
+	// Enabled description: Whether to automatically merge changesets once their checks have passed and they have been approved.
+	Enabled bool `json:"enabled"`
+	// MergeWindow description: A window in which changesets may be merged. Times are in UTC. If omitted, changesets are merged as soon as they become mergeable.
+	MergeWindow *ChangesetAutoMergeWindow `json:"mergeWindow,omitempty"`
+	// Squash description: Whether to squash the commits of the changeset when merging it.
+	Squash bool `json:"squash,omitempty"`
+}
+
+// ChangesetAutoMergeWindow description: A window in which changesets may be merged. Times are in UTC. If omitted, changesets are merged as soon as they become mergeable.
+type ChangesetAutoMergeWindow struct {
+	// Days description: Day(s) the window applies to. If omitted, changesets can be merged on all days of the week.
+	Days []string `json:"days,omitempty"`
+	// End description: Window end time. If omitted, no time window is applied to the day(s) that match this rule.
+	End string `json:"end,omitempty"`
+	// Start description: Window start time. If omitted, no time window is applied to the day(s) that match this rule.
+	Start string `json:"start,omitempty"`
+}
+
 // ChangesetTemplate description: A template describing how to create (and update) changesets with the file changes produced by the command steps.
 type ChangesetTemplate struct {
+	// AutoMerge description: Whether and how to automatically merge published changesets once all checks have passed and the changeset has been approved.
+	AutoMerge *ChangesetAutoMerge `json:"autoMerge,omitempty"`
 	// Body description: The body (description) of the changeset.
 	Body string `json:"body,omitempty"`
 	// Branch description: The name of the Git branch to create or update on each repository with the changes.
 	Branch string `json:"branch"`
 	// Commit description: The Git commit to create with the changes.
 	Commit ExpandedGitCommitDescription `json:"commit"`
+	// Overrides description: Overrides of the changeset template for specific repositories. Each override is matched against repository names with a glob pattern, and fields set in the last matching override replace those of the changeset template.
+	Overrides []*ChangesetTemplateOverride `json:"overrides,omitempty"`
 	// Published description: Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the batch change, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host. If omitted, the publication state is controlled from the Batch Changes UI.
 	Published interface{} `json:"published,omitempty"`
 	// Title description: The title of the changeset.
 	Title string `json:"title"`
 }
+type ChangesetTemplateOverride struct {
+	// Body description: The body (description) of the changeset.
+	Body string `json:"body,omitempty"`
+	// Branch description: The name of the Git branch to create or update with the changes.
+	Branch string `json:"branch,omitempty"`
+	// Commit description: The Git commit to create with the changes.
+	Commit *ChangesetTemplateOverrideCommit `json:"commit,omitempty"`
+	// Repository description: A glob pattern matching the names of the repositories to apply this override to.
+	Repository string `json:"repository"`
+	// Title description: The title of the changeset.
+	Title string `json:"title,omitempty"`
+}
+
+// ChangesetTemplateOverrideCommit description: The Git commit to create with the changes.
+type ChangesetTemplateOverrideCommit struct {
+	// Message description: The Git commit message.
+	Message string `json:"message,omitempty"`
+}
 
 // CloneURLToRepositoryName description: Describes a mapping from clone URL to repository name. The `from` field contains a regular expression with named capturing groups. The `to` field contains a template string that references capturing group names. For instance, if `from` is "^../(?P<name>\w+)$" and `to` is "github.com/user/{name}", the clone URL "../myRepository" would be mapped to the repository name "github.com/user/myRepository".
 type CloneURLToRepositoryName struct {
@@ -533,6 +575,8 @@ type ExperimentalFeatures struct {
 	EnablePostSignupFlow bool `json:"enablePostSignupFlow,omitempty"`
 	// EventLogging description: Enables user event logging inside of the Sourcegraph instance. This will allow admins to have greater visibility of user activity, such as frequently viewed pages, frequent searches, and more. These event logs (and any specific user actions) are only stored locally, and never leave this Sourcegraph instance.
 	EventLogging string `json:"eventLogging,omitempty"`
+	// GoPackages description: Allow adding Go module proxy code host connections
+	GoPackages string `json:"goPackages,omitempty"`
 	// JvmPackages description: Allow adding JVM packages code host connections
 	JvmPackages string `json:"jvmPackages,omitempty"`
 	// Perforce description: Allow adding Perforce code host connections
@@ -793,6 +837,24 @@ type GitoliteConnection struct {
 	Prefix string `json:"prefix"`
 }
 
+// GoModulesConnection description: Configuration for a connection to Go module proxies
+type GoModulesConnection struct {
+	// Dependencies description: An array of "module@version" strings specifying which Go modules to mirror on Sourcegraph. Modules referenced by precise code intelligence indexes are mirrored automatically.
+	Dependencies []string `json:"dependencies,omitempty"`
+	// RateLimit description: Rate limit applied when making background API requests to the configured Go module proxies.
+	RateLimit *GoRateLimit `json:"rateLimit,omitempty"`
+	// Urls description: The list of Go module proxy URLs to fetch modules from. 404 Not found or 410 Gone responses will result in the next URL to be attempted.
+	Urls []string `json:"urls"`
+}
+
+// GoRateLimit description: Rate limit applied when making background API requests to the configured Go module proxies.
+type GoRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
+
 // HTTPHeaderAuthProvider description: Configures the HTTP header authentication provider (which authenticates users by consulting an HTTP request header set by an authentication proxy such as https://github.com/bitly/oauth2_proxy).
 type HTTPHeaderAuthProvider struct {
 	// EmailHeader description: The name (case-insensitive) of an HTTP header whose value is taken to be the email of the client requesting the page. Set this value when using an HTTP proxy that authenticates requests, and you don't want the extra configurability of the other authentication methods.
@@ -1592,7 +1654,17 @@ type TlsExternal struct {
 // TransformChanges description: Optional transformations to apply to the changes produced in each repository.
 type TransformChanges struct {
 	// Group description: A list of groups of changes in a repository that each create a separate, additional changeset for this repository, with all ungrouped changes being in the default changeset.
-	Group []interface{} `json:"group,omitempty"`
+	Group []*TransformChangesGroup `json:"group,omitempty"`
+}
+type TransformChangesGroup struct {
+	// Branch description: The branch to push the changes in this group to. This replaces the branch of the changeset template.
+	Branch string `json:"branch,omitempty"`
+	// BranchSuffix description: A suffix that is appended to the branch of the changeset template to get the branch to push the changes in this group to.
+	BranchSuffix string `json:"branchSuffix,omitempty"`
+	// Directory description: The directory path (relative to the repository root) of the changes to include in this group.
+	Directory string `json:"directory"`
+	// Repository description: Only apply this transformation in the repository with this name (as it is known to Sourcegraph).
+	Repository string `json:"repository,omitempty"`
 }
 type UpdateIntervalRule struct {
 	// Interval description: An integer representing the number of minutes to wait until the next update
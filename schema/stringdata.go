@@ -3,57 +3,76 @@ package schema
 import _ "embed"
 
 // AWSCodeCommitSchemaJSON is the content of the file "aws_codecommit.schema.json".
+//
 //go:embed aws_codecommit.schema.json
 var AWSCodeCommitSchemaJSON string
 
 // BatchSpecSchemaJSON is the content of the file "batch_spec.schema.json".
+//
 //go:embed batch_spec.schema.json
 var BatchSpecSchemaJSON string
 
 // BitbucketCloudSchemaJSON is the content of the file "bitbucket_cloud.schema.json".
+//
 //go:embed bitbucket_cloud.schema.json
 var BitbucketCloudSchemaJSON string
 
 // BitbucketServerSchemaJSON is the content of the file "bitbucket_server.schema.json".
+//
 //go:embed bitbucket_server.schema.json
 var BitbucketServerSchemaJSON string
 
 // ChangesetSpecSchemaJSON is the content of the file "changeset_spec.schema.json".
+//
 //go:embed changeset_spec.schema.json
 var ChangesetSpecSchemaJSON string
 
 // GitHubSchemaJSON is the content of the file "github.schema.json".
+//
 //go:embed github.schema.json
 var GitHubSchemaJSON string
 
 // GitLabSchemaJSON is the content of the file "gitlab.schema.json".
+//
 //go:embed gitlab.schema.json
 var GitLabSchemaJSON string
 
 // GitoliteSchemaJSON is the content of the file "gitolite.schema.json".
+//
 //go:embed gitolite.schema.json
 var GitoliteSchemaJSON string
 
+// GoModulesSchemaJSON is the content of the file "go-modules.schema.json".
+//
+//go:embed go-modules.schema.json
+var GoModulesSchemaJSON string
+
 // JVMPackagesSchemaJSON is the content of the file "jvm-packages.schema.json".
+//
 //go:embed jvm-packages.schema.json
 var JVMPackagesSchemaJSON string
 
 // OtherExternalServiceSchemaJSON is the content of the file "other_external_service.schema.json".
+//
 //go:embed other_external_service.schema.json
 var OtherExternalServiceSchemaJSON string
 
 // PerforceSchemaJSON is the content of the file "perforce.schema.json".
+//
 //go:embed perforce.schema.json
 var PerforceSchemaJSON string
 
 // PhabricatorSchemaJSON is the content of the file "phabricator.schema.json".
+//
 //go:embed phabricator.schema.json
 var PhabricatorSchemaJSON string
 
 // SettingsSchemaJSON is the content of the file "settings.schema.json".
+//
 //go:embed settings.schema.json
 var SettingsSchemaJSON string
 
 // SiteSchemaJSON is the content of the file "site.schema.json".
+//
 //go:embed site.schema.json
 var SiteSchemaJSON string